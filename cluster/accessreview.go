@@ -0,0 +1,128 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/basebandit/kai"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// AccessReview asks the API server whether the current user, or the
+// identity being impersonated, may perform an action, like kubectl auth
+// can-i. Resource takes the forms kubectl accepts: "pods",
+// "deployments.apps" or "pods/log".
+type AccessReview struct {
+	Verb        string
+	Resource    string
+	Subresource string
+	Name        string
+	// Namespace is the namespace to check in; empty checks every
+	// namespace, which is also how cluster-scoped resources are checked.
+	Namespace string
+}
+
+// Check runs a SelfSubjectAccessReview and reports whether the action is
+// allowed, with the authorizer's reason when it gives one.
+func (a *AccessReview) Check(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if a.Verb == "" {
+		return "", errors.New("verb is required")
+	}
+	if a.Resource == "" {
+		return "", errors.New("resource is required")
+	}
+
+	resource, subresource, _ := strings.Cut(a.Resource, "/")
+	if a.Subresource != "" {
+		if subresource != "" && subresource != a.Subresource {
+			return "", fmt.Errorf("resource %q names subresource %q, not %q", a.Resource, subresource, a.Subresource)
+		}
+		subresource = a.Subresource
+	}
+	gr := a.resolve(ctx, cm, schema.ParseGroupResource(resource))
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   a.Namespace,
+				Verb:        a.Verb,
+				Group:       gr.Group,
+				Resource:    gr.Resource,
+				Subresource: subresource,
+				Name:        a.Name,
+			},
+		},
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	response, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(timeoutCtx, review, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to review access: %w", err)
+	}
+
+	action := a.Verb + " " + gr.String()
+	if subresource != "" {
+		action += "/" + subresource
+	}
+	if a.Name != "" {
+		action += fmt.Sprintf(" %q", a.Name)
+	}
+	scope := "in any namespace"
+	if a.Namespace != "" {
+		scope = fmt.Sprintf("in namespace %q", a.Namespace)
+	}
+
+	slog.Debug("access reviewed",
+		slog.String("action", action),
+		slog.String("namespace", a.Namespace),
+		slog.Bool("allowed", response.Status.Allowed),
+	)
+
+	var sb strings.Builder
+	if response.Status.Allowed {
+		fmt.Fprintf(&sb, "yes: you can %s %s", action, scope)
+	} else {
+		fmt.Fprintf(&sb, "no: you cannot %s %s", action, scope)
+		if response.Status.Denied {
+			sb.WriteString(" (explicitly denied)")
+		}
+	}
+	if response.Status.Reason != "" {
+		fmt.Fprintf(&sb, "\nReason: %s", response.Status.Reason)
+	}
+	if response.Status.EvaluationError != "" {
+		fmt.Fprintf(&sb, "\nEvaluation error: %s", response.Status.EvaluationError)
+	}
+	return sb.String(), nil
+}
+
+// resolve fills in the API group of a resource given without one, as
+// kubectl auth can-i does, so "deployments" is checked as
+// "deployments.apps". The resource is used as given when discovery does
+// not know it, since access can be granted to resources that do not exist.
+func (a *AccessReview) resolve(ctx context.Context, cm kai.ClusterManager, gr schema.GroupResource) schema.GroupResource {
+	if gr.Group != "" || gr.Resource == "*" {
+		return gr
+	}
+	mapper, err := kai.CurrentRESTMapper(ctx, cm)
+	if err != nil {
+		return gr
+	}
+	gvr, err := mapper.ResourceFor(gr.WithVersion(""))
+	if err != nil {
+		return gr
+	}
+	return gvr.GroupResource()
+}
@@ -0,0 +1,75 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestAccessReviewCheck(t *testing.T) {
+	ctx := context.Background()
+
+	// setup answers every review with status and records what was asked.
+	setup := func(status authorizationv1.SubjectAccessReviewStatus) (*testmocks.MockClusterManager, *authorizationv1.ResourceAttributes) {
+		client := fake.NewSimpleClientset()
+		client.Resources = []*metav1.APIResourceList{
+			{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "pods", Namespaced: true, Kind: "Pod"}}},
+			{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{{Name: "deployments", Namespaced: true, Kind: "Deployment"}}},
+		}
+		asked := &authorizationv1.ResourceAttributes{}
+		client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview).DeepCopy()
+			*asked = *review.Spec.ResourceAttributes
+			review.Status = status
+			return true, review, nil
+		})
+		cm := testmocks.NewMockClusterManager()
+		cm.On("GetCurrentClient").Return(client, nil)
+		return cm, asked
+	}
+
+	t.Run("Allowed", func(t *testing.T) {
+		cm, asked := setup(authorizationv1.SubjectAccessReviewStatus{Allowed: true, Reason: `RBAC: allowed by RoleBinding "dev/test-namespace"`})
+		result, err := (&AccessReview{Verb: "create", Resource: "deployments", Namespace: testNamespace}).Check(ctx, cm)
+		require.NoError(t, err)
+		assert.Equal(t, "yes: you can create deployments.apps in namespace \"test-namespace\"\nReason: RBAC: allowed by RoleBinding \"dev/test-namespace\"", result)
+		assert.Equal(t, authorizationv1.ResourceAttributes{Namespace: testNamespace, Verb: "create", Group: "apps", Resource: "deployments"}, *asked)
+	})
+
+	t.Run("DeniedSubresource", func(t *testing.T) {
+		cm, asked := setup(authorizationv1.SubjectAccessReviewStatus{Denied: true, EvaluationError: "webhook unavailable"})
+		result, err := (&AccessReview{Verb: "get", Resource: "pods/log", Name: "web", Namespace: testNamespace}).Check(ctx, cm)
+		require.NoError(t, err)
+		assert.Equal(t, "no: you cannot get pods/log \"web\" in namespace \"test-namespace\" (explicitly denied)\nEvaluation error: webhook unavailable", result)
+		assert.Equal(t, "pods", asked.Resource)
+		assert.Equal(t, "log", asked.Subresource)
+		assert.Equal(t, "web", asked.Name)
+	})
+
+	t.Run("UnknownResourceAnyNamespace", func(t *testing.T) {
+		cm, asked := setup(authorizationv1.SubjectAccessReviewStatus{})
+		result, err := (&AccessReview{Verb: "list", Resource: "widgets.example.com"}).Check(ctx, cm)
+		require.NoError(t, err)
+		assert.Equal(t, "no: you cannot list widgets.example.com in any namespace", result)
+		assert.Equal(t, "example.com", asked.Group)
+		assert.Empty(t, asked.Namespace)
+	})
+
+	t.Run("ConflictingSubresource", func(t *testing.T) {
+		_, err := (&AccessReview{Verb: "get", Resource: "pods/log", Subresource: "exec"}).Check(ctx, testmocks.NewMockClusterManager())
+		assert.EqualError(t, err, `resource "pods/log" names subresource "log", not "exec"`)
+	})
+
+	t.Run("MissingVerb", func(t *testing.T) {
+		_, err := (&AccessReview{Resource: "pods"}).Check(ctx, testmocks.NewMockClusterManager())
+		assert.EqualError(t, err, "verb is required")
+	})
+}
@@ -0,0 +1,176 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/basebandit/kai"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultActivityLimit = 5
+
+// NamespaceActivity represents a query for resource age and deploy churn in
+// a namespace.
+type NamespaceActivity struct {
+	Namespace string
+	Limit     int // entries per section; defaults to 5
+}
+
+type activityEntry struct {
+	kind    string
+	name    string
+	created time.Time
+}
+
+type deploymentUpdate struct {
+	name     string
+	revision string
+	updated  time.Time
+}
+
+// Report lists the newest and oldest resources in the namespace and the
+// deployments most recently updated, judged by the latest condition
+// lastUpdateTime.
+func (a *NamespaceActivity) Report(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := a.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+	limit := a.Limit
+	if limit <= 0 {
+		limit = defaultActivityLimit
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	var entries []activityEntry
+	add := func(kind string, meta metav1.ObjectMeta) {
+		entries = append(entries, activityEntry{kind: kind, name: meta.Name, created: meta.CreationTimestamp.Time})
+	}
+
+	deployments, err := client.AppsV1().Deployments(namespace).List(timeoutCtx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, d := range deployments.Items {
+		add("Deployment", d.ObjectMeta)
+	}
+
+	statefulSets, err := client.AppsV1().StatefulSets(namespace).List(timeoutCtx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, s := range statefulSets.Items {
+		add("StatefulSet", s.ObjectMeta)
+	}
+
+	daemonSets, err := client.AppsV1().DaemonSets(namespace).List(timeoutCtx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, d := range daemonSets.Items {
+		add("DaemonSet", d.ObjectMeta)
+	}
+
+	jobs, err := client.BatchV1().Jobs(namespace).List(timeoutCtx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for _, j := range jobs.Items {
+		add("Job", j.ObjectMeta)
+	}
+
+	services, err := client.CoreV1().Services(namespace).List(timeoutCtx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list services: %w", err)
+	}
+	for _, s := range services.Items {
+		add("Service", s.ObjectMeta)
+	}
+
+	configMaps, err := client.CoreV1().ConfigMaps(namespace).List(timeoutCtx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list configmaps: %w", err)
+	}
+	for _, c := range configMaps.Items {
+		add("ConfigMap", c.ObjectMeta)
+	}
+
+	secrets, err := client.CoreV1().Secrets(namespace).List(timeoutCtx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list secrets: %w", err)
+	}
+	for _, s := range secrets.Items {
+		add("Secret", s.ObjectMeta)
+	}
+
+	if len(entries) == 0 {
+		return fmt.Sprintf("No resources found in namespace %q", namespace), nil
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].created.After(entries[j].created)
+	})
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Activity in namespace %q (%d resources):\n", namespace, len(entries))
+
+	sb.WriteString("\nNewest:\n")
+	for _, e := range entries[:min(limit, len(entries))] {
+		fmt.Fprintf(&sb, "• %s/%s\tage: %s\n", e.kind, e.name, formatDuration(time.Since(e.created)))
+	}
+
+	sb.WriteString("\nOldest:\n")
+	for i := len(entries) - 1; i >= max(len(entries)-limit, 0); i-- {
+		e := entries[i]
+		fmt.Fprintf(&sb, "• %s/%s\tage: %s\n", e.kind, e.name, formatDuration(time.Since(e.created)))
+	}
+
+	updated := recentlyUpdatedDeployments(deployments.Items)
+	if len(updated) > 0 {
+		sb.WriteString("\nRecently updated deployments:\n")
+		for _, u := range updated[:min(limit, len(updated))] {
+			fmt.Fprintf(&sb, "• %s\tupdated: %s ago\trevision: %s\n",
+				u.name, formatDuration(time.Since(u.updated)), u.revision)
+		}
+	}
+
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// recentlyUpdatedDeployments returns deployments ordered by the most recent
+// condition lastUpdateTime.
+func recentlyUpdatedDeployments(deployments []appsv1.Deployment) []deploymentUpdate {
+	var updated []deploymentUpdate
+	for _, d := range deployments {
+		var last time.Time
+		for _, cond := range d.Status.Conditions {
+			if cond.LastUpdateTime.After(last) {
+				last = cond.LastUpdateTime.Time
+			}
+		}
+		if last.IsZero() {
+			continue
+		}
+		revision := d.Annotations[revisionAnnotation]
+		if revision == "" {
+			revision = "<none>"
+		}
+		updated = append(updated, deploymentUpdate{name: d.Name, revision: revision, updated: last})
+	}
+	sort.SliceStable(updated, func(i, j int) bool {
+		return updated[i].updated.After(updated[j].updated)
+	})
+	return updated
+}
@@ -0,0 +1,63 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNamespaceActivityReport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("NewestAndOldest", func(t *testing.T) {
+		now := time.Now()
+		oldConfig := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: "settings", Namespace: defaultNamespace,
+			CreationTimestamp: metav1.NewTime(now.Add(-30 * 24 * time.Hour)),
+		}}
+		newDeployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "checkout", Namespace: defaultNamespace,
+				CreationTimestamp: metav1.NewTime(now.Add(-time.Minute)),
+				Annotations:       map[string]string{"deployment.kubernetes.io/revision": "4"},
+			},
+			Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{{
+				Type:           appsv1.DeploymentProgressing,
+				Status:         corev1.ConditionTrue,
+				LastUpdateTime: metav1.NewTime(now.Add(-time.Minute)),
+			}}},
+		}
+		fakeClient := fake.NewSimpleClientset(oldConfig, newDeployment)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+		mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+
+		activity := &NamespaceActivity{Limit: 1}
+		result, err := activity.Report(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Newest:\n• Deployment/checkout")
+		assert.Contains(t, result, "Oldest:\n• ConfigMap/settings")
+		assert.Contains(t, result, "Recently updated deployments:\n• checkout")
+		assert.Contains(t, result, "revision: 4")
+	})
+
+	t.Run("EmptyNamespace", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(), nil)
+		mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+
+		activity := &NamespaceActivity{}
+		result, err := activity.Report(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "No resources found")
+	})
+}
@@ -1,6 +1,8 @@
 package cluster
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -13,14 +15,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/yaml"
-	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/restmapper"
 )
 
 // Apply applies one or more YAML/JSON manifest documents to the cluster. It
-// mirrors `kubectl apply -f`: each document is created if absent or replaced if
-// it already exists (upsert). Documents are separated by `---`.
+// mirrors `kubectl apply --server-side -f`: each document is created if absent
+// or has its fields merged into the existing object, owned by the kai field
+// manager. Documents are separated by `---`.
 type Apply struct {
 	// Manifest is the raw YAML/JSON, optionally multiple `---` separated docs.
 	Manifest string
@@ -28,6 +29,10 @@ type Apply struct {
 	// Namespace optionally overrides the target namespace for namespaced objects
 	// whose manifest omits metadata.namespace. Ignored for cluster-scoped kinds.
 	Namespace string
+
+	// DryRun submits every apply with dryRun=All, so the API server validates
+	// and admits the objects without persisting them.
+	DryRun bool
 }
 
 // Run applies every document in the manifest and returns a per-object summary.
@@ -45,24 +50,23 @@ func (a *Apply) Run(ctx context.Context, cm kai.ClusterManager) (string, error)
 		return "", errors.New("no kubernetes objects found in manifest")
 	}
 
-	client, err := cm.GetCurrentClient()
+	mapper, err := kai.CurrentRESTMapper(ctx, cm)
 	if err != nil {
-		return "", fmt.Errorf("error getting client: %w", err)
+		return "", fmt.Errorf("error getting REST mapper: %w", err)
 	}
-	dyn, err := cm.GetCurrentDynamicClient()
+	dyn, err := kai.CurrentDynamicClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting dynamic client: %w", err)
 	}
 
-	mapper, err := newRESTMapper(client.Discovery())
-	if err != nil {
-		return "", fmt.Errorf("failed to build REST mapper: %w", err)
-	}
-
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "Applied %d object(s):\n", len(objs))
+	if a.DryRun {
+		fmt.Fprintf(&sb, "Dry run: %d object(s) would be applied:\n", len(objs))
+	} else {
+		fmt.Fprintf(&sb, "Applied %d object(s):\n", len(objs))
+	}
 	for _, obj := range objs {
-		line, err := applyObject(ctx, dyn, mapper, obj, a.Namespace, cm)
+		line, err := a.applyObject(ctx, dyn, mapper, obj, cm)
 		if err != nil {
 			return "", err
 		}
@@ -87,31 +91,31 @@ func decodeManifests(manifest string) ([]*unstructured.Unstructured, error) {
 		if len(raw) == 0 {
 			continue // empty document between separators
 		}
-		obj := &unstructured.Unstructured{Object: raw}
-		if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
-			return nil, errors.New("manifest document missing apiVersion or kind")
-		}
-		if obj.GetName() == "" {
-			return nil, fmt.Errorf("%s document missing metadata.name", obj.GetKind())
+		obj, err := manifestObject(raw)
+		if err != nil {
+			return nil, err
 		}
 		objs = append(objs, obj)
 	}
 	return objs, nil
 }
 
-// newRESTMapper builds a REST mapper from server discovery so arbitrary kinds
-// (built-in or CRD) can be resolved to their resource and scope.
-func newRESTMapper(disc discovery.DiscoveryInterface) (meta.RESTMapper, error) {
-	groupResources, err := restmapper.GetAPIGroupResources(disc)
-	if err != nil {
-		return nil, err
+// manifestObject wraps a decoded document, checking that it carries
+// apiVersion, kind and metadata.name.
+func manifestObject(raw map[string]interface{}) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{Object: raw}
+	if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+		return nil, errors.New("manifest document missing apiVersion or kind")
 	}
-	return restmapper.NewDiscoveryRESTMapper(groupResources), nil
+	if obj.GetName() == "" {
+		return nil, fmt.Errorf("%s document missing metadata.name", obj.GetKind())
+	}
+	return obj, nil
 }
 
 // applyObject resolves an object's GVK to a resource via the mapper and applies
 // it with server-side apply, honoring namespace scope.
-func applyObject(ctx context.Context, dyn dynamic.Interface, mapper meta.RESTMapper, obj *unstructured.Unstructured, nsOverride string, cm kai.ClusterManager) (string, error) {
+func (a *Apply) applyObject(ctx context.Context, dyn dynamic.Interface, mapper meta.RESTMapper, obj *unstructured.Unstructured, cm kai.ClusterManager) (string, error) {
 	gvk := obj.GroupVersionKind()
 	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 	if err != nil {
@@ -129,10 +133,10 @@ func applyObject(ctx context.Context, dyn dynamic.Interface, mapper meta.RESTMap
 	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
 		ns := obj.GetNamespace()
 		if ns == "" {
-			if nsOverride != "" {
-				ns = nsOverride
+			if a.Namespace != "" {
+				ns = a.Namespace
 			} else {
-				ns = cm.GetCurrentNamespace()
+				ns = kai.CurrentNamespace(ctx, cm)
 			}
 		}
 		obj.SetNamespace(ns)
@@ -142,22 +146,257 @@ func applyObject(ctx context.Context, dyn dynamic.Interface, mapper meta.RESTMap
 		ri = dyn.Resource(mapping.Resource)
 	}
 
+	var dryRun []string
+	suffix := ""
+	if a.DryRun {
+		dryRun = []string{metav1.DryRunAll}
+		suffix = " (dry run)"
+	}
+
+	// The lookup only decides whether the object is reported as created or
+	// configured; server-side apply handles both.
 	name := obj.GetName()
-	existing, err := ri.Get(timeoutCtx, name, metav1.GetOptions{})
+	_, err = ri.Get(timeoutCtx, name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", fmt.Errorf("failed to get %s %q: %w", gvk.Kind, name, err)
+	}
+	action := "configured"
 	if apierrors.IsNotFound(err) {
-		if _, err := ri.Create(timeoutCtx, obj, metav1.CreateOptions{}); err != nil {
-			return "", fmt.Errorf("failed to create %s %q: %w", gvk.Kind, name, err)
+		action = "created"
+	}
+
+	// Forcing takes ownership of fields other managers set, as the replace
+	// this used to be did, instead of failing on the conflict.
+	if _, err := ri.Apply(timeoutCtx, name, obj, metav1.ApplyOptions{
+		FieldManager: fieldManager,
+		Force:        true,
+		DryRun:       dryRun,
+	}); err != nil {
+		return "", fmt.Errorf("failed to apply %s %q: %w", gvk.Kind, name, err)
+	}
+	return fmt.Sprintf("%s %s%s %s%s", gvk.Kind, prefix, name, action, suffix), nil
+}
+
+// CreateResource creates a single object of any kind from structured fields
+// rather than a manifest, for kinds without a dedicated tool.
+type CreateResource struct {
+	Kind    string
+	Group   string
+	Version string // optional; the preferred version is used when empty
+	Name    string
+	// Namespace is used for namespaced kinds, defaulting to the current
+	// namespace. It is ignored for cluster-scoped kinds.
+	Namespace string
+	Labels    map[string]interface{}
+	Spec      map[string]interface{}
+}
+
+// Run builds an unstructured object from the fields and creates it via the
+// dynamic client. It fails if the object already exists.
+func (c *CreateResource) Run(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if c.Kind == "" {
+		return "", errors.New("kind is required")
+	}
+	if c.Name == "" {
+		return "", errors.New("metadata name is required")
+	}
+
+	mapper, err := kai.CurrentRESTMapper(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting REST mapper: %w", err)
+	}
+	dyn, err := kai.CurrentDynamicClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting dynamic client: %w", err)
+	}
+
+	mapping, err := resolveMapping(mapper, c.Group, c.Version, c.Kind)
+	if err != nil {
+		return "", err
+	}
+	gvk := mapping.GroupVersionKind
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(c.Name)
+	if len(c.Labels) > 0 {
+		obj.SetLabels(convertToStringMap(c.Labels))
+	}
+	if len(c.Spec) > 0 {
+		obj.Object["spec"] = c.Spec
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	var (
+		ri     dynamic.ResourceInterface
+		prefix string
+	)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		ns := c.Namespace
+		if ns == "" {
+			ns = kai.CurrentNamespace(ctx, cm)
+		}
+		obj.SetNamespace(ns)
+		ri = dyn.Resource(mapping.Resource).Namespace(ns)
+		prefix = ns + "/"
+	} else {
+		ri = dyn.Resource(mapping.Resource)
+	}
+
+	if _, err := ri.Create(timeoutCtx, obj, metav1.CreateOptions{}); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return "", fmt.Errorf("%s %s%s already exists", gvk.Kind, prefix, c.Name)
 		}
-		return fmt.Sprintf("%s %s%s created", gvk.Kind, prefix, name), nil
+		return "", fmt.Errorf("failed to create %s %q: %w", gvk.Kind, c.Name, err)
 	}
+	return fmt.Sprintf("%s %s%s created", gvk.Kind, prefix, c.Name), nil
+}
+
+// fieldManager identifies kai as the field owner for server-side apply
+// requests.
+const fieldManager = "kai"
+
+// ValidateManifest checks manifest documents against the cluster without
+// persisting anything. Each document is parsed, resolved to a resource and
+// submitted as a server-side apply dry-run, so schema validation and
+// admission webhooks run as they would for a real apply.
+type ValidateManifest struct {
+	// Manifest is the raw YAML/JSON, optionally multiple `---` separated docs.
+	Manifest string
+
+	// Namespace optionally sets the namespace for namespaced objects whose
+	// manifest omits metadata.namespace.
+	Namespace string
+}
+
+// Run validates every document independently and reports each as valid or
+// invalid. A parse error in one document does not stop the others from
+// being checked.
+func (v *ValidateManifest) Run(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if strings.TrimSpace(v.Manifest) == "" {
+		return "", errors.New("manifest is required")
+	}
+
+	docs, err := splitManifest(v.Manifest)
 	if err != nil {
-		return "", fmt.Errorf("failed to get %s %q: %w", gvk.Kind, name, err)
+		return "", err
+	}
+	if len(docs) == 0 {
+		return "", errors.New("no kubernetes objects found in manifest")
+	}
+
+	mapper, err := kai.CurrentRESTMapper(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting REST mapper: %w", err)
+	}
+	dyn, err := kai.CurrentDynamicClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting dynamic client: %w", err)
+	}
+
+	var (
+		lines   []string
+		invalid int
+	)
+	for i, doc := range docs {
+		line, ok := v.validateDocument(ctx, dyn, mapper, doc, cm)
+		if !ok {
+			invalid++
+		}
+		lines = append(lines, fmt.Sprintf("• [%d] %s", i+1, line))
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Validated %d document(s): %d valid, %d invalid\n", len(docs), len(docs)-invalid, invalid)
+	sb.WriteString(strings.Join(lines, "\n"))
+	return sb.String(), nil
+}
+
+// validateDocument parses and dry-run applies a single document, returning
+// its report line and whether it is valid.
+func (v *ValidateManifest) validateDocument(ctx context.Context, dyn dynamic.Interface, mapper meta.RESTMapper, doc []byte, cm kai.ClusterManager) (string, bool) {
+	raw := map[string]interface{}{}
+	if err := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(doc), 4096).Decode(&raw); err != nil {
+		return fmt.Sprintf("invalid (parse error): %s", err.Error()), false
+	}
+	obj, err := manifestObject(raw)
+	if err != nil {
+		return fmt.Sprintf("invalid (parse error): %s", err.Error()), false
+	}
+
+	gvk := obj.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return fmt.Sprintf("%s %s: invalid (unknown kind): unable to resolve %s/%s", gvk.Kind, obj.GetName(), gvk.GroupVersion().String(), gvk.Kind), false
+	}
+
+	var (
+		ri     dynamic.ResourceInterface
+		prefix string
+	)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		ns := obj.GetNamespace()
+		if ns == "" {
+			ns = v.Namespace
+		}
+		if ns == "" {
+			ns = kai.CurrentNamespace(ctx, cm)
+		}
+		obj.SetNamespace(ns)
+		ri = dyn.Resource(mapping.Resource).Namespace(ns)
+		prefix = ns + "/"
+	} else {
+		ri = dyn.Resource(mapping.Resource)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	ref := fmt.Sprintf("%s %s%s", gvk.Kind, prefix, obj.GetName())
+	_, err = ri.Apply(timeoutCtx, obj.GetName(), obj, metav1.ApplyOptions{
+		FieldManager: fieldManager,
+		Force:        true,
+		DryRun:       []string{metav1.DryRunAll},
+	})
+	if err != nil {
+		return fmt.Sprintf("%s: invalid (admission error): %s", ref, err.Error()), false
+	}
+	return fmt.Sprintf("%s: valid", ref), true
+}
+
+// splitManifest splits a multi-document stream into raw documents, dropping
+// documents that are empty or hold only comments.
+func splitManifest(manifest string) ([][]byte, error) {
+	reader := yaml.NewYAMLReader(bufio.NewReader(strings.NewReader(manifest)))
+	var docs [][]byte
+	for {
+		doc, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to read manifest: %w", err)
+		}
+		if len(bytes.TrimSpace(stripComments(doc))) == 0 {
+			continue
+		}
+		docs = append(docs, doc)
 	}
+	return docs, nil
+}
 
-	// Preserve resourceVersion so the update is accepted as a replace.
-	obj.SetResourceVersion(existing.GetResourceVersion())
-	if _, err := ri.Update(timeoutCtx, obj, metav1.UpdateOptions{}); err != nil {
-		return "", fmt.Errorf("failed to update %s %q: %w", gvk.Kind, name, err)
+// stripComments drops full-line YAML comments and document separators so
+// comment-only documents can be recognized as empty.
+func stripComments(doc []byte) []byte {
+	var out [][]byte
+	for _, line := range bytes.Split(doc, []byte("\n")) {
+		trimmed := bytes.TrimSpace(line)
+		if bytes.HasPrefix(trimmed, []byte("#")) || bytes.Equal(trimmed, []byte("---")) {
+			continue
+		}
+		out = append(out, line)
 	}
-	return fmt.Sprintf("%s %s%s configured", gvk.Kind, prefix, name), nil
+	return bytes.Join(out, []byte("\n"))
 }
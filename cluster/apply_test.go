@@ -2,16 +2,29 @@ package cluster
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/basebandit/kai/testmocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 // applyDiscovery advertises configmaps (namespaced) and namespaces (cluster)
@@ -56,12 +69,53 @@ metadata:
   name: team-a
 `
 
+// serveApply makes the fake dynamic client answer server-side apply, which
+// its tracker cannot do for unstructured objects: absent objects are created
+// and existing ones get the applied fields merged in.
+func serveApply(dyn *dynamicfake.FakeDynamicClient) {
+	dyn.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		if patch.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+		applied := map[string]interface{}{}
+		if err := json.Unmarshal(patch.GetPatch(), &applied); err != nil {
+			return true, nil, err
+		}
+		gvr, ns := patch.GetResource(), patch.GetNamespace()
+		existing, err := dyn.Tracker().Get(gvr, ns, patch.GetName())
+		if apierrors.IsNotFound(err) {
+			obj := &unstructured.Unstructured{Object: applied}
+			return true, obj, dyn.Tracker().Create(gvr, obj, ns)
+		}
+		if err != nil {
+			return true, nil, err
+		}
+		obj := existing.(*unstructured.Unstructured).DeepCopy()
+		mergeFields(obj.Object, applied)
+		return true, obj, dyn.Tracker().Update(gvr, obj, ns)
+	})
+}
+
+func mergeFields(dst, src map[string]interface{}) {
+	for key, value := range src {
+		if srcMap, ok := value.(map[string]interface{}); ok {
+			if dstMap, ok := dst[key].(map[string]interface{}); ok {
+				mergeFields(dstMap, srcMap)
+				continue
+			}
+		}
+		dst[key] = value
+	}
+}
+
 func TestApplyRun(t *testing.T) {
 	ctx := context.Background()
 
 	fakeClient := fake.NewSimpleClientset()
 	fakeClient.Resources = applyDiscovery()
 	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), applyListKinds)
+	serveApply(dyn)
 
 	mockCM := testmocks.NewMockClusterManager()
 	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
@@ -89,6 +143,7 @@ func TestApplyUpdate(t *testing.T) {
 
 	cmGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
 	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), applyListKinds)
+	serveApply(dyn)
 	_, err := dyn.Resource(cmGVR).Namespace(defaultNamespace).Create(ctx, uObj("v1", "ConfigMap", "cm1", defaultNamespace), metav1.CreateOptions{})
 	assert.NoError(t, err)
 
@@ -97,7 +152,7 @@ func TestApplyUpdate(t *testing.T) {
 	mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
 	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
 
-	// Re-applying an existing object takes the update branch.
+	// Re-applying an existing object reports it as configured.
 	manifest := `apiVersion: v1
 kind: ConfigMap
 metadata:
@@ -116,6 +171,7 @@ func TestApplyNamespaceOverride(t *testing.T) {
 	fakeClient := fake.NewSimpleClientset()
 	fakeClient.Resources = applyDiscovery()
 	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), applyListKinds)
+	serveApply(dyn)
 
 	mockCM := testmocks.NewMockClusterManager()
 	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
@@ -133,6 +189,59 @@ metadata:
 	assert.Contains(t, result, "ConfigMap "+otherNamespace+"/cm2 created")
 }
 
+func TestApplyDryRun(t *testing.T) {
+	ctx := context.Background()
+
+	// The fake dynamic client drops write options, so serve the API over
+	// HTTP and record how every write was sent. team-a exists;
+	// cm1 does not.
+	var (
+		mu      sync.Mutex
+		dryRuns []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/namespaces/team-a"):
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"team-a","resourceVersion":"7"}}`))
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"NotFound","code":404}`))
+		default:
+			mu.Lock()
+			dryRuns = append(dryRuns, strings.Join([]string{
+				r.Method,
+				r.Header.Get("Content-Type"),
+				r.URL.Query().Get("dryRun"),
+				r.URL.Query().Get("fieldManager"),
+			}, " "))
+			mu.Unlock()
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(body)
+		}
+	}))
+	defer srv.Close()
+	dyn, err := dynamic.NewForConfig(&rest.Config{Host: srv.URL})
+	require.NoError(t, err)
+
+	fakeClient := fake.NewSimpleClientset()
+	fakeClient.Resources = applyDiscovery()
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+
+	result, err := (&Apply{Manifest: applyManifest, DryRun: true}).Run(ctx, mockCM)
+	require.NoError(t, err)
+	assert.Equal(t, `Dry run: 2 object(s) would be applied:
+• ConfigMap default/cm1 created (dry run)
+• Namespace team-a configured (dry run)`, result)
+	assert.Equal(t, []string{
+		"PATCH application/apply-patch+yaml All kai",
+		"PATCH application/apply-patch+yaml All kai",
+	}, dryRuns)
+}
+
 func TestApplyValidation(t *testing.T) {
 	ctx := context.Background()
 	mockCM := testmocks.NewMockClusterManager()
@@ -157,3 +266,152 @@ kind: ConfigMap
 `)
 	assert.Error(t, err) // missing metadata.name
 }
+
+func TestValidateManifestRun(t *testing.T) {
+	ctx := context.Background()
+
+	fakeClient := fake.NewSimpleClientset()
+	fakeClient.Resources = applyDiscovery()
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), applyListKinds)
+
+	// The fake tracker has no dry-run support; answer apply patches directly
+	// and reject one object the way an admission webhook would.
+	var applied []string
+	dyn.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		assert.Equal(t, types.ApplyPatchType, patch.GetPatchType())
+		applied = append(applied, patch.GetName())
+		if patch.GetName() == "rejected" {
+			return true, nil, errors.New(`admission webhook "policy.example.com" denied the request`)
+		}
+		return true, nil, nil
+	})
+
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+
+	t.Run("Valid", func(t *testing.T) {
+		result, err := (&ValidateManifest{Manifest: applyManifest}).Run(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Validated 2 document(s): 2 valid, 0 invalid")
+		assert.Contains(t, result, "• [1] ConfigMap default/cm1: valid")
+		assert.Contains(t, result, "• [2] Namespace team-a: valid")
+
+		// Nothing is persisted.
+		cmGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+		_, err = dyn.Resource(cmGVR).Namespace(defaultNamespace).Get(ctx, "cm1", metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("SyntaxError", func(t *testing.T) {
+		applied = nil
+		manifest := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: [broken
+`
+		result, err := (&ValidateManifest{Manifest: manifest}).Run(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Validated 2 document(s): 1 valid, 1 invalid")
+		assert.Contains(t, result, "• [1] ConfigMap default/cm1: valid")
+		assert.Contains(t, result, "• [2] invalid (parse error):")
+		assert.Equal(t, []string{"cm1"}, applied)
+	})
+
+	t.Run("AdmissionError", func(t *testing.T) {
+		manifest := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: rejected
+  namespace: other
+`
+		result, err := (&ValidateManifest{Manifest: manifest}).Run(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "0 valid, 1 invalid")
+		assert.Contains(t, result, "• [1] ConfigMap other/rejected: invalid (admission error): admission webhook")
+	})
+
+	t.Run("UnknownKind", func(t *testing.T) {
+		manifest := `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: w1
+`
+		result, err := (&ValidateManifest{Manifest: manifest}).Run(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "• [1] Widget w1: invalid (unknown kind)")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		_, err := (&ValidateManifest{Manifest: "---\n# only a comment\n---\n"}).Run(ctx, mockCM)
+		assert.EqualError(t, err, "no kubernetes objects found in manifest")
+	})
+}
+
+func TestCreateResourceRun(t *testing.T) {
+	ctx := context.Background()
+
+	widgetGVR := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	fakeClient := fake.NewSimpleClientset()
+	fakeClient.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "example.com/v1",
+		APIResources: []metav1.APIResource{{Name: "widgets", Namespaced: true, Kind: "Widget"}},
+	}}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		widgetGVR: "WidgetList",
+	})
+
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+
+	create := &CreateResource{
+		Kind:      "Widget",
+		Group:     "example.com",
+		Name:      "w1",
+		Namespace: testNamespace,
+		Labels:    map[string]interface{}{"app": "demo"},
+		Spec: map[string]interface{}{
+			"size":  float64(3),
+			"color": "blue",
+			"tags":  []interface{}{"a", "b"},
+		},
+	}
+	result, err := create.Run(ctx, mockCM)
+	assert.NoError(t, err)
+	assert.Equal(t, "Widget test-namespace/w1 created", result)
+
+	got, err := dyn.Resource(widgetGVR).Namespace(testNamespace).Get(ctx, "w1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com/v1", got.GetAPIVersion())
+	assert.Equal(t, "demo", got.GetLabels()["app"])
+	color, _, _ := unstructured.NestedString(got.Object, "spec", "color")
+	assert.Equal(t, "blue", color)
+	size, _, _ := unstructured.NestedFloat64(got.Object, "spec", "size")
+	assert.Equal(t, float64(3), size)
+
+	// Creating it again reports the conflict.
+	_, err = create.Run(ctx, mockCM)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+}
+
+func TestCreateResourceValidation(t *testing.T) {
+	ctx := context.Background()
+	mockCM := testmocks.NewMockClusterManager()
+
+	_, err := (&CreateResource{Name: "w1"}).Run(ctx, mockCM)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "kind is required")
+
+	_, err = (&CreateResource{Kind: "Widget"}).Run(ctx, mockCM)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "name is required")
+}
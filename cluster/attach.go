@@ -0,0 +1,209 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+const (
+	// defaultAttachDuration is how long attach_pod stays attached when no
+	// duration is given.
+	defaultAttachDuration = 10 * time.Second
+	// maxAttachDuration caps the duration, since the tool call blocks for as
+	// long as it is attached.
+	maxAttachDuration = 5 * time.Minute
+)
+
+// errAttachOutputLimit ends an attach once maxBytes of output are captured.
+var errAttachOutputLimit = errors.New("output limit reached")
+
+// newPodAttacher opens the streaming connection to the pods/attach
+// subresource. Tests replace it, since the fake clientset cannot stream.
+var newPodAttacher = newStreamExecutor
+
+// Attach connects to the running process of a container and captures what
+// it writes to stdout and stderr for duration, like kubectl attach. When
+// stdin is not empty it is sent to the process first, which needs the
+// container to have been started with stdin: true. Attaching ends early
+// when the process exits or maxBytes of output have been captured. A zero
+// duration or maxBytes uses the defaults.
+func (p *Pod) Attach(ctx context.Context, cm kai.ClusterManager, container, stdin string, duration time.Duration, maxBytes int) (string, error) {
+	if duration <= 0 {
+		duration = defaultAttachDuration
+	}
+	if duration > maxAttachDuration {
+		return "", fmt.Errorf("duration %s exceeds the maximum of %s", duration, maxAttachDuration)
+	}
+	if maxBytes <= 0 {
+		maxBytes = maxLogBytes
+	}
+	if maxBytes > maxLogBytes {
+		return "", fmt.Errorf("max_bytes %d exceeds the maximum of %d", maxBytes, maxLogBytes)
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	config, err := kai.CurrentRESTConfig(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting REST config: %w", err)
+	}
+
+	namespace := p.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	getCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	pod, err := client.CoreV1().Pods(namespace).Get(getCtx, p.Name, metav1.GetOptions{})
+	cancel()
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("pod %q not found in namespace %q", p.Name, namespace)
+		}
+		return "", fmt.Errorf("failed to get pod %q: %w", p.Name, err)
+	}
+
+	container, err = execContainer(pod, container)
+	if err != nil {
+		return "", err
+	}
+	var spec corev1.Container
+	for _, c := range pod.Spec.Containers {
+		if c.Name == container {
+			spec = c
+		}
+	}
+	if !containerRunning(pod, container) {
+		return "", fmt.Errorf("container %q in pod %q is not running", container, p.Name)
+	}
+	if stdin != "" && !spec.Stdin {
+		return "", fmt.Errorf("container %q was not started with stdin: true, so it cannot read input", container)
+	}
+
+	reqURL, err := url.Parse(fmt.Sprintf("%s/api/v1/namespaces/%s/pods/%s/attach", strings.TrimRight(config.Host, "/"), namespace, p.Name))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+	// With a TTY the kubelet merges stderr into stdout and rejects a
+	// separate stderr stream.
+	query, err := scheme.ParameterCodec.EncodeParameters(&corev1.PodAttachOptions{
+		Container: container,
+		Stdin:     stdin != "",
+		Stdout:    true,
+		Stderr:    !spec.TTY,
+		TTY:       spec.TTY,
+	}, corev1.SchemeGroupVersion)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode attach options: %w", err)
+	}
+	reqURL.RawQuery = query.Encode()
+
+	attacher, err := newPodAttacher(config, "POST", reqURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to create attacher: %w", err)
+	}
+
+	timeoutCtx, cancelTimeout := context.WithTimeout(ctx, duration)
+	defer cancelTimeout()
+	attachCtx, stop := context.WithCancelCause(timeoutCtx)
+	defer stop(nil)
+
+	output := &attachOutput{limit: maxBytes, full: func() { stop(errAttachOutputLimit) }}
+	options := remotecommand.StreamOptions{Stdout: output, Tty: spec.TTY}
+	if !spec.TTY {
+		options.Stderr = output
+	}
+	if stdin != "" {
+		options.Stdin = strings.NewReader(stdin)
+	}
+
+	started := time.Now()
+	err = attacher.StreamWithContext(attachCtx, options)
+
+	var ended string
+	switch {
+	case errors.Is(context.Cause(attachCtx), errAttachOutputLimit):
+		ended = fmt.Sprintf("output limit of %d bytes reached", maxBytes)
+	case ctx.Err() != nil:
+		return "", ctx.Err()
+	case timeoutCtx.Err() != nil:
+		ended = "duration elapsed"
+	case err != nil:
+		return "", fmt.Errorf("failed to attach to container %q: %w", container, err)
+	default:
+		ended = "stream closed; the process exited or the container stopped"
+	}
+
+	return formatAttachResult(namespace, p.Name, container, time.Since(started).Round(time.Second), ended, output.String()), nil
+}
+
+// containerRunning reports whether the named container is in the Running
+// state, which attach needs; a waiting or terminated container has no
+// process to attach to.
+func containerRunning(pod *corev1.Pod, container string) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container {
+			return status.State.Running != nil
+		}
+	}
+	return false
+}
+
+// attachOutput collects stdout and stderr in the order they arrive, up to
+// limit bytes, and calls full once the limit is hit.
+type attachOutput struct {
+	mu    sync.Mutex
+	buf   strings.Builder
+	limit int
+	full  func()
+	done  bool
+}
+
+func (o *attachOutput) Write(b []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.done {
+		return len(b), nil
+	}
+	if room := o.limit - o.buf.Len(); len(b) >= room {
+		o.buf.Write(b[:room])
+		o.done = true
+		o.full()
+		return len(b), nil
+	}
+	o.buf.Write(b)
+	return len(b), nil
+}
+
+func (o *attachOutput) String() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.buf.String()
+}
+
+func formatAttachResult(namespace, pod, container string, attached time.Duration, ended, output string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Attached to %s/%s (container %s) for %s; detached: %s\n", namespace, pod, container, attached, ended)
+	if output == "" {
+		sb.WriteString("\n(no output)")
+		return sb.String()
+	}
+	sb.WriteString("\n")
+	sb.WriteString(strings.TrimRight(output, "\n"))
+	return sb.String()
+}
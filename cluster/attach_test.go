@@ -0,0 +1,186 @@
+package cluster
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// attachStream plays a process's side of an attach: it echoes stdin, writes
+// output, and then either returns (the process exited) or, with follow,
+// keeps writing until the attach is ended.
+type attachStream struct {
+	output string
+	follow bool
+}
+
+func (a *attachStream) Stream(options remotecommand.StreamOptions) error {
+	return a.StreamWithContext(context.Background(), options)
+}
+
+func (a *attachStream) StreamWithContext(ctx context.Context, options remotecommand.StreamOptions) error {
+	if options.Stdin != nil {
+		if _, err := io.Copy(options.Stdout, options.Stdin); err != nil {
+			return err
+		}
+	}
+	_, _ = io.WriteString(options.Stdout, a.output)
+	if options.Stderr != nil {
+		_, _ = io.WriteString(options.Stderr, "warn\n")
+	}
+	for a.follow {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Millisecond):
+			_, _ = io.WriteString(options.Stdout, "tick\n")
+		}
+	}
+	return nil
+}
+
+func stubPodAttacher(t *testing.T, stream *attachStream) *url.URL {
+	t.Helper()
+	var requested url.URL
+	original := newPodAttacher
+	newPodAttacher = func(_ *rest.Config, method string, u *url.URL) (remotecommand.Executor, error) {
+		assert.Equal(t, "POST", method)
+		requested = *u
+		return stream, nil
+	}
+	t.Cleanup(func() { newPodAttacher = original })
+	return &requested
+}
+
+func TestPodAttach(t *testing.T) {
+	ctx := context.Background()
+
+	attachPod := func(running bool, mutate func(*corev1.Container)) *corev1.Pod {
+		c := corev1.Container{Name: "app", Image: "busybox"}
+		if mutate != nil {
+			mutate(&c)
+		}
+		state := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}
+		if running {
+			state = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: execPodName, Namespace: testNamespace},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{c}},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "app", State: state}},
+			},
+		}
+	}
+
+	newCM := func(pods ...*corev1.Pod) *testmocks.MockClusterManager {
+		clientset := fake.NewSimpleClientset()
+		for _, pod := range pods {
+			_ = clientset.Tracker().Add(pod)
+		}
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(clientset, nil)
+		mockCM.On("GetCurrentRESTConfig").Return(&rest.Config{Host: "https://cluster.example:6443"}, nil)
+		return mockCM
+	}
+
+	attach := func(cm *testmocks.MockClusterManager, stdin string, duration time.Duration, maxBytes int) (string, error) {
+		p := &Pod{Name: execPodName, Namespace: testNamespace}
+		return p.Attach(ctx, cm, "", stdin, duration, maxBytes)
+	}
+
+	t.Run("ProcessExits", func(t *testing.T) {
+		requested := stubPodAttacher(t, &attachStream{output: "hello\n"})
+
+		result, err := attach(newCM(attachPod(true, nil)), "", time.Second, 0)
+
+		require.NoError(t, err)
+		assert.Contains(t, result, "Attached to test-namespace/test-pod (container app)")
+		assert.Contains(t, result, "detached: stream closed")
+		assert.Contains(t, result, "hello\nwarn")
+		assert.Equal(t, "/api/v1/namespaces/test-namespace/pods/test-pod/attach", requested.Path)
+		query := requested.Query()
+		assert.Equal(t, "app", query.Get("container"))
+		assert.Equal(t, "true", query.Get("stdout"))
+		assert.Equal(t, "true", query.Get("stderr"))
+		assert.Empty(t, query.Get("stdin"))
+	})
+
+	t.Run("DetachesAfterDuration", func(t *testing.T) {
+		stubPodAttacher(t, &attachStream{output: "started\n", follow: true})
+
+		start := time.Now()
+		result, err := attach(newCM(attachPod(true, nil)), "", 50*time.Millisecond, 0)
+
+		require.NoError(t, err)
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.Contains(t, result, "detached: duration elapsed")
+		assert.Contains(t, result, "started")
+	})
+
+	t.Run("DetachesAtOutputLimit", func(t *testing.T) {
+		stubPodAttacher(t, &attachStream{follow: true})
+
+		result, err := attach(newCM(attachPod(true, nil)), "", time.Minute, 64)
+
+		require.NoError(t, err)
+		assert.Contains(t, result, "detached: output limit of 64 bytes reached")
+		_, output, _ := strings.Cut(result, "\n\n")
+		assert.LessOrEqual(t, len(output), 64)
+	})
+
+	t.Run("SendsStdinWithTTY", func(t *testing.T) {
+		requested := stubPodAttacher(t, &attachStream{})
+
+		result, err := attach(newCM(attachPod(true, func(c *corev1.Container) {
+			c.Stdin, c.TTY = true, true
+		})), "status\n", time.Second, 0)
+
+		require.NoError(t, err)
+		assert.Contains(t, result, "status")
+		assert.NotContains(t, result, "warn")
+		query := requested.Query()
+		assert.Equal(t, "true", query.Get("stdin"))
+		assert.Equal(t, "true", query.Get("tty"))
+		assert.Empty(t, query.Get("stderr"))
+	})
+
+	t.Run("StdinNeedsStdinContainer", func(t *testing.T) {
+		stubPodAttacher(t, &attachStream{})
+		_, err := attach(newCM(attachPod(true, nil)), "status\n", time.Second, 0)
+		assert.ErrorContains(t, err, "was not started with stdin: true")
+	})
+
+	t.Run("ContainerNotRunning", func(t *testing.T) {
+		stubPodAttacher(t, &attachStream{})
+		_, err := attach(newCM(attachPod(false, nil)), "", time.Second, 0)
+		assert.EqualError(t, err, `container "app" in pod "test-pod" is not running`)
+	})
+
+	t.Run("PodNotFound", func(t *testing.T) {
+		stubPodAttacher(t, &attachStream{})
+		_, err := attach(newCM(), "", time.Second, 0)
+		assert.EqualError(t, err, `pod "test-pod" not found in namespace "test-namespace"`)
+	})
+
+	t.Run("Bounds", func(t *testing.T) {
+		_, err := attach(newCM(), "", maxAttachDuration+time.Second, 0)
+		assert.ErrorContains(t, err, "exceeds the maximum of 5m0s")
+
+		_, err = attach(newCM(), "", 0, maxLogBytes+1)
+		assert.ErrorContains(t, err, "max_bytes 102401 exceeds the maximum")
+	})
+}
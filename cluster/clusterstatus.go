@@ -0,0 +1,128 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/basebandit/kai"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// defaultClusterStatusTimeout bounds each apiserver probe made by Clusters.
+const defaultClusterStatusTimeout = 5 * time.Second
+
+// clusterProbe is the outcome of probing one loaded context.
+type clusterProbe struct {
+	context string
+	server  string
+	version string
+	latency time.Duration
+	err     error
+}
+
+// Clusters probes the apiserver of every loaded context by requesting
+// /version and reports whether it answered and how long it took. Probes run
+// concurrently, each bounded by timeout (5s when zero or negative), so one
+// unreachable cluster does not hold up the report on the others.
+func (h *Health) Clusters(ctx context.Context, cm kai.ClusterManager, timeout time.Duration) (string, error) {
+	if timeout <= 0 {
+		timeout = defaultClusterStatusTimeout
+	}
+
+	contexts := cm.ListContexts()
+	if len(contexts) == 0 {
+		return "", errors.New("no clusters configured - use the load_kubeconfig tool first")
+	}
+
+	probes := make([]clusterProbe, len(contexts))
+	var wg sync.WaitGroup
+	for i, info := range contexts {
+		probes[i] = clusterProbe{context: info.Name, server: info.ServerURL}
+		wg.Add(1)
+		go func(p *clusterProbe) {
+			defer wg.Done()
+			probeCluster(ctx, cm, p, timeout)
+		}(&probes[i])
+	}
+	wg.Wait()
+
+	return formatClusterStatus(probes, kai.CurrentContext(ctx, cm)), nil
+}
+
+// probeCluster fills in p with the apiserver's version and latency, or the
+// reason it could not be reached within timeout.
+func probeCluster(ctx context.Context, cm kai.ClusterManager, p *clusterProbe, timeout time.Duration) {
+	client, err := cm.GetClient(p.context)
+	if err != nil {
+		p.err = err
+		return
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		info *version.Info
+		err  error
+	}
+	// ServerVersion takes no context, so wait on it separately; the client's
+	// own request timeout ends the abandoned call.
+	done := make(chan result, 1)
+	started := time.Now()
+	go func() {
+		info, err := client.Discovery().ServerVersion()
+		done <- result{info: info, err: err}
+	}()
+
+	select {
+	case <-probeCtx.Done():
+		p.latency = time.Since(started)
+		if ctx.Err() != nil {
+			p.err = ctx.Err()
+		} else {
+			p.err = fmt.Errorf("no response within %s", timeout)
+		}
+	case r := <-done:
+		p.latency = time.Since(started)
+		p.err = r.err
+		if r.info != nil {
+			p.version = r.info.GitVersion
+		}
+	}
+}
+
+func formatClusterStatus(probes []clusterProbe, current string) string {
+	reachable := 0
+	for _, p := range probes {
+		if p.err == nil {
+			reachable++
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Clusters (%d loaded, %d reachable):\n", len(probes), reachable)
+	for _, p := range probes {
+		name := p.context
+		if name == current {
+			name += " (active)"
+		}
+		latency := p.latency.Round(time.Millisecond)
+		if p.err != nil {
+			fmt.Fprintf(&sb, "• %s: unreachable after %s: %s", name, latency, p.err.Error())
+		} else {
+			fmt.Fprintf(&sb, "• %s: reachable in %s", name, latency)
+			if p.version != "" {
+				fmt.Fprintf(&sb, ", version %s", p.version)
+			}
+		}
+		if p.server != "" {
+			fmt.Fprintf(&sb, " (%s)", p.server)
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
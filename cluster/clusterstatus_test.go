@@ -0,0 +1,79 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/basebandit/kai"
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestHealthClusters(t *testing.T) {
+	ctx := context.Background()
+
+	reachable := fake.NewSimpleClientset()
+	reachable.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.30.2"}
+
+	unreachable := fake.NewSimpleClientset()
+	unreachable.PrependReactor("get", "version", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("dial tcp 10.0.0.9:6443: connect: connection refused")
+	})
+
+	hanging := fake.NewSimpleClientset()
+	release := make(chan struct{})
+	defer close(release)
+	hanging.PrependReactor("get", "version", func(k8stesting.Action) (bool, runtime.Object, error) {
+		<-release
+		return true, nil, nil
+	})
+
+	t.Run("ReachableAndUnreachable", func(t *testing.T) {
+		cm := testmocks.NewMockClusterManager()
+		cm.On("ListContexts").Return([]*kai.ContextInfo{
+			{Name: "prod", ServerURL: "https://prod.example.com"},
+			{Name: "staging", ServerURL: "https://staging.example.com"},
+		})
+		cm.On("GetCurrentContext").Return("prod")
+		cm.On("GetClient", "prod").Return(reachable, nil)
+		cm.On("GetClient", "staging").Return(unreachable, nil)
+
+		result, err := (&Health{}).Clusters(ctx, cm, time.Second)
+		require.NoError(t, err)
+		assert.Contains(t, result, "Clusters (2 loaded, 1 reachable):")
+		assert.Regexp(t, `• prod \(active\): reachable in \d+m?s, version v1\.30\.2 \(https://prod\.example\.com\)`, result)
+		assert.Contains(t, result, "connection refused (https://staging.example.com)")
+		assert.Contains(t, result, "• staging: unreachable after")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		cm := testmocks.NewMockClusterManager()
+		cm.On("ListContexts").Return([]*kai.ContextInfo{{Name: "prod"}, {Name: "edge"}})
+		cm.On("GetCurrentContext").Return("prod")
+		cm.On("GetClient", "prod").Return(reachable, nil)
+		cm.On("GetClient", "edge").Return(hanging, nil)
+
+		started := time.Now()
+		result, err := (&Health{}).Clusters(ctx, cm, 50*time.Millisecond)
+		require.NoError(t, err)
+		assert.Less(t, time.Since(started), time.Second)
+		assert.Contains(t, result, "Clusters (2 loaded, 1 reachable):")
+		assert.Contains(t, result, "no response within 50ms")
+	})
+
+	t.Run("NoClusters", func(t *testing.T) {
+		cm := testmocks.NewMockClusterManager()
+		cm.On("ListContexts").Return([]*kai.ContextInfo{})
+
+		_, err := (&Health{}).Clusters(ctx, cm, time.Second)
+		assert.EqualError(t, err, "no clusters configured - use the load_kubeconfig tool first")
+	})
+}
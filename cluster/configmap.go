@@ -2,6 +2,8 @@ package cluster
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -9,6 +11,7 @@ import (
 
 	"github.com/basebandit/kai"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 )
@@ -21,8 +24,23 @@ type ConfigMap struct {
 	BinaryData  map[string]interface{}
 	Labels      map[string]interface{}
 	Annotations map[string]interface{}
+	// Encoding controls how Get renders binary data values: base64 (the
+	// default), hex, or utf8 with invalid bytes replaced.
+	Encoding string
+	// DryRun has the API server validate Create and Update without
+	// persisting them.
+	DryRun bool
+	// ShowDiff appends the fields Update changed to its result.
+	ShowDiff bool
 }
 
+// Encodings for rendering ConfigMap binary data.
+const (
+	EncodingBase64 = "base64"
+	EncodingHex    = "hex"
+	EncodingUTF8   = "utf8"
+)
+
 // Create creates a new ConfigMap in the specified namespace.
 func (c *ConfigMap) Create(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
@@ -41,7 +59,7 @@ func (c *ConfigMap) Create(ctx context.Context, cm kai.ClusterManager) (string,
 		slog.String("namespace", c.Namespace),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for ConfigMap create",
 			slog.String("name", c.Name),
@@ -76,7 +94,11 @@ func (c *ConfigMap) Create(ctx context.Context, cm kai.ClusterManager) (string,
 	}
 
 	if c.BinaryData != nil {
-		configMap.BinaryData = convertToBinaryDataMap(c.BinaryData)
+		binaryData, err := convertToBinaryDataMap(c.BinaryData)
+		if err != nil {
+			return result, err
+		}
+		configMap.BinaryData = binaryData
 	}
 
 	if c.Labels != nil {
@@ -93,7 +115,7 @@ func (c *ConfigMap) Create(ctx context.Context, cm kai.ClusterManager) (string,
 		}
 	}
 
-	createdConfigMap, err := client.CoreV1().ConfigMaps(c.Namespace).Create(timeoutCtx, configMap, metav1.CreateOptions{})
+	createdConfigMap, err := client.CoreV1().ConfigMaps(c.Namespace).Create(timeoutCtx, configMap, metav1.CreateOptions{DryRun: dryRunOption(c.DryRun)})
 	if err != nil {
 		slog.Warn("failed to create ConfigMap",
 			slog.String("name", c.Name),
@@ -109,7 +131,7 @@ func (c *ConfigMap) Create(ctx context.Context, cm kai.ClusterManager) (string,
 	)
 
 	result = fmt.Sprintf("ConfigMap %q created successfully in namespace %q", createdConfigMap.Name, createdConfigMap.Namespace)
-	return result, nil
+	return dryRunResult(result, c.DryRun), nil
 }
 
 // Get retrieves a ConfigMap by name from the specified namespace.
@@ -121,7 +143,12 @@ func (c *ConfigMap) Get(ctx context.Context, cm kai.ClusterManager) (string, err
 		slog.String("namespace", c.Namespace),
 	)
 
-	client, err := cm.GetCurrentClient()
+	encoding, err := binaryEncoding(c.Encoding)
+	if err != nil {
+		return result, err
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for ConfigMap get",
 			slog.String("name", c.Name),
@@ -133,7 +160,7 @@ func (c *ConfigMap) Get(ctx context.Context, cm kai.ClusterManager) (string, err
 
 	var configMap *corev1.ConfigMap
 	err = retry.OnError(retry.DefaultRetry, func(err error) bool {
-		return !strings.Contains(err.Error(), "not found")
+		return !apierrors.IsNotFound(err)
 	}, func() error {
 		var getErr error
 		configMap, getErr = client.CoreV1().ConfigMaps(c.Namespace).Get(ctx, c.Name, metav1.GetOptions{})
@@ -141,23 +168,23 @@ func (c *ConfigMap) Get(ctx context.Context, cm kai.ClusterManager) (string, err
 	})
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if apierrors.IsNotFound(err) {
 			slog.Warn("ConfigMap not found",
 				slog.String("name", c.Name),
 				slog.String("namespace", c.Namespace),
 				slog.String("error", err.Error()),
 			)
-			return result, fmt.Errorf("ConfigMap %q not found in namespace %q", c.Name, c.Namespace)
+			return result, fmt.Errorf("ConfigMap %q not found in namespace %q: %w", c.Name, c.Namespace, err)
 		}
 		slog.Warn("failed to get ConfigMap",
 			slog.String("name", c.Name),
 			slog.String("namespace", c.Namespace),
 			slog.String("error", err.Error()),
 		)
-		return result, fmt.Errorf("failed to get ConfigMap %q: %v", c.Name, err)
+		return result, fmt.Errorf("failed to get ConfigMap %q: %w", c.Name, err)
 	}
 
-	return formatConfigMap(configMap), nil
+	return formatConfigMap(configMap, encoding), nil
 }
 
 // List retrieves all ConfigMaps matching the specified criteria.
@@ -170,7 +197,7 @@ func (c *ConfigMap) List(ctx context.Context, cm kai.ClusterManager, allNamespac
 		slog.String("label_selector", labelSelector),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for ConfigMap list",
 			slog.Bool("all_namespaces", allNamespaces),
@@ -233,7 +260,7 @@ func (c *ConfigMap) Delete(ctx context.Context, cm kai.ClusterManager) (string,
 		slog.String("namespace", c.Namespace),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for ConfigMap delete",
 			slog.String("name", c.Name),
@@ -292,7 +319,7 @@ func (c *ConfigMap) Update(ctx context.Context, cm kai.ClusterManager) (string,
 		slog.String("namespace", c.Namespace),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for ConfigMap update",
 			slog.String("name", c.Name),
@@ -315,12 +342,18 @@ func (c *ConfigMap) Update(ctx context.Context, cm kai.ClusterManager) (string,
 		return result, fmt.Errorf("ConfigMap %q not found in namespace %q: %w", c.Name, c.Namespace, err)
 	}
 
+	before := existingConfigMap.DeepCopy()
+
 	if c.Data != nil {
 		existingConfigMap.Data = convertToStringMap(c.Data)
 	}
 
 	if c.BinaryData != nil {
-		existingConfigMap.BinaryData = convertToBinaryDataMap(c.BinaryData)
+		binaryData, err := convertToBinaryDataMap(c.BinaryData)
+		if err != nil {
+			return result, err
+		}
+		existingConfigMap.BinaryData = binaryData
 	}
 
 	if c.Labels != nil {
@@ -337,7 +370,7 @@ func (c *ConfigMap) Update(ctx context.Context, cm kai.ClusterManager) (string,
 		}
 	}
 
-	updatedConfigMap, err := client.CoreV1().ConfigMaps(c.Namespace).Update(timeoutCtx, existingConfigMap, metav1.UpdateOptions{})
+	updatedConfigMap, err := client.CoreV1().ConfigMaps(c.Namespace).Update(timeoutCtx, existingConfigMap, metav1.UpdateOptions{DryRun: dryRunOption(c.DryRun)})
 	if err != nil {
 		slog.Warn("failed to update ConfigMap",
 			slog.String("name", c.Name),
@@ -353,7 +386,10 @@ func (c *ConfigMap) Update(ctx context.Context, cm kai.ClusterManager) (string,
 	)
 
 	result = fmt.Sprintf("ConfigMap %q updated successfully in namespace %q", updatedConfigMap.Name, updatedConfigMap.Namespace)
-	return result, nil
+	if c.ShowDiff {
+		result = withUpdateDiff(result, before, updatedConfigMap)
+	}
+	return dryRunResult(result, c.DryRun), nil
 }
 
 func (c *ConfigMap) validate() error {
@@ -366,21 +402,52 @@ func (c *ConfigMap) validate() error {
 	return nil
 }
 
-func convertToBinaryDataMap(input map[string]interface{}) map[string][]byte {
+// convertToBinaryDataMap converts binary data values to bytes. Strings, as
+// they arrive from tool arguments, must be base64 encoded.
+func convertToBinaryDataMap(input map[string]interface{}) (map[string][]byte, error) {
 	if input == nil {
-		return nil
+		return nil, nil
 	}
 
 	result := make(map[string][]byte, len(input))
 	for k, v := range input {
 		switch val := v.(type) {
 		case string:
-			result[k] = []byte(val)
+			decoded, err := base64.StdEncoding.DecodeString(val)
+			if err != nil {
+				return nil, fmt.Errorf("binary data key %q is not valid base64: %w", k, err)
+			}
+			result[k] = decoded
 		case []byte:
 			result[k] = val
 		default:
-			result[k] = []byte(fmt.Sprintf("%v", v))
+			return nil, fmt.Errorf("binary data key %q must be a base64 encoded string", k)
 		}
 	}
-	return result
+	return result, nil
+}
+
+// binaryEncoding validates a requested encoding, defaulting to base64.
+func binaryEncoding(encoding string) (string, error) {
+	switch strings.ToLower(encoding) {
+	case "", EncodingBase64:
+		return EncodingBase64, nil
+	case EncodingHex:
+		return EncodingHex, nil
+	case EncodingUTF8, "utf-8":
+		return EncodingUTF8, nil
+	}
+	return "", fmt.Errorf("invalid encoding %q: must be %s, %s or %s", encoding, EncodingBase64, EncodingHex, EncodingUTF8)
+}
+
+// encodeBinary renders a binary value as text in the given encoding.
+func encodeBinary(value []byte, encoding string) string {
+	switch encoding {
+	case EncodingHex:
+		return hex.EncodeToString(value)
+	case EncodingUTF8:
+		return strings.ToValidUTF8(string(value), "\uFFFD")
+	default:
+		return base64.StdEncoding.EncodeToString(value)
+	}
 }
@@ -140,6 +140,47 @@ func testCreateConfigMap(t *testing.T) {
 				assert.Equal(t, []byte{0xAA, 0xBB}, cm.BinaryData["data.bin"])
 			},
 		},
+		{
+			name: "Create ConfigMap with base64 binary data",
+			configMap: &ConfigMap{
+				Name:      "encoded-configmap",
+				Namespace: testNamespace,
+				BinaryData: map[string]interface{}{
+					"data.bin": "AQID",
+				},
+			},
+			setupMock: func(mockCM *testmocks.MockClusterManager) {
+				ns := &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: testNamespace},
+				}
+				fakeClient := fake.NewSimpleClientset(ns)
+				mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+			},
+			expectedResult: "ConfigMap \"encoded-configmap\" created successfully",
+			validateCreate: func(t *testing.T, client kubernetes.Interface) {
+				cm, err := client.CoreV1().ConfigMaps(testNamespace).Get(ctx, "encoded-configmap", metav1.GetOptions{})
+				assert.NoError(t, err)
+				assert.Equal(t, []byte{0x01, 0x02, 0x03}, cm.BinaryData["data.bin"])
+			},
+		},
+		{
+			name: "Invalid base64 binary data",
+			configMap: &ConfigMap{
+				Name:      "bad-configmap",
+				Namespace: testNamespace,
+				BinaryData: map[string]interface{}{
+					"data.bin": "not base64!",
+				},
+			},
+			setupMock: func(mockCM *testmocks.MockClusterManager) {
+				ns := &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: testNamespace},
+				}
+				fakeClient := fake.NewSimpleClientset(ns)
+				mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+			},
+			expectedError: "binary data key \"data.bin\" is not valid base64",
+		},
 		{
 			name: "Namespace not found",
 			configMap: &ConfigMap{
@@ -238,6 +279,38 @@ func testGetConfigMap(t *testing.T) {
 			},
 			expectedResult: "ConfigMap: test-configmap",
 		},
+		{
+			name: "Get binary data as hex",
+			configMap: &ConfigMap{
+				Name:      "binary-configmap",
+				Namespace: testNamespace,
+				Encoding:  "hex",
+			},
+			setupMock: func(mockCM *testmocks.MockClusterManager) {
+				existingCM := &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "binary-configmap",
+						Namespace: testNamespace,
+					},
+					BinaryData: map[string][]byte{
+						"data.bin": {0x00, 0xff, 0x10},
+					},
+				}
+				fakeClient := fake.NewSimpleClientset(existingCM)
+				mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+			},
+			expectedResult: "- data.bin: 00ff10 (3 bytes)",
+		},
+		{
+			name: "Invalid encoding",
+			configMap: &ConfigMap{
+				Name:      configMapName,
+				Namespace: testNamespace,
+				Encoding:  "base32",
+			},
+			setupMock:     func(mockCM *testmocks.MockClusterManager) {},
+			expectedError: `invalid encoding "base32": must be base64, hex or utf8`,
+		},
 		{
 			name: "ConfigMap not found",
 			configMap: &ConfigMap{
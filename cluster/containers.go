@@ -0,0 +1,96 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// buildContainers converts the container specs of a multi-container pod or
+// deployment. Every container needs a unique name and an image.
+func buildContainers(specs []kai.ContainerSpec) ([]corev1.Container, error) {
+	containers := make([]corev1.Container, 0, len(specs))
+	seen := make(map[string]bool, len(specs))
+	for i, spec := range specs {
+		if spec.Name == "" {
+			return nil, fmt.Errorf("container %d: name is required", i+1)
+		}
+		if seen[spec.Name] {
+			return nil, fmt.Errorf("container %q is defined more than once", spec.Name)
+		}
+		seen[spec.Name] = true
+		if spec.Image == "" {
+			return nil, fmt.Errorf("container %q: image is required", spec.Name)
+		}
+
+		container := corev1.Container{
+			Name:            spec.Name,
+			Image:           spec.Image,
+			Command:         convertToStringSlice(spec.Command),
+			Args:            convertToStringSlice(spec.Args),
+			ImagePullPolicy: corev1.PullPolicy(spec.ImagePullPolicy),
+		}
+
+		for _, p := range spec.Ports {
+			port, err := parseContainerPort(p)
+			if err != nil {
+				return nil, fmt.Errorf("container %q: %w", spec.Name, err)
+			}
+			container.Ports = append(container.Ports, port)
+		}
+
+		// Sorted so the pod template does not change between identical
+		// requests and trigger a rollout.
+		envNames := make([]string, 0, len(spec.Env))
+		for name := range spec.Env {
+			envNames = append(envNames, name)
+		}
+		sort.Strings(envNames)
+		for _, name := range envNames {
+			container.Env = append(container.Env, corev1.EnvVar{Name: name, Value: fmt.Sprintf("%v", spec.Env[name])})
+		}
+
+		var err error
+		if container.Resources.Requests, err = resourceList(spec.Requests); err != nil {
+			return nil, fmt.Errorf("container %q requests: %w", spec.Name, err)
+		}
+		if container.Resources.Limits, err = resourceList(spec.Limits); err != nil {
+			return nil, fmt.Errorf("container %q limits: %w", spec.Name, err)
+		}
+
+		containers = append(containers, container)
+	}
+	return containers, nil
+}
+
+// containerResources parses the requests and limits of a single container.
+func containerResources(requests, limits map[string]interface{}) (corev1.ResourceRequirements, error) {
+	var resources corev1.ResourceRequirements
+	var err error
+	if resources.Requests, err = resourceList(requests); err != nil {
+		return resources, fmt.Errorf("requests: %w", err)
+	}
+	if resources.Limits, err = resourceList(limits); err != nil {
+		return resources, fmt.Errorf("limits: %w", err)
+	}
+	return resources, nil
+}
+
+// resourceList parses resource quantities such as {"cpu": "100m"}.
+func resourceList(input map[string]interface{}) (corev1.ResourceList, error) {
+	if len(input) == 0 {
+		return nil, nil
+	}
+	list := make(corev1.ResourceList, len(input))
+	for name, value := range input {
+		q, err := resource.ParseQuantity(fmt.Sprintf("%v", value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity %v for %s", value, name)
+		}
+		list[corev1.ResourceName(name)] = q
+	}
+	return list, nil
+}
@@ -0,0 +1,187 @@
+package cluster
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/basebandit/kai"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+// copyTimeout bounds a copy into or out of a pod.
+const copyTimeout = 2 * time.Minute
+
+// MaxCopyBytes caps how much CopyTo writes and CopyFrom reads, since the
+// content travels base64-encoded inside a single tool call.
+const MaxCopyBytes = 4 << 20
+
+// errCopyTooLarge stops the tar stream once CopyFrom has read MaxCopyBytes.
+var errCopyTooLarge = errors.New("copy limit reached")
+
+// CopyTo writes data to destPath in a container of the pod, like kubectl
+// cp, by streaming a tar archive into tar running in the container. The
+// container image must include tar. Missing parent directories are not
+// created.
+func (p *Pod) CopyTo(ctx context.Context, cm kai.ClusterManager, container string, data []byte, destPath string) (string, error) {
+	if destPath == "" {
+		return "", errors.New("destination path is required")
+	}
+	name := path.Base(destPath)
+	if strings.HasSuffix(destPath, "/") || name == "." || name == "/" {
+		return "", fmt.Errorf("destination path %q must name a file", destPath)
+	}
+	if len(data) > MaxCopyBytes {
+		return "", fmt.Errorf("content is %d bytes, over the %d-byte copy limit", len(data), MaxCopyBytes)
+	}
+
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: time.Now()}); err != nil {
+		return "", fmt.Errorf("failed to build archive: %w", err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return "", fmt.Errorf("failed to build archive: %w", err)
+	}
+	if err := tw.Close(); err != nil {
+		return "", fmt.Errorf("failed to build archive: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, copyTimeout)
+	defer cancel()
+
+	command := []string{"tar", "-xmf", "-", "-C", path.Dir(destPath)}
+	executor, container, err := p.execExecutor(timeoutCtx, cm, container, command, true)
+	if err != nil {
+		return "", err
+	}
+
+	var stderr bytes.Buffer
+	err = executor.StreamWithContext(timeoutCtx, remotecommand.StreamOptions{Stdin: &archive, Stdout: io.Discard, Stderr: &stderr})
+	if err != nil {
+		return "", copyError(fmt.Sprintf("failed to copy to %s in %s/%s", destPath, p.Name, container), container, err, stderr.String())
+	}
+
+	return fmt.Sprintf("Copied %d bytes to %s in %s/%s", len(data), destPath, p.Name, container), nil
+}
+
+// CopyFrom reads srcPath, a file or a directory, from a container of the
+// pod through tar running in the container, and returns each regular file
+// in it base64-encoded. Symlinks and other special files are skipped.
+func (p *Pod) CopyFrom(ctx context.Context, cm kai.ClusterManager, container, srcPath string) (string, error) {
+	if srcPath == "" {
+		return "", errors.New("source path is required")
+	}
+	srcPath = path.Clean(srcPath)
+	dir, name := path.Dir(srcPath), path.Base(srcPath)
+	if name == "/" {
+		return "", errors.New("source path must not be the root directory")
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, copyTimeout)
+	defer cancel()
+
+	command := []string{"tar", "-cf", "-", "-C", dir, name}
+	executor, container, err := p.execExecutor(timeoutCtx, cm, container, command, false)
+	if err != nil {
+		return "", err
+	}
+
+	var archive, stderr bytes.Buffer
+	stdout := &limitedWriter{w: &archive, remaining: MaxCopyBytes}
+	err = executor.StreamWithContext(timeoutCtx, remotecommand.StreamOptions{Stdout: stdout, Stderr: &stderr})
+	if stdout.exceeded {
+		return "", fmt.Errorf("%s in %s/%s is over the %d-byte copy limit", srcPath, p.Name, container, MaxCopyBytes)
+	}
+	if err != nil {
+		return "", copyError(fmt.Sprintf("failed to copy %s from %s/%s", srcPath, p.Name, container), container, err, stderr.String())
+	}
+
+	var (
+		files   strings.Builder
+		count   int
+		total   int64
+		skipped int
+	)
+	tr := tar.NewReader(&archive)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read archive of %s: %w", srcPath, err)
+		}
+		switch header.Typeflag {
+		case tar.TypeReg:
+		case tar.TypeDir:
+			continue
+		default:
+			skipped++
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s from archive: %w", header.Name, err)
+		}
+		count++
+		total += int64(len(content))
+		fmt.Fprintf(&files, "\nFile: %s (%d bytes)\n%s\n", path.Join(dir, header.Name), len(content), base64.StdEncoding.EncodeToString(content))
+	}
+
+	if count == 0 {
+		return fmt.Sprintf("No regular files found at %s in %s/%s", srcPath, p.Name, container), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Copied %d file(s), %d bytes, from %s in %s/%s; content is base64-encoded\n", count, total, srcPath, p.Name, container)
+	if skipped > 0 {
+		fmt.Fprintf(&sb, "Skipped %d entries that are not regular files (symlinks, devices)\n", skipped)
+	}
+	sb.WriteString(files.String())
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// copyError explains a failed tar run, calling out a container without tar
+// since copying depends on it.
+func copyError(action, container string, err error, stderr string) error {
+	var exitErr exec.ExitError
+	exitCode := 0
+	if errors.As(err, &exitErr) {
+		exitCode = exitErr.ExitStatus()
+	}
+	if exitCode == 126 || exitCode == 127 ||
+		strings.Contains(err.Error(), "executable file not found") ||
+		strings.Contains(stderr, "tar: not found") {
+		return fmt.Errorf("%s: container %q has no tar binary, which copying requires; use an image that includes tar", action, container)
+	}
+	if exitCode != 0 && strings.TrimSpace(stderr) != "" {
+		return fmt.Errorf("%s: %s", action, strings.TrimSpace(stderr))
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
+// limitedWriter fails with errCopyTooLarge once more than remaining bytes
+// are written, which aborts the stream feeding it.
+type limitedWriter struct {
+	w         io.Writer
+	remaining int
+	exceeded  bool
+}
+
+func (l *limitedWriter) Write(b []byte) (int, error) {
+	if len(b) > l.remaining {
+		l.exceeded = true
+		return 0, errCopyTooLarge
+	}
+	l.remaining -= len(b)
+	return l.w.Write(b)
+}
@@ -0,0 +1,123 @@
+package cluster
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/exec"
+)
+
+func TestPodCopy(t *testing.T) {
+	ctx := context.Background()
+
+	newCM := func() *testmocks.MockClusterManager {
+		clientset := fake.NewSimpleClientset(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: execPodName, Namespace: testNamespace},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		})
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(clientset, nil)
+		mockCM.On("GetCurrentRESTConfig").Return(&rest.Config{Host: "https://cluster.example:6443"}, nil)
+		return mockCM
+	}
+	pod := &Pod{Name: execPodName, Namespace: testNamespace}
+
+	// archive builds the tar stream tar -cf would write for headers, with
+	// content for each regular file.
+	archive := func(t *testing.T, entries ...*tar.Header) string {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, header := range entries {
+			content := strings.Repeat("x", int(header.Size))
+			require.NoError(t, tw.WriteHeader(header))
+			_, err := tw.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		return buf.String()
+	}
+
+	t.Run("CopyToStreamsArchive", func(t *testing.T) {
+		executor := &fakeExecutor{}
+		requested := stubPodExecutor(t, executor)
+
+		result, err := pod.CopyTo(ctx, newCM(), "", []byte("listen 8080;\n"), "/etc/nginx/conf.d/app.conf")
+		require.NoError(t, err)
+		assert.Equal(t, `Copied 13 bytes to /etc/nginx/conf.d/app.conf in test-pod/nginx`, result)
+
+		query := requested.Query()
+		assert.Equal(t, []string{"tar", "-xmf", "-", "-C", "/etc/nginx/conf.d"}, query["command"])
+		assert.Equal(t, "true", query.Get("stdin"))
+
+		tr := tar.NewReader(&executor.stdin)
+		header, err := tr.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "app.conf", header.Name)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		assert.Equal(t, "listen 8080;\n", string(content))
+	})
+
+	t.Run("CopyToRequiresFileName", func(t *testing.T) {
+		_, err := pod.CopyTo(ctx, newCM(), "", []byte("x"), "/tmp/")
+		assert.EqualError(t, err, `destination path "/tmp/" must name a file`)
+	})
+
+	t.Run("CopyToWithoutTar", func(t *testing.T) {
+		stubPodExecutor(t, &fakeExecutor{
+			err: errors.New(`OCI runtime exec failed: exec failed: unable to start container process: exec: "tar": executable file not found in $PATH: unknown`),
+		})
+		_, err := pod.CopyTo(ctx, newCM(), "", []byte("x"), "/tmp/x")
+		assert.EqualError(t, err, `failed to copy to /tmp/x in test-pod/nginx: container "nginx" has no tar binary, which copying requires; use an image that includes tar`)
+	})
+
+	t.Run("CopyFromReturnsBase64Files", func(t *testing.T) {
+		requested := stubPodExecutor(t, &fakeExecutor{stdout: archive(t,
+			&tar.Header{Name: "nginx/", Typeflag: tar.TypeDir, Mode: 0o755},
+			&tar.Header{Name: "nginx/nginx.conf", Typeflag: tar.TypeReg, Mode: 0o644, Size: 3},
+			&tar.Header{Name: "nginx/current", Typeflag: tar.TypeSymlink, Linkname: "nginx.conf"},
+		)})
+
+		result, err := pod.CopyFrom(ctx, newCM(), "", "/etc/nginx/")
+		require.NoError(t, err)
+		assert.Contains(t, result, "Copied 1 file(s), 3 bytes, from /etc/nginx in test-pod/nginx; content is base64-encoded")
+		assert.Contains(t, result, "Skipped 1 entries that are not regular files")
+		assert.Contains(t, result, "File: /etc/nginx/nginx.conf (3 bytes)\n"+base64.StdEncoding.EncodeToString([]byte("xxx")))
+		assert.Equal(t, []string{"tar", "-cf", "-", "-C", "/etc", "nginx"}, requested.Query()["command"])
+	})
+
+	t.Run("CopyFromMissingPath", func(t *testing.T) {
+		stubPodExecutor(t, &fakeExecutor{
+			stderr: "tar: missing.conf: No such file or directory\n",
+			err:    exec.CodeExitError{Err: errors.New("command terminated with exit code 2"), Code: 2},
+		})
+		_, err := pod.CopyFrom(ctx, newCM(), "nginx", "/etc/missing.conf")
+		assert.EqualError(t, err, "failed to copy /etc/missing.conf from test-pod/nginx: tar: missing.conf: No such file or directory")
+	})
+
+	t.Run("CopyFromWithoutTar", func(t *testing.T) {
+		stubPodExecutor(t, &fakeExecutor{err: exec.CodeExitError{Err: errors.New("command terminated with exit code 127"), Code: 127}})
+		_, err := pod.CopyFrom(ctx, newCM(), "", "/etc/hostname")
+		assert.ErrorContains(t, err, `container "nginx" has no tar binary`)
+	})
+
+	t.Run("CopyFromOverLimit", func(t *testing.T) {
+		stubPodExecutor(t, &fakeExecutor{stdout: strings.Repeat("x", MaxCopyBytes+1)})
+		_, err := pod.CopyFrom(ctx, newCM(), "", "/var/log")
+		assert.ErrorContains(t, err, "/var/log in test-pod/nginx is over the 4194304-byte copy limit")
+	})
+}
@@ -5,11 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"strings"
 
 	"github.com/basebandit/kai"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 )
@@ -33,6 +33,8 @@ type CronJob struct {
 	Env                        map[string]interface{}
 	ImagePullPolicy            string
 	ImagePullSecrets           []interface{}
+	DryRun                     bool
+	ShowDiff                   bool
 }
 
 // Create creates a new CronJob in the specified namespace.
@@ -53,7 +55,7 @@ func (c *CronJob) Create(ctx context.Context, cm kai.ClusterManager) (string, er
 		slog.String("namespace", c.Namespace),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for CronJob create",
 			slog.String("name", c.Name),
@@ -161,7 +163,7 @@ func (c *CronJob) Create(ctx context.Context, cm kai.ClusterManager) (string, er
 		cronJob.Spec.JobTemplate.Spec.BackoffLimit = c.BackoffLimit
 	}
 
-	createdCronJob, err := client.BatchV1().CronJobs(c.Namespace).Create(timeoutCtx, cronJob, metav1.CreateOptions{})
+	createdCronJob, err := client.BatchV1().CronJobs(c.Namespace).Create(timeoutCtx, cronJob, metav1.CreateOptions{DryRun: dryRunOption(c.DryRun)})
 	if err != nil {
 		slog.Warn("failed to create CronJob",
 			slog.String("name", c.Name),
@@ -178,7 +180,7 @@ func (c *CronJob) Create(ctx context.Context, cm kai.ClusterManager) (string, er
 	)
 
 	result = fmt.Sprintf("CronJob %q created successfully in namespace %q with schedule %q", createdCronJob.Name, createdCronJob.Namespace, createdCronJob.Spec.Schedule)
-	return result, nil
+	return dryRunResult(result, c.DryRun), nil
 }
 
 // Get retrieves a CronJob by name from the specified namespace.
@@ -190,7 +192,7 @@ func (c *CronJob) Get(ctx context.Context, cm kai.ClusterManager) (string, error
 		slog.String("namespace", c.Namespace),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for CronJob get",
 			slog.String("name", c.Name),
@@ -202,7 +204,7 @@ func (c *CronJob) Get(ctx context.Context, cm kai.ClusterManager) (string, error
 
 	var cronJob *batchv1.CronJob
 	err = retry.OnError(retry.DefaultRetry, func(err error) bool {
-		return !strings.Contains(err.Error(), "not found")
+		return !apierrors.IsNotFound(err)
 	}, func() error {
 		var getErr error
 		cronJob, getErr = client.BatchV1().CronJobs(c.Namespace).Get(ctx, c.Name, metav1.GetOptions{})
@@ -210,20 +212,20 @@ func (c *CronJob) Get(ctx context.Context, cm kai.ClusterManager) (string, error
 	})
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if apierrors.IsNotFound(err) {
 			slog.Warn("CronJob not found",
 				slog.String("name", c.Name),
 				slog.String("namespace", c.Namespace),
 				slog.String("error", err.Error()),
 			)
-			return result, fmt.Errorf("CronJob %q not found in namespace %q", c.Name, c.Namespace)
+			return result, fmt.Errorf("CronJob %q not found in namespace %q: %w", c.Name, c.Namespace, err)
 		}
 		slog.Warn("failed to get CronJob",
 			slog.String("name", c.Name),
 			slog.String("namespace", c.Namespace),
 			slog.String("error", err.Error()),
 		)
-		return result, fmt.Errorf("failed to get CronJob %q: %v", c.Name, err)
+		return result, fmt.Errorf("failed to get CronJob %q: %w", c.Name, err)
 	}
 
 	return formatCronJob(cronJob), nil
@@ -239,7 +241,7 @@ func (c *CronJob) List(ctx context.Context, cm kai.ClusterManager, allNamespaces
 		slog.String("label_selector", labelSelector),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for CronJob list",
 			slog.Bool("all_namespaces", allNamespaces),
@@ -302,7 +304,7 @@ func (c *CronJob) Delete(ctx context.Context, cm kai.ClusterManager) (string, er
 		slog.String("namespace", c.Namespace),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for CronJob delete",
 			slog.String("name", c.Name),
@@ -357,7 +359,7 @@ func (c *CronJob) Update(ctx context.Context, cm kai.ClusterManager) (string, er
 		return result, errors.New("CronJob name is required")
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -370,6 +372,8 @@ func (c *CronJob) Update(ctx context.Context, cm kai.ClusterManager) (string, er
 		return result, fmt.Errorf("failed to get CronJob: %w", err)
 	}
 
+	before := cronJob.DeepCopy()
+
 	if len(c.Labels) > 0 {
 		if cronJob.Labels == nil {
 			cronJob.Labels = make(map[string]string)
@@ -395,13 +399,16 @@ func (c *CronJob) Update(ctx context.Context, cm kai.ClusterManager) (string, er
 		cronJob.Spec.FailedJobsHistoryLimit = c.FailedJobsHistoryLimit
 	}
 
-	updatedCronJob, err := client.BatchV1().CronJobs(c.Namespace).Update(timeoutCtx, cronJob, metav1.UpdateOptions{})
+	updatedCronJob, err := client.BatchV1().CronJobs(c.Namespace).Update(timeoutCtx, cronJob, metav1.UpdateOptions{DryRun: dryRunOption(c.DryRun)})
 	if err != nil {
 		return result, fmt.Errorf("failed to update CronJob: %w", err)
 	}
 
 	result = fmt.Sprintf("CronJob %q updated successfully in namespace %q", updatedCronJob.Name, updatedCronJob.Namespace)
-	return result, nil
+	if c.ShowDiff {
+		result = withUpdateDiff(result, before, updatedCronJob)
+	}
+	return dryRunResult(result, c.DryRun), nil
 }
 
 // SetSuspended sets the suspend state of a CronJob
@@ -412,7 +419,7 @@ func (c *CronJob) SetSuspended(ctx context.Context, cm kai.ClusterManager, suspe
 		return result, errors.New("CronJob name is required")
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
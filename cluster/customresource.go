@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/basebandit/kai"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -28,32 +29,52 @@ type CustomResource struct {
 	Namespace string
 }
 
-// ListCRDs lists all CustomResourceDefinitions registered in the cluster.
+// ListCRDs lists the CustomResourceDefinitions registered in the cluster with
+// their served versions and scope. When Group is set, only CRDs in that API
+// group are listed.
 func (c *CustomResource) ListCRDs(ctx context.Context, cm kai.ClusterManager) (string, error) {
-	dyn, err := cm.GetCurrentDynamicClient()
+	client, err := kai.CurrentAPIExtensionsClient(ctx, cm)
 	if err != nil {
-		return "", fmt.Errorf("error getting dynamic client: %w", err)
+		return "", fmt.Errorf("error getting apiextensions client: %w", err)
 	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
 	defer cancel()
 
-	list, err := dyn.Resource(crdGVR).List(timeoutCtx, metav1.ListOptions{})
+	list, err := client.ApiextensionsV1().CustomResourceDefinitions().List(timeoutCtx, metav1.ListOptions{})
 	if err != nil {
 		return "", fmt.Errorf("failed to list CRDs: %w", err)
 	}
-	if len(list.Items) == 0 {
+
+	var crds []apiextensionsv1.CustomResourceDefinition
+	for _, crd := range list.Items {
+		if c.Group == "" || crd.Spec.Group == c.Group {
+			crds = append(crds, crd)
+		}
+	}
+	if len(crds) == 0 {
+		if c.Group != "" {
+			return fmt.Sprintf("No custom resource definitions found in group %q", c.Group), nil
+		}
 		return "No custom resource definitions found", nil
 	}
+	sort.Slice(crds, func(i, j int) bool { return crds[i].Name < crds[j].Name })
 
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "Custom Resource Definitions (%d):\n", len(list.Items))
-	for i := range list.Items {
-		item := list.Items[i]
-		group, _, _ := unstructured.NestedString(item.Object, "spec", "group")
-		scope, _, _ := unstructured.NestedString(item.Object, "spec", "scope")
-		kind, _, _ := unstructured.NestedString(item.Object, "spec", "names", "kind")
-		fmt.Fprintf(&sb, "• %s\tgroup: %s\tkind: %s\tscope: %s\n", item.GetName(), group, kind, scope)
+	fmt.Fprintf(&sb, "Custom Resource Definitions (%d):\n", len(crds))
+	for _, crd := range crds {
+		var served []string
+		for _, v := range crd.Spec.Versions {
+			if v.Served {
+				served = append(served, v.Name)
+			}
+		}
+		versions := strings.Join(served, ", ")
+		if versions == "" {
+			versions = "<none>"
+		}
+		fmt.Fprintf(&sb, "• %s\tgroup: %s\tkind: %s\tversions: %s\tscope: %s\n",
+			crd.Name, crd.Spec.Group, crd.Spec.Names.Kind, versions, crd.Spec.Scope)
 	}
 	return strings.TrimRight(sb.String(), "\n"), nil
 }
@@ -63,7 +84,7 @@ func (c *CustomResource) GetCRD(ctx context.Context, cm kai.ClusterManager) (str
 	if c.Name == "" {
 		return "", fmt.Errorf("CRD name is required")
 	}
-	dyn, err := cm.GetCurrentDynamicClient()
+	dyn, err := kai.CurrentDynamicClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting dynamic client: %w", err)
 	}
@@ -108,7 +129,7 @@ func (c *CustomResource) List(ctx context.Context, cm kai.ClusterManager, allNam
 	if c.Version == "" || c.Resource == "" {
 		return "", fmt.Errorf("version and resource are required")
 	}
-	dyn, err := cm.GetCurrentDynamicClient()
+	dyn, err := kai.CurrentDynamicClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting dynamic client: %w", err)
 	}
@@ -124,7 +145,7 @@ func (c *CustomResource) List(ctx context.Context, cm kai.ClusterManager, allNam
 	} else {
 		ns := c.Namespace
 		if ns == "" {
-			ns = cm.GetCurrentNamespace()
+			ns = kai.CurrentNamespace(ctx, cm)
 		}
 		list, err = dyn.Resource(gvr).Namespace(ns).List(timeoutCtx, metav1.ListOptions{})
 	}
@@ -153,7 +174,7 @@ func (c *CustomResource) Get(ctx context.Context, cm kai.ClusterManager) (string
 	if c.Version == "" || c.Resource == "" || c.Name == "" {
 		return "", fmt.Errorf("version, resource and name are required")
 	}
-	dyn, err := cm.GetCurrentDynamicClient()
+	dyn, err := kai.CurrentDynamicClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting dynamic client: %w", err)
 	}
@@ -169,7 +190,7 @@ func (c *CustomResource) Get(ctx context.Context, cm kai.ClusterManager) (string
 	)
 	ns := c.Namespace
 	if ns == "" {
-		ns = cm.GetCurrentNamespace()
+		ns = kai.CurrentNamespace(ctx, cm)
 	}
 	obj, getErr = dyn.Resource(gvr).Namespace(ns).Get(timeoutCtx, c.Name, metav1.GetOptions{})
 	if getErr != nil {
@@ -207,7 +228,7 @@ func (c *CustomResource) Delete(ctx context.Context, cm kai.ClusterManager) (str
 	if c.Version == "" || c.Resource == "" || c.Name == "" {
 		return "", fmt.Errorf("version, resource and name are required")
 	}
-	dyn, err := cm.GetCurrentDynamicClient()
+	dyn, err := kai.CurrentDynamicClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting dynamic client: %w", err)
 	}
@@ -219,7 +240,7 @@ func (c *CustomResource) Delete(ctx context.Context, cm kai.ClusterManager) (str
 
 	ns := c.Namespace
 	if ns == "" {
-		ns = cm.GetCurrentNamespace()
+		ns = kai.CurrentNamespace(ctx, cm)
 	}
 	delErr := dyn.Resource(gvr).Namespace(ns).Delete(timeoutCtx, c.Name, metav1.DeleteOptions{})
 	if delErr != nil {
@@ -233,7 +254,7 @@ func (c *CustomResource) Delete(ctx context.Context, cm kai.ClusterManager) (str
 
 // ListAPIResources lists the server's preferred API resources (discovery).
 func (c *CustomResource) ListAPIResources(ctx context.Context, cm kai.ClusterManager) (string, error) {
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting client: %w", err)
 	}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/basebandit/kai/testmocks"
 	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -68,11 +70,6 @@ func TestCustomResourceCRDs(t *testing.T) {
 	mockCM := testmocks.NewMockClusterManager()
 	mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
 
-	list, err := (&CustomResource{}).ListCRDs(ctx, mockCM)
-	assert.NoError(t, err)
-	assert.Contains(t, list, "widgets.example.com")
-	assert.Contains(t, list, "Widget")
-
 	get, err := (&CustomResource{Name: "widgets.example.com"}).GetCRD(ctx, mockCM)
 	assert.NoError(t, err)
 	assert.Contains(t, get, "Group: example.com")
@@ -82,6 +79,58 @@ func TestCustomResourceCRDs(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func apiextCRD(name, group, kind string, scope apiextensionsv1.ResourceScope, versions ...apiextensionsv1.CustomResourceDefinitionVersion) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group:    group,
+			Scope:    scope,
+			Names:    apiextensionsv1.CustomResourceDefinitionNames{Kind: kind},
+			Versions: versions,
+		},
+	}
+}
+
+func TestCustomResourceListCRDs(t *testing.T) {
+	ctx := context.Background()
+
+	client := apiextensionsfake.NewSimpleClientset(
+		apiextCRD("widgets.example.com", "example.com", "Widget", apiextensionsv1.NamespaceScoped,
+			apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true},
+			apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1beta1", Served: true},
+			apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1alpha1", Served: false},
+		),
+		apiextCRD("certificates.cert-manager.io", "cert-manager.io", "Certificate", apiextensionsv1.ClusterScoped,
+			apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true},
+		),
+	)
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentAPIExtensionsClient").Return(client, nil)
+
+	t.Run("All", func(t *testing.T) {
+		list, err := (&CustomResource{}).ListCRDs(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, list, "Custom Resource Definitions (2)")
+		assert.Contains(t, list, "• widgets.example.com\tgroup: example.com\tkind: Widget\tversions: v1, v1beta1\tscope: Namespaced")
+		assert.Contains(t, list, "• certificates.cert-manager.io\tgroup: cert-manager.io\tkind: Certificate\tversions: v1\tscope: Cluster")
+		assert.NotContains(t, list, "v1alpha1")
+	})
+
+	t.Run("GroupFilter", func(t *testing.T) {
+		list, err := (&CustomResource{Group: "cert-manager.io"}).ListCRDs(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, list, "Custom Resource Definitions (1)")
+		assert.Contains(t, list, "certificates.cert-manager.io")
+		assert.NotContains(t, list, "widgets.example.com")
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		list, err := (&CustomResource{Group: "nothing.io"}).ListCRDs(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Equal(t, `No custom resource definitions found in group "nothing.io"`, list)
+	})
+}
+
 func TestCustomResourceInstances(t *testing.T) {
 	ctx := context.Background()
 
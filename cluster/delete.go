@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 )
 
@@ -39,40 +40,44 @@ func (d *Delete) Run(ctx context.Context, cm kai.ClusterManager) (string, error)
 		return "", errors.New("no kubernetes objects found in manifest")
 	}
 
-	client, err := cm.GetCurrentClient()
+	mapper, err := kai.CurrentRESTMapper(ctx, cm)
 	if err != nil {
-		return "", fmt.Errorf("error getting client: %w", err)
+		return "", fmt.Errorf("error getting REST mapper: %w", err)
 	}
-	dyn, err := cm.GetCurrentDynamicClient()
+	dyn, err := kai.CurrentDynamicClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting dynamic client: %w", err)
 	}
 
-	mapper, err := newRESTMapper(client.Discovery())
-	if err != nil {
-		return "", fmt.Errorf("failed to build REST mapper: %w", err)
-	}
-
-	var sb strings.Builder
-	fmt.Fprintf(&sb, "Deleted %d object(s):\n", len(objs))
+	lines := make([]string, 0, len(objs))
+	skipped := 0
 	for _, obj := range objs {
-		line, err := deleteObject(ctx, dyn, mapper, obj, d.Namespace, cm)
+		line, deleted, err := deleteObject(ctx, dyn, mapper, obj, d.Namespace, cm)
 		if err != nil {
 			return "", err
 		}
-		fmt.Fprintf(&sb, "• %s\n", line)
+		if !deleted {
+			skipped++
+		}
+		lines = append(lines, "• "+line)
 	}
-	return strings.TrimRight(sb.String(), "\n"), nil
+
+	header := fmt.Sprintf("Deleted %d object(s):", len(objs)-skipped)
+	if skipped > 0 {
+		header = fmt.Sprintf("Deleted %d object(s), skipped %d already absent:", len(objs)-skipped, skipped)
+	}
+	return header + "\n" + strings.Join(lines, "\n"), nil
 }
 
 // deleteObject resolves an object's GVK to a resource via the mapper and deletes
-// it, honoring namespace scope. A missing object is reported, not treated as an
-// error, so deleting an already-gone manifest is idempotent.
-func deleteObject(ctx context.Context, dyn dynamic.Interface, mapper meta.RESTMapper, obj *unstructured.Unstructured, nsOverride string, cm kai.ClusterManager) (string, error) {
+// it, honoring namespace scope. A missing object is reported as skipped
+// (deleted is false), not treated as an error, so deleting an already-gone
+// manifest is idempotent.
+func deleteObject(ctx context.Context, dyn dynamic.Interface, mapper meta.RESTMapper, obj *unstructured.Unstructured, nsOverride string, cm kai.ClusterManager) (line string, deleted bool, err error) {
 	gvk := obj.GroupVersionKind()
 	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 	if err != nil {
-		return "", fmt.Errorf("unable to resolve %s/%s: %w", gvk.GroupVersion().String(), gvk.Kind, err)
+		return "", false, fmt.Errorf("unable to resolve %s/%s: %w", gvk.GroupVersion().String(), gvk.Kind, err)
 	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
@@ -88,7 +93,7 @@ func deleteObject(ctx context.Context, dyn dynamic.Interface, mapper meta.RESTMa
 			if nsOverride != "" {
 				ns = nsOverride
 			} else {
-				ns = cm.GetCurrentNamespace()
+				ns = kai.CurrentNamespace(ctx, cm)
 			}
 		}
 		ri = dyn.Resource(mapping.Resource).Namespace(ns)
@@ -100,10 +105,110 @@ func deleteObject(ctx context.Context, dyn dynamic.Interface, mapper meta.RESTMa
 	name := obj.GetName()
 	err = ri.Delete(timeoutCtx, name, metav1.DeleteOptions{})
 	if apierrors.IsNotFound(err) {
-		return fmt.Sprintf("%s %s%s not found (already deleted)", gvk.Kind, prefix, name), nil
+		return fmt.Sprintf("%s %s%s not found (already deleted)", gvk.Kind, prefix, name), false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to delete %s %q: %w", gvk.Kind, name, err)
+	}
+	return fmt.Sprintf("%s %s%s deleted", gvk.Kind, prefix, name), true, nil
+}
+
+// DeleteResource removes a single object of any kind, identified by kind (or
+// resource name), optional group/version, and name. It is the by-reference
+// counterpart of Delete, which works from a manifest.
+type DeleteResource struct {
+	Kind    string
+	Group   string
+	Version string // optional; the preferred version is used when empty
+	Name    string
+	// Namespace is used for namespaced kinds, defaulting to the current
+	// namespace. It is ignored for cluster-scoped kinds.
+	Namespace          string
+	IgnoreNotFound     bool
+	GracePeriodSeconds *int64
+	Propagation        string // Foreground, Background or Orphan; empty uses the server default
+}
+
+// Run resolves the kind through the REST mapper and deletes the object via
+// the dynamic client.
+func (d *DeleteResource) Run(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if d.Kind == "" {
+		return "", errors.New("kind is required")
+	}
+	if d.Name == "" {
+		return "", errors.New("name is required")
+	}
+
+	opts := metav1.DeleteOptions{GracePeriodSeconds: d.GracePeriodSeconds}
+	if d.Propagation != "" {
+		policy := metav1.DeletionPropagation(d.Propagation)
+		switch policy {
+		case metav1.DeletePropagationForeground, metav1.DeletePropagationBackground, metav1.DeletePropagationOrphan:
+			opts.PropagationPolicy = &policy
+		default:
+			return "", fmt.Errorf("invalid propagation %q: must be Foreground, Background or Orphan", d.Propagation)
+		}
+	}
+
+	mapper, err := kai.CurrentRESTMapper(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting REST mapper: %w", err)
+	}
+	dyn, err := kai.CurrentDynamicClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting dynamic client: %w", err)
+	}
+
+	mapping, err := resolveMapping(mapper, d.Group, d.Version, d.Kind)
+	if err != nil {
+		return "", err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	var (
+		ri     dynamic.ResourceInterface
+		prefix string
+	)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		ns := d.Namespace
+		if ns == "" {
+			ns = kai.CurrentNamespace(ctx, cm)
+		}
+		ri = dyn.Resource(mapping.Resource).Namespace(ns)
+		prefix = ns + "/"
+	} else {
+		ri = dyn.Resource(mapping.Resource)
+	}
+
+	kind := mapping.GroupVersionKind.Kind
+	err = ri.Delete(timeoutCtx, d.Name, opts)
+	if apierrors.IsNotFound(err) && d.IgnoreNotFound {
+		return fmt.Sprintf("%s %s%s not found (ignored)", kind, prefix, d.Name), nil
 	}
 	if err != nil {
-		return "", fmt.Errorf("failed to delete %s %q: %w", gvk.Kind, name, err)
+		return "", fmt.Errorf("failed to delete %s %s%s: %w", kind, prefix, d.Name, err)
+	}
+	return fmt.Sprintf("%s %s%s deleted", kind, prefix, d.Name), nil
+}
+
+// resolveMapping finds the REST mapping for a kind such as "Deployment", or
+// for a resource name such as "deployments" when no kind matches.
+func resolveMapping(mapper meta.RESTMapper, group, version, kind string) (*meta.RESTMapping, error) {
+	var versions []string
+	if version != "" {
+		versions = append(versions, version)
+	}
+
+	mapping, err := mapper.RESTMapping(schema.GroupKind{Group: group, Kind: kind}, versions...)
+	if err == nil {
+		return mapping, nil
+	}
+
+	gvk, resErr := mapper.KindFor(schema.GroupVersionResource{Group: group, Version: version, Resource: strings.ToLower(kind)})
+	if resErr != nil {
+		return nil, fmt.Errorf("unable to resolve kind %q: %w", kind, err)
 	}
-	return fmt.Sprintf("%s %s%s deleted", gvk.Kind, prefix, name), nil
+	return mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 }
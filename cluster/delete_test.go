@@ -55,15 +55,26 @@ func TestDeleteNotFound(t *testing.T) {
 	mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
 	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
 
+	cmGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	_, err := dyn.Resource(cmGVR).Namespace(defaultNamespace).Create(ctx, uObj("v1", "ConfigMap", "present", defaultNamespace), metav1.CreateOptions{})
+	assert.NoError(t, err)
+
 	// Deleting an absent object is reported, not errored (idempotent).
 	manifest := `apiVersion: v1
 kind: ConfigMap
 metadata:
   name: ghost
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: present
 `
 	result, err := (&Delete{Manifest: manifest}).Run(ctx, mockCM)
 	assert.NoError(t, err)
-	assert.Contains(t, result, "not found (already deleted)")
+	assert.Equal(t, `Deleted 1 object(s), skipped 1 already absent:
+• ConfigMap default/ghost not found (already deleted)
+• ConfigMap default/present deleted`, result)
 }
 
 func TestDeleteValidation(t *testing.T) {
@@ -76,3 +87,78 @@ func TestDeleteValidation(t *testing.T) {
 	_, err = (&Delete{Manifest: "---\n---\n"}).Run(ctx, mockCM)
 	assert.Error(t, err)
 }
+
+func TestDeleteResourceRun(t *testing.T) {
+	ctx := context.Background()
+
+	widgetGVR := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	clusterWidgetGVR := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "clusterwidgets"}
+
+	newCM := func() (*testmocks.MockClusterManager, *dynamicfake.FakeDynamicClient) {
+		fakeClient := fake.NewSimpleClientset()
+		fakeClient.Resources = []*metav1.APIResourceList{{
+			GroupVersion: "example.com/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "widgets", Namespaced: true, Kind: "Widget"},
+				{Name: "clusterwidgets", Namespaced: false, Kind: "ClusterWidget"},
+			},
+		}}
+		dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			widgetGVR:        "WidgetList",
+			clusterWidgetGVR: "ClusterWidgetList",
+		})
+		_, err := dyn.Resource(widgetGVR).Namespace(testNamespace).Create(ctx, uObj("example.com/v1", "Widget", "w1", testNamespace), metav1.CreateOptions{})
+		assert.NoError(t, err)
+		_, err = dyn.Resource(clusterWidgetGVR).Create(ctx, uObj("example.com/v1", "ClusterWidget", "cw1", ""), metav1.CreateOptions{})
+		assert.NoError(t, err)
+
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+		mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+		mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+		return mockCM, dyn
+	}
+
+	t.Run("NamespacedCustomResource", func(t *testing.T) {
+		mockCM, dyn := newCM()
+		del := &DeleteResource{Kind: "Widget", Group: "example.com", Name: "w1", Namespace: testNamespace}
+		result, err := del.Run(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Widget test-namespace/w1 deleted", result)
+		_, err = dyn.Resource(widgetGVR).Namespace(testNamespace).Get(ctx, "w1", metav1.GetOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("ClusterScopedIgnoresNamespace", func(t *testing.T) {
+		mockCM, dyn := newCM()
+		del := &DeleteResource{Kind: "clusterwidgets", Group: "example.com", Version: "v1", Name: "cw1", Namespace: testNamespace}
+		result, err := del.Run(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ClusterWidget cw1 deleted", result)
+		_, err = dyn.Resource(clusterWidgetGVR).Get(ctx, "cw1", metav1.GetOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockCM, _ := newCM()
+		_, err := (&DeleteResource{Kind: "Widget", Group: "example.com", Name: "ghost"}).Run(ctx, mockCM)
+		assert.Error(t, err)
+
+		result, err := (&DeleteResource{Kind: "Widget", Group: "example.com", Name: "ghost", IgnoreNotFound: true}).Run(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "not found (ignored)")
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+
+		_, err := (&DeleteResource{Name: "w1"}).Run(ctx, mockCM)
+		assert.Error(t, err)
+
+		_, err = (&DeleteResource{Kind: "Widget", Name: "w1", Propagation: "Sideways"}).Run(ctx, mockCM)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid propagation")
+	})
+}
@@ -4,16 +4,26 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/basebandit/kai"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
 )
 
+// revisionAnnotation records the rollout revision on a deployment and its
+// ReplicaSets.
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
 // Deployment represents a Kubernetes deployment configuration
 type Deployment struct {
 	Name             string
@@ -25,6 +35,15 @@ type Deployment struct {
 	Env              map[string]interface{}
 	ImagePullPolicy  string
 	ImagePullSecrets []interface{}
+	// Requests and Limits set the resources of the single container.
+	Requests map[string]interface{}
+	Limits   map[string]interface{}
+	// Containers, when set, replaces the single container described by
+	// Image, ContainerPort, Env, ImagePullPolicy, Requests and Limits.
+	Containers []kai.ContainerSpec
+	CheckQuota bool
+	DryRun     bool
+	ShowDiff   bool
 }
 
 // Create creates a new deployment in the cluster
@@ -95,10 +114,38 @@ func (d *Deployment) Create(ctx context.Context, cm kai.ClusterManager) (string,
 		}
 	}
 
+	if len(d.Requests) > 0 || len(d.Limits) > 0 {
+		resources, err := containerResources(d.Requests, d.Limits)
+		if err != nil {
+			return result, fmt.Errorf("failed to create deployment: %w", err)
+		}
+		item, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&resources)
+		if err != nil {
+			return result, fmt.Errorf("failed to create deployment: %w", err)
+		}
+		container["resources"] = item
+	}
+
 	podSpec := map[string]interface{}{
 		"containers": []interface{}{container},
 	}
 
+	if len(d.Containers) > 0 {
+		containers, err := buildContainers(d.Containers)
+		if err != nil {
+			return result, fmt.Errorf("failed to create deployment: %w", err)
+		}
+		items := make([]interface{}, 0, len(containers))
+		for i := range containers {
+			item, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&containers[i])
+			if err != nil {
+				return result, fmt.Errorf("failed to create deployment: %w", err)
+			}
+			items = append(items, item)
+		}
+		podSpec["containers"] = items
+	}
+
 	// Add image pull secrets if specified
 	if len(d.ImagePullSecrets) > 0 {
 		pullSecrets := make([]interface{}, 0, len(d.ImagePullSecrets))
@@ -148,7 +195,7 @@ func (d *Deployment) Create(ctx context.Context, cm kai.ClusterManager) (string,
 	timeoutCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	client, err := cm.GetCurrentDynamicClient()
+	client, err := kai.CurrentDynamicClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get dynamic client for deployment create",
 			slog.String("name", d.Name),
@@ -158,7 +205,7 @@ func (d *Deployment) Create(ctx context.Context, cm kai.ClusterManager) (string,
 		return result, fmt.Errorf("failed to get a dynamic client: %w", err)
 	}
 
-	_, err = client.Resource(gvr).Namespace(d.Namespace).Create(timeoutCtx, deployment, metav1.CreateOptions{})
+	_, err = client.Resource(gvr).Namespace(d.Namespace).Create(timeoutCtx, deployment, metav1.CreateOptions{DryRun: dryRunOption(d.DryRun)})
 	if err != nil {
 		slog.Warn("failed to create deployment",
 			slog.String("name", d.Name),
@@ -175,7 +222,7 @@ func (d *Deployment) Create(ctx context.Context, cm kai.ClusterManager) (string,
 
 	result = fmt.Sprintf("Deployment %q created successfully in namespace %q with %g replica(s)", d.Name, d.Namespace, d.Replicas)
 
-	return result, nil
+	return dryRunResult(result, d.DryRun), nil
 }
 
 // Get retrieves information about a specific deployment
@@ -187,7 +234,7 @@ func (d *Deployment) Get(ctx context.Context, cm kai.ClusterManager) (string, er
 		slog.String("namespace", d.Namespace),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for deployment get",
 			slog.String("name", d.Name),
@@ -203,7 +250,7 @@ func (d *Deployment) Get(ctx context.Context, cm kai.ClusterManager) (string, er
 	// If namespace is empty, use current namespace
 	namespace := d.Namespace
 	if namespace == "" {
-		namespace = cm.GetCurrentNamespace()
+		namespace = kai.CurrentNamespace(ctx, cm)
 	}
 
 	// Get the deployment
@@ -230,7 +277,7 @@ func (d *Deployment) Update(ctx context.Context, cm kai.ClusterManager) (string,
 		slog.String("namespace", d.Namespace),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for deployment update",
 			slog.String("name", d.Name),
@@ -246,7 +293,7 @@ func (d *Deployment) Update(ctx context.Context, cm kai.ClusterManager) (string,
 	// If namespace is empty, use current namespace
 	namespace := d.Namespace
 	if namespace == "" {
-		namespace = cm.GetCurrentNamespace()
+		namespace = kai.CurrentNamespace(ctx, cm)
 	}
 
 	// Get the current deployment
@@ -260,6 +307,8 @@ func (d *Deployment) Update(ctx context.Context, cm kai.ClusterManager) (string,
 		return result, fmt.Errorf("failed to get deployment: %w", err)
 	}
 
+	before := deployment.DeepCopy()
+
 	// Update replicas if specified
 	if d.Replicas > 0 {
 		replicas := int32(d.Replicas)
@@ -476,7 +525,7 @@ func (d *Deployment) Update(ctx context.Context, cm kai.ClusterManager) (string,
 	}
 
 	// Update the deployment
-	updatedDeployment, err := client.AppsV1().Deployments(namespace).Update(timeoutCtx, deployment, metav1.UpdateOptions{})
+	updatedDeployment, err := client.AppsV1().Deployments(namespace).Update(timeoutCtx, deployment, metav1.UpdateOptions{DryRun: dryRunOption(d.DryRun)})
 	if err != nil {
 		slog.Warn("failed to update deployment",
 			slog.String("name", d.Name),
@@ -496,11 +545,16 @@ func (d *Deployment) Update(ctx context.Context, cm kai.ClusterManager) (string,
 		result += fmt.Sprintf(" with %d replica(s)", *updatedDeployment.Spec.Replicas)
 	}
 
-	return result, nil
+	if d.ShowDiff {
+		result = withUpdateDiff(result, before, updatedDeployment)
+	}
+	return dryRunResult(result, d.DryRun), nil
 }
 
-// List lists deployments in the specified namespace or across all namespaces
-func (d *Deployment) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string) (string, error) {
+// List lists deployments in the specified namespace or across all namespaces.
+// A positive limit returns one page, followed by the continue token for the
+// next one.
+func (d *Deployment) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string, limit int64, continueToken string) (string, error) {
 	var result string
 
 	slog.Debug("deployment list requested",
@@ -509,7 +563,7 @@ func (d *Deployment) List(ctx context.Context, cm kai.ClusterManager, allNamespa
 		slog.String("label_selector", labelSelector),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for deployment list",
 			slog.Bool("all_namespaces", allNamespaces),
@@ -521,6 +575,8 @@ func (d *Deployment) List(ctx context.Context, cm kai.ClusterManager, allNamespa
 
 	listOptions := metav1.ListOptions{
 		LabelSelector: labelSelector,
+		Limit:         limit,
+		Continue:      continueToken,
 	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
@@ -529,7 +585,7 @@ func (d *Deployment) List(ctx context.Context, cm kai.ClusterManager, allNamespa
 	// If namespace is empty but allNamespaces is false, use the current namespace
 	namespace := d.Namespace
 	if namespace == "" && !allNamespaces {
-		namespace = cm.GetCurrentNamespace()
+		namespace = kai.CurrentNamespace(ctx, cm)
 	}
 
 	if allNamespaces {
@@ -547,7 +603,7 @@ func (d *Deployment) List(ctx context.Context, cm kai.ClusterManager, allNamespa
 			return result, nil
 		}
 		result = "Deployments across all namespaces:\n"
-		result += formatDeploymentList(deployments)
+		result += formatDeploymentList(deployments) + continueHint(deployments.Continue)
 	} else {
 		deployments, err := client.AppsV1().Deployments(namespace).List(timeoutCtx, listOptions)
 		if err != nil {
@@ -565,7 +621,7 @@ func (d *Deployment) List(ctx context.Context, cm kai.ClusterManager, allNamespa
 		}
 
 		result = fmt.Sprintf("Deployments in namespace %q:\n", namespace)
-		result += formatDeploymentList(deployments)
+		result += formatDeploymentList(deployments) + continueHint(deployments.Continue)
 	}
 
 	return result, nil
@@ -577,7 +633,7 @@ func (d *Deployment) Describe(ctx context.Context, cm kai.ClusterManager) (strin
 		slog.String("name", d.Name),
 		slog.String("namespace", d.Namespace),
 	)
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for deployment describe",
 			slog.String("name", d.Name),
@@ -592,7 +648,7 @@ func (d *Deployment) Describe(ctx context.Context, cm kai.ClusterManager) (strin
 
 	namespace := d.Namespace
 	if namespace == "" {
-		namespace = cm.GetCurrentNamespace()
+		namespace = kai.CurrentNamespace(ctx, cm)
 	}
 
 	deployment, err := client.AppsV1().Deployments(namespace).Get(timeoutCtx, d.Name, metav1.GetOptions{})
@@ -613,7 +669,7 @@ func (d *Deployment) Describe(ctx context.Context, cm kai.ClusterManager) (strin
 func (d *Deployment) Delete(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -623,7 +679,7 @@ func (d *Deployment) Delete(ctx context.Context, cm kai.ClusterManager) (string,
 
 	namespace := d.Namespace
 	if namespace == "" {
-		namespace = cm.GetCurrentNamespace()
+		namespace = kai.CurrentNamespace(ctx, cm)
 	}
 
 	err = client.AppsV1().Deployments(namespace).Delete(timeoutCtx, d.Name, metav1.DeleteOptions{})
@@ -635,11 +691,16 @@ func (d *Deployment) Delete(ctx context.Context, cm kai.ClusterManager) (string,
 	return result, nil
 }
 
-// Scale adjusts the number of replicas for a deployment
-func (d *Deployment) Scale(ctx context.Context, cm kai.ClusterManager) (string, error) {
+// Scale sets the replica count through the scale subresource, leaving the
+// pod template untouched.
+func (d *Deployment) Scale(ctx context.Context, cm kai.ClusterManager, replicas int32) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	if replicas < 0 {
+		return result, fmt.Errorf("replicas must be non-negative, got %d", replicas)
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -649,18 +710,26 @@ func (d *Deployment) Scale(ctx context.Context, cm kai.ClusterManager) (string,
 
 	namespace := d.Namespace
 	if namespace == "" {
-		namespace = cm.GetCurrentNamespace()
+		namespace = kai.CurrentNamespace(ctx, cm)
 	}
 
-	deployment, err := client.AppsV1().Deployments(namespace).Get(timeoutCtx, d.Name, metav1.GetOptions{})
+	scale, err := client.AppsV1().Deployments(namespace).GetScale(timeoutCtx, d.Name, metav1.GetOptions{})
 	if err != nil {
-		return result, fmt.Errorf("failed to get deployment: %w", err)
+		return result, fmt.Errorf("failed to get deployment scale: %w", err)
 	}
 
-	replicas := int32(d.Replicas)
-	deployment.Spec.Replicas = &replicas
+	if d.CheckQuota {
+		deployment, err := client.AppsV1().Deployments(namespace).Get(timeoutCtx, d.Name, metav1.GetOptions{})
+		if err != nil {
+			return result, fmt.Errorf("failed to get deployment: %w", err)
+		}
+		if err := checkScaleQuota(timeoutCtx, client, deployment, scale.Spec.Replicas, replicas); err != nil {
+			return result, err
+		}
+	}
 
-	_, err = client.AppsV1().Deployments(namespace).Update(timeoutCtx, deployment, metav1.UpdateOptions{})
+	scale.Spec.Replicas = replicas
+	_, err = client.AppsV1().Deployments(namespace).UpdateScale(timeoutCtx, d.Name, scale, metav1.UpdateOptions{})
 	if err != nil {
 		return result, fmt.Errorf("failed to scale deployment: %w", err)
 	}
@@ -673,7 +742,7 @@ func (d *Deployment) Scale(ctx context.Context, cm kai.ClusterManager) (string,
 func (d *Deployment) RolloutStatus(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -683,7 +752,7 @@ func (d *Deployment) RolloutStatus(ctx context.Context, cm kai.ClusterManager) (
 
 	namespace := d.Namespace
 	if namespace == "" {
-		namespace = cm.GetCurrentNamespace()
+		namespace = kai.CurrentNamespace(ctx, cm)
 	}
 
 	deployment, err := client.AppsV1().Deployments(namespace).Get(timeoutCtx, d.Name, metav1.GetOptions{})
@@ -722,7 +791,7 @@ func (d *Deployment) RolloutStatus(ctx context.Context, cm kai.ClusterManager) (
 func (d *Deployment) RolloutHistory(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -732,7 +801,7 @@ func (d *Deployment) RolloutHistory(ctx context.Context, cm kai.ClusterManager)
 
 	namespace := d.Namespace
 	if namespace == "" {
-		namespace = cm.GetCurrentNamespace()
+		namespace = kai.CurrentNamespace(ctx, cm)
 	}
 
 	deployment, err := client.AppsV1().Deployments(namespace).Get(timeoutCtx, d.Name, metav1.GetOptions{})
@@ -751,7 +820,7 @@ func (d *Deployment) RolloutHistory(ctx context.Context, cm kai.ClusterManager)
 	result += "REVISION  CHANGE-CAUSE\n"
 
 	for _, rs := range replicaSets.Items {
-		if revision, ok := rs.Annotations["deployment.kubernetes.io/revision"]; ok {
+		if revision, ok := rs.Annotations[revisionAnnotation]; ok {
 			changeCause := rs.Annotations["kubernetes.io/change-cause"]
 			if changeCause == "" {
 				changeCause = "<none>"
@@ -763,11 +832,247 @@ func (d *Deployment) RolloutHistory(ctx context.Context, cm kai.ClusterManager)
 	return result, nil
 }
 
-// RolloutUndo rolls back a deployment to a previous revision
-func (d *Deployment) RolloutUndo(ctx context.Context, cm kai.ClusterManager, revision int64) (string, error) {
+// DiffRevisions compares the pod templates of two revisions of a deployment
+// and reports changes to container images, environment variables and
+// resource requests/limits. A zero toRevision selects the latest revision and
+// a zero fromRevision the one before it.
+func (d *Deployment) DiffRevisions(ctx context.Context, cm kai.ClusterManager, fromRevision, toRevision int64) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	namespace := d.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(timeoutCtx, d.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	revisions, err := deploymentRevisions(timeoutCtx, client, deployment)
+	if err != nil {
+		return "", err
+	}
+	if len(revisions) == 0 {
+		return "", fmt.Errorf("deployment %q has no recorded revisions", d.Name)
+	}
+
+	numbers := make([]int64, 0, len(revisions))
+	for revision := range revisions {
+		numbers = append(numbers, revision)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+
+	if toRevision == 0 {
+		toRevision = numbers[len(numbers)-1]
+	}
+	if fromRevision == 0 {
+		for _, revision := range numbers {
+			if revision < toRevision {
+				fromRevision = revision
+			}
+		}
+		if fromRevision == 0 {
+			return "", fmt.Errorf("deployment %q has no revision before %d", d.Name, toRevision)
+		}
+	}
+
+	from, ok := revisions[fromRevision]
+	if !ok {
+		return "", fmt.Errorf("revision %d not found for deployment %q", fromRevision, d.Name)
+	}
+	to, ok := revisions[toRevision]
+	if !ok {
+		return "", fmt.Errorf("revision %d not found for deployment %q", toRevision, d.Name)
+	}
+
+	changes := diffPodTemplates(from.Spec.Template.Spec, to.Spec.Template.Spec)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Deployment %q revision %d -> %d", d.Name, fromRevision, toRevision)
+	if len(changes) == 0 {
+		sb.WriteString(": no differences in images, env, or resources")
+		return sb.String(), nil
+	}
+	sb.WriteString(":\n")
+	for _, change := range changes {
+		fmt.Fprintf(&sb, "%s\n", change)
+	}
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// deploymentRevisions returns the ReplicaSets controlled by deployment keyed
+// by their revision annotation. ReplicaSets without a parseable revision are
+// skipped.
+func deploymentRevisions(ctx context.Context, client kubernetes.Interface, deployment *appsv1.Deployment) (map[int64]*appsv1.ReplicaSet, error) {
+	replicaSets, err := client.AppsV1().ReplicaSets(deployment.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(deployment.Spec.Selector),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replica sets: %w", err)
+	}
+
+	revisions := make(map[int64]*appsv1.ReplicaSet)
+	for i := range replicaSets.Items {
+		rs := &replicaSets.Items[i]
+		if !metav1.IsControlledBy(rs, deployment) {
+			continue
+		}
+		revision, err := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+		revisions[revision] = rs
+	}
+	return revisions, nil
+}
+
+// diffPodTemplates lists image, env and resource changes between two pod
+// specs, matching containers by name. Added lines start with "+", removed
+// ones with "-" and changed ones with "~".
+func diffPodTemplates(from, to corev1.PodSpec) []string {
+	fromContainers := containersByName(from)
+	toContainers := containersByName(to)
+
+	names := make([]string, 0, len(fromContainers)+len(toContainers))
+	for name := range fromContainers {
+		names = append(names, name)
+	}
+	for name := range toContainers {
+		if _, ok := fromContainers[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var images, env, resources []string
+	for _, name := range names {
+		before, inFrom := fromContainers[name]
+		after, inTo := toContainers[name]
+		switch {
+		case !inFrom:
+			images = append(images, fmt.Sprintf("  + %s: %s", name, after.Image))
+			continue
+		case !inTo:
+			images = append(images, fmt.Sprintf("  - %s: %s", name, before.Image))
+			continue
+		}
+
+		if before.Image != after.Image {
+			images = append(images, fmt.Sprintf("  ~ %s: %s -> %s", name, before.Image, after.Image))
+		}
+		env = append(env, diffStringMaps(name, envValues(before.Env), envValues(after.Env))...)
+		resources = append(resources, diffStringMaps(name, resourceValues(before.Resources), resourceValues(after.Resources))...)
+	}
+
+	var changes []string
+	for _, section := range []struct {
+		title string
+		lines []string
+	}{
+		{"Images", images},
+		{"Env", env},
+		{"Resources", resources},
+	} {
+		if len(section.lines) == 0 {
+			continue
+		}
+		changes = append(changes, section.title+":")
+		changes = append(changes, section.lines...)
+	}
+	return changes
+}
+
+// containersByName indexes the init and regular containers of a pod spec.
+func containersByName(spec corev1.PodSpec) map[string]corev1.Container {
+	containers := make(map[string]corev1.Container, len(spec.InitContainers)+len(spec.Containers))
+	for _, c := range spec.InitContainers {
+		containers[c.Name] = c
+	}
+	for _, c := range spec.Containers {
+		containers[c.Name] = c
+	}
+	return containers
+}
+
+// envValues renders a container's environment as name -> value, describing
+// the source of values that come from references.
+func envValues(envVars []corev1.EnvVar) map[string]string {
+	values := make(map[string]string, len(envVars))
+	for _, e := range envVars {
+		value := e.Value
+		if src := e.ValueFrom; src != nil {
+			switch {
+			case src.SecretKeyRef != nil:
+				value = fmt.Sprintf("<secret %s/%s>", src.SecretKeyRef.Name, src.SecretKeyRef.Key)
+			case src.ConfigMapKeyRef != nil:
+				value = fmt.Sprintf("<configmap %s/%s>", src.ConfigMapKeyRef.Name, src.ConfigMapKeyRef.Key)
+			case src.FieldRef != nil:
+				value = fmt.Sprintf("<field %s>", src.FieldRef.FieldPath)
+			case src.ResourceFieldRef != nil:
+				value = fmt.Sprintf("<resource %s>", src.ResourceFieldRef.Resource)
+			}
+		}
+		values[e.Name] = value
+	}
+	return values
+}
+
+// resourceValues flattens requests and limits into keys like
+// "requests.cpu".
+func resourceValues(reqs corev1.ResourceRequirements) map[string]string {
+	values := make(map[string]string, len(reqs.Requests)+len(reqs.Limits))
+	for name, qty := range reqs.Requests {
+		values["requests."+string(name)] = qty.String()
+	}
+	for name, qty := range reqs.Limits {
+		values["limits."+string(name)] = qty.String()
+	}
+	return values
+}
+
+// diffStringMaps reports added, removed and changed keys for one container.
+func diffStringMaps(container string, before, after map[string]string) []string {
+	keys := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		keys = append(keys, k)
+	}
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var lines []string
+	for _, k := range keys {
+		oldValue, inBefore := before[k]
+		newValue, inAfter := after[k]
+		switch {
+		case !inBefore:
+			lines = append(lines, fmt.Sprintf("  + %s %s=%s", container, k, newValue))
+		case !inAfter:
+			lines = append(lines, fmt.Sprintf("  - %s %s=%s", container, k, oldValue))
+		case oldValue != newValue:
+			lines = append(lines, fmt.Sprintf("  ~ %s %s: %s -> %s", container, k, oldValue, newValue))
+		}
+	}
+	return lines
+}
+
+// RolloutUndo rolls a deployment back to toRevision by copying the pod
+// template of that revision's ReplicaSet back onto the deployment. A zero
+// toRevision selects the revision before the current one.
+func (d *Deployment) RolloutUndo(ctx context.Context, cm kai.ClusterManager, toRevision int64) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -777,7 +1082,7 @@ func (d *Deployment) RolloutUndo(ctx context.Context, cm kai.ClusterManager, rev
 
 	namespace := d.Namespace
 	if namespace == "" {
-		namespace = cm.GetCurrentNamespace()
+		namespace = kai.CurrentNamespace(ctx, cm)
 	}
 
 	deployment, err := client.AppsV1().Deployments(namespace).Get(timeoutCtx, d.Name, metav1.GetOptions{})
@@ -785,37 +1090,69 @@ func (d *Deployment) RolloutUndo(ctx context.Context, cm kai.ClusterManager, rev
 		return result, fmt.Errorf("failed to get deployment: %w", err)
 	}
 
-	if revision > 0 {
-		if deployment.Annotations == nil {
-			deployment.Annotations = make(map[string]string)
+	revisions, err := deploymentRevisions(timeoutCtx, client, deployment)
+	if err != nil {
+		return result, err
+	}
+
+	if toRevision == 0 {
+		toRevision = previousRevision(deployment, revisions)
+		if toRevision == 0 {
+			return result, fmt.Errorf("deployment %q has no previous revision to roll back to", d.Name)
 		}
-		deployment.Annotations["deployment.kubernetes.io/revision"] = fmt.Sprintf("%d", revision)
 	}
 
-	if deployment.Spec.Template.Annotations == nil {
-		deployment.Spec.Template.Annotations = make(map[string]string)
+	rs, ok := revisions[toRevision]
+	if !ok {
+		return result, fmt.Errorf("revision %d not found for deployment %q", toRevision, d.Name)
 	}
-	deployment.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"] = time.Now().Format(time.RFC3339)
+
+	// The pod-template-hash label belongs to the ReplicaSet; the deployment
+	// controller adds it back when it adopts or creates one.
+	template := rs.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	deployment.Spec.Template = *template
 
 	_, err = client.AppsV1().Deployments(namespace).Update(timeoutCtx, deployment, metav1.UpdateOptions{})
 	if err != nil {
 		return result, fmt.Errorf("failed to rollback deployment: %w", err)
 	}
 
-	if revision > 0 {
-		result = fmt.Sprintf("Deployment %q rolled back to revision %d in namespace %q", d.Name, revision, namespace)
-	} else {
-		result = fmt.Sprintf("Deployment %q rolled back to previous revision in namespace %q", d.Name, namespace)
+	result = fmt.Sprintf("Deployment %q rolled back to revision %d", d.Name, toRevision)
+	return result, nil
+}
+
+// previousRevision returns the highest revision below the deployment's
+// current one, or zero when there is none. Without a current revision
+// annotation the latest recorded revision is treated as current.
+func previousRevision(deployment *appsv1.Deployment, revisions map[int64]*appsv1.ReplicaSet) int64 {
+	current, err := strconv.ParseInt(deployment.Annotations[revisionAnnotation], 10, 64)
+	if err != nil {
+		for revision := range revisions {
+			if revision > current {
+				current = revision
+			}
+		}
 	}
 
-	return result, nil
+	var previous int64
+	for revision := range revisions {
+		if revision < current && revision > previous {
+			previous = revision
+		}
+	}
+	return previous
 }
 
+// restartedAtAnnotation is the pod template annotation kubectl rollout
+// restart bumps to roll a workload's pods.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
 // RolloutRestart restarts a deployment
 func (d *Deployment) RolloutRestart(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -825,7 +1162,7 @@ func (d *Deployment) RolloutRestart(ctx context.Context, cm kai.ClusterManager)
 
 	namespace := d.Namespace
 	if namespace == "" {
-		namespace = cm.GetCurrentNamespace()
+		namespace = kai.CurrentNamespace(ctx, cm)
 	}
 
 	deployment, err := client.AppsV1().Deployments(namespace).Get(timeoutCtx, d.Name, metav1.GetOptions{})
@@ -836,7 +1173,7 @@ func (d *Deployment) RolloutRestart(ctx context.Context, cm kai.ClusterManager)
 	if deployment.Spec.Template.Annotations == nil {
 		deployment.Spec.Template.Annotations = make(map[string]string)
 	}
-	deployment.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"] = time.Now().Format(time.RFC3339)
+	deployment.Spec.Template.Annotations[restartedAtAnnotation] = time.Now().Format(time.RFC3339)
 
 	_, err = client.AppsV1().Deployments(namespace).Update(timeoutCtx, deployment, metav1.UpdateOptions{})
 	if err != nil {
@@ -851,7 +1188,7 @@ func (d *Deployment) RolloutRestart(ctx context.Context, cm kai.ClusterManager)
 func (d *Deployment) RolloutPause(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -861,7 +1198,7 @@ func (d *Deployment) RolloutPause(ctx context.Context, cm kai.ClusterManager) (s
 
 	namespace := d.Namespace
 	if namespace == "" {
-		namespace = cm.GetCurrentNamespace()
+		namespace = kai.CurrentNamespace(ctx, cm)
 	}
 
 	deployment, err := client.AppsV1().Deployments(namespace).Get(timeoutCtx, d.Name, metav1.GetOptions{})
@@ -884,7 +1221,7 @@ func (d *Deployment) RolloutPause(ctx context.Context, cm kai.ClusterManager) (s
 func (d *Deployment) RolloutResume(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -894,7 +1231,7 @@ func (d *Deployment) RolloutResume(ctx context.Context, cm kai.ClusterManager) (
 
 	namespace := d.Namespace
 	if namespace == "" {
-		namespace = cm.GetCurrentNamespace()
+		namespace = kai.CurrentNamespace(ctx, cm)
 	}
 
 	deployment, err := client.AppsV1().Deployments(namespace).Get(timeoutCtx, d.Name, metav1.GetOptions{})
@@ -912,3 +1249,187 @@ func (d *Deployment) RolloutResume(ctx context.Context, cm kai.ClusterManager) (
 	result = fmt.Sprintf("Deployment %q resumed in namespace %q", d.Name, namespace)
 	return result, nil
 }
+
+// Health combines rollout status, replica readiness, crash-looping pods and
+// recent Warning events into a single verdict: Healthy, Progressing or
+// Degraded.
+func (d *Deployment) Health(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	namespace := d.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(timeoutCtx, d.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(timeoutCtx, metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(deployment.Spec.Selector),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var crashLooping []string
+	podNames := make(map[string]bool, len(pods.Items))
+	for _, pod := range pods.Items {
+		podNames[pod.Name] = true
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, cs := range statuses {
+			if cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff" {
+				crashLooping = append(crashLooping, fmt.Sprintf("%s (container %s, %d restarts)", pod.Name, cs.Name, cs.RestartCount))
+				break
+			}
+		}
+	}
+
+	events, err := client.CoreV1().Events(namespace).List(timeoutCtx, metav1.ListOptions{
+		FieldSelector: "type=Warning",
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list events: %w", err)
+	}
+
+	// Warning events about the deployment, its ReplicaSets or its pods
+	// within the last hour.
+	cutoff := time.Now().Add(-time.Hour)
+	var warnings []corev1.Event
+	for _, e := range events.Items {
+		if e.Type != corev1.EventTypeWarning || eventTime(e).Time.Before(cutoff) {
+			continue
+		}
+		name := e.InvolvedObject.Name
+		if name == d.Name || podNames[name] ||
+			(e.InvolvedObject.Kind == "ReplicaSet" && strings.HasPrefix(name, d.Name+"-")) {
+			warnings = append(warnings, e)
+		}
+	}
+	sort.Slice(warnings, func(i, j int) bool {
+		return eventTime(warnings[i]).After(eventTime(warnings[j]).Time)
+	})
+
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+
+	deadlineExceeded := false
+	for _, cond := range status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == "ProgressDeadlineExceeded" {
+			deadlineExceeded = true
+		}
+	}
+	rolledOut := status.ObservedGeneration >= deployment.Generation &&
+		status.UpdatedReplicas == desired &&
+		status.Replicas == status.UpdatedReplicas
+
+	var verdict string
+	var reasons []string
+	switch {
+	case len(crashLooping) > 0 || deadlineExceeded:
+		verdict = "Degraded"
+		if len(crashLooping) > 0 {
+			reasons = append(reasons, fmt.Sprintf("%d pod(s) crash-looping", len(crashLooping)))
+		}
+		if deadlineExceeded {
+			reasons = append(reasons, "rollout exceeded its progress deadline")
+		}
+	case !rolledOut:
+		verdict = "Progressing"
+		reasons = append(reasons, fmt.Sprintf("%d of %d replicas updated", status.UpdatedReplicas, desired))
+	case status.AvailableReplicas < desired:
+		verdict = "Degraded"
+		reasons = append(reasons, fmt.Sprintf("only %d of %d replicas available", status.AvailableReplicas, desired))
+	default:
+		verdict = "Healthy"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Deployment %q in namespace %q: %s\n", d.Name, namespace, verdict)
+	if len(reasons) > 0 {
+		fmt.Fprintf(&sb, "Reason: %s\n", strings.Join(reasons, "; "))
+	}
+	fmt.Fprintf(&sb, "Replicas: %d desired | %d updated | %d ready | %d available\n",
+		desired, status.UpdatedReplicas, status.ReadyReplicas, status.AvailableReplicas)
+	if deployment.Spec.Paused {
+		sb.WriteString("Rollout: paused\n")
+	}
+
+	if len(crashLooping) > 0 {
+		sb.WriteString("\nCrash-looping pods:\n")
+		for _, p := range crashLooping {
+			fmt.Fprintf(&sb, "• %s\n", p)
+		}
+	}
+
+	if len(warnings) > 0 {
+		sb.WriteString("\nRecent warning events:\n")
+		for _, e := range warnings[:min(len(warnings), 5)] {
+			fmt.Fprintf(&sb, "• %s/%s\t%s: %s\n", e.InvolvedObject.Kind, e.InvolvedObject.Name, e.Reason, e.Message)
+		}
+	}
+
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// checkScaleQuota verifies that the requests added by scaling deployment
+// from current to target replicas fit within the headroom of every
+// ResourceQuota in its namespace. Scaling down always passes.
+func checkScaleQuota(ctx context.Context, client kubernetes.Interface, deployment *appsv1.Deployment, current, target int32) error {
+	if target <= current {
+		return nil
+	}
+
+	quotas, err := client.CoreV1().ResourceQuotas(deployment.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list resource quotas: %w", err)
+	}
+
+	added := int64(target - current)
+	cpu, memory := podRequests(&corev1.Pod{Spec: deployment.Spec.Template.Spec})
+	cpu = *resource.NewMilliQuantity(cpu.MilliValue()*added, resource.DecimalSI)
+	memory = *resource.NewQuantity(memory.Value()*added, resource.BinarySI)
+	pods := *resource.NewQuantity(added, resource.DecimalSI)
+
+	deltas := []struct {
+		label string
+		names []corev1.ResourceName
+		qty   resource.Quantity
+	}{
+		{"cpu", []corev1.ResourceName{corev1.ResourceRequestsCPU, corev1.ResourceCPU}, cpu},
+		{"memory", []corev1.ResourceName{corev1.ResourceRequestsMemory, corev1.ResourceMemory}, memory},
+		{"pods", []corev1.ResourceName{corev1.ResourcePods}, pods},
+	}
+
+	for _, quota := range quotas.Items {
+		for _, delta := range deltas {
+			for _, name := range delta.names {
+				hard, ok := quota.Status.Hard[name]
+				if !ok {
+					hard, ok = quota.Spec.Hard[name]
+				}
+				if !ok {
+					continue
+				}
+				used := quota.Status.Used[name]
+				headroom := hard.DeepCopy()
+				headroom.Sub(used)
+				if delta.qty.Cmp(headroom) > 0 {
+					return fmt.Errorf("scaling to %d would exceed %s quota %q: needs %s more, %s of %s available",
+						target, delta.label, quota.Name, delta.qty.String(), headroom.String(), hard.String())
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -6,15 +6,21 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/testmocks"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 // TestNewDeployment tests deployment creation with defaults
@@ -57,6 +63,105 @@ func TestDeployment_Create(t *testing.T) {
 
 		mockCM.AssertExpectations(t)
 	})
+
+	t.Run("With sidecar container", func(t *testing.T) {
+		deployment := &Deployment{
+			Name:      deploymentName1,
+			Namespace: defaultNamespace,
+			Replicas:  1,
+			Containers: []kai.ContainerSpec{
+				{Name: "app", Image: nginxImage, Ports: []string{"80"}, Requests: map[string]interface{}{"cpu": "100m"}},
+				{Name: "proxy", Image: "envoyproxy/envoy:v1.30", Ports: []string{"9901/TCP"}, Env: map[string]interface{}{"LOG_LEVEL": "info"}},
+			},
+		}
+
+		dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+
+		result, err := deployment.Create(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "created successfully")
+
+		gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+		obj, err := dyn.Resource(gvr).Namespace(defaultNamespace).Get(ctx, deploymentName1, metav1.GetOptions{})
+		assert.NoError(t, err)
+
+		var created appsv1.Deployment
+		assert.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &created))
+		containers := created.Spec.Template.Spec.Containers
+		if assert.Len(t, containers, 2) {
+			assert.Equal(t, "app", containers[0].Name)
+			assert.Equal(t, nginxImage, containers[0].Image)
+			assert.Equal(t, int32(80), containers[0].Ports[0].ContainerPort)
+			assert.Equal(t, "100m", containers[0].Resources.Requests.Cpu().String())
+			assert.Equal(t, "proxy", containers[1].Name)
+			assert.Equal(t, int32(9901), containers[1].Ports[0].ContainerPort)
+			assert.Equal(t, []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "info"}}, containers[1].Env)
+		}
+
+		mockCM.AssertExpectations(t)
+	})
+
+	t.Run("With resources", func(t *testing.T) {
+		deployment := &Deployment{
+			Name:      deploymentName1,
+			Namespace: defaultNamespace,
+			Replicas:  1,
+			Image:     nginxImage,
+			Requests:  map[string]interface{}{"cpu": "250m", "memory": "256Mi"},
+			Limits:    map[string]interface{}{"memory": "512Mi"},
+		}
+
+		dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+
+		_, err := deployment.Create(ctx, mockCM)
+		assert.NoError(t, err)
+
+		gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+		obj, err := dyn.Resource(gvr).Namespace(defaultNamespace).Get(ctx, deploymentName1, metav1.GetOptions{})
+		assert.NoError(t, err)
+
+		var created appsv1.Deployment
+		assert.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &created))
+		resources := created.Spec.Template.Spec.Containers[0].Resources
+		assert.Equal(t, "250m", resources.Requests.Cpu().String())
+		assert.Equal(t, "256Mi", resources.Requests.Memory().String())
+		assert.Equal(t, "512Mi", resources.Limits.Memory().String())
+		assert.True(t, resources.Limits.Cpu().IsZero())
+	})
+
+	t.Run("Invalid resource quantity", func(t *testing.T) {
+		deployment := &Deployment{
+			Name:      deploymentName1,
+			Namespace: defaultNamespace,
+			Replicas:  1,
+			Image:     nginxImage,
+			Limits:    map[string]interface{}{"memory": "lots"},
+		}
+
+		_, err := deployment.Create(ctx, testmocks.NewMockClusterManager())
+		assert.EqualError(t, err, "failed to create deployment: limits: invalid quantity lots for memory")
+	})
+
+	t.Run("Duplicate container names", func(t *testing.T) {
+		deployment := &Deployment{
+			Name:      deploymentName1,
+			Namespace: defaultNamespace,
+			Replicas:  1,
+			Containers: []kai.ContainerSpec{
+				{Name: "app", Image: nginxImage},
+				{Name: "app", Image: "envoyproxy/envoy:v1.30"},
+			},
+		}
+
+		result, err := deployment.Create(ctx, testmocks.NewMockClusterManager())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `container "app" is defined more than once`)
+		assert.Empty(t, result)
+	})
 }
 
 // TestDeployment_Update tests the Update method
@@ -652,7 +757,7 @@ func TestDeployment_List(t *testing.T) {
 			mockCM := testmocks.NewMockClusterManager()
 			tc.setupMock(mockCM)
 
-			result, err := tc.deployment.List(ctx, mockCM, tc.allNamespaces, tc.labelSelector)
+			result, err := tc.deployment.List(ctx, mockCM, tc.allNamespaces, tc.labelSelector, 0, "")
 			if tc.expectedError != "" {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tc.expectedError)
@@ -951,6 +1056,41 @@ func TestDeployment_Delete(t *testing.T) {
 	}
 }
 
+// addScaleReactors serves the deployments/scale subresource from the
+// tracked Deployment, which the fake clientset does not do on its own.
+func addScaleReactors(client *fake.Clientset) {
+	client.PrependReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		get := action.(k8stesting.GetAction)
+		if get.GetSubresource() != "scale" {
+			return false, nil, nil
+		}
+		obj, err := client.Tracker().Get(appsv1.SchemeGroupVersion.WithResource("deployments"), get.GetNamespace(), get.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+		deployment := obj.(*appsv1.Deployment)
+		return true, &autoscalingv1.Scale{
+			ObjectMeta: metav1.ObjectMeta{Name: deployment.Name, Namespace: deployment.Namespace},
+			Spec:       autoscalingv1.ScaleSpec{Replicas: *deployment.Spec.Replicas},
+		}, nil
+	})
+	client.PrependReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		update := action.(k8stesting.UpdateAction)
+		if update.GetSubresource() != "scale" {
+			return false, nil, nil
+		}
+		scale := update.GetObject().(*autoscalingv1.Scale)
+		gvr := appsv1.SchemeGroupVersion.WithResource("deployments")
+		obj, err := client.Tracker().Get(gvr, update.GetNamespace(), scale.Name)
+		if err != nil {
+			return true, nil, err
+		}
+		deployment := obj.(*appsv1.Deployment).DeepCopy()
+		deployment.Spec.Replicas = &scale.Spec.Replicas
+		return true, scale, client.Tracker().Update(gvr, deployment, update.GetNamespace())
+	})
+}
+
 func TestDeployment_Scale(t *testing.T) {
 	ctx := context.Background()
 
@@ -998,6 +1138,7 @@ func TestDeployment_Scale(t *testing.T) {
 	testCases := []struct {
 		name           string
 		deployment     *Deployment
+		replicas       int32
 		setupMock      func(*testmocks.MockClusterManager)
 		expectedError  string
 		expectedResult string
@@ -1007,11 +1148,12 @@ func TestDeployment_Scale(t *testing.T) {
 			deployment: &Deployment{
 				Name:      deploymentName1,
 				Namespace: testNamespace,
-				Replicas:  5,
 			},
+			replicas: 5,
 			setupMock: func(mockCM *testmocks.MockClusterManager) {
 				deployment := createDeploymentObj(deploymentName1, testNamespace, 3)
 				fakeClient := fake.NewSimpleClientset(deployment)
+				addScaleReactors(fakeClient)
 				mockCM.On("GetCurrentClient").Return(fakeClient, nil)
 			},
 			expectedResult: fmt.Sprintf("Deployment %q scaled to 5 replica(s) in namespace %q", deploymentName1, testNamespace),
@@ -1021,11 +1163,12 @@ func TestDeployment_Scale(t *testing.T) {
 			deployment: &Deployment{
 				Name:      deploymentName1,
 				Namespace: testNamespace,
-				Replicas:  0,
 			},
+			replicas: 0,
 			setupMock: func(mockCM *testmocks.MockClusterManager) {
 				deployment := createDeploymentObj(deploymentName1, testNamespace, 3)
 				fakeClient := fake.NewSimpleClientset(deployment)
+				addScaleReactors(fakeClient)
 				mockCM.On("GetCurrentClient").Return(fakeClient, nil)
 			},
 			expectedResult: fmt.Sprintf("Deployment %q scaled to 0 replica(s) in namespace %q", deploymentName1, testNamespace),
@@ -1035,14 +1178,25 @@ func TestDeployment_Scale(t *testing.T) {
 			deployment: &Deployment{
 				Name:      "nonexistent",
 				Namespace: testNamespace,
-				Replicas:  3,
 			},
+			replicas: 3,
 			setupMock: func(mockCM *testmocks.MockClusterManager) {
 				fakeClient := fake.NewSimpleClientset()
+				addScaleReactors(fakeClient)
 				mockCM.On("GetCurrentClient").Return(fakeClient, nil)
 			},
 			expectedError: "failed to get deployment",
 		},
+		{
+			name: "Negative replicas",
+			deployment: &Deployment{
+				Name:      deploymentName1,
+				Namespace: testNamespace,
+			},
+			replicas:      -1,
+			setupMock:     func(mockCM *testmocks.MockClusterManager) {},
+			expectedError: "replicas must be non-negative",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1050,7 +1204,7 @@ func TestDeployment_Scale(t *testing.T) {
 			mockCM := testmocks.NewMockClusterManager()
 			tc.setupMock(mockCM)
 
-			result, err := tc.deployment.Scale(ctx, mockCM)
+			result, err := tc.deployment.Scale(ctx, mockCM, tc.replicas)
 
 			if tc.expectedError != "" {
 				assert.Error(t, err)
@@ -1065,6 +1219,77 @@ func TestDeployment_Scale(t *testing.T) {
 	}
 }
 
+func TestDeployment_ScaleCheckQuota(t *testing.T) {
+	ctx := context.Background()
+
+	replicas := int32(1)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: deploymentName1, Namespace: testNamespace},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": deploymentName1}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": deploymentName1}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  deploymentName1,
+						Image: nginxImage,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("100m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: testNamespace},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("3Gi")},
+		},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("3Gi")},
+			Used: corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("1Gi")},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		replicas      int32
+		expectedError string
+	}{
+		{name: "WithinQuota", replicas: 3},
+		{name: "ExceedsQuota", replicas: 5, expectedError: "scaling to 5 would exceed memory quota"},
+		{name: "ScaleDown", replicas: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset(deployment.DeepCopy(), quota.DeepCopy())
+			addScaleReactors(fakeClient)
+			mockCM := testmocks.NewMockClusterManager()
+			mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+			d := &Deployment{Name: deploymentName1, Namespace: testNamespace, CheckQuota: true}
+			_, err := d.Scale(ctx, mockCM, tc.replicas)
+
+			updated, getErr := fakeClient.AppsV1().Deployments(testNamespace).Get(ctx, deploymentName1, metav1.GetOptions{})
+			assert.NoError(t, getErr)
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				assert.Equal(t, int32(1), *updated.Spec.Replicas)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.replicas, *updated.Spec.Replicas)
+			}
+		})
+	}
+}
+
 func TestDeployment_RolloutStatus(t *testing.T) {
 	ctx := context.Background()
 
@@ -1284,6 +1509,160 @@ func TestDeployment_RolloutHistory(t *testing.T) {
 	}
 }
 
+func TestDeployment_DiffRevisions(t *testing.T) {
+	ctx := context.Background()
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deploymentName1,
+			Namespace: testNamespace,
+			UID:       "deploy-uid",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": deploymentName1}},
+		},
+	}
+
+	replicaSet := func(revision string, image string, env []corev1.EnvVar, cpu string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        deploymentName1 + "-" + revision,
+				Namespace:   testNamespace,
+				Labels:      map[string]string{"app": deploymentName1},
+				Annotations: map[string]string{revisionAnnotation: revision},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       deploymentName1,
+					UID:        deployment.UID,
+					Controller: ptr(true),
+				}},
+			},
+			Spec: appsv1.ReplicaSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name:  "app",
+							Image: image,
+							Env:   env,
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+							},
+						}},
+					},
+				},
+			},
+		}
+	}
+
+	rs1 := replicaSet("1", "nginx:1.24", []corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "OLD_FLAG", Value: "true"},
+	}, "100m")
+	rs2 := replicaSet("2", "nginx:1.25", []corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "DB_PASSWORD", ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}, Key: "password"},
+		}},
+	}, "100m")
+	rs3 := replicaSet("3", "nginx:1.25", rs2.Spec.Template.Spec.Containers[0].Env, "250m")
+
+	testCases := []struct {
+		name          string
+		objects       []runtime.Object
+		from, to      int64
+		expectedError string
+		expected      []string
+		unexpected    []string
+	}{
+		{
+			name:    "ImageAndEnvChange",
+			objects: []runtime.Object{deployment, rs1, rs2},
+			from:    1,
+			to:      2,
+			expected: []string{
+				`Deployment "deployment1" revision 1 -> 2:`,
+				"Images:\n  ~ app: nginx:1.24 -> nginx:1.25",
+				"Env:",
+				"  + app DB_PASSWORD=<secret db/password>",
+				"  ~ app LOG_LEVEL: info -> debug",
+				"  - app OLD_FLAG=true",
+			},
+			unexpected: []string{"Resources:"},
+		},
+		{
+			name:     "DefaultsToLatestTwo",
+			objects:  []runtime.Object{deployment, rs1, rs2, rs3},
+			expected: []string{"revision 2 -> 3:", "Resources:\n  ~ app requests.cpu: 100m -> 250m"},
+			unexpected: []string{
+				"Images:",
+				"Env:",
+			},
+		},
+		{
+			name:     "NoDifferences",
+			objects:  []runtime.Object{deployment, rs2, replicaSet("4", "nginx:1.25", rs2.Spec.Template.Spec.Containers[0].Env, "100m")},
+			from:     2,
+			to:       4,
+			expected: []string{"revision 2 -> 4: no differences in images, env, or resources"},
+		},
+		{
+			name:          "RevisionNotFound",
+			objects:       []runtime.Object{deployment, rs1, rs2},
+			from:          1,
+			to:            7,
+			expectedError: `revision 7 not found for deployment "deployment1"`,
+		},
+		{
+			name:          "IgnoresUnownedReplicaSets",
+			objects:       []runtime.Object{deployment, &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "stray", Namespace: testNamespace, Labels: map[string]string{"app": deploymentName1}, Annotations: map[string]string{revisionAnnotation: "1"}}}},
+			expectedError: "has no recorded revisions",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(tc.objects...), nil)
+
+			result, err := (&Deployment{Name: deploymentName1, Namespace: testNamespace}).DiffRevisions(ctx, mockCM, tc.from, tc.to)
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			for _, expected := range tc.expected {
+				assert.Contains(t, result, expected)
+			}
+			for _, unexpected := range tc.unexpected {
+				assert.NotContains(t, result, unexpected)
+			}
+		})
+	}
+}
+
+// revisionReplicaSet returns a ReplicaSet owned by deployment that records
+// revision and runs image, as the deployment controller would create it.
+func revisionReplicaSet(deployment *appsv1.Deployment, revision, image string) *appsv1.ReplicaSet {
+	template := deployment.Spec.Template.DeepCopy()
+	template.Labels[appsv1.DefaultDeploymentUniqueLabelKey] = "hash-" + revision
+	template.Spec.Containers[0].Image = image
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        deployment.Name + "-hash-" + revision,
+			Namespace:   deployment.Namespace,
+			Labels:      template.Labels,
+			Annotations: map[string]string{revisionAnnotation: revision},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(deployment, appsv1.SchemeGroupVersion.WithKind("Deployment")),
+			},
+		},
+		Spec: appsv1.ReplicaSetSpec{Template: *template},
+	}
+}
+
 func TestDeployment_RolloutUndo(t *testing.T) {
 	ctx := context.Background()
 
@@ -1335,6 +1714,7 @@ func TestDeployment_RolloutUndo(t *testing.T) {
 		setupMock      func(*testmocks.MockClusterManager)
 		expectedError  string
 		expectedResult string
+		expectedImage  string
 	}{
 		{
 			name: "Rollback to previous revision",
@@ -1345,10 +1725,16 @@ func TestDeployment_RolloutUndo(t *testing.T) {
 			revision: 0,
 			setupMock: func(mockCM *testmocks.MockClusterManager) {
 				deployment := createDeploymentObj(deploymentName1, testNamespace, 3)
-				fakeClient := fake.NewSimpleClientset(deployment)
+				deployment.Annotations = map[string]string{revisionAnnotation: "3"}
+				fakeClient := fake.NewSimpleClientset(deployment,
+					revisionReplicaSet(deployment, "1", "nginx:1.23"),
+					revisionReplicaSet(deployment, "2", "nginx:1.24"),
+					revisionReplicaSet(deployment, "3", nginxImage),
+				)
 				mockCM.On("GetCurrentClient").Return(fakeClient, nil)
 			},
-			expectedResult: fmt.Sprintf("Deployment %q rolled back to previous revision in namespace %q", deploymentName1, testNamespace),
+			expectedResult: fmt.Sprintf("Deployment %q rolled back to revision 2", deploymentName1),
+			expectedImage:  "nginx:1.24",
 		},
 		{
 			name: "Rollback to specific revision",
@@ -1356,13 +1742,47 @@ func TestDeployment_RolloutUndo(t *testing.T) {
 				Name:      deploymentName1,
 				Namespace: testNamespace,
 			},
-			revision: 2,
+			revision: 1,
 			setupMock: func(mockCM *testmocks.MockClusterManager) {
 				deployment := createDeploymentObj(deploymentName1, testNamespace, 3)
-				fakeClient := fake.NewSimpleClientset(deployment)
+				deployment.Annotations = map[string]string{revisionAnnotation: "3"}
+				fakeClient := fake.NewSimpleClientset(deployment,
+					revisionReplicaSet(deployment, "1", "nginx:1.23"),
+					revisionReplicaSet(deployment, "2", "nginx:1.24"),
+					revisionReplicaSet(deployment, "3", nginxImage),
+				)
 				mockCM.On("GetCurrentClient").Return(fakeClient, nil)
 			},
-			expectedResult: fmt.Sprintf("Deployment %q rolled back to revision 2 in namespace %q", deploymentName1, testNamespace),
+			expectedResult: fmt.Sprintf("Deployment %q rolled back to revision 1", deploymentName1),
+			expectedImage:  "nginx:1.23",
+		},
+		{
+			name: "Revision does not exist",
+			deployment: &Deployment{
+				Name:      deploymentName1,
+				Namespace: testNamespace,
+			},
+			revision: 9,
+			setupMock: func(mockCM *testmocks.MockClusterManager) {
+				deployment := createDeploymentObj(deploymentName1, testNamespace, 3)
+				fakeClient := fake.NewSimpleClientset(deployment, revisionReplicaSet(deployment, "1", nginxImage))
+				mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+			},
+			expectedError: fmt.Sprintf("revision 9 not found for deployment %q", deploymentName1),
+		},
+		{
+			name: "No previous revision",
+			deployment: &Deployment{
+				Name:      deploymentName1,
+				Namespace: testNamespace,
+			},
+			revision: 0,
+			setupMock: func(mockCM *testmocks.MockClusterManager) {
+				deployment := createDeploymentObj(deploymentName1, testNamespace, 3)
+				fakeClient := fake.NewSimpleClientset(deployment, revisionReplicaSet(deployment, "1", nginxImage))
+				mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+			},
+			expectedError: "has no previous revision to roll back to",
 		},
 	}
 
@@ -1379,6 +1799,12 @@ func TestDeployment_RolloutUndo(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tc.expectedResult, result)
+
+				client, _ := mockCM.GetCurrentClient()
+				updated, err := client.AppsV1().Deployments(testNamespace).Get(ctx, deploymentName1, metav1.GetOptions{})
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedImage, updated.Spec.Template.Spec.Containers[0].Image)
+				assert.NotContains(t, updated.Spec.Template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
 			}
 
 			mockCM.AssertExpectations(t)
@@ -1644,3 +2070,239 @@ func TestDeployment_RolloutResume(t *testing.T) {
 		})
 	}
 }
+
+func TestDeployment_Health(t *testing.T) {
+	ctx := context.Background()
+
+	newDeployment := func(replicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: deploymentName1, Namespace: testNamespace},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": deploymentName1}},
+			},
+			Status: appsv1.DeploymentStatus{
+				Replicas:          replicas,
+				UpdatedReplicas:   replicas,
+				ReadyReplicas:     replicas,
+				AvailableReplicas: replicas,
+			},
+		}
+	}
+	newPod := func(name string, waitingReason string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+				Labels:    map[string]string{"app": deploymentName1},
+			},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "app", Ready: waitingReason == ""}},
+			},
+		}
+		if waitingReason != "" {
+			pod.Status.ContainerStatuses[0].RestartCount = 7
+			pod.Status.ContainerStatuses[0].State.Waiting = &corev1.ContainerStateWaiting{Reason: waitingReason}
+		}
+		return pod
+	}
+
+	t.Run("Healthy", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(newDeployment(2), newPod("web-a", ""), newPod("web-b", ""))
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		deployment := &Deployment{Name: deploymentName1, Namespace: testNamespace}
+		result, err := deployment.Health(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, ": Healthy")
+		assert.Contains(t, result, "2 desired | 2 updated | 2 ready | 2 available")
+	})
+
+	t.Run("DegradedWithCrashLoop", func(t *testing.T) {
+		dep := newDeployment(2)
+		dep.Status.ReadyReplicas = 1
+		dep.Status.AvailableReplicas = 1
+		event := &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "web-b.1", Namespace: testNamespace},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "BackOff",
+			Message:        "Back-off restarting failed container",
+			LastTimestamp:  metav1.Now(),
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-b"},
+		}
+		fakeClient := fake.NewSimpleClientset(dep, newPod("web-a", ""), newPod("web-b", "CrashLoopBackOff"), event)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		deployment := &Deployment{Name: deploymentName1, Namespace: testNamespace}
+		result, err := deployment.Health(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, ": Degraded")
+		assert.Contains(t, result, "1 pod(s) crash-looping")
+		assert.Contains(t, result, "web-b (container app, 7 restarts)")
+		assert.Contains(t, result, "BackOff: Back-off restarting failed container")
+	})
+
+	t.Run("Progressing", func(t *testing.T) {
+		dep := newDeployment(3)
+		dep.Status.UpdatedReplicas = 1
+		fakeClient := fake.NewSimpleClientset(dep)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		deployment := &Deployment{Name: deploymentName1, Namespace: testNamespace}
+		result, err := deployment.Health(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, ": Progressing")
+	})
+}
+
+func TestDeployment_Summarize(t *testing.T) {
+	replicas := int32(3)
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deploymentName1,
+			Namespace: testNamespace,
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kubectl-client-side-apply", Operation: metav1.ManagedFieldsOperationUpdate},
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": deploymentName1}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "app",
+						Image: "nginx:1.27",
+						Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 80, Protocol: corev1.ProtocolTCP}},
+						Env: []corev1.EnvVar{
+							{Name: "DB_PASSWORD", Value: "hunter2"},
+							{Name: "LOG_LEVEL", Value: "debug"},
+						},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+							Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+						},
+						ReadinessProbe: &corev1.Probe{},
+					}},
+				},
+			},
+		},
+	}
+
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(dep), nil)
+
+	deployment := &Deployment{Name: deploymentName1, Namespace: testNamespace}
+	result, err := deployment.Summarize(context.Background(), mockCM)
+
+	assert.NoError(t, err)
+	assert.Contains(t, result, `"image": "nginx:1.27"`)
+	assert.Contains(t, result, `"replicas": 3`)
+	assert.Contains(t, result, `"http:80/TCP"`)
+	assert.Contains(t, result, `"cpu": "100m"`)
+	assert.Contains(t, result, `"memory": "256Mi"`)
+	assert.Contains(t, result, `"readiness"`)
+	assert.Contains(t, result, `"DB_PASSWORD"`)
+	assert.NotContains(t, result, "hunter2", "env values are left out")
+	assert.NotContains(t, result, "managedFields")
+	assert.NotContains(t, result, "kubectl-client-side-apply")
+
+	_, err = (&Deployment{Name: "missing", Namespace: testNamespace}).Summarize(context.Background(), mockCM)
+	assert.ErrorContains(t, err, "failed to get deployment")
+}
+
+func TestDeployment_HibernateWake(t *testing.T) {
+	ctx := context.Background()
+
+	newDeployment := func(replicas int32, annotations map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: deploymentName1, Namespace: testNamespace, Annotations: annotations},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		}
+	}
+	setup := func(dep *appsv1.Deployment) (*testmocks.MockClusterManager, *fake.Clientset) {
+		fakeClient := fake.NewSimpleClientset(dep)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+		return mockCM, fakeClient
+	}
+	current := func(t *testing.T, client *fake.Clientset) *appsv1.Deployment {
+		dep, err := client.AppsV1().Deployments(testNamespace).Get(ctx, deploymentName1, metav1.GetOptions{})
+		assert.NoError(t, err)
+		return dep
+	}
+	deployment := &Deployment{Name: deploymentName1, Namespace: testNamespace}
+
+	t.Run("Hibernate records the replica count", func(t *testing.T) {
+		mockCM, client := setup(newDeployment(3, map[string]string{"team": "web"}))
+		result, err := deployment.Hibernate(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Equal(t, `Deployment "deployment1" hibernated in namespace "test-namespace": scaled from 3 to 0 replica(s)`, result)
+
+		dep := current(t, client)
+		assert.Equal(t, int32(0), *dep.Spec.Replicas)
+		assert.Equal(t, "3", dep.Annotations[previousReplicasAnnotation])
+		assert.Equal(t, "web", dep.Annotations["team"])
+
+		result, err = deployment.Hibernate(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "is already hibernated (will wake to 3 replica(s))")
+		assert.Equal(t, "3", current(t, client).Annotations[previousReplicasAnnotation])
+	})
+
+	t.Run("Wake restores the replica count", func(t *testing.T) {
+		mockCM, client := setup(newDeployment(0, map[string]string{previousReplicasAnnotation: "4"}))
+		result, err := deployment.Wake(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Equal(t, `Deployment "deployment1" woken in namespace "test-namespace": scaled from 0 to 4 replica(s)`, result)
+
+		dep := current(t, client)
+		assert.Equal(t, int32(4), *dep.Spec.Replicas)
+		assert.NotContains(t, dep.Annotations, previousReplicasAnnotation)
+	})
+
+	t.Run("Wake keeps replicas scaled up by hand", func(t *testing.T) {
+		mockCM, client := setup(newDeployment(2, map[string]string{previousReplicasAnnotation: "4"}))
+		result, err := deployment.Wake(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "was already running 2 replica(s)")
+
+		dep := current(t, client)
+		assert.Equal(t, int32(2), *dep.Spec.Replicas)
+		assert.NotContains(t, dep.Annotations, previousReplicasAnnotation)
+	})
+
+	t.Run("Hibernate at zero replicas", func(t *testing.T) {
+		mockCM, client := setup(newDeployment(0, nil))
+		result, err := deployment.Hibernate(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "already has 0 replicas; nothing to hibernate")
+		assert.NotContains(t, current(t, client).Annotations, previousReplicasAnnotation)
+	})
+
+	t.Run("Wake without hibernation", func(t *testing.T) {
+		mockCM, _ := setup(newDeployment(0, nil))
+		_, err := deployment.Wake(ctx, mockCM)
+		assert.EqualError(t, err, `deployment "deployment1" in namespace "test-namespace" is not hibernated: no kai.dev/previous-replicas annotation`)
+	})
+
+	t.Run("Wake with invalid annotation", func(t *testing.T) {
+		mockCM, client := setup(newDeployment(0, map[string]string{previousReplicasAnnotation: "lots"}))
+		_, err := deployment.Wake(ctx, mockCM)
+		assert.ErrorContains(t, err, `invalid kai.dev/previous-replicas annotation "lots"`)
+		assert.Equal(t, int32(0), *current(t, client).Spec.Replicas)
+	})
+
+	t.Run("Deployment not found", func(t *testing.T) {
+		mockCM, _ := setup(newDeployment(1, nil))
+		_, err := (&Deployment{Name: "missing", Namespace: testNamespace}).Hibernate(ctx, mockCM)
+		assert.ErrorContains(t, err, "failed to get deployment")
+	})
+}
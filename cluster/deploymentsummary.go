@@ -0,0 +1,159 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/basebandit/kai"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// deploymentSummary is the compact view of a deployment spec returned by
+// Summarize. It keeps what is needed to plan an edit and drops metadata such
+// as managedFields, status and defaulted fields.
+type deploymentSummary struct {
+	Name           string             `json:"name"`
+	Namespace      string             `json:"namespace"`
+	Replicas       int32              `json:"replicas"`
+	Strategy       string             `json:"strategy,omitempty"`
+	Selector       map[string]string  `json:"selector,omitempty"`
+	ServiceAccount string             `json:"serviceAccount,omitempty"`
+	InitContainers []containerSummary `json:"initContainers,omitempty"`
+	Containers     []containerSummary `json:"containers"`
+	Volumes        []string           `json:"volumes,omitempty"`
+}
+
+type containerSummary struct {
+	Name  string   `json:"name"`
+	Image string   `json:"image"`
+	Ports []string `json:"ports,omitempty"`
+	// EnvKeys lists variable names only; values may hold secrets and cost
+	// context without helping to plan an edit.
+	EnvKeys   []string          `json:"envKeys,omitempty"`
+	EnvFrom   []string          `json:"envFrom,omitempty"`
+	Requests  map[string]string `json:"requests,omitempty"`
+	Limits    map[string]string `json:"limits,omitempty"`
+	Probes    []string          `json:"probes,omitempty"`
+	Mounts    []string          `json:"mounts,omitempty"`
+	Command   []string          `json:"command,omitempty"`
+	Arguments []string          `json:"args,omitempty"`
+}
+
+// Summarize returns a minimal JSON view of the deployment spec: replicas,
+// and per container the image, ports, env keys, resources and which probes
+// are set.
+func (d *Deployment) Summarize(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	namespace := d.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(timeoutCtx, d.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	data, err := json.MarshalIndent(summarizeDeployment(deployment), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode summary: %w", err)
+	}
+	return string(data), nil
+}
+
+func summarizeDeployment(deployment *appsv1.Deployment) deploymentSummary {
+	spec := deployment.Spec.Template.Spec
+	summary := deploymentSummary{
+		Name:           deployment.Name,
+		Namespace:      deployment.Namespace,
+		Replicas:       1,
+		Strategy:       string(deployment.Spec.Strategy.Type),
+		ServiceAccount: spec.ServiceAccountName,
+	}
+	if deployment.Spec.Replicas != nil {
+		summary.Replicas = *deployment.Spec.Replicas
+	}
+	if deployment.Spec.Selector != nil {
+		summary.Selector = deployment.Spec.Selector.MatchLabels
+	}
+	for _, c := range spec.InitContainers {
+		summary.InitContainers = append(summary.InitContainers, summarizeContainer(c))
+	}
+	summary.Containers = make([]containerSummary, 0, len(spec.Containers))
+	for _, c := range spec.Containers {
+		summary.Containers = append(summary.Containers, summarizeContainer(c))
+	}
+	for _, v := range spec.Volumes {
+		summary.Volumes = append(summary.Volumes, fmt.Sprintf("%s (%s)", v.Name, formatVolumeSource(v.VolumeSource)))
+	}
+	return summary
+}
+
+func summarizeContainer(c corev1.Container) containerSummary {
+	summary := containerSummary{
+		Name:      c.Name,
+		Image:     c.Image,
+		Command:   c.Command,
+		Arguments: c.Args,
+		Requests:  resourceStrings(c.Resources.Requests),
+		Limits:    resourceStrings(c.Resources.Limits),
+	}
+	for _, p := range c.Ports {
+		port := fmt.Sprintf("%d/%s", p.ContainerPort, p.Protocol)
+		if p.Protocol == "" {
+			port = fmt.Sprintf("%d/TCP", p.ContainerPort)
+		}
+		if p.Name != "" {
+			port = p.Name + ":" + port
+		}
+		summary.Ports = append(summary.Ports, port)
+	}
+	for _, e := range c.Env {
+		summary.EnvKeys = append(summary.EnvKeys, e.Name)
+	}
+	for _, e := range c.EnvFrom {
+		switch {
+		case e.ConfigMapRef != nil:
+			summary.EnvFrom = append(summary.EnvFrom, "configmap/"+e.ConfigMapRef.Name)
+		case e.SecretRef != nil:
+			summary.EnvFrom = append(summary.EnvFrom, "secret/"+e.SecretRef.Name)
+		}
+	}
+	if c.LivenessProbe != nil {
+		summary.Probes = append(summary.Probes, "liveness")
+	}
+	if c.ReadinessProbe != nil {
+		summary.Probes = append(summary.Probes, "readiness")
+	}
+	if c.StartupProbe != nil {
+		summary.Probes = append(summary.Probes, "startup")
+	}
+	for _, m := range c.VolumeMounts {
+		summary.Mounts = append(summary.Mounts, m.Name+":"+m.MountPath)
+	}
+	return summary
+}
+
+// resourceStrings renders a resource list as name to quantity, or nil when
+// it is empty so the field is omitted.
+func resourceStrings(list corev1.ResourceList) map[string]string {
+	if len(list) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(list))
+	for name, q := range list {
+		out[string(name)] = q.String()
+	}
+	return out
+}
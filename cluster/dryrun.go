@@ -0,0 +1,30 @@
+package cluster
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// dryRunOption returns the DryRun field for create, update and patch
+// options. When enabled the API server runs validation, defaulting and
+// admission but persists nothing.
+func dryRunOption(enabled bool) []string {
+	if enabled {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
+
+// dryRunResult marks a result describing a change that was not persisted.
+// The marker goes on the first line, which names the change.
+func dryRunResult(result string, enabled bool) string {
+	if !enabled {
+		return result
+	}
+	first, rest, multiline := strings.Cut(result, "\n")
+	if multiline {
+		return first + " (dry run)\n" + rest
+	}
+	return result + " (dry run)"
+}
@@ -0,0 +1,84 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestDryRunResult(t *testing.T) {
+	assert.Equal(t, "Secret created", dryRunResult("Secret created", false))
+	assert.Equal(t, "Secret created (dry run)", dryRunResult("Secret created", true))
+	assert.Equal(t, "Service created (dry run)\nClusterIP: 10.96.0.1", dryRunResult("Service created\nClusterIP: 10.96.0.1", true))
+}
+
+func TestDryRunWrites(t *testing.T) {
+	ctx := context.Background()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: testNamespace},
+		Data:       map[string][]byte{"user": []byte("admin")},
+	}
+
+	// setup records the dryRun option of every create and update and, like
+	// the API server, persists nothing when it is set.
+	setup := func() (*testmocks.MockClusterManager, *fake.Clientset, *[][]string) {
+		client := fake.NewSimpleClientset(ns, existing)
+		var dryRuns [][]string
+		client.PrependReactor("create", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			opts := action.(k8stesting.CreateActionImpl).CreateOptions
+			dryRuns = append(dryRuns, opts.DryRun)
+			return len(opts.DryRun) > 0, action.(k8stesting.CreateActionImpl).GetObject(), nil
+		})
+		client.PrependReactor("update", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			opts := action.(k8stesting.UpdateActionImpl).UpdateOptions
+			dryRuns = append(dryRuns, opts.DryRun)
+			return len(opts.DryRun) > 0, action.(k8stesting.UpdateActionImpl).GetObject(), nil
+		})
+		cm := testmocks.NewMockClusterManager()
+		cm.On("GetCurrentClient").Return(client, nil)
+		return cm, client, &dryRuns
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		cm, client, dryRuns := setup()
+		result, err := (&ConfigMap{Name: "settings", Namespace: testNamespace, Data: map[string]interface{}{"k": "v"}, DryRun: true}).Create(ctx, cm)
+		require.NoError(t, err)
+		assert.Equal(t, `ConfigMap "settings" created successfully in namespace "test-namespace" (dry run)`, result)
+		assert.Equal(t, [][]string{{metav1.DryRunAll}}, *dryRuns)
+
+		_, err = client.CoreV1().ConfigMaps(testNamespace).Get(ctx, "settings", metav1.GetOptions{})
+		assert.Error(t, err, "a dry run persists nothing")
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		cm, client, dryRuns := setup()
+		result, err := (&Secret{Name: "creds", Namespace: testNamespace, StringData: map[string]interface{}{"user": "root"}, DryRun: true}).Update(ctx, cm)
+		require.NoError(t, err)
+		assert.Contains(t, result, "(dry run)")
+		assert.Equal(t, [][]string{{metav1.DryRunAll}}, *dryRuns)
+
+		secret, err := client.CoreV1().Secrets(testNamespace).Get(ctx, "creds", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, secret.StringData)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		cm, client, dryRuns := setup()
+		result, err := (&PersistentVolumeClaim{Name: "data", Namespace: testNamespace, Storage: "1Gi"}).Create(ctx, cm)
+		require.NoError(t, err)
+		assert.NotContains(t, result, "dry run")
+		assert.Equal(t, [][]string{nil}, *dryRuns)
+
+		_, err = client.CoreV1().PersistentVolumeClaims(testNamespace).Get(ctx, "data", metav1.GetOptions{})
+		assert.NoError(t, err)
+	})
+}
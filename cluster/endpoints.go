@@ -0,0 +1,103 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/basebandit/kai"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EndpointSlices lists the EndpointSlices of a namespace, the objects that
+// carry the actual backends of every service.
+type EndpointSlices struct {
+	Namespace     string
+	LabelSelector string // e.g. kubernetes.io/service-name=web
+}
+
+// List reports each slice with its service, ports and how many of its
+// addresses are ready. An endpoint without a ready condition counts as ready,
+// as it does for the service proxy.
+func (e *EndpointSlices) List(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := e.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	slices, err := client.DiscoveryV1().EndpointSlices(namespace).List(timeoutCtx, metav1.ListOptions{LabelSelector: e.LabelSelector})
+	if err != nil {
+		return "", fmt.Errorf("failed to list endpoint slices: %w", err)
+	}
+	if len(slices.Items) == 0 {
+		if e.LabelSelector != "" {
+			return fmt.Sprintf("No endpoint slices matching %q found in namespace %q", e.LabelSelector, namespace), nil
+		}
+		return fmt.Sprintf("No endpoint slices found in namespace %q", namespace), nil
+	}
+
+	return formatEndpointSliceList(slices.Items, namespace), nil
+}
+
+func formatEndpointSliceList(slices []discoveryv1.EndpointSlice, namespace string) string {
+	sort.Slice(slices, func(i, j int) bool {
+		si, sj := slices[i].Labels[discoveryv1.LabelServiceName], slices[j].Labels[discoveryv1.LabelServiceName]
+		if si != sj {
+			return si < sj
+		}
+		return slices[i].Name < slices[j].Name
+	})
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Endpoint slices in namespace %q:\n", namespace)
+	for _, slice := range slices {
+		service := slice.Labels[discoveryv1.LabelServiceName]
+		if service == "" {
+			service = "<none>"
+		}
+		ready, total := 0, 0
+		for _, ep := range slice.Endpoints {
+			total += len(ep.Addresses)
+			if ep.Conditions.Ready == nil || *ep.Conditions.Ready {
+				ready += len(ep.Addresses)
+			}
+		}
+		fmt.Fprintf(&sb, "• %s: Service=%s, AddressType=%s, Ports=%s, Ready=%d/%d\n",
+			slice.Name, service, slice.AddressType, formatEndpointPorts(slice.Ports), ready, total)
+	}
+	fmt.Fprintf(&sb, "\nTotal: %d EndpointSlice(s)", len(slices))
+	return sb.String()
+}
+
+// formatEndpointPorts renders ports as name:port/protocol, omitting the name
+// when it is empty.
+func formatEndpointPorts(ports []discoveryv1.EndpointPort) string {
+	if len(ports) == 0 {
+		return "<none>"
+	}
+	parts := make([]string, 0, len(ports))
+	for _, p := range ports {
+		port := "<any>"
+		if p.Port != nil {
+			port = fmt.Sprint(*p.Port)
+		}
+		if p.Protocol != nil {
+			port += "/" + string(*p.Protocol)
+		}
+		if p.Name != nil && *p.Name != "" {
+			port = *p.Name + ":" + port
+		}
+		parts = append(parts, port)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,71 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestEndpointSlicesList(t *testing.T) {
+	ctx := context.Background()
+
+	endpoint := func(addr string, ready bool) discoveryv1.Endpoint {
+		return discoveryv1.Endpoint{Addresses: []string{addr}, Conditions: discoveryv1.EndpointConditions{Ready: ptr(ready)}}
+	}
+	slice := func(name, service string, ports []discoveryv1.EndpointPort, endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+				Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints:   endpoints,
+			Ports:       ports,
+		}
+	}
+	tcp := corev1.ProtocolTCP
+	setup := func() *testmocks.MockClusterManager {
+		client := fake.NewSimpleClientset(
+			slice("web-abc12", "web",
+				[]discoveryv1.EndpointPort{{Name: ptr("http"), Port: ptr(int32(8080)), Protocol: &tcp}},
+				endpoint("10.244.0.5", true), endpoint("10.244.0.6", true), endpoint("10.244.0.7", false)),
+			slice("db-x9k2p", "db",
+				[]discoveryv1.EndpointPort{{Port: ptr(int32(5432)), Protocol: &tcp}},
+				endpoint("10.244.1.3", true)),
+		)
+		cm := testmocks.NewMockClusterManager()
+		cm.On("GetCurrentClient").Return(client, nil)
+		return cm
+	}
+
+	t.Run("TwoServices", func(t *testing.T) {
+		result, err := (&EndpointSlices{Namespace: testNamespace}).List(ctx, setup())
+		require.NoError(t, err)
+		assert.Equal(t, `Endpoint slices in namespace "test-namespace":
+• db-x9k2p: Service=db, AddressType=IPv4, Ports=5432/TCP, Ready=1/1
+• web-abc12: Service=web, AddressType=IPv4, Ports=http:8080/TCP, Ready=2/3
+
+Total: 2 EndpointSlice(s)`, result)
+	})
+
+	t.Run("ByServiceName", func(t *testing.T) {
+		result, err := (&EndpointSlices{Namespace: testNamespace, LabelSelector: "kubernetes.io/service-name=web"}).List(ctx, setup())
+		require.NoError(t, err)
+		assert.Contains(t, result, "web-abc12")
+		assert.NotContains(t, result, "db-x9k2p")
+	})
+
+	t.Run("NoneMatching", func(t *testing.T) {
+		result, err := (&EndpointSlices{Namespace: testNamespace, LabelSelector: "kubernetes.io/service-name=cache"}).List(ctx, setup())
+		require.NoError(t, err)
+		assert.Equal(t, `No endpoint slices matching "kubernetes.io/service-name=cache" found in namespace "test-namespace"`, result)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
 )
 
 // Event represents a query for Kubernetes events.
@@ -18,13 +19,14 @@ type Event struct {
 	Namespace      string
 	AllNamespaces  bool
 	Type           string // "Warning" or "Normal"; empty means all types
+	InvolvedKind   string // filter by involved object kind, such as Pod or Deployment
 	InvolvedObject string // filter to a single involved object by name
 	Limit          int64
 }
 
 // List returns events for the requested scope, most recent first.
 func (e *Event) List(ctx context.Context, cm kai.ClusterManager) (string, error) {
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting client: %w", err)
 	}
@@ -33,7 +35,7 @@ func (e *Event) List(ctx context.Context, cm kai.ClusterManager) (string, error)
 	if !e.AllNamespaces {
 		namespace = e.Namespace
 		if namespace == "" {
-			namespace = cm.GetCurrentNamespace()
+			namespace = kai.CurrentNamespace(ctx, cm)
 		}
 	}
 
@@ -46,6 +48,9 @@ func (e *Event) List(ctx context.Context, cm kai.ClusterManager) (string, error)
 	if e.Type != "" {
 		selectors = append(selectors, fields.OneTermEqualSelector("type", e.Type))
 	}
+	if e.InvolvedKind != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("involvedObject.kind", e.InvolvedKind))
+	}
 	if e.InvolvedObject != "" {
 		selectors = append(selectors, fields.OneTermEqualSelector("involvedObject.name", e.InvolvedObject))
 	}
@@ -78,6 +83,30 @@ func eventTime(e corev1.Event) metav1.Time {
 	return e.FirstTimestamp
 }
 
+// objectEvents returns up to limit events about a single object, most recent
+// first.
+func objectEvents(ctx context.Context, client kubernetes.Interface, namespace, kind, name string, limit int) ([]corev1.Event, error) {
+	selector := fields.Set{"involvedObject.kind": kind, "involvedObject.name": name}.AsSelector().String()
+	events, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	var matched []corev1.Event
+	for _, e := range events.Items {
+		if e.InvolvedObject.Kind == kind && e.InvolvedObject.Name == name {
+			matched = append(matched, e)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return eventTime(matched[i]).After(eventTime(matched[j]).Time)
+	})
+	if limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, nil
+}
+
 func formatEventList(events *corev1.EventList, allNamespaces bool) string {
 	items := make([]corev1.Event, len(events.Items))
 	copy(items, events.Items)
@@ -9,6 +9,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func newEvent(name, namespace, evType, reason, objName string) *corev1.Event {
@@ -93,4 +94,18 @@ func TestEventList(t *testing.T) {
 		assert.Contains(t, result, "ns: "+otherNamespace)
 		assert.Contains(t, result, "count: 5")
 	})
+	t.Run("FiltersByInvolvedKindAndName", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset()
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		event := &Event{Namespace: defaultNamespace, Type: "Warning", InvolvedKind: "Deployment", InvolvedObject: "web"}
+		_, err := event.List(ctx, mockCM)
+		assert.NoError(t, err)
+
+		actions := fakeClient.Actions()
+		assert.Len(t, actions, 1)
+		list := actions[0].(k8stesting.ListActionImpl)
+		assert.Equal(t, "type=Warning,involvedObject.kind=Deployment,involvedObject.name=web", list.ListOptions.FieldSelector)
+	})
 }
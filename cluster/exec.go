@@ -0,0 +1,183 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+// defaultContainerAnnotation names the container kubectl picks when a pod
+// has several and none is given.
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// execTimeout bounds how long a command run through exec may take.
+const execTimeout = 60 * time.Second
+
+// newPodExecutor opens the streaming connection to the pods/exec
+// subresource. Tests replace it, since the fake clientset cannot stream.
+var newPodExecutor = newStreamExecutor
+
+// Exec runs command in a container of the pod and returns its combined
+// stdout and stderr along with the exit code. A command that exits non-zero
+// is reported, not returned as an error. When container is empty, the pod's
+// only container is used, or the one its default-container annotation
+// names.
+func (p *Pod) Exec(ctx context.Context, cm kai.ClusterManager, container string, command []string) (string, error) {
+	if len(command) == 0 {
+		return "", errors.New("command is required")
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, execTimeout)
+	defer cancel()
+
+	executor, container, err := p.execExecutor(timeoutCtx, cm, container, command, false)
+	if err != nil {
+		return "", err
+	}
+
+	var stdout, stderr bytes.Buffer
+	exitCode := 0
+	err = executor.StreamWithContext(timeoutCtx, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr})
+	if err != nil {
+		var exitErr exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return "", fmt.Errorf("failed to exec in container %q: %w", container, err)
+		}
+		exitCode = exitErr.ExitStatus()
+	}
+
+	return formatExecResult(p.Name, container, command, exitCode, stdout.String(), stderr.String()), nil
+}
+
+// execExecutor resolves the container command runs in and opens the
+// pods/exec stream for it, with stdin attached when stdin is set. It
+// returns the container name alongside, for messages.
+func (p *Pod) execExecutor(ctx context.Context, cm kai.ClusterManager, container string, command []string, stdin bool) (remotecommand.Executor, string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return nil, "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	config, err := kai.CurrentRESTConfig(ctx, cm)
+	if err != nil {
+		return nil, "", fmt.Errorf("error getting REST config: %w", err)
+	}
+
+	namespace := p.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, p.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, "", fmt.Errorf("pod %q not found in namespace %q", p.Name, namespace)
+		}
+		return nil, "", fmt.Errorf("failed to get pod %q: %w", p.Name, err)
+	}
+
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return nil, "", fmt.Errorf("cannot exec into pod %q: it has completed (phase %s)", p.Name, pod.Status.Phase)
+	}
+
+	container, err = execContainer(pod, container)
+	if err != nil {
+		return nil, "", err
+	}
+
+	reqURL, err := url.Parse(fmt.Sprintf("%s/api/v1/namespaces/%s/pods/%s/exec", strings.TrimRight(config.Host, "/"), namespace, p.Name))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+	query, err := scheme.ParameterCodec.EncodeParameters(&corev1.PodExecOptions{
+		Container: container,
+		Command:   command,
+		Stdin:     stdin,
+		Stdout:    true,
+		Stderr:    true,
+	}, corev1.SchemeGroupVersion)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode exec options: %w", err)
+	}
+	reqURL.RawQuery = query.Encode()
+
+	executor, err := newPodExecutor(config, "POST", reqURL)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create executor: %w", err)
+	}
+	return executor, container, nil
+}
+
+// execContainer picks the container to exec into, refusing to guess when
+// the pod has several and nothing says which one is meant.
+func execContainer(pod *corev1.Pod, container string) (string, error) {
+	names := make([]string, 0, len(pod.Spec.Containers))
+	for _, c := range pod.Spec.Containers {
+		names = append(names, c.Name)
+	}
+
+	if container != "" {
+		for _, name := range names {
+			if name == container {
+				return container, nil
+			}
+		}
+		return "", fmt.Errorf("container %q not found in pod %q; available containers: %s", container, pod.Name, strings.Join(names, ", "))
+	}
+
+	switch len(names) {
+	case 0:
+		return "", fmt.Errorf("no containers found in pod %q", pod.Name)
+	case 1:
+		return names[0], nil
+	}
+
+	if annotated := pod.Annotations[defaultContainerAnnotation]; annotated != "" {
+		for _, name := range names {
+			if name == annotated {
+				return name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("pod %q has %d containers (%s); specify container", pod.Name, len(names), strings.Join(names, ", "))
+}
+
+func formatExecResult(podName, container string, command []string, exitCode int, stdout, stderr string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Ran %q in %s/%s (exit code %d)\n", strings.Join(command, " "), podName, container, exitCode)
+
+	if stdout == "" && stderr == "" {
+		sb.WriteString("\n(no output)")
+		return sb.String()
+	}
+	if stdout != "" {
+		sb.WriteString("\nstdout:\n")
+		sb.WriteString(truncateExecOutput(stdout))
+	}
+	if stderr != "" {
+		sb.WriteString("\nstderr:\n")
+		sb.WriteString(truncateExecOutput(stderr))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// truncateExecOutput keeps the tail of the output within maxLogBytes, as the
+// end of a command's output is usually what explains its result.
+func truncateExecOutput(output string) string {
+	if len(output) <= maxLogBytes {
+		return strings.TrimRight(output, "\n") + "\n"
+	}
+	return fmt.Sprintf("[truncated to the last %d bytes]\n%s\n", maxLogBytes, strings.TrimRight(output[len(output)-maxLogBytes:], "\n"))
+}
@@ -0,0 +1,208 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+const execPodName = "test-pod"
+
+// fakeExecutor writes canned output to the exec streams and keeps what
+// it reads from stdin.
+type fakeExecutor struct {
+	stdout, stderr string
+	err            error
+	stdin          bytes.Buffer
+}
+
+func (f *fakeExecutor) Stream(options remotecommand.StreamOptions) error {
+	return f.StreamWithContext(context.Background(), options)
+}
+
+func (f *fakeExecutor) StreamWithContext(_ context.Context, options remotecommand.StreamOptions) error {
+	if options.Stdin != nil {
+		_, _ = io.Copy(&f.stdin, options.Stdin)
+	}
+	if options.Stdout != nil {
+		_, _ = io.WriteString(options.Stdout, f.stdout)
+	}
+	if options.Stderr != nil {
+		_, _ = io.WriteString(options.Stderr, f.stderr)
+	}
+	return f.err
+}
+
+func stubPodExecutor(t *testing.T, executor *fakeExecutor) *url.URL {
+	t.Helper()
+	var requested url.URL
+	original := newPodExecutor
+	newPodExecutor = func(_ *rest.Config, method string, u *url.URL) (remotecommand.Executor, error) {
+		assert.Equal(t, "POST", method)
+		requested = *u
+		return executor, nil
+	}
+	t.Cleanup(func() { newPodExecutor = original })
+	return &requested
+}
+
+func TestPodExec(t *testing.T) {
+	ctx := context.Background()
+	config := &rest.Config{Host: "https://cluster.example:6443"}
+
+	execPod := func(phase corev1.PodPhase, annotations map[string]string, containers ...string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: execPodName, Namespace: testNamespace, Annotations: annotations},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+		for _, name := range containers {
+			pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: name, Image: "busybox"})
+		}
+		return pod
+	}
+
+	newCM := func(objects ...*corev1.Pod) *testmocks.MockClusterManager {
+		clientset := fake.NewSimpleClientset()
+		for _, obj := range objects {
+			_ = clientset.Tracker().Add(obj)
+		}
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(clientset, nil)
+		mockCM.On("GetCurrentRESTConfig").Return(config, nil)
+		return mockCM
+	}
+
+	t.Run("SingleContainer", func(t *testing.T) {
+		requested := stubPodExecutor(t, &fakeExecutor{stdout: "hello\n", stderr: "warning: something\n"})
+		mockCM := newCM(execPod(corev1.PodRunning, nil, "app"))
+
+		pod := &Pod{Name: execPodName, Namespace: testNamespace}
+		result, err := pod.Exec(ctx, mockCM, "", []string{"echo", "hello"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, `Ran "echo hello" in test-pod/app (exit code 0)`)
+		assert.Contains(t, result, "stdout:\nhello")
+		assert.Contains(t, result, "stderr:\nwarning: something")
+
+		assert.Equal(t, "/api/v1/namespaces/test-namespace/pods/test-pod/exec", requested.Path)
+		query := requested.Query()
+		assert.Equal(t, "app", query.Get("container"))
+		assert.Equal(t, []string{"echo", "hello"}, query["command"])
+		assert.Equal(t, "true", query.Get("stdout"))
+		assert.Equal(t, "true", query.Get("stderr"))
+		assert.Empty(t, query.Get("stdin"))
+	})
+
+	t.Run("NonZeroExit", func(t *testing.T) {
+		stubPodExecutor(t, &fakeExecutor{
+			stderr: "ls: /missing: No such file or directory\n",
+			err:    exec.CodeExitError{Err: errors.New("command terminated with exit code 2"), Code: 2},
+		})
+		mockCM := newCM(execPod(corev1.PodRunning, nil, "app"))
+
+		pod := &Pod{Name: execPodName, Namespace: testNamespace}
+		result, err := pod.Exec(ctx, mockCM, "app", []string{"ls", "/missing"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "(exit code 2)")
+		assert.Contains(t, result, "No such file or directory")
+	})
+
+	t.Run("StreamError", func(t *testing.T) {
+		stubPodExecutor(t, &fakeExecutor{err: errors.New("upgrade request required")})
+		mockCM := newCM(execPod(corev1.PodRunning, nil, "app"))
+
+		pod := &Pod{Name: execPodName, Namespace: testNamespace}
+		_, err := pod.Exec(ctx, mockCM, "", []string{"true"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "upgrade request required")
+	})
+
+	t.Run("AmbiguousContainer", func(t *testing.T) {
+		stubPodExecutor(t, &fakeExecutor{})
+		mockCM := newCM(execPod(corev1.PodRunning, nil, "app", "sidecar"))
+
+		pod := &Pod{Name: execPodName, Namespace: testNamespace}
+		_, err := pod.Exec(ctx, mockCM, "", []string{"true"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `pod "test-pod" has 2 containers (app, sidecar); specify container`)
+	})
+
+	t.Run("DefaultContainerAnnotation", func(t *testing.T) {
+		requested := stubPodExecutor(t, &fakeExecutor{stdout: "ok"})
+		mockCM := newCM(execPod(corev1.PodRunning, map[string]string{defaultContainerAnnotation: "sidecar"}, "app", "sidecar"))
+
+		pod := &Pod{Name: execPodName, Namespace: testNamespace}
+		result, err := pod.Exec(ctx, mockCM, "", []string{"true"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "test-pod/sidecar")
+		assert.Equal(t, "sidecar", requested.Query().Get("container"))
+	})
+
+	t.Run("UnknownContainer", func(t *testing.T) {
+		stubPodExecutor(t, &fakeExecutor{})
+		mockCM := newCM(execPod(corev1.PodRunning, nil, "app", "sidecar"))
+
+		pod := &Pod{Name: execPodName, Namespace: testNamespace}
+		_, err := pod.Exec(ctx, mockCM, "db", []string{"true"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `container "db" not found in pod "test-pod"; available containers: app, sidecar`)
+	})
+
+	t.Run("CompletedPod", func(t *testing.T) {
+		stubPodExecutor(t, &fakeExecutor{})
+		mockCM := newCM(execPod(corev1.PodSucceeded, nil, "app"))
+
+		pod := &Pod{Name: execPodName, Namespace: testNamespace}
+		_, err := pod.Exec(ctx, mockCM, "", []string{"true"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "it has completed")
+	})
+
+	t.Run("PodNotFound", func(t *testing.T) {
+		stubPodExecutor(t, &fakeExecutor{})
+		mockCM := newCM()
+
+		pod := &Pod{Name: execPodName, Namespace: testNamespace}
+		_, err := pod.Exec(ctx, mockCM, "", []string{"true"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `pod "test-pod" not found in namespace "test-namespace"`)
+	})
+
+	t.Run("TruncatesLargeOutput", func(t *testing.T) {
+		stubPodExecutor(t, &fakeExecutor{stdout: strings.Repeat("x", maxLogBytes) + "tail-marker"})
+		mockCM := newCM(execPod(corev1.PodRunning, nil, "app"))
+
+		pod := &Pod{Name: execPodName, Namespace: testNamespace}
+		result, err := pod.Exec(ctx, mockCM, "", []string{"cat", "big"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "[truncated to the last")
+		assert.True(t, strings.HasSuffix(result, "tail-marker"))
+	})
+
+	t.Run("EmptyCommand", func(t *testing.T) {
+		pod := &Pod{Name: execPodName, Namespace: testNamespace}
+		_, err := pod.Exec(ctx, testmocks.NewMockClusterManager(), "", nil)
+		assert.Error(t, err)
+	})
+}
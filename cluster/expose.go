@@ -0,0 +1,149 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/basebandit/kai"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Expose creates a Service that selects the deployment's pods, the way
+// kubectl expose does: the selector is the deployment's matchLabels, the
+// Service carries the deployment's labels and is named after it unless
+// serviceName is set. With port set, a single port is exposed and targets
+// targetPort, or port itself when targetPort is zero. Otherwise every
+// container port in the pod template is exposed as is.
+func (d *Deployment) Expose(ctx context.Context, cm kai.ClusterManager, serviceName, serviceType string, port, targetPort int32) (string, error) {
+	var result string
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return result, fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	namespace := d.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	if serviceType == "" {
+		serviceType = string(corev1.ServiceTypeClusterIP)
+	}
+	switch corev1.ServiceType(serviceType) {
+	case corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer:
+	default:
+		return result, fmt.Errorf("invalid service type %q: must be ClusterIP, NodePort or LoadBalancer", serviceType)
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(timeoutCtx, d.Name, metav1.GetOptions{})
+	if err != nil {
+		return result, fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	selector, err := exposeSelector(deployment)
+	if err != nil {
+		return result, err
+	}
+
+	ports, err := exposePorts(deployment, port, targetPort)
+	if err != nil {
+		return result, err
+	}
+
+	if serviceName == "" {
+		serviceName = deployment.Name
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName,
+			Namespace: namespace,
+			Labels:    deployment.Labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceType(serviceType),
+			Selector: selector,
+			Ports:    ports,
+		},
+	}
+
+	created, err := client.CoreV1().Services(namespace).Create(timeoutCtx, service, metav1.CreateOptions{})
+	if err != nil {
+		return result, fmt.Errorf("failed to create service: %w", err)
+	}
+
+	portList := make([]string, 0, len(created.Spec.Ports))
+	for _, p := range created.Spec.Ports {
+		portList = append(portList, fmt.Sprintf("%d->%s/%s", p.Port, p.TargetPort.String(), p.Protocol))
+	}
+
+	result = fmt.Sprintf("Deployment %q exposed as %s service %q in namespace %q (ports: %s)",
+		deployment.Name, created.Spec.Type, created.Name, namespace, strings.Join(portList, ", "))
+	return result, nil
+}
+
+// exposeSelector returns the labels a Service can select the deployment's
+// pods by. Services only support equality selectors, so deployments that
+// rely on matchExpressions cannot be exposed.
+func exposeSelector(deployment *appsv1.Deployment) (map[string]string, error) {
+	if deployment.Spec.Selector == nil || len(deployment.Spec.Selector.MatchLabels) == 0 {
+		return nil, fmt.Errorf("deployment %q has no matchLabels selector to expose", deployment.Name)
+	}
+	if len(deployment.Spec.Selector.MatchExpressions) > 0 {
+		return nil, fmt.Errorf("deployment %q uses matchExpressions, which a service selector cannot express", deployment.Name)
+	}
+	return deployment.Spec.Selector.MatchLabels, nil
+}
+
+// exposePorts builds the Service ports: a single port when one was
+// requested, otherwise one per container port in the pod template.
+func exposePorts(deployment *appsv1.Deployment, port, targetPort int32) ([]corev1.ServicePort, error) {
+	if port != 0 {
+		if targetPort == 0 {
+			targetPort = port
+		}
+		return []corev1.ServicePort{{
+			Port:       port,
+			TargetPort: intstr.FromInt32(targetPort),
+			Protocol:   corev1.ProtocolTCP,
+		}}, nil
+	}
+
+	var ports []corev1.ServicePort
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		for _, cp := range container.Ports {
+			protocol := cp.Protocol
+			if protocol == "" {
+				protocol = corev1.ProtocolTCP
+			}
+			ports = append(ports, corev1.ServicePort{
+				Name:       cp.Name,
+				Port:       cp.ContainerPort,
+				TargetPort: intstr.FromInt32(cp.ContainerPort),
+				Protocol:   protocol,
+			})
+		}
+	}
+	if len(ports) == 0 {
+		return nil, fmt.Errorf("deployment %q declares no container ports; specify port to expose", deployment.Name)
+	}
+
+	// A Service with more than one port requires every port to be named.
+	if len(ports) > 1 {
+		for i := range ports {
+			if ports[i].Name == "" {
+				ports[i].Name = fmt.Sprintf("port-%d", i+1)
+			}
+		}
+	}
+	return ports, nil
+}
@@ -0,0 +1,140 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeploymentExpose(t *testing.T) {
+	ctx := context.Background()
+
+	newExposable := func(ports ...corev1.ContainerPort) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web",
+				Namespace: testNamespace,
+				Labels:    map[string]string{"app": "web", "tier": "frontend"},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web", "version": "v1"}},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "web", Image: "nginx", Ports: ports}},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("ClusterIPFromTemplatePort", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(newExposable(corev1.ContainerPort{ContainerPort: 8080}))
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		d := &Deployment{Name: "web", Namespace: testNamespace}
+		result, err := d.Expose(ctx, mockCM, "", "", 0, 0)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, `exposed as ClusterIP service "web"`)
+
+		svc, err := fakeClient.CoreV1().Services(testNamespace).Get(ctx, "web", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, corev1.ServiceTypeClusterIP, svc.Spec.Type)
+		assert.Equal(t, map[string]string{"app": "web"}, svc.Spec.Selector)
+		assert.Equal(t, map[string]string{"app": "web", "tier": "frontend"}, svc.Labels)
+		assert.Len(t, svc.Spec.Ports, 1)
+		assert.Equal(t, int32(8080), svc.Spec.Ports[0].Port)
+		assert.Equal(t, intstr.FromInt32(8080), svc.Spec.Ports[0].TargetPort)
+		assert.Equal(t, corev1.ProtocolTCP, svc.Spec.Ports[0].Protocol)
+	})
+
+	t.Run("ExplicitPort", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(newExposable(corev1.ContainerPort{ContainerPort: 8080}))
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		d := &Deployment{Name: "web", Namespace: testNamespace}
+		result, err := d.Expose(ctx, mockCM, "web-public", "NodePort", 80, 8080)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "80->8080/TCP")
+
+		svc, err := fakeClient.CoreV1().Services(testNamespace).Get(ctx, "web-public", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, corev1.ServiceTypeNodePort, svc.Spec.Type)
+		assert.Equal(t, int32(80), svc.Spec.Ports[0].Port)
+		assert.Equal(t, intstr.FromInt32(8080), svc.Spec.Ports[0].TargetPort)
+	})
+
+	t.Run("MultiplePortsAreNamed", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(newExposable(
+			corev1.ContainerPort{Name: "http", ContainerPort: 8080},
+			corev1.ContainerPort{ContainerPort: 9090, Protocol: corev1.ProtocolUDP},
+		))
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		d := &Deployment{Name: "web", Namespace: testNamespace}
+		_, err := d.Expose(ctx, mockCM, "", "", 0, 0)
+		assert.NoError(t, err)
+
+		svc, _ := fakeClient.CoreV1().Services(testNamespace).Get(ctx, "web", metav1.GetOptions{})
+		assert.Len(t, svc.Spec.Ports, 2)
+		assert.Equal(t, "http", svc.Spec.Ports[0].Name)
+		assert.Equal(t, "port-2", svc.Spec.Ports[1].Name)
+		assert.Equal(t, corev1.ProtocolUDP, svc.Spec.Ports[1].Protocol)
+	})
+
+	t.Run("NoPorts", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(newExposable()), nil)
+
+		d := &Deployment{Name: "web", Namespace: testNamespace}
+		_, err := d.Expose(ctx, mockCM, "", "", 0, 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "declares no container ports")
+	})
+
+	t.Run("MatchExpressions", func(t *testing.T) {
+		deployment := newExposable(corev1.ContainerPort{ContainerPort: 8080})
+		deployment.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{
+			{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"frontend"}},
+		}
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(deployment), nil)
+
+		d := &Deployment{Name: "web", Namespace: testNamespace}
+		_, err := d.Expose(ctx, mockCM, "", "", 0, 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "matchExpressions")
+	})
+
+	t.Run("InvalidType", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(), nil)
+
+		d := &Deployment{Name: "web", Namespace: testNamespace}
+		_, err := d.Expose(ctx, mockCM, "", "ExternalName", 80, 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid service type")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(), nil)
+
+		d := &Deployment{Name: "missing", Namespace: testNamespace}
+		_, err := d.Expose(ctx, mockCM, "", "", 0, 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get deployment")
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -48,6 +49,29 @@ func formatPod(pod *corev1.Pod) string {
 				break
 			}
 		}
+
+		if len(container.VolumeMounts) > 0 {
+			result += "   Mounts:\n"
+			for _, mount := range container.VolumeMounts {
+				mode := "rw"
+				if mount.ReadOnly {
+					mode = "ro"
+				}
+				path := mount.MountPath
+				if mount.SubPath != "" {
+					path += fmt.Sprintf(" (subPath: %s)", mount.SubPath)
+				}
+				result += fmt.Sprintf("   - %s from %s (%s)\n", path, mount.Name, mode)
+			}
+		}
+	}
+
+	// Add volumes
+	if len(pod.Spec.Volumes) > 0 {
+		result += "\nVolumes:\n"
+		for _, volume := range pod.Spec.Volumes {
+			result += fmt.Sprintf("- %s: %s\n", volume.Name, formatVolumeSource(volume.VolumeSource))
+		}
 	}
 
 	// Add labels
@@ -61,6 +85,37 @@ func formatPod(pod *corev1.Pod) string {
 	return result
 }
 
+// formatVolumeSource summarizes where a pod volume comes from.
+func formatVolumeSource(src corev1.VolumeSource) string {
+	switch {
+	case src.Secret != nil:
+		return fmt.Sprintf("Secret (secretName: %s)", src.Secret.SecretName)
+	case src.ConfigMap != nil:
+		return fmt.Sprintf("ConfigMap (name: %s)", src.ConfigMap.Name)
+	case src.PersistentVolumeClaim != nil:
+		return fmt.Sprintf("PersistentVolumeClaim (claimName: %s, readOnly: %t)",
+			src.PersistentVolumeClaim.ClaimName, src.PersistentVolumeClaim.ReadOnly)
+	case src.EmptyDir != nil:
+		if src.EmptyDir.Medium != "" {
+			return fmt.Sprintf("EmptyDir (medium: %s)", src.EmptyDir.Medium)
+		}
+		return "EmptyDir"
+	case src.HostPath != nil:
+		return fmt.Sprintf("HostPath (path: %s)", src.HostPath.Path)
+	case src.Projected != nil:
+		return fmt.Sprintf("Projected (%d source(s))", len(src.Projected.Sources))
+	case src.DownwardAPI != nil:
+		return "DownwardAPI"
+	case src.NFS != nil:
+		return fmt.Sprintf("NFS (server: %s, path: %s)", src.NFS.Server, src.NFS.Path)
+	case src.CSI != nil:
+		return fmt.Sprintf("CSI (driver: %s)", src.CSI.Driver)
+	case src.Ephemeral != nil:
+		return "Ephemeral"
+	}
+	return "<unknown>"
+}
+
 func formatPodList(pods *corev1.PodList, allNamespaces bool, limit int64, resultText string) string {
 	// Format the pods list
 	for _, pod := range pods.Items {
@@ -117,6 +172,15 @@ func formatPodList(pods *corev1.PodList, allNamespaces bool, limit int64, result
 	return resultText
 }
 
+// continueHint tells the caller how to fetch the next page of a truncated
+// list. It is empty when the list is complete.
+func continueHint(token string) string {
+	if token == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n\nMore results available. Pass continue=%q to fetch the next page.", token)
+}
+
 func formatDeploymentList(deployments *appsv1.DeploymentList) string {
 	var resultText string
 	for _, deployment := range deployments.Items {
@@ -640,7 +704,7 @@ func formatNamespaceList(namespaces *corev1.NamespaceList, labelSelector string)
 	return result.String()
 }
 
-func formatConfigMap(cm *corev1.ConfigMap) string {
+func formatConfigMap(cm *corev1.ConfigMap, encoding string) string {
 	result := fmt.Sprintf("ConfigMap: %s\n", cm.Name)
 	result += fmt.Sprintf("Namespace: %s\n", cm.Namespace)
 	result += fmt.Sprintf("Created: %s\n", cm.CreationTimestamp.Time.Format(time.RFC3339))
@@ -657,9 +721,13 @@ func formatConfigMap(cm *corev1.ConfigMap) string {
 	}
 
 	if len(cm.BinaryData) > 0 {
-		result += "\nBinary Data:\n"
+		result += fmt.Sprintf("\nBinary Data (%s):\n", encoding)
 		for k, v := range cm.BinaryData {
-			result += fmt.Sprintf("- %s: (%d bytes)\n", k, len(v))
+			encoded := encodeBinary(v, encoding)
+			if len(encoded) > 100 {
+				encoded = encoded[:100] + "..."
+			}
+			result += fmt.Sprintf("- %s: %s (%d bytes)\n", k, encoded, len(v))
 		}
 	}
 
@@ -775,23 +843,22 @@ func formatJob(job *batchv1.Job) string {
 		result += fmt.Sprintf("Parallelism: %d\n", *job.Spec.Parallelism)
 	}
 
-	if job.Status.Active > 0 {
-		result += fmt.Sprintf("Active: %d\n", job.Status.Active)
-	}
-	if job.Status.Failed > 0 {
-		result += fmt.Sprintf("Failed: %d\n", job.Status.Failed)
-	}
+	result += fmt.Sprintf("Status: %s\n", jobStatus(job))
+	result += fmt.Sprintf("Active: %d\n", job.Status.Active)
+	result += fmt.Sprintf("Succeeded: %d\n", job.Status.Succeeded)
+	result += fmt.Sprintf("Failed: %d\n", job.Status.Failed)
 
 	result += fmt.Sprintf("Created: %s\n", job.CreationTimestamp.Time.Format(time.RFC3339))
-	result += fmt.Sprintf("Succeeded: %d\n", job.Status.Succeeded)
 
 	if job.Status.StartTime != nil {
 		result += fmt.Sprintf("Start Time: %s\n", job.Status.StartTime.Time.Format(time.RFC3339))
 	}
 	if job.Status.CompletionTime != nil {
 		result += fmt.Sprintf("Completion Time: %s\n", job.Status.CompletionTime.Time.Format(time.RFC3339))
-		duration := job.Status.CompletionTime.Time.Sub(job.Status.StartTime.Time)
-		result += fmt.Sprintf("Duration: %s\n", formatDuration(duration))
+		if job.Status.StartTime != nil {
+			duration := job.Status.CompletionTime.Time.Sub(job.Status.StartTime.Time)
+			result += fmt.Sprintf("Duration: %s\n", formatDuration(duration))
+		}
 	}
 
 	if len(job.Labels) > 0 {
@@ -806,6 +873,39 @@ func formatJob(job *batchv1.Job) string {
 	return result
 }
 
+// jobStatus summarizes a Job as Complete, Failed, or Running from its
+// finished condition, if it has one.
+func jobStatus(job *batchv1.Job) string {
+	if condition, ok := jobFinishedCondition(job); ok {
+		if condition.Type == batchv1.JobComplete {
+			return "Complete"
+		}
+		return "Failed"
+	}
+	return "Running"
+}
+
+// formatJobOutcome reports how a finished Job ended, given its Complete or
+// Failed condition.
+func formatJobOutcome(job *batchv1.Job, condition batchv1.JobCondition) string {
+	if condition.Type == batchv1.JobComplete {
+		result := fmt.Sprintf("Job %q completed", job.Name)
+		if job.Status.StartTime != nil && job.Status.CompletionTime != nil {
+			result += " in " + formatDuration(job.Status.CompletionTime.Sub(job.Status.StartTime.Time))
+		}
+		return result + fmt.Sprintf(" (succeeded: %d, failed: %d)", job.Status.Succeeded, job.Status.Failed)
+	}
+
+	result := fmt.Sprintf("Job %q failed (succeeded: %d, failed: %d)", job.Name, job.Status.Succeeded, job.Status.Failed)
+	if condition.Reason != "" {
+		result += fmt.Sprintf("\nReason: %s", condition.Reason)
+	}
+	if condition.Message != "" {
+		result += fmt.Sprintf("\nMessage: %s", condition.Message)
+	}
+	return result
+}
+
 func formatJobList(jobs *batchv1.JobList, includeNamespace bool) string {
 	var result strings.Builder
 
@@ -1157,3 +1257,322 @@ func formatIngressList(ingresses *networkingv1.IngressList, includeNamespace boo
 
 	return result.String()
 }
+
+func formatStatefulSet(statefulSet *appsv1.StatefulSet) string {
+	var result strings.Builder
+
+	var replicas int32
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+
+	fmt.Fprintf(&result, "StatefulSet: %s\n", statefulSet.Name)
+	fmt.Fprintf(&result, "Namespace: %s\n", statefulSet.Namespace)
+	fmt.Fprintf(&result, "Service Name: %s\n", statefulSet.Spec.ServiceName)
+	fmt.Fprintf(&result, "Replicas: %d/%d (ready/desired)\n", statefulSet.Status.ReadyReplicas, replicas)
+	fmt.Fprintf(&result, "Update Strategy: %s\n", formatStatefulSetUpdateStrategy(statefulSet.Spec.UpdateStrategy))
+	fmt.Fprintf(&result, "Created: %s\n", statefulSet.CreationTimestamp.Format(time.RFC3339))
+
+	if len(statefulSet.Labels) > 0 {
+		result.WriteString("\nLabels:\n")
+		for k, v := range statefulSet.Labels {
+			fmt.Fprintf(&result, "- %s: %s\n", k, v)
+		}
+	}
+
+	if len(statefulSet.Spec.Template.Spec.Containers) > 0 {
+		fmt.Fprintf(&result, "\nImage: %s\n", statefulSet.Spec.Template.Spec.Containers[0].Image)
+	}
+
+	return result.String()
+}
+
+func formatStatefulSetList(statefulSets *appsv1.StatefulSetList, includeNamespace bool) string {
+	var result strings.Builder
+
+	if includeNamespace {
+		result.WriteString("StatefulSets across all namespaces:\n")
+	} else {
+		fmt.Fprintf(&result, "StatefulSets in namespace %q:\n", statefulSets.Items[0].Namespace)
+	}
+
+	for _, statefulSet := range statefulSets.Items {
+		age := time.Since(statefulSet.CreationTimestamp.Time).Round(time.Second)
+
+		var replicas int32
+		if statefulSet.Spec.Replicas != nil {
+			replicas = *statefulSet.Spec.Replicas
+		}
+
+		if includeNamespace {
+			fmt.Fprintf(&result, "• %s/%s: %d/%d replicas ready - Service: %s - Age: %s",
+				statefulSet.Namespace, statefulSet.Name, statefulSet.Status.ReadyReplicas, replicas,
+				statefulSet.Spec.ServiceName, formatDuration(age))
+		} else {
+			fmt.Fprintf(&result, "• %s: %d/%d replicas ready - Service: %s - Age: %s",
+				statefulSet.Name, statefulSet.Status.ReadyReplicas, replicas,
+				statefulSet.Spec.ServiceName, formatDuration(age))
+		}
+
+		result.WriteString("\n")
+	}
+
+	fmt.Fprintf(&result, "\nTotal: %d StatefulSet(s)", len(statefulSets.Items))
+
+	return result.String()
+}
+
+// formatStatefulSetDetailed renders a StatefulSet for describe_statefulset.
+// serviceState notes whether the governing service exists and is headless.
+func formatStatefulSetDetailed(statefulSet *appsv1.StatefulSet, serviceState string) string {
+	var result strings.Builder
+
+	var replicas int32
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+
+	fmt.Fprintf(&result, "StatefulSet: %s\n", statefulSet.Name)
+	fmt.Fprintf(&result, "Namespace: %s\n", statefulSet.Namespace)
+	fmt.Fprintf(&result, "Created: %s\n", statefulSet.CreationTimestamp.Format(time.RFC3339))
+
+	if statefulSet.Spec.ServiceName == "" {
+		result.WriteString("Headless Service: <none>\n")
+	} else if serviceState != "" {
+		fmt.Fprintf(&result, "Headless Service: %s (%s)\n", statefulSet.Spec.ServiceName, serviceState)
+	} else {
+		fmt.Fprintf(&result, "Headless Service: %s\n", statefulSet.Spec.ServiceName)
+	}
+
+	fmt.Fprintf(&result, "Update Strategy: %s\n", formatStatefulSetUpdateStrategy(statefulSet.Spec.UpdateStrategy))
+	if statefulSet.Spec.PodManagementPolicy != "" {
+		fmt.Fprintf(&result, "Pod Management Policy: %s\n", statefulSet.Spec.PodManagementPolicy)
+	}
+
+	result.WriteString("\nReplicas:\n")
+	fmt.Fprintf(&result, "- Desired: %d\n", replicas)
+	fmt.Fprintf(&result, "- Current: %d\n", statefulSet.Status.CurrentReplicas)
+	fmt.Fprintf(&result, "- Ready: %d\n", statefulSet.Status.ReadyReplicas)
+	fmt.Fprintf(&result, "- Updated: %d\n", statefulSet.Status.UpdatedReplicas)
+	if statefulSet.Status.CurrentRevision != "" {
+		fmt.Fprintf(&result, "- Current Revision: %s\n", statefulSet.Status.CurrentRevision)
+	}
+	if statefulSet.Status.UpdateRevision != "" && statefulSet.Status.UpdateRevision != statefulSet.Status.CurrentRevision {
+		fmt.Fprintf(&result, "- Update Revision: %s\n", statefulSet.Status.UpdateRevision)
+	}
+
+	if statefulSet.Spec.Selector != nil && len(statefulSet.Spec.Selector.MatchLabels) > 0 {
+		result.WriteString("\nSelector:\n")
+		for k, v := range statefulSet.Spec.Selector.MatchLabels {
+			fmt.Fprintf(&result, "- %s: %s\n", k, v)
+		}
+	}
+
+	if len(statefulSet.Spec.Template.Spec.Containers) > 0 {
+		result.WriteString("\nContainers:\n")
+		for i, container := range statefulSet.Spec.Template.Spec.Containers {
+			fmt.Fprintf(&result, "%d. %s (Image: %s)\n", i+1, container.Name, container.Image)
+			for _, port := range container.Ports {
+				fmt.Fprintf(&result, "   Port: %d/%s\n", port.ContainerPort, port.Protocol)
+			}
+			for _, mount := range container.VolumeMounts {
+				fmt.Fprintf(&result, "   Mount: %s -> %s\n", mount.Name, mount.MountPath)
+			}
+		}
+	}
+
+	if len(statefulSet.Spec.VolumeClaimTemplates) > 0 {
+		result.WriteString("\nVolume Claim Templates:\n")
+		for _, claim := range statefulSet.Spec.VolumeClaimTemplates {
+			storage := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+			storageClass := "<default>"
+			if claim.Spec.StorageClassName != nil {
+				storageClass = *claim.Spec.StorageClassName
+			}
+			fmt.Fprintf(&result, "- %s: %s, %s, StorageClass: %s\n",
+				claim.Name, storage.String(), accessModesToString(claim.Spec.AccessModes), storageClass)
+		}
+	}
+
+	if len(statefulSet.Status.Conditions) > 0 {
+		result.WriteString("\nConditions:\n")
+		for _, condition := range statefulSet.Status.Conditions {
+			fmt.Fprintf(&result, "- Type: %s, Status: %s\n", condition.Type, condition.Status)
+			if condition.Message != "" {
+				fmt.Fprintf(&result, "  Message: %s\n", condition.Message)
+			}
+		}
+	}
+
+	return strings.TrimRight(result.String(), "\n")
+}
+
+func formatStatefulSetUpdateStrategy(strategy appsv1.StatefulSetUpdateStrategy) string {
+	strategyType := strategy.Type
+	if strategyType == "" {
+		strategyType = appsv1.RollingUpdateStatefulSetStrategyType
+	}
+	if strategy.RollingUpdate != nil && strategy.RollingUpdate.Partition != nil {
+		return fmt.Sprintf("%s (partition %d)", strategyType, *strategy.RollingUpdate.Partition)
+	}
+	return string(strategyType)
+}
+
+func formatHPA(hpa *autoscalingv2.HorizontalPodAutoscaler) string {
+	ref := hpa.Spec.ScaleTargetRef
+	minReplicas := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = *hpa.Spec.MinReplicas
+	}
+
+	result := fmt.Sprintf("HPA: %s\n", hpa.Name)
+	result += fmt.Sprintf("Namespace: %s\n", hpa.Namespace)
+	result += fmt.Sprintf("Target: %s/%s\n", ref.Kind, ref.Name)
+	result += fmt.Sprintf("Replicas: current %d, desired %d (min %d, max %d)\n",
+		hpa.Status.CurrentReplicas, hpa.Status.DesiredReplicas, minReplicas, hpa.Spec.MaxReplicas)
+	if hpa.Status.LastScaleTime != nil {
+		result += fmt.Sprintf("Last Scale: %s ago\n", formatDuration(time.Since(hpa.Status.LastScaleTime.Time)))
+	}
+	result += fmt.Sprintf("Created: %s\n", hpa.CreationTimestamp.Time.Format(time.RFC3339))
+
+	if len(hpa.Spec.Metrics) == 0 {
+		result += "\nMetrics: <none> (the API server default is 80% CPU utilization)\n"
+	} else {
+		result += "\nMetrics (current / target):\n"
+		for _, metric := range hpa.Spec.Metrics {
+			name, current, target := hpaMetric(metric, hpa.Status.CurrentMetrics)
+			result += fmt.Sprintf("- %s: %s / %s\n", name, current, target)
+		}
+	}
+
+	if len(hpa.Status.Conditions) > 0 {
+		result += "\nConditions:\n"
+		for _, c := range hpa.Status.Conditions {
+			result += fmt.Sprintf("- %s=%s (%s): %s\n", c.Type, c.Status, c.Reason, c.Message)
+		}
+	}
+
+	return result
+}
+
+func formatHPAList(hpas *autoscalingv2.HorizontalPodAutoscalerList, includeNamespace bool) string {
+	var result strings.Builder
+
+	if includeNamespace {
+		result.WriteString("HPAs across all namespaces:\n")
+	} else {
+		fmt.Fprintf(&result, "HPAs in namespace %q:\n", hpas.Items[0].Namespace)
+	}
+
+	for _, hpa := range hpas.Items {
+		name := hpa.Name
+		if includeNamespace {
+			name = hpa.Namespace + "/" + name
+		}
+		minReplicas := int32(1)
+		if hpa.Spec.MinReplicas != nil {
+			minReplicas = *hpa.Spec.MinReplicas
+		}
+
+		metrics := make([]string, 0, len(hpa.Spec.Metrics))
+		for _, metric := range hpa.Spec.Metrics {
+			metricName, current, target := hpaMetric(metric, hpa.Status.CurrentMetrics)
+			metrics = append(metrics, fmt.Sprintf("%s %s/%s", metricName, current, target))
+		}
+		if len(metrics) == 0 {
+			metrics = append(metrics, "<default>")
+		}
+
+		fmt.Fprintf(&result, "• %s: Target=%s/%s, Replicas=%d (desired %d), Min=%d, Max=%d, Metrics=%s, Age=%s\n",
+			name, hpa.Spec.ScaleTargetRef.Kind, hpa.Spec.ScaleTargetRef.Name,
+			hpa.Status.CurrentReplicas, hpa.Status.DesiredReplicas, minReplicas, hpa.Spec.MaxReplicas,
+			strings.Join(metrics, ", "), formatDuration(time.Since(hpa.CreationTimestamp.Time)))
+	}
+
+	fmt.Fprintf(&result, "\nTotal: %d HPA(s)", len(hpas.Items))
+
+	return result.String()
+}
+
+// hpaMetric names a metric spec and renders its target and the matching
+// current value from the autoscaler's status, or <unknown> before the
+// controller has read it.
+func hpaMetric(spec autoscalingv2.MetricSpec, statuses []autoscalingv2.MetricStatus) (name, current, target string) {
+	current = "<unknown>"
+	for _, status := range statuses {
+		if status.Type != spec.Type {
+			continue
+		}
+		switch spec.Type {
+		case autoscalingv2.ResourceMetricSourceType:
+			if status.Resource != nil && spec.Resource != nil && status.Resource.Name == spec.Resource.Name {
+				current = metricValue(status.Resource.Current)
+			}
+		case autoscalingv2.ContainerResourceMetricSourceType:
+			if status.ContainerResource != nil && spec.ContainerResource != nil &&
+				status.ContainerResource.Name == spec.ContainerResource.Name && status.ContainerResource.Container == spec.ContainerResource.Container {
+				current = metricValue(status.ContainerResource.Current)
+			}
+		case autoscalingv2.PodsMetricSourceType:
+			if status.Pods != nil && spec.Pods != nil && status.Pods.Metric.Name == spec.Pods.Metric.Name {
+				current = metricValue(status.Pods.Current)
+			}
+		case autoscalingv2.ObjectMetricSourceType:
+			if status.Object != nil && spec.Object != nil && status.Object.Metric.Name == spec.Object.Metric.Name {
+				current = metricValue(status.Object.Current)
+			}
+		case autoscalingv2.ExternalMetricSourceType:
+			if status.External != nil && spec.External != nil && status.External.Metric.Name == spec.External.Metric.Name {
+				current = metricValue(status.External.Current)
+			}
+		}
+	}
+
+	switch spec.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if spec.Resource != nil {
+			return string(spec.Resource.Name), current, metricTarget(spec.Resource.Target)
+		}
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if spec.ContainerResource != nil {
+			return fmt.Sprintf("%s (container %s)", spec.ContainerResource.Name, spec.ContainerResource.Container), current, metricTarget(spec.ContainerResource.Target)
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if spec.Pods != nil {
+			return "pods " + spec.Pods.Metric.Name, current, metricTarget(spec.Pods.Target)
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if spec.Object != nil {
+			return fmt.Sprintf("%s on %s/%s", spec.Object.Metric.Name, spec.Object.DescribedObject.Kind, spec.Object.DescribedObject.Name), current, metricTarget(spec.Object.Target)
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if spec.External != nil {
+			return "external " + spec.External.Metric.Name, current, metricTarget(spec.External.Target)
+		}
+	}
+	return string(spec.Type), current, "<unknown>"
+}
+
+func metricTarget(target autoscalingv2.MetricTarget) string {
+	switch {
+	case target.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *target.AverageUtilization)
+	case target.AverageValue != nil:
+		return target.AverageValue.String() + " (average)"
+	case target.Value != nil:
+		return target.Value.String()
+	}
+	return "<unknown>"
+}
+
+func metricValue(value autoscalingv2.MetricValueStatus) string {
+	switch {
+	case value.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *value.AverageUtilization)
+	case value.AverageValue != nil:
+		return value.AverageValue.String()
+	case value.Value != nil:
+		return value.Value.String()
+	}
+	return "<unknown>"
+}
@@ -296,6 +296,42 @@ func TestFormatPod(t *testing.T) {
 		assert.Contains(t, result, "Labels:")
 		assert.Contains(t, result, "app")
 	})
+
+	t.Run("Format pod with volumes and mounts", func(t *testing.T) {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "mounted-pod",
+				Namespace:         "default",
+				CreationTimestamp: metav1.Time{Time: time.Now()},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  "app",
+						Image: "app:v1",
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "creds", MountPath: "/etc/creds", ReadOnly: true},
+							{Name: "scratch", MountPath: "/tmp/scratch"},
+						},
+					},
+				},
+				Volumes: []corev1.Volume{
+					{Name: "creds", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "db-creds"}}},
+					{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+			},
+		}
+
+		result := formatPod(pod)
+		assert.Contains(t, result, "Volumes:")
+		assert.Contains(t, result, "creds: Secret (secretName: db-creds)")
+		assert.Contains(t, result, "scratch: EmptyDir")
+		assert.Contains(t, result, "/etc/creds from creds (ro)")
+		assert.Contains(t, result, "/tmp/scratch from scratch (rw)")
+	})
 }
 
 func TestFormatPodList(t *testing.T) {
@@ -500,7 +536,7 @@ func TestFormatConfigMap(t *testing.T) {
 			},
 		}
 
-		result := formatConfigMap(cm)
+		result := formatConfigMap(cm, EncodingBase64)
 		assert.Contains(t, result, "test-cm")
 		assert.Contains(t, result, "default")
 		assert.Contains(t, result, "key1")
@@ -519,9 +555,29 @@ func TestFormatConfigMap(t *testing.T) {
 			},
 		}
 
-		result := formatConfigMap(cm)
+		result := formatConfigMap(cm, EncodingBase64)
 		assert.Contains(t, result, "binary-cm")
-		assert.Contains(t, result, "file.bin")
+		assert.Contains(t, result, "Binary Data (base64):\n- file.bin: AQID (3 bytes)")
+	})
+
+	t.Run("Format binary data as hex", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "binary-cm", Namespace: "default"},
+			BinaryData: map[string][]byte{"file.bin": {0xde, 0xad, 0xbe, 0xef}},
+		}
+
+		result := formatConfigMap(cm, EncodingHex)
+		assert.Contains(t, result, "Binary Data (hex):\n- file.bin: deadbeef (4 bytes)")
+	})
+
+	t.Run("Format binary data as utf8", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "binary-cm", Namespace: "default"},
+			BinaryData: map[string][]byte{"file.bin": {'o', 'k', 0xff}},
+		}
+
+		result := formatConfigMap(cm, EncodingUTF8)
+		assert.Contains(t, result, "- file.bin: ok\uFFFD (3 bytes)")
 	})
 
 	t.Run("Format configmap with labels", func(t *testing.T) {
@@ -535,7 +591,7 @@ func TestFormatConfigMap(t *testing.T) {
 			Data: map[string]string{"key": "value"},
 		}
 
-		result := formatConfigMap(cm)
+		result := formatConfigMap(cm, EncodingBase64)
 		assert.Contains(t, result, "Labels:")
 		assert.Contains(t, result, "app")
 	})
@@ -0,0 +1,274 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Output formats for ResourceGraph.
+const (
+	GraphFormatDOT  = "dot"
+	GraphFormatJSON = "json"
+)
+
+// Relations between the nodes of a resource graph.
+const (
+	graphOwns    = "owns"
+	graphSelects = "selects"
+	graphRoutes  = "routes"
+)
+
+// ResourceGraph represents a query for the topology of a namespace: which
+// workloads own which ReplicaSets, Jobs and Pods, which Pods each Service
+// selects, and which Services each Ingress routes to.
+type ResourceGraph struct {
+	Namespace string
+	// Format is GraphFormatDOT, the default, or GraphFormatJSON.
+	Format string
+}
+
+type graphNode struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Missing marks an object that is referenced, such as the Service an
+	// Ingress routes to, but does not exist.
+	Missing bool `json:"missing,omitempty"`
+}
+
+type graphEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+}
+
+type resourceGraph struct {
+	Namespace string      `json:"namespace"`
+	Nodes     []graphNode `json:"nodes"`
+	Edges     []graphEdge `json:"edges"`
+
+	nodes map[string]*graphNode
+	edges map[graphEdge]bool
+}
+
+func newResourceGraph(namespace string) *resourceGraph {
+	return &resourceGraph{
+		Namespace: namespace,
+		nodes:     make(map[string]*graphNode),
+		edges:     make(map[graphEdge]bool),
+	}
+}
+
+// addNode adds kind/name and returns its ID. A node first added as missing
+// is marked present once the object itself is added.
+func (g *resourceGraph) addNode(kind, name string, missing bool) string {
+	id := kind + "/" + name
+	if node, ok := g.nodes[id]; ok {
+		node.Missing = node.Missing && missing
+		return id
+	}
+	g.nodes[id] = &graphNode{ID: id, Kind: kind, Name: name, Missing: missing}
+	return id
+}
+
+func (g *resourceGraph) addEdge(from, to, relation string) {
+	g.edges[graphEdge{From: from, To: to, Relation: relation}] = true
+}
+
+// addObject adds an object and an owns edge from each of its owners.
+func (g *resourceGraph) addObject(kind string, meta *metav1.ObjectMeta) string {
+	id := g.addNode(kind, meta.Name, false)
+	for _, ref := range meta.OwnerReferences {
+		g.addEdge(g.addNode(ref.Kind, ref.Name, true), id, graphOwns)
+	}
+	return id
+}
+
+// sorted fills Nodes and Edges in a stable order for output.
+func (g *resourceGraph) sorted() {
+	g.Nodes = make([]graphNode, 0, len(g.nodes))
+	for _, node := range g.nodes {
+		g.Nodes = append(g.Nodes, *node)
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+
+	g.Edges = make([]graphEdge, 0, len(g.edges))
+	for edge := range g.edges {
+		g.Edges = append(g.Edges, edge)
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		a, b := g.Edges[i], g.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Relation < b.Relation
+	})
+}
+
+// Export builds the namespace's graph and renders it as Graphviz DOT or
+// JSON.
+func (r *ResourceGraph) Export(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	format := strings.ToLower(r.Format)
+	if format == "" {
+		format = GraphFormatDOT
+	}
+	if format != GraphFormatDOT && format != GraphFormatJSON {
+		return "", fmt.Errorf("unsupported format %q: must be dot or json", r.Format)
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := r.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	g := newResourceGraph(namespace)
+	listOpts := metav1.ListOptions{}
+
+	deployments, err := client.AppsV1().Deployments(namespace).List(timeoutCtx, listOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for i := range deployments.Items {
+		g.addObject("Deployment", &deployments.Items[i].ObjectMeta)
+	}
+
+	replicaSets, err := client.AppsV1().ReplicaSets(namespace).List(timeoutCtx, listOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to list replicasets: %w", err)
+	}
+	for i := range replicaSets.Items {
+		g.addObject("ReplicaSet", &replicaSets.Items[i].ObjectMeta)
+	}
+
+	statefulSets, err := client.AppsV1().StatefulSets(namespace).List(timeoutCtx, listOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for i := range statefulSets.Items {
+		g.addObject("StatefulSet", &statefulSets.Items[i].ObjectMeta)
+	}
+
+	daemonSets, err := client.AppsV1().DaemonSets(namespace).List(timeoutCtx, listOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for i := range daemonSets.Items {
+		g.addObject("DaemonSet", &daemonSets.Items[i].ObjectMeta)
+	}
+
+	cronJobs, err := client.BatchV1().CronJobs(namespace).List(timeoutCtx, listOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to list cronjobs: %w", err)
+	}
+	for i := range cronJobs.Items {
+		g.addObject("CronJob", &cronJobs.Items[i].ObjectMeta)
+	}
+
+	jobs, err := client.BatchV1().Jobs(namespace).List(timeoutCtx, listOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for i := range jobs.Items {
+		g.addObject("Job", &jobs.Items[i].ObjectMeta)
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(timeoutCtx, listOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods: %w", err)
+	}
+	for i := range pods.Items {
+		g.addObject("Pod", &pods.Items[i].ObjectMeta)
+	}
+
+	services, err := client.CoreV1().Services(namespace).List(timeoutCtx, listOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to list services: %w", err)
+	}
+	for i := range services.Items {
+		svc := &services.Items[i]
+		id := g.addObject("Service", &svc.ObjectMeta)
+		// A Service without a selector has its endpoints managed by hand
+		// and selects nothing.
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		selector := labels.SelectorFromSet(svc.Spec.Selector)
+		for _, pod := range pods.Items {
+			if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed && selector.Matches(labels.Set(pod.Labels)) {
+				g.addEdge(id, "Pod/"+pod.Name, graphSelects)
+			}
+		}
+	}
+
+	ingresses, err := client.NetworkingV1().Ingresses(namespace).List(timeoutCtx, listOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to list ingresses: %w", err)
+	}
+	for i := range ingresses.Items {
+		ing := &ingresses.Items[i]
+		id := g.addObject("Ingress", &ing.ObjectMeta)
+		if backend := ing.Spec.DefaultBackend; backend != nil && backend.Service != nil {
+			g.addEdge(id, g.addNode("Service", backend.Service.Name, true), graphRoutes)
+		}
+		for _, rule := range ing.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				if path.Backend.Service != nil {
+					g.addEdge(id, g.addNode("Service", path.Backend.Service.Name, true), graphRoutes)
+				}
+			}
+		}
+	}
+
+	g.sorted()
+	if format == GraphFormatJSON {
+		data, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to encode graph: %w", err)
+		}
+		return string(data), nil
+	}
+	return g.dot(), nil
+}
+
+// dot renders the graph in Graphviz DOT, left to right so ownership chains
+// read as Deployment -> ReplicaSet -> Pod.
+func (g *resourceGraph) dot() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "digraph %q {\n", g.Namespace)
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+	for _, node := range g.Nodes {
+		attrs := fmt.Sprintf("label=%q", node.Kind+"\n"+node.Name)
+		if node.Missing {
+			attrs += ", style=dashed"
+		}
+		fmt.Fprintf(&sb, "  %q [%s];\n", node.ID, attrs)
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&sb, "  %q -> %q [label=%q];\n", edge.From, edge.To, edge.Relation)
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
@@ -0,0 +1,89 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResourceGraphExport(t *testing.T) {
+	ctx := context.Background()
+
+	owner := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: ptr(true)}}
+	}
+	webLabels := map[string]string{"app": "web"}
+	newCM := func() *testmocks.MockClusterManager {
+		fakeClient := fake.NewSimpleClientset(
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace}},
+			&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "web-7d4b9", Namespace: testNamespace, OwnerReferences: owner("Deployment", "web")}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-7d4b9-abcde", Namespace: testNamespace, Labels: webLabels, OwnerReferences: owner("ReplicaSet", "web-7d4b9")}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-7d4b9-fghij", Namespace: testNamespace, Labels: webLabels, OwnerReferences: owner("ReplicaSet", "web-7d4b9")}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: testNamespace}},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace},
+				Spec:       corev1.ServiceSpec{Selector: webLabels},
+			},
+			&networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace},
+				Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{
+						{Path: "/", Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "web"}}},
+						{Path: "/api", Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "api"}}},
+					}}},
+				}}},
+			},
+		)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+		return mockCM
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		graph := &ResourceGraph{Namespace: testNamespace, Format: GraphFormatJSON}
+		result, err := graph.Export(ctx, newCM())
+		require.NoError(t, err)
+
+		var got resourceGraph
+		require.NoError(t, json.Unmarshal([]byte(result), &got))
+		assert.Equal(t, testNamespace, got.Namespace)
+		assert.ElementsMatch(t, []graphEdge{
+			{From: "Deployment/web", To: "ReplicaSet/web-7d4b9", Relation: "owns"},
+			{From: "ReplicaSet/web-7d4b9", To: "Pod/web-7d4b9-abcde", Relation: "owns"},
+			{From: "ReplicaSet/web-7d4b9", To: "Pod/web-7d4b9-fghij", Relation: "owns"},
+			{From: "Service/web", To: "Pod/web-7d4b9-abcde", Relation: "selects"},
+			{From: "Service/web", To: "Pod/web-7d4b9-fghij", Relation: "selects"},
+			{From: "Ingress/web", To: "Service/web", Relation: "routes"},
+			{From: "Ingress/web", To: "Service/api", Relation: "routes"},
+		}, got.Edges)
+		assert.Contains(t, got.Nodes, graphNode{ID: "Pod/debug", Kind: "Pod", Name: "debug"})
+		assert.Contains(t, got.Nodes, graphNode{ID: "Service/api", Kind: "Service", Name: "api", Missing: true})
+		assert.Contains(t, got.Nodes, graphNode{ID: "Service/web", Kind: "Service", Name: "web"})
+	})
+
+	t.Run("DOT", func(t *testing.T) {
+		graph := &ResourceGraph{Namespace: testNamespace}
+		result, err := graph.Export(ctx, newCM())
+		require.NoError(t, err)
+		assert.Contains(t, result, `digraph "test-namespace" {`)
+		assert.Contains(t, result, `"Deployment/web" [label="Deployment\nweb"];`)
+		assert.Contains(t, result, `"Deployment/web" -> "ReplicaSet/web-7d4b9" [label="owns"];`)
+		assert.Contains(t, result, `"ReplicaSet/web-7d4b9" -> "Pod/web-7d4b9-abcde" [label="owns"];`)
+		assert.Contains(t, result, `"Service/api" [label="Service\napi", style=dashed];`)
+	})
+
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		graph := &ResourceGraph{Namespace: testNamespace, Format: "svg"}
+		_, err := graph.Export(ctx, testmocks.NewMockClusterManager())
+		assert.EqualError(t, err, `unsupported format "svg": must be dot or json`)
+	})
+}
@@ -23,7 +23,7 @@ var (
 
 // Cluster summarises node readiness and pod phase distribution.
 func (h *Health) Cluster(ctx context.Context, cm kai.ClusterManager) (string, error) {
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting client: %w", err)
 	}
@@ -99,14 +99,14 @@ func (h *Health) PodMetrics(ctx context.Context, cm kai.ClusterManager, namespac
 	if !allNamespaces {
 		ns = namespace
 		if ns == "" {
-			ns = cm.GetCurrentNamespace()
+			ns = kai.CurrentNamespace(ctx, cm)
 		}
 	}
 	return h.resourceMetrics(ctx, cm, podMetricsGVR, ns, "Pod metrics")
 }
 
 func (h *Health) resourceMetrics(ctx context.Context, cm kai.ClusterManager, gvr schema.GroupVersionResource, namespace, title string) (string, error) {
-	dyn, err := cm.GetCurrentDynamicClient()
+	dyn, err := kai.CurrentDynamicClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting dynamic client: %w", err)
 	}
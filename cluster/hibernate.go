@@ -0,0 +1,299 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/basebandit/kai"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// previousReplicasAnnotation records the replica count a hibernated workload
+// is restored to when it wakes.
+const previousReplicasAnnotation = "kai.dev/previous-replicas"
+
+// Hibernate scales a deployment to zero replicas, recording the current
+// count in the kai.dev/previous-replicas annotation so Wake can restore it.
+// The annotation and the replica count are written in one update.
+func (d *Deployment) Hibernate(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	namespace := d.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(timeoutCtx, d.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	previous, changed := hibernateReplicas(&deployment.ObjectMeta, &deployment.Spec.Replicas)
+	if !changed {
+		if _, ok := deployment.Annotations[previousReplicasAnnotation]; ok {
+			return fmt.Sprintf("Deployment %q in namespace %q is already hibernated (will wake to %s replica(s))",
+				d.Name, namespace, deployment.Annotations[previousReplicasAnnotation]), nil
+		}
+		return fmt.Sprintf("Deployment %q in namespace %q already has 0 replicas; nothing to hibernate", d.Name, namespace), nil
+	}
+
+	if _, err := client.AppsV1().Deployments(namespace).Update(timeoutCtx, deployment, metav1.UpdateOptions{}); err != nil {
+		return "", fmt.Errorf("failed to hibernate deployment: %w", err)
+	}
+
+	return fmt.Sprintf("Deployment %q hibernated in namespace %q: scaled from %d to 0 replica(s)", d.Name, namespace, previous), nil
+}
+
+// Wake restores a hibernated deployment to the replica count recorded by
+// Hibernate and removes the annotation. A deployment scaled up by hand while
+// hibernated keeps its current count.
+func (d *Deployment) Wake(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	namespace := d.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(timeoutCtx, d.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	if _, ok := deployment.Annotations[previousReplicasAnnotation]; !ok {
+		return "", fmt.Errorf("deployment %q in namespace %q is not hibernated: no %s annotation", d.Name, namespace, previousReplicasAnnotation)
+	}
+	replicas, restored, err := wakeReplicas(&deployment.ObjectMeta, &deployment.Spec.Replicas)
+	if err != nil {
+		return "", fmt.Errorf("deployment %q: %w", d.Name, err)
+	}
+
+	if _, err := client.AppsV1().Deployments(namespace).Update(timeoutCtx, deployment, metav1.UpdateOptions{}); err != nil {
+		return "", fmt.Errorf("failed to wake deployment: %w", err)
+	}
+
+	if !restored {
+		return fmt.Sprintf("Deployment %q in namespace %q was already running %d replica(s); cleared the hibernation record", d.Name, namespace, replicas), nil
+	}
+	return fmt.Sprintf("Deployment %q woken in namespace %q: scaled from 0 to %d replica(s)", d.Name, namespace, replicas), nil
+}
+
+// Hibernate scales every Deployment and StatefulSet in the namespace to
+// zero, recording each prior count like Deployment.Hibernate does.
+// Workloads already at zero are skipped, and failures are reported per
+// workload rather than aborting the rest.
+func (n *Namespace) Hibernate(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	workloads, err := n.scalableWorkloads(ctx, cm)
+	if err != nil {
+		return "", err
+	}
+
+	var hibernated, skipped, failed []string
+	for _, w := range workloads {
+		previous, changed := hibernateReplicas(w.meta, w.replicas)
+		if !changed {
+			if _, ok := w.meta.Annotations[previousReplicasAnnotation]; ok {
+				skipped = append(skipped, w.ref+" (already hibernated)")
+			}
+			continue
+		}
+		if err := w.update(ctx); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", w.ref, err.Error()))
+			continue
+		}
+		hibernated = append(hibernated, fmt.Sprintf("%s: %d → 0 replica(s)", w.ref, previous))
+	}
+
+	if len(hibernated)+len(skipped)+len(failed) == 0 {
+		return fmt.Sprintf("No running Deployments or StatefulSets to hibernate in namespace %q", n.Name), nil
+	}
+	return formatWorkloadChanges(fmt.Sprintf("Hibernated %d workload(s) in namespace %q", len(hibernated), n.Name), hibernated, skipped, failed), nil
+}
+
+// Wake restores every Deployment and StatefulSet in the namespace that
+// carries the kai.dev/previous-replicas annotation. Workloads that were
+// never hibernated are left alone.
+func (n *Namespace) Wake(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	workloads, err := n.scalableWorkloads(ctx, cm)
+	if err != nil {
+		return "", err
+	}
+
+	var woken, skipped, failed []string
+	for _, w := range workloads {
+		if _, ok := w.meta.Annotations[previousReplicasAnnotation]; !ok {
+			continue
+		}
+		replicas, restored, err := wakeReplicas(w.meta, w.replicas)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", w.ref, err.Error()))
+			continue
+		}
+		if err := w.update(ctx); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", w.ref, err.Error()))
+			continue
+		}
+		if !restored {
+			skipped = append(skipped, fmt.Sprintf("%s (already running %d replica(s))", w.ref, replicas))
+			continue
+		}
+		woken = append(woken, fmt.Sprintf("%s: 0 → %d replica(s)", w.ref, replicas))
+	}
+
+	if len(woken)+len(skipped)+len(failed) == 0 {
+		return fmt.Sprintf("No hibernated workloads to wake in namespace %q", n.Name), nil
+	}
+	return formatWorkloadChanges(fmt.Sprintf("Woke %d workload(s) in namespace %q", len(woken), n.Name), woken, skipped, failed), nil
+}
+
+// scalableWorkload is a Deployment or StatefulSet whose replica count and
+// annotations are edited in place and then written back with update.
+type scalableWorkload struct {
+	ref      string
+	meta     *metav1.ObjectMeta
+	replicas **int32
+	update   func(ctx context.Context) error
+}
+
+// scalableWorkloads lists the namespace's Deployments and then its
+// StatefulSets, each sorted by name.
+func (n *Namespace) scalableWorkloads(ctx context.Context, cm kai.ClusterManager) ([]scalableWorkload, error) {
+	if err := n.validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return nil, fmt.Errorf("error getting client: %w", err)
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	if _, err := client.CoreV1().Namespaces().Get(listCtx, n.Name, metav1.GetOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to get namespace %q: %w", n.Name, err)
+	}
+
+	deployments, err := client.AppsV1().Deployments(n.Name).List(listCtx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	statefulSets, err := client.AppsV1().StatefulSets(n.Name).List(listCtx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	sort.Slice(deployments.Items, func(i, j int) bool {
+		return deployments.Items[i].Name < deployments.Items[j].Name
+	})
+	sort.Slice(statefulSets.Items, func(i, j int) bool {
+		return statefulSets.Items[i].Name < statefulSets.Items[j].Name
+	})
+
+	var workloads []scalableWorkload
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		workloads = append(workloads, scalableWorkload{
+			ref:      "Deployment/" + deployment.Name,
+			meta:     &deployment.ObjectMeta,
+			replicas: &deployment.Spec.Replicas,
+			update: func(ctx context.Context) error {
+				timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+				defer cancel()
+				_, err := client.AppsV1().Deployments(n.Name).Update(timeoutCtx, deployment, metav1.UpdateOptions{})
+				return err
+			},
+		})
+	}
+	for i := range statefulSets.Items {
+		sts := &statefulSets.Items[i]
+		workloads = append(workloads, scalableWorkload{
+			ref:      "StatefulSet/" + sts.Name,
+			meta:     &sts.ObjectMeta,
+			replicas: &sts.Spec.Replicas,
+			update: func(ctx context.Context) error {
+				timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+				defer cancel()
+				_, err := client.AppsV1().StatefulSets(n.Name).Update(timeoutCtx, sts, metav1.UpdateOptions{})
+				return err
+			},
+		})
+	}
+	return workloads, nil
+}
+
+// formatWorkloadChanges lists the workloads a namespace-wide operation
+// changed under heading, followed by those it skipped or failed on.
+func formatWorkloadChanges(heading string, changed, skipped, failed []string) string {
+	var sb strings.Builder
+	sb.WriteString(heading)
+	for _, ref := range changed {
+		fmt.Fprintf(&sb, "\n• %s", ref)
+	}
+	if len(skipped) > 0 {
+		fmt.Fprintf(&sb, "\nSkipped %d:", len(skipped))
+		for _, ref := range skipped {
+			fmt.Fprintf(&sb, "\n• %s", ref)
+		}
+	}
+	if len(failed) > 0 {
+		fmt.Fprintf(&sb, "\nFailed %d:", len(failed))
+		for _, ref := range failed {
+			fmt.Fprintf(&sb, "\n• %s", ref)
+		}
+	}
+	return sb.String()
+}
+
+// hibernateReplicas scales a workload to zero in place and records the
+// previous count in its annotations. It reports false, leaving the workload
+// untouched, when there are no replicas to record.
+func hibernateReplicas(meta *metav1.ObjectMeta, replicas **int32) (int32, bool) {
+	previous := int32(1)
+	if *replicas != nil {
+		previous = **replicas
+	}
+	if previous == 0 {
+		return 0, false
+	}
+
+	if meta.Annotations == nil {
+		meta.Annotations = make(map[string]string)
+	}
+	meta.Annotations[previousReplicasAnnotation] = strconv.Itoa(int(previous))
+	*replicas = ptr(int32(0))
+	return previous, true
+}
+
+// wakeReplicas restores the count recorded by hibernateReplicas in place and
+// drops the annotation. It reports false when the workload already has
+// replicas, in which case only the annotation is removed and the current
+// count is returned.
+func wakeReplicas(meta *metav1.ObjectMeta, replicas **int32) (int32, bool, error) {
+	value := meta.Annotations[previousReplicasAnnotation]
+	previous, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || previous < 0 {
+		return 0, false, fmt.Errorf("invalid %s annotation %q: expected a non-negative replica count", previousReplicasAnnotation, value)
+	}
+	delete(meta.Annotations, previousReplicasAnnotation)
+
+	if *replicas != nil && **replicas > 0 {
+		return **replicas, false, nil
+	}
+	*replicas = ptr(int32(previous))
+	return int32(previous), true, nil
+}
@@ -0,0 +1,361 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/basebandit/kai"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// HPA represents an operation target for a HorizontalPodAutoscaler.
+type HPA struct {
+	Name              string
+	Namespace         string
+	TargetKind        string
+	TargetName        string
+	TargetAPIVersion  string
+	MinReplicas       *int32
+	MaxReplicas       int32
+	CPUUtilization    *int32
+	MemoryUtilization *int32
+	// CustomMetrics lists Pods or External metrics, each an object with
+	// name, average_value and an optional type (Pods by default).
+	CustomMetrics []interface{}
+	Labels        map[string]interface{}
+	DryRun        bool
+}
+
+// Create creates a HorizontalPodAutoscaler (autoscaling/v2) that scales the
+// target between MinReplicas and MaxReplicas on the given CPU and memory
+// utilization and custom metrics. With no metric the API server defaults to
+// 80% CPU utilization.
+func (h *HPA) Create(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if err := h.validate(); err != nil {
+		return "", err
+	}
+
+	metrics, err := h.metrics()
+	if err != nil {
+		return "", err
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	kind := h.TargetKind
+	if kind == "" {
+		kind = "Deployment"
+	}
+	apiVersion := h.TargetAPIVersion
+	if apiVersion == "" {
+		apiVersion = "apps/v1"
+	}
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      h.Name,
+			Namespace: h.Namespace,
+			Labels:    convertToStringMap(h.Labels),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: apiVersion,
+				Kind:       kind,
+				Name:       h.TargetName,
+			},
+			MinReplicas: h.MinReplicas,
+			MaxReplicas: h.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	created, err := client.AutoscalingV2().HorizontalPodAutoscalers(h.Namespace).Create(timeoutCtx, hpa, metav1.CreateOptions{DryRun: dryRunOption(h.DryRun)})
+	if err != nil {
+		slog.Warn("failed to create HPA",
+			slog.String("name", h.Name),
+			slog.String("namespace", h.Namespace),
+			slog.String("error", err.Error()),
+		)
+		return "", fmt.Errorf("failed to create HPA: %w", err)
+	}
+
+	minReplicas := int32(1)
+	if created.Spec.MinReplicas != nil {
+		minReplicas = *created.Spec.MinReplicas
+	}
+	result := fmt.Sprintf("HPA %q created successfully in namespace %q, scaling %s/%s between %d and %d replicas",
+		created.Name, created.Namespace, kind, h.TargetName, minReplicas, created.Spec.MaxReplicas)
+	return dryRunResult(result, h.DryRun), nil
+}
+
+// Get shows the autoscaler's target, its current and desired replica
+// counts, and each metric's current value against its target.
+func (h *HPA) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if h.Name == "" {
+		return "", errors.New("HPA name is required")
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	hpa, err := client.AutoscalingV2().HorizontalPodAutoscalers(h.Namespace).Get(timeoutCtx, h.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("HPA %q not found in namespace %q: %w", h.Name, h.Namespace, err)
+		}
+		return "", fmt.Errorf("failed to get HPA %q: %w", h.Name, err)
+	}
+
+	return formatHPA(hpa), nil
+}
+
+// List lists autoscalers in the namespace, or in all namespaces.
+func (h *HPA) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := h.Namespace
+	if allNamespaces {
+		namespace = ""
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	hpas, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(timeoutCtx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return "", fmt.Errorf("failed to list HPAs: %w", err)
+	}
+
+	if len(hpas.Items) == 0 {
+		if labelSelector != "" {
+			return "", errors.New("no HPAs found matching the specified label selector")
+		}
+		if allNamespaces {
+			return "", errors.New("no HPAs found in any namespace")
+		}
+		return "", fmt.Errorf("no HPAs found in namespace %q", h.Namespace)
+	}
+
+	return formatHPAList(hpas, allNamespaces), nil
+}
+
+// Delete removes the autoscaler. The workload it scaled keeps its current
+// replica count.
+func (h *HPA) Delete(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if h.Name == "" {
+		return "", errors.New("HPA name is required for deletion")
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if err := client.AutoscalingV2().HorizontalPodAutoscalers(h.Namespace).Delete(timeoutCtx, h.Name, metav1.DeleteOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("HPA %q not found in namespace %q: %w", h.Name, h.Namespace, err)
+		}
+		return "", fmt.Errorf("failed to delete HPA %q: %w", h.Name, err)
+	}
+
+	slog.Info("HPA deleted",
+		slog.String("name", h.Name),
+		slog.String("namespace", h.Namespace),
+	)
+	return fmt.Sprintf("HPA %q deleted successfully from namespace %q", h.Name, h.Namespace), nil
+}
+
+// SetBounds patches the autoscaler's minReplicas and/or maxReplicas. Bounds
+// left nil keep their current value; the resulting pair must satisfy
+// 1 <= min <= max.
+func (h *HPA) SetBounds(ctx context.Context, cm kai.ClusterManager, minReplicas, maxReplicas *int32) (string, error) {
+	if h.Name == "" {
+		return "", errors.New("HPA name is required")
+	}
+	if minReplicas == nil && maxReplicas == nil {
+		return "", errors.New("at least one of min_replicas or max_replicas is required")
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := h.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	hpa, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(timeoutCtx, h.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get HPA %q: %w", h.Name, err)
+	}
+
+	newMin := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		newMin = *hpa.Spec.MinReplicas
+	}
+	newMax := hpa.Spec.MaxReplicas
+	spec := map[string]interface{}{}
+	if minReplicas != nil {
+		newMin = *minReplicas
+		spec["minReplicas"] = newMin
+	}
+	if maxReplicas != nil {
+		newMax = *maxReplicas
+		spec["maxReplicas"] = newMax
+	}
+
+	if newMin < 1 {
+		return "", fmt.Errorf("minReplicas must be at least 1, got %d", newMin)
+	}
+	if newMin > newMax {
+		return "", fmt.Errorf("minReplicas (%d) must not exceed maxReplicas (%d)", newMin, newMax)
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"spec": spec})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode patch: %w", err)
+	}
+
+	if _, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).Patch(timeoutCtx, h.Name, types.MergePatchType, data, metav1.PatchOptions{}); err != nil {
+		return "", fmt.Errorf("failed to patch HPA %q: %w", h.Name, err)
+	}
+
+	return fmt.Sprintf("HPA %q in namespace %q bounds set to min=%d, max=%d", h.Name, namespace, newMin, newMax), nil
+}
+
+func (h *HPA) validate() error {
+	if h.Name == "" {
+		return errors.New("HPA name is required")
+	}
+	if h.Namespace == "" {
+		return errors.New("namespace is required")
+	}
+	if h.TargetName == "" {
+		return errors.New("target name is required")
+	}
+	if h.MaxReplicas < 1 {
+		return fmt.Errorf("maxReplicas must be at least 1, got %d", h.MaxReplicas)
+	}
+	if h.MinReplicas != nil {
+		if *h.MinReplicas < 1 {
+			return fmt.Errorf("minReplicas must be at least 1, got %d", *h.MinReplicas)
+		}
+		if *h.MinReplicas > h.MaxReplicas {
+			return fmt.Errorf("minReplicas (%d) must not exceed maxReplicas (%d)", *h.MinReplicas, h.MaxReplicas)
+		}
+	}
+	for name, target := range map[string]*int32{"CPU": h.CPUUtilization, "memory": h.MemoryUtilization} {
+		if target != nil && *target < 1 {
+			return fmt.Errorf("%s target utilization must be at least 1%%, got %d", name, *target)
+		}
+	}
+	return nil
+}
+
+// metrics builds the metric specs: resource utilization targets first, then
+// custom metrics in the order given.
+func (h *HPA) metrics() ([]autoscalingv2.MetricSpec, error) {
+	var metrics []autoscalingv2.MetricSpec
+	for _, r := range []struct {
+		name   corev1.ResourceName
+		target *int32
+	}{{corev1.ResourceCPU, h.CPUUtilization}, {corev1.ResourceMemory, h.MemoryUtilization}} {
+		if r.target == nil {
+			continue
+		}
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: r.name,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: r.target,
+				},
+			},
+		})
+	}
+
+	for i, raw := range h.CustomMetrics {
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("custom metric %d must be an object with name and average_value", i)
+		}
+		name, _ := m["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("custom metric %d is missing name", i)
+		}
+		value, err := metricQuantity(m["average_value"])
+		if err != nil {
+			return nil, fmt.Errorf("custom metric %q: %w", name, err)
+		}
+		target := autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: &value}
+		identifier := autoscalingv2.MetricIdentifier{Name: name}
+
+		metricType, _ := m["type"].(string)
+		switch strings.ToLower(metricType) {
+		case "", "pods":
+			metrics = append(metrics, autoscalingv2.MetricSpec{
+				Type: autoscalingv2.PodsMetricSourceType,
+				Pods: &autoscalingv2.PodsMetricSource{Metric: identifier, Target: target},
+			})
+		case "external":
+			metrics = append(metrics, autoscalingv2.MetricSpec{
+				Type:     autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{Metric: identifier, Target: target},
+			})
+		default:
+			return nil, fmt.Errorf("custom metric %q has unsupported type %q (use Pods or External)", name, metricType)
+		}
+	}
+	return metrics, nil
+}
+
+// metricQuantity reads a metric target given as a number or a quantity
+// string such as "100" or "500m".
+func metricQuantity(v interface{}) (resource.Quantity, error) {
+	switch value := v.(type) {
+	case float64:
+		return resource.ParseQuantity(strconv.FormatFloat(value, 'f', -1, 64))
+	case string:
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return resource.Quantity{}, fmt.Errorf("invalid average_value %q: %w", value, err)
+		}
+		return q, nil
+	case nil:
+		return resource.Quantity{}, errors.New("average_value is required")
+	}
+	return resource.Quantity{}, fmt.Errorf("average_value must be a number or quantity string, got %T", v)
+}
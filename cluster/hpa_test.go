@@ -0,0 +1,214 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHPASetBounds(t *testing.T) {
+	ctx := context.Background()
+
+	newClient := func() *fake.Clientset {
+		return fake.NewSimpleClientset(&autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "web", APIVersion: "apps/v1"},
+				MinReplicas:    ptr(int32(2)),
+				MaxReplicas:    5,
+			},
+		})
+	}
+
+	t.Run("UpdatesBounds", func(t *testing.T) {
+		fakeClient := newClient()
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		hpa := &HPA{Name: "web", Namespace: testNamespace}
+		result, err := hpa.SetBounds(ctx, mockCM, ptr(int32(3)), ptr(int32(10)))
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "min=3, max=10")
+
+		updated, err := fakeClient.AutoscalingV2().HorizontalPodAutoscalers(testNamespace).Get(ctx, "web", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), *updated.Spec.MinReplicas)
+		assert.Equal(t, int32(10), updated.Spec.MaxReplicas)
+	})
+
+	t.Run("RejectsMinAboveMax", func(t *testing.T) {
+		fakeClient := newClient()
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		hpa := &HPA{Name: "web", Namespace: testNamespace}
+		_, err := hpa.SetBounds(ctx, mockCM, ptr(int32(8)), nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "must not exceed maxReplicas")
+
+		unchanged, err := fakeClient.AutoscalingV2().HorizontalPodAutoscalers(testNamespace).Get(ctx, "web", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), *unchanged.Spec.MinReplicas)
+	})
+
+	t.Run("RejectsMinBelowOne", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(newClient(), nil)
+
+		hpa := &HPA{Name: "web", Namespace: testNamespace}
+		_, err := hpa.SetBounds(ctx, mockCM, ptr(int32(0)), nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "at least 1")
+	})
+}
+
+func TestHPACreate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("BuildsMetricSpecs", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset()
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		hpa := &HPA{
+			Name:           "web",
+			Namespace:      testNamespace,
+			TargetName:     "web",
+			MinReplicas:    ptr(int32(2)),
+			MaxReplicas:    8,
+			CPUUtilization: ptr(int32(70)),
+			CustomMetrics: []interface{}{
+				map[string]interface{}{"name": "http_requests_per_second", "average_value": "100"},
+				map[string]interface{}{"name": "queue_depth", "average_value": float64(30), "type": "External"},
+			},
+		}
+		result, err := hpa.Create(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "scaling Deployment/web between 2 and 8 replicas")
+
+		created, err := fakeClient.AutoscalingV2().HorizontalPodAutoscalers(testNamespace).Get(ctx, "web", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, "apps/v1", created.Spec.ScaleTargetRef.APIVersion)
+		assert.Len(t, created.Spec.Metrics, 3)
+		assert.Equal(t, autoscalingv2.ResourceMetricSourceType, created.Spec.Metrics[0].Type)
+		assert.Equal(t, int32(70), *created.Spec.Metrics[0].Resource.Target.AverageUtilization)
+		assert.Equal(t, autoscalingv2.PodsMetricSourceType, created.Spec.Metrics[1].Type)
+		assert.Equal(t, autoscalingv2.ExternalMetricSourceType, created.Spec.Metrics[2].Type)
+		assert.Equal(t, "30", created.Spec.Metrics[2].External.Target.AverageValue.String())
+	})
+
+	t.Run("RejectsInvalidParams", func(t *testing.T) {
+		tests := []struct {
+			name string
+			hpa  HPA
+			err  string
+		}{
+			{"MissingTarget", HPA{Name: "web", Namespace: testNamespace, MaxReplicas: 3}, "target name is required"},
+			{"MinAboveMax", HPA{Name: "web", Namespace: testNamespace, TargetName: "web", MinReplicas: ptr(int32(4)), MaxReplicas: 3}, "must not exceed maxReplicas"},
+			{"BadMetricType", HPA{Name: "web", Namespace: testNamespace, TargetName: "web", MaxReplicas: 3,
+				CustomMetrics: []interface{}{map[string]interface{}{"name": "rps", "average_value": "1", "type": "Object"}}}, "unsupported type"},
+			{"BadQuantity", HPA{Name: "web", Namespace: testNamespace, TargetName: "web", MaxReplicas: 3,
+				CustomMetrics: []interface{}{map[string]interface{}{"name": "rps", "average_value": "lots"}}}, "invalid average_value"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockCM := testmocks.NewMockClusterManager()
+				_, err := tt.hpa.Create(ctx, mockCM)
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+			})
+		}
+	})
+}
+
+func TestHPAGetListDelete(t *testing.T) {
+	ctx := context.Background()
+
+	newClient := func() *fake.Clientset {
+		return fake.NewSimpleClientset(&autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "web", APIVersion: "apps/v1"},
+				MaxReplicas:    10,
+				Metrics: []autoscalingv2.MetricSpec{{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name:   corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: ptr(int32(60))},
+					},
+				}},
+			},
+			Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+				CurrentReplicas: 2,
+				DesiredReplicas: 4,
+				CurrentMetrics: []autoscalingv2.MetricStatus{{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricStatus{
+						Name:    corev1.ResourceCPU,
+						Current: autoscalingv2.MetricValueStatus{AverageUtilization: ptr(int32(95))},
+					},
+				}},
+			},
+		})
+	}
+
+	t.Run("GetShowsReplicasAndMetrics", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(newClient(), nil)
+
+		hpa := &HPA{Name: "web", Namespace: testNamespace}
+		result, err := hpa.Get(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Replicas: current 2, desired 4 (min 1, max 10)")
+		assert.Contains(t, result, "95% / 60%")
+	})
+
+	t.Run("GetNotFound", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(), nil)
+
+		hpa := &HPA{Name: "web", Namespace: testNamespace}
+		_, err := hpa.Get(ctx, mockCM)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("List", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(newClient(), nil)
+
+		hpa := &HPA{Namespace: testNamespace}
+		result, err := hpa.List(ctx, mockCM, false, "")
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Target=Deployment/web")
+		assert.Contains(t, result, "Total: 1 HPA(s)")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		fakeClient := newClient()
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		hpa := &HPA{Name: "web", Namespace: testNamespace}
+		result, err := hpa.Delete(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "deleted successfully")
+
+		_, err = fakeClient.AutoscalingV2().HorizontalPodAutoscalers(testNamespace).Get(ctx, "web", metav1.GetOptions{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,344 @@
+package cluster
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	dockerHubDomain   = "docker.io"
+	dockerHubRegistry = "registry-1.docker.io"
+)
+
+// registryClient talks to image registries. Tests swap it for a client that
+// trusts their TLS server.
+var registryClient = &http.Client{Timeout: 10 * time.Second}
+
+// manifestMediaTypes are the manifest formats a registry may hold for an
+// image; without them some registries answer 404 for multi-arch images.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// VerifyImage checks that image exists in its registry before a workload is
+// created with it, so a typo fails fast instead of ending in ImagePullBackOff.
+// Credentials are taken from the named image pull secrets in namespace. The
+// check is best effort: only a registry that answers that the manifest is
+// missing or forbidden fails it; unreachable registries and unexpected
+// responses are logged and let through.
+func VerifyImage(ctx context.Context, cm kai.ClusterManager, namespace, image string, pullSecrets []string) error {
+	ref, err := parseImageRef(image)
+	if err != nil {
+		return err
+	}
+
+	var creds *registryCredentials
+	if len(pullSecrets) > 0 {
+		client, err := kai.CurrentClient(ctx, cm)
+		if err != nil {
+			return fmt.Errorf("error getting client: %w", err)
+		}
+		creds = pullSecretCredentials(ctx, client, namespace, pullSecrets, ref.domain)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	status, err := manifestStatus(timeoutCtx, ref, creds)
+	switch {
+	case err != nil:
+		slog.Warn("skipping image check, registry not reachable",
+			slog.String("image", image),
+			slog.String("error", err.Error()),
+		)
+		return nil
+	case status == http.StatusOK:
+		return nil
+	case status == http.StatusNotFound, status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return fmt.Errorf("image %q not found or not accessible (registry %s returned %d)", image, ref.registry, status)
+	}
+	slog.Warn("skipping image check, unexpected registry response",
+		slog.String("image", image),
+		slog.Int("status", status),
+	)
+	return nil
+}
+
+// imageRef is an image reference split the way the registry API addresses it.
+type imageRef struct {
+	domain     string // as written in the image, e.g. docker.io or ghcr.io
+	registry   string // host serving the v2 API
+	repository string
+	reference  string // tag or digest
+}
+
+// parseImageRef follows the docker reference rules: the first path component
+// is a registry only when it looks like a host, Docker Hub images without an
+// organisation live under library/, and the tag defaults to latest.
+func parseImageRef(image string) (imageRef, error) {
+	name := strings.TrimSpace(image)
+	if name == "" {
+		return imageRef{}, fmt.Errorf("image is required")
+	}
+
+	ref := imageRef{reference: "latest"}
+	if at := strings.Index(name, "@"); at >= 0 {
+		name, ref.reference = name[:at], name[at+1:]
+	} else if colon := strings.LastIndex(name, ":"); colon > strings.LastIndex(name, "/") {
+		name, ref.reference = name[:colon], name[colon+1:]
+	}
+
+	ref.domain = dockerHubDomain
+	if first, rest, ok := strings.Cut(name, "/"); ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		ref.domain, name = first, rest
+	}
+	ref.registry = ref.domain
+	if ref.domain == dockerHubDomain || ref.domain == "index.docker.io" {
+		ref.domain, ref.registry = dockerHubDomain, dockerHubRegistry
+		if !strings.Contains(name, "/") {
+			name = "library/" + name
+		}
+	}
+	if name == "" || ref.reference == "" {
+		return imageRef{}, fmt.Errorf("invalid image reference %q", image)
+	}
+	ref.repository = name
+	return ref, nil
+}
+
+type registryCredentials struct {
+	username string
+	password string
+}
+
+// dockerConfig is the layout of .dockerconfigjson, and under "auths" that of
+// the legacy .dockercfg.
+type dockerConfig struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Auth     string `json:"auth"`
+}
+
+// pullSecretCredentials returns the first credentials for domain found in
+// the named secrets. Secrets that are missing or malformed are skipped, so
+// the check falls back to anonymous access.
+func pullSecretCredentials(ctx context.Context, client kubernetes.Interface, namespace string, names []string, domain string) *registryCredentials {
+	for _, name := range names {
+		secret, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			slog.Warn("ignoring image pull secret",
+				slog.String("secret", name),
+				slog.String("namespace", namespace),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+
+		var auths map[string]dockerConfigEntry
+		switch secret.Type {
+		case corev1.SecretTypeDockerConfigJson:
+			var cfg dockerConfig
+			if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &cfg); err == nil {
+				auths = cfg.Auths
+			}
+		case corev1.SecretTypeDockercfg:
+			_ = json.Unmarshal(secret.Data[corev1.DockerConfigKey], &auths)
+		}
+
+		for server, entry := range auths {
+			if registryDomain(server) != domain {
+				continue
+			}
+			if creds := entry.credentials(); creds != nil {
+				return creds
+			}
+		}
+	}
+	return nil
+}
+
+func (e dockerConfigEntry) credentials() *registryCredentials {
+	if e.Username != "" || e.Password != "" {
+		return &registryCredentials{username: e.Username, password: e.Password}
+	}
+	decoded, err := base64.StdEncoding.DecodeString(e.Auth)
+	if err != nil {
+		return nil
+	}
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return nil
+	}
+	return &registryCredentials{username: username, password: password}
+}
+
+// registryDomain normalises a docker config key, which may be a bare host or
+// a URL such as https://index.docker.io/v1/, to the domain used in images.
+func registryDomain(server string) string {
+	host := server
+	if u, err := url.Parse(server); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	host, _, _ = strings.Cut(host, "/")
+	switch host {
+	case "index.docker.io", dockerHubRegistry:
+		return dockerHubDomain
+	}
+	return host
+}
+
+// manifestStatus asks the registry for the image manifest and returns the
+// HTTP status, answering a 401 challenge with Basic or Bearer token auth.
+func manifestStatus(ctx context.Context, ref imageRef, creds *registryCredentials) (int, error) {
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", ref.registry, ref.repository, ref.reference)
+
+	resp, err := headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp.StatusCode, nil
+	}
+
+	authorization, err := authorize(ctx, resp.Header.Get("WWW-Authenticate"), ref.repository, creds)
+	if err != nil {
+		return 0, err
+	}
+	if authorization == "" {
+		return resp.StatusCode, nil
+	}
+	resp, err = headManifest(ctx, manifestURL, authorization)
+	if err != nil {
+		return 0, err
+	}
+	return resp.StatusCode, nil
+}
+
+func headManifest(ctx context.Context, manifestURL, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := registryClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	return resp, nil
+}
+
+// authorize answers a WWW-Authenticate challenge and returns the
+// Authorization header to retry with, or "" when it cannot be answered.
+func authorize(ctx context.Context, challenge, repository string, creds *registryCredentials) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if creds == nil {
+			return "", nil
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(creds.username+":"+creds.password)), nil
+	case "bearer":
+	default:
+		return "", nil
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Scheme == "" {
+		return "", fmt.Errorf("invalid token realm %q", params["realm"])
+	}
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", repository)
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if creds != nil {
+		req.SetBasicAuth(creds.username, creds.password)
+	}
+	resp, err := registryClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		// The token service refused the credentials; the manifest request
+		// stays unauthorized.
+		return "", nil
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode registry token: %w", err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return "", nil
+	}
+	return "Bearer " + token.Token, nil
+}
+
+// parseChallenge splits a WWW-Authenticate header such as
+// `Bearer realm="https://auth.example/token",service="registry"` into its
+// scheme and parameters. Quoted values may contain commas.
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := map[string]string{}
+	for rest != "" {
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[key] = value[1:]
+				break
+			}
+			params[key] = value[1 : end+1]
+			rest = strings.TrimPrefix(strings.TrimSpace(value[end+2:]), ",")
+			continue
+		}
+		value, rest, _ = strings.Cut(value, ",")
+		params[key] = strings.TrimSpace(value)
+	}
+	return scheme, params
+}
@@ -0,0 +1,139 @@
+package cluster
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseImageRef(t *testing.T) {
+	tests := []struct {
+		image string
+		want  imageRef
+	}{
+		{"nginx", imageRef{domain: "docker.io", registry: "registry-1.docker.io", repository: "library/nginx", reference: "latest"}},
+		{"bitnami/redis:7.2", imageRef{domain: "docker.io", registry: "registry-1.docker.io", repository: "bitnami/redis", reference: "7.2"}},
+		{"docker.io/library/busybox:1.36", imageRef{domain: "docker.io", registry: "registry-1.docker.io", repository: "library/busybox", reference: "1.36"}},
+		{"ghcr.io/org/app/api:v1", imageRef{domain: "ghcr.io", registry: "ghcr.io", repository: "org/app/api", reference: "v1"}},
+		{"localhost:5000/app", imageRef{domain: "localhost:5000", registry: "localhost:5000", repository: "app", reference: "latest"}},
+		{"quay.io/org/app@sha256:abc", imageRef{domain: "quay.io", registry: "quay.io", repository: "org/app", reference: "sha256:abc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			got, err := parseImageRef(tt.image)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, bad := range []string{"", "nginx@", "nginx:"} {
+		_, err := parseImageRef(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:org/app:pull,push"`)
+	assert.Equal(t, "Bearer", scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:org/app:pull,push",
+	}, params)
+
+	scheme, params = parseChallenge(`Basic realm=registry`)
+	assert.Equal(t, "Basic", scheme)
+	assert.Equal(t, "registry", params["realm"])
+}
+
+// fakeRegistry serves one image, team/app:v1, behind Bearer token auth that
+// accepts only user:secret.
+func fakeRegistry(t *testing.T) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			assert.Equal(t, "repository:team/app:pull", r.URL.Query().Get("scope"))
+			_, _ = w.Write([]byte(`{"token":"t0ken"}`))
+		case r.Header.Get("Authorization") != "Bearer t0ken":
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="fake"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.Method == http.MethodHead && r.URL.Path == "/v2/team/app/manifests/v1":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	original := registryClient
+	registryClient = srv.Client()
+	t.Cleanup(func() { registryClient = original })
+	return srv
+}
+
+func pullSecret(name, registry, user, pass string) *corev1.Secret {
+	auth := base64.StdEncoding.EncodeToString([]byte(user + ":" + pass))
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths":{"https://` + registry + `":{"auth":"` + auth + `"}}}`),
+		},
+	}
+}
+
+func TestVerifyImage(t *testing.T) {
+	ctx := context.Background()
+	srv := fakeRegistry(t)
+	host := strings.TrimPrefix(srv.URL, "https://")
+
+	verify := func(image string, secrets ...string) error {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(
+			pullSecret("regcred", host, "user", "secret"),
+			pullSecret("wrong", host, "user", "nope"),
+		), nil)
+		return VerifyImage(ctx, mockCM, testNamespace, image, secrets)
+	}
+
+	t.Run("FoundWithPullSecret", func(t *testing.T) {
+		assert.NoError(t, verify(host+"/team/app:v1", "missing", "regcred"))
+	})
+
+	t.Run("TagNotFound", func(t *testing.T) {
+		err := verify(host+"/team/app:v2", "regcred")
+		assert.ErrorContains(t, err, "not found or not accessible")
+		assert.ErrorContains(t, err, "returned 404")
+	})
+
+	t.Run("AnonymousIsNotAccessible", func(t *testing.T) {
+		err := verify(host + "/team/app:v1")
+		assert.ErrorContains(t, err, "returned 401")
+	})
+
+	t.Run("WrongCredentials", func(t *testing.T) {
+		assert.ErrorContains(t, verify(host+"/team/app:v1", "wrong"), "not found or not accessible")
+	})
+
+	t.Run("UnreachableRegistryIsSkipped", func(t *testing.T) {
+		closed := httptest.NewTLSServer(http.NotFoundHandler())
+		closed.Close()
+		assert.NoError(t, verify(strings.TrimPrefix(closed.URL, "https://")+"/team/app:v1"))
+	})
+}
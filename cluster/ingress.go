@@ -8,7 +8,9 @@ import (
 	"strings"
 
 	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 )
@@ -23,6 +25,8 @@ type Ingress struct {
 	Rules            []kai.IngressRule
 	TLS              []kai.IngressTLS
 	DefaultBackend   *kai.IngressBackend
+	DryRun           bool
+	ShowDiff         bool
 }
 
 // Create creates a new Ingress in the specified namespace.
@@ -43,7 +47,7 @@ func (i *Ingress) Create(ctx context.Context, cm kai.ClusterManager) (string, er
 		slog.String("namespace", i.Namespace),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for Ingress create",
 			slog.String("name", i.Name),
@@ -158,7 +162,7 @@ func (i *Ingress) Create(ctx context.Context, cm kai.ClusterManager) (string, er
 		ingress.Spec.TLS = tlsConfigs
 	}
 
-	createdIngress, err := client.NetworkingV1().Ingresses(i.Namespace).Create(timeoutCtx, ingress, metav1.CreateOptions{})
+	createdIngress, err := client.NetworkingV1().Ingresses(i.Namespace).Create(timeoutCtx, ingress, metav1.CreateOptions{DryRun: dryRunOption(i.DryRun)})
 	if err != nil {
 		slog.Warn("failed to create Ingress",
 			slog.String("name", i.Name),
@@ -178,21 +182,105 @@ func (i *Ingress) Create(ctx context.Context, cm kai.ClusterManager) (string, er
 		result += fmt.Sprintf(" (Class: %s)", i.IngressClassName)
 	}
 
-	return result, nil
+	return dryRunResult(result, i.DryRun), nil
+}
+
+// CreateForService creates an Ingress routing host and path.Path to the
+// service named by path.ServiceName, which must exist in the Ingress's
+// namespace. A nil path.ServicePort picks the service's only port; a port
+// number or name must match one the service exposes.
+func (i *Ingress) CreateForService(ctx context.Context, cm kai.ClusterManager, host string, path kai.IngressPath) (string, error) {
+	if path.ServiceName == "" {
+		return "", errors.New("service name is required")
+	}
+	if i.Namespace == "" {
+		return "", errors.New("namespace is required")
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	service, err := client.CoreV1().Services(i.Namespace).Get(timeoutCtx, path.ServiceName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("service %q not found in namespace %q", path.ServiceName, i.Namespace)
+		}
+		return "", fmt.Errorf("failed to get service %q: %w", path.ServiceName, err)
+	}
+
+	port, err := ingressServicePort(service, path.ServicePort)
+	if err != nil {
+		return "", err
+	}
+	path.ServicePort = port
+	if path.Path == "" {
+		path.Path = "/"
+	}
+
+	i.Rules = []kai.IngressRule{{Host: host, Paths: []kai.IngressPath{path}}}
+	return i.Create(ctx, cm)
+}
+
+// ingressServicePort resolves the backend port for service: the number or
+// name of one of its ports, or its only port when want is nil.
+func ingressServicePort(service *corev1.Service, want interface{}) (interface{}, error) {
+	if len(service.Spec.Ports) == 0 {
+		return nil, fmt.Errorf("service %q exposes no ports", service.Name)
+	}
+
+	switch v := want.(type) {
+	case nil:
+		if len(service.Spec.Ports) > 1 {
+			ports := make([]string, 0, len(service.Spec.Ports))
+			for _, p := range service.Spec.Ports {
+				ports = append(ports, servicePortLabel(p))
+			}
+			return nil, fmt.Errorf("service %q has %d ports (%s); set port to choose one", service.Name, len(ports), strings.Join(ports, ", "))
+		}
+		return service.Spec.Ports[0].Port, nil
+	case int32:
+		for _, p := range service.Spec.Ports {
+			if p.Port == v {
+				return v, nil
+			}
+		}
+		return nil, fmt.Errorf("service %q has no port %d", service.Name, v)
+	case string:
+		for _, p := range service.Spec.Ports {
+			if p.Name == v {
+				return v, nil
+			}
+		}
+		return nil, fmt.Errorf("service %q has no port named %q", service.Name, v)
+	default:
+		return nil, fmt.Errorf("unsupported service port type: %T", v)
+	}
+}
+
+func servicePortLabel(p corev1.ServicePort) string {
+	if p.Name != "" {
+		return fmt.Sprintf("%s:%d", p.Name, p.Port)
+	}
+	return fmt.Sprintf("%d", p.Port)
 }
 
 // Get retrieves an Ingress by name from the specified namespace.
 func (i *Ingress) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
 
 	var ingress *networkingv1.Ingress
 	err = retry.OnError(retry.DefaultRetry, func(err error) bool {
-		return !strings.Contains(err.Error(), "not found")
+		return !apierrors.IsNotFound(err)
 	}, func() error {
 		var getErr error
 		ingress, getErr = client.NetworkingV1().Ingresses(i.Namespace).Get(ctx, i.Name, metav1.GetOptions{})
@@ -200,10 +288,10 @@ func (i *Ingress) Get(ctx context.Context, cm kai.ClusterManager) (string, error
 	})
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return result, fmt.Errorf("Ingress %q not found in namespace %q", i.Name, i.Namespace)
+		if apierrors.IsNotFound(err) {
+			return result, fmt.Errorf("Ingress %q not found in namespace %q: %w", i.Name, i.Namespace, err)
 		}
-		return result, fmt.Errorf("failed to get Ingress %q: %v", i.Name, err)
+		return result, fmt.Errorf("failed to get Ingress %q: %w", i.Name, err)
 	}
 
 	return formatIngress(ingress), nil
@@ -213,7 +301,7 @@ func (i *Ingress) Get(ctx context.Context, cm kai.ClusterManager) (string, error
 func (i *Ingress) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -261,7 +349,7 @@ func (i *Ingress) Update(ctx context.Context, cm kai.ClusterManager) (string, er
 		return result, errors.New("namespace is required for update")
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -275,6 +363,8 @@ func (i *Ingress) Update(ctx context.Context, cm kai.ClusterManager) (string, er
 		return result, fmt.Errorf("Ingress %q not found in namespace %q: %w", i.Name, i.Namespace, err)
 	}
 
+	before := existingIngress.DeepCopy()
+
 	// Update fields if specified
 	if i.IngressClassName != "" {
 		existingIngress.Spec.IngressClassName = &i.IngressClassName
@@ -370,13 +460,16 @@ func (i *Ingress) Update(ctx context.Context, cm kai.ClusterManager) (string, er
 		existingIngress.Spec.TLS = tlsConfigs
 	}
 
-	updatedIngress, err := client.NetworkingV1().Ingresses(i.Namespace).Update(timeoutCtx, existingIngress, metav1.UpdateOptions{})
+	updatedIngress, err := client.NetworkingV1().Ingresses(i.Namespace).Update(timeoutCtx, existingIngress, metav1.UpdateOptions{DryRun: dryRunOption(i.DryRun)})
 	if err != nil {
 		return result, fmt.Errorf("failed to update Ingress: %w", err)
 	}
 
 	result = fmt.Sprintf("Ingress %q updated successfully in namespace %q", updatedIngress.Name, updatedIngress.Namespace)
-	return result, nil
+	if i.ShowDiff {
+		result = withUpdateDiff(result, before, updatedIngress)
+	}
+	return dryRunResult(result, i.DryRun), nil
 }
 
 // Delete removes an Ingress by name from the specified namespace.
@@ -387,7 +480,7 @@ func (i *Ingress) Delete(ctx context.Context, cm kai.ClusterManager) (string, er
 		return result, errors.New("Ingress name is required for deletion")
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/basebandit/kai/testmocks"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -20,6 +21,8 @@ func TestIngressOperations(t *testing.T) {
 	t.Run("ListIngresses", testListIngresses)
 	t.Run("UpdateIngress", testUpdateIngress)
 	t.Run("DeleteIngress", testDeleteIngress)
+	t.Run("CreateIngressForService", testCreateIngressForService)
+	t.Run("DescribeIngress", testDescribeIngress)
 }
 
 func testCreateIngress(t *testing.T) {
@@ -865,3 +868,187 @@ func testDeleteIngress(t *testing.T) {
 		})
 	}
 }
+
+func testCreateIngressForService(t *testing.T) {
+	ctx := context.Background()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+	web := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 8080}}},
+	}
+	api := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: testNamespace},
+		Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+			{Name: "http", Port: 80},
+			{Name: "grpc", Port: 9090},
+		}},
+	}
+
+	t.Run("ServiceWithSinglePort", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(ns, web)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		ingress := &Ingress{Name: "web", Namespace: testNamespace}
+		result, err := ingress.CreateForService(ctx, mockCM, "web.example.com", kai.IngressPath{ServiceName: "web"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, `Ingress "web" created successfully`)
+
+		created, err := fakeClient.NetworkingV1().Ingresses(testNamespace).Get(ctx, "web", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, created.Spec.Rules, 1)
+		rule := created.Spec.Rules[0]
+		assert.Equal(t, "web.example.com", rule.Host)
+		assert.Equal(t, "/", rule.HTTP.Paths[0].Path)
+		assert.Equal(t, networkingv1.PathTypePrefix, *rule.HTTP.Paths[0].PathType)
+		assert.Equal(t, "web", rule.HTTP.Paths[0].Backend.Service.Name)
+		assert.Equal(t, int32(8080), rule.HTTP.Paths[0].Backend.Service.Port.Number)
+	})
+
+	t.Run("NamedPort", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(ns, api)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		ingress := &Ingress{Name: "api", Namespace: testNamespace}
+		_, err := ingress.CreateForService(ctx, mockCM, "", kai.IngressPath{Path: "/api", ServiceName: "api", ServicePort: "grpc"})
+		assert.NoError(t, err)
+
+		created, err := fakeClient.NetworkingV1().Ingresses(testNamespace).Get(ctx, "api", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, "grpc", created.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name)
+	})
+
+	t.Run("AmbiguousPort", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(ns, api), nil)
+
+		ingress := &Ingress{Name: "api", Namespace: testNamespace}
+		_, err := ingress.CreateForService(ctx, mockCM, "", kai.IngressPath{ServiceName: "api"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "has 2 ports (http:80, grpc:9090)")
+	})
+
+	t.Run("UnknownPort", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(ns, web), nil)
+
+		ingress := &Ingress{Name: "web", Namespace: testNamespace}
+		_, err := ingress.CreateForService(ctx, mockCM, "", kai.IngressPath{ServiceName: "web", ServicePort: int32(80)})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `service "web" has no port 80`)
+	})
+
+	t.Run("MissingService", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(ns)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		ingress := &Ingress{Name: "web", Namespace: testNamespace}
+		_, err := ingress.CreateForService(ctx, mockCM, "web.example.com", kai.IngressPath{ServiceName: "web"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `service "web" not found in namespace "test-namespace"`)
+
+		ingresses, _ := fakeClient.NetworkingV1().Ingresses(testNamespace).List(ctx, metav1.ListOptions{})
+		assert.Empty(t, ingresses.Items)
+	})
+}
+
+func testDescribeIngress(t *testing.T) {
+	ctx := context.Background()
+	prefix := networkingv1.PathTypePrefix
+	backend := func(service string, port networkingv1.ServiceBackendPort) networkingv1.IngressBackend {
+		return networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: service, Port: port}}
+	}
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: testNamespace},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: ptr("nginx"),
+			Rules: []networkingv1.IngressRule{{
+				Host: "shop.example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{Path: "/", PathType: &prefix, Backend: backend("web", networkingv1.ServiceBackendPort{Number: 80})},
+						{Path: "/api", PathType: &prefix, Backend: backend("api", networkingv1.ServiceBackendPort{Name: "http"})},
+						{Path: "/admin", PathType: &prefix, Backend: backend("admin", networkingv1.ServiceBackendPort{Number: 80})},
+						{Path: "/legacy", PathType: &prefix, Backend: backend("web", networkingv1.ServiceBackendPort{Number: 8080})},
+					},
+				}},
+			}},
+			TLS: []networkingv1.IngressTLS{
+				{Hosts: []string{"shop.example.com"}, SecretName: "shop-tls"},
+				{Hosts: []string{"old.example.com"}, SecretName: "old-tls"},
+			},
+		},
+		Status: networkingv1.IngressStatus{LoadBalancer: networkingv1.IngressLoadBalancerStatus{
+			Ingress: []networkingv1.IngressLoadBalancerIngress{{IP: "203.0.113.10"}, {Hostname: "lb.example.com"}},
+		}},
+	}
+	web := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80}}},
+	}
+	api := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: testNamespace},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8000}}},
+	}
+	webSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-abc",
+			Namespace: testNamespace,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "web"},
+		},
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"10.0.0.1"}},
+			{Addresses: []string{"10.0.0.2"}},
+			{Addresses: []string{"10.0.0.3"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr(false)}},
+		},
+	}
+	tlsSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "shop-tls", Namespace: testNamespace}}
+
+	t.Run("AddressBackendsAndTLS", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(ingress, web, api, webSlice, tlsSecret), nil)
+
+		result, err := (&Ingress{Name: "shop", Namespace: testNamespace}).Describe(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Address: 203.0.113.10, lb.example.com")
+		assert.Contains(t, result, "Class: nginx")
+		assert.Contains(t, result, "  Host: shop.example.com\n")
+		assert.Contains(t, result, "    / (Prefix) → web:80 (2 ready endpoint(s))")
+		assert.Contains(t, result, "    /api (Prefix) → api:http (no ready endpoints)")
+		assert.Contains(t, result, "    /admin (Prefix) → admin:80 (service not found)")
+		assert.Contains(t, result, "    /legacy (Prefix) → web:8080 (port 8080 not found on service)")
+		assert.Contains(t, result, "  shop.example.com → secret shop-tls\n")
+		assert.Contains(t, result, "  old.example.com → secret old-tls (secret not found)")
+		assert.Contains(t, result, "Events: <none>")
+	})
+
+	t.Run("PendingAddressAndDefaultClass", func(t *testing.T) {
+		pending := ingress.DeepCopy()
+		pending.Spec.IngressClassName = nil
+		pending.Status = networkingv1.IngressStatus{}
+		defaultClass := &networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{
+			Name:        "traefik",
+			Annotations: map[string]string{defaultIngressClassAnnotation: "true"},
+		}}
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(pending, defaultClass), nil)
+
+		result, err := (&Ingress{Name: "shop", Namespace: testNamespace}).Describe(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Address: <pending>")
+		assert.Contains(t, result, "Class: traefik (cluster default)")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(), nil)
+
+		_, err := (&Ingress{Name: "shop", Namespace: testNamespace}).Describe(ctx, mockCM)
+		assert.EqualError(t, err, `Ingress "shop" not found in namespace "test-namespace"`)
+	})
+}
@@ -0,0 +1,251 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+)
+
+const maxIngressEvents = 10
+
+// defaultIngressClassAnnotation marks the IngressClass used for Ingresses
+// that do not name one.
+const defaultIngressClassAnnotation = "ingressclass.kubernetes.io/is-default-class"
+
+// Describe shows where an Ingress is reachable and where its traffic goes:
+// the load balancer address (or <pending>), the class, each rule's
+// host/path → service:port with how many ready endpoints back it, TLS hosts
+// and whether their secrets exist, and recent events.
+func (i *Ingress) Describe(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	ingress, err := client.NetworkingV1().Ingresses(i.Namespace).Get(timeoutCtx, i.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("Ingress %q not found in namespace %q", i.Name, i.Namespace)
+		}
+		return "", fmt.Errorf("failed to get Ingress %q: %w", i.Name, err)
+	}
+
+	backends := &backendResolver{ctx: timeoutCtx, client: client, namespace: ingress.Namespace}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Ingress: %s\n", ingress.Name)
+	fmt.Fprintf(&sb, "Namespace: %s\n", ingress.Namespace)
+	fmt.Fprintf(&sb, "Address: %s\n", ingressAddress(ingress))
+	fmt.Fprintf(&sb, "Class: %s\n", ingressClass(timeoutCtx, client, ingress))
+
+	if ingress.Spec.DefaultBackend != nil {
+		fmt.Fprintf(&sb, "Default Backend: %s\n", backends.describe(ingress.Spec.DefaultBackend))
+	}
+
+	if len(ingress.Spec.Rules) == 0 {
+		sb.WriteString("\nRules: <none>\n")
+	} else {
+		sb.WriteString("\nRules:\n")
+		for _, rule := range ingress.Spec.Rules {
+			host := rule.Host
+			if host == "" {
+				host = "*"
+			}
+			fmt.Fprintf(&sb, "  Host: %s\n", host)
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				pathType := "Prefix"
+				if path.PathType != nil {
+					pathType = string(*path.PathType)
+				}
+				p := path.Path
+				if p == "" {
+					p = "/"
+				}
+				fmt.Fprintf(&sb, "    %s (%s) → %s\n", p, pathType, backends.describe(&path.Backend))
+			}
+		}
+	}
+
+	if len(ingress.Spec.TLS) > 0 {
+		sb.WriteString("\nTLS:\n")
+		for _, tls := range ingress.Spec.TLS {
+			hosts := "*"
+			if len(tls.Hosts) > 0 {
+				hosts = strings.Join(tls.Hosts, ", ")
+			}
+			secret := "<none>"
+			if tls.SecretName != "" {
+				secret = tls.SecretName
+				if _, err := client.CoreV1().Secrets(ingress.Namespace).Get(timeoutCtx, tls.SecretName, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+					secret += " (secret not found)"
+				}
+			}
+			fmt.Fprintf(&sb, "  %s → secret %s\n", hosts, secret)
+		}
+	}
+
+	events, err := objectEvents(timeoutCtx, client, ingress.Namespace, "Ingress", ingress.Name, maxIngressEvents)
+	if err != nil {
+		return "", err
+	}
+	if len(events) == 0 {
+		sb.WriteString("\nEvents: <none>")
+	} else {
+		sb.WriteString("\n")
+		sb.WriteString(formatEventList(&corev1.EventList{Items: events}, false))
+	}
+
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// ingressAddress lists the load balancer IPs and hostnames assigned to the
+// Ingress, or <pending> while the controller has not assigned any.
+func ingressAddress(ingress *networkingv1.Ingress) string {
+	var addresses []string
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			addresses = append(addresses, lb.IP)
+		}
+		if lb.Hostname != "" {
+			addresses = append(addresses, lb.Hostname)
+		}
+	}
+	if len(addresses) == 0 {
+		return "<pending>"
+	}
+	return strings.Join(addresses, ", ")
+}
+
+// ingressClass names the class the Ingress asked for, or the cluster's
+// default IngressClass when it names none.
+func ingressClass(ctx context.Context, client kubernetes.Interface, ingress *networkingv1.Ingress) string {
+	if ingress.Spec.IngressClassName != nil && *ingress.Spec.IngressClassName != "" {
+		return *ingress.Spec.IngressClassName
+	}
+	classes, err := client.NetworkingV1().IngressClasses().List(ctx, metav1.ListOptions{})
+	if err == nil {
+		for _, class := range classes.Items {
+			if class.Annotations[defaultIngressClassAnnotation] == "true" {
+				return class.Name + " (cluster default)"
+			}
+		}
+	}
+	return "<none>"
+}
+
+// backendResolver looks up the services behind Ingress backends, fetching
+// each service and its endpoints once.
+type backendResolver struct {
+	ctx       context.Context
+	client    kubernetes.Interface
+	namespace string
+	services  map[string]*corev1.Service
+	ready     map[string]int
+}
+
+// describe renders a backend as service:port followed by its state, such as
+// "(2 ready endpoint(s))" or "(service not found)".
+func (r *backendResolver) describe(backend *networkingv1.IngressBackend) string {
+	if backend.Resource != nil {
+		return fmt.Sprintf("resource %s/%s", backend.Resource.Kind, backend.Resource.Name)
+	}
+	if backend.Service == nil {
+		return "<none>"
+	}
+
+	name := backend.Service.Name
+	port := backend.Service.Port.Name
+	if backend.Service.Port.Number > 0 {
+		port = fmt.Sprintf("%d", backend.Service.Port.Number)
+	}
+	target := fmt.Sprintf("%s:%s", name, port)
+
+	service, err := r.service(name)
+	if err != nil {
+		return fmt.Sprintf("%s (%v)", target, err)
+	}
+	if service == nil {
+		return target + " (service not found)"
+	}
+	if !serviceHasPort(service, backend.Service.Port) {
+		return fmt.Sprintf("%s (port %s not found on service)", target, port)
+	}
+
+	ready, err := r.readyEndpoints(name)
+	if err != nil {
+		return fmt.Sprintf("%s (%v)", target, err)
+	}
+	if ready == 0 {
+		return target + " (no ready endpoints)"
+	}
+	return fmt.Sprintf("%s (%d ready endpoint(s))", target, ready)
+}
+
+func (r *backendResolver) service(name string) (*corev1.Service, error) {
+	if service, ok := r.services[name]; ok {
+		return service, nil
+	}
+	service, err := r.client.CoreV1().Services(r.namespace).Get(r.ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		service, err = nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service: %w", err)
+	}
+	if r.services == nil {
+		r.services = map[string]*corev1.Service{}
+	}
+	r.services[name] = service
+	return service, nil
+}
+
+// readyEndpoints counts the distinct ready addresses in the service's
+// EndpointSlices.
+func (r *backendResolver) readyEndpoints(service string) (int, error) {
+	if ready, ok := r.ready[service]; ok {
+		return ready, nil
+	}
+	slices, err := r.client.DiscoveryV1().EndpointSlices(r.namespace).List(r.ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + service,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list endpoint slices: %w", err)
+	}
+	addresses := sets.New[string]()
+	for _, slice := range slices.Items {
+		for _, ep := range slice.Endpoints {
+			if ep.Conditions.Ready == nil || *ep.Conditions.Ready {
+				addresses.Insert(ep.Addresses...)
+			}
+		}
+	}
+	if r.ready == nil {
+		r.ready = map[string]int{}
+	}
+	r.ready[service] = addresses.Len()
+	return addresses.Len(), nil
+}
+
+func serviceHasPort(service *corev1.Service, port networkingv1.ServiceBackendPort) bool {
+	for _, p := range service.Spec.Ports {
+		if (port.Number > 0 && p.Port == port.Number) || (port.Name != "" && p.Name == port.Name) {
+			return true
+		}
+	}
+	return false
+}
@@ -5,11 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/basebandit/kai"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 )
@@ -29,6 +32,14 @@ type Job struct {
 	Env              map[string]interface{}
 	ImagePullPolicy  string
 	ImagePullSecrets []interface{}
+	// FailOnExitCodes fails the whole Job, without further retries, when a
+	// container exits with one of these codes.
+	FailOnExitCodes []int32
+	// IgnoreDisruptions keeps pods evicted by disruptions such as node
+	// drains or preemption from counting against BackoffLimit.
+	IgnoreDisruptions bool
+	DryRun            bool
+	ShowDiff          bool
 }
 
 // Create creates a new Job in the specified namespace.
@@ -49,7 +60,7 @@ func (j *Job) Create(ctx context.Context, cm kai.ClusterManager) (string, error)
 		slog.String("namespace", j.Namespace),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for Job create",
 			slog.String("name", j.Name),
@@ -131,6 +142,8 @@ func (j *Job) Create(ctx context.Context, cm kai.ClusterManager) (string, error)
 		job.Spec.BackoffLimit = j.BackoffLimit
 	}
 
+	job.Spec.PodFailurePolicy = j.podFailurePolicy()
+
 	if j.Completions != nil {
 		job.Spec.Completions = j.Completions
 	}
@@ -139,7 +152,7 @@ func (j *Job) Create(ctx context.Context, cm kai.ClusterManager) (string, error)
 		job.Spec.Parallelism = j.Parallelism
 	}
 
-	createdJob, err := client.BatchV1().Jobs(j.Namespace).Create(timeoutCtx, job, metav1.CreateOptions{})
+	createdJob, err := client.BatchV1().Jobs(j.Namespace).Create(timeoutCtx, job, metav1.CreateOptions{DryRun: dryRunOption(j.DryRun)})
 	if err != nil {
 		slog.Warn("failed to create Job",
 			slog.String("name", j.Name),
@@ -155,7 +168,12 @@ func (j *Job) Create(ctx context.Context, cm kai.ClusterManager) (string, error)
 	)
 
 	result = fmt.Sprintf("Job %q created successfully in namespace %q", createdJob.Name, createdJob.Namespace)
-	return result, nil
+	// API servers without pod failure policy support drop the field rather
+	// than reject the Job.
+	if job.Spec.PodFailurePolicy != nil && createdJob.Spec.PodFailurePolicy == nil {
+		result += " (note: the cluster ignored the pod failure policy; it needs Kubernetes 1.26 or later)"
+	}
+	return dryRunResult(result, j.DryRun), nil
 }
 
 // Get retrieves a Job by name from the specified namespace.
@@ -167,7 +185,7 @@ func (j *Job) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
 		slog.String("namespace", j.Namespace),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for Job get",
 			slog.String("name", j.Name),
@@ -179,7 +197,7 @@ func (j *Job) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
 
 	var job *batchv1.Job
 	err = retry.OnError(retry.DefaultRetry, func(err error) bool {
-		return !strings.Contains(err.Error(), "not found")
+		return !apierrors.IsNotFound(err)
 	}, func() error {
 		var getErr error
 		job, getErr = client.BatchV1().Jobs(j.Namespace).Get(ctx, j.Name, metav1.GetOptions{})
@@ -187,20 +205,20 @@ func (j *Job) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	})
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if apierrors.IsNotFound(err) {
 			slog.Warn("Job not found",
 				slog.String("name", j.Name),
 				slog.String("namespace", j.Namespace),
 				slog.String("error", err.Error()),
 			)
-			return result, fmt.Errorf("Job %q not found in namespace %q", j.Name, j.Namespace)
+			return result, fmt.Errorf("Job %q not found in namespace %q: %w", j.Name, j.Namespace, err)
 		}
 		slog.Warn("failed to get Job",
 			slog.String("name", j.Name),
 			slog.String("namespace", j.Namespace),
 			slog.String("error", err.Error()),
 		)
-		return result, fmt.Errorf("failed to get Job %q: %v", j.Name, err)
+		return result, fmt.Errorf("failed to get Job %q: %w", j.Name, err)
 	}
 
 	return formatJob(job), nil
@@ -216,7 +234,7 @@ func (j *Job) List(ctx context.Context, cm kai.ClusterManager, allNamespaces boo
 		slog.String("label_selector", labelSelector),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for Job list",
 			slog.Bool("all_namespaces", allNamespaces),
@@ -279,7 +297,7 @@ func (j *Job) Delete(ctx context.Context, cm kai.ClusterManager) (string, error)
 		slog.String("namespace", j.Namespace),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for Job delete",
 			slog.String("name", j.Name),
@@ -334,7 +352,7 @@ func (j *Job) Update(ctx context.Context, cm kai.ClusterManager) (string, error)
 		return result, errors.New("Job name is required")
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -347,6 +365,8 @@ func (j *Job) Update(ctx context.Context, cm kai.ClusterManager) (string, error)
 		return result, fmt.Errorf("failed to get Job: %w", err)
 	}
 
+	before := job.DeepCopy()
+
 	if len(j.Labels) > 0 {
 		if job.Labels == nil {
 			job.Labels = make(map[string]string)
@@ -360,13 +380,89 @@ func (j *Job) Update(ctx context.Context, cm kai.ClusterManager) (string, error)
 		job.Spec.Parallelism = j.Parallelism
 	}
 
-	updatedJob, err := client.BatchV1().Jobs(j.Namespace).Update(timeoutCtx, job, metav1.UpdateOptions{})
+	updatedJob, err := client.BatchV1().Jobs(j.Namespace).Update(timeoutCtx, job, metav1.UpdateOptions{DryRun: dryRunOption(j.DryRun)})
 	if err != nil {
 		return result, fmt.Errorf("failed to update Job: %w", err)
 	}
 
 	result = fmt.Sprintf("Job %q updated successfully in namespace %q", updatedJob.Name, updatedJob.Namespace)
-	return result, nil
+	if j.ShowDiff {
+		result = withUpdateDiff(result, before, updatedJob)
+	}
+	return dryRunResult(result, j.DryRun), nil
+}
+
+// Logs returns the logs of every pod created by the Job, each line prefixed
+// with the pod name. tailLines and since apply to each pod.
+func (j *Job) Logs(ctx context.Context, cm kai.ClusterManager, tailLines int64, since *time.Duration) (string, error) {
+	if j.Name == "" {
+		return "", errors.New("Job name is required")
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := j.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	pods, err := client.CoreV1().Pods(namespace).List(timeoutCtx, metav1.ListOptions{
+		LabelSelector: "job-name=" + j.Name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods for Job %q: %w", j.Name, err)
+	}
+
+	if len(pods.Items) == 0 {
+		return fmt.Sprintf("No pods found for Job %q in namespace %q", j.Name, namespace), nil
+	}
+
+	sort.Slice(pods.Items, func(a, b int) bool {
+		return pods.Items[a].CreationTimestamp.Before(&pods.Items[b].CreationTimestamp)
+	})
+
+	// Share the output budget between pods so one chatty pod cannot
+	// crowd out the rest.
+	perPod := maxLogBytes / len(pods.Items)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Logs for Job %q in namespace %q (%d pod(s)):\n", j.Name, namespace, len(pods.Items))
+	for _, pod := range pods.Items {
+		if len(pod.Spec.Containers) == 0 {
+			continue
+		}
+		logOptions := &corev1.PodLogOptions{Container: pod.Spec.Containers[0].Name}
+		if tailLines > 0 {
+			logOptions.TailLines = &tailLines
+		}
+		if since != nil {
+			logOptions.SinceSeconds = ptr(int64(since.Seconds()))
+		}
+
+		logs, err := readPodLogs(timeoutCtx, client, namespace, pod.Name, logOptions, perPod)
+		if err != nil {
+			fmt.Fprintf(&sb, "[%s] <%s>\n", pod.Name, err.Error())
+			continue
+		}
+		if len(logs) == 0 {
+			fmt.Fprintf(&sb, "[%s] <no logs> (phase: %s)\n", pod.Name, pod.Status.Phase)
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimRight(string(logs), "\n"), "\n") {
+			fmt.Fprintf(&sb, "[%s] %s\n", pod.Name, line)
+		}
+		if len(logs) == perPod {
+			fmt.Fprintf(&sb, "[%s] [output truncated; use 'tail' or 'since' to narrow]\n", pod.Name)
+		}
+	}
+
+	return strings.TrimRight(sb.String(), "\n"), nil
 }
 
 func (j *Job) validate() error {
@@ -379,5 +475,137 @@ func (j *Job) validate() error {
 	if j.Image == "" {
 		return errors.New("image is required")
 	}
+	if j.BackoffLimit != nil && *j.BackoffLimit < 0 {
+		return fmt.Errorf("backoff_limit must be non-negative, got %d", *j.BackoffLimit)
+	}
+	if len(j.FailOnExitCodes) > 0 || j.IgnoreDisruptions {
+		if j.RestartPolicy != "" && corev1.RestartPolicy(j.RestartPolicy) != corev1.RestartPolicyNever {
+			return fmt.Errorf("a pod failure policy requires restart policy Never, got %s", j.RestartPolicy)
+		}
+		for _, code := range j.FailOnExitCodes {
+			if code == 0 {
+				return errors.New("fail_on_exit_codes cannot include 0, which marks success")
+			}
+		}
+	}
 	return nil
 }
+
+// podFailurePolicy builds the Job's pod failure policy, or nil when none was
+// asked for. Disruption rules come first so that an evicted pod is not also
+// matched by its exit code.
+func (j *Job) podFailurePolicy() *batchv1.PodFailurePolicy {
+	var rules []batchv1.PodFailurePolicyRule
+	if j.IgnoreDisruptions {
+		rules = append(rules, batchv1.PodFailurePolicyRule{
+			Action: batchv1.PodFailurePolicyActionIgnore,
+			OnPodConditions: []batchv1.PodFailurePolicyOnPodConditionsPattern{{
+				Type:   corev1.DisruptionTarget,
+				Status: corev1.ConditionTrue,
+			}},
+		})
+	}
+	if len(j.FailOnExitCodes) > 0 {
+		rules = append(rules, batchv1.PodFailurePolicyRule{
+			Action: batchv1.PodFailurePolicyActionFailJob,
+			OnExitCodes: &batchv1.PodFailurePolicyOnExitCodesRequirement{
+				Operator: batchv1.PodFailurePolicyOnExitCodesOpIn,
+				Values:   j.FailOnExitCodes,
+			},
+		})
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	return &batchv1.PodFailurePolicy{Rules: rules}
+}
+
+const (
+	defaultJobWaitTimeout = 60 * time.Second
+	maxJobWaitTimeout     = 5 * time.Minute
+)
+
+// jobWaitPollInterval is how often WaitForCompletion re-reads the Job.
+// Tests shorten it.
+var jobWaitPollInterval = 2 * time.Second
+
+// WaitForCompletion polls the Job until it reports a Complete or Failed
+// condition or timeout elapses. timeout defaults to 60s and is capped at 5m.
+// A failed Job is reported in the result rather than as an error; only
+// timeouts and API errors are errors.
+func (j *Job) WaitForCompletion(ctx context.Context, cm kai.ClusterManager, timeout time.Duration) (string, error) {
+	if j.Name == "" {
+		return "", errors.New("Job name is required")
+	}
+	if timeout <= 0 {
+		timeout = defaultJobWaitTimeout
+	}
+	timeout = min(timeout, maxJobWaitTimeout)
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := j.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	slog.Debug("Job wait requested",
+		slog.String("name", j.Name),
+		slog.String("namespace", namespace),
+		slog.Duration("timeout", timeout),
+	)
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(jobWaitPollInterval)
+	defer ticker.Stop()
+
+	var last batchv1.JobStatus
+	timedOut := func() error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("timed out after %s waiting for Job %q to finish (active: %d, succeeded: %d, failed: %d)",
+			timeout, j.Name, last.Active, last.Succeeded, last.Failed)
+	}
+
+	for {
+		job, err := client.BatchV1().Jobs(namespace).Get(waitCtx, j.Name, metav1.GetOptions{})
+		if err != nil {
+			if waitCtx.Err() != nil {
+				return "", timedOut()
+			}
+			if apierrors.IsNotFound(err) {
+				return "", fmt.Errorf("Job %q not found in namespace %q: %w", j.Name, namespace, err)
+			}
+			return "", fmt.Errorf("failed to get Job %q: %w", j.Name, err)
+		}
+
+		if condition, ok := jobFinishedCondition(job); ok {
+			return formatJobOutcome(job, condition), nil
+		}
+		last = job.Status
+
+		select {
+		case <-waitCtx.Done():
+			return "", timedOut()
+		case <-ticker.C:
+		}
+	}
+}
+
+// jobFinishedCondition returns the Job's Complete or Failed condition if
+// either is true.
+func jobFinishedCondition(job *batchv1.Job) (batchv1.JobCondition, bool) {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) &&
+			condition.Status == corev1.ConditionTrue {
+			return condition, true
+		}
+	}
+	return batchv1.JobCondition{}, false
+}
@@ -3,22 +3,28 @@ package cluster
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/basebandit/kai/testmocks"
 	"github.com/stretchr/testify/assert"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestJobOperations(t *testing.T) {
 	t.Run("CreateJob", testCreateJob)
+	t.Run("CreateJobFailurePolicy", testCreateJobFailurePolicy)
 	t.Run("GetJob", testGetJob)
 	t.Run("ListJobs", testListJobs)
 	t.Run("DeleteJob", testDeleteJob)
 	t.Run("UpdateJob", testUpdateJob)
+	t.Run("JobLogs", testJobLogs)
+	t.Run("WaitForCompletion", testJobWaitForCompletion)
 }
 
 func testCreateJob(t *testing.T) {
@@ -104,6 +110,95 @@ func testCreateJob(t *testing.T) {
 	}
 }
 
+func testCreateJobFailurePolicy(t *testing.T) {
+	ctx := context.Background()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+
+	t.Run("BackoffLimitAndPolicyReachSpec", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(ns)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+		backoffLimit := int32(2)
+
+		job := &Job{
+			Name:              "flaky",
+			Namespace:         testNamespace,
+			Image:             "busybox:latest",
+			BackoffLimit:      &backoffLimit,
+			FailOnExitCodes:   []int32{42},
+			IgnoreDisruptions: true,
+		}
+		result, err := job.Create(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Equal(t, `Job "flaky" created successfully in namespace "test-namespace"`, result)
+
+		created, err := fakeClient.BatchV1().Jobs(testNamespace).Get(ctx, "flaky", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), *created.Spec.BackoffLimit)
+		assert.Equal(t, &batchv1.PodFailurePolicy{Rules: []batchv1.PodFailurePolicyRule{
+			{
+				Action: batchv1.PodFailurePolicyActionIgnore,
+				OnPodConditions: []batchv1.PodFailurePolicyOnPodConditionsPattern{{
+					Type:   corev1.DisruptionTarget,
+					Status: corev1.ConditionTrue,
+				}},
+			},
+			{
+				Action: batchv1.PodFailurePolicyActionFailJob,
+				OnExitCodes: &batchv1.PodFailurePolicyOnExitCodesRequirement{
+					Operator: batchv1.PodFailurePolicyOnExitCodesOpIn,
+					Values:   []int32{42},
+				},
+			},
+		}}, created.Spec.PodFailurePolicy)
+	})
+
+	t.Run("NoPolicyByDefault", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(ns)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		_, err := (&Job{Name: "plain", Namespace: testNamespace, Image: "busybox:latest"}).Create(ctx, mockCM)
+		assert.NoError(t, err)
+		created, err := fakeClient.BatchV1().Jobs(testNamespace).Get(ctx, "plain", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Nil(t, created.Spec.PodFailurePolicy)
+		assert.Nil(t, created.Spec.BackoffLimit)
+	})
+
+	t.Run("ClusterDropsPolicy", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(ns)
+		fakeClient.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			job := action.(k8stesting.CreateAction).GetObject().(*batchv1.Job).DeepCopy()
+			job.Spec.PodFailurePolicy = nil
+			return true, job, nil
+		})
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		result, err := (&Job{Name: "old", Namespace: testNamespace, Image: "busybox:latest", FailOnExitCodes: []int32{1}}).Create(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "the cluster ignored the pod failure policy")
+	})
+
+	invalid := []struct {
+		name string
+		job  *Job
+		want string
+	}{
+		{"NegativeBackoffLimit", &Job{BackoffLimit: func() *int32 { v := int32(-1); return &v }()}, "backoff_limit must be non-negative, got -1"},
+		{"PolicyNeedsNeverRestart", &Job{RestartPolicy: "OnFailure", IgnoreDisruptions: true}, "a pod failure policy requires restart policy Never, got OnFailure"},
+		{"ZeroExitCode", &Job{FailOnExitCodes: []int32{0}}, "fail_on_exit_codes cannot include 0, which marks success"},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.job.Name, tc.job.Namespace, tc.job.Image = "bad", testNamespace, "busybox:latest"
+			_, err := tc.job.Create(ctx, testmocks.NewMockClusterManager())
+			assert.EqualError(t, err, tc.want)
+		})
+	}
+}
+
 func testGetJob(t *testing.T) {
 	ctx := context.Background()
 	completions := int32(1)
@@ -506,3 +601,133 @@ func testUpdateJob(t *testing.T) {
 		})
 	}
 }
+
+func testJobLogs(t *testing.T) {
+	ctx := context.Background()
+
+	jobPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+				Labels:    map[string]string{"job-name": "batch"},
+			},
+			Spec:   corev1.PodSpec{Containers: []corev1.Container{{Name: "worker", Image: "busybox"}}},
+			Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+		}
+	}
+	otherPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: testNamespace},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "busybox"}}},
+	}
+
+	t.Run("PrefixesEachPod", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(jobPod("batch-abcde"), jobPod("batch-fghij"), otherPod)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		job := &Job{Name: "batch", Namespace: testNamespace}
+		result, err := job.Logs(ctx, mockCM, 10, nil)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "(2 pod(s))")
+		assert.Contains(t, result, "[batch-abcde] fake logs")
+		assert.Contains(t, result, "[batch-fghij] fake logs")
+		assert.NotContains(t, result, "unrelated")
+	})
+
+	t.Run("NoPods", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(otherPod)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		job := &Job{Name: "batch", Namespace: testNamespace}
+		result, err := job.Logs(ctx, mockCM, 0, nil)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "No pods found")
+	})
+}
+
+func testJobWaitForCompletion(t *testing.T) {
+	ctx := context.Background()
+
+	originalInterval := jobWaitPollInterval
+	jobWaitPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { jobWaitPollInterval = originalInterval })
+
+	start := metav1.NewTime(time.Now().Add(-90 * time.Second))
+	finished := metav1.NewTime(start.Add(90 * time.Second))
+
+	runningJob := func() *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "batch", Namespace: testNamespace},
+			Status:     batchv1.JobStatus{Active: 1, StartTime: &start},
+		}
+	}
+
+	t.Run("CompletesAfterPolling", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(runningJob())
+		gets := 0
+		fakeClient.PrependReactor("get", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			gets++
+			if gets < 3 {
+				return false, nil, nil
+			}
+			job := runningJob()
+			job.Status.Active = 0
+			job.Status.Succeeded = 1
+			job.Status.CompletionTime = &finished
+			job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+			return true, job, nil
+		})
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		job := &Job{Name: "batch", Namespace: testNamespace}
+		result, err := job.WaitForCompletion(ctx, mockCM, time.Second)
+
+		assert.NoError(t, err)
+		assert.Equal(t, `Job "batch" completed in 1m (succeeded: 1, failed: 0)`, result)
+		assert.Equal(t, 3, gets)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		job := runningJob()
+		job.Status.Active = 0
+		job.Status.Failed = 4
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type:    batchv1.JobFailed,
+			Status:  corev1.ConditionTrue,
+			Reason:  "BackoffLimitExceeded",
+			Message: "Job has reached the specified backoff limit",
+		}}
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(job), nil)
+
+		result, err := (&Job{Name: "batch", Namespace: testNamespace}).WaitForCompletion(ctx, mockCM, time.Second)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, `Job "batch" failed (succeeded: 0, failed: 4)`)
+		assert.Contains(t, result, "Reason: BackoffLimitExceeded")
+		assert.Contains(t, result, "Message: Job has reached the specified backoff limit")
+	})
+
+	t.Run("TimesOut", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(runningJob()), nil)
+
+		_, err := (&Job{Name: "batch", Namespace: testNamespace}).WaitForCompletion(ctx, mockCM, 50*time.Millisecond)
+
+		assert.EqualError(t, err, `timed out after 50ms waiting for Job "batch" to finish (active: 1, succeeded: 0, failed: 0)`)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(), nil)
+
+		_, err := (&Job{Name: "batch", Namespace: testNamespace}).WaitForCompletion(ctx, mockCM, time.Second)
+
+		assert.EqualError(t, err, `Job "batch" not found in namespace "test-namespace": jobs.batch "batch" not found`)
+	})
+}
@@ -0,0 +1,135 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LimitRange represents an operation target for a namespace's LimitRange.
+type LimitRange struct {
+	Name      string
+	Namespace string
+}
+
+// Get shows the limit range's min, max, defaults and max limit/request
+// ratio per type and resource.
+func (l *LimitRange) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if l.Name == "" {
+		return "", errors.New("limit range name is required")
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	limitRange, err := client.CoreV1().LimitRanges(l.Namespace).Get(timeoutCtx, l.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("limit range %q not found in namespace %q", l.Name, l.Namespace)
+		}
+		return "", fmt.Errorf("failed to get limit range %q: %w", l.Name, err)
+	}
+
+	return formatLimitRange(limitRange), nil
+}
+
+// List lists the limit ranges in the namespace, or in all namespaces, with
+// the types each one constrains.
+func (l *LimitRange) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := l.Namespace
+	if allNamespaces {
+		namespace = ""
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	limitRanges, err := client.CoreV1().LimitRanges(namespace).List(timeoutCtx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list limit ranges: %w", err)
+	}
+
+	if len(limitRanges.Items) == 0 {
+		if allNamespaces {
+			return "No limit ranges found in any namespace", nil
+		}
+		return fmt.Sprintf("No limit ranges found in namespace %q", l.Namespace), nil
+	}
+
+	var sb strings.Builder
+	if allNamespaces {
+		sb.WriteString("Limit ranges across all namespaces:\n")
+	} else {
+		fmt.Fprintf(&sb, "Limit ranges in namespace %q:\n", l.Namespace)
+	}
+	for _, limitRange := range limitRanges.Items {
+		name := limitRange.Name
+		if allNamespaces {
+			name = limitRange.Namespace + "/" + name
+		}
+		types := make([]string, 0, len(limitRange.Spec.Limits))
+		for _, item := range limitRange.Spec.Limits {
+			types = append(types, string(item.Type))
+		}
+		fmt.Fprintf(&sb, "• %s: Types=%s, Age=%s\n", name, strings.Join(types, ", "), formatDuration(time.Since(limitRange.CreationTimestamp.Time)))
+	}
+	fmt.Fprintf(&sb, "\nTotal: %d limit range(s)", len(limitRanges.Items))
+	return sb.String(), nil
+}
+
+// formatLimitRange renders a limit range as a table in the layout of
+// kubectl describe limitrange, with one row per type and resource and "-"
+// where a bound is not set.
+func formatLimitRange(limitRange *corev1.LimitRange) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "LimitRange: %s\n", limitRange.Name)
+	fmt.Fprintf(&sb, "Namespace: %s\n", limitRange.Namespace)
+	if len(limitRange.Spec.Limits) == 0 {
+		sb.WriteString("No limits set\n")
+		return strings.TrimRight(sb.String(), "\n")
+	}
+
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  TYPE\tRESOURCE\tMIN\tMAX\tDEFAULT REQUEST\tDEFAULT LIMIT\tMAX LIMIT/REQUEST RATIO")
+	for _, item := range limitRange.Spec.Limits {
+		resources := corev1.ResourceList{}
+		for _, list := range []corev1.ResourceList{item.Min, item.Max, item.DefaultRequest, item.Default, item.MaxLimitRequestRatio} {
+			for name, quantity := range list {
+				resources[name] = quantity
+			}
+		}
+		for _, resource := range sortedResourceNames(resources) {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\n", item.Type, resource,
+				limitValue(item.Min, resource), limitValue(item.Max, resource),
+				limitValue(item.DefaultRequest, resource), limitValue(item.Default, resource),
+				limitValue(item.MaxLimitRequestRatio, resource))
+		}
+	}
+	_ = w.Flush()
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func limitValue(list corev1.ResourceList, resource corev1.ResourceName) string {
+	if _, ok := list[resource]; !ok {
+		return "-"
+	}
+	return resourceAmount(list, resource)
+}
@@ -0,0 +1,245 @@
+package cluster
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultTailTimeout   = 10 * time.Second
+	maxTailTimeout       = 60 * time.Second
+	defaultTailSelectorN = 10
+)
+
+// tailPollInterval controls how often the pod list is refreshed while
+// tailing, so pods created during the window are picked up.
+var tailPollInterval = 2 * time.Second
+
+// LogTail follows the logs of every pod matching a label selector for a
+// bounded window and merges them into one stream.
+type LogTail struct {
+	Namespace     string
+	LabelSelector string
+	Container     string        // container to follow; defaults to each pod's first container
+	TailLines     int64         // lines of history per pod; defaults to 10
+	Timeout       time.Duration // follow window; defaults to 10s, capped at 60s
+}
+
+type tailLine struct {
+	pod  string
+	text string
+}
+
+// Run streams logs from all matching pods concurrently until the window
+// closes or the output cap is reached. Each line is prefixed with its pod
+// name; lines appear in the order they were received.
+func (l *LogTail) Run(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if l.LabelSelector == "" {
+		return "", errors.New("label selector is required")
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := l.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+	window := l.Timeout
+	if window <= 0 {
+		window = defaultTailTimeout
+	}
+	window = min(window, maxTailTimeout)
+	tailLines := l.TailLines
+	if tailLines <= 0 {
+		tailLines = defaultTailSelectorN
+	}
+
+	windowCtx, cancel := context.WithTimeout(ctx, window)
+	defer cancel()
+
+	pods, err := client.CoreV1().Pods(namespace).List(windowCtx, metav1.ListOptions{LabelSelector: l.LabelSelector})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods: %w", err)
+	}
+	if len(pods.Items) == 0 {
+		return fmt.Sprintf("No pods matching %q in namespace %q", l.LabelSelector, namespace), nil
+	}
+
+	lines := make(chan tailLine, 256)
+	var wg sync.WaitGroup
+	started := make(map[string]bool)
+	follow := func(pods []corev1.Pod) {
+		for i := range pods {
+			pod := pods[i]
+			if started[pod.Name] || pod.Status.Phase == corev1.PodPending {
+				continue
+			}
+			started[pod.Name] = true
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				l.followPod(windowCtx, client, namespace, &pod, tailLines, lines)
+			}()
+		}
+	}
+	follow(pods.Items)
+
+	// Pick up pods that appear during the window. Streams for pods that
+	// go away end on their own.
+	go func() {
+		ticker := time.NewTicker(tailPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-windowCtx.Done():
+				wg.Wait()
+				close(lines)
+				return
+			case <-ticker.C:
+				refreshed, err := client.CoreV1().Pods(namespace).List(windowCtx, metav1.ListOptions{LabelSelector: l.LabelSelector})
+				if err == nil {
+					follow(refreshed.Items)
+				}
+			}
+		}
+	}()
+
+	var sb strings.Builder
+	truncated := false
+	for line := range lines {
+		if truncated {
+			continue
+		}
+		entry := fmt.Sprintf("[%s] %s\n", line.pod, line.text)
+		if sb.Len()+len(entry) > maxLogBytes {
+			truncated = true
+			cancel()
+			continue
+		}
+		sb.WriteString(entry)
+	}
+
+	header := fmt.Sprintf("Logs from %d pod(s) matching %q in namespace %q (window %s, tail=%d):\n\n",
+		len(started), l.LabelSelector, namespace, window, tailLines)
+	result := header + strings.TrimRight(sb.String(), "\n")
+	if sb.Len() == 0 {
+		result = header + "<no log lines received>"
+	}
+	if truncated {
+		result += "\n\n[Output truncated due to size limits. Use a narrower selector or smaller tail.]"
+	}
+	return result, nil
+}
+
+// followPod streams one pod's logs into lines until ctx is done or the pod
+// stops running. A stream that drops while the pod is still running is
+// reopened with backoff, resuming after the last line received.
+func (l *LogTail) followPod(ctx context.Context, client kubernetes.Interface, namespace string, pod *corev1.Pod, tailLines int64, lines chan<- tailLine) {
+	container := l.Container
+	if container == "" && len(pod.Spec.Containers) > 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	// Timestamps give a point to resume from; they are stripped before the
+	// lines are forwarded.
+	opts := &corev1.PodLogOptions{
+		Container:  container,
+		Follow:     true,
+		TailLines:  &tailLines,
+		Timestamps: true,
+	}
+	backoff := watchBackoff
+	var last time.Time
+	received := false
+	for {
+		stream, err := client.CoreV1().Pods(namespace).GetLogs(pod.Name, opts).Stream(ctx)
+		if err == nil {
+			n := forwardLogLines(ctx, stream, pod.Name, &last, lines)
+			_ = stream.Close()
+			if n > 0 {
+				received = true
+				backoff = watchBackoff
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		reportErr := func() {
+			if err != nil {
+				lines <- tailLine{pod: pod.Name, text: fmt.Sprintf("<failed to stream logs: %v>", err)}
+			}
+		}
+		if err != nil && !isTransientWatchError(err) {
+			reportErr()
+			return
+		}
+		// Without timestamps there is nothing to resume from, and reading
+		// the tail again would repeat lines already forwarded.
+		if received && last.IsZero() {
+			return
+		}
+		// Streams also end when the container stops; only a running pod
+		// is worth reconnecting to.
+		current, getErr := client.CoreV1().Pods(namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if getErr != nil || current.Status.Phase != corev1.PodRunning {
+			if ctx.Err() == nil {
+				reportErr()
+			}
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff.Step()):
+		}
+		if !last.IsZero() {
+			opts.TailLines = nil
+			opts.SinceTime = &metav1.Time{Time: last}
+		}
+	}
+}
+
+// forwardLogLines sends each line of a timestamped log stream to lines and
+// returns how many it sent. last is the timestamp of the newest line already
+// forwarded; SinceTime only has second precision, so lines at or before it
+// at the start of a resumed stream are skipped as repeats.
+func forwardLogLines(ctx context.Context, stream io.Reader, pod string, last *time.Time, lines chan<- tailLine) int {
+	resuming := !last.IsZero()
+	sent := 0
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		text := scanner.Text()
+		if stamp, rest, ok := strings.Cut(text, " "); ok {
+			if ts, err := time.Parse(time.RFC3339Nano, stamp); err == nil {
+				if resuming && !ts.After(*last) {
+					continue
+				}
+				resuming = false
+				*last = ts
+				text = rest
+			}
+		}
+		select {
+		case lines <- tailLine{pod: pod, text: text}:
+			sent++
+		case <-ctx.Done():
+			return sent
+		}
+	}
+	return sent
+}
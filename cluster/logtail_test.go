@@ -0,0 +1,109 @@
+package cluster
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestLogTailRun(t *testing.T) {
+	ctx := context.Background()
+
+	appPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: map[string]string{"app": "web"}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web"}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+
+	t.Run("MergesPods", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(appPod("web-a"), appPod("web-b"))
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		tail := &LogTail{Namespace: testNamespace, LabelSelector: "app=web", Timeout: 100 * time.Millisecond}
+		result, err := tail.Run(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Logs from 2 pod(s)")
+		assert.Contains(t, result, "[web-a] fake logs")
+		assert.Contains(t, result, "[web-b] fake logs")
+	})
+
+	t.Run("PicksUpNewPods", func(t *testing.T) {
+		orig := tailPollInterval
+		tailPollInterval = 10 * time.Millisecond
+		defer func() { tailPollInterval = orig }()
+
+		fakeClient := fake.NewSimpleClientset(appPod("web-a"))
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		go func() {
+			time.Sleep(30 * time.Millisecond)
+			_, _ = fakeClient.CoreV1().Pods(testNamespace).Create(ctx, appPod("web-c"), metav1.CreateOptions{})
+		}()
+
+		tail := &LogTail{Namespace: testNamespace, LabelSelector: "app=web", Timeout: 300 * time.Millisecond}
+		result, err := tail.Run(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "[web-a] fake logs")
+		assert.Contains(t, result, "[web-c] fake logs")
+	})
+
+	t.Run("NoMatchingPods", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(), nil)
+
+		tail := &LogTail{Namespace: testNamespace, LabelSelector: "app=web"}
+		result, err := tail.Run(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "No pods matching")
+	})
+
+	t.Run("RequiresSelector", func(t *testing.T) {
+		tail := &LogTail{Namespace: testNamespace}
+		_, err := tail.Run(ctx, testmocks.NewMockClusterManager())
+		assert.Error(t, err)
+	})
+}
+
+func TestForwardLogLines(t *testing.T) {
+	ctx := context.Background()
+	collect := func(stream string, last *time.Time) ([]string, int) {
+		lines := make(chan tailLine, 10)
+		n := forwardLogLines(ctx, strings.NewReader(stream), "web-a", last, lines)
+		close(lines)
+		var got []string
+		for l := range lines {
+			got = append(got, l.text)
+		}
+		return got, n
+	}
+
+	var last time.Time
+	got, n := collect("2024-01-15T10:30:00.1Z first\n2024-01-15T10:30:00.2Z second\n", &last)
+	assert.Equal(t, []string{"first", "second"}, got)
+	assert.Equal(t, 2, n)
+
+	// A resumed stream starts at the whole second, so it repeats lines
+	// already forwarded before reaching new ones.
+	got, _ = collect("2024-01-15T10:30:00.1Z first\n2024-01-15T10:30:00.2Z second\n2024-01-15T10:30:01Z third\n", &last)
+	assert.Equal(t, []string{"third"}, got)
+	assert.Equal(t, "2024-01-15T10:30:01Z", last.Format(time.RFC3339Nano))
+
+	var none time.Time
+	got, _ = collect("no timestamp here\n", &none)
+	assert.Equal(t, []string{"no timestamp here"}, got)
+	assert.True(t, none.IsZero())
+}
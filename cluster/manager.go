@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -15,28 +16,60 @@ import (
 	"time"
 
 	"github.com/basebandit/kai"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
 	"k8s.io/client-go/util/homedir"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
-// Manager maintains connections to Kubernetes clusters
+// Manager maintains connections to Kubernetes clusters. Every context of
+// every loaded kubeconfig is kept side by side under its own name, and the
+// current context selects which one the Current* accessors resolve to.
 type Manager struct {
+	// mu guards the per-context maps and the current context and namespace,
+	// which concurrent tool calls read while load and switch tools write.
+	mu               sync.RWMutex
 	kubeconfigs      map[string]string
 	restConfigs      map[string]*rest.Config
 	clients          map[string]kubernetes.Interface
 	dynamicClients   map[string]dynamic.Interface
+	restMappers      map[string]*cachedRESTMapper
 	contexts         map[string]*kai.ContextInfo
 	currentContext   string
 	currentNamespace string
 	requestTimeout   time.Duration
+	clientQPS        float32
+	clientBurst      int
+	apiRetries       int
+	listThreshold    int
+	maxNamespaces    int
+	impersonation    kai.Impersonation
+	// impersonationFixed is set when the identity came from WithImpersonation
+	// and SetImpersonation must not replace it.
+	impersonationFixed bool
 }
 
+// Client-side rate limits applied to every Kubernetes API client. client-go
+// defaults to 5 QPS with a burst of 10, which throttles bulk operations such
+// as all-namespace scans; these are higher while staying well below what an
+// API server's priority and fairness limits typically allow a single client.
+const (
+	DefaultClientQPS   float32 = 50
+	DefaultClientBurst         = 100
+)
+
+// DefaultListSummaryThreshold is the number of matching pods above which a
+// list_pods call without a limit is summarized; see WithListSummaryThreshold.
+const DefaultListSummaryThreshold = 500
+
 // Option configures a Manager.
 type Option func(*Manager)
 
@@ -51,17 +84,88 @@ func WithRequestTimeout(d time.Duration) Option {
 	}
 }
 
+// WithClientRateLimit sets the client-side QPS and burst applied to every
+// Kubernetes API client created by the Manager. Raising them speeds up bulk
+// operations at the cost of more load on the API server, which may then
+// throttle kai server-side instead. Zero or negative values keep the
+// defaults.
+func WithClientRateLimit(qps float32, burst int) Option {
+	return func(cm *Manager) {
+		if qps > 0 {
+			cm.clientQPS = qps
+		}
+		if burst > 0 {
+			cm.clientBurst = burst
+		}
+	}
+}
+
+// WithAPIRetries sets how many times a read that fails with a transient
+// error (dropped connection, 429, 502, 503, 504) is retried with exponential
+// backoff before the error is returned. Zero disables retries; negative
+// values keep the default.
+func WithAPIRetries(n int) Option {
+	return func(cm *Manager) {
+		if n >= 0 {
+			cm.apiRetries = n
+		}
+	}
+}
+
+// WithListSummaryThreshold sets the number of matching pods above which a
+// pod list without an explicit limit is cut down to its first page plus a
+// match count, to keep huge listings out of the caller's context. Zero
+// disables the summary; negative values keep the default.
+func WithListSummaryThreshold(n int) Option {
+	return func(cm *Manager) {
+		if n >= 0 {
+			cm.listThreshold = n
+		}
+	}
+}
+
+// WithMaxNamespacesScan sets the number of namespaces above which
+// all_namespaces operations refuse to run without confirmation, so a stray
+// request cannot sweep a huge cluster. Zero, the default, disables the
+// check; negative values are ignored.
+func WithMaxNamespacesScan(n int) Option {
+	return func(cm *Manager) {
+		if n >= 0 {
+			cm.maxNamespaces = n
+		}
+	}
+}
+
+// WithImpersonation makes every Kubernetes API client created by the Manager
+// act as imp, like kubectl's --as flags. The API server must allow the
+// kubeconfig's user to impersonate that identity. A non-zero imp is fixed:
+// SetImpersonation refuses to replace it.
+func WithImpersonation(imp kai.Impersonation) Option {
+	return func(cm *Manager) {
+		cm.impersonation = imp
+		cm.impersonationFixed = !imp.IsZero()
+	}
+}
+
 // New creates a new cluster Manager. Without options the default request
-// timeout is 30 seconds.
+// timeout is 30 seconds, clients are limited to DefaultClientQPS with a
+// burst of DefaultClientBurst, transient read failures are retried
+// DefaultAPIRetries times, and pod lists are summarized above
+// DefaultListSummaryThreshold pods.
 func New(opts ...Option) *Manager {
 	cm := &Manager{
 		kubeconfigs:      make(map[string]string),
 		restConfigs:      make(map[string]*rest.Config),
 		clients:          make(map[string]kubernetes.Interface),
 		dynamicClients:   make(map[string]dynamic.Interface),
+		restMappers:      make(map[string]*cachedRESTMapper),
 		contexts:         make(map[string]*kai.ContextInfo),
 		currentNamespace: "default",
 		requestTimeout:   30 * time.Second,
+		clientQPS:        DefaultClientQPS,
+		clientBurst:      DefaultClientBurst,
+		apiRetries:       DefaultAPIRetries,
+		listThreshold:    DefaultListSummaryThreshold,
 	}
 	for _, opt := range opts {
 		opt(cm)
@@ -74,6 +178,18 @@ func (cm *Manager) RequestTimeout() time.Duration {
 	return cm.requestTimeout
 }
 
+// ListSummaryThreshold returns the number of matching pods above which a pod
+// list without a limit is summarized, or zero when summaries are disabled.
+func (cm *Manager) ListSummaryThreshold() int {
+	return cm.listThreshold
+}
+
+// MaxNamespacesScan returns the number of namespaces above which
+// all_namespaces operations need confirmation, or zero when unchecked.
+func (cm *Manager) MaxNamespacesScan() int {
+	return cm.maxNamespaces
+}
+
 // LoadInClusterConfig loads the in-cluster Kubernetes configuration
 // This is used when kai is running inside a Kubernetes pod
 func (cm *Manager) LoadInClusterConfig(name string) error {
@@ -81,7 +197,7 @@ func (cm *Manager) LoadInClusterConfig(name string) error {
 		name = "in-cluster"
 	}
 
-	if _, exists := cm.contexts[name]; exists {
+	if cm.hasContext(name) {
 		return fmt.Errorf("context %s already exists", name)
 	}
 
@@ -90,7 +206,7 @@ func (cm *Manager) LoadInClusterConfig(name string) error {
 		return fmt.Errorf("failed to load in-cluster config: %w", err)
 	}
 
-	config.Timeout = 30 * time.Second
+	cm.configureClient(config)
 
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
@@ -102,7 +218,7 @@ func (cm *Manager) LoadInClusterConfig(name string) error {
 		return fmt.Errorf("error creating dynamic client: %w", err)
 	}
 
-	if err := testConnection(clientset); err != nil {
+	if err := testConnection(clientset, cm.requestTimeout); err != nil {
 		return fmt.Errorf("failed to connect to cluster: %w", err)
 	}
 
@@ -119,6 +235,12 @@ func (cm *Manager) LoadInClusterConfig(name string) error {
 		IsActive:   true,
 	}
 
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if _, exists := cm.contexts[name]; exists {
+		return fmt.Errorf("context %s already exists", name)
+	}
+
 	cm.kubeconfigs[name] = ""
 	cm.restConfigs[name] = config
 	cm.clients[name] = clientset
@@ -134,7 +256,18 @@ func (cm *Manager) LoadInClusterConfig(name string) error {
 	return nil
 }
 
-// LoadKubeConfig loads a kubeconfig file into the manager
+// contextClients holds the clients built for one kubeconfig context.
+type contextClients struct {
+	config    *rest.Config
+	clientset kubernetes.Interface
+	dynamic   dynamic.Interface
+}
+
+// LoadKubeConfig loads every context of a kubeconfig file into the manager,
+// registered as "<name>-<context>". Each context gets clients for its own
+// cluster and user, so contexts from one file can be switched between. The
+// file's current context becomes active only when no context is active yet,
+// which keeps the first loaded kubeconfig in charge until switch_context.
 func (cm *Manager) LoadKubeConfig(name, path string) error {
 	if err := validateInputs(name, path); err != nil {
 		return err
@@ -149,7 +282,7 @@ func (cm *Manager) LoadKubeConfig(name, path string) error {
 		return err
 	}
 
-	if _, exists := cm.contexts[name]; exists {
+	if cm.hasContext(name) {
 		return fmt.Errorf("context %s already exists", name)
 	}
 
@@ -158,27 +291,93 @@ func (cm *Manager) LoadKubeConfig(name, path string) error {
 		return err
 	}
 
-	restConfig, clientset, dynamicClient, err := cm.createClients(resolvedPath)
+	return cm.registerContexts(name, allContexts, currentContext, func(contextName string) (*rest.Config, kubernetes.Interface, dynamic.Interface, error) {
+		return cm.createClients(resolvedPath, contextName)
+	})
+}
+
+// LoadKubeConfigBytes loads every context of an inline kubeconfig, such as
+// one handed over by a remote caller, exactly as LoadKubeConfig does for a
+// file. Nothing is written to disk, so switching to these contexts does not
+// update any kubeconfig file.
+func (cm *Manager) LoadKubeConfigBytes(name string, data []byte) error {
+	if name == "" {
+		return errors.New("cluster name cannot be empty")
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return errors.New("kubeconfig content is empty")
+	}
+
+	rawConfig, err := clientcmd.Load(data)
 	if err != nil {
-		return err
+		return fmt.Errorf("error parsing kubeconfig: %w", err)
 	}
 
-	if err := testConnection(clientset); err != nil {
-		return err
+	if cm.hasContext(name) {
+		return fmt.Errorf("context %s already exists", name)
+	}
+
+	allContexts := contextsFromConfig(rawConfig, "")
+	if len(allContexts) == 0 {
+		return errors.New("kubeconfig has no contexts with a matching cluster")
+	}
+
+	return cm.registerContexts(name, allContexts, rawConfig.CurrentContext, func(contextName string) (*rest.Config, kubernetes.Interface, dynamic.Interface, error) {
+		clientConfig := clientcmd.NewNonInteractiveClientConfig(*rawConfig, contextName, &clientcmd.ConfigOverrides{}, nil)
+		return cm.clientsFor(clientConfig, contextName)
+	})
+}
+
+// registerContexts builds clients for each context of a kubeconfig and
+// registers them as "<name>-<context>". A context whose clients cannot be
+// built is skipped unless it is the kubeconfig's current context.
+func (cm *Manager) registerContexts(name string, allContexts map[string]*kai.ContextInfo, currentContext string,
+	build func(contextName string) (*rest.Config, kubernetes.Interface, dynamic.Interface, error)) error {
+	clients := make(map[string]contextClients, len(allContexts))
+	for contextName, contextInfo := range allContexts {
+		restConfig, clientset, dynamicClient, err := build(contextName)
+		if err != nil {
+			if contextName == currentContext {
+				return err
+			}
+			// One broken context should not keep the rest of the file from
+			// loading.
+			slog.Warn("skipping kubeconfig context",
+				slog.String("context", contextName),
+				slog.String("path", contextInfo.ConfigPath),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		clients[contextName] = contextClients{config: restConfig, clientset: clientset, dynamic: dynamicClient}
+	}
+
+	if current, ok := clients[currentContext]; ok {
+		if err := testConnection(current.clientset, cm.requestTimeout); err != nil {
+			return err
+		}
 	}
 
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
 	// Store all contexts from this kubeconfig
 	for contextName, contextInfo := range allContexts {
+		c, ok := clients[contextName]
+		if !ok {
+			continue
+		}
+
 		uniqueName := contextName
 		if name != "" {
 			uniqueName = fmt.Sprintf("%s-%s", name, contextName)
 		}
 
 		if _, exists := cm.contexts[uniqueName]; !exists {
-			cm.kubeconfigs[uniqueName] = resolvedPath
-			cm.restConfigs[uniqueName] = restConfig
-			cm.clients[uniqueName] = clientset
-			cm.dynamicClients[uniqueName] = dynamicClient
+			cm.kubeconfigs[uniqueName] = contextInfo.ConfigPath
+			cm.restConfigs[uniqueName] = c.config
+			cm.clients[uniqueName] = c.clientset
+			cm.dynamicClients[uniqueName] = c.dynamic
 			cm.contexts[uniqueName] = contextInfo
 			contextInfo.Name = uniqueName
 		}
@@ -200,8 +399,19 @@ func (cm *Manager) LoadKubeConfig(name, path string) error {
 	return nil
 }
 
+// hasContext reports whether a context is registered under name.
+func (cm *Manager) hasContext(name string) bool {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	_, exists := cm.contexts[name]
+	return exists
+}
+
 // DeleteContext removes a context from the manager
 func (cm *Manager) DeleteContext(name string) error {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
 	if _, exists := cm.contexts[name]; !exists {
 		slog.Debug("context not found for deletion", slog.String("context", name))
 		return fmt.Errorf("context %s not found", name)
@@ -211,6 +421,7 @@ func (cm *Manager) DeleteContext(name string) error {
 		delete(cm.contexts, name)
 		delete(cm.clients, name)
 		delete(cm.dynamicClients, name)
+		delete(cm.restMappers, name)
 		delete(cm.kubeconfigs, name)
 		delete(cm.restConfigs, name)
 
@@ -227,6 +438,7 @@ func (cm *Manager) DeleteContext(name string) error {
 	delete(cm.contexts, name)
 	delete(cm.clients, name)
 	delete(cm.dynamicClients, name)
+	delete(cm.restMappers, name)
 	delete(cm.kubeconfigs, name)
 	delete(cm.restConfigs, name)
 
@@ -236,6 +448,9 @@ func (cm *Manager) DeleteContext(name string) error {
 
 // GetContextInfo returns detailed information about a specific context
 func (cm *Manager) GetContextInfo(name string) (*kai.ContextInfo, error) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
 	contextInfo, exists := cm.contexts[name]
 	if !exists {
 		return nil, fmt.Errorf("context %s not found", name)
@@ -251,6 +466,9 @@ func (cm *Manager) RenameContext(oldName, newName string) error {
 		return errors.New("old and new context names cannot be the same")
 	}
 
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
 	contextInfo, exists := cm.contexts[oldName]
 	if !exists {
 		return fmt.Errorf("context %s not found", oldName)
@@ -264,12 +482,16 @@ func (cm *Manager) RenameContext(oldName, newName string) error {
 	cm.contexts[newName] = contextInfo
 	cm.clients[newName] = cm.clients[oldName]
 	cm.dynamicClients[newName] = cm.dynamicClients[oldName]
+	if mapper, ok := cm.restMappers[oldName]; ok {
+		cm.restMappers[newName] = mapper
+	}
 	cm.kubeconfigs[newName] = cm.kubeconfigs[oldName]
 	cm.restConfigs[newName] = cm.restConfigs[oldName]
 
 	delete(cm.contexts, oldName)
 	delete(cm.clients, oldName)
 	delete(cm.dynamicClients, oldName)
+	delete(cm.restMappers, oldName)
 	delete(cm.kubeconfigs, oldName)
 	delete(cm.restConfigs, oldName)
 
@@ -282,6 +504,9 @@ func (cm *Manager) RenameContext(oldName, newName string) error {
 
 // ListContexts returns all available contexts
 func (cm *Manager) ListContexts() []*kai.ContextInfo {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
 	contexts := make([]*kai.ContextInfo, 0, len(cm.contexts))
 	for _, contextInfo := range cm.contexts {
 		contextCopy := *contextInfo
@@ -295,6 +520,9 @@ func (cm *Manager) ListContexts() []*kai.ContextInfo {
 
 // GetClient returns the Kubernetes client for a specific cluster
 func (cm *Manager) GetClient(clusterName string) (kubernetes.Interface, error) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
 	client, exists := cm.clients[clusterName]
 	if !exists {
 		return nil, fmt.Errorf("cluster %s not found", clusterName)
@@ -304,6 +532,9 @@ func (cm *Manager) GetClient(clusterName string) (kubernetes.Interface, error) {
 
 // GetDynamicClient returns the dynamic client for a specific cluster
 func (cm *Manager) GetDynamicClient(clusterName string) (dynamic.Interface, error) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
 	client, exists := cm.dynamicClients[clusterName]
 	if !exists {
 		return nil, fmt.Errorf("cluster %s not found", clusterName)
@@ -313,6 +544,9 @@ func (cm *Manager) GetDynamicClient(clusterName string) (dynamic.Interface, erro
 
 // GetCurrentClient returns the client for the current context
 func (cm *Manager) GetCurrentClient() (kubernetes.Interface, error) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
 	if len(cm.clients) == 0 {
 		return nil, errors.New("no clusters configured - use the load_kubeconfig tool first")
 	}
@@ -330,6 +564,9 @@ func (cm *Manager) GetCurrentClient() (kubernetes.Interface, error) {
 
 // GetCurrentDynamicClient returns the dynamic client for the current context
 func (cm *Manager) GetCurrentDynamicClient() (dynamic.Interface, error) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
 	if len(cm.dynamicClients) == 0 {
 		return nil, errors.New("no clusters configured - use the load_kubeconfig tool first")
 	}
@@ -345,21 +582,151 @@ func (cm *Manager) GetCurrentDynamicClient() (dynamic.Interface, error) {
 	return nil, errors.New("no dynamic clients available")
 }
 
+// GetRESTMapper returns the REST mapper for a specific cluster. It is built
+// on first use and caches discovery data until a kind is not found or the
+// context is switched to.
+func (cm *Manager) GetRESTMapper(clusterName string) (meta.RESTMapper, error) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	return cm.restMapperLocked(clusterName)
+}
+
+// GetCurrentRESTMapper returns the REST mapper for the current context.
+func (cm *Manager) GetCurrentRESTMapper() (meta.RESTMapper, error) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	if len(cm.clients) == 0 {
+		return nil, errors.New("no clusters configured - use the load_kubeconfig tool first")
+	}
+	if _, exists := cm.clients[cm.currentContext]; exists {
+		return cm.restMapperLocked(cm.currentContext)
+	}
+	for name := range cm.clients {
+		return cm.restMapperLocked(name)
+	}
+	return nil, errors.New("no clients available")
+}
+
+// restMapperLocked must be called with cm.mu held for writing.
+func (cm *Manager) restMapperLocked(clusterName string) (meta.RESTMapper, error) {
+	if mapper, exists := cm.restMappers[clusterName]; exists {
+		return mapper, nil
+	}
+	client, exists := cm.clients[clusterName]
+	if !exists {
+		return nil, fmt.Errorf("cluster %s not found", clusterName)
+	}
+	mapper := newCachedRESTMapper(client.Discovery())
+	cm.restMappers[clusterName] = mapper
+	return mapper, nil
+}
+
+// GetAPIExtensionsClient returns an apiextensions client for a specific
+// cluster. It is built on demand from the cluster's REST config.
+func (cm *Manager) GetAPIExtensionsClient(clusterName string) (apiextensionsclientset.Interface, error) {
+	config, err := cm.GetRESTConfig(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return newAPIExtensionsClientForConfig(config)
+}
+
+// GetCurrentAPIExtensionsClient returns an apiextensions client for the
+// current context.
+func (cm *Manager) GetCurrentAPIExtensionsClient() (apiextensionsclientset.Interface, error) {
+	config, err := cm.GetCurrentRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	return newAPIExtensionsClientForConfig(config)
+}
+
+func newAPIExtensionsClientForConfig(config *rest.Config) (apiextensionsclientset.Interface, error) {
+	client, err := apiextensionsclientset.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating apiextensions client: %w", err)
+	}
+	return client, nil
+}
+
+// GetMetricsClient returns a metrics.k8s.io client for a specific cluster.
+// It is built on demand from the cluster's REST config.
+func (cm *Manager) GetMetricsClient(clusterName string) (metricsclientset.Interface, error) {
+	config, err := cm.GetRESTConfig(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return newMetricsClientForConfig(config)
+}
+
+// GetCurrentMetricsClient returns a metrics.k8s.io client for the current
+// context.
+func (cm *Manager) GetCurrentMetricsClient() (metricsclientset.Interface, error) {
+	config, err := cm.GetCurrentRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	return newMetricsClientForConfig(config)
+}
+
+func newMetricsClientForConfig(config *rest.Config) (metricsclientset.Interface, error) {
+	client, err := metricsclientset.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating metrics client: %w", err)
+	}
+	return client, nil
+}
+
+// GetRESTConfig returns the REST config for a specific cluster.
+func (cm *Manager) GetRESTConfig(clusterName string) (*rest.Config, error) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	config, exists := cm.restConfigs[clusterName]
+	if !exists {
+		return nil, fmt.Errorf("cluster %s not found", clusterName)
+	}
+	return config, nil
+}
+
+// GetCurrentRESTConfig returns the REST config for the current context.
+func (cm *Manager) GetCurrentRESTConfig() (*rest.Config, error) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	if len(cm.restConfigs) == 0 {
+		return nil, errors.New("no clusters configured - use the load_kubeconfig tool first")
+	}
+	config, exists := cm.restConfigs[cm.currentContext]
+	if !exists {
+		return nil, fmt.Errorf("cluster %s not found", cm.currentContext)
+	}
+	return config, nil
+}
+
 // SetCurrentNamespace sets the current namespace
 func (cm *Manager) SetCurrentNamespace(namespace string) {
 	if namespace == "" {
 		namespace = "default"
 	}
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
 	cm.currentNamespace = namespace
 }
 
 // GetCurrentNamespace returns the current namespace
 func (cm *Manager) GetCurrentNamespace() string {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
 	return cm.currentNamespace
 }
 
 // ListClusters returns a list of all configured clusters
 func (cm *Manager) ListClusters() []string {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
 	clusters := make([]string, 0, len(cm.clients))
 	for name := range cm.clients {
 		clusters = append(clusters, name)
@@ -369,6 +736,9 @@ func (cm *Manager) ListClusters() []string {
 
 // SetCurrentContext sets the current context and updates the kubeconfig file
 func (cm *Manager) SetCurrentContext(contextName string) error {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
 	if _, exists := cm.clients[contextName]; !exists {
 		slog.Debug("context not found", slog.String("context", contextName))
 		return fmt.Errorf("cluster %s not found", contextName)
@@ -382,12 +752,20 @@ func (cm *Manager) SetCurrentContext(contextName string) error {
 	}
 
 	cm.currentContext = contextName
+	// Switching is the natural point to pick up API changes made while the
+	// context was not in use.
+	if mapper, ok := cm.restMappers[contextName]; ok {
+		mapper.Reset()
+	}
 	if contextInfo, exists := cm.contexts[contextName]; exists {
 		contextInfo.IsActive = true
 
-		// Update the kubeconfig file to reflect the context switch
-		if err := cm.updateKubeconfigCurrentContext(contextName, contextInfo.ConfigPath); err != nil {
-			return fmt.Errorf("failed to update kubeconfig file: %w", err)
+		// Update the kubeconfig file to reflect the context switch. In-cluster
+		// and inline contexts have no file to update.
+		if contextInfo.ConfigPath != "" {
+			if err := cm.updateKubeconfigCurrentContext(contextName, contextInfo.ConfigPath); err != nil {
+				return fmt.Errorf("failed to update kubeconfig file: %w", err)
+			}
 		}
 	}
 
@@ -400,6 +778,8 @@ func (cm *Manager) SetCurrentContext(contextName string) error {
 
 // GetCurrentContext returns the current context name
 func (cm *Manager) GetCurrentContext() string {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
 	return cm.currentContext
 }
 
@@ -442,6 +822,12 @@ func extractAllContextsInfo(path, prefix string) (map[string]*kai.ContextInfo, s
 		return nil, "", fmt.Errorf("error getting raw config: %w", err)
 	}
 
+	return contextsFromConfig(&rawConfig, cleanPath), rawConfig.CurrentContext, nil
+}
+
+// contextsFromConfig describes each context of a parsed kubeconfig whose
+// cluster is defined. configPath is empty for kubeconfigs loaded inline.
+func contextsFromConfig(rawConfig *clientcmdapi.Config, configPath string) map[string]*kai.ContextInfo {
 	contexts := make(map[string]*kai.ContextInfo)
 
 	for contextName, context := range rawConfig.Contexts {
@@ -456,12 +842,12 @@ func extractAllContextsInfo(path, prefix string) (map[string]*kai.ContextInfo, s
 			User:       context.AuthInfo,
 			Namespace:  context.Namespace,
 			ServerURL:  cluster.Server,
-			ConfigPath: cleanPath,
+			ConfigPath: configPath,
 			IsActive:   false,
 		}
 	}
 
-	return contexts, rawConfig.CurrentContext, nil
+	return contexts
 }
 
 // updateKubeconfigCurrentContext updates the current-context in the kubeconfig file
@@ -501,16 +887,27 @@ func (cm *Manager) updateKubeconfigCurrentContext(contextName, configPath string
 }
 
 // createClients builds the rest.Config plus Kubernetes typed and dynamic
-// clients from a kubeconfig path. The rest.Config is returned so callers can
-// reuse it for port forwarding. The per-request timeout is taken from the
-// Manager so the user-facing --request-timeout flag is honored end-to-end.
-func (cm *Manager) createClients(path string) (*rest.Config, kubernetes.Interface, dynamic.Interface, error) {
-	config, err := clientcmd.BuildConfigFromFlags("", path)
+// clients for one context of a kubeconfig file, or for its current context
+// when contextName is empty. The rest.Config is returned so callers can
+// reuse it for port forwarding. The per-request timeout and rate limits are
+// taken from the Manager so the user-facing --request-timeout, --client-qps
+// and --client-burst flags are honored end-to-end.
+func (cm *Manager) createClients(path, contextName string) (*rest.Config, kubernetes.Interface, dynamic.Interface, error) {
+	return cm.clientsFor(clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: path},
+		&clientcmd.ConfigOverrides{CurrentContext: contextName},
+	), contextName)
+}
+
+// clientsFor builds the clients for contextName from an already selected
+// client config.
+func (cm *Manager) clientsFor(clientConfig clientcmd.ClientConfig, contextName string) (*rest.Config, kubernetes.Interface, dynamic.Interface, error) {
+	config, err := clientConfig.ClientConfig()
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("error building config from flags: %w", err)
+		return nil, nil, nil, fmt.Errorf("error building config for context %q: %w", contextName, err)
 	}
 
-	config.Timeout = cm.requestTimeout
+	cm.configureClient(config)
 
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
@@ -525,9 +922,84 @@ func (cm *Manager) createClients(path string) (*rest.Config, kubernetes.Interfac
 	return config, clientset, dynamicClient, nil
 }
 
-// testConnection tests the connection to the Kubernetes cluster
-func testConnection(client kubernetes.Interface) error {
-	_, err := client.CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{Limit: 1})
+// configureClient applies the Manager's timeout, rate limit, retry and
+// impersonation settings to a rest.Config before clients are built from it.
+func (cm *Manager) configureClient(config *rest.Config) {
+	config.Timeout = cm.requestTimeout
+	config.QPS = cm.clientQPS
+	config.Burst = cm.clientBurst
+	if wrap := newRetryTransport(cm.apiRetries); wrap != nil {
+		config.Wrap(wrap)
+	}
+	cm.mu.RLock()
+	config.Impersonate = cm.impersonation.Config()
+	cm.mu.RUnlock()
+}
+
+// GetImpersonation returns the identity the Manager's clients act as.
+func (cm *Manager) GetImpersonation() kai.Impersonation {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	return cm.impersonation
+}
+
+// SetImpersonation rebuilds the clients of every loaded context to act as
+// imp; the zero value goes back to the kubeconfig's own identity. Contexts
+// loaded afterwards act as imp too. REST mappers are kept, since discovery
+// does not depend on who is asking. An identity set with WithImpersonation
+// cannot be changed.
+func (cm *Manager) SetImpersonation(imp kai.Impersonation) error {
+	if err := imp.Validate(); err != nil {
+		return err
+	}
+
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	if cm.impersonationFixed {
+		return fmt.Errorf("requests act as %s, fixed at startup", cm.impersonation)
+	}
+
+	configs := make(map[string]*rest.Config, len(cm.restConfigs))
+	clients := make(map[string]kubernetes.Interface, len(cm.restConfigs))
+	dynamicClients := make(map[string]dynamic.Interface, len(cm.restConfigs))
+	for name, config := range cm.restConfigs {
+		config = rest.CopyConfig(config)
+		config.Impersonate = imp.Config()
+
+		clientset, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			return fmt.Errorf("error creating client for context %q: %w", name, err)
+		}
+		dynamicClient, err := dynamic.NewForConfig(config)
+		if err != nil {
+			return fmt.Errorf("error creating dynamic client for context %q: %w", name, err)
+		}
+		configs[name], clients[name], dynamicClients[name] = config, clientset, dynamicClient
+	}
+
+	for name := range configs {
+		cm.restConfigs[name] = configs[name]
+		cm.clients[name] = clients[name]
+		cm.dynamicClients[name] = dynamicClients[name]
+	}
+	previous := cm.impersonation
+	cm.impersonation = imp
+
+	slog.Info("impersonation changed",
+		slog.String("from", previous.String()),
+		slog.String("to", imp.String()),
+	)
+	return nil
+}
+
+// testConnection tests the connection to the Kubernetes cluster, giving up
+// after timeout so an unresponsive API server cannot block loading a context.
+func testConnection(client kubernetes.Interface, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	_, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{Limit: 1})
 	if err != nil {
 		return fmt.Errorf("failed to connect to cluster: %w", err)
 	}
@@ -613,19 +1085,18 @@ func (cm *Manager) StartPortForward(
 	localPort int,
 	remotePort int,
 ) (*PortForwardSession, error) {
-	currentContext := cm.GetCurrentContext()
-	config, exists := cm.restConfigs[currentContext]
-	if !exists {
-		return nil, fmt.Errorf("config not found for context %s", currentContext)
+	config, err := kai.CurrentRESTConfig(ctx, cm)
+	if err != nil {
+		return nil, fmt.Errorf("error getting REST config: %w", err)
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
 	}
 
 	if namespace == "" {
-		namespace = cm.GetCurrentNamespace()
+		namespace = kai.CurrentNamespace(ctx, cm)
 	}
 
 	podName := targetName
@@ -1,15 +1,24 @@
 package cluster
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/basebandit/kai"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
@@ -28,6 +37,7 @@ func TestClusterManager(t *testing.T) {
 	t.Run("Namespace", testNamespaceOperations)
 	t.Run("Context", testContextOperations)
 	t.Run("Clients", testClientOperations)
+	t.Run("RESTMapper", testRESTMapperCache)
 	t.Run("ListClusters", testListClusters)
 }
 
@@ -47,6 +57,7 @@ func TestExtendedClusterManager(t *testing.T) {
 	t.Run("LoadKubeConfigDuplicateName", testLoadKubeConfigDuplicateName)
 	t.Run("SetCurrentContextUpdatesActiveStatus", testSetCurrentContextUpdatesActiveStatus)
 	t.Run("UpdateKubeconfigCurrentContext", testUpdateKubeconfigCurrentContext)
+	t.Run("MultipleKubeconfigContexts", testMultipleKubeconfigContexts)
 }
 
 func TestInClusterConfig(t *testing.T) {
@@ -202,6 +213,75 @@ func testClientOperations(t *testing.T) {
 	dynamicClient, err := cm.GetCurrentDynamicClient()
 	assert.Error(t, err) // We haven't set any dynamic clients
 	assert.Nil(t, dynamicClient)
+
+	config, err := cm.GetCurrentRESTConfig()
+	assert.Error(t, err) // Nor any REST configs
+	assert.Nil(t, config)
+
+	restConfig := &rest.Config{Host: "https://cluster.example:6443"}
+	cm.restConfigs[testCluster] = restConfig
+
+	config, err = cm.GetCurrentRESTConfig()
+	assert.NoError(t, err)
+	assert.Same(t, restConfig, config)
+
+	config, err = cm.GetRESTConfig("nonexistent-cluster")
+	assert.Error(t, err)
+	assert.Nil(t, config)
+}
+
+func testRESTMapperCache(t *testing.T) {
+	cm := New()
+	_, err := cm.GetCurrentRESTMapper()
+	assert.Error(t, err)
+
+	fakeClient := fake.NewSimpleClientset()
+	fakeClient.Resources = applyDiscovery()
+	cm.clients[testCluster] = fakeClient
+	cm.contexts[testCluster] = &kai.ContextInfo{Name: testCluster}
+	cm.currentContext = testCluster
+	configMap := schema.GroupKind{Kind: "ConfigMap"}
+
+	mapper, err := cm.GetCurrentRESTMapper()
+	require.NoError(t, err)
+	_, err = mapper.RESTMapping(configMap, "v1")
+	require.NoError(t, err)
+	discoveryCalls := len(fakeClient.Actions())
+	assert.NotZero(t, discoveryCalls)
+
+	t.Run("Memoized", func(t *testing.T) {
+		again, err := cm.GetRESTMapper(testCluster)
+		require.NoError(t, err)
+		assert.Same(t, mapper, again)
+		_, err = again.RESTMapping(configMap, "v1")
+		require.NoError(t, err)
+		assert.Len(t, fakeClient.Actions(), discoveryCalls, "cached mapper should not rediscover")
+	})
+
+	t.Run("RefreshesOnUnknownKind", func(t *testing.T) {
+		fakeClient.Resources = append(fakeClient.Resources, &metav1.APIResourceList{
+			GroupVersion: "example.com/v1",
+			APIResources: []metav1.APIResource{{Name: "widgets", Namespaced: true, Kind: "Widget"}},
+		})
+		mapping, err := mapper.RESTMapping(schema.GroupKind{Group: "example.com", Kind: "Widget"}, "v1")
+		require.NoError(t, err)
+		assert.Equal(t, "widgets", mapping.Resource.Resource)
+		discoveryCalls = len(fakeClient.Actions())
+	})
+
+	t.Run("InvalidatedOnContextSwitch", func(t *testing.T) {
+		require.NoError(t, cm.SetCurrentContext(testCluster))
+		_, err := mapper.RESTMapping(configMap, "v1")
+		require.NoError(t, err)
+		assert.Greater(t, len(fakeClient.Actions()), discoveryCalls)
+	})
+
+	t.Run("DroppedWithContext", func(t *testing.T) {
+		require.NoError(t, cm.DeleteContext(testCluster))
+		_, err := cm.GetRESTMapper(testCluster)
+		assert.Error(t, err)
+		assert.NotContains(t, cm.restMappers, testCluster)
+	})
 }
 
 func testListClusters(t *testing.T) {
@@ -285,6 +365,57 @@ users:
 	err := os.WriteFile(kubeconfigPath, []byte(kubeconfigContent), 0600)
 	require.NoError(t, err)
 
+	t.Run("ClientRateLimit", func(t *testing.T) {
+		config, _, _, err := New().createClients(kubeconfigPath, "")
+		require.NoError(t, err)
+		assert.Equal(t, DefaultClientQPS, config.QPS)
+		assert.Equal(t, DefaultClientBurst, config.Burst)
+
+		cm := New(WithRequestTimeout(10*time.Second), WithClientRateLimit(200, 400))
+		config, _, _, err = cm.createClients(kubeconfigPath, "")
+		require.NoError(t, err)
+		assert.Equal(t, float32(200), config.QPS)
+		assert.Equal(t, 400, config.Burst)
+		assert.Equal(t, 10*time.Second, config.Timeout)
+
+		config, _, _, err = New(WithClientRateLimit(0, -1)).createClients(kubeconfigPath, "")
+		require.NoError(t, err)
+		assert.Equal(t, DefaultClientQPS, config.QPS)
+		assert.Equal(t, DefaultClientBurst, config.Burst)
+	})
+
+	t.Run("Impersonation", func(t *testing.T) {
+		imp := kai.Impersonation{ServiceAccount: "team-a/deployer", Groups: []string{"qa"}}
+		config, _, _, err := New(WithImpersonation(imp)).createClients(kubeconfigPath, "")
+		require.NoError(t, err)
+		assert.Equal(t, "system:serviceaccount:team-a:deployer", config.Impersonate.UserName)
+		assert.Equal(t, []string{"qa"}, config.Impersonate.Groups)
+
+		config, _, _, err = New().createClients(kubeconfigPath, "")
+		require.NoError(t, err)
+		assert.Empty(t, config.Impersonate.UserName)
+	})
+
+	t.Run("ConnectionTestTimesOut", func(t *testing.T) {
+		release := make(chan struct{})
+		hung := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+		}))
+		defer hung.Close()
+		defer close(release)
+
+		client, err := kubernetes.NewForConfig(&rest.Config{Host: hung.URL})
+		require.NoError(t, err)
+
+		start := time.Now()
+		err = testConnection(client, 100*time.Millisecond)
+		assert.ErrorContains(t, err, "failed to connect to cluster")
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
 	t.Run("EmptyClusterName", func(t *testing.T) {
 		cm := New()
 		err := cm.LoadKubeConfig("", kubeconfigPath)
@@ -885,7 +1016,7 @@ func testStartPortForwardErrors(t *testing.T) {
 			80,
 		)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "config not found")
+		assert.Contains(t, err.Error(), "error getting REST config")
 	})
 
 	t.Run("InClusterConfigPortForward", func(t *testing.T) {
@@ -923,15 +1054,241 @@ func testStartPortForwardErrors(t *testing.T) {
 			80,
 		)
 		// Will fail because we don't have a real cluster, but should NOT fail
-		// with "config not found" error - it should fail later in the process
+		// to find the config - it should fail later in the process
 		assert.Error(t, err)
-		assert.NotContains(t, err.Error(), "config not found")
+		assert.NotContains(t, err.Error(), "error getting REST config")
 		// Should fail when trying to get the client or pod
 		assert.Contains(t, err.Error(), "not found")
 	})
 
+	t.Run("SessionContext", func(t *testing.T) {
+		// The session's context, not the manager's current one, supplies
+		// the client used to resolve the target.
+		cm := New()
+		for _, name := range []string{"prod", "staging"} {
+			cm.restConfigs[name] = &rest.Config{Host: "https://" + name + ".example.com"}
+			cm.contexts[name] = &kai.ContextInfo{Name: name, Namespace: "default"}
+		}
+		cm.clients["prod"] = fake.NewSimpleClientset()
+		cm.clients["staging"] = fake.NewSimpleClientset(&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		})
+		cm.currentContext = "prod"
+
+		session := &kai.Session{}
+		session.SetContext("staging")
+		_, err := cm.StartPortForward(
+			kai.WithSession(t.Context(), session),
+			"default",
+			"service",
+			"web",
+			8080,
+			80,
+		)
+		assert.EqualError(t, err, `service "web" has no selector`)
+	})
+
 	// Cleanup
 	pfMutex.Lock()
 	portForwardSessions = make(map[string]*PortForwardSession)
 	pfMutex.Unlock()
 }
+
+func testMultipleKubeconfigContexts(t *testing.T) {
+	// Only the kubeconfig's current context is probed on load, so the
+	// staging server never needs to answer.
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"NamespaceList","apiVersion":"v1","items":[]}`))
+	}))
+	defer apiServer.Close()
+
+	kubeconfigYAML := func(current string) string {
+		return fmt.Sprintf(`
+apiVersion: v1
+kind: Config
+current-context: %s
+contexts:
+- name: prod
+  context:
+    cluster: prod-cluster
+    user: admin
+- name: staging
+  context:
+    cluster: staging-cluster
+    user: admin
+clusters:
+- name: prod-cluster
+  cluster:
+    server: %s
+- name: staging-cluster
+  cluster:
+    server: https://staging.example.com
+users:
+- name: admin
+  user:
+    token: test-token
+`, current, apiServer.URL)
+	}
+
+	writeKubeconfig := func(t *testing.T, current string) string {
+		path := filepath.Join(t.TempDir(), "config")
+		require.NoError(t, os.WriteFile(path, []byte(kubeconfigYAML(current)), 0600))
+		return path
+	}
+
+	t.Run("ClientsPerContext", func(t *testing.T) {
+		cm := New()
+		require.NoError(t, cm.LoadKubeConfig("local", writeKubeconfig(t, "prod")))
+
+		names := make([]string, 0, 2)
+		for _, info := range cm.ListContexts() {
+			names = append(names, info.Name)
+		}
+		assert.Equal(t, []string{"local-prod", "local-staging"}, names)
+		assert.Equal(t, "local-prod", cm.GetCurrentContext())
+
+		config, err := cm.GetCurrentRESTConfig()
+		require.NoError(t, err)
+		assert.Equal(t, apiServer.URL, config.Host)
+
+		require.NoError(t, cm.SetCurrentContext("local-staging"))
+		config, err = cm.GetCurrentRESTConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "https://staging.example.com", config.Host)
+
+		prodClient, err := cm.GetClient("local-prod")
+		require.NoError(t, err)
+		currentClient, err := cm.GetCurrentClient()
+		require.NoError(t, err)
+		assert.NotSame(t, prodClient, currentClient)
+	})
+
+	t.Run("InlineKubeconfig", func(t *testing.T) {
+		cm := New()
+		require.NoError(t, cm.LoadKubeConfigBytes("ci", []byte(kubeconfigYAML("prod"))))
+
+		assert.Equal(t, "ci-prod", cm.GetCurrentContext())
+		assert.Len(t, cm.ListContexts(), 2)
+		config, err := cm.GetCurrentRESTConfig()
+		require.NoError(t, err)
+		assert.Equal(t, apiServer.URL, config.Host)
+
+		// There is no file behind an inline kubeconfig to record the switch in.
+		require.NoError(t, cm.SetCurrentContext("ci-staging"))
+		info, err := cm.GetContextInfo("ci-staging")
+		require.NoError(t, err)
+		assert.Empty(t, info.ConfigPath)
+	})
+
+	t.Run("InlineKubeconfigRejectsMalformed", func(t *testing.T) {
+		cm := New()
+		for _, data := range []string{"", "  \n", "clusters: [unterminated", "apiVersion: v1\nkind: Config\n"} {
+			assert.Error(t, cm.LoadKubeConfigBytes("ci", []byte(data)), "%q", data)
+		}
+		assert.Error(t, cm.LoadKubeConfigBytes("", []byte(kubeconfigYAML("prod"))))
+		assert.Empty(t, cm.ListContexts())
+	})
+
+	t.Run("SecondKubeconfigKeepsActiveContext", func(t *testing.T) {
+		cm := New()
+		require.NoError(t, cm.LoadKubeConfig("local", writeKubeconfig(t, "prod")))
+		require.NoError(t, cm.LoadKubeConfig("dr", writeKubeconfig(t, "prod")))
+
+		assert.Len(t, cm.ListContexts(), 4)
+		assert.Equal(t, "local-prod", cm.GetCurrentContext())
+
+		require.NoError(t, cm.SetCurrentContext("dr-staging"))
+		assert.Equal(t, "dr-staging", cm.GetCurrentContext())
+	})
+
+	t.Run("ConcurrentSwitchAndRead", func(t *testing.T) {
+		cm := New()
+		require.NoError(t, cm.LoadKubeConfig("local", writeKubeconfig(t, "prod")))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				name := "local-prod"
+				if i%2 == 1 {
+					name = "local-staging"
+				}
+				assert.NoError(t, cm.SetCurrentContext(name))
+			}(i)
+			go func() {
+				defer wg.Done()
+				_, err := cm.GetCurrentClient()
+				assert.NoError(t, err)
+				_, err = cm.GetCurrentRESTConfig()
+				assert.NoError(t, err)
+				cm.ListContexts()
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestWithListSummaryThreshold(t *testing.T) {
+	assert.Equal(t, DefaultListSummaryThreshold, New().ListSummaryThreshold())
+	assert.Equal(t, 20, New(WithListSummaryThreshold(20)).ListSummaryThreshold())
+	assert.Equal(t, 0, New(WithListSummaryThreshold(0)).ListSummaryThreshold())
+	assert.Equal(t, DefaultListSummaryThreshold, New(WithListSummaryThreshold(-1)).ListSummaryThreshold())
+}
+
+func TestWithMaxNamespacesScan(t *testing.T) {
+	assert.Equal(t, 0, New().MaxNamespacesScan())
+	assert.Equal(t, 100, New(WithMaxNamespacesScan(100)).MaxNamespacesScan())
+	assert.Equal(t, 0, New(WithMaxNamespacesScan(-1)).MaxNamespacesScan())
+}
+
+func TestSetImpersonation(t *testing.T) {
+	headers := make(chan http.Header, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"NamespaceList","apiVersion":"v1","items":[]}`))
+	}))
+	defer srv.Close()
+
+	cm := New()
+	config := &rest.Config{Host: srv.URL}
+	cm.configureClient(config)
+	cm.restConfigs[testCluster] = config
+	cm.currentContext = testCluster
+
+	list := func(t *testing.T) http.Header {
+		client, err := cm.GetCurrentClient()
+		require.NoError(t, err)
+		_, err = client.CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{})
+		require.NoError(t, err)
+		return <-headers
+	}
+
+	require.NoError(t, cm.SetImpersonation(kai.Impersonation{User: "jane", Groups: []string{"dev", "qa"}}))
+	assert.Equal(t, "jane", cm.GetImpersonation().User)
+	h := list(t)
+	assert.Equal(t, "jane", h.Get("Impersonate-User"))
+	assert.Equal(t, []string{"dev", "qa"}, h.Values("Impersonate-Group"))
+
+	require.NoError(t, cm.SetImpersonation(kai.Impersonation{}))
+	h = list(t)
+	assert.Empty(t, h.Get("Impersonate-User"))
+
+	err := cm.SetImpersonation(kai.Impersonation{User: "jane", ServiceAccount: "team-a/deployer"})
+	assert.ErrorContains(t, err, "cannot both be impersonated")
+}
+
+func TestSetImpersonationFixedAtStartup(t *testing.T) {
+	fixed := kai.Impersonation{ServiceAccount: "team-a/deployer"}
+	cm := New(WithImpersonation(fixed))
+
+	err := cm.SetImpersonation(kai.Impersonation{})
+	assert.ErrorContains(t, err, "fixed at startup")
+	err = cm.SetImpersonation(kai.Impersonation{User: "cluster-admin"})
+	assert.ErrorContains(t, err, "fixed at startup")
+	assert.Equal(t, fixed, cm.GetImpersonation())
+
+	assert.NoError(t, New(WithImpersonation(kai.Impersonation{})).SetImpersonation(kai.Impersonation{User: "jane"}))
+}
@@ -0,0 +1,88 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSetMetadataRun(t *testing.T) {
+	ctx := context.Background()
+	cmGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+	newCM := func() (*testmocks.MockClusterManager, *dynamicfake.FakeDynamicClient) {
+		fakeClient := fake.NewSimpleClientset()
+		fakeClient.Resources = applyDiscovery()
+		dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), applyListKinds)
+		obj := uObj("v1", "ConfigMap", "cm1", testNamespace)
+		obj.SetLabels(map[string]string{"app": "web", "stale": "yes"})
+		obj.SetAnnotations(map[string]string{"owner": "team-a"})
+		_, err := dyn.Resource(cmGVR).Namespace(testNamespace).Create(ctx, obj, metav1.CreateOptions{})
+		assert.NoError(t, err)
+
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+		mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+		mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+		return mockCM, dyn
+	}
+	str := func(s string) *string { return &s }
+
+	t.Run("Labels", func(t *testing.T) {
+		mockCM, dyn := newCM()
+		update := &SetMetadata{Kind: "ConfigMap", Name: "cm1", Namespace: testNamespace, Field: "labels",
+			Values: map[string]*string{"tier": str("frontend"), "app": str("api"), "stale": nil}}
+		result, err := update.Run(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ConfigMap test-namespace/cm1 labeled (set app=api, tier=frontend; removed stale)", result)
+		obj, err := dyn.Resource(cmGVR).Namespace(testNamespace).Get(ctx, "cm1", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"app": "api", "tier": "frontend"}, obj.GetLabels())
+		assert.Equal(t, map[string]string{"owner": "team-a"}, obj.GetAnnotations(), "annotations are untouched")
+	})
+
+	t.Run("AnnotationsEmptyValueRemoves", func(t *testing.T) {
+		mockCM, dyn := newCM()
+		update := &SetMetadata{Kind: "configmaps", Name: "cm1", Namespace: testNamespace, Field: "annotations",
+			Values: map[string]*string{"owner": str(""), "note": str("migrated to v2, see #42")}}
+		result, err := update.Run(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "annotated (set note=migrated to v2, see #42; removed owner)")
+		obj, err := dyn.Resource(cmGVR).Namespace(testNamespace).Get(ctx, "cm1", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"note": "migrated to v2, see #42"}, obj.GetAnnotations())
+	})
+
+	t.Run("InvalidLabelValue", func(t *testing.T) {
+		mockCM, _ := newCM()
+		update := &SetMetadata{Kind: "ConfigMap", Name: "cm1", Field: "labels",
+			Values: map[string]*string{"note": str("has spaces")}}
+		_, err := update.Run(ctx, mockCM)
+		assert.ErrorContains(t, err, `invalid value for label "note"`)
+	})
+
+	t.Run("InvalidKey", func(t *testing.T) {
+		mockCM, _ := newCM()
+		update := &SetMetadata{Kind: "ConfigMap", Name: "cm1", Field: "annotations",
+			Values: map[string]*string{"-bad": str("x")}}
+		_, err := update.Run(ctx, mockCM)
+		assert.ErrorContains(t, err, `invalid key "-bad"`)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockCM, _ := newCM()
+		update := &SetMetadata{Kind: "ConfigMap", Name: "missing", Namespace: testNamespace, Field: "labels",
+			Values: map[string]*string{"a": str("b")}}
+		_, err := update.Run(ctx, mockCM)
+		assert.ErrorContains(t, err, "failed to update labels of ConfigMap test-namespace/missing")
+	})
+}
@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/basebandit/kai"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 )
 
@@ -18,6 +21,8 @@ type Namespace struct {
 	Name        string
 	Labels      map[string]interface{}
 	Annotations map[string]interface{}
+	DryRun      bool
+	ShowDiff    bool
 }
 
 const (
@@ -25,6 +30,33 @@ const (
 	listTimeout    = 20 * time.Second
 )
 
+// CheckNamespaceScan returns an error when the cluster has more than the
+// cluster manager's MaxNamespacesScan namespaces.
+func CheckNamespaceScan(ctx context.Context, cm kai.ClusterManager) error {
+	maxNamespaces := cm.MaxNamespacesScan()
+	if maxNamespaces <= 0 {
+		return nil
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	// One namespace past the limit is enough to know it is exceeded.
+	namespaces, err := client.CoreV1().Namespaces().List(timeoutCtx, metav1.ListOptions{Limit: int64(maxNamespaces) + 1})
+	if err != nil {
+		return fmt.Errorf("failed to count namespaces: %w", err)
+	}
+	if len(namespaces.Items) > maxNamespaces {
+		return fmt.Errorf("more than %d namespaces; narrow with a namespace or label_selector, or set confirm=true to scan all of them", maxNamespaces)
+	}
+	return nil
+}
+
 func (n *Namespace) Create(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
 
@@ -40,7 +72,7 @@ func (n *Namespace) Create(ctx context.Context, cm kai.ClusterManager) (string,
 		slog.String("name", n.Name),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for namespace create",
 			slog.String("name", n.Name),
@@ -72,7 +104,7 @@ func (n *Namespace) Create(ctx context.Context, cm kai.ClusterManager) (string,
 		}
 	}
 
-	createdNamespace, err := client.CoreV1().Namespaces().Create(timeoutCtx, namespace, metav1.CreateOptions{})
+	createdNamespace, err := client.CoreV1().Namespaces().Create(timeoutCtx, namespace, metav1.CreateOptions{DryRun: dryRunOption(n.DryRun)})
 	if err != nil {
 		slog.Warn("failed to create namespace",
 			slog.String("name", n.Name),
@@ -86,7 +118,7 @@ func (n *Namespace) Create(ctx context.Context, cm kai.ClusterManager) (string,
 	)
 
 	result = fmt.Sprintf("Namespace %q created successfully", createdNamespace.Name)
-	return result, nil
+	return dryRunResult(result, n.DryRun), nil
 }
 
 func (n *Namespace) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
@@ -95,7 +127,7 @@ func (n *Namespace) Get(ctx context.Context, cm kai.ClusterManager) (string, err
 	slog.Debug("namespace get requested",
 		slog.String("name", n.Name),
 	)
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for namespace get",
 			slog.String("name", n.Name),
@@ -106,7 +138,7 @@ func (n *Namespace) Get(ctx context.Context, cm kai.ClusterManager) (string, err
 
 	var namespace *corev1.Namespace
 	err = retry.OnError(retry.DefaultRetry, func(err error) bool {
-		return !strings.Contains(err.Error(), "not found")
+		return !apierrors.IsNotFound(err)
 	}, func() error {
 		var getErr error
 		namespace, getErr = client.CoreV1().Namespaces().Get(ctx, n.Name, metav1.GetOptions{})
@@ -114,18 +146,18 @@ func (n *Namespace) Get(ctx context.Context, cm kai.ClusterManager) (string, err
 	})
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if apierrors.IsNotFound(err) {
 			slog.Warn("namespace not found",
 				slog.String("name", n.Name),
 				slog.String("error", err.Error()),
 			)
-			return result, fmt.Errorf("namespace '%s' not found", n.Name)
+			return result, fmt.Errorf("namespace '%s' not found: %w", n.Name, err)
 		}
 		slog.Warn("failed to get namespace",
 			slog.String("name", n.Name),
 			slog.String("error", err.Error()),
 		)
-		return result, fmt.Errorf("failed to get namespace '%s': %v", n.Name, err)
+		return result, fmt.Errorf("failed to get namespace '%s': %w", n.Name, err)
 	}
 
 	return formatNamespace(namespace), nil
@@ -137,7 +169,7 @@ func (n *Namespace) List(ctx context.Context, cm kai.ClusterManager, labelSelect
 	slog.Debug("namespace list requested",
 		slog.String("label_selector", labelSelector),
 	)
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for namespace list",
 			slog.String("label_selector", labelSelector),
@@ -179,7 +211,7 @@ func (n *Namespace) Delete(ctx context.Context, cm kai.ClusterManager) (string,
 		slog.String("name", n.Name),
 	)
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		slog.Warn("failed to get client for namespace delete",
 			slog.String("name", n.Name),
@@ -289,7 +321,7 @@ func (n *Namespace) Delete(ctx context.Context, cm kai.ClusterManager) (string,
 func (n *Namespace) Update(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -302,6 +334,8 @@ func (n *Namespace) Update(ctx context.Context, cm kai.ClusterManager) (string,
 		return result, fmt.Errorf("failed to get namespace: %w", err)
 	}
 
+	before := namespace.DeepCopy()
+
 	if len(n.Labels) > 0 {
 		if namespace.Labels == nil {
 			namespace.Labels = make(map[string]string)
@@ -320,13 +354,109 @@ func (n *Namespace) Update(ctx context.Context, cm kai.ClusterManager) (string,
 		}
 	}
 
-	updatedNamespace, err := client.CoreV1().Namespaces().Update(timeoutCtx, namespace, metav1.UpdateOptions{})
+	updatedNamespace, err := client.CoreV1().Namespaces().Update(timeoutCtx, namespace, metav1.UpdateOptions{DryRun: dryRunOption(n.DryRun)})
 	if err != nil {
 		return result, fmt.Errorf("failed to update namespace: %w", err)
 	}
 
 	result = fmt.Sprintf("Namespace %q updated successfully", updatedNamespace.Name)
-	return result, nil
+	if n.ShowDiff {
+		result = withUpdateDiff(result, before, updatedNamespace)
+	}
+	return dryRunResult(result, n.DryRun), nil
+}
+
+// RestartWorkloads rollout-restarts every Deployment in the namespace, and
+// optionally every StatefulSet and DaemonSet, the way kubectl rollout
+// restart does. Paused Deployments are skipped since they would not roll
+// until resumed. Failures are reported per workload rather than aborting
+// the rest.
+func (n *Namespace) RestartWorkloads(ctx context.Context, cm kai.ClusterManager, includeStatefulSets, includeDaemonSets bool) (string, error) {
+	if err := n.validate(); err != nil {
+		return "", err
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	if _, err := client.CoreV1().Namespaces().Get(listCtx, n.Name, metav1.GetOptions{}); err != nil {
+		return "", fmt.Errorf("failed to get namespace %q: %w", n.Name, err)
+	}
+
+	var restarted, skipped, failed []string
+
+	deployments, err := client.AppsV1().Deployments(n.Name).List(listCtx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list deployments: %w", err)
+	}
+	sort.Slice(deployments.Items, func(i, j int) bool {
+		return deployments.Items[i].Name < deployments.Items[j].Name
+	})
+	for _, deployment := range deployments.Items {
+		ref := "Deployment/" + deployment.Name
+		if deployment.Spec.Paused {
+			skipped = append(skipped, ref+" (paused)")
+			continue
+		}
+		d := &Deployment{Name: deployment.Name, Namespace: n.Name}
+		if _, err := d.RolloutRestart(ctx, cm); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", ref, err.Error()))
+			continue
+		}
+		restarted = append(restarted, ref)
+	}
+
+	// StatefulSets and DaemonSets have no RolloutRestart of their own, so
+	// they get the same restartedAt template annotation as a patch.
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`,
+		restartedAtAnnotation, time.Now().Format(time.RFC3339)))
+
+	if includeStatefulSets {
+		statefulSets, err := client.AppsV1().StatefulSets(n.Name).List(listCtx, metav1.ListOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to list statefulsets: %w", err)
+		}
+		sort.Slice(statefulSets.Items, func(i, j int) bool {
+			return statefulSets.Items[i].Name < statefulSets.Items[j].Name
+		})
+		for _, sts := range statefulSets.Items {
+			ref := "StatefulSet/" + sts.Name
+			if _, err := client.AppsV1().StatefulSets(n.Name).Patch(ctx, sts.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %s", ref, err.Error()))
+				continue
+			}
+			restarted = append(restarted, ref)
+		}
+	}
+
+	if includeDaemonSets {
+		daemonSets, err := client.AppsV1().DaemonSets(n.Name).List(listCtx, metav1.ListOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to list daemonsets: %w", err)
+		}
+		sort.Slice(daemonSets.Items, func(i, j int) bool {
+			return daemonSets.Items[i].Name < daemonSets.Items[j].Name
+		})
+		for _, ds := range daemonSets.Items {
+			ref := "DaemonSet/" + ds.Name
+			if _, err := client.AppsV1().DaemonSets(n.Name).Patch(ctx, ds.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %s", ref, err.Error()))
+				continue
+			}
+			restarted = append(restarted, ref)
+		}
+	}
+
+	if len(restarted)+len(skipped)+len(failed) == 0 {
+		return fmt.Sprintf("No workloads to restart in namespace %q", n.Name), nil
+	}
+
+	return formatWorkloadChanges(fmt.Sprintf("Restarted %d workload(s) in namespace %q", len(restarted), n.Name), restarted, skipped, failed), nil
 }
 
 func (n *Namespace) validate() error {
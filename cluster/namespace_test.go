@@ -6,10 +6,13 @@ import (
 
 	"github.com/basebandit/kai/testmocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestNamespaceOperations(t *testing.T) {
@@ -18,6 +21,9 @@ func TestNamespaceOperations(t *testing.T) {
 	t.Run("ListNamespaces", testListNamespaces)
 	t.Run("DeleteNamespace", testDeleteNamespace)
 	t.Run("UpdateNamespace", testUpdateNamespace)
+	t.Run("RestartWorkloads", testRestartWorkloads)
+	t.Run("HibernateWorkloads", testHibernateWorkloads)
+	t.Run("CheckNamespaceScan", testCheckNamespaceScan)
 }
 
 func testCreateNamespaces(t *testing.T) {
@@ -559,3 +565,213 @@ func testUpdateNamespace(t *testing.T) {
 		})
 	}
 }
+
+func testRestartWorkloads(t *testing.T) {
+	ctx := context.Background()
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+	deployment := func(name string, paused bool) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+			Spec:       appsv1.DeploymentSpec{Paused: paused},
+		}
+	}
+	statefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: testNamespace}}
+	daemonSet := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: testNamespace}}
+	elsewhere := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"}}
+
+	t.Run("DeploymentsOnly", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(namespace, deployment("web", false), deployment("api", false),
+			deployment("batch", true), statefulSet, daemonSet, elsewhere)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		result, err := (&Namespace{Name: testNamespace}).RestartWorkloads(ctx, mockCM, false, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Restarted 2 workload(s) in namespace \"test-namespace\"\n• Deployment/api\n• Deployment/web\nSkipped 1:\n• Deployment/batch (paused)", result)
+
+		for _, name := range []string{"api", "web"} {
+			got, err := fakeClient.AppsV1().Deployments(testNamespace).Get(ctx, name, metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.NotEmpty(t, got.Spec.Template.Annotations[restartedAtAnnotation])
+		}
+		paused, err := fakeClient.AppsV1().Deployments(testNamespace).Get(ctx, "batch", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, paused.Spec.Template.Annotations)
+		sts, err := fakeClient.AppsV1().StatefulSets(testNamespace).Get(ctx, "db", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, sts.Spec.Template.Annotations)
+	})
+
+	t.Run("IncludeStatefulSetsAndDaemonSets", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(namespace, deployment("web", false), statefulSet, daemonSet)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		result, err := (&Namespace{Name: testNamespace}).RestartWorkloads(ctx, mockCM, true, true)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Restarted 3 workload(s)")
+		assert.Contains(t, result, "• StatefulSet/db")
+		assert.Contains(t, result, "• DaemonSet/agent")
+
+		sts, err := fakeClient.AppsV1().StatefulSets(testNamespace).Get(ctx, "db", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, sts.Spec.Template.Annotations[restartedAtAnnotation])
+		ds, err := fakeClient.AppsV1().DaemonSets(testNamespace).Get(ctx, "agent", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, ds.Spec.Template.Annotations[restartedAtAnnotation])
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(namespace, elsewhere), nil)
+
+		result, err := (&Namespace{Name: testNamespace}).RestartWorkloads(ctx, mockCM, true, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, `No workloads to restart in namespace "test-namespace"`, result)
+	})
+
+	t.Run("MissingNamespace", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(), nil)
+
+		_, err := (&Namespace{Name: testNamespace}).RestartWorkloads(ctx, mockCM, false, false)
+
+		assert.ErrorContains(t, err, `failed to get namespace "test-namespace"`)
+	})
+}
+
+func testHibernateWorkloads(t *testing.T) {
+	ctx := context.Background()
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+	deployment := func(name string, replicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		}
+	}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: testNamespace},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr(int32(1))},
+	}
+	elsewhere := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr(int32(2))},
+	}
+	replicasOf := func(t *testing.T, client *fake.Clientset, name string) (int32, string) {
+		got, err := client.AppsV1().Deployments(testNamespace).Get(ctx, name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		return *got.Spec.Replicas, got.Annotations[previousReplicasAnnotation]
+	}
+
+	t.Run("HibernateAndWake", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(namespace, deployment("web", 3), deployment("api", 2),
+			deployment("idle", 0), statefulSet, elsewhere)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+		ns := &Namespace{Name: testNamespace}
+
+		result, err := ns.Hibernate(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Equal(t, "Hibernated 3 workload(s) in namespace \"test-namespace\"\n• Deployment/api: 2 → 0 replica(s)\n• Deployment/web: 3 → 0 replica(s)\n• StatefulSet/db: 1 → 0 replica(s)", result)
+
+		replicas, previous := replicasOf(t, fakeClient, "web")
+		assert.Equal(t, int32(0), replicas)
+		assert.Equal(t, "3", previous)
+		replicas, previous = replicasOf(t, fakeClient, "api")
+		assert.Equal(t, int32(0), replicas)
+		assert.Equal(t, "2", previous)
+		_, previous = replicasOf(t, fakeClient, "idle")
+		assert.Empty(t, previous, "workloads already at zero are not recorded")
+		other, err := fakeClient.AppsV1().Deployments("default").Get(ctx, "other", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), *other.Spec.Replicas)
+
+		result, err = ns.Hibernate(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Equal(t, "Hibernated 0 workload(s) in namespace \"test-namespace\"\nSkipped 3:\n• Deployment/api (already hibernated)\n• Deployment/web (already hibernated)\n• StatefulSet/db (already hibernated)", result)
+
+		result, err = ns.Wake(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Equal(t, "Woke 3 workload(s) in namespace \"test-namespace\"\n• Deployment/api: 0 → 2 replica(s)\n• Deployment/web: 0 → 3 replica(s)\n• StatefulSet/db: 0 → 1 replica(s)", result)
+
+		replicas, previous = replicasOf(t, fakeClient, "web")
+		assert.Equal(t, int32(3), replicas)
+		assert.Empty(t, previous)
+		replicas, previous = replicasOf(t, fakeClient, "api")
+		assert.Equal(t, int32(2), replicas)
+		assert.Empty(t, previous)
+		replicas, _ = replicasOf(t, fakeClient, "idle")
+		assert.Equal(t, int32(0), replicas)
+
+		result, err = ns.Wake(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Equal(t, `No hibernated workloads to wake in namespace "test-namespace"`, result)
+	})
+
+	t.Run("WakeReportsInvalidAnnotation", func(t *testing.T) {
+		broken := deployment("web", 0)
+		broken.Annotations = map[string]string{previousReplicasAnnotation: "-1"}
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(namespace, broken), nil)
+
+		result, err := (&Namespace{Name: testNamespace}).Wake(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Equal(t, "Woke 0 workload(s) in namespace \"test-namespace\"\nFailed 1:\n• Deployment/web: invalid kai.dev/previous-replicas annotation \"-1\": expected a non-negative replica count", result)
+	})
+
+	t.Run("MissingNamespace", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(), nil)
+
+		_, err := (&Namespace{Name: testNamespace}).Hibernate(ctx, mockCM)
+		assert.ErrorContains(t, err, `failed to get namespace "test-namespace"`)
+	})
+}
+
+func testCheckNamespaceScan(t *testing.T) {
+	ctx := context.Background()
+
+	newClient := func() *fake.Clientset {
+		return fake.NewSimpleClientset(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}},
+		)
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+
+		assert.NoError(t, CheckNamespaceScan(ctx, mockCM))
+		mockCM.AssertNotCalled(t, "GetCurrentClient")
+	})
+
+	t.Run("WithinLimit", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.SetMaxNamespacesScan(3)
+		mockCM.On("GetCurrentClient").Return(newClient(), nil)
+
+		assert.NoError(t, CheckNamespaceScan(ctx, mockCM))
+	})
+
+	t.Run("OverLimit", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.SetMaxNamespacesScan(2)
+		client := newClient()
+		mockCM.On("GetCurrentClient").Return(client, nil)
+
+		err := CheckNamespaceScan(ctx, mockCM)
+		assert.EqualError(t, err, "more than 2 namespaces; narrow with a namespace or label_selector, or set confirm=true to scan all of them")
+
+		// Only one namespace past the limit is asked for, not all of them.
+		require.NotEmpty(t, client.Actions())
+		list, ok := client.Actions()[len(client.Actions())-1].(k8stesting.ListActionImpl)
+		require.True(t, ok)
+		assert.Equal(t, int64(3), list.ListOptions.Limit)
+	})
+}
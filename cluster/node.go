@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"sort"
@@ -11,13 +12,20 @@ import (
 	"github.com/basebandit/kai"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 )
 
 // Node represents an operation target for a cluster node.
 type Node struct {
 	Name string
+
+	// Progress, when set, is called as a drain works through the node's
+	// pods, with the number handled so far, the total and what happened.
+	Progress func(done, total int, message string)
 }
 
 func (n *Node) validate() error {
@@ -29,7 +37,7 @@ func (n *Node) validate() error {
 
 // List returns a summary of all nodes in the cluster.
 func (n *Node) List(ctx context.Context, cm kai.ClusterManager) (string, error) {
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting client: %w", err)
 	}
@@ -55,7 +63,7 @@ func (n *Node) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
 		return "", err
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting client: %w", err)
 	}
@@ -71,6 +79,29 @@ func (n *Node) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	return formatNode(node), nil
 }
 
+// Describe returns the node's details along with what the scheduler works
+// from: allocatable resources, taints and the full condition set.
+func (n *Node) Describe(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if err := n.validate(); err != nil {
+		return "", err
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	node, err := client.CoreV1().Nodes().Get(timeoutCtx, n.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get node %q: %w", n.Name, err)
+	}
+
+	return formatNodeDetailed(node), nil
+}
+
 // Cordon marks the node unschedulable.
 func (n *Node) Cordon(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	return n.setSchedulable(ctx, cm, true)
@@ -86,7 +117,7 @@ func (n *Node) setSchedulable(ctx context.Context, cm kai.ClusterManager, unsche
 		return "", err
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting client: %w", err)
 	}
@@ -117,6 +148,181 @@ func (n *Node) setSchedulable(ctx context.Context, cm kai.ClusterManager, unsche
 	return fmt.Sprintf("Node %q %s successfully", n.Name, verb), nil
 }
 
+// Taint adds a taint to the node, replacing any existing taint with the same
+// key and effect.
+func (n *Node) Taint(ctx context.Context, cm kai.ClusterManager, key, value, effect string) (string, error) {
+	if err := n.validate(); err != nil {
+		return "", err
+	}
+	if key == "" {
+		return "", fmt.Errorf("taint key is required")
+	}
+	if err := validateTaintEffect(effect); err != nil {
+		return "", err
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	node, err := client.CoreV1().Nodes().Get(timeoutCtx, n.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get node %q: %w", n.Name, err)
+	}
+
+	taint := corev1.Taint{Key: key, Value: value, Effect: corev1.TaintEffect(effect)}
+	taints := make([]corev1.Taint, 0, len(node.Spec.Taints)+1)
+	for _, t := range node.Spec.Taints {
+		if t.Key == key && t.Effect == taint.Effect {
+			continue
+		}
+		taints = append(taints, t)
+	}
+	taints = append(taints, taint)
+
+	if err := patchNode(timeoutCtx, cm, n.Name, map[string]interface{}{
+		"spec": map[string]interface{}{"taints": taints},
+	}); err != nil {
+		return "", err
+	}
+
+	slog.Info("node tainted", slog.String("node", n.Name), slog.String("taint", taint.ToString()))
+	return fmt.Sprintf("Node %q tainted with %s", n.Name, taint.ToString()), nil
+}
+
+// Untaint removes taints with the given key from the node. If effect is
+// empty, taints with that key are removed regardless of effect.
+func (n *Node) Untaint(ctx context.Context, cm kai.ClusterManager, key, effect string) (string, error) {
+	if err := n.validate(); err != nil {
+		return "", err
+	}
+	if key == "" {
+		return "", fmt.Errorf("taint key is required")
+	}
+	if effect != "" {
+		if err := validateTaintEffect(effect); err != nil {
+			return "", err
+		}
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	node, err := client.CoreV1().Nodes().Get(timeoutCtx, n.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get node %q: %w", n.Name, err)
+	}
+
+	taints := make([]corev1.Taint, 0, len(node.Spec.Taints))
+	removed := 0
+	for _, t := range node.Spec.Taints {
+		if t.Key == key && (effect == "" || string(t.Effect) == effect) {
+			removed++
+			continue
+		}
+		taints = append(taints, t)
+	}
+	if removed == 0 {
+		return fmt.Sprintf("Node %q has no taint with key %q", n.Name, key), nil
+	}
+
+	if err := patchNode(timeoutCtx, cm, n.Name, map[string]interface{}{
+		"spec": map[string]interface{}{"taints": taints},
+	}); err != nil {
+		return "", err
+	}
+
+	slog.Info("node untainted", slog.String("node", n.Name), slog.String("key", key))
+	return fmt.Sprintf("Removed %d taint(s) with key %q from node %q", removed, key, n.Name), nil
+}
+
+// Label sets the given labels on the node, overwriting existing values.
+func (n *Node) Label(ctx context.Context, cm kai.ClusterManager, labelsArg map[string]interface{}) (string, error) {
+	if err := n.validate(); err != nil {
+		return "", err
+	}
+	labels := convertToStringMap(labelsArg)
+	if len(labels) == 0 {
+		return "", fmt.Errorf("at least one label is required")
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if err := patchNode(timeoutCtx, cm, n.Name, map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": labels},
+	}); err != nil {
+		return "", err
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k, v := range labels {
+		keys = append(keys, k+"="+v)
+	}
+	sort.Strings(keys)
+	return fmt.Sprintf("Node %q labeled: %s", n.Name, strings.Join(keys, ", ")), nil
+}
+
+// Unlabel removes the given label keys from the node.
+func (n *Node) Unlabel(ctx context.Context, cm kai.ClusterManager, keys []string) (string, error) {
+	if err := n.validate(); err != nil {
+		return "", err
+	}
+	if len(keys) == 0 {
+		return "", fmt.Errorf("at least one label key is required")
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	// A null value in a merge patch deletes the key.
+	labels := make(map[string]interface{}, len(keys))
+	for _, k := range keys {
+		labels[k] = nil
+	}
+	if err := patchNode(timeoutCtx, cm, n.Name, map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": labels},
+	}); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Removed label(s) %s from node %q", strings.Join(keys, ", "), n.Name), nil
+}
+
+func patchNode(ctx context.Context, cm kai.ClusterManager, name string, patch map[string]interface{}) error {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return fmt.Errorf("error getting client: %w", err)
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to encode patch: %w", err)
+	}
+
+	if _, err := client.CoreV1().Nodes().Patch(ctx, name, types.MergePatchType, data, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to patch node %q: %w", name, err)
+	}
+	return nil
+}
+
+func validateTaintEffect(effect string) error {
+	switch corev1.TaintEffect(effect) {
+	case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		return nil
+	}
+	return fmt.Errorf("invalid taint effect %q: must be NoSchedule, PreferNoSchedule or NoExecute", effect)
+}
+
 // Drain cordons the node and evicts its pods. DaemonSet-managed and
 // mirror (static) pods are skipped, matching kubectl drain behaviour.
 func (n *Node) Drain(ctx context.Context, cm kai.ClusterManager, ignoreDaemonSets, deleteLocalData bool, gracePeriod int64) (string, error) {
@@ -124,7 +330,7 @@ func (n *Node) Drain(ctx context.Context, cm kai.ClusterManager, ignoreDaemonSet
 		return "", err
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting client: %w", err)
 	}
@@ -133,23 +339,50 @@ func (n *Node) Drain(ctx context.Context, cm kai.ClusterManager, ignoreDaemonSet
 		return "", err
 	}
 
+	pods, err := n.pods(ctx, client)
+	if err != nil {
+		return "", err
+	}
+	return n.evict(ctx, client, pods, ignoreDaemonSets, deleteLocalData, gracePeriod).String(), nil
+}
+
+// pods lists every pod scheduled on the node, across namespaces.
+func (n *Node) pods(ctx context.Context, client kubernetes.Interface) ([]corev1.Pod, error) {
 	pods, err := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{
 		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", n.Name).String(),
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to list pods on node %q: %w", n.Name, err)
+		return nil, fmt.Errorf("failed to list pods on node %q: %w", n.Name, err)
+	}
+	// Field selectors are not honoured by every client (fakes included), so
+	// filter again rather than risk evicting pods from other nodes.
+	onNode := pods.Items[:0]
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == n.Name {
+			onNode = append(onNode, pod)
+		}
 	}
+	return onNode, nil
+}
 
-	var (
-		evicted []string
-		skipped []string
-		failed  []string
-	)
+// drainResult records what happened to each pod of a drained node.
+type drainResult struct {
+	node    string
+	evicted []corev1.Pod
+	skipped []string
+	failed  []string
+}
 
-	for i := range pods.Items {
-		pod := pods.Items[i]
+// evict sends an eviction for each of pods that drain is allowed to move.
+// Evictions go through the Eviction API so PodDisruptionBudgets are honoured;
+// a pod the budget protects ends up in failed.
+func (n *Node) evict(ctx context.Context, client kubernetes.Interface, pods []corev1.Pod, ignoreDaemonSets, deleteLocalData bool, gracePeriod int64) drainResult {
+	res := drainResult{node: n.Name}
+	for i := range pods {
+		pod := pods[i]
 		if reason, skip := shouldSkipPod(&pod, ignoreDaemonSets, deleteLocalData); skip {
-			skipped = append(skipped, fmt.Sprintf("%s/%s (%s)", pod.Namespace, pod.Name, reason))
+			res.skipped = append(res.skipped, fmt.Sprintf("%s/%s (%s)", pod.Namespace, pod.Name, reason))
+			n.progress(i+1, len(pods), "skipped %s/%s (%s)", pod.Namespace, pod.Name, reason)
 			continue
 		}
 
@@ -161,28 +394,158 @@ func (n *Node) Drain(ctx context.Context, cm kai.ClusterManager, ignoreDaemonSet
 		}
 
 		if err := client.PolicyV1().Evictions(pod.Namespace).Evict(ctx, eviction); err != nil {
-			failed = append(failed, fmt.Sprintf("%s/%s: %v", pod.Namespace, pod.Name, err))
+			res.failed = append(res.failed, fmt.Sprintf("%s/%s: %v", pod.Namespace, pod.Name, err))
+			n.progress(i+1, len(pods), "failed to evict %s/%s: %v", pod.Namespace, pod.Name, err)
 			continue
 		}
-		evicted = append(evicted, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+		res.evicted = append(res.evicted, pod)
+		n.progress(i+1, len(pods), "evicted %s/%s", pod.Namespace, pod.Name)
 	}
+	return res
+}
 
+func (n *Node) progress(done, total int, format string, args ...interface{}) {
+	if n.Progress != nil {
+		n.Progress(done, total, fmt.Sprintf(format, args...))
+	}
+}
+
+func (r drainResult) String() string {
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "Node %q drained (cordoned).\n", n.Name)
-	fmt.Fprintf(&sb, "Evicted %d pod(s)", len(evicted))
-	if len(evicted) > 0 {
-		sb.WriteString(":\n- " + strings.Join(evicted, "\n- "))
+	fmt.Fprintf(&sb, "Node %q drained (cordoned).\n", r.node)
+	fmt.Fprintf(&sb, "Evicted %d pod(s)", len(r.evicted))
+	if len(r.evicted) > 0 {
+		sb.WriteString(":")
+	}
+	for _, pod := range r.evicted {
+		fmt.Fprintf(&sb, "\n- %s/%s", pod.Namespace, pod.Name)
 	}
 	sb.WriteString("\n")
-	if len(skipped) > 0 {
-		fmt.Fprintf(&sb, "Skipped %d pod(s):\n- %s\n", len(skipped), strings.Join(skipped, "\n- "))
+	if len(r.skipped) > 0 {
+		fmt.Fprintf(&sb, "Skipped %d pod(s):\n- %s\n", len(r.skipped), strings.Join(r.skipped, "\n- "))
+	}
+	if len(r.failed) > 0 {
+		fmt.Fprintf(&sb, "Failed to evict %d pod(s):\n- %s\n", len(r.failed), strings.Join(r.failed, "\n- "))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// Allocations reports, per node, the sum of pod resource requests against
+// allocatable CPU and memory. Figures come from pod specs rather than
+// metrics, so they reflect what the scheduler sees. If Name is empty every
+// node is reported.
+func (n *Node) Allocations(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	var nodes []corev1.Node
+	podOpts := metav1.ListOptions{}
+	if n.Name != "" {
+		node, err := client.CoreV1().Nodes().Get(timeoutCtx, n.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get node %q: %w", n.Name, err)
+		}
+		nodes = []corev1.Node{*node}
+		podOpts.FieldSelector = fields.OneTermEqualSelector("spec.nodeName", n.Name).String()
+	} else {
+		nodeList, err := client.CoreV1().Nodes().List(timeoutCtx, metav1.ListOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to list nodes: %w", err)
+		}
+		nodes = nodeList.Items
+	}
+
+	if len(nodes) == 0 {
+		return "No nodes found", nil
+	}
+
+	pods, err := client.CoreV1().Pods("").List(timeoutCtx, podOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	type requested struct {
+		cpu, memory resource.Quantity
+		pods        int
+	}
+	byNode := make(map[string]*requested, len(nodes))
+	for i := range nodes {
+		byNode[nodes[i].Name] = &requested{}
 	}
-	if len(failed) > 0 {
-		fmt.Fprintf(&sb, "Failed to evict %d pod(s):\n- %s\n", len(failed), strings.Join(failed, "\n- "))
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		// Terminated pods no longer hold their requests on the node.
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		req, ok := byNode[pod.Spec.NodeName]
+		if !ok {
+			continue
+		}
+		cpu, memory := podRequests(pod)
+		req.cpu.Add(cpu)
+		req.memory.Add(memory)
+		req.pods++
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Node allocations (%d):\n", len(nodes))
+	for i := range nodes {
+		node := nodes[i]
+		req := byNode[node.Name]
+		allocCPU := node.Status.Allocatable.Cpu()
+		allocMem := node.Status.Allocatable.Memory()
+
+		cpuFree := allocCPU.DeepCopy()
+		cpuFree.Sub(req.cpu)
+		memFree := allocMem.DeepCopy()
+		memFree.Sub(req.memory)
+
+		fmt.Fprintf(&sb, "• %s\tpods: %d\tcpu: %s/%s (%s)\tmemory: %s/%s (%s)\theadroom: cpu=%s, memory=%s\n",
+			node.Name, req.pods,
+			req.cpu.String(), allocCPU.String(), percentOf(req.cpu.MilliValue(), allocCPU.MilliValue()),
+			req.memory.String(), allocMem.String(), percentOf(req.memory.Value(), allocMem.Value()),
+			cpuFree.String(), memFree.String())
 	}
 	return strings.TrimRight(sb.String(), "\n"), nil
 }
 
+// podRequests returns the effective CPU and memory requests of a pod: the sum
+// of its app containers, raised to the largest init container request if
+// that is higher, plus any pod overhead.
+func podRequests(pod *corev1.Pod) (resource.Quantity, resource.Quantity) {
+	var cpu, memory resource.Quantity
+	for _, c := range pod.Spec.Containers {
+		cpu.Add(*c.Resources.Requests.Cpu())
+		memory.Add(*c.Resources.Requests.Memory())
+	}
+	for _, c := range pod.Spec.InitContainers {
+		if initCPU := c.Resources.Requests.Cpu(); initCPU.Cmp(cpu) > 0 {
+			cpu = initCPU.DeepCopy()
+		}
+		if initMem := c.Resources.Requests.Memory(); initMem.Cmp(memory) > 0 {
+			memory = initMem.DeepCopy()
+		}
+	}
+	if pod.Spec.Overhead != nil {
+		cpu.Add(*pod.Spec.Overhead.Cpu())
+		memory.Add(*pod.Spec.Overhead.Memory())
+	}
+	return cpu, memory
+}
+
+func percentOf(used, total int64) string {
+	if total == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%d%%", used*100/total)
+}
+
 func shouldSkipPod(pod *corev1.Pod, ignoreDaemonSets, deleteLocalData bool) (string, bool) {
 	for _, owner := range pod.OwnerReferences {
 		if owner.Kind == "DaemonSet" {
@@ -199,7 +562,7 @@ func shouldSkipPod(pod *corev1.Pod, ignoreDaemonSets, deleteLocalData bool) (str
 	if !deleteLocalData {
 		for _, vol := range pod.Spec.Volumes {
 			if vol.EmptyDir != nil {
-				return "uses emptyDir (set delete_local_data=true to evict)", true
+				return "uses emptyDir (set delete_emptydir_data=true to evict)", true
 			}
 		}
 	}
@@ -281,3 +644,69 @@ func formatNode(node *corev1.Node) string {
 
 	return strings.TrimRight(sb.String(), "\n")
 }
+
+func formatNodeDetailed(node *corev1.Node) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Node: %s\n", node.Name)
+	status := nodeReadyStatus(node)
+	if node.Spec.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	fmt.Fprintf(&sb, "Status: %s\n", status)
+	fmt.Fprintf(&sb, "Roles: %s\n", nodeRoles(node))
+	fmt.Fprintf(&sb, "Kubelet Version: %s\n", node.Status.NodeInfo.KubeletVersion)
+	fmt.Fprintf(&sb, "OS Image: %s\n", node.Status.NodeInfo.OSImage)
+	fmt.Fprintf(&sb, "Architecture: %s\n", node.Status.NodeInfo.Architecture)
+	fmt.Fprintf(&sb, "Container Runtime: %s\n", node.Status.NodeInfo.ContainerRuntimeVersion)
+	fmt.Fprintf(&sb, "Age: %s\n", formatDuration(time.Since(node.CreationTimestamp.Time)))
+	for _, addr := range node.Status.Addresses {
+		fmt.Fprintf(&sb, "%s: %s\n", addr.Type, addr.Address)
+	}
+
+	sb.WriteString("\nResources (allocatable / capacity):\n")
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage, corev1.ResourcePods} {
+		allocatable, hasAllocatable := node.Status.Allocatable[name]
+		capacity, hasCapacity := node.Status.Capacity[name]
+		if !hasAllocatable && !hasCapacity {
+			continue
+		}
+		fmt.Fprintf(&sb, "  %s: %s / %s\n", name, quantityOrUnknown(allocatable, hasAllocatable), quantityOrUnknown(capacity, hasCapacity))
+	}
+
+	if len(node.Spec.Taints) == 0 {
+		sb.WriteString("\nTaints: <none>\n")
+	} else {
+		sb.WriteString("\nTaints:\n")
+		for _, taint := range node.Spec.Taints {
+			if taint.Value != "" {
+				fmt.Fprintf(&sb, "  %s=%s:%s\n", taint.Key, taint.Value, taint.Effect)
+			} else {
+				fmt.Fprintf(&sb, "  %s:%s\n", taint.Key, taint.Effect)
+			}
+		}
+	}
+
+	sb.WriteString("\nConditions:\n")
+	for _, cond := range node.Status.Conditions {
+		fmt.Fprintf(&sb, "  %s: %s", cond.Type, cond.Status)
+		if cond.Reason != "" {
+			fmt.Fprintf(&sb, " (%s)", cond.Reason)
+		}
+		if !cond.LastTransitionTime.IsZero() {
+			fmt.Fprintf(&sb, ", since %s ago", formatDuration(time.Since(cond.LastTransitionTime.Time)))
+		}
+		if cond.Message != "" {
+			fmt.Fprintf(&sb, " - %s", cond.Message)
+		}
+		sb.WriteString("\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func quantityOrUnknown(q resource.Quantity, ok bool) string {
+	if !ok {
+		return "<unknown>"
+	}
+	return q.String()
+}
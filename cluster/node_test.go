@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/basebandit/kai/testmocks"
@@ -185,4 +186,184 @@ func TestNodeOperations(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Contains(t, result, "app-pod")
 	})
+
+	t.Run("DrainReportsProgress", func(t *testing.T) {
+		dsPod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ds-pod", Namespace: defaultNamespace,
+				OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "ds"}},
+			},
+			Spec: corev1.PodSpec{NodeName: testNodeName},
+		}
+		appPod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-pod", Namespace: defaultNamespace},
+			Spec:       corev1.PodSpec{NodeName: testNodeName},
+		}
+		fakeClient := fake.NewSimpleClientset(newNode(testNodeName, true, false), dsPod, appPod)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		var (
+			counts   []string
+			messages []string
+		)
+		node := &Node{Name: testNodeName, Progress: func(done, total int, message string) {
+			counts = append(counts, fmt.Sprintf("%d/%d", done, total))
+			messages = append(messages, message)
+		}}
+		_, err := node.Drain(ctx, mockCM, true, false, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1/2", "2/2"}, counts)
+		assert.ElementsMatch(t, []string{
+			"evicted default/app-pod",
+			"skipped default/ds-pod (DaemonSet-managed)",
+		}, messages)
+	})
+
+	t.Run("Allocations", func(t *testing.T) {
+		n := newNode(testNodeName, true, false)
+		n.Status.Allocatable = corev1.ResourceList{
+			corev1.ResourceCPU:    resourceQty("2"),
+			corev1.ResourceMemory: resourceQty("4Gi"),
+		}
+		podWithRequests := func(name, cpu, memory string) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNamespace},
+				Spec: corev1.PodSpec{
+					NodeName: testNodeName,
+					Containers: []corev1.Container{{
+						Name: "app",
+						Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resourceQty(cpu),
+							corev1.ResourceMemory: resourceQty(memory),
+						}},
+					}},
+				},
+			}
+		}
+		fakeClient := fake.NewSimpleClientset(n,
+			podWithRequests("web", "500m", "1Gi"),
+			podWithRequests("worker", "500m", "1Gi"),
+		)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		node := &Node{}
+		result, err := node.Allocations(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Node allocations (1)")
+		assert.Contains(t, result, "pods: 2")
+		assert.Contains(t, result, "cpu: 1/2 (50%)")
+		assert.Contains(t, result, "memory: 2Gi/4Gi (50%)")
+		assert.Contains(t, result, "headroom: cpu=1, memory=2Gi")
+	})
+
+	t.Run("Describe", func(t *testing.T) {
+		n := newNode(testNodeName, true, false)
+		n.Spec.Taints = []corev1.Taint{
+			{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+			{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule},
+		}
+		n.Status.Capacity = corev1.ResourceList{
+			corev1.ResourceCPU:    resourceQty("4"),
+			corev1.ResourceMemory: resourceQty("16Gi"),
+		}
+		n.Status.Allocatable = corev1.ResourceList{
+			corev1.ResourceCPU:    resourceQty("3800m"),
+			corev1.ResourceMemory: resourceQty("15Gi"),
+		}
+		n.Status.Conditions = append(n.Status.Conditions, corev1.NodeCondition{
+			Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse, Reason: "KubeletHasSufficientMemory",
+		})
+		fakeClient := fake.NewSimpleClientset(n)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		node := &Node{Name: testNodeName}
+		result, err := node.Describe(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Node: node-1")
+		assert.Contains(t, result, "Roles: control-plane")
+		assert.Contains(t, result, "cpu: 3800m / 4")
+		assert.Contains(t, result, "memory: 15Gi / 16Gi")
+		assert.Contains(t, result, "dedicated=gpu:NoSchedule")
+		assert.Contains(t, result, "spot:PreferNoSchedule")
+		assert.Contains(t, result, "MemoryPressure: False (KubeletHasSufficientMemory)")
+	})
+
+	t.Run("DescribeNoTaints", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(newNode(testNodeName, false, true))
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		node := &Node{Name: testNodeName}
+		result, err := node.Describe(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Status: NotReady,SchedulingDisabled")
+		assert.Contains(t, result, "Taints: <none>")
+	})
+
+	t.Run("DescribeNotFound", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset()
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		node := &Node{Name: "missing"}
+		_, err := node.Describe(ctx, mockCM)
+		assert.Error(t, err)
+	})
+
+	t.Run("TaintAndUntaint", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(newNode(testNodeName, true, false))
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		node := &Node{Name: testNodeName}
+		result, err := node.Taint(ctx, mockCM, "dedicated", "gpu", "NoSchedule")
+		assert.NoError(t, err)
+		assert.Contains(t, result, "dedicated=gpu:NoSchedule")
+
+		updated, _ := fakeClient.CoreV1().Nodes().Get(ctx, testNodeName, metav1.GetOptions{})
+		assert.Len(t, updated.Spec.Taints, 1)
+		assert.Equal(t, corev1.TaintEffectNoSchedule, updated.Spec.Taints[0].Effect)
+
+		result, err = node.Untaint(ctx, mockCM, "dedicated", "NoSchedule")
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Removed 1 taint(s)")
+
+		updated, _ = fakeClient.CoreV1().Nodes().Get(ctx, testNodeName, metav1.GetOptions{})
+		assert.Empty(t, updated.Spec.Taints)
+	})
+
+	t.Run("TaintInvalidEffect", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		node := &Node{Name: testNodeName}
+		_, err := node.Taint(ctx, mockCM, "dedicated", "gpu", "Sometimes")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid taint effect")
+	})
+
+	t.Run("LabelAndUnlabel", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(newNode(testNodeName, true, false))
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		node := &Node{Name: testNodeName}
+		_, err := node.Label(ctx, mockCM, map[string]interface{}{"pool": "gpu"})
+		assert.NoError(t, err)
+
+		updated, _ := fakeClient.CoreV1().Nodes().Get(ctx, testNodeName, metav1.GetOptions{})
+		assert.Equal(t, "gpu", updated.Labels["pool"])
+
+		_, err = node.Unlabel(ctx, mockCM, []string{"pool"})
+		assert.NoError(t, err)
+
+		updated, _ = fakeClient.CoreV1().Nodes().Get(ctx, testNodeName, metav1.GetOptions{})
+		assert.NotContains(t, updated.Labels, "pool")
+		assert.Contains(t, updated.Labels, "node-role.kubernetes.io/control-plane")
+	})
 }
@@ -0,0 +1,215 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultSafeDrainTimeout = 2 * time.Minute
+	maxSafeDrainTimeout     = 10 * time.Minute
+)
+
+// safeDrainPollInterval is how often SafeDrain looks for replacement pods.
+// Tests shorten it.
+var safeDrainPollInterval = 2 * time.Second
+
+// SafeDrain cordons and drains the node like Drain (DaemonSet pods are always
+// skipped), then waits up to timeout for the controllers of the evicted pods
+// to schedule replacements elsewhere and reports where each one landed.
+// timeout defaults to 2m and is capped at 10m. Pods that were not replaced in
+// time are listed in the result rather than reported as an error.
+func (n *Node) SafeDrain(ctx context.Context, cm kai.ClusterManager, deleteLocalData bool, gracePeriod int64, timeout time.Duration) (string, error) {
+	if err := n.validate(); err != nil {
+		return "", err
+	}
+	if timeout <= 0 {
+		timeout = defaultSafeDrainTimeout
+	}
+	timeout = min(timeout, maxSafeDrainTimeout)
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	if _, err := n.Cordon(ctx, cm); err != nil {
+		return "", err
+	}
+
+	pods, err := n.pods(ctx, client)
+	if err != nil {
+		return "", err
+	}
+
+	// Remember the pods that already exist in the affected namespaces, so a
+	// replica that was running elsewhere before the drain is not mistaken
+	// for a replacement.
+	existing := sets.New[string]()
+	for _, namespace := range podNamespaces(pods) {
+		list, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to list pods in namespace %q: %w", namespace, err)
+		}
+		for i := range list.Items {
+			existing.Insert(podKey(&list.Items[i]))
+		}
+	}
+
+	drained := n.evict(ctx, client, pods, true, deleteLocalData, gracePeriod)
+	moves, err := n.waitForReplacements(ctx, client, drained.evicted, existing, timeout)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(drained.String())
+	if len(moves) > 0 {
+		rescheduled := 0
+		for _, m := range moves {
+			if m.replacement != nil {
+				rescheduled++
+			}
+		}
+		fmt.Fprintf(&sb, "\nRescheduled %d of %d evicted pod(s):", rescheduled, len(moves))
+		for _, m := range moves {
+			sb.WriteString("\n- " + m.String(timeout))
+		}
+	}
+	return sb.String(), nil
+}
+
+// podMove tracks an evicted pod and the pod its controller created in its
+// place, if one has been scheduled yet.
+type podMove struct {
+	pod         corev1.Pod
+	owner       *metav1.OwnerReference
+	replacement *corev1.Pod
+}
+
+func (m podMove) String(timeout time.Duration) string {
+	name := fmt.Sprintf("%s/%s", m.pod.Namespace, m.pod.Name)
+	switch {
+	case m.owner == nil:
+		return name + ": no controller, will not be recreated"
+	case m.replacement == nil:
+		return fmt.Sprintf("%s (%s/%s): not rescheduled within %s", name, m.owner.Kind, m.owner.Name, timeout)
+	}
+	return fmt.Sprintf("%s (%s/%s) → %s on %s (%s)", name, m.owner.Kind, m.owner.Name,
+		m.replacement.Name, m.replacement.Spec.NodeName, m.replacement.Status.Phase)
+}
+
+// waitForReplacements polls the namespaces of the evicted pods until every
+// controlled pod has a new sibling with the same controller scheduled on
+// another node, or timeout elapses. Each replacement is matched to at most
+// one evicted pod.
+func (n *Node) waitForReplacements(ctx context.Context, client kubernetes.Interface, evicted []corev1.Pod, existing sets.Set[string], timeout time.Duration) ([]podMove, error) {
+	moves := make([]podMove, len(evicted))
+	pending := 0
+	for i := range evicted {
+		moves[i] = podMove{pod: evicted[i], owner: metav1.GetControllerOf(&evicted[i])}
+		if moves[i].owner != nil {
+			pending++
+		}
+	}
+	if pending == 0 {
+		return moves, nil
+	}
+
+	slog.Debug("waiting for evicted pods to be rescheduled",
+		slog.String("node", n.Name),
+		slog.Int("pods", pending),
+		slog.Duration("timeout", timeout),
+	)
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(safeDrainPollInterval)
+	defer ticker.Stop()
+
+	claimed := sets.New[string]()
+	for {
+		for _, namespace := range podNamespaces(evicted) {
+			list, err := client.CoreV1().Pods(namespace).List(waitCtx, metav1.ListOptions{})
+			if err != nil {
+				if waitCtx.Err() != nil {
+					break
+				}
+				return nil, fmt.Errorf("failed to list pods in namespace %q: %w", namespace, err)
+			}
+			for i := range list.Items {
+				candidate := &list.Items[i]
+				key := podKey(candidate)
+				if existing.Has(key) || claimed.Has(key) || !isScheduledElsewhere(candidate, n.Name) {
+					continue
+				}
+				for j := range moves {
+					m := &moves[j]
+					if m.owner == nil || m.replacement != nil || m.pod.Namespace != namespace ||
+						!sameController(m.owner, metav1.GetControllerOf(candidate)) {
+						continue
+					}
+					m.replacement = candidate
+					claimed.Insert(key)
+					pending--
+					break
+				}
+			}
+		}
+		if pending == 0 {
+			return moves, nil
+		}
+
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return moves, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func isScheduledElsewhere(pod *corev1.Pod, node string) bool {
+	return pod.DeletionTimestamp == nil && pod.Spec.NodeName != "" && pod.Spec.NodeName != node
+}
+
+// sameController matches by UID when both references carry one. StatefulSet
+// replacements reuse the evicted pod's name, so pods are told apart by UID
+// too (see podKey).
+func sameController(a, b *metav1.OwnerReference) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	if a.UID != "" && b.UID != "" {
+		return a.UID == b.UID
+	}
+	return a.Kind == b.Kind && a.Name == b.Name
+}
+
+func podKey(pod *corev1.Pod) string {
+	if pod.UID != "" {
+		return string(pod.UID)
+	}
+	return types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}.String()
+}
+
+func podNamespaces(pods []corev1.Pod) []string {
+	namespaces := sets.New[string]()
+	for _, pod := range pods {
+		namespaces.Insert(pod.Namespace)
+	}
+	return sets.List(namespaces)
+}
@@ -0,0 +1,136 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestNodeSafeDrain(t *testing.T) {
+	ctx := context.Background()
+
+	orig := safeDrainPollInterval
+	safeDrainPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { safeDrainPollInterval = orig })
+
+	rsOwner := metav1.OwnerReference{
+		APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-7d4b9", UID: types.UID("rs-uid"),
+		Controller: ptr(true),
+	}
+	webPod := func(name, node string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name, Namespace: defaultNamespace, UID: types.UID(name),
+				OwnerReferences: []metav1.OwnerReference{rsOwner},
+			},
+			Spec: corev1.PodSpec{NodeName: node},
+		}
+	}
+
+	// rescheduleOnEviction makes the fake behave like a ReplicaSet controller
+	// and scheduler: each evicted pod is deleted and a replacement is created
+	// on node-2.
+	rescheduleOnEviction := func(fakeClient *fake.Clientset) {
+		fakeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() != "eviction" {
+				return false, nil, nil
+			}
+			name := action.(k8stesting.CreateAction).GetObject().(metav1.Object).GetName()
+			tracker := fakeClient.Tracker()
+			gvr := corev1.SchemeGroupVersion.WithResource("pods")
+			if err := tracker.Delete(gvr, defaultNamespace, name); err != nil {
+				return true, nil, err
+			}
+			replacement := webPod(name+"-new", "node-2")
+			replacement.Status.Phase = corev1.PodPending
+			return true, nil, tracker.Create(gvr, replacement, defaultNamespace)
+		})
+	}
+
+	t.Run("ReportsRescheduledPods", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(
+			newNode(testNodeName, true, false),
+			webPod("web-a", testNodeName),
+			webPod("web-b", testNodeName),
+			webPod("web-c", "node-2"),
+		)
+		rescheduleOnEviction(fakeClient)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		node := &Node{Name: testNodeName}
+		result, err := node.SafeDrain(ctx, mockCM, false, -1, time.Second)
+
+		require.NoError(t, err)
+		assert.Contains(t, result, "Evicted 2 pod(s)")
+		assert.Contains(t, result, "Rescheduled 2 of 2 evicted pod(s)")
+		assert.Contains(t, result, "default/web-a (ReplicaSet/web-7d4b9) → web-a-new on node-2 (Pending)")
+		assert.Contains(t, result, "default/web-b (ReplicaSet/web-7d4b9) → web-b-new on node-2 (Pending)")
+		assert.NotContains(t, result, "web-c")
+
+		updated, _ := fakeClient.CoreV1().Nodes().Get(ctx, testNodeName, metav1.GetOptions{})
+		assert.True(t, updated.Spec.Unschedulable)
+	})
+
+	t.Run("ReportsPodsNotRescheduled", func(t *testing.T) {
+		bare := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "bare", Namespace: defaultNamespace},
+			Spec:       corev1.PodSpec{NodeName: testNodeName},
+		}
+		dsPod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ds-pod", Namespace: defaultNamespace,
+				OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "ds", Controller: ptr(true)}},
+			},
+			Spec: corev1.PodSpec{NodeName: testNodeName},
+		}
+		// Nothing recreates web-a, and web-c was already running elsewhere
+		// so it must not be taken for its replacement.
+		fakeClient := fake.NewSimpleClientset(
+			newNode(testNodeName, true, false),
+			webPod("web-a", testNodeName),
+			webPod("web-c", "node-2"),
+			bare, dsPod,
+		)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		node := &Node{Name: testNodeName}
+		result, err := node.SafeDrain(ctx, mockCM, false, -1, 50*time.Millisecond)
+
+		require.NoError(t, err)
+		assert.Contains(t, result, "Skipped 1 pod(s)")
+		assert.Contains(t, result, "Rescheduled 0 of 2 evicted pod(s)")
+		assert.Contains(t, result, "default/web-a (ReplicaSet/web-7d4b9): not rescheduled within 50ms")
+		assert.Contains(t, result, "default/bare: no controller, will not be recreated")
+	})
+
+	t.Run("NoPods", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(newNode(testNodeName, true, false))
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		node := &Node{Name: testNodeName}
+		result, err := node.SafeDrain(ctx, mockCM, false, -1, 0)
+
+		require.NoError(t, err)
+		assert.Contains(t, result, "Evicted 0 pod(s)")
+		assert.NotContains(t, result, "Rescheduled")
+	})
+
+	t.Run("MissingName", func(t *testing.T) {
+		node := &Node{}
+		_, err := node.SafeDrain(ctx, testmocks.NewMockClusterManager(), false, -1, 0)
+		assert.Error(t, err)
+	})
+}
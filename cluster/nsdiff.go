@@ -0,0 +1,226 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/basebandit/kai"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// NamespaceDiff compares the objects of one kind in two namespaces, for
+// keeping environments such as staging and prod in step.
+type NamespaceDiff struct {
+	// Kind is a kind or plural resource name, such as Deployment or
+	// configmaps. It must be namespaced.
+	Kind          string
+	Group         string
+	Version       string
+	LabelSelector string
+	From          string
+	To            string
+}
+
+// ignoredDiffFields are server-assigned fields that always differ between
+// namespaces and say nothing about configuration drift.
+var ignoredDiffFields = map[string]bool{
+	"spec.clusterIP":  true,
+	"spec.clusterIPs": true,
+}
+
+// Run lists the kind in both namespaces and reports objects that exist in
+// only one of them, then field differences for the names they share. Only
+// labels are compared from metadata, and status is ignored, so uids,
+// resourceVersions and timestamps never show up as drift.
+func (d *NamespaceDiff) Run(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if d.Kind == "" {
+		return "", errors.New("kind is required")
+	}
+	if d.From == "" || d.To == "" {
+		return "", errors.New("both namespaces are required")
+	}
+	if d.From == d.To {
+		return "", fmt.Errorf("cannot diff namespace %q against itself", d.From)
+	}
+	selector, err := labels.Parse(d.LabelSelector)
+	if err != nil {
+		return "", fmt.Errorf("invalid label selector %q: %w", d.LabelSelector, err)
+	}
+
+	mapper, err := kai.CurrentRESTMapper(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting REST mapper: %w", err)
+	}
+	dyn, err := kai.CurrentDynamicClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting dynamic client: %w", err)
+	}
+
+	mapping, err := resolveMapping(mapper, d.Group, d.Version, d.Kind)
+	if err != nil {
+		return "", err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return "", fmt.Errorf("kind %s is cluster-scoped; only namespaced kinds can be compared", mapping.GroupVersionKind.Kind)
+	}
+	kind := mapping.GroupVersionKind.Kind
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	objects := make([]map[string]*unstructured.Unstructured, 2)
+	for i, namespace := range []string{d.From, d.To} {
+		list, err := dyn.Resource(mapping.Resource).Namespace(namespace).List(timeoutCtx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return "", fmt.Errorf("failed to list %s in namespace %q: %w", mapping.Resource.Resource, namespace, err)
+		}
+		objects[i] = map[string]*unstructured.Unstructured{}
+		for j := range list.Items {
+			item := &list.Items[j]
+			if selector.Matches(labels.Set(item.GetLabels())) {
+				objects[i][item.GetName()] = item
+			}
+		}
+	}
+	from, to := objects[0], objects[1]
+
+	var onlyFrom, onlyTo, shared []string
+	for name := range from {
+		if _, ok := to[name]; ok {
+			shared = append(shared, name)
+		} else {
+			onlyFrom = append(onlyFrom, name)
+		}
+	}
+	for name := range to {
+		if _, ok := from[name]; !ok {
+			onlyTo = append(onlyTo, name)
+		}
+	}
+	sort.Strings(onlyFrom)
+	sort.Strings(onlyTo)
+	sort.Strings(shared)
+
+	var (
+		differing []string
+		identical int
+	)
+	for _, name := range shared {
+		changes := diffObjects(from[name], to[name])
+		if len(changes) == 0 {
+			identical++
+			continue
+		}
+		differing = append(differing, fmt.Sprintf("%s:\n  %s", name, strings.Join(changes, "\n  ")))
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Comparing %s objects", kind)
+	if !selector.Empty() {
+		fmt.Fprintf(&sb, " matching %q", selector.String())
+	}
+	fmt.Fprintf(&sb, " in namespaces %q and %q", d.From, d.To)
+	if len(onlyFrom) == 0 && len(onlyTo) == 0 && len(differing) == 0 {
+		fmt.Fprintf(&sb, ": in sync (%d identical)", identical)
+		return sb.String(), nil
+	}
+	sb.WriteString(":")
+	if len(onlyFrom) > 0 {
+		fmt.Fprintf(&sb, "\nOnly in %q (%d):\n- %s", d.From, len(onlyFrom), strings.Join(onlyFrom, "\n- "))
+	}
+	if len(onlyTo) > 0 {
+		fmt.Fprintf(&sb, "\nOnly in %q (%d):\n- %s", d.To, len(onlyTo), strings.Join(onlyTo, "\n- "))
+	}
+	if len(differing) > 0 {
+		fmt.Fprintf(&sb, "\nDiffering (%d):\n- %s", len(differing), strings.Join(differing, "\n- "))
+	}
+	fmt.Fprintf(&sb, "\nIdentical: %d", identical)
+	return sb.String(), nil
+}
+
+// diffObjects lists "path: from → to" for every field whose value differs
+// between the two objects, sorted by path.
+func diffObjects(from, to *unstructured.Unstructured) []string {
+	return diffFields(comparableContent(from), comparableContent(to))
+}
+
+// diffFields lists "path: from → to" for every leaf that differs between
+// two object contents, sorted by path.
+func diffFields(from, to map[string]interface{}) []string {
+	before, after := map[string]string{}, map[string]string{}
+	flattenFields("", from, before)
+	flattenFields("", to, after)
+
+	var changes []string
+	for path, value := range before {
+		if ignoredDiffFields[path] {
+			continue
+		}
+		if other, ok := after[path]; !ok {
+			changes = append(changes, fmt.Sprintf("%s: %s → <unset>", path, value))
+		} else if other != value {
+			changes = append(changes, fmt.Sprintf("%s: %s → %s", path, value, other))
+		}
+	}
+	for path, value := range after {
+		if _, ok := before[path]; !ok && !ignoredDiffFields[path] {
+			changes = append(changes, fmt.Sprintf("%s: <unset> → %s", path, value))
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+// comparableContent drops metadata other than labels, status, and the
+// apiVersion/kind header from an object.
+func comparableContent(obj *unstructured.Unstructured) map[string]interface{} {
+	content := map[string]interface{}{}
+	for key, value := range obj.Object {
+		switch key {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		}
+		content[key] = value
+	}
+	if lbls := obj.GetLabels(); len(lbls) > 0 {
+		asMap := make(map[string]interface{}, len(lbls))
+		for k, v := range lbls {
+			asMap[k] = v
+		}
+		content["metadata"] = map[string]interface{}{"labels": asMap}
+	}
+	return content
+}
+
+// flattenFields records every leaf of value under a dotted path, indexing
+// list elements as path[i].
+func flattenFields(path string, value interface{}, out map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			flattenFields(childPath, child, out)
+		}
+	case []interface{}:
+		for i, child := range v {
+			flattenFields(fmt.Sprintf("%s[%d]", path, i), child, out)
+		}
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			out[path] = fmt.Sprint(v)
+			return
+		}
+		out[path] = string(raw)
+	}
+}
@@ -0,0 +1,129 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNamespaceDiff(t *testing.T) {
+	ctx := context.Background()
+	deployGVR := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	deployment := func(name, namespace string, replicas int64, image string) *unstructured.Unstructured {
+		obj := uObj("apps/v1", "Deployment", name, namespace)
+		md := obj.Object["metadata"].(map[string]interface{})
+		md["labels"] = map[string]interface{}{"app": name}
+		md["uid"] = namespace + "-" + name
+		md["resourceVersion"] = namespace
+		obj.Object["spec"] = map[string]interface{}{
+			"replicas": replicas,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{map[string]interface{}{"name": name, "image": image}},
+				},
+			},
+		}
+		obj.Object["status"] = map[string]interface{}{"readyReplicas": replicas}
+		return obj
+	}
+
+	setup := func(t *testing.T, objs ...*unstructured.Unstructured) *testmocks.MockClusterManager {
+		fakeClient := fake.NewSimpleClientset()
+		fakeClient.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{{Name: "namespaces", Namespaced: false, Kind: "Namespace"}},
+			},
+			{
+				GroupVersion: "apps/v1",
+				APIResources: []metav1.APIResource{{Name: "deployments", Namespaced: true, Kind: "Deployment"}},
+			},
+		}
+		dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			deployGVR: "DeploymentList",
+		})
+		for _, obj := range objs {
+			_, err := dyn.Resource(deployGVR).Namespace(obj.GetNamespace()).Create(ctx, obj, metav1.CreateOptions{})
+			require.NoError(t, err)
+		}
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+		mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+		return mockCM
+	}
+
+	t.Run("ReportsReplicaDifference", func(t *testing.T) {
+		mockCM := setup(t,
+			deployment("web", "staging", 2, "web:1.4"),
+			deployment("web", "prod", 5, "web:1.4"),
+			deployment("api", "staging", 1, "api:2.0"),
+			deployment("api", "prod", 1, "api:2.0"),
+			deployment("canary", "staging", 1, "web:1.5"),
+			deployment("worker", "prod", 3, "worker:0.9"),
+		)
+
+		diff := &NamespaceDiff{Kind: "Deployment", From: "staging", To: "prod"}
+		result, err := diff.Run(ctx, mockCM)
+
+		require.NoError(t, err)
+		assert.Equal(t, `Comparing Deployment objects in namespaces "staging" and "prod":
+Only in "staging" (1):
+- canary
+Only in "prod" (1):
+- worker
+Differing (1):
+- web:
+  spec.replicas: 2 → 5
+Identical: 1`, result)
+	})
+
+	t.Run("InSyncWithSelector", func(t *testing.T) {
+		mockCM := setup(t,
+			deployment("web", "staging", 2, "web:1.4"),
+			deployment("web", "prod", 2, "web:1.4"),
+			deployment("worker", "prod", 3, "worker:0.9"),
+		)
+
+		diff := &NamespaceDiff{Kind: "deployments", LabelSelector: "app=web", From: "staging", To: "prod"}
+		result, err := diff.Run(ctx, mockCM)
+
+		require.NoError(t, err)
+		assert.Equal(t, `Comparing Deployment objects matching "app=web" in namespaces "staging" and "prod": in sync (1 identical)`, result)
+	})
+
+	t.Run("ReportsImageAndLabelChanges", func(t *testing.T) {
+		prod := deployment("web", "prod", 2, "web:1.5")
+		prod.SetLabels(map[string]string{"app": "web", "tier": "frontend"})
+		mockCM := setup(t, deployment("web", "staging", 2, "web:1.4"), prod)
+
+		diff := &NamespaceDiff{Kind: "Deployment", From: "staging", To: "prod"}
+		result, err := diff.Run(ctx, mockCM)
+
+		require.NoError(t, err)
+		assert.Contains(t, result, `metadata.labels.tier: <unset> → "frontend"`)
+		assert.Contains(t, result, `spec.template.spec.containers[0].image: "web:1.4" → "web:1.5"`)
+	})
+
+	t.Run("RejectsClusterScopedKind", func(t *testing.T) {
+		mockCM := setup(t)
+		diff := &NamespaceDiff{Kind: "Namespace", From: "staging", To: "prod"}
+		_, err := diff.Run(ctx, mockCM)
+		assert.ErrorContains(t, err, "cluster-scoped")
+	})
+
+	t.Run("RejectsSameNamespace", func(t *testing.T) {
+		diff := &NamespaceDiff{Kind: "Deployment", From: "prod", To: "prod"}
+		_, err := diff.Run(ctx, testmocks.NewMockClusterManager())
+		assert.ErrorContains(t, err, "against itself")
+	})
+}
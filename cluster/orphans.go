@@ -0,0 +1,372 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Kinds understood by the orphan finder.
+const (
+	orphanKindServices   = "services"
+	orphanKindConfigMaps = "configmaps"
+	orphanKindSecrets    = "secrets"
+	orphanKindPVCs       = "persistentvolumeclaims"
+)
+
+var orphanKindAliases = map[string]string{
+	"service":                orphanKindServices,
+	"services":               orphanKindServices,
+	"svc":                    orphanKindServices,
+	"configmap":              orphanKindConfigMaps,
+	"configmaps":             orphanKindConfigMaps,
+	"cm":                     orphanKindConfigMaps,
+	"secret":                 orphanKindSecrets,
+	"secrets":                orphanKindSecrets,
+	"persistentvolumeclaim":  orphanKindPVCs,
+	"persistentvolumeclaims": orphanKindPVCs,
+	"pvc":                    orphanKindPVCs,
+	"pvcs":                   orphanKindPVCs,
+}
+
+// Orphans represents a query for unreferenced resources in a namespace.
+type Orphans struct {
+	Namespace string
+	Kinds     []string // empty means all supported kinds
+}
+
+// consumers records which ConfigMaps, Secrets and PVCs are referenced by the
+// pod specs in a namespace.
+type consumers struct {
+	configMaps map[string][]string
+	secrets    map[string][]string
+	pvcs       map[string][]string
+}
+
+func newConsumers() *consumers {
+	return &consumers{
+		configMaps: make(map[string][]string),
+		secrets:    make(map[string][]string),
+		pvcs:       make(map[string][]string),
+	}
+}
+
+// addPodSpec records every ConfigMap, Secret and PVC referenced by spec
+// under the given owner (e.g. "Deployment/web").
+func (c *consumers) addPodSpec(owner string, spec *corev1.PodSpec) {
+	for _, ref := range spec.ImagePullSecrets {
+		c.secrets[ref.Name] = append(c.secrets[ref.Name], owner)
+	}
+
+	for _, vol := range spec.Volumes {
+		switch {
+		case vol.ConfigMap != nil:
+			c.configMaps[vol.ConfigMap.Name] = append(c.configMaps[vol.ConfigMap.Name], owner)
+		case vol.Secret != nil:
+			c.secrets[vol.Secret.SecretName] = append(c.secrets[vol.Secret.SecretName], owner)
+		case vol.PersistentVolumeClaim != nil:
+			c.pvcs[vol.PersistentVolumeClaim.ClaimName] = append(c.pvcs[vol.PersistentVolumeClaim.ClaimName], owner)
+		case vol.Projected != nil:
+			for _, src := range vol.Projected.Sources {
+				if src.ConfigMap != nil {
+					c.configMaps[src.ConfigMap.Name] = append(c.configMaps[src.ConfigMap.Name], owner)
+				}
+				if src.Secret != nil {
+					c.secrets[src.Secret.Name] = append(c.secrets[src.Secret.Name], owner)
+				}
+			}
+		}
+	}
+
+	containers := make([]corev1.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+	containers = append(containers, spec.InitContainers...)
+	containers = append(containers, spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				c.configMaps[envFrom.ConfigMapRef.Name] = append(c.configMaps[envFrom.ConfigMapRef.Name], owner)
+			}
+			if envFrom.SecretRef != nil {
+				c.secrets[envFrom.SecretRef.Name] = append(c.secrets[envFrom.SecretRef.Name], owner)
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+				c.configMaps[ref.Name] = append(c.configMaps[ref.Name], owner)
+			}
+			if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+				c.secrets[ref.Name] = append(c.secrets[ref.Name], owner)
+			}
+		}
+	}
+}
+
+// findConsumers indexes the pods and workload templates in namespace by the
+// ConfigMaps, Secrets and PVCs they reference. Workload templates are
+// included so that resources used by a workload scaled to zero are not
+// reported as unreferenced.
+func findConsumers(ctx context.Context, client kubernetes.Interface, namespace string) (*consumers, error) {
+	c := newConsumers()
+
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	for i := range pods.Items {
+		c.addPodSpec("Pod/"+pods.Items[i].Name, &pods.Items[i].Spec)
+	}
+
+	deployments, err := client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for i := range deployments.Items {
+		c.addPodSpec("Deployment/"+deployments.Items[i].Name, &deployments.Items[i].Spec.Template.Spec)
+	}
+
+	statefulSets, err := client.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for i := range statefulSets.Items {
+		sts := &statefulSets.Items[i]
+		c.addPodSpec("StatefulSet/"+sts.Name, &sts.Spec.Template.Spec)
+		// Claims stamped out from volumeClaimTemplates are named
+		// <template>-<statefulset>-<ordinal>; record the prefix so
+		// they are not flagged.
+		for _, tmpl := range sts.Spec.VolumeClaimTemplates {
+			prefix := tmpl.Name + "-" + sts.Name + "-"
+			c.pvcs[prefix] = append(c.pvcs[prefix], "StatefulSet/"+sts.Name)
+		}
+	}
+
+	daemonSets, err := client.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for i := range daemonSets.Items {
+		c.addPodSpec("DaemonSet/"+daemonSets.Items[i].Name, &daemonSets.Items[i].Spec.Template.Spec)
+	}
+
+	cronJobs, err := client.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cronjobs: %w", err)
+	}
+	for i := range cronJobs.Items {
+		c.addPodSpec("CronJob/"+cronJobs.Items[i].Name, &cronJobs.Items[i].Spec.JobTemplate.Spec.Template.Spec)
+	}
+
+	serviceAccounts, err := client.CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	for _, sa := range serviceAccounts.Items {
+		for _, ref := range sa.Secrets {
+			c.secrets[ref.Name] = append(c.secrets[ref.Name], "ServiceAccount/"+sa.Name)
+		}
+		for _, ref := range sa.ImagePullSecrets {
+			c.secrets[ref.Name] = append(c.secrets[ref.Name], "ServiceAccount/"+sa.Name)
+		}
+	}
+
+	ingresses, err := client.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ingresses: %w", err)
+	}
+	for _, ing := range ingresses.Items {
+		for _, tls := range ing.Spec.TLS {
+			if tls.SecretName != "" {
+				c.secrets[tls.SecretName] = append(c.secrets[tls.SecretName], "Ingress/"+ing.Name)
+			}
+		}
+	}
+
+	return c, nil
+}
+
+func (c *consumers) hasPVC(name string) bool {
+	if _, ok := c.pvcs[name]; ok {
+		return true
+	}
+	for prefix := range c.pvcs {
+		if strings.HasSuffix(prefix, "-") && strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *Orphans) kinds() ([]string, error) {
+	if len(o.Kinds) == 0 {
+		return []string{orphanKindServices, orphanKindConfigMaps, orphanKindSecrets, orphanKindPVCs}, nil
+	}
+	seen := make(map[string]bool)
+	var kinds []string
+	for _, k := range o.Kinds {
+		kind, ok := orphanKindAliases[strings.ToLower(strings.TrimSpace(k))]
+		if !ok {
+			return nil, fmt.Errorf("unsupported kind %q: must be one of services, configmaps, secrets, persistentvolumeclaims", k)
+		}
+		if !seen[kind] {
+			seen[kind] = true
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds, nil
+}
+
+// Find reports Services that select no pods and ConfigMaps, Secrets and
+// PVCs that nothing in the namespace references.
+func (o *Orphans) Find(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	kinds, err := o.kinds()
+	if err != nil {
+		return "", err
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := o.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	refs, err := findConsumers(timeoutCtx, client, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	total := 0
+	for _, kind := range kinds {
+		var orphans []string
+		switch kind {
+		case orphanKindServices:
+			orphans, err = orphanedServices(timeoutCtx, client, namespace)
+		case orphanKindConfigMaps:
+			orphans, err = orphanedConfigMaps(timeoutCtx, client, namespace, refs)
+		case orphanKindSecrets:
+			orphans, err = orphanedSecrets(timeoutCtx, client, namespace, refs)
+		case orphanKindPVCs:
+			orphans, err = orphanedPVCs(timeoutCtx, client, namespace, refs)
+		}
+		if err != nil {
+			return "", err
+		}
+		if len(orphans) == 0 {
+			continue
+		}
+		sort.Strings(orphans)
+		total += len(orphans)
+		fmt.Fprintf(&sb, "%s (%d):\n", kind, len(orphans))
+		for _, line := range orphans {
+			fmt.Fprintf(&sb, "• %s\n", line)
+		}
+	}
+
+	if total == 0 {
+		return fmt.Sprintf("No orphaned resources found in namespace %q", namespace), nil
+	}
+
+	header := fmt.Sprintf("Orphaned resources in namespace %q (%d):\n", namespace, total)
+	return header + strings.TrimRight(sb.String(), "\n"), nil
+}
+
+func orphanedServices(ctx context.Context, client kubernetes.Interface, namespace string) ([]string, error) {
+	services, err := client.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var orphans []string
+	for _, svc := range services.Items {
+		// Services without a selector have manually managed endpoints.
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		selector := labels.SelectorFromSet(svc.Spec.Selector)
+		matched := false
+		for _, pod := range pods.Items {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			orphans = append(orphans, fmt.Sprintf("%s\tselector: %s", svc.Name, selector.String()))
+		}
+	}
+	return orphans, nil
+}
+
+func orphanedConfigMaps(ctx context.Context, client kubernetes.Interface, namespace string, refs *consumers) ([]string, error) {
+	configMaps, err := client.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configmaps: %w", err)
+	}
+
+	var orphans []string
+	for _, cmap := range configMaps.Items {
+		// Published into every namespace by the control plane.
+		if cmap.Name == "kube-root-ca.crt" {
+			continue
+		}
+		if _, ok := refs.configMaps[cmap.Name]; !ok {
+			orphans = append(orphans, cmap.Name)
+		}
+	}
+	return orphans, nil
+}
+
+func orphanedSecrets(ctx context.Context, client kubernetes.Interface, namespace string, refs *consumers) ([]string, error) {
+	secrets, err := client.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	var orphans []string
+	for _, secret := range secrets.Items {
+		// Token secrets and Helm release records are consumed outside pod specs.
+		if secret.Type == corev1.SecretTypeServiceAccountToken || strings.HasPrefix(string(secret.Type), "helm.sh/") {
+			continue
+		}
+		if _, ok := refs.secrets[secret.Name]; !ok {
+			orphans = append(orphans, fmt.Sprintf("%s\ttype: %s", secret.Name, secret.Type))
+		}
+	}
+	return orphans, nil
+}
+
+func orphanedPVCs(ctx context.Context, client kubernetes.Interface, namespace string, refs *consumers) ([]string, error) {
+	pvcs, err := client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list persistent volume claims: %w", err)
+	}
+
+	var orphans []string
+	for _, pvc := range pvcs.Items {
+		if !refs.hasPVC(pvc.Name) {
+			orphans = append(orphans, fmt.Sprintf("%s\tstatus: %s", pvc.Name, pvc.Status.Phase))
+		}
+	}
+	return orphans, nil
+}
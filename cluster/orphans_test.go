@@ -0,0 +1,75 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestOrphansFind(t *testing.T) {
+	ctx := context.Background()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: defaultNamespace, Labels: map[string]string{"app": "web"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "web",
+				Image: nginxImage,
+				EnvFrom: []corev1.EnvFromSource{{
+					ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "web-config"}},
+				}},
+			}},
+		},
+	}
+	referencedSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: defaultNamespace},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "web"}},
+	}
+	orphanedSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy-api", Namespace: defaultNamespace},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "legacy-api"}},
+	}
+	usedConfig := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web-config", Namespace: defaultNamespace}}
+	unusedConfig := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "old-config", Namespace: defaultNamespace}}
+
+	newCM := func() *testmocks.MockClusterManager {
+		fakeClient := fake.NewSimpleClientset(pod, referencedSvc, orphanedSvc, usedConfig, unusedConfig)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+		mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+		return mockCM
+	}
+
+	t.Run("ReportsOrphans", func(t *testing.T) {
+		orphans := &Orphans{}
+		result, err := orphans.Find(ctx, newCM())
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "legacy-api")
+		assert.NotContains(t, result, "• web\t")
+		assert.Contains(t, result, "old-config")
+		assert.NotContains(t, result, "web-config")
+	})
+
+	t.Run("KindsFilter", func(t *testing.T) {
+		orphans := &Orphans{Kinds: []string{"svc"}}
+		result, err := orphans.Find(ctx, newCM())
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "legacy-api")
+		assert.NotContains(t, result, "old-config")
+	})
+
+	t.Run("InvalidKind", func(t *testing.T) {
+		orphans := &Orphans{Kinds: []string{"widgets"}}
+		_, err := orphans.Find(ctx, testmocks.NewMockClusterManager())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported kind")
+	})
+}
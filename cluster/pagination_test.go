@@ -0,0 +1,126 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// pagedLists serves three objects per resource two at a time, the way the
+// apiserver does for a limited list: the first page carries a continue
+// token and the second page, requested with it, does not.
+func pagedLists(t *testing.T, fakeClient *fake.Clientset) {
+	names := []string{"a", "b", "c"}
+	fakeClient.PrependReactor("list", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		opts := action.(k8stesting.ListActionImpl).ListOptions
+		require.Equal(t, int64(2), opts.Limit)
+
+		page, next := names[:2], "page-2"
+		if opts.Continue == "page-2" {
+			page, next = names[2:], ""
+		}
+		meta := metav1.ObjectMeta{Namespace: testNamespace}
+		listMeta := metav1.ListMeta{Continue: next}
+		switch action.GetResource().Resource {
+		case "pods":
+			list := &corev1.PodList{ListMeta: listMeta}
+			for _, name := range page {
+				meta.Name = "pod-" + name
+				list.Items = append(list.Items, corev1.Pod{ObjectMeta: meta})
+			}
+			return true, list, nil
+		case "deployments":
+			list := &appsv1.DeploymentList{ListMeta: listMeta}
+			for _, name := range page {
+				meta.Name = "deploy-" + name
+				list.Items = append(list.Items, appsv1.Deployment{ObjectMeta: meta})
+			}
+			return true, list, nil
+		case "services":
+			list := &corev1.ServiceList{ListMeta: listMeta}
+			for _, name := range page {
+				meta.Name = "svc-" + name
+				list.Items = append(list.Items, corev1.Service{ObjectMeta: meta})
+			}
+			return true, list, nil
+		case "secrets":
+			list := &corev1.SecretList{ListMeta: listMeta}
+			for _, name := range page {
+				meta.Name = "secret-" + name
+				list.Items = append(list.Items, corev1.Secret{ObjectMeta: meta})
+			}
+			return true, list, nil
+		}
+		return false, nil, nil
+	})
+}
+
+func TestListPagination(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name   string
+		prefix string
+		list   func(cm *testmocks.MockClusterManager, continueToken string) (string, error)
+	}{
+		{
+			name:   "Pods",
+			prefix: "pod-",
+			list: func(cm *testmocks.MockClusterManager, continueToken string) (string, error) {
+				return (&Pod{Namespace: testNamespace}).List(ctx, cm, 2, continueToken, "", "")
+			},
+		},
+		{
+			name:   "Deployments",
+			prefix: "deploy-",
+			list: func(cm *testmocks.MockClusterManager, continueToken string) (string, error) {
+				return (&Deployment{Namespace: testNamespace}).List(ctx, cm, false, "", 2, continueToken)
+			},
+		},
+		{
+			name:   "Services",
+			prefix: "svc-",
+			list: func(cm *testmocks.MockClusterManager, continueToken string) (string, error) {
+				return (&Service{Namespace: testNamespace}).List(ctx, cm, false, "", 2, continueToken)
+			},
+		},
+		{
+			name:   "Secrets",
+			prefix: "secret-",
+			list: func(cm *testmocks.MockClusterManager, continueToken string) (string, error) {
+				return (&Secret{Namespace: testNamespace}).List(ctx, cm, false, "", 2, continueToken)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}})
+			pagedLists(t, fakeClient)
+			mockCM := testmocks.NewMockClusterManager()
+			mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+			first, err := tc.list(mockCM, "")
+			require.NoError(t, err)
+			assert.Contains(t, first, tc.prefix+"a")
+			assert.Contains(t, first, tc.prefix+"b")
+			assert.NotContains(t, first, tc.prefix+"c")
+			assert.Contains(t, first, `More results available. Pass continue="page-2" to fetch the next page.`)
+
+			second, err := tc.list(mockCM, "page-2")
+			require.NoError(t, err)
+			assert.Contains(t, second, tc.prefix+"c")
+			assert.NotContains(t, second, tc.prefix+"a")
+			assert.NotContains(t, second, "More results available")
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/basebandit/kai"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+// PatchResource applies a patch to a single object of any kind, identified
+// like DeleteResource by kind (or resource name), optional group/version, and
+// name. It mirrors `kubectl patch --type`.
+type PatchResource struct {
+	Kind    string
+	Group   string
+	Version string // optional; the preferred version is used when empty
+	Name    string
+	// Namespace is used for namespaced kinds, defaulting to the current
+	// namespace. It is ignored for cluster-scoped kinds.
+	Namespace string
+	// PatchType is strategic, merge or json; empty means strategic.
+	PatchType string
+	// Patch is the patch document as JSON or YAML. A json patch is a list of
+	// operations; the other types are partial objects.
+	Patch  string
+	DryRun bool
+}
+
+// patchTypes maps the names accepted by PatchType to their content types.
+var patchTypes = map[string]types.PatchType{
+	"strategic": types.StrategicMergePatchType,
+	"merge":     types.MergePatchType,
+	"json":      types.JSONPatchType,
+}
+
+// Run resolves the kind through the REST mapper and patches the object via
+// the dynamic client.
+func (p *PatchResource) Run(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if p.Kind == "" {
+		return "", errors.New("kind is required")
+	}
+	if p.Name == "" {
+		return "", errors.New("name is required")
+	}
+	if strings.TrimSpace(p.Patch) == "" {
+		return "", errors.New("patch is required")
+	}
+
+	typeName := strings.ToLower(p.PatchType)
+	if typeName == "" {
+		typeName = "strategic"
+	}
+	patchType, ok := patchTypes[typeName]
+	if !ok {
+		return "", fmt.Errorf("invalid patch type %q: must be strategic, merge or json", p.PatchType)
+	}
+
+	data, err := patchJSON(p.Patch, patchType)
+	if err != nil {
+		return "", err
+	}
+
+	ri, mapping, prefix, err := resourceClient(ctx, cm, p.Group, p.Version, p.Kind, p.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	kind := mapping.GroupVersionKind.Kind
+	_, err = ri.Patch(timeoutCtx, p.Name, patchType, data, metav1.PatchOptions{DryRun: dryRunOption(p.DryRun)})
+	if err != nil {
+		// Custom resources have no Go type to derive merge keys from, so the
+		// API server rejects strategic merge patches for them.
+		if patchType == types.StrategicMergePatchType && strings.Contains(err.Error(), "strategic merge patch") {
+			return "", fmt.Errorf("failed to patch %s %s%s: %w (use patch_type merge or json for custom resources)", kind, prefix, p.Name, err)
+		}
+		return "", fmt.Errorf("failed to patch %s %s%s: %w", kind, prefix, p.Name, err)
+	}
+
+	result := fmt.Sprintf("%s %s%s patched (%s patch)", kind, prefix, p.Name, typeName)
+	return dryRunResult(result, p.DryRun), nil
+}
+
+// resourceClient resolves a kind through the REST mapper and returns the
+// dynamic client for it, scoped to namespace (or the current namespace) when
+// the kind is namespaced, along with the "namespace/" prefix used in messages.
+func resourceClient(ctx context.Context, cm kai.ClusterManager, group, version, kind, namespace string) (dynamic.ResourceInterface, *meta.RESTMapping, string, error) {
+	mapper, err := kai.CurrentRESTMapper(ctx, cm)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("error getting REST mapper: %w", err)
+	}
+	dyn, err := kai.CurrentDynamicClient(ctx, cm)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("error getting dynamic client: %w", err)
+	}
+
+	mapping, err := resolveMapping(mapper, group, version, kind)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return dyn.Resource(mapping.Resource), mapping, "", nil
+	}
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+	return dyn.Resource(mapping.Resource).Namespace(namespace), mapping, namespace + "/", nil
+}
+
+// patchJSON converts the patch document to JSON and checks that its shape
+// matches the patch type before anything is sent to the API server.
+func patchJSON(patch string, patchType types.PatchType) ([]byte, error) {
+	data, err := yaml.YAMLToJSON([]byte(patch))
+	if err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+	switch doc.(type) {
+	case []interface{}:
+		if patchType != types.JSONPatchType {
+			return nil, errors.New("a list of operations is a json patch; set patch_type to json")
+		}
+	case map[string]interface{}:
+		if patchType == types.JSONPatchType {
+			return nil, errors.New("a json patch must be a list of operations, e.g. [{\"op\": \"replace\", \"path\": \"/spec/replicas\", \"value\": 3}]")
+		}
+	default:
+		return nil, errors.New("patch must be an object or, for a json patch, a list of operations")
+	}
+	return data, nil
+}
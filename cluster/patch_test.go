@@ -0,0 +1,83 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPatchResourceRun(t *testing.T) {
+	ctx := context.Background()
+	cmGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+	newCM := func() (*testmocks.MockClusterManager, *dynamicfake.FakeDynamicClient) {
+		fakeClient := fake.NewSimpleClientset()
+		fakeClient.Resources = applyDiscovery()
+		dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), applyListKinds)
+		obj := uObj("v1", "ConfigMap", "cm1", testNamespace)
+		obj.Object["data"] = map[string]interface{}{"mode": "blue", "level": "info"}
+		_, err := dyn.Resource(cmGVR).Namespace(testNamespace).Create(ctx, obj, metav1.CreateOptions{})
+		assert.NoError(t, err)
+
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+		mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+		mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+		return mockCM, dyn
+	}
+
+	data := func(t *testing.T, dyn *dynamicfake.FakeDynamicClient) map[string]string {
+		obj, err := dyn.Resource(cmGVR).Namespace(testNamespace).Get(ctx, "cm1", metav1.GetOptions{})
+		assert.NoError(t, err)
+		values, _, _ := unstructured.NestedStringMap(obj.Object, "data")
+		return values
+	}
+
+	t.Run("MergePatchFromYAML", func(t *testing.T) {
+		mockCM, dyn := newCM()
+		patch := &PatchResource{Kind: "configmaps", Name: "cm1", Namespace: testNamespace, PatchType: "merge", Patch: "data:\n  mode: green\n"}
+		result, err := patch.Run(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ConfigMap test-namespace/cm1 patched (merge patch)", result)
+		assert.Equal(t, map[string]string{"mode": "green", "level": "info"}, data(t, dyn))
+	})
+
+	t.Run("JSONPatch", func(t *testing.T) {
+		mockCM, dyn := newCM()
+		patch := &PatchResource{Kind: "ConfigMap", Name: "cm1", Namespace: testNamespace, PatchType: "json",
+			Patch: `[{"op": "remove", "path": "/data/level"}]`}
+		_, err := patch.Run(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"mode": "blue"}, data(t, dyn))
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			patch PatchResource
+			err   string
+		}{
+			{"MissingPatch", PatchResource{Kind: "ConfigMap", Name: "cm1"}, "patch is required"},
+			{"UnknownType", PatchResource{Kind: "ConfigMap", Name: "cm1", PatchType: "apply", Patch: "{}"}, "invalid patch type"},
+			{"ListWithoutJSONType", PatchResource{Kind: "ConfigMap", Name: "cm1", PatchType: "merge", Patch: "[]"}, "set patch_type to json"},
+			{"ObjectWithJSONType", PatchResource{Kind: "ConfigMap", Name: "cm1", PatchType: "json", Patch: "{}"}, "list of operations"},
+			{"Malformed", PatchResource{Kind: "ConfigMap", Name: "cm1", Patch: "{data: [}"}, "invalid patch"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := tt.patch.Run(ctx, testmocks.NewMockClusterManager())
+				assert.ErrorContains(t, err, tt.err)
+			})
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// noPendingReason groups pending pods that report neither a scheduling
+// failure nor a waiting container, typically because they were only just
+// created.
+const noPendingReason = "NoReasonReported"
+
+// PendingPods represents a query for why pods are stuck in Pending.
+type PendingPods struct {
+	Namespace     string
+	AllNamespaces bool
+}
+
+// pendingEntry is one pod under a pending reason.
+type pendingEntry struct {
+	pod    string
+	detail string
+}
+
+// Reasons lists Pending pods grouped by what holds them back: the
+// scheduler's reason when the pod cannot be placed, and the waiting reason
+// of each container that cannot start, such as ImagePullBackOff. The
+// largest groups come first. A pod appears under every reason that applies.
+func (p *PendingPods) Reasons(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := ""
+	if !p.AllNamespaces {
+		namespace = p.Namespace
+		if namespace == "" {
+			namespace = kai.CurrentNamespace(ctx, cm)
+		}
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	pods, err := client.CoreV1().Pods(namespace).List(timeoutCtx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("status.phase", string(corev1.PodPending)).String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	groups := map[string][]pendingEntry{}
+	pending := 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodPending {
+			continue
+		}
+		pending++
+		name := pod.Name
+		if p.AllNamespaces {
+			name = pod.Namespace + "/" + pod.Name
+		}
+		for reason, details := range pendingReasons(pod) {
+			for _, detail := range details {
+				groups[reason] = append(groups[reason], pendingEntry{pod: name, detail: detail})
+			}
+		}
+	}
+
+	if pending == 0 {
+		if p.AllNamespaces {
+			return "No pending pods", nil
+		}
+		return fmt.Sprintf("No pending pods in namespace %q", namespace), nil
+	}
+
+	return formatPendingReasons(groups, pending, namespace, p.AllNamespaces), nil
+}
+
+// pendingReasons extracts why a single pod is pending, keyed by reason.
+func pendingReasons(pod *corev1.Pod) map[string][]string {
+	reasons := map[string][]string{}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse {
+			reason := c.Reason
+			if reason == "" {
+				reason = "NotScheduled"
+			}
+			reasons[reason] = append(reasons[reason], strings.TrimSpace(c.Message))
+		}
+	}
+
+	waiting := func(statuses []corev1.ContainerStatus, kind string) {
+		for _, s := range statuses {
+			if s.State.Waiting == nil || s.State.Waiting.Reason == "" {
+				continue
+			}
+			detail := fmt.Sprintf("%s %s", kind, s.Name)
+			if msg := strings.TrimSpace(s.State.Waiting.Message); msg != "" {
+				detail += ": " + msg
+			}
+			reasons[s.State.Waiting.Reason] = append(reasons[s.State.Waiting.Reason], detail)
+		}
+	}
+	waiting(pod.Status.InitContainerStatuses, "init container")
+	waiting(pod.Status.ContainerStatuses, "container")
+
+	if len(reasons) == 0 {
+		reasons[noPendingReason] = []string{""}
+	}
+	return reasons
+}
+
+func formatPendingReasons(groups map[string][]pendingEntry, pending int, namespace string, allNamespaces bool) string {
+	reasons := make([]string, 0, len(groups))
+	for reason := range groups {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if len(groups[reasons[i]]) != len(groups[reasons[j]]) {
+			return len(groups[reasons[i]]) > len(groups[reasons[j]])
+		}
+		return reasons[i] < reasons[j]
+	})
+
+	var sb strings.Builder
+	if allNamespaces {
+		fmt.Fprintf(&sb, "Pending pods across all namespaces (%d):\n", pending)
+	} else {
+		fmt.Fprintf(&sb, "Pending pods in namespace %q (%d):\n", namespace, pending)
+	}
+	for _, reason := range reasons {
+		entries := groups[reason]
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].pod < entries[j].pod })
+		fmt.Fprintf(&sb, "\n%s (%d):\n", reason, len(entries))
+		for _, e := range entries {
+			if e.detail == "" {
+				fmt.Fprintf(&sb, "• %s\n", e.pod)
+			} else {
+				fmt.Fprintf(&sb, "• %s: %s\n", e.pod, e.detail)
+			}
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
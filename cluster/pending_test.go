@@ -0,0 +1,90 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPendingPodsReasons(t *testing.T) {
+	ctx := context.Background()
+
+	unschedulable := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "big-job", Namespace: defaultNamespace},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:    corev1.PodScheduled,
+				Status:  corev1.ConditionFalse,
+				Reason:  corev1.PodReasonUnschedulable,
+				Message: "0/3 nodes are available: 3 Insufficient cpu.",
+			}},
+		},
+	}
+	imagePull := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "nginx",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+					Reason:  "ImagePullBackOff",
+					Message: `Back-off pulling image "nginx:does-not-exist"`,
+				}},
+			}},
+		},
+	}
+	justCreated := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "fresh", Namespace: defaultNamespace},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	running := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: defaultNamespace},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	fakeClient := fake.NewSimpleClientset(unschedulable, imagePull, justCreated, running)
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+
+	t.Run("Namespace", func(t *testing.T) {
+		result, err := (&PendingPods{}).Reasons(ctx, mockCM)
+		require.NoError(t, err)
+		assert.Equal(t, `Pending pods in namespace "default" (2):
+
+NoReasonReported (1):
+• fresh
+
+Unschedulable (1):
+• big-job: 0/3 nodes are available: 3 Insufficient cpu.`, result)
+	})
+
+	t.Run("AllNamespaces", func(t *testing.T) {
+		result, err := (&PendingPods{AllNamespaces: true}).Reasons(ctx, mockCM)
+		require.NoError(t, err)
+		assert.Contains(t, result, "Pending pods across all namespaces (3):")
+		assert.Contains(t, result, "ImagePullBackOff (1):\n• test-namespace/web: container nginx: Back-off pulling image \"nginx:does-not-exist\"")
+		assert.Contains(t, result, "Unschedulable (1):\n• default/big-job: 0/3 nodes are available")
+		assert.NotContains(t, result, "api")
+	})
+
+	t.Run("NonePending", func(t *testing.T) {
+		client := fake.NewSimpleClientset(running)
+		cm := testmocks.NewMockClusterManager()
+		cm.On("GetCurrentClient").Return(client, nil)
+
+		result, err := (&PendingPods{Namespace: defaultNamespace}).Reasons(ctx, cm)
+		require.NoError(t, err)
+		assert.Equal(t, `No pending pods in namespace "default"`, result)
+	})
+}
@@ -25,7 +25,7 @@ func (p *PersistentVolume) validate() error {
 
 // List returns all persistent volumes in the cluster.
 func (p *PersistentVolume) List(ctx context.Context, cm kai.ClusterManager) (string, error) {
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting client: %w", err)
 	}
@@ -51,7 +51,7 @@ func (p *PersistentVolume) Get(ctx context.Context, cm kai.ClusterManager) (stri
 		return "", err
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting client: %w", err)
 	}
@@ -73,7 +73,7 @@ func (p *PersistentVolume) Delete(ctx context.Context, cm kai.ClusterManager) (s
 		return "", err
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting client: %w", err)
 	}
@@ -22,13 +22,14 @@ type PersistentVolumeClaim struct {
 	VolumeMode       string
 	Labels           map[string]interface{}
 	Annotations      map[string]interface{}
+	DryRun           bool
 }
 
-func (p *PersistentVolumeClaim) namespace(cm kai.ClusterManager) string {
+func (p *PersistentVolumeClaim) namespace(ctx context.Context, cm kai.ClusterManager) string {
 	if p.Namespace != "" {
 		return p.Namespace
 	}
-	return cm.GetCurrentNamespace()
+	return kai.CurrentNamespace(ctx, cm)
 }
 
 // Create provisions a new PersistentVolumeClaim.
@@ -45,20 +46,31 @@ func (p *PersistentVolumeClaim) Create(ctx context.Context, cm kai.ClusterManage
 		return "", fmt.Errorf("invalid storage quantity %q: %w", p.Storage, err)
 	}
 
-	client, err := cm.GetCurrentClient()
-	if err != nil {
-		return "", fmt.Errorf("error getting client: %w", err)
-	}
-
-	ns := p.namespace(cm)
-
 	accessModes := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
 	if len(p.AccessModes) > 0 {
 		accessModes = accessModes[:0]
 		for _, m := range p.AccessModes {
-			accessModes = append(accessModes, corev1.PersistentVolumeAccessMode(m))
+			mode := corev1.PersistentVolumeAccessMode(m)
+			switch mode {
+			case corev1.ReadWriteOnce, corev1.ReadOnlyMany, corev1.ReadWriteMany, corev1.ReadWriteOncePod:
+			default:
+				return "", fmt.Errorf("invalid access mode %q: must be ReadWriteOnce, ReadOnlyMany, ReadWriteMany or ReadWriteOncePod", m)
+			}
+			accessModes = append(accessModes, mode)
 		}
 	}
+	switch corev1.PersistentVolumeMode(p.VolumeMode) {
+	case "", corev1.PersistentVolumeFilesystem, corev1.PersistentVolumeBlock:
+	default:
+		return "", fmt.Errorf("invalid volume mode %q: must be Filesystem or Block", p.VolumeMode)
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	ns := p.namespace(ctx, cm)
 
 	pvc := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{Name: p.Name, Namespace: ns},
@@ -87,24 +99,24 @@ func (p *PersistentVolumeClaim) Create(ctx context.Context, cm kai.ClusterManage
 	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
 	defer cancel()
 
-	created, err := client.CoreV1().PersistentVolumeClaims(ns).Create(timeoutCtx, pvc, metav1.CreateOptions{})
+	created, err := client.CoreV1().PersistentVolumeClaims(ns).Create(timeoutCtx, pvc, metav1.CreateOptions{DryRun: dryRunOption(p.DryRun)})
 	if err != nil {
 		return "", fmt.Errorf("failed to create persistent volume claim: %w", err)
 	}
 
-	return fmt.Sprintf("PersistentVolumeClaim %q created successfully in namespace %q", created.Name, ns), nil
+	return dryRunResult(fmt.Sprintf("PersistentVolumeClaim %q created successfully in namespace %q", created.Name, ns), p.DryRun), nil
 }
 
 // List returns PVCs in the requested namespace.
 func (p *PersistentVolumeClaim) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string) (string, error) {
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting client: %w", err)
 	}
 
 	ns := ""
 	if !allNamespaces {
-		ns = p.namespace(cm)
+		ns = p.namespace(ctx, cm)
 	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
@@ -128,12 +140,12 @@ func (p *PersistentVolumeClaim) Get(ctx context.Context, cm kai.ClusterManager)
 		return "", fmt.Errorf("persistent volume claim name is required")
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting client: %w", err)
 	}
 
-	ns := p.namespace(cm)
+	ns := p.namespace(ctx, cm)
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
 	defer cancel()
@@ -152,12 +164,12 @@ func (p *PersistentVolumeClaim) Delete(ctx context.Context, cm kai.ClusterManage
 		return "", fmt.Errorf("persistent volume claim name is required")
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting client: %w", err)
 	}
 
-	ns := p.namespace(cm)
+	ns := p.namespace(ctx, cm)
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
 	defer cancel()
@@ -169,7 +181,16 @@ func (p *PersistentVolumeClaim) Delete(ctx context.Context, cm kai.ClusterManage
 	return fmt.Sprintf("PersistentVolumeClaim %q deleted successfully from namespace %q", p.Name, ns), nil
 }
 
+// pvcCapacity reports the capacity of the bound volume, falling back to the
+// requested size while the claim is unbound.
 func pvcCapacity(pvc *corev1.PersistentVolumeClaim) string {
+	if storage, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+		return storage.String()
+	}
+	return pvcRequest(pvc)
+}
+
+func pvcRequest(pvc *corev1.PersistentVolumeClaim) string {
 	if storage, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
 		return storage.String()
 	}
@@ -201,14 +222,21 @@ func formatPVC(pvc *corev1.PersistentVolumeClaim) string {
 	fmt.Fprintf(&sb, "PersistentVolumeClaim: %s\n", pvc.Name)
 	fmt.Fprintf(&sb, "Namespace: %s\n", pvc.Namespace)
 	fmt.Fprintf(&sb, "Status: %s\n", pvc.Status.Phase)
+	if pvc.Spec.VolumeName != "" {
+		fmt.Fprintf(&sb, "Bound Volume: %s\n", pvc.Spec.VolumeName)
+	} else {
+		sb.WriteString("Bound Volume: <none>\n")
+	}
+	fmt.Fprintf(&sb, "Requested: %s\n", pvcRequest(pvc))
 	fmt.Fprintf(&sb, "Capacity: %s\n", pvcCapacity(pvc))
-	fmt.Fprintf(&sb, "Access Modes: %s\n", accessModesToString(pvc.Spec.AccessModes))
+	accessModes := pvc.Spec.AccessModes
+	if len(pvc.Status.AccessModes) > 0 {
+		accessModes = pvc.Status.AccessModes
+	}
+	fmt.Fprintf(&sb, "Access Modes: %s\n", accessModesToString(accessModes))
 	if pvc.Spec.StorageClassName != nil {
 		fmt.Fprintf(&sb, "Storage Class: %s\n", *pvc.Spec.StorageClassName)
 	}
-	if pvc.Spec.VolumeName != "" {
-		fmt.Fprintf(&sb, "Bound Volume: %s\n", pvc.Spec.VolumeName)
-	}
 	if pvc.Spec.VolumeMode != nil {
 		fmt.Fprintf(&sb, "Volume Mode: %s\n", *pvc.Spec.VolumeMode)
 	}
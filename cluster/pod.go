@@ -5,15 +5,25 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/basebandit/kai"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/retry"
 )
 
+// maxLogBytes caps how much log output a single tool call returns.
+const maxLogBytes = 100 * 1024
+
+// listSummaryPageSize is how many pods a list summarized under the cluster
+// manager's ListSummaryThreshold still shows.
+const listSummaryPageSize = 50
+
 type Pod struct {
 	Name             string
 	Image            string
@@ -29,17 +39,41 @@ type Pod struct {
 	NodeSelector     map[string]interface{}
 	Labels           map[string]interface{}
 	Env              map[string]interface{}
+	// Requests and Limits set the resources of the single container.
+	Requests map[string]interface{}
+	Limits   map[string]interface{}
+	// Containers, when set, replaces the single container described by
+	// Image and the fields around it.
+	Containers []kai.ContainerSpec
+	// ActiveDeadlineSeconds bounds how long the pod may run before the
+	// kubelet kills it and marks it Failed.
+	ActiveDeadlineSeconds *int64
+	DryRun                bool
 }
 
 // Create creates a new pod in the cluster
 func (p *Pod) Create(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
 
-	if p.Image == "" {
-		return result, fmt.Errorf("failed to create pod: image cannot be empty")
+	var containers []corev1.Container
+	if len(p.Containers) > 0 {
+		var err error
+		if containers, err = buildContainers(p.Containers); err != nil {
+			return result, fmt.Errorf("failed to create pod: %w", err)
+		}
+	} else {
+		if p.Image == "" {
+			return result, fmt.Errorf("failed to create pod: image cannot be empty")
+		}
+		container := p.container()
+		var err error
+		if container.Resources, err = containerResources(p.Requests, p.Limits); err != nil {
+			return result, fmt.Errorf("failed to create pod: %w", err)
+		}
+		containers = []corev1.Container{container}
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -74,6 +108,68 @@ func (p *Pod) Create(ctx context.Context, cm kai.ClusterManager) (string, error)
 		}
 	}
 
+	pod.Spec.Containers = containers
+
+	// Set restart policy if specified
+	if p.RestartPolicy != "" {
+		policyMap := map[string]corev1.RestartPolicy{
+			"Always":    corev1.RestartPolicyAlways,
+			"OnFailure": corev1.RestartPolicyOnFailure,
+			"Never":     corev1.RestartPolicyNever,
+		}
+		if policy, ok := policyMap[p.RestartPolicy]; ok {
+			pod.Spec.RestartPolicy = policy
+		}
+	}
+
+	pod.Spec.ActiveDeadlineSeconds = p.ActiveDeadlineSeconds
+
+	// Set service account if specified
+	if p.ServiceAccount != "" {
+		pod.Spec.ServiceAccountName = p.ServiceAccount
+	}
+
+	// Set node selector if specified
+	if p.NodeSelector != nil {
+		nodeSelector := make(map[string]string)
+		for k, v := range p.NodeSelector {
+			if strVal, ok := v.(string); ok {
+				nodeSelector[k] = strVal
+			}
+		}
+		if len(nodeSelector) > 0 {
+			pod.Spec.NodeSelector = nodeSelector
+		}
+	}
+
+	// Set image pull secrets if specified
+	if p.ImagePullSecrets != nil {
+		pullSecrets := make([]corev1.LocalObjectReference, 0, len(p.ImagePullSecrets))
+		for _, v := range p.ImagePullSecrets {
+			if strVal, ok := v.(string); ok && strVal != "" {
+				pullSecrets = append(pullSecrets, corev1.LocalObjectReference{
+					Name: strVal,
+				})
+			}
+		}
+		if len(pullSecrets) > 0 {
+			pod.Spec.ImagePullSecrets = pullSecrets
+		}
+	}
+
+	// Create the pod
+	createdPod, err := client.CoreV1().Pods(p.Namespace).Create(timeoutCtx, pod, metav1.CreateOptions{DryRun: dryRunOption(p.DryRun)})
+	if err != nil {
+		return result, fmt.Errorf("failed to create pod: %w", err)
+	}
+
+	result = fmt.Sprintf("Pod %q created successfully in namespace %q", createdPod.Name, createdPod.Namespace)
+	return dryRunResult(result, p.DryRun), nil
+}
+
+// container builds the pod's only container from the single-container
+// fields.
+func (p *Pod) container() corev1.Container {
 	container := corev1.Container{
 		Name:  p.ContainerName,
 		Image: p.Image,
@@ -159,67 +255,12 @@ func (p *Pod) Create(ctx context.Context, cm kai.ClusterManager) (string, error)
 		}
 	}
 
-	// Add the container to the pod
-	pod.Spec.Containers = []corev1.Container{container}
-
-	// Set restart policy if specified
-	if p.RestartPolicy != "" {
-		policyMap := map[string]corev1.RestartPolicy{
-			"Always":    corev1.RestartPolicyAlways,
-			"OnFailure": corev1.RestartPolicyOnFailure,
-			"Never":     corev1.RestartPolicyNever,
-		}
-		if policy, ok := policyMap[p.RestartPolicy]; ok {
-			pod.Spec.RestartPolicy = policy
-		}
-	}
-
-	// Set service account if specified
-	if p.ServiceAccount != "" {
-		pod.Spec.ServiceAccountName = p.ServiceAccount
-	}
-
-	// Set node selector if specified
-	if p.NodeSelector != nil {
-		nodeSelector := make(map[string]string)
-		for k, v := range p.NodeSelector {
-			if strVal, ok := v.(string); ok {
-				nodeSelector[k] = strVal
-			}
-		}
-		if len(nodeSelector) > 0 {
-			pod.Spec.NodeSelector = nodeSelector
-		}
-	}
-
-	// Set image pull secrets if specified
-	if p.ImagePullSecrets != nil {
-		pullSecrets := make([]corev1.LocalObjectReference, 0, len(p.ImagePullSecrets))
-		for _, v := range p.ImagePullSecrets {
-			if strVal, ok := v.(string); ok && strVal != "" {
-				pullSecrets = append(pullSecrets, corev1.LocalObjectReference{
-					Name: strVal,
-				})
-			}
-		}
-		if len(pullSecrets) > 0 {
-			pod.Spec.ImagePullSecrets = pullSecrets
-		}
-	}
-
-	// Create the pod
-	createdPod, err := client.CoreV1().Pods(p.Namespace).Create(timeoutCtx, pod, metav1.CreateOptions{})
-	if err != nil {
-		return result, fmt.Errorf("failed to create pod: %w", err)
-	}
-
-	result = fmt.Sprintf("Pod %q created successfully in namespace %q", createdPod.Name, createdPod.Namespace)
-	return result, nil
+	return container
 }
 
 func (p *Pod) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, err
 	}
@@ -227,14 +268,14 @@ func (p *Pod) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	// Verify the namespace exists
 	_, err = client.CoreV1().Namespaces().Get(ctx, p.Namespace, metav1.GetOptions{})
 	if err != nil {
-		return result, fmt.Errorf("namespace '%s' not found: %v", p.Namespace, err)
+		return result, fmt.Errorf("namespace '%s' not found: %w", p.Namespace, err)
 	}
 
 	// Use retry for potential transient issues
 	var pod *corev1.Pod
 	err = retry.OnError(retry.DefaultRetry, func(err error) bool {
 		// Only retry on transient errors
-		return !strings.Contains(err.Error(), "not found")
+		return !apierrors.IsNotFound(err)
 	}, func() error {
 		var getErr error
 		pod, getErr = client.CoreV1().Pods(p.Namespace).Get(ctx, p.Name, metav1.GetOptions{})
@@ -242,18 +283,21 @@ func (p *Pod) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	})
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return result, fmt.Errorf("pod '%s' not found in namespace '%s'", p.Name, p.Namespace)
+		if apierrors.IsNotFound(err) {
+			return result, fmt.Errorf("pod '%s' not found in namespace '%s': %w", p.Name, p.Namespace, err)
 		}
-		return result, fmt.Errorf("failed to get pod '%s' in namespace '%s': %v", p.Name, p.Namespace, err)
+		return result, fmt.Errorf("failed to get pod '%s' in namespace '%s': %w", p.Name, p.Namespace, err)
 	}
 
 	return formatPod(pod), nil
 }
 
-func (p *Pod) List(ctx context.Context, cm kai.ClusterManager, limit int64, labelSelector, fieldSelector string) (string, error) {
+// List lists pods in p.Namespace, or in every namespace when it is empty. A
+// limit returns one page; the continue token needed for the next page is
+// included in the output.
+func (p *Pod) List(ctx context.Context, cm kai.ClusterManager, limit int64, continueToken, labelSelector, fieldSelector string) (string, error) {
 	var result string
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, nil
 	}
@@ -261,6 +305,7 @@ func (p *Pod) List(ctx context.Context, cm kai.ClusterManager, limit int64, labe
 	listOptions := metav1.ListOptions{
 		LabelSelector: labelSelector,
 		FieldSelector: fieldSelector,
+		Continue:      continueToken,
 	}
 
 	if limit > 0 {
@@ -286,7 +331,7 @@ func (p *Pod) List(ctx context.Context, cm kai.ClusterManager, limit int64, labe
 		// First verify the namespace exists
 		_, err = client.CoreV1().Namespaces().Get(timeoutCtx, p.Namespace, metav1.GetOptions{})
 		if err != nil {
-			return result, fmt.Errorf("namespace %q not found: %v", p.Namespace, err)
+			return result, fmt.Errorf("namespace %q not found: %w", p.Namespace, err)
 		}
 
 		pods, listErr = client.CoreV1().Pods(p.Namespace).List(timeoutCtx, listOptions)
@@ -294,7 +339,7 @@ func (p *Pod) List(ctx context.Context, cm kai.ClusterManager, limit int64, labe
 	}
 
 	if listErr != nil {
-		return result, fmt.Errorf("failed to list pods: %v", listErr)
+		return result, fmt.Errorf("failed to list pods: %w", listErr)
 	}
 
 	if len(pods.Items) == 0 {
@@ -304,15 +349,68 @@ func (p *Pod) List(ctx context.Context, cm kai.ClusterManager, limit int64, labe
 		return result, errors.New("no pods found")
 	}
 
-	return formatPodList(pods, allNamespaces, limit, resultText), nil
+	if threshold := cm.ListSummaryThreshold(); limit <= 0 && threshold > 0 && len(pods.Items) > threshold {
+		// A threshold below the page size shrinks the page with it, so
+		// the summary never shows more pods than the threshold allows.
+		shown := min(listSummaryPageSize, threshold)
+		resultText = fmt.Sprintf("%d pods match; showing first %d, pass limit to see more\n\n", len(pods.Items), shown) + resultText
+		pods.Items = pods.Items[:shown]
+		limit = int64(shown)
+	}
+
+	return formatPodList(pods, allNamespaces, limit, resultText) + continueHint(pods.Continue), nil
+}
+
+// lastTermination returns how the previous instance of the named container
+// ended, or nil if it has not restarted.
+// getForLogs fetches the pod whose logs are being read, turning a missing
+// namespace or pod, or a pod that has not run yet, into a readable error.
+func (p *Pod) getForLogs(ctx context.Context, client kubernetes.Interface, previous bool) (*corev1.Pod, error) {
+	// verify the namespace exists
+	_, err := client.CoreV1().Namespaces().Get(ctx, p.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("namespace %q not found: %w", p.Namespace, err)
+	}
+
+	// Get the pod to find its containers and verify it exists
+	pod, err := client.CoreV1().Pods(p.Namespace).Get(ctx, p.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("pod '%s' not found in namespace '%s': %w", p.Name, p.Namespace, err)
+		}
+		return nil, fmt.Errorf("failed to get pod '%s' in namespace '%s': %w", p.Name, p.Namespace, err)
+	}
+
+	// Check if pod is running or has run before
+	if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodSucceeded && !previous {
+		return nil, fmt.Errorf("pod '%s' is in '%s' state. Logs may not be available. Use previous=true for crashed containers",
+			p.Name, pod.Status.Phase)
+	}
+
+	if len(pod.Spec.Containers) == 0 {
+		return nil, fmt.Errorf("no containers found in pod '%s'", p.Name)
+	}
+
+	return pod, nil
+}
+
+func lastTermination(pod *corev1.Pod, container string) *corev1.ContainerStateTerminated {
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.ContainerStatuses, pod.Status.InitContainerStatuses} {
+		for _, cs := range statuses {
+			if cs.Name == container {
+				return cs.LastTerminationState.Terminated
+			}
+		}
+	}
+	return nil
 }
 
 func (p *Pod) Delete(ctx context.Context, cm kai.ClusterManager, force bool) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
-		return result, fmt.Errorf("error: %v", err)
+		return result, fmt.Errorf("error: %w", err)
 	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
@@ -321,13 +419,16 @@ func (p *Pod) Delete(ctx context.Context, cm kai.ClusterManager, force bool) (st
 	// verify namespace exists
 	_, err = client.CoreV1().Namespaces().Get(timeoutCtx, p.Namespace, metav1.GetOptions{})
 	if err != nil {
-		return result, fmt.Errorf("namespace %q not found: %v", p.Namespace, err)
+		return result, fmt.Errorf("namespace %q not found: %w", p.Namespace, err)
 	}
 
 	// verify the pod exists
 	_, err = client.CoreV1().Pods(p.Namespace).Get(timeoutCtx, p.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return result, fmt.Errorf("pod %q not found in namespace %q: %w", p.Name, p.Namespace, err)
+	}
 	if err != nil {
-		return result, fmt.Errorf("pod %q not found in namespace %q", p.Name, p.Namespace)
+		return result, fmt.Errorf("failed to get pod %q in namespace %q: %w", p.Name, p.Namespace, err)
 	}
 
 	deleteOptions := metav1.DeleteOptions{}
@@ -338,7 +439,7 @@ func (p *Pod) Delete(ctx context.Context, cm kai.ClusterManager, force bool) (st
 
 	err = client.CoreV1().Pods(p.Namespace).Delete(timeoutCtx, p.Name, deleteOptions)
 	if err != nil {
-		return result, fmt.Errorf("failed to delete pod %q in namespace %q: %v", p.Name, p.Namespace, err)
+		return result, fmt.Errorf("failed to delete pod %q in namespace %q: %w", p.Name, p.Namespace, err)
 	}
 
 	return fmt.Sprintf("Successfully delete pod %q in namespace %q", p.Name, p.Namespace), nil
@@ -347,37 +448,17 @@ func (p *Pod) Delete(ctx context.Context, cm kai.ClusterManager, force bool) (st
 func (p *Pod) StreamLogs(ctx context.Context, cm kai.ClusterManager, tailLines int64, previous bool, since *time.Duration) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
-		return result, fmt.Errorf("error: %v", err)
+		return result, fmt.Errorf("error: %w", err)
 	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	// verify the namespace exists
-	_, err = client.CoreV1().Namespaces().Get(timeoutCtx, p.Namespace, metav1.GetOptions{})
-	if err != nil {
-		return result, fmt.Errorf("namespace %q not found: %v", p.Namespace, err)
-	}
-
-	// Get the pod to find the container name if not specified and verify pod exists
-	pod, err := client.CoreV1().Pods(p.Namespace).Get(timeoutCtx, p.Name, metav1.GetOptions{})
+	pod, err := p.getForLogs(timeoutCtx, client, previous)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return result, fmt.Errorf("pod '%s' not found in namespace '%s'", p.Name, p.Namespace)
-		}
-		return result, fmt.Errorf("failed to get pod '%s' in namespace '%s': %v", p.Name, p.Namespace, err)
-	}
-
-	// Check if pod is running or has run before
-	if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodSucceeded && !previous {
-		return result, fmt.Errorf("pod '%s' is in '%s' state. Logs may not be available. Use previous=true for crashed containers",
-			p.Name, pod.Status.Phase)
-	}
-
-	if len(pod.Spec.Containers) == 0 {
-		return result, fmt.Errorf("no containers found in pod '%s'", p.Name)
+		return result, err
 	}
 
 	// Set default container if not specified
@@ -405,6 +486,18 @@ func (p *Pod) StreamLogs(ctx context.Context, cm kai.ClusterManager, tailLines i
 			p.ContainerName, p.Name, strings.Join(availableContainers, ", "))
 	}
 
+	// The kubelet only keeps the logs of the instance before the current
+	// one, and only once the container has restarted. Check up front rather
+	// than surface the apiserver's terse 400.
+	var lastExit *corev1.ContainerStateTerminated
+	if previous {
+		lastExit = lastTermination(pod, p.ContainerName)
+		if lastExit == nil {
+			return result, fmt.Errorf("container '%s' in pod '%s' has not restarted, so there is no previous instance to read logs from",
+				p.ContainerName, p.Name)
+		}
+	}
+
 	// Configure log options
 	logOptions := &corev1.PodLogOptions{
 		Container: p.ContainerName,
@@ -420,28 +513,9 @@ func (p *Pod) StreamLogs(ctx context.Context, cm kai.ClusterManager, tailLines i
 		logOptions.SinceSeconds = ptr(int64(since.Seconds()))
 	}
 
-	// Get the logs with retry for transient errors
-	var logsStream io.ReadCloser
-	err = retry.OnError(retry.DefaultRetry, func(err error) bool {
-		// Retry on network errors
-		return !strings.Contains(err.Error(), "not found")
-	}, func() error {
-		logsReq := client.CoreV1().Pods(p.Namespace).GetLogs(p.Name, logOptions)
-		var streamErr error
-		logsStream, streamErr = logsReq.Stream(timeoutCtx)
-		return streamErr
-	})
-
+	logs, err := readPodLogs(timeoutCtx, client, p.Namespace, p.Name, logOptions, maxLogBytes)
 	if err != nil {
-		return result, fmt.Errorf("failed to stream logs: %v", err)
-	}
-	defer func() { _ = logsStream.Close() }()
-
-	// Read the logs with a max size limit to prevent excessive output
-	maxSize := 100 * 1024 // Limit to ~100KB of logs
-	logs, err := io.ReadAll(io.LimitReader(logsStream, int64(maxSize)))
-	if err != nil {
-		return result, fmt.Errorf("failed to read logs: %v", err)
+		return result, err
 	}
 
 	if len(logs) == 0 {
@@ -454,7 +528,11 @@ func (p *Pod) StreamLogs(ctx context.Context, cm kai.ClusterManager, tailLines i
 	// Build the result
 	options := []string{}
 	if previous {
-		options = append(options, "previous=true")
+		exit := fmt.Sprintf("previous=true, exit code %d", lastExit.ExitCode)
+		if lastExit.Reason != "" {
+			exit += ": " + lastExit.Reason
+		}
+		options = append(options, exit)
 	}
 	if tailLines > 0 {
 		options = append(options, fmt.Sprintf("tail=%d", tailLines))
@@ -471,9 +549,140 @@ func (p *Pod) StreamLogs(ctx context.Context, cm kai.ClusterManager, tailLines i
 	result += string(logs)
 
 	// Check if we reached the size limit
-	if len(logs) == maxSize {
+	if len(logs) == maxLogBytes {
 		result += "\n\n[Output truncated due to size limits. Use the 'tail' or 'since' parameters to view specific sections of logs.]"
 	}
 
 	return result, nil
 }
+
+const (
+	// defaultSearchTailLines bounds how much recent history search_logs scans
+	// when no tail is given.
+	defaultSearchTailLines = 5000
+	maxSearchMatches       = 200
+)
+
+// SearchLogs returns the lines of a container's recent logs that match
+// pattern, with up to before/after lines of surrounding context. Output
+// follows grep -n conventions: "N:" marks a match and "N-" a context line.
+func (p *Pod) SearchLogs(ctx context.Context, cm kai.ClusterManager, pattern string, before, after int, tailLines int64) (string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid pattern %q: %v", pattern, err)
+	}
+	if before < 0 || after < 0 {
+		return "", errors.New("context line counts must not be negative")
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	pod, err := client.CoreV1().Pods(p.Namespace).Get(timeoutCtx, p.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get pod '%s' in namespace '%s': %w", p.Name, p.Namespace, err)
+	}
+	if len(pod.Spec.Containers) == 0 {
+		return "", fmt.Errorf("no containers found in pod '%s'", p.Name)
+	}
+
+	container := p.ContainerName
+	if container == "" {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	if tailLines <= 0 {
+		tailLines = defaultSearchTailLines
+	}
+	logOptions := &corev1.PodLogOptions{
+		Container: container,
+		TailLines: &tailLines,
+	}
+
+	logs, err := readPodLogs(timeoutCtx, client, p.Namespace, p.Name, logOptions, 10*maxLogBytes)
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(strings.TrimRight(string(logs), "\n"), "\n")
+	matches, output := grepLines(lines, re, before, after, maxSearchMatches)
+	if matches == 0 {
+		return fmt.Sprintf("No lines matching %q in container '%s' of pod '%s/%s' (searched last %d lines)",
+			pattern, container, p.Namespace, p.Name, tailLines), nil
+	}
+
+	result := fmt.Sprintf("%d line(s) matching %q in container '%s' of pod '%s/%s':\n\n",
+		matches, pattern, container, p.Namespace, p.Name)
+	if len(output) > maxLogBytes {
+		output = output[:maxLogBytes] + "\n\n[Output truncated due to size limits. Narrow the pattern or reduce context lines.]"
+	} else if matches == maxSearchMatches {
+		output += fmt.Sprintf("\n\n[Stopped after %d matches. Narrow the pattern to see more.]", maxSearchMatches)
+	}
+	return result + output, nil
+}
+
+// grepLines returns the number of lines matching re (up to maxMatches) and
+// the matches with their context, groups separated by "--".
+func grepLines(lines []string, re *regexp.Regexp, before, after, maxMatches int) (int, string) {
+	var sb strings.Builder
+	matches := 0
+	lastPrinted := -1
+	for i := 0; i < len(lines) && matches < maxMatches; i++ {
+		if !re.MatchString(lines[i]) {
+			continue
+		}
+		matches++
+
+		start := max(i-before, lastPrinted+1)
+		if lastPrinted >= 0 && start > lastPrinted+1 {
+			sb.WriteString("--\n")
+		}
+		for j := start; j < i; j++ {
+			fmt.Fprintf(&sb, "%d-%s\n", j+1, lines[j])
+		}
+		fmt.Fprintf(&sb, "%d:%s\n", i+1, lines[i])
+		lastPrinted = i
+
+		// Emit trailing context until the next match, which the outer
+		// loop handles so it is counted and marked as a match.
+		for j := i + 1; j <= i+after && j < len(lines); j++ {
+			if re.MatchString(lines[j]) {
+				break
+			}
+			fmt.Fprintf(&sb, "%d-%s\n", j+1, lines[j])
+			lastPrinted = j
+		}
+	}
+	return matches, strings.TrimRight(sb.String(), "\n")
+}
+
+// readPodLogs fetches logs for a pod, retrying transient errors, and reads
+// at most maxSize bytes.
+func readPodLogs(ctx context.Context, client kubernetes.Interface, namespace, name string, logOptions *corev1.PodLogOptions, maxSize int) ([]byte, error) {
+	var logsStream io.ReadCloser
+	err := retry.OnError(retry.DefaultRetry, func(err error) bool {
+		// Retry on network errors
+		return !strings.Contains(err.Error(), "not found")
+	}, func() error {
+		logsReq := client.CoreV1().Pods(namespace).GetLogs(name, logOptions)
+		var streamErr error
+		logsStream, streamErr = logsReq.Stream(ctx)
+		return streamErr
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream logs: %w", err)
+	}
+	defer func() { _ = logsStream.Close() }()
+
+	logs, err := io.ReadAll(io.LimitReader(logsStream, int64(maxSize)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logs: %w", err)
+	}
+	return logs, nil
+}
@@ -2,13 +2,18 @@ package cluster
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/testmocks"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 )
@@ -34,8 +39,12 @@ func TestPodOperations(t *testing.T) {
 	t.Run("CreatePod", testCreatePods)
 	t.Run("GetPod", testGetPod)
 	t.Run("ListPods", testListPods)
+	t.Run("ListPodsSummary", testListPodsSummary)
 	t.Run("DeletePod", testDeletePod)
 	t.Run("StreamPodLogs", testStreamPodLogs)
+	t.Run("SearchPodLogs", testSearchPodLogs)
+	t.Run("StreamAllPodLogs", testStreamAllPodLogs)
+	t.Run("LogsAcrossRestarts", testLogsAcrossRestarts)
 }
 
 func testCreatePods(t *testing.T) {
@@ -72,6 +81,30 @@ func testCreatePods(t *testing.T) {
 				assert.Equal(t, nginxImage, pod.Spec.Containers[0].Image)
 			},
 		},
+		{
+			name: "Create pod with resources",
+			pod: &Pod{
+				Name:      "sized-pod",
+				Namespace: testNamespace,
+				Image:     nginxImage,
+				Requests:  map[string]interface{}{"cpu": "250m", "memory": "256Mi"},
+				Limits:    map[string]interface{}{"cpu": "1", "memory": "512Mi"},
+			},
+			setupMock: func(mockCM *testmocks.MockClusterManager) {
+				fakeClient := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}})
+				mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+			},
+			expectedResult: "created successfully",
+			validateCreate: func(t *testing.T, client kubernetes.Interface) {
+				pod, err := client.CoreV1().Pods(testNamespace).Get(ctx, "sized-pod", metav1.GetOptions{})
+				assert.NoError(t, err)
+				resources := pod.Spec.Containers[0].Resources
+				assert.Equal(t, "250m", resources.Requests.Cpu().String())
+				assert.Equal(t, "256Mi", resources.Requests.Memory().String())
+				assert.Equal(t, "1", resources.Limits.Cpu().String())
+				assert.Equal(t, "512Mi", resources.Limits.Memory().String())
+			},
+		},
 		{
 			name: "Create pod with custom container name",
 			pod: &Pod{
@@ -363,6 +396,48 @@ func testCreatePods(t *testing.T) {
 				assert.Equal(t, testServiceAccount, pod.Spec.ServiceAccountName)
 			},
 		},
+		{
+			name: "Create pod with sidecar container",
+			pod: &Pod{
+				Name:      "sidecar-pod",
+				Namespace: testNamespace,
+				Containers: []kai.ContainerSpec{
+					{Name: "app", Image: nginxImage, Ports: []string{"80"}, Limits: map[string]interface{}{"memory": "256Mi"}},
+					{Name: "log-shipper", Image: "fluent/fluent-bit:3.0", Args: []interface{}{"-c", "/etc/fluent-bit.conf"}},
+				},
+			},
+			setupMock: func(mockCM *testmocks.MockClusterManager) {
+				ns := &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: testNamespace},
+				}
+				fakeClient := fake.NewSimpleClientset(ns)
+				mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+			},
+			expectedResult: "created successfully",
+			validateCreate: func(t *testing.T, client kubernetes.Interface) {
+				pod, err := client.CoreV1().Pods(testNamespace).Get(ctx, "sidecar-pod", metav1.GetOptions{})
+				assert.NoError(t, err)
+				if assert.Len(t, pod.Spec.Containers, 2) {
+					assert.Equal(t, "app", pod.Spec.Containers[0].Name)
+					assert.Equal(t, int32(80), pod.Spec.Containers[0].Ports[0].ContainerPort)
+					assert.Equal(t, "256Mi", pod.Spec.Containers[0].Resources.Limits.Memory().String())
+					assert.Equal(t, "log-shipper", pod.Spec.Containers[1].Name)
+					assert.Equal(t, []string{"-c", "/etc/fluent-bit.conf"}, pod.Spec.Containers[1].Args)
+				}
+			},
+		},
+		{
+			name: "Container with invalid quantity",
+			pod: &Pod{
+				Name:      "bad-quantity-pod",
+				Namespace: testNamespace,
+				Containers: []kai.ContainerSpec{
+					{Name: "app", Image: nginxImage, Requests: map[string]interface{}{"cpu": "lots"}},
+				},
+			},
+			setupMock:     func(mockCM *testmocks.MockClusterManager) {},
+			expectedError: `container "app" requests: invalid quantity lots for cpu`,
+		},
 		{
 			name: "Missing image",
 			pod: &Pod{
@@ -644,7 +719,7 @@ func testListPods(t *testing.T) {
 			mockCM := testmocks.NewMockClusterManager()
 			tc.setupMock(mockCM)
 
-			result, err := tc.pod.List(ctx, mockCM, tc.limit, tc.labelSelector, tc.fieldSelector)
+			result, err := tc.pod.List(ctx, mockCM, tc.limit, "", tc.labelSelector, tc.fieldSelector)
 
 			if tc.expectedError != "" {
 				assert.Error(t, err)
@@ -666,6 +741,76 @@ func testListPods(t *testing.T) {
 	}
 }
 
+func testListPodsSummary(t *testing.T) {
+	ctx := context.Background()
+
+	newClient := func(count int) *fake.Clientset {
+		objects := []runtime.Object{&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}}
+		for i := 0; i < count; i++ {
+			objects = append(objects, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("pod-%03d", i),
+				Namespace: testNamespace,
+			}})
+		}
+		return fake.NewSimpleClientset(objects...)
+	}
+
+	newClusterManager := func(threshold, count int) *testmocks.MockClusterManager {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.SetListSummaryThreshold(threshold)
+		mockCM.On("GetCurrentClient").Return(newClient(count), nil)
+		return mockCM
+	}
+
+	t.Run("AboveThreshold", func(t *testing.T) {
+		mockCM := newClusterManager(60, 75)
+
+		result, err := (&Pod{Namespace: testNamespace}).List(ctx, mockCM, 0, "", "", "")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(result, "75 pods match; showing first 50, pass limit to see more"))
+		assert.Contains(t, result, "Total: 50 pod(s) (limited to 50 results)")
+		assert.Equal(t, 50, strings.Count(result, "• pod-"))
+	})
+
+	t.Run("ThresholdBelowPageSize", func(t *testing.T) {
+		mockCM := newClusterManager(10, 30)
+
+		result, err := (&Pod{Namespace: testNamespace}).List(ctx, mockCM, 0, "", "", "")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(result, "30 pods match; showing first 10, pass limit to see more"))
+		assert.Equal(t, 10, strings.Count(result, "• pod-"))
+	})
+
+	t.Run("BelowThreshold", func(t *testing.T) {
+		mockCM := newClusterManager(60, 55)
+
+		result, err := (&Pod{Namespace: testNamespace}).List(ctx, mockCM, 0, "", "", "")
+		assert.NoError(t, err)
+		assert.NotContains(t, result, "pods match")
+		assert.Contains(t, result, "Total: 55 pod(s)")
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		mockCM := newClusterManager(0, 75)
+
+		result, err := (&Pod{Namespace: testNamespace}).List(ctx, mockCM, 0, "", "", "")
+		assert.NoError(t, err)
+		assert.NotContains(t, result, "pods match")
+		assert.Contains(t, result, "Total: 75 pod(s)")
+	})
+
+	t.Run("ExplicitLimit", func(t *testing.T) {
+		mockCM := newClusterManager(60, 75)
+
+		// The fake clientset ignores Limit, so every pod comes back; the
+		// summary must still stay out of the way when a limit was given.
+		result, err := (&Pod{Namespace: testNamespace}).List(ctx, mockCM, 100, "", "", "")
+		assert.NoError(t, err)
+		assert.NotContains(t, result, "pods match")
+		assert.Contains(t, result, "Total: 75 pod(s)")
+	})
+}
+
 func testDeletePod(t *testing.T) {
 	ctx := context.Background()
 
@@ -821,6 +966,31 @@ func testStreamPodLogs(t *testing.T) {
 		},
 	}
 
+	crashedPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "crashed-pod",
+			Namespace: testNamespace,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "container1"},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "container1",
+				RestartCount: 4,
+				State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+				},
+				LastTerminationState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"},
+				},
+			}},
+		},
+	}
+
 	podWithNoContainers := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "no-container-pod",
@@ -831,13 +1001,14 @@ func testStreamPodLogs(t *testing.T) {
 	}
 
 	testCases := []struct {
-		name          string
-		pod           *Pod
-		tailLines     int64
-		previous      bool
-		since         *time.Duration
-		setupMock     func(*testmocks.MockClusterManager)
-		expectedError string
+		name           string
+		pod            *Pod
+		tailLines      int64
+		previous       bool
+		since          *time.Duration
+		setupMock      func(*testmocks.MockClusterManager)
+		expectedError  string
+		expectedResult string
 	}{
 		{
 			name: "Pod not found",
@@ -901,6 +1072,40 @@ func testStreamPodLogs(t *testing.T) {
 			},
 			expectedError: "container 'nonexistent-container' not found",
 		},
+		{
+			name: "Previous without a restart",
+			pod: &Pod{
+				Name:          "running-pod",
+				Namespace:     testNamespace,
+				ContainerName: "container1",
+			},
+			previous: true,
+			setupMock: func(mockCM *testmocks.MockClusterManager) {
+				ns := &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: testNamespace},
+				}
+				fakeClient := fake.NewSimpleClientset(runningPod, ns)
+				mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+			},
+			expectedError: "has not restarted, so there is no previous instance",
+		},
+		{
+			name: "Previous after a crash",
+			pod: &Pod{
+				Name:          "crashed-pod",
+				Namespace:     testNamespace,
+				ContainerName: "container1",
+			},
+			previous: true,
+			setupMock: func(mockCM *testmocks.MockClusterManager) {
+				ns := &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: testNamespace},
+				}
+				fakeClient := fake.NewSimpleClientset(crashedPod, ns)
+				mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+			},
+			expectedResult: "(previous=true, exit code 1: Error)",
+		},
 		{
 			name: "Pod has no containers",
 			pod: &Pod{
@@ -924,14 +1129,214 @@ func testStreamPodLogs(t *testing.T) {
 			mockCM := testmocks.NewMockClusterManager()
 			tc.setupMock(mockCM)
 
-			_, err := tc.pod.StreamLogs(ctx, mockCM, tc.tailLines, tc.previous, tc.since)
+			result, err := tc.pod.StreamLogs(ctx, mockCM, tc.tailLines, tc.previous, tc.since)
 
 			if tc.expectedError != "" {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tc.expectedError)
 			}
+			if tc.expectedResult != "" {
+				assert.NoError(t, err)
+				assert.Contains(t, result, tc.expectedResult)
+			}
 
 			mockCM.AssertExpectations(t)
 		})
 	}
 }
+
+func testStreamAllPodLogs(t *testing.T) {
+	ctx := context.Background()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+	multiPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "multi-pod", Namespace: testNamespace},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "migrate"}},
+			Containers:     []corev1.Container{{Name: "app"}, {Name: "proxy"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "proxy",
+				LastTerminationState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"},
+				},
+			}},
+		},
+	}
+
+	run := func(t *testing.T, target *corev1.Pod, previous, includeInit bool) (string, error) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(ns, target), nil)
+		pod := &Pod{Name: "multi-pod", Namespace: testNamespace}
+		return pod.StreamAllLogs(ctx, mockCM, 0, previous, nil, includeInit)
+	}
+
+	t.Run("PrefixesEachContainer", func(t *testing.T) {
+		result, err := run(t, multiPod, false, false)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Logs from 2 container(s) in pod 'test-namespace/multi-pod':")
+		assert.Contains(t, result, "[app] fake logs\n[proxy] fake logs")
+		assert.NotContains(t, result, "[migrate]")
+	})
+
+	t.Run("IncludeInit", func(t *testing.T) {
+		result, err := run(t, multiPod, false, true)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "(include_init=true)")
+		assert.Contains(t, result, "[migrate] fake logs\n[app] fake logs\n[proxy] fake logs")
+	})
+
+	t.Run("PreviousOnlyRestarted", func(t *testing.T) {
+		result, err := run(t, multiPod, true, false)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "[proxy] fake logs")
+		assert.Contains(t, result, "[app] <has not restarted, no previous logs>")
+	})
+
+	t.Run("PreviousNoneRestarted", func(t *testing.T) {
+		healthy := multiPod.DeepCopy()
+		healthy.Status.ContainerStatuses = nil
+		_, err := run(t, healthy, true, false)
+		assert.ErrorContains(t, err, "no container in pod 'multi-pod' has restarted")
+	})
+}
+
+func testLogsAcrossRestarts(t *testing.T) {
+	ctx := context.Background()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+	crashing := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "crashing-pod", Namespace: testNamespace},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "app",
+				RestartCount: 3,
+				LastTerminationState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"},
+				},
+			}},
+		},
+	}
+
+	run := func(t *testing.T, target *corev1.Pod, container string) (string, error) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(ns, target), nil)
+		pod := &Pod{Name: target.Name, Namespace: testNamespace, ContainerName: container}
+		return pod.LogsAcrossRestarts(ctx, mockCM, 0)
+	}
+
+	t.Run("WithPreviousInstance", func(t *testing.T) {
+		result, err := run(t, crashing, "")
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Logs from container 'app' in pod 'test-namespace/crashing-pod' across restarts (restart count 3):")
+		assert.Contains(t, result, "===== previous instance (exit code 1: Error) =====\nfake logs")
+		assert.Contains(t, result, "===== current instance =====\nfake logs")
+	})
+
+	t.Run("WithoutPreviousInstance", func(t *testing.T) {
+		healthy := crashing.DeepCopy()
+		healthy.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "app"}}
+		result, err := run(t, healthy, "app")
+		assert.NoError(t, err)
+		assert.Contains(t, result, "restart count 0")
+		assert.Contains(t, result, "<container has not restarted, so there is no previous instance>")
+		assert.Contains(t, result, "===== current instance =====\nfake logs")
+	})
+
+	t.Run("UnknownContainer", func(t *testing.T) {
+		_, err := run(t, crashing, "sidecar")
+		assert.ErrorContains(t, err, "container 'sidecar' not found")
+	})
+}
+
+func TestTimestampedLines(t *testing.T) {
+	lines := timestampedLines("app", "2024-01-01T00:00:02Z second\ncontinued\n2024-01-01T00:00:01.5Z first\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "second", lines[0].text)
+	assert.Equal(t, "continued", lines[1].text)
+	assert.Equal(t, lines[0].at, lines[1].at)
+	assert.Equal(t, "first", lines[2].text)
+	assert.True(t, lines[2].at.Before(lines[0].at))
+}
+
+func testSearchPodLogs(t *testing.T) {
+	ctx := context.Background()
+
+	runningPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "running-pod", Namespace: testNamespace},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "container1"}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(runningPod), nil)
+
+		pod := &Pod{Name: "running-pod", Namespace: testNamespace}
+		result, err := pod.SearchLogs(ctx, mockCM, "fake", 0, 0, 0)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "1 line(s) matching")
+		assert.Contains(t, result, "1:fake logs")
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(runningPod), nil)
+
+		pod := &Pod{Name: "running-pod", Namespace: testNamespace}
+		result, err := pod.SearchLogs(ctx, mockCM, "panic", 0, 0, 0)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "No lines matching")
+	})
+
+	t.Run("InvalidPattern", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+
+		pod := &Pod{Name: "running-pod", Namespace: testNamespace}
+		_, err := pod.SearchLogs(ctx, mockCM, "(unclosed", 0, 0, 0)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid pattern")
+	})
+}
+
+func TestGrepLines(t *testing.T) {
+	lines := []string{
+		"starting",
+		"GET /health 200",
+		"ERROR db timeout",
+		"retrying",
+		"GET /health 200",
+		"GET /health 200",
+		"GET /health 200",
+		"ERROR db timeout",
+		"shutting down",
+	}
+	re := regexp.MustCompile("ERROR")
+
+	t.Run("ContextLines", func(t *testing.T) {
+		matches, output := grepLines(lines, re, 1, 1, 10)
+
+		assert.Equal(t, 2, matches)
+		assert.Equal(t, "2-GET /health 200\n3:ERROR db timeout\n4-retrying\n--\n7-GET /health 200\n8:ERROR db timeout\n9-shutting down", output)
+	})
+
+	t.Run("OverlappingContext", func(t *testing.T) {
+		matches, output := grepLines(lines, re, 3, 3, 10)
+
+		assert.Equal(t, 2, matches)
+		assert.NotContains(t, output, "--")
+		assert.Contains(t, output, "6-GET /health 200\n7-GET /health 200\n8:ERROR db timeout")
+	})
+
+	t.Run("MaxMatches", func(t *testing.T) {
+		matches, output := grepLines(lines, re, 0, 0, 1)
+
+		assert.Equal(t, 1, matches)
+		assert.Equal(t, "3:ERROR db timeout", output)
+	})
+}
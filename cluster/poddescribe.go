@@ -0,0 +1,234 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxPodEvents caps the events shown by Describe.
+const maxPodEvents = 15
+
+// Describe returns a kubectl describe style view of the pod: placement, QoS
+// class, per-container state with restarts and the last termination, init
+// containers, conditions, volumes and recent events.
+func (p *Pod) Describe(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := p.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	pod, err := client.CoreV1().Pods(namespace).Get(timeoutCtx, p.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("pod %q not found in namespace %q", p.Name, namespace)
+		}
+		return "", fmt.Errorf("failed to get pod %q: %w", p.Name, err)
+	}
+
+	events, err := objectEvents(timeoutCtx, client, namespace, "Pod", p.Name, maxPodEvents)
+	if err != nil {
+		return "", err
+	}
+
+	return formatPodDetailed(pod, events), nil
+}
+
+func formatPodDetailed(pod *corev1.Pod, events []corev1.Event) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Name: %s\n", pod.Name)
+	fmt.Fprintf(&sb, "Namespace: %s\n", pod.Namespace)
+	node := pod.Spec.NodeName
+	if node == "" {
+		node = "<none>"
+	} else if pod.Status.HostIP != "" {
+		node += "/" + pod.Status.HostIP
+	}
+	fmt.Fprintf(&sb, "Node: %s\n", node)
+	if pod.Status.StartTime != nil {
+		fmt.Fprintf(&sb, "Start Time: %s\n", pod.Status.StartTime.Time.Format(time.RFC3339))
+	}
+	fmt.Fprintf(&sb, "Labels: %s\n", formatLabelSet(pod.Labels))
+
+	status := string(pod.Status.Phase)
+	if pod.DeletionTimestamp != nil {
+		status = "Terminating"
+	}
+	fmt.Fprintf(&sb, "Status: %s\n", status)
+	if pod.Status.Reason != "" {
+		fmt.Fprintf(&sb, "Reason: %s\n", pod.Status.Reason)
+	}
+	if pod.Status.Message != "" {
+		fmt.Fprintf(&sb, "Message: %s\n", pod.Status.Message)
+	}
+	fmt.Fprintf(&sb, "IP: %s\n", valueOrNone(pod.Status.PodIP))
+	fmt.Fprintf(&sb, "QoS Class: %s\n", valueOrNone(string(pod.Status.QOSClass)))
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		fmt.Fprintf(&sb, "Controlled By: %s/%s\n", owner.Kind, owner.Name)
+	}
+	if pod.Spec.ServiceAccountName != "" {
+		fmt.Fprintf(&sb, "Service Account: %s\n", pod.Spec.ServiceAccountName)
+	}
+
+	if len(pod.Spec.InitContainers) > 0 {
+		sb.WriteString("\nInit Containers:\n")
+		for _, c := range pod.Spec.InitContainers {
+			writeContainerDetail(&sb, c, findContainerStatus(pod.Status.InitContainerStatuses, c.Name))
+		}
+	}
+
+	sb.WriteString("\nContainers:\n")
+	for _, c := range pod.Spec.Containers {
+		writeContainerDetail(&sb, c, findContainerStatus(pod.Status.ContainerStatuses, c.Name))
+	}
+
+	if len(pod.Status.Conditions) > 0 {
+		sb.WriteString("\nConditions:\n")
+		for _, c := range pod.Status.Conditions {
+			fmt.Fprintf(&sb, "• %s: %s", c.Type, c.Status)
+			if c.Reason != "" {
+				fmt.Fprintf(&sb, " (%s)", c.Reason)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(pod.Spec.Volumes) > 0 {
+		sb.WriteString("\nVolumes:\n")
+		for _, v := range pod.Spec.Volumes {
+			fmt.Fprintf(&sb, "• %s: %s\n", v.Name, formatVolumeSource(v.VolumeSource))
+		}
+	}
+
+	if len(events) == 0 {
+		sb.WriteString("\nEvents: <none>")
+	} else {
+		sb.WriteString("\n")
+		sb.WriteString(formatEventList(&corev1.EventList{Items: events}, false))
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func writeContainerDetail(sb *strings.Builder, c corev1.Container, status *corev1.ContainerStatus) {
+	fmt.Fprintf(sb, "  %s:\n", c.Name)
+	fmt.Fprintf(sb, "    Image: %s\n", c.Image)
+	if len(c.Ports) > 0 {
+		ports := make([]string, 0, len(c.Ports))
+		for _, port := range c.Ports {
+			protocol := port.Protocol
+			if protocol == "" {
+				protocol = corev1.ProtocolTCP
+			}
+			ports = append(ports, fmt.Sprintf("%d/%s", port.ContainerPort, protocol))
+		}
+		fmt.Fprintf(sb, "    Ports: %s\n", strings.Join(ports, ", "))
+	}
+
+	if status == nil {
+		sb.WriteString("    State: <unknown>\n")
+	} else {
+		fmt.Fprintf(sb, "    State: %s\n", formatContainerState(status.State))
+		if status.LastTerminationState.Terminated != nil {
+			fmt.Fprintf(sb, "    Last State: %s\n", formatContainerState(status.LastTerminationState))
+		}
+		fmt.Fprintf(sb, "    Ready: %t\n", status.Ready)
+		fmt.Fprintf(sb, "    Restart Count: %d\n", status.RestartCount)
+	}
+
+	if len(c.Resources.Requests) > 0 {
+		fmt.Fprintf(sb, "    Requests: %s\n", formatResourceList(c.Resources.Requests))
+	}
+	if len(c.Resources.Limits) > 0 {
+		fmt.Fprintf(sb, "    Limits: %s\n", formatResourceList(c.Resources.Limits))
+	}
+}
+
+func findContainerStatus(statuses []corev1.ContainerStatus, name string) *corev1.ContainerStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+func formatContainerState(state corev1.ContainerState) string {
+	switch {
+	case state.Running != nil:
+		return fmt.Sprintf("Running (started %s)", state.Running.StartedAt.Time.Format(time.RFC3339))
+	case state.Waiting != nil:
+		s := "Waiting"
+		if state.Waiting.Reason != "" {
+			s += fmt.Sprintf(" (%s)", state.Waiting.Reason)
+		}
+		if state.Waiting.Message != "" {
+			s += ": " + state.Waiting.Message
+		}
+		return s
+	case state.Terminated != nil:
+		t := state.Terminated
+		s := fmt.Sprintf("Terminated (%s, exit code %d", valueOrNone(t.Reason), t.ExitCode)
+		if !t.FinishedAt.IsZero() {
+			s += ", finished " + t.FinishedAt.Time.Format(time.RFC3339)
+		}
+		s += ")"
+		if t.Message != "" {
+			s += ": " + strings.TrimSpace(t.Message)
+		}
+		return s
+	}
+	return "<unknown>"
+}
+
+func formatResourceList(resources corev1.ResourceList) string {
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		q := resources[corev1.ResourceName(name)]
+		parts = append(parts, fmt.Sprintf("%s=%s", name, q.String()))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatLabelSet(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "<none>"
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+labels[k])
+	}
+	return strings.Join(parts, ", ")
+}
+
+func valueOrNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
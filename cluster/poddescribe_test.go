@@ -0,0 +1,139 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPodDescribe(t *testing.T) {
+	const podName = "api-7d9f8"
+	ctx := context.Background()
+	started := metav1.NewTime(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	isController := true
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: testNamespace,
+			Labels:    map[string]string{"app": "api", "tier": "backend"},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "api-7d9f", Controller: &isController},
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:       "worker-1",
+			InitContainers: []corev1.Container{{Name: "migrate", Image: "api:1.4"}},
+			Containers: []corev1.Container{{
+				Name:  "api",
+				Image: "api:1.4",
+				Ports: []corev1.ContainerPort{{ContainerPort: 8080}},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+				},
+			}},
+			Volumes: []corev1.Volume{{
+				Name:         "config",
+				VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "api-config"}}},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodRunning,
+			HostIP:    "10.0.0.5",
+			PodIP:     "10.244.1.7",
+			QOSClass:  corev1.PodQOSBurstable,
+			StartTime: &started,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionFalse, Reason: "ContainersNotReady"},
+			},
+			InitContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "migrate",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Completed", ExitCode: 0}},
+				Ready: true,
+			}},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "api",
+				RestartCount: 4,
+				State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					Reason:   "OOMKilled",
+					ExitCode: 137,
+				}},
+			}},
+		},
+	}
+	podEvent := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: podName + ".1", Namespace: testNamespace},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: podName, Namespace: testNamespace},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "BackOff",
+		Message:        "Back-off restarting failed container api",
+		LastTimestamp:  metav1.Now(),
+	}
+	otherEvent := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "other.1", Namespace: testNamespace},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "other", Namespace: testNamespace},
+		Type:           corev1.EventTypeNormal,
+		Reason:         "Scheduled",
+		Message:        "Successfully assigned",
+		LastTimestamp:  metav1.Now(),
+	}
+
+	fakeClient := fake.NewSimpleClientset(pod, podEvent, otherEvent)
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+	t.Run("Running", func(t *testing.T) {
+		result, err := (&Pod{Name: podName, Namespace: testNamespace}).Describe(ctx, mockCM)
+		require.NoError(t, err)
+
+		for _, want := range []string{
+			"Node: worker-1/10.0.0.5",
+			"Start Time: 2025-03-01T12:00:00Z",
+			"Labels: app=api, tier=backend",
+			"Status: Running",
+			"IP: 10.244.1.7",
+			"QoS Class: Burstable",
+			"Controlled By: ReplicaSet/api-7d9f",
+			"Init Containers:\n  migrate:\n    Image: api:1.4\n    State: Terminated (Completed, exit code 0)",
+			"    Ports: 8080/TCP",
+			"    State: Waiting (CrashLoopBackOff)",
+			"    Last State: Terminated (OOMKilled, exit code 137)",
+			"    Restart Count: 4",
+			"    Requests: cpu=250m",
+			"    Limits: memory=256Mi",
+			"• Ready: False (ContainersNotReady)",
+			"• config: ConfigMap (name: api-config)",
+			"Back-off restarting failed container api",
+		} {
+			assert.Contains(t, result, want)
+		}
+		assert.NotContains(t, result, "Successfully assigned")
+	})
+
+	t.Run("NoEvents", func(t *testing.T) {
+		quiet := pod.DeepCopy()
+		quiet.Name = "quiet"
+		client := fake.NewSimpleClientset(quiet)
+		cm := testmocks.NewMockClusterManager()
+		cm.On("GetCurrentClient").Return(client, nil)
+
+		result, err := (&Pod{Name: "quiet", Namespace: testNamespace}).Describe(ctx, cm)
+		require.NoError(t, err)
+		assert.Contains(t, result, "Events: <none>")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := (&Pod{Name: "missing", Namespace: testNamespace}).Describe(ctx, mockCM)
+		assert.EqualError(t, err, `pod "missing" not found in namespace "test-namespace"`)
+	})
+}
@@ -0,0 +1,88 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodByIP looks up the pod that owns an IP address across all namespaces.
+type PodByIP struct {
+	IP string
+}
+
+// Find returns the pod whose status carries the IP. The list is narrowed
+// with the status.podIP field selector. That selector only sees a pod's
+// primary IP, so when it matches nothing, or the API server rejects it,
+// every pod is scanned instead to catch the secondary IP of a dual-stack
+// pod. Pods on the host network share their node's IP, so more than one
+// pod may match.
+func (p *PodByIP) Find(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	ip := net.ParseIP(p.IP)
+	if ip == nil {
+		return "", fmt.Errorf("invalid IP address %q", p.IP)
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	pods, err := client.CoreV1().Pods("").List(timeoutCtx, metav1.ListOptions{
+		FieldSelector: "status.podIP=" + ip.String(),
+	})
+	if apierrors.IsBadRequest(err) || (err == nil && len(pods.Items) == 0) {
+		pods, err = client.CoreV1().Pods("").List(timeoutCtx, metav1.ListOptions{})
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	// The fallback returns everything, so filter on every IP the pod
+	// reports.
+	var matches []*corev1.Pod
+	for i := range pods.Items {
+		if podHasIP(&pods.Items[i], ip) {
+			matches = append(matches, &pods.Items[i])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no pod found with IP %s", ip)
+	case 1:
+		return formatPod(matches[0]), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d pods have IP %s:\n", len(matches), ip)
+	for _, pod := range matches {
+		fmt.Fprintf(&sb, "• %s/%s\tphase: %s\tnode: %s", pod.Namespace, pod.Name, pod.Status.Phase, pod.Spec.NodeName)
+		if pod.Spec.HostNetwork {
+			sb.WriteString("\thostNetwork")
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+func podHasIP(pod *corev1.Pod, ip net.IP) bool {
+	if podIP := net.ParseIP(pod.Status.PodIP); podIP != nil && podIP.Equal(ip) {
+		return true
+	}
+	for _, podIP := range pod.Status.PodIPs {
+		if parsed := net.ParseIP(podIP.IP); parsed != nil && parsed.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
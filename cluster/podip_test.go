@@ -0,0 +1,142 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestPodByIPFind(t *testing.T) {
+	ctx := context.Background()
+
+	podWithIPs := func(namespace, name string, ips ...string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       corev1.PodSpec{NodeName: "node-1", Containers: []corev1.Container{{Name: "app", Image: "nginx"}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: ips[0]},
+		}
+		for _, ip := range ips {
+			pod.Status.PodIPs = append(pod.Status.PodIPs, corev1.PodIP{IP: ip})
+		}
+		return pod
+	}
+	web := podWithIPs(testNamespace, "web-0", "10.244.1.7")
+	api := podWithIPs("payments", "api-0", "10.244.2.9", "fd00::9")
+
+	t.Run("Found", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(web, api)
+		var fieldSelector string
+		fakeClient.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			fieldSelector = action.(k8stesting.ListAction).GetListRestrictions().Fields.String()
+			return false, nil, nil
+		})
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		result, err := (&PodByIP{IP: "10.244.2.9"}).Find(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "status.podIP=10.244.2.9", fieldSelector)
+		assert.Contains(t, result, "api-0")
+		assert.Contains(t, result, "payments")
+		assert.NotContains(t, result, "web-0")
+	})
+
+	t.Run("SecondaryIP", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(web, api), nil)
+
+		result, err := (&PodByIP{IP: "fd00:0::9"}).Find(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "api-0")
+	})
+
+	t.Run("DualStackSecondaryIP", func(t *testing.T) {
+		// Like a real API server, status.podIP only matches the primary IP.
+		fakeClient := fake.NewSimpleClientset(web, api)
+		var listCalls int
+		fakeClient.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			listCalls++
+			selector := action.(k8stesting.ListAction).GetListRestrictions().Fields
+			if selector.Empty() {
+				return false, nil, nil
+			}
+			list := &corev1.PodList{}
+			for _, pod := range []*corev1.Pod{web, api} {
+				if selector.Matches(fields.Set{"status.podIP": pod.Status.PodIP}) {
+					list.Items = append(list.Items, *pod)
+				}
+			}
+			return true, list, nil
+		})
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		result, err := (&PodByIP{IP: "fd00::9"}).Find(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, listCalls)
+		assert.Contains(t, result, "api-0")
+		assert.NotContains(t, result, "web-0")
+	})
+
+	t.Run("FieldSelectorUnsupported", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(web, api)
+		fakeClient.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if !action.(k8stesting.ListAction).GetListRestrictions().Fields.Empty() {
+				return true, nil, apierrors.NewBadRequest(`field label not supported: status.podIP`)
+			}
+			return false, nil, nil
+		})
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		result, err := (&PodByIP{IP: "10.244.1.7"}).Find(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "web-0")
+		assert.NotContains(t, result, "api-0")
+	})
+
+	t.Run("HostNetwork", func(t *testing.T) {
+		first := podWithIPs("kube-system", "kube-proxy-a", "192.168.1.10")
+		first.Spec.HostNetwork = true
+		second := podWithIPs("monitoring", "node-exporter-a", "192.168.1.10")
+		second.Spec.HostNetwork = true
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(first, second), nil)
+
+		result, err := (&PodByIP{IP: "192.168.1.10"}).Find(ctx, mockCM)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "2 pods have IP 192.168.1.10")
+		assert.Contains(t, result, "kube-system/kube-proxy-a")
+		assert.Contains(t, result, "monitoring/node-exporter-a")
+		assert.Contains(t, result, "hostNetwork")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(web), nil)
+
+		_, err := (&PodByIP{IP: "10.0.0.1"}).Find(ctx, mockCM)
+
+		assert.EqualError(t, err, "no pod found with IP 10.0.0.1")
+	})
+
+	t.Run("InvalidIP", func(t *testing.T) {
+		_, err := (&PodByIP{IP: "10.0.0"}).Find(ctx, testmocks.NewMockClusterManager())
+
+		assert.EqualError(t, err, `invalid IP address "10.0.0"`)
+	})
+}
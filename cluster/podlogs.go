@@ -0,0 +1,243 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// containerLogLine is one line of a container's log and the time the
+// kubelet recorded it.
+type containerLogLine struct {
+	container string
+	at        time.Time
+	text      string
+}
+
+// StreamAllLogs reads the logs of every container in the pod and merges them
+// by timestamp, prefixing each line with its container name like kubectl logs
+// --all-containers --prefix. Init containers come first when includeInit is
+// set. With previous, only containers that have restarted are read. The
+// output budget is shared between containers so one chatty container cannot
+// crowd out the rest.
+func (p *Pod) StreamAllLogs(ctx context.Context, cm kai.ClusterManager, tailLines int64, previous bool, since *time.Duration, includeInit bool) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	pod, err := p.getForLogs(timeoutCtx, client, previous)
+	if err != nil {
+		return "", err
+	}
+
+	var containers []string
+	if includeInit {
+		for _, c := range pod.Spec.InitContainers {
+			containers = append(containers, c.Name)
+		}
+	}
+	for _, c := range pod.Spec.Containers {
+		containers = append(containers, c.Name)
+	}
+
+	var notes []string
+	if previous {
+		restarted := containers[:0]
+		for _, name := range containers {
+			if lastTermination(pod, name) == nil {
+				notes = append(notes, fmt.Sprintf("[%s] <has not restarted, no previous logs>", name))
+				continue
+			}
+			restarted = append(restarted, name)
+		}
+		containers = restarted
+		if len(containers) == 0 {
+			return "", fmt.Errorf("no container in pod '%s' has restarted, so there are no previous instances to read logs from", p.Name)
+		}
+	}
+
+	perContainer := maxLogBytes / len(containers)
+
+	var lines []containerLogLine
+	for _, name := range containers {
+		// Timestamps let lines from different containers be interleaved in
+		// the order they were written; they are stripped from the output.
+		logOptions := &corev1.PodLogOptions{
+			Container:  name,
+			Previous:   previous,
+			Timestamps: true,
+		}
+		if tailLines > 0 {
+			logOptions.TailLines = &tailLines
+		}
+		if since != nil {
+			logOptions.SinceSeconds = ptr(int64(since.Seconds()))
+		}
+
+		logs, err := readPodLogs(timeoutCtx, client, p.Namespace, p.Name, logOptions, perContainer)
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("[%s] <%s>", name, err.Error()))
+			continue
+		}
+		if len(logs) == 0 {
+			notes = append(notes, fmt.Sprintf("[%s] <no logs>", name))
+			continue
+		}
+		lines = append(lines, timestampedLines(name, string(logs))...)
+		if len(logs) == perContainer {
+			notes = append(notes, fmt.Sprintf("[%s] [output truncated; use 'tail' or 'since' to narrow]", name))
+		}
+	}
+
+	// Lines of one container are already in order, so a stable sort keeps
+	// them that way when timestamps tie or are missing.
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].at.Before(lines[j].at)
+	})
+
+	options := []string{}
+	if previous {
+		options = append(options, "previous=true")
+	}
+	if tailLines > 0 {
+		options = append(options, fmt.Sprintf("tail=%d", tailLines))
+	}
+	if since != nil {
+		options = append(options, fmt.Sprintf("since=%s", since.String()))
+	}
+	if includeInit {
+		options = append(options, "include_init=true")
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Logs from %d container(s) in pod '%s/%s'", len(containers), p.Namespace, p.Name)
+	if len(options) > 0 {
+		fmt.Fprintf(&sb, " (%s)", strings.Join(options, ", "))
+	}
+	sb.WriteString(":\n\n")
+	for _, line := range lines {
+		fmt.Fprintf(&sb, "[%s] %s\n", line.container, line.text)
+	}
+	for _, note := range notes {
+		sb.WriteString(note + "\n")
+	}
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// timestampedLines splits a log read with Timestamps set into lines, parsing
+// and stripping the leading RFC3339 timestamp. A line without one takes the
+// time of the line before it.
+func timestampedLines(container, logs string) []containerLogLine {
+	var (
+		lines []containerLogLine
+		at    time.Time
+	)
+	for _, text := range strings.Split(strings.TrimRight(logs, "\n"), "\n") {
+		if stamp, rest, ok := strings.Cut(text, " "); ok {
+			if ts, err := time.Parse(time.RFC3339Nano, stamp); err == nil {
+				at, text = ts, rest
+			}
+		}
+		lines = append(lines, containerLogLine{container: container, at: at, text: text})
+	}
+	return lines
+}
+
+// LogsAcrossRestarts reads both the previous and the current instance of a
+// container, so a crash and the restart that followed can be read in one
+// call. A container that has not restarted has no previous section, and a
+// current instance that cannot be read yet, such as one waiting in
+// CrashLoopBackOff, is noted instead of failing the whole call.
+func (p *Pod) LogsAcrossRestarts(ctx context.Context, cm kai.ClusterManager, tailLines int64) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	// The phase check is skipped: a crashing pod is exactly the one whose
+	// logs are wanted here.
+	pod, err := p.getForLogs(timeoutCtx, client, true)
+	if err != nil {
+		return "", err
+	}
+
+	container := p.ContainerName
+	if container == "" {
+		container = pod.Spec.Containers[0].Name
+	}
+	found := false
+	for _, containers := range [][]corev1.Container{pod.Spec.Containers, pod.Spec.InitContainers} {
+		for _, c := range containers {
+			found = found || c.Name == container
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("container '%s' not found in pod '%s'", container, p.Name)
+	}
+	var restarts int32
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.ContainerStatuses, pod.Status.InitContainerStatuses} {
+		for _, cs := range statuses {
+			if cs.Name == container {
+				restarts = cs.RestartCount
+			}
+		}
+	}
+
+	read := func(previous bool, budget int) string {
+		logOptions := &corev1.PodLogOptions{Container: container, Previous: previous}
+		if tailLines > 0 {
+			logOptions.TailLines = &tailLines
+		}
+		logs, err := readPodLogs(timeoutCtx, client, p.Namespace, p.Name, logOptions, budget)
+		switch {
+		case err != nil:
+			return fmt.Sprintf("<%s>", err.Error())
+		case len(logs) == 0:
+			return "<no logs>"
+		case len(logs) == budget:
+			return strings.TrimRight(string(logs), "\n") + "\n[output truncated; use 'tail' to narrow]"
+		}
+		return strings.TrimRight(string(logs), "\n")
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Logs from container '%s' in pod '%s/%s' across restarts (restart count %d", container, p.Namespace, p.Name, restarts)
+	if tailLines > 0 {
+		fmt.Fprintf(&sb, ", tail=%d", tailLines)
+	}
+	sb.WriteString("):\n\n")
+
+	// Each instance gets half the output budget so a long current run
+	// cannot hide the crash that preceded it.
+	budget := maxLogBytes / 2
+	if lastExit := lastTermination(pod, container); lastExit != nil {
+		header := fmt.Sprintf("exit code %d", lastExit.ExitCode)
+		if lastExit.Reason != "" {
+			header += ": " + lastExit.Reason
+		}
+		if !lastExit.FinishedAt.IsZero() {
+			header += ", finished " + lastExit.FinishedAt.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(&sb, "===== previous instance (%s) =====\n", header)
+		sb.WriteString(read(true, budget))
+	} else {
+		sb.WriteString("===== previous instance =====\n")
+		sb.WriteString("<container has not restarted, so there is no previous instance>")
+	}
+
+	sb.WriteString("\n\n===== current instance =====\n")
+	sb.WriteString(read(false, budget))
+	return sb.String(), nil
+}
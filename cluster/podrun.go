@@ -0,0 +1,134 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// runCleanupTimeout bounds the removal of a run-once pod, which goes ahead
+// even when the caller's context is done.
+const runCleanupTimeout = 10 * time.Second
+
+// Run creates the pod as a run-once pod with restartPolicy Never, like
+// kubectl run --restart=Never. With wait it waits up to timeout for the pod
+// to finish and returns its phase, exit codes and logs. With rm it then
+// deletes the pod, also when the wait times out, like kubectl run --rm.
+func (p *Pod) Run(ctx context.Context, cm kai.ClusterManager, wait bool, timeout time.Duration, rm bool) (string, error) {
+	if rm && !wait {
+		return "", errors.New("rm requires wait: the pod is only removed once it has finished")
+	}
+
+	p.RestartPolicy = string(corev1.RestartPolicyNever)
+	created, err := p.Create(ctx, cm)
+	if err != nil {
+		return "", err
+	}
+	if !wait || p.DryRun {
+		return created, nil
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	waited, waitErr := p.WaitFor(ctx, cm, PodConditionCompleted, timeout)
+
+	var sb strings.Builder
+	if waitErr == nil {
+		sb.WriteString(waited + "\n")
+	}
+	if pod, err := client.CoreV1().Pods(p.Namespace).Get(ctx, p.Name, metav1.GetOptions{}); err == nil {
+		sb.WriteString(formatRunStatus(pod))
+		sb.WriteString(p.runLogs(ctx, client, pod))
+	}
+
+	if rm {
+		// The pod has finished or is being given up on; either way nothing
+		// depends on a graceful shutdown. A cancelled call must not leave
+		// the pod behind, so the delete does not inherit ctx's cancellation.
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), runCleanupTimeout)
+		defer cancel()
+		gracePeriod := int64(0)
+		if err := client.CoreV1().Pods(p.Namespace).Delete(cleanupCtx, p.Name, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod}); err != nil {
+			slog.Warn("failed to remove run-once pod",
+				slog.String("name", p.Name),
+				slog.String("namespace", p.Namespace),
+				slog.String("error", err.Error()),
+			)
+			fmt.Fprintf(&sb, "Failed to delete pod %q: %v\n", p.Name, err)
+		} else {
+			fmt.Fprintf(&sb, "Pod %q deleted\n", p.Name)
+		}
+	}
+
+	if waitErr != nil {
+		if sb.Len() == 0 {
+			return "", waitErr
+		}
+		return "", fmt.Errorf("%w\n%s", waitErr, strings.TrimRight(sb.String(), "\n"))
+	}
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// formatRunStatus reports the phase of a run-once pod and how each
+// container exited.
+func formatRunStatus(pod *corev1.Pod) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Phase: %s", pod.Status.Phase)
+	if pod.Status.Reason != "" {
+		fmt.Fprintf(&sb, " (%s)", pod.Status.Reason)
+	}
+	sb.WriteString("\n")
+	for _, status := range pod.Status.ContainerStatuses {
+		if terminated := status.State.Terminated; terminated != nil {
+			fmt.Fprintf(&sb, "Container %s exited with code %d", status.Name, terminated.ExitCode)
+			if terminated.Reason != "" {
+				fmt.Fprintf(&sb, " (%s)", terminated.Reason)
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// runLogs returns the logs of every container of a run-once pod, headed by
+// the container name when there are several.
+func (p *Pod) runLogs(ctx context.Context, client kubernetes.Interface, pod *corev1.Pod) string {
+	var sb strings.Builder
+	for _, container := range pod.Spec.Containers {
+		logs, err := readPodLogs(ctx, client, p.Namespace, p.Name, &corev1.PodLogOptions{Container: container.Name}, maxLogBytes)
+		if len(pod.Spec.Containers) > 1 {
+			fmt.Fprintf(&sb, "\n==> %s <==\n", container.Name)
+		} else {
+			sb.WriteString("\nLogs:\n")
+		}
+		switch {
+		case err != nil:
+			fmt.Fprintf(&sb, "(logs unavailable: %v)\n", err)
+		case len(logs) == 0:
+			sb.WriteString("(no output)\n")
+		default:
+			sb.Write(logs)
+			if logs[len(logs)-1] != '\n' {
+				sb.WriteString("\n")
+			}
+			if len(logs) == maxLogBytes {
+				sb.WriteString("[Output truncated due to size limits. Use stream_logs with tail to read the end.]\n")
+			}
+		}
+	}
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,118 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestPodRun(t *testing.T) {
+	ctx := context.Background()
+
+	// setup returns a clientset whose pods finish with exitCode as soon as
+	// they are created, or stay Pending when exitCode is negative.
+	setup := func(exitCode int32) (*testmocks.MockClusterManager, *fake.Clientset) {
+		client := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}})
+		if exitCode >= 0 {
+			client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+				pod.Status.Phase = corev1.PodSucceeded
+				if exitCode != 0 {
+					pod.Status.Phase = corev1.PodFailed
+				}
+				pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+					Name:  pod.Spec.Containers[0].Name,
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode}},
+				}}
+				return false, nil, nil
+			})
+		}
+		cm := testmocks.NewMockClusterManager()
+		cm.On("GetCurrentClient").Return(client, nil)
+		return cm, client
+	}
+
+	newPod := func() *Pod {
+		return &Pod{
+			Name:                  "dns-check",
+			Namespace:             testNamespace,
+			Image:                 "busybox:1.36",
+			Command:               []interface{}{"nslookup", "kubernetes.default"},
+			ActiveDeadlineSeconds: ptr(int64(60)),
+		}
+	}
+
+	t.Run("CompletedReturnsLogs", func(t *testing.T) {
+		cm, client := setup(0)
+		result, err := newPod().Run(ctx, cm, true, time.Second, false)
+		require.NoError(t, err)
+		assert.Contains(t, result, `Pod "dns-check" in namespace "test-namespace" completed after`)
+		assert.Contains(t, result, "Phase: Succeeded")
+		assert.Contains(t, result, "Container dns-check exited with code 0")
+		assert.Contains(t, result, "Logs:\nfake logs")
+		assert.NotContains(t, result, "deleted")
+
+		pod, err := client.CoreV1().Pods(testNamespace).Get(ctx, "dns-check", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, corev1.RestartPolicyNever, pod.Spec.RestartPolicy)
+		assert.Equal(t, int64(60), *pod.Spec.ActiveDeadlineSeconds)
+	})
+
+	t.Run("RemovesAfterCompletion", func(t *testing.T) {
+		cm, client := setup(1)
+		result, err := newPod().Run(ctx, cm, true, time.Second, true)
+		require.NoError(t, err)
+		assert.Contains(t, result, "Phase: Failed")
+		assert.Contains(t, result, "exited with code 1")
+		assert.Contains(t, result, "fake logs")
+		assert.Contains(t, result, `Pod "dns-check" deleted`)
+
+		_, err = client.CoreV1().Pods(testNamespace).Get(ctx, "dns-check", metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("RemovesAfterTimeout", func(t *testing.T) {
+		cm, client := setup(-1)
+		_, err := newPod().Run(ctx, cm, true, 50*time.Millisecond, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `timed out after 50ms waiting for pod "dns-check" to be Completed`)
+		assert.Contains(t, err.Error(), `Pod "dns-check" deleted`)
+
+		_, err = client.CoreV1().Pods(testNamespace).Get(ctx, "dns-check", metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("RemovesAfterCancel", func(t *testing.T) {
+		cm, client := setup(-1)
+		runCtx, cancel := context.WithCancel(ctx)
+		time.AfterFunc(50*time.Millisecond, cancel)
+		_, err := newPod().Run(runCtx, cm, true, time.Minute, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `Pod "dns-check" deleted`)
+
+		_, err = client.CoreV1().Pods(testNamespace).Get(ctx, "dns-check", metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("NoWait", func(t *testing.T) {
+		cm, _ := setup(-1)
+		result, err := newPod().Run(ctx, cm, false, 0, false)
+		require.NoError(t, err)
+		assert.Equal(t, `Pod "dns-check" created successfully in namespace "test-namespace"`, result)
+	})
+
+	t.Run("RemoveRequiresWait", func(t *testing.T) {
+		_, err := newPod().Run(ctx, testmocks.NewMockClusterManager(), false, 0, true)
+		assert.EqualError(t, err, "rm requires wait: the pod is only removed once it has finished")
+	})
+}
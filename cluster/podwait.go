@@ -0,0 +1,179 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// Conditions WaitFor can wait for.
+const (
+	PodConditionReady     = "Ready"
+	PodConditionDeleted   = "Deleted"
+	PodConditionCompleted = "Completed"
+)
+
+const (
+	defaultPodWaitTimeout = 60 * time.Second
+	maxPodWaitTimeout     = 5 * time.Minute
+)
+
+// WaitFor watches the pod until condition holds: Ready once the pod reports
+// the Ready condition, Deleted once it is gone, Completed once it has
+// Succeeded or Failed. timeout defaults to 60s and is capped at 5m. Waiting for Ready fails early when the pod finishes or is
+// deleted, since it can then never become ready.
+func (p *Pod) WaitFor(ctx context.Context, cm kai.ClusterManager, condition string, timeout time.Duration) (string, error) {
+	switch {
+	case condition == "" || strings.EqualFold(condition, PodConditionReady):
+		condition = PodConditionReady
+	case strings.EqualFold(condition, PodConditionDeleted):
+		condition = PodConditionDeleted
+	case strings.EqualFold(condition, PodConditionCompleted):
+		condition = PodConditionCompleted
+	default:
+		return "", fmt.Errorf("invalid condition %q: must be %s, %s or %s", condition, PodConditionReady, PodConditionDeleted, PodConditionCompleted)
+	}
+	if timeout <= 0 {
+		timeout = defaultPodWaitTimeout
+	}
+	timeout = min(timeout, maxPodWaitTimeout)
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := p.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	slog.Debug("Pod wait requested",
+		slog.String("name", p.Name),
+		slog.String("namespace", namespace),
+		slog.String("condition", condition),
+		slog.Duration("timeout", timeout),
+	)
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	started := time.Now()
+	satisfied := func() (string, error) {
+		verb := "is Ready"
+		switch condition {
+		case PodConditionDeleted:
+			verb = "was deleted"
+		case PodConditionCompleted:
+			verb = "completed"
+		}
+		return fmt.Sprintf("Pod %q in namespace %q %s after %s", p.Name, namespace, verb, time.Since(started).Round(time.Second)), nil
+	}
+
+	var last *corev1.Pod
+	timedOut := func() error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		phase := "unknown"
+		if last != nil {
+			phase = string(last.Status.Phase)
+		}
+		return fmt.Errorf("timed out after %s waiting for pod %q to be %s (phase: %s)", timeout, p.Name, condition, phase)
+	}
+
+	// get re-reads the pod, reporting done when the condition already holds.
+	get := func(ctx context.Context) (string, bool, error) {
+		pod, err := client.CoreV1().Pods(namespace).Get(ctx, p.Name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			if condition == PodConditionDeleted {
+				return "", true, nil
+			}
+			return "", false, fmt.Errorf("pod %q not found in namespace %q", p.Name, namespace)
+		case err != nil:
+			return "", false, fmt.Errorf("failed to get pod %q: %w", p.Name, err)
+		}
+		last = pod
+		done, err := podWaitDone(pod, condition)
+		return pod.ResourceVersion, done, err
+	}
+
+	resourceVersion, done, err := get(waitCtx)
+	if err != nil {
+		if waitCtx.Err() != nil {
+			return "", timedOut()
+		}
+		return "", err
+	}
+	if done {
+		return satisfied()
+	}
+
+	// The watch can drop before the wait is over; resumableWatch reopens it
+	// with backoff from the last resourceVersion seen.
+	rw := &resumableWatch{
+		open: func(ctx context.Context, resourceVersion string) (watch.Interface, error) {
+			w, err := client.CoreV1().Pods(namespace).Watch(ctx, metav1.ListOptions{
+				FieldSelector:   fields.OneTermEqualSelector("metadata.name", p.Name).String(),
+				ResourceVersion: resourceVersion,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to watch pod %q: %w", p.Name, err)
+			}
+			return w, nil
+		},
+		relist: get,
+		handle: func(event watch.Event) (bool, error) {
+			switch event.Type {
+			case watch.Deleted:
+				if condition == PodConditionDeleted {
+					return true, nil
+				}
+				return false, fmt.Errorf("pod %q was deleted before becoming %s", p.Name, condition)
+			case watch.Added, watch.Modified:
+				if pod, ok := event.Object.(*corev1.Pod); ok {
+					last = pod
+					return podWaitDone(pod, condition)
+				}
+			}
+			return false, nil
+		},
+	}
+	if err := rw.run(waitCtx, resourceVersion); err != nil {
+		if waitCtx.Err() != nil {
+			return "", timedOut()
+		}
+		return "", err
+	}
+	return satisfied()
+}
+
+// podWaitDone reports whether the pod already meets condition, or an error
+// when waiting for Ready is pointless because the pod has finished.
+func podWaitDone(pod *corev1.Pod, condition string) (bool, error) {
+	finished := pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+	switch condition {
+	case PodConditionDeleted:
+		return false, nil
+	case PodConditionCompleted:
+		return finished, nil
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+			return true, nil
+		}
+	}
+	if finished {
+		return false, fmt.Errorf("pod %q finished with phase %s and will not become Ready", pod.Name, pod.Status.Phase)
+	}
+	return false, nil
+}
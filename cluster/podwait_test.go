@@ -0,0 +1,169 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestPodWaitFor(t *testing.T) {
+	const waitPodName = "web"
+	ctx := context.Background()
+
+	newPod := func(phase corev1.PodPhase, ready bool) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: waitPodName, Namespace: testNamespace},
+			Status: corev1.PodStatus{
+				Phase:      phase,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+	}
+
+	// setup seeds the clientset and routes pod watches to a fake watcher the
+	// test drives.
+	setup := func(objects ...*corev1.Pod) (*testmocks.MockClusterManager, *watch.FakeWatcher) {
+		client := fake.NewSimpleClientset()
+		for _, obj := range objects {
+			require.NoError(t, client.Tracker().Add(obj))
+		}
+		watcher := watch.NewFake()
+		client.PrependWatchReactor("pods", k8stesting.DefaultWatchReactor(watcher, nil))
+		cm := testmocks.NewMockClusterManager()
+		cm.On("GetCurrentClient").Return(client, nil)
+		return cm, watcher
+	}
+
+	t.Run("AlreadyReady", func(t *testing.T) {
+		cm, _ := setup(newPod(corev1.PodRunning, true))
+		result, err := (&Pod{Name: waitPodName, Namespace: testNamespace}).WaitFor(ctx, cm, "", time.Second)
+		require.NoError(t, err)
+		assert.Contains(t, result, `Pod "web" in namespace "test-namespace" is Ready after`)
+	})
+
+	t.Run("BecomesReady", func(t *testing.T) {
+		cm, watcher := setup(newPod(corev1.PodPending, false))
+		go func() {
+			watcher.Modify(newPod(corev1.PodRunning, false))
+			watcher.Modify(newPod(corev1.PodRunning, true))
+		}()
+		result, err := (&Pod{Name: waitPodName, Namespace: testNamespace}).WaitFor(ctx, cm, "ready", 5*time.Second)
+		require.NoError(t, err)
+		assert.Contains(t, result, "is Ready after")
+		assert.True(t, watcher.IsStopped())
+	})
+
+	t.Run("BecomesCompleted", func(t *testing.T) {
+		cm, watcher := setup(newPod(corev1.PodRunning, true))
+		go watcher.Modify(newPod(corev1.PodFailed, false))
+		result, err := (&Pod{Name: waitPodName, Namespace: testNamespace}).WaitFor(ctx, cm, "completed", 5*time.Second)
+		require.NoError(t, err)
+		assert.Contains(t, result, `Pod "web" in namespace "test-namespace" completed after`)
+	})
+
+	t.Run("ResumesAfterWatchDrops", func(t *testing.T) {
+		orig := watchBackoff
+		watchBackoff.Duration = time.Millisecond
+		defer func() { watchBackoff = orig }()
+
+		client := fake.NewSimpleClientset()
+		pending := newPod(corev1.PodPending, false)
+		pending.ResourceVersion = "1"
+		require.NoError(t, client.Tracker().Add(pending))
+
+		// The first watch delivers one update and then closes, as it does
+		// when the apiserver restarts; the second carries on from there.
+		running := newPod(corev1.PodRunning, false)
+		running.ResourceVersion = "2"
+		first, second := watch.NewFake(), watch.NewFake()
+		var resumedFrom []string
+		client.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+			resumedFrom = append(resumedFrom, action.(k8stesting.WatchActionImpl).WatchRestrictions.ResourceVersion)
+			if len(resumedFrom) == 1 {
+				go func() {
+					first.Modify(running)
+					first.Stop()
+				}()
+				return true, first, nil
+			}
+			go second.Modify(newPod(corev1.PodRunning, true))
+			return true, second, nil
+		})
+		cm := testmocks.NewMockClusterManager()
+		cm.On("GetCurrentClient").Return(client, nil)
+
+		result, err := (&Pod{Name: waitPodName, Namespace: testNamespace}).WaitFor(ctx, cm, PodConditionReady, 5*time.Second)
+		require.NoError(t, err)
+		assert.Contains(t, result, "is Ready after")
+		assert.Equal(t, []string{"1", "2"}, resumedFrom)
+	})
+
+	t.Run("FinishesBeforeReady", func(t *testing.T) {
+		cm, watcher := setup(newPod(corev1.PodPending, false))
+		go watcher.Modify(newPod(corev1.PodFailed, false))
+		_, err := (&Pod{Name: waitPodName, Namespace: testNamespace}).WaitFor(ctx, cm, PodConditionReady, 5*time.Second)
+		assert.EqualError(t, err, `pod "web" finished with phase Failed and will not become Ready`)
+	})
+
+	t.Run("DeletedBeforeReady", func(t *testing.T) {
+		cm, watcher := setup(newPod(corev1.PodPending, false))
+		go watcher.Delete(newPod(corev1.PodPending, false))
+		_, err := (&Pod{Name: waitPodName, Namespace: testNamespace}).WaitFor(ctx, cm, PodConditionReady, 5*time.Second)
+		assert.EqualError(t, err, `pod "web" was deleted before becoming Ready`)
+	})
+
+	t.Run("Deleted", func(t *testing.T) {
+		cm, watcher := setup(newPod(corev1.PodRunning, true))
+		go watcher.Delete(newPod(corev1.PodRunning, true))
+		result, err := (&Pod{Name: waitPodName, Namespace: testNamespace}).WaitFor(ctx, cm, PodConditionDeleted, 5*time.Second)
+		require.NoError(t, err)
+		assert.Contains(t, result, `Pod "web" in namespace "test-namespace" was deleted after`)
+		assert.True(t, watcher.IsStopped())
+	})
+
+	t.Run("AlreadyDeleted", func(t *testing.T) {
+		cm, _ := setup()
+		result, err := (&Pod{Name: waitPodName, Namespace: testNamespace}).WaitFor(ctx, cm, "deleted", time.Second)
+		require.NoError(t, err)
+		assert.Contains(t, result, "was deleted after")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		cm, _ := setup()
+		_, err := (&Pod{Name: waitPodName, Namespace: testNamespace}).WaitFor(ctx, cm, PodConditionReady, time.Second)
+		assert.EqualError(t, err, `pod "web" not found in namespace "test-namespace"`)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		cm, watcher := setup(newPod(corev1.PodPending, false))
+		_, err := (&Pod{Name: waitPodName, Namespace: testNamespace}).WaitFor(ctx, cm, PodConditionReady, 50*time.Millisecond)
+		assert.EqualError(t, err, `timed out after 50ms waiting for pod "web" to be Ready (phase: Pending)`)
+		assert.True(t, watcher.IsStopped())
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		cm, _ := setup(newPod(corev1.PodPending, false))
+		cancelCtx, cancel := context.WithCancel(ctx)
+		time.AfterFunc(20*time.Millisecond, cancel)
+		_, err := (&Pod{Name: waitPodName, Namespace: testNamespace}).WaitFor(cancelCtx, cm, PodConditionReady, time.Minute)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("InvalidCondition", func(t *testing.T) {
+		_, err := (&Pod{Name: waitPodName, Namespace: testNamespace}).WaitFor(ctx, testmocks.NewMockClusterManager(), "Running", time.Second)
+		assert.EqualError(t, err, `invalid condition "Running": must be Ready, Deleted or Completed`)
+	})
+}
@@ -0,0 +1,183 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+const (
+	// defaultPortForwardDuration is how long port_forward_pod keeps a
+	// forward open when no duration is given.
+	defaultPortForwardDuration = 30 * time.Second
+	// maxPortForwardDuration caps the duration, since the tool call blocks
+	// for as long as the forward is open.
+	maxPortForwardDuration = 10 * time.Minute
+	// portForwardReadyTimeout bounds the wait for the tunnel to come up.
+	portForwardReadyTimeout = 15 * time.Second
+)
+
+// podPortForwarder is the part of *portforward.PortForwarder that
+// PortForward uses.
+type podPortForwarder interface {
+	ForwardPorts() error
+	GetPorts() ([]portforward.ForwardedPort, error)
+}
+
+// newPodPortForwarder dials the pods/portforward subresource over SPDY.
+// Tests replace it, since the fake clientset cannot stream.
+var newPodPortForwarder = func(config *rest.Config, u *url.URL, ports []string, stopChan <-chan struct{}, readyChan chan struct{}, out, errOut *syncBuffer) (podPortForwarder, error) {
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create round tripper: %w", err)
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, u)
+	return portforward.New(dialer, ports, stopChan, readyChan, out, errOut)
+}
+
+// PortForward forwards localPort on this host to podPort in the pod for
+// duration, then tears the forward down and summarises it. A zero localPort
+// lets the system pick a free one, and a zero duration uses the default.
+// It only counts as started once the tunnel reports ready; cancelling ctx
+// ends the forward early.
+func (p *Pod) PortForward(ctx context.Context, cm kai.ClusterManager, localPort, podPort int, duration time.Duration) (string, error) {
+	if podPort < 1 || podPort > 65535 {
+		return "", fmt.Errorf("invalid pod port %d: must be between 1 and 65535", podPort)
+	}
+	if localPort < 0 || localPort > 65535 {
+		return "", fmt.Errorf("invalid local port %d: must be between 0 and 65535", localPort)
+	}
+	if duration <= 0 {
+		duration = defaultPortForwardDuration
+	}
+	if duration > maxPortForwardDuration {
+		return "", fmt.Errorf("duration %s exceeds the maximum of %s", duration, maxPortForwardDuration)
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	config, err := kai.CurrentRESTConfig(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting REST config: %w", err)
+	}
+
+	namespace := p.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	getCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	pod, err := client.CoreV1().Pods(namespace).Get(getCtx, p.Name, metav1.GetOptions{})
+	cancel()
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("pod %q not found in namespace %q", p.Name, namespace)
+		}
+		return "", fmt.Errorf("failed to get pod %q: %w", p.Name, err)
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return "", fmt.Errorf("pod %q is %s; port forwarding needs a running pod", p.Name, pod.Status.Phase)
+	}
+
+	reqURL, err := url.Parse(fmt.Sprintf("%s/api/v1/namespaces/%s/pods/%s/portforward", strings.TrimRight(config.Host, "/"), namespace, p.Name))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	stopChan := make(chan struct{})
+	readyChan := make(chan struct{})
+	var out, errOut syncBuffer
+	forwarder, err := newPodPortForwarder(config, reqURL, []string{fmt.Sprintf("%d:%d", localPort, podPort)}, stopChan, readyChan, &out, &errOut)
+	if err != nil {
+		return "", fmt.Errorf("failed to create port forwarder: %w", err)
+	}
+
+	forwardErr := make(chan error, 1)
+	go func() { forwardErr <- forwarder.ForwardPorts() }()
+
+	readyTimer := time.NewTimer(portForwardReadyTimeout)
+	defer readyTimer.Stop()
+	select {
+	case <-readyChan:
+	case err := <-forwardErr:
+		if err == nil {
+			err = errors.New("forwarder exited before becoming ready")
+		}
+		return "", fmt.Errorf("port forward to pod %q failed: %w", p.Name, err)
+	case <-readyTimer.C:
+		close(stopChan)
+		return "", fmt.Errorf("port forward to pod %q was not ready after %s", p.Name, portForwardReadyTimeout)
+	case <-ctx.Done():
+		close(stopChan)
+		return "", ctx.Err()
+	}
+
+	boundPort := localPort
+	if ports, err := forwarder.GetPorts(); err == nil && len(ports) > 0 {
+		boundPort = int(ports[0].Local)
+	}
+
+	started := time.Now()
+	stopped := ""
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		stopped = "cancelled"
+	case err := <-forwardErr:
+		stopped = "the connection to the pod closed"
+		if err != nil {
+			stopped = err.Error()
+		}
+	}
+	close(stopChan)
+	elapsed := time.Since(started).Round(time.Second)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Forwarded localhost:%d -> pod %s/%s:%d for %s", boundPort, namespace, p.Name, podPort, elapsed)
+	if stopped != "" {
+		fmt.Fprintf(&sb, " (stopped early: %s)", stopped)
+	}
+	fmt.Fprintf(&sb, "\nConnections handled: %d", strings.Count(out.String(), "Handling connection"))
+	if errText := strings.TrimSpace(errOut.String()); errText != "" {
+		fmt.Fprintf(&sb, "\nErrors:\n%s", errText)
+	}
+	return sb.String(), nil
+}
+
+// syncBuffer is a bytes.Buffer safe for the concurrent writes the port
+// forwarder makes from its connection handlers.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
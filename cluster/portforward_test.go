@@ -0,0 +1,178 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+)
+
+// fakePortForwarder stands in for the SPDY forwarder: it signals ready
+// unless failErr is set, reports connections, and runs until stopped or
+// until dropErr is sent.
+type fakePortForwarder struct {
+	stopChan    <-chan struct{}
+	readyChan   chan struct{}
+	out         *syncBuffer
+	local       uint16
+	connections int
+	failErr     error
+	dropErr     chan error
+	stopped     chan struct{}
+}
+
+func (f *fakePortForwarder) ForwardPorts() error {
+	if f.failErr != nil {
+		return f.failErr
+	}
+	close(f.readyChan)
+	for i := 0; i < f.connections; i++ {
+		fmt.Fprintf(f.out, "Handling connection for %d\n", f.local)
+	}
+	select {
+	case <-f.stopChan:
+		close(f.stopped)
+		return nil
+	case err := <-f.dropErr:
+		return err
+	}
+}
+
+func (f *fakePortForwarder) GetPorts() ([]portforward.ForwardedPort, error) {
+	return []portforward.ForwardedPort{{Local: f.local, Remote: 80}}, nil
+}
+
+func stubPortForwarder(t *testing.T, fpf *fakePortForwarder) *[]string {
+	t.Helper()
+	var requested []string
+	fpf.stopped = make(chan struct{})
+	if fpf.dropErr == nil {
+		fpf.dropErr = make(chan error)
+	}
+	original := newPodPortForwarder
+	newPodPortForwarder = func(_ *rest.Config, u *url.URL, ports []string, stopChan <-chan struct{}, readyChan chan struct{}, out, _ *syncBuffer) (podPortForwarder, error) {
+		requested = append([]string{u.Path}, ports...)
+		fpf.stopChan, fpf.readyChan, fpf.out = stopChan, readyChan, out
+		return fpf, nil
+	}
+	t.Cleanup(func() { newPodPortForwarder = original })
+	return &requested
+}
+
+func TestPodPortForward(t *testing.T) {
+	ctx := context.Background()
+	config := &rest.Config{Host: "https://cluster.example:6443"}
+
+	newCM := func(phase corev1.PodPhase) *testmocks.MockClusterManager {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: testNamespace},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(pod), nil)
+		mockCM.On("GetCurrentRESTConfig").Return(config, nil)
+		return mockCM
+	}
+
+	t.Run("ForwardsForDuration", func(t *testing.T) {
+		fpf := &fakePortForwarder{local: 8080, connections: 2}
+		requested := stubPortForwarder(t, fpf)
+
+		pod := &Pod{Name: "web-0", Namespace: testNamespace}
+		start := time.Now()
+		result, err := pod.PortForward(ctx, newCM(corev1.PodRunning), 8080, 80, 50*time.Millisecond)
+
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+		assert.Equal(t, []string{"/api/v1/namespaces/test-namespace/pods/web-0/portforward", "8080:80"}, *requested)
+		assert.Contains(t, result, "Forwarded localhost:8080 -> pod test-namespace/web-0:80")
+		assert.Contains(t, result, "Connections handled: 2")
+		assert.NotContains(t, result, "stopped early")
+
+		select {
+		case <-fpf.stopped:
+		case <-time.After(time.Second):
+			t.Fatal("forwarder was not stopped")
+		}
+	})
+
+	t.Run("ReportsPickedLocalPort", func(t *testing.T) {
+		stubPortForwarder(t, &fakePortForwarder{local: 41234})
+
+		pod := &Pod{Name: "web-0", Namespace: testNamespace}
+		result, err := pod.PortForward(ctx, newCM(corev1.PodRunning), 0, 80, 10*time.Millisecond)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "localhost:41234")
+	})
+
+	t.Run("NotReady", func(t *testing.T) {
+		stubPortForwarder(t, &fakePortForwarder{failErr: errors.New("unable to listen on any of the requested ports")})
+
+		pod := &Pod{Name: "web-0", Namespace: testNamespace}
+		_, err := pod.PortForward(ctx, newCM(corev1.PodRunning), 8080, 80, time.Second)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unable to listen")
+	})
+
+	t.Run("ConnectionDropped", func(t *testing.T) {
+		fpf := &fakePortForwarder{local: 8080, dropErr: make(chan error, 1)}
+		fpf.dropErr <- errors.New("lost connection to pod")
+		stubPortForwarder(t, fpf)
+
+		pod := &Pod{Name: "web-0", Namespace: testNamespace}
+		result, err := pod.PortForward(ctx, newCM(corev1.PodRunning), 8080, 80, time.Minute)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "stopped early: lost connection to pod")
+	})
+
+	t.Run("ContextCancelled", func(t *testing.T) {
+		fpf := &fakePortForwarder{local: 8080}
+		stubPortForwarder(t, fpf)
+		cancelCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+
+		pod := &Pod{Name: "web-0", Namespace: testNamespace}
+		result, err := pod.PortForward(cancelCtx, newCM(corev1.PodRunning), 8080, 80, time.Minute)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "stopped early: cancelled")
+		<-fpf.stopped
+	})
+
+	t.Run("PodNotRunning", func(t *testing.T) {
+		stubPortForwarder(t, &fakePortForwarder{})
+
+		pod := &Pod{Name: "web-0", Namespace: testNamespace}
+		_, err := pod.PortForward(ctx, newCM(corev1.PodPending), 8080, 80, time.Second)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "needs a running pod")
+	})
+
+	t.Run("InvalidArguments", func(t *testing.T) {
+		pod := &Pod{Name: "web-0", Namespace: testNamespace}
+		mockCM := testmocks.NewMockClusterManager()
+
+		_, err := pod.PortForward(ctx, mockCM, 8080, 0, time.Second)
+		assert.ErrorContains(t, err, "invalid pod port")
+
+		_, err = pod.PortForward(ctx, mockCM, 70000, 80, time.Second)
+		assert.ErrorContains(t, err, "invalid local port")
+
+		_, err = pod.PortForward(ctx, mockCM, 8080, 80, time.Hour)
+		assert.ErrorContains(t, err, "exceeds the maximum")
+	})
+}
@@ -0,0 +1,137 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/basebandit/kai"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// PruneByLabel deletes every object of the given kinds in one namespace that
+// matches a label selector, the way GitOps tools prune resources that left
+// the desired state.
+type PruneByLabel struct {
+	LabelSelector string
+	// Kinds are kinds or plural resource names, such as Deployment or
+	// services. Only namespaced kinds can be pruned.
+	Kinds     []string
+	Namespace string
+	// DryRun lists what would be deleted without deleting anything.
+	DryRun bool
+}
+
+// prunedObject is one object matched by the selector.
+type prunedObject struct {
+	kind string
+	name string
+}
+
+// Run resolves every kind before touching anything, so a typo in one kind
+// does not leave the prune half done, then lists and deletes the matches.
+// Objects that disappear in between are skipped.
+func (p *PruneByLabel) Run(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	selector, err := labels.Parse(p.LabelSelector)
+	if err != nil {
+		return "", fmt.Errorf("invalid label selector %q: %w", p.LabelSelector, err)
+	}
+	if selector.Empty() {
+		return "", errors.New("label_selector is required; an empty selector would match every object")
+	}
+	if len(p.Kinds) == 0 {
+		return "", errors.New("at least one kind is required")
+	}
+
+	mapper, err := kai.CurrentRESTMapper(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting REST mapper: %w", err)
+	}
+	dyn, err := kai.CurrentDynamicClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting dynamic client: %w", err)
+	}
+
+	var mappings []*meta.RESTMapping
+	seen := map[string]bool{}
+	for _, kind := range p.Kinds {
+		mapping, err := resolveMapping(mapper, "", "", kind)
+		if err != nil {
+			return "", err
+		}
+		if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+			return "", fmt.Errorf("kind %s is cluster-scoped; only namespaced kinds can be pruned", mapping.GroupVersionKind.Kind)
+		}
+		if seen[mapping.Resource.String()] {
+			continue
+		}
+		seen[mapping.Resource.String()] = true
+		mappings = append(mappings, mapping)
+	}
+
+	namespace := p.Namespace
+	if namespace == "" {
+		namespace = kai.CurrentNamespace(ctx, cm)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	var matched []prunedObject
+	for _, mapping := range mappings {
+		kind := mapping.GroupVersionKind.Kind
+		ri := dyn.Resource(mapping.Resource).Namespace(namespace)
+		list, err := ri.List(timeoutCtx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return "", fmt.Errorf("failed to list %s: %w", mapping.Resource.Resource, err)
+		}
+		for _, item := range list.Items {
+			// Fake and aggregated APIs do not always honour the selector.
+			if !selector.Matches(labels.Set(item.GetLabels())) {
+				continue
+			}
+			if !p.DryRun {
+				err := ri.Delete(timeoutCtx, item.GetName(), metav1.DeleteOptions{})
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				if err != nil {
+					return "", fmt.Errorf("failed to delete %s %s/%s after pruning %d object(s): %w", kind, namespace, item.GetName(), len(matched), err)
+				}
+			}
+			matched = append(matched, prunedObject{kind: kind, name: item.GetName()})
+		}
+	}
+
+	return formatPrune(matched, selector.String(), namespace, p.DryRun), nil
+}
+
+func formatPrune(objs []prunedObject, selector, namespace string, dryRun bool) string {
+	if len(objs) == 0 {
+		return fmt.Sprintf("No objects matching %q in namespace %q", selector, namespace)
+	}
+	sort.SliceStable(objs, func(i, j int) bool {
+		if objs[i].kind != objs[j].kind {
+			return objs[i].kind < objs[j].kind
+		}
+		return objs[i].name < objs[j].name
+	})
+
+	var sb strings.Builder
+	verb := "deleted"
+	if dryRun {
+		verb = "would be deleted"
+		fmt.Fprintf(&sb, "Dry run: %d object(s) matching %q in namespace %q would be pruned:\n", len(objs), selector, namespace)
+	} else {
+		fmt.Fprintf(&sb, "Pruned %d object(s) matching %q in namespace %q:\n", len(objs), selector, namespace)
+	}
+	for _, o := range objs {
+		fmt.Fprintf(&sb, "• %s %s %s\n", o.kind, o.name, verb)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
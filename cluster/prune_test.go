@@ -0,0 +1,145 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPruneByLabel(t *testing.T) {
+	ctx := context.Background()
+	deployGVR := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	svcGVR := schema.GroupVersionResource{Version: "v1", Resource: "services"}
+
+	labeled := func(apiVersion, kind, name string, lbls map[string]interface{}) *unstructured.Unstructured {
+		obj := uObj(apiVersion, kind, name, defaultNamespace)
+		obj.Object["metadata"].(map[string]interface{})["labels"] = lbls
+		return obj
+	}
+
+	// setup seeds a release "demo" of a deployment and a service, plus objects
+	// that must survive: another release and a demo service elsewhere.
+	setup := func(t *testing.T) (*testmocks.MockClusterManager, *dynamicfake.FakeDynamicClient) {
+		fakeClient := fake.NewSimpleClientset()
+		fakeClient.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{
+					{Name: "services", Namespaced: true, Kind: "Service"},
+					{Name: "namespaces", Namespaced: false, Kind: "Namespace"},
+				},
+			},
+			{
+				GroupVersion: "apps/v1",
+				APIResources: []metav1.APIResource{
+					{Name: "deployments", Namespaced: true, Kind: "Deployment"},
+				},
+			},
+		}
+		dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			deployGVR: "DeploymentList",
+			svcGVR:    "ServiceList",
+		})
+		demo := map[string]interface{}{"app.kubernetes.io/instance": "demo"}
+		other := map[string]interface{}{"app.kubernetes.io/instance": "other"}
+		for obj, gvr := range map[*unstructured.Unstructured]schema.GroupVersionResource{
+			labeled("apps/v1", "Deployment", "demo-web", demo):   deployGVR,
+			labeled("v1", "Service", "demo-web", demo):           svcGVR,
+			labeled("apps/v1", "Deployment", "other-web", other): deployGVR,
+		} {
+			_, err := dyn.Resource(gvr).Namespace(defaultNamespace).Create(ctx, obj, metav1.CreateOptions{})
+			require.NoError(t, err)
+		}
+		elsewhere := uObj("v1", "Service", "demo-web", testNamespace)
+		elsewhere.Object["metadata"].(map[string]interface{})["labels"] = demo
+		_, err := dyn.Resource(svcGVR).Namespace(testNamespace).Create(ctx, elsewhere, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+		mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+		mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+		return mockCM, dyn
+	}
+
+	prune := func() *PruneByLabel {
+		return &PruneByLabel{LabelSelector: "app.kubernetes.io/instance=demo", Kinds: []string{"Deployment", "services"}}
+	}
+
+	t.Run("DeletesMatchingAcrossKinds", func(t *testing.T) {
+		mockCM, dyn := setup(t)
+
+		result, err := prune().Run(ctx, mockCM)
+		require.NoError(t, err)
+		assert.Equal(t, `Pruned 2 object(s) matching "app.kubernetes.io/instance=demo" in namespace "default":
+• Deployment demo-web deleted
+• Service demo-web deleted`, result)
+
+		_, err = dyn.Resource(deployGVR).Namespace(defaultNamespace).Get(ctx, "demo-web", metav1.GetOptions{})
+		assert.Error(t, err)
+		_, err = dyn.Resource(svcGVR).Namespace(defaultNamespace).Get(ctx, "demo-web", metav1.GetOptions{})
+		assert.Error(t, err)
+		_, err = dyn.Resource(deployGVR).Namespace(defaultNamespace).Get(ctx, "other-web", metav1.GetOptions{})
+		assert.NoError(t, err)
+		_, err = dyn.Resource(svcGVR).Namespace(testNamespace).Get(ctx, "demo-web", metav1.GetOptions{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		mockCM, dyn := setup(t)
+
+		p := prune()
+		p.DryRun = true
+		result, err := p.Run(ctx, mockCM)
+		require.NoError(t, err)
+		assert.Contains(t, result, `Dry run: 2 object(s) matching "app.kubernetes.io/instance=demo" in namespace "default" would be pruned:`)
+		assert.Contains(t, result, "• Deployment demo-web would be deleted")
+
+		_, err = dyn.Resource(deployGVR).Namespace(defaultNamespace).Get(ctx, "demo-web", metav1.GetOptions{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("NoMatches", func(t *testing.T) {
+		mockCM, _ := setup(t)
+
+		p := prune()
+		p.LabelSelector = "app.kubernetes.io/instance=gone"
+		result, err := p.Run(ctx, mockCM)
+		require.NoError(t, err)
+		assert.Equal(t, `No objects matching "app.kubernetes.io/instance=gone" in namespace "default"`, result)
+	})
+
+	t.Run("ClusterScopedKind", func(t *testing.T) {
+		mockCM, dyn := setup(t)
+
+		p := prune()
+		p.Kinds = []string{"Deployment", "Namespace"}
+		_, err := p.Run(ctx, mockCM)
+		assert.EqualError(t, err, "kind Namespace is cluster-scoped; only namespaced kinds can be pruned")
+
+		_, err = dyn.Resource(deployGVR).Namespace(defaultNamespace).Get(ctx, "demo-web", metav1.GetOptions{})
+		assert.NoError(t, err, "nothing is deleted when a kind is rejected")
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+
+		_, err := (&PruneByLabel{Kinds: []string{"Deployment"}}).Run(ctx, mockCM)
+		assert.EqualError(t, err, "label_selector is required; an empty selector would match every object")
+
+		_, err = (&PruneByLabel{LabelSelector: "app=demo"}).Run(ctx, mockCM)
+		assert.EqualError(t, err, "at least one kind is required")
+
+		_, err = (&PruneByLabel{LabelSelector: "app in (", Kinds: []string{"Deployment"}}).Run(ctx, mockCM)
+		assert.ErrorContains(t, err, "invalid label selector")
+	})
+}
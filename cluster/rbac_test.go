@@ -3,134 +3,134 @@ package cluster
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/basebandit/kai/testmocks"
 	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
-	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
-func TestRBACRoles(t *testing.T) {
+func TestRBACAttachPullSecret(t *testing.T) {
 	ctx := context.Background()
-	role := &rbacv1.Role{
-		ObjectMeta: metav1.ObjectMeta{Name: "reader", Namespace: defaultNamespace},
-		Rules: []rbacv1.PolicyRule{{
-			APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}, ResourceNames: []string{"p1"},
-		}},
-	}
-	fakeClient := fake.NewSimpleClientset(role)
-	mockCM := testmocks.NewMockClusterManager()
-	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
-	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-
-	list, err := (&RBAC{}).ListRoles(ctx, mockCM, false)
-	assert.NoError(t, err)
-	assert.Contains(t, list, "reader")
-
-	all, err := (&RBAC{}).ListRoles(ctx, mockCM, true)
-	assert.NoError(t, err)
-	assert.Contains(t, all, "reader")
-
-	get, err := (&RBAC{Name: "reader"}).GetRole(ctx, mockCM)
-	assert.NoError(t, err)
-	assert.Contains(t, get, "pods")
-	assert.Contains(t, get, "p1")
-
-	_, err = (&RBAC{}).GetRole(ctx, mockCM)
-	assert.Error(t, err)
-}
-
-func TestRBACClusterRoles(t *testing.T) {
-	ctx := context.Background()
-	cr := &rbacv1.ClusterRole{
-		ObjectMeta: metav1.ObjectMeta{Name: "admin"},
-		Rules:      []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+	sa := &corev1.ServiceAccount{
+		ObjectMeta:       metav1.ObjectMeta{Name: "builder", Namespace: defaultNamespace},
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "existing-creds"}},
 	}
-	fakeClient := fake.NewSimpleClientset(cr)
-	mockCM := testmocks.NewMockClusterManager()
-	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
-
-	list, err := (&RBAC{}).ListClusterRoles(ctx, mockCM)
-	assert.NoError(t, err)
-	assert.Contains(t, list, "admin")
-
-	get, err := (&RBAC{Name: "admin"}).GetClusterRole(ctx, mockCM)
-	assert.NoError(t, err)
-	assert.Contains(t, get, "ClusterRole: admin")
-
-	_, err = (&RBAC{}).GetClusterRole(ctx, mockCM)
-	assert.Error(t, err)
-}
-
-func TestRBACBindings(t *testing.T) {
-	ctx := context.Background()
-	rb := &rbacv1.RoleBinding{
-		ObjectMeta: metav1.ObjectMeta{Name: "rb1", Namespace: defaultNamespace},
-		RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "reader"},
-		Subjects:   []rbacv1.Subject{{Kind: "ServiceAccount", Name: "sa1", Namespace: defaultNamespace}},
+	registry := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry-creds", Namespace: defaultNamespace},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`)},
 	}
-	crb := &rbacv1.ClusterRoleBinding{
-		ObjectMeta: metav1.ObjectMeta{Name: "crb1"},
-		RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "admin"},
-		Subjects:   []rbacv1.Subject{{Kind: "User", Name: "alice"}},
+	opaque := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: defaultNamespace},
+		Type:       corev1.SecretTypeOpaque,
 	}
-	fakeClient := fake.NewSimpleClientset(rb, crb)
+	fakeClient := fake.NewSimpleClientset(sa, registry, opaque)
 	mockCM := testmocks.NewMockClusterManager()
 	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
 	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
 
-	rbList, err := (&RBAC{}).ListRoleBindings(ctx, mockCM, false)
-	assert.NoError(t, err)
-	assert.Contains(t, rbList, "rb1")
-
-	_, err = (&RBAC{}).ListRoleBindings(ctx, mockCM, true)
-	assert.NoError(t, err)
-
-	rbGet, err := (&RBAC{Name: "rb1"}).GetRoleBinding(ctx, mockCM)
-	assert.NoError(t, err)
-	assert.Contains(t, rbGet, "ServiceAccount:default/sa1")
-
-	_, err = (&RBAC{}).GetRoleBinding(ctx, mockCM)
-	assert.Error(t, err)
-
-	crbList, err := (&RBAC{}).ListClusterRoleBindings(ctx, mockCM)
-	assert.NoError(t, err)
-	assert.Contains(t, crbList, "crb1")
-
-	crbGet, err := (&RBAC{Name: "crb1"}).GetClusterRoleBinding(ctx, mockCM)
-	assert.NoError(t, err)
-	assert.Contains(t, crbGet, "User:alice")
+	t.Run("RegistrySecret", func(t *testing.T) {
+		result, err := (&RBAC{Name: "builder"}).AttachPullSecret(ctx, mockCM, "registry-creds")
+		assert.NoError(t, err)
+		assert.Equal(t, `Image pull secret "registry-creds" attached to ServiceAccount "builder" in namespace "default"`, result)
+
+		got, err := fakeClient.CoreV1().ServiceAccounts(defaultNamespace).Get(ctx, "builder", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []corev1.LocalObjectReference{{Name: "existing-creds"}, {Name: "registry-creds"}}, got.ImagePullSecrets)
+
+		again, err := (&RBAC{Name: "builder"}).AttachPullSecret(ctx, mockCM, "registry-creds")
+		assert.NoError(t, err)
+		assert.Contains(t, again, "already uses image pull secret")
+	})
+
+	t.Run("RejectsNonDockerConfigSecret", func(t *testing.T) {
+		_, err := (&RBAC{Name: "builder"}).AttachPullSecret(ctx, mockCM, "app-config")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `secret "app-config" has type "Opaque"`)
+	})
+
+	t.Run("MissingSecret", func(t *testing.T) {
+		_, err := (&RBAC{Name: "builder"}).AttachPullSecret(ctx, mockCM, "nope")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `failed to get secret "nope"`)
+	})
+
+	t.Run("MissingServiceAccount", func(t *testing.T) {
+		_, err := (&RBAC{Name: "ghost"}).AttachPullSecret(ctx, mockCM, "registry-creds")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `failed to get service account "ghost"`)
+	})
+}
 
-	_, err = (&RBAC{}).GetClusterRoleBinding(ctx, mockCM)
-	assert.Error(t, err)
+// tokenReactor answers TokenRequests the way the API server would, echoing
+// the requested expiration back in the status.
+func tokenReactor(token string, now time.Time) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		req := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenRequest).DeepCopy()
+		req.Status = authenticationv1.TokenRequestStatus{
+			Token:               token,
+			ExpirationTimestamp: metav1.NewTime(now.Add(time.Duration(*req.Spec.ExpirationSeconds) * time.Second)),
+		}
+		return true, req, nil
+	}
 }
 
-func TestRBACServiceAccounts(t *testing.T) {
+func TestRBACCreateToken(t *testing.T) {
 	ctx := context.Background()
-	automount := true
-	sa := &corev1.ServiceAccount{
-		ObjectMeta:                   metav1.ObjectMeta{Name: "sa1", Namespace: defaultNamespace},
-		Secrets:                      []corev1.ObjectReference{{Name: "sa1-token"}},
-		AutomountServiceAccountToken: &automount,
-	}
-	fakeClient := fake.NewSimpleClientset(sa)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	fakeClient := fake.NewSimpleClientset(&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "deployer", Namespace: defaultNamespace}})
+	fakeClient.PrependReactor("create", "serviceaccounts", tokenReactor("eyJhbGciOi.test.token", now))
 	mockCM := testmocks.NewMockClusterManager()
 	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
 	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
 
-	list, err := (&RBAC{}).ListServiceAccounts(ctx, mockCM, false)
-	assert.NoError(t, err)
-	assert.Contains(t, list, "sa1")
-
-	_, err = (&RBAC{}).ListServiceAccounts(ctx, mockCM, true)
-	assert.NoError(t, err)
-
-	get, err := (&RBAC{Name: "sa1"}).GetServiceAccount(ctx, mockCM)
-	assert.NoError(t, err)
-	assert.Contains(t, get, "sa1-token")
-
-	_, err = (&RBAC{}).GetServiceAccount(ctx, mockCM)
-	assert.Error(t, err)
+	t.Run("DefaultExpiration", func(t *testing.T) {
+		result, err := (&RBAC{Name: "deployer"}).CreateToken(ctx, mockCM, 0)
+		assert.NoError(t, err)
+		assert.Contains(t, result, `SENSITIVE: bearer token for ServiceAccount "deployer" in namespace "default"`)
+		assert.Contains(t, result, "Expires: 2025-01-02T04:04:05Z")
+		assert.Contains(t, result, "Token: eyJhbGciOi.test.token")
+
+		var requested int64
+		for _, action := range fakeClient.Actions() {
+			if action.GetVerb() == "create" && action.GetSubresource() == "token" {
+				requested = *action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenRequest).Spec.ExpirationSeconds
+			}
+		}
+		assert.Equal(t, int64(3600), requested)
+	})
+
+	t.Run("CustomExpiration", func(t *testing.T) {
+		result, err := (&RBAC{Name: "deployer"}).CreateToken(ctx, mockCM, 30*time.Minute)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Expires: 2025-01-02T03:34:05Z")
+	})
+
+	t.Run("ExpirationOutOfRange", func(t *testing.T) {
+		_, err := (&RBAC{Name: "deployer"}).CreateToken(ctx, mockCM, time.Minute)
+		assert.EqualError(t, err, "invalid duration 1m0s: must be between 10m0s and 24h0m0s")
+
+		_, err = (&RBAC{Name: "deployer"}).CreateToken(ctx, mockCM, 48*time.Hour)
+		assert.Error(t, err)
+	})
+
+	t.Run("MissingServiceAccount", func(t *testing.T) {
+		_, err := (&RBAC{Name: "ghost"}).CreateToken(ctx, mockCM, 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `failed to get service account "ghost"`)
+	})
+
+	t.Run("MissingName", func(t *testing.T) {
+		_, err := (&RBAC{}).CreateToken(ctx, mockCM, 0)
+		assert.EqualError(t, err, "service account name is required")
+	})
 }
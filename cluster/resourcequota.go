@@ -0,0 +1,166 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceQuota represents an operation target for a namespace's
+// ResourceQuota.
+type ResourceQuota struct {
+	Name      string
+	Namespace string
+}
+
+// Get shows the quota's hard limits against current usage, flagging the
+// resources that are used up, since those are what make the API server
+// reject new pods.
+func (r *ResourceQuota) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if r.Name == "" {
+		return "", errors.New("resource quota name is required")
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	quota, err := client.CoreV1().ResourceQuotas(r.Namespace).Get(timeoutCtx, r.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("resource quota %q not found in namespace %q", r.Name, r.Namespace)
+		}
+		return "", fmt.Errorf("failed to get resource quota %q: %w", r.Name, err)
+	}
+
+	return formatResourceQuota(quota), nil
+}
+
+// List lists the quotas in the namespace, or in all namespaces, with used
+// and hard amounts in the layout of kubectl get resourcequota.
+func (r *ResourceQuota) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := r.Namespace
+	if allNamespaces {
+		namespace = ""
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	quotas, err := client.CoreV1().ResourceQuotas(namespace).List(timeoutCtx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list resource quotas: %w", err)
+	}
+
+	if len(quotas.Items) == 0 {
+		if allNamespaces {
+			return "No resource quotas found in any namespace", nil
+		}
+		return fmt.Sprintf("No resource quotas found in namespace %q", r.Namespace), nil
+	}
+
+	var sb strings.Builder
+	if allNamespaces {
+		sb.WriteString("Resource quotas across all namespaces:\n")
+	} else {
+		fmt.Fprintf(&sb, "Resource quotas in namespace %q:\n", r.Namespace)
+	}
+	for _, quota := range quotas.Items {
+		name := quota.Name
+		if allNamespaces {
+			name = quota.Namespace + "/" + name
+		}
+		usage := make([]string, 0, len(quota.Status.Hard))
+		for _, resource := range sortedResourceNames(quota.Status.Hard) {
+			usage = append(usage, fmt.Sprintf("%s: %s/%s", resource, quotaUsed(quota.Status.Used, resource), resourceAmount(quota.Status.Hard, resource)))
+		}
+		if len(usage) == 0 {
+			usage = append(usage, "<not yet computed>")
+		}
+		fmt.Fprintf(&sb, "• %s: %s, Age=%s\n", name, strings.Join(usage, ", "), formatDuration(time.Since(quota.CreationTimestamp.Time)))
+	}
+	fmt.Fprintf(&sb, "\nTotal: %d resource quota(s)", len(quotas.Items))
+	return sb.String(), nil
+}
+
+// formatResourceQuota renders a quota as a used/hard table in the layout of
+// kubectl describe resourcequota. The quota controller fills in status, so
+// a quota created moments ago falls back to the hard limits in its spec.
+func formatResourceQuota(quota *corev1.ResourceQuota) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "ResourceQuota: %s\n", quota.Name)
+	fmt.Fprintf(&sb, "Namespace: %s\n", quota.Namespace)
+	if len(quota.Spec.Scopes) > 0 {
+		scopes := make([]string, 0, len(quota.Spec.Scopes))
+		for _, scope := range quota.Spec.Scopes {
+			scopes = append(scopes, string(scope))
+		}
+		fmt.Fprintf(&sb, "Scopes: %s\n", strings.Join(scopes, ", "))
+	}
+
+	hard := quota.Status.Hard
+	if len(hard) == 0 {
+		hard = quota.Spec.Hard
+	}
+	if len(hard) == 0 {
+		sb.WriteString("No hard limits set\n")
+		return strings.TrimRight(sb.String(), "\n")
+	}
+
+	var exhausted []string
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  RESOURCE\tUSED\tHARD")
+	for _, resource := range sortedResourceNames(hard) {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", resource, quotaUsed(quota.Status.Used, resource), resourceAmount(hard, resource))
+		if used, ok := quota.Status.Used[resource]; ok && used.Cmp(hard[resource]) >= 0 {
+			exhausted = append(exhausted, string(resource))
+		}
+	}
+	_ = w.Flush()
+
+	if len(exhausted) > 0 {
+		fmt.Fprintf(&sb, "At limit: %s (new objects requesting these are rejected)\n", strings.Join(exhausted, ", "))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// quotaUsed is the used amount of a resource, or 0 before the quota
+// controller has reported usage.
+func quotaUsed(used corev1.ResourceList, resource corev1.ResourceName) string {
+	if _, ok := used[resource]; !ok {
+		return "0"
+	}
+	return resourceAmount(used, resource)
+}
+
+func resourceAmount(list corev1.ResourceList, resource corev1.ResourceName) string {
+	quantity := list[resource]
+	return quantity.String()
+}
+
+func sortedResourceNames(list corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(list))
+	for name := range list {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
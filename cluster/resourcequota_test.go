@@ -0,0 +1,155 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResourceQuotaOperations(t *testing.T) {
+	ctx := context.Background()
+
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: testNamespace},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{
+				corev1.ResourcePods:           resource.MustParse("10"),
+				corev1.ResourceRequestsCPU:    resource.MustParse("2"),
+				corev1.ResourceRequestsMemory: resource.MustParse("4Gi"),
+			},
+		},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{
+				corev1.ResourcePods:           resource.MustParse("10"),
+				corev1.ResourceRequestsCPU:    resource.MustParse("2"),
+				corev1.ResourceRequestsMemory: resource.MustParse("4Gi"),
+			},
+			Used: corev1.ResourceList{
+				corev1.ResourcePods:        resource.MustParse("4"),
+				corev1.ResourceRequestsCPU: resource.MustParse("2"),
+			},
+		},
+	}
+	fresh := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "objects", Namespace: "default"},
+		Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceConfigMaps: resource.MustParse("5")}},
+	}
+	setup := func() *testmocks.MockClusterManager {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(quota, fresh), nil)
+		return mockCM
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		result, err := (&ResourceQuota{Name: "compute", Namespace: testNamespace}).Get(ctx, setup())
+		require.NoError(t, err)
+		assert.Equal(t, "ResourceQuota: compute\n"+
+			"Namespace: test-namespace\n"+
+			"  RESOURCE         USED  HARD\n"+
+			"  pods             4     10\n"+
+			"  requests.cpu     2     2\n"+
+			"  requests.memory  0     4Gi\n"+
+			"At limit: requests.cpu (new objects requesting these are rejected)", result)
+	})
+
+	t.Run("GetBeforeUsageIsComputed", func(t *testing.T) {
+		result, err := (&ResourceQuota{Name: "objects", Namespace: "default"}).Get(ctx, setup())
+		require.NoError(t, err)
+		assert.Contains(t, result, "configmaps  0     5")
+		assert.NotContains(t, result, "At limit")
+	})
+
+	t.Run("GetNotFound", func(t *testing.T) {
+		_, err := (&ResourceQuota{Name: "missing", Namespace: testNamespace}).Get(ctx, setup())
+		assert.EqualError(t, err, `resource quota "missing" not found in namespace "test-namespace"`)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		result, err := (&ResourceQuota{Namespace: testNamespace}).List(ctx, setup(), false)
+		require.NoError(t, err)
+		assert.Contains(t, result, `Resource quotas in namespace "test-namespace":`)
+		assert.Contains(t, result, "• compute: pods: 4/10, requests.cpu: 2/2, requests.memory: 0/4Gi, Age=")
+		assert.NotContains(t, result, "objects")
+		assert.Contains(t, result, "Total: 1 resource quota(s)")
+	})
+
+	t.Run("ListAllNamespaces", func(t *testing.T) {
+		result, err := (&ResourceQuota{}).List(ctx, setup(), true)
+		require.NoError(t, err)
+		assert.Contains(t, result, "• test-namespace/compute:")
+		assert.Contains(t, result, "• default/objects: <not yet computed>")
+	})
+
+	t.Run("ListEmpty", func(t *testing.T) {
+		result, err := (&ResourceQuota{Namespace: "empty"}).List(ctx, setup(), false)
+		require.NoError(t, err)
+		assert.Equal(t, `No resource quotas found in namespace "empty"`, result)
+	})
+}
+
+func TestLimitRangeOperations(t *testing.T) {
+	ctx := context.Background()
+
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: testNamespace},
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{
+				{
+					Type:           corev1.LimitTypeContainer,
+					Max:            corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+					Default:        corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("512Mi")},
+					DefaultRequest: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("128Mi")},
+				},
+				{
+					Type: corev1.LimitTypePersistentVolumeClaim,
+					Min:  corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+				},
+			},
+		},
+	}
+	setup := func() *testmocks.MockClusterManager {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(limitRange), nil)
+		return mockCM
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		result, err := (&LimitRange{Name: "defaults", Namespace: testNamespace}).Get(ctx, setup())
+		require.NoError(t, err)
+		assert.Equal(t, "LimitRange: defaults\n"+
+			"Namespace: test-namespace\n"+
+			"  TYPE                   RESOURCE  MIN  MAX  DEFAULT REQUEST  DEFAULT LIMIT  MAX LIMIT/REQUEST RATIO\n"+
+			"  Container              cpu       -    2    100m             500m           -\n"+
+			"  Container              memory    -    -    128Mi            512Mi          -\n"+
+			"  PersistentVolumeClaim  storage   1Gi  -    -                -              -", result)
+	})
+
+	t.Run("GetNotFound", func(t *testing.T) {
+		_, err := (&LimitRange{Name: "missing", Namespace: testNamespace}).Get(ctx, setup())
+		assert.EqualError(t, err, `limit range "missing" not found in namespace "test-namespace"`)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		result, err := (&LimitRange{Namespace: testNamespace}).List(ctx, setup(), false)
+		require.NoError(t, err)
+		assert.Contains(t, result, "• defaults: Types=Container, PersistentVolumeClaim, Age=")
+		assert.Contains(t, result, "Total: 1 limit range(s)")
+	})
+
+	t.Run("ListEmpty", func(t *testing.T) {
+		result, err := (&LimitRange{}).List(ctx, setup(), true)
+		require.NoError(t, err)
+		assert.Contains(t, result, "• test-namespace/defaults:")
+
+		result, err = (&LimitRange{Namespace: "empty"}).List(ctx, setup(), false)
+		require.NoError(t, err)
+		assert.Equal(t, `No limit ranges found in namespace "empty"`, result)
+	})
+}
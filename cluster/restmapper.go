@@ -0,0 +1,48 @@
+package cluster
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+)
+
+// cachedRESTMapper resolves kinds to resources from discovery data fetched
+// once and kept in memory, so dynamic operations do not rediscover every API
+// group on each call. A kind that is not found triggers one refresh and
+// retry, so CRDs installed after the cache was filled still resolve.
+type cachedRESTMapper struct {
+	*restmapper.DeferredDiscoveryRESTMapper
+}
+
+func newCachedRESTMapper(disc discovery.DiscoveryInterface) *cachedRESTMapper {
+	return &cachedRESTMapper{restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(disc))}
+}
+
+func (m *cachedRESTMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	mapping, err := m.DeferredDiscoveryRESTMapper.RESTMapping(gk, versions...)
+	if meta.IsNoMatchError(err) {
+		m.Reset()
+		mapping, err = m.DeferredDiscoveryRESTMapper.RESTMapping(gk, versions...)
+	}
+	return mapping, err
+}
+
+func (m *cachedRESTMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	gvk, err := m.DeferredDiscoveryRESTMapper.KindFor(resource)
+	if meta.IsNoMatchError(err) {
+		m.Reset()
+		gvk, err = m.DeferredDiscoveryRESTMapper.KindFor(resource)
+	}
+	return gvk, err
+}
+
+func (m *cachedRESTMapper) ResourceFor(resource schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	gvr, err := m.DeferredDiscoveryRESTMapper.ResourceFor(resource)
+	if meta.IsNoMatchError(err) {
+		m.Reset()
+		gvr, err = m.DeferredDiscoveryRESTMapper.ResourceFor(resource)
+	}
+	return gvr, err
+}
@@ -0,0 +1,112 @@
+package cluster
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// DefaultAPIRetries is how many times a read against the Kubernetes API is
+// retried after a transient failure before the error reaches the tool.
+const DefaultAPIRetries = 3
+
+// retryBackoff spaces retries 200ms, 400ms, 800ms, ... apart. The client's
+// request timeout still bounds the whole exchange, retries included.
+var retryBackoff = wait.Backoff{
+	Duration: 200 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+	Cap:      5 * time.Second,
+}
+
+// retryTransport retries reads (GET and HEAD) that fail with a dropped or
+// refused connection, or that the API server or a proxy in front of it
+// answers with 429, 502, 503 or 504. Writes are never retried here: a
+// request that reached the server may have been applied. Responses that
+// carry Retry-After are passed through, since client-go already waits and
+// retries those itself.
+type retryTransport struct {
+	next    http.RoundTripper
+	retries int
+	backoff wait.Backoff
+}
+
+// newRetryTransport returns a rest.Config WrapTransport func, or nil when
+// retries is not positive.
+func newRetryTransport(retries int) func(http.RoundTripper) http.RoundTripper {
+	if retries <= 0 {
+		return nil
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &retryTransport{next: next, retries: retries, backoff: retryBackoff}
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Connection upgrades (exec and attach over WebSocket) are GETs too, but
+	// they open a stream rather than read, and the executor handles their
+	// failures itself.
+	if (req.Method != http.MethodGet && req.Method != http.MethodHead) || req.Header.Get("Upgrade") != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	backoff := t.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.retries || !retriable(req, resp, err) {
+			return resp, err
+		}
+
+		delay := backoff.Step()
+		slog.Debug("retrying Kubernetes API request",
+			slog.String("url", req.URL.Redacted()),
+			slog.Int("attempt", attempt+1),
+			slog.Duration("delay", delay),
+			slog.String("reason", retryReason(resp, err)),
+		)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			_ = resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func retriable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		var netErr net.Error
+		return utilnet.IsConnectionReset(err) ||
+			utilnet.IsConnectionRefused(err) ||
+			utilnet.IsProbableEOF(err) ||
+			(errors.As(err, &netErr) && netErr.Timeout())
+	}
+	if resp.Header.Get("Retry-After") != "" {
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func retryReason(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return resp.Status
+}
@@ -0,0 +1,137 @@
+package cluster
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// flakyAPIServer answers the first failures requests with status and the
+// rest with an empty namespace list. It counts every request it receives.
+func flakyAPIServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"NamespaceList","apiVersion":"v1","items":[]}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func retryingClient(t *testing.T, host string, retries int) kubernetes.Interface {
+	t.Helper()
+	original := retryBackoff
+	retryBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 10}
+	t.Cleanup(func() { retryBackoff = original })
+
+	config := &rest.Config{Host: host}
+	New(WithAPIRetries(retries)).configureClient(config)
+	client, err := kubernetes.NewForConfig(config)
+	require.NoError(t, err)
+	return client
+}
+
+func TestRetryTransport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("RetriesTransientReads", func(t *testing.T) {
+		srv, calls := flakyAPIServer(t, 2, http.StatusServiceUnavailable)
+		client := retryingClient(t, srv.URL, 3)
+
+		_, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("GivesUpAfterRetries", func(t *testing.T) {
+		srv, calls := flakyAPIServer(t, 10, http.StatusBadGateway)
+		client := retryingClient(t, srv.URL, 2)
+
+		_, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		assert.Error(t, err)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("DisabledWithZero", func(t *testing.T) {
+		srv, calls := flakyAPIServer(t, 1, http.StatusServiceUnavailable)
+		client := retryingClient(t, srv.URL, 0)
+
+		_, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("DoesNotRetryPermanentErrors", func(t *testing.T) {
+		srv, calls := flakyAPIServer(t, 1, http.StatusForbidden)
+		client := retryingClient(t, srv.URL, 3)
+
+		_, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		assert.True(t, apierrors.IsForbidden(err))
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("DoesNotRetryWrites", func(t *testing.T) {
+		srv, calls := flakyAPIServer(t, 1, http.StatusServiceUnavailable)
+		client := retryingClient(t, srv.URL, 3)
+
+		err := client.CoreV1().Namespaces().Delete(ctx, "demo", metav1.DeleteOptions{})
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("DoesNotRetryUpgrades", func(t *testing.T) {
+		srv, calls := flakyAPIServer(t, 1, http.StatusServiceUnavailable)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set("Upgrade", "websocket")
+
+		resp, err := newRetryTransport(3)(http.DefaultTransport).RoundTrip(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("RetriesRefusedConnections", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+
+		original := retryBackoff
+		retryBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 10}
+		defer func() { retryBackoff = original }()
+
+		transport := &countingTransport{next: http.DefaultTransport}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, closed.URL, nil)
+		require.NoError(t, err)
+
+		_, err = newRetryTransport(2)(transport).RoundTrip(req)
+		assert.Error(t, err)
+		assert.Equal(t, int32(3), transport.calls.Load())
+	})
+}
+
+type countingTransport struct {
+	next  http.RoundTripper
+	calls atomic.Int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.calls.Add(1)
+	return c.next.RoundTrip(req)
+}
@@ -0,0 +1,379 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/basebandit/kai"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Role represents an operation target for a namespaced Role.
+type Role struct {
+	Name      string
+	Namespace string
+	Rules     []interface{}
+	Labels    map[string]interface{}
+	DryRun    bool
+}
+
+// Create creates the Role from its rules.
+func (r *Role) Create(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if r.Name == "" {
+		return "", errors.New("role name is required")
+	}
+	rules, err := parsePolicyRules(r.Rules, false)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := r.namespace(ctx, cm)
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.Name,
+			Namespace: namespace,
+			Labels:    convertToStringMap(r.Labels),
+		},
+		Rules: rules,
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if _, err := client.RbacV1().Roles(namespace).Create(timeoutCtx, role, metav1.CreateOptions{DryRun: dryRunOption(r.DryRun)}); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return "", fmt.Errorf("role %q already exists in namespace %q", r.Name, namespace)
+		}
+		return "", fmt.Errorf("failed to create role %q: %w", r.Name, err)
+	}
+
+	slog.Info("role created",
+		slog.String("name", r.Name),
+		slog.String("namespace", namespace),
+	)
+	result := fmt.Sprintf("Role %q created successfully in namespace %q with %d rule(s)", r.Name, namespace, len(rules))
+	return dryRunResult(result, r.DryRun), nil
+}
+
+// Get describes the Role with its rules as a table.
+func (r *Role) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if r.Name == "" {
+		return "", errors.New("role name is required")
+	}
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+	namespace := r.namespace(ctx, cm)
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	role, err := client.RbacV1().Roles(namespace).Get(timeoutCtx, r.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("role %q not found in namespace %q: %w", r.Name, namespace, err)
+		}
+		return "", fmt.Errorf("failed to get role %q: %w", r.Name, err)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Role: %s\nNamespace: %s\nAge: %s\n", role.Name, role.Namespace, formatDuration(time.Since(role.CreationTimestamp.Time)))
+	sb.WriteString(formatPolicyRules(role.Rules))
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// List lists Roles in the namespace, or in all namespaces.
+func (r *Role) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+	ns := ""
+	if !allNamespaces {
+		ns = r.namespace(ctx, cm)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	roles, err := client.RbacV1().Roles(ns).List(timeoutCtx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list roles: %w", err)
+	}
+	if len(roles.Items) == 0 {
+		return "No roles found", nil
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Roles (%d):\n", len(roles.Items))
+	for i := range roles.Items {
+		role := roles.Items[i]
+		name := role.Name
+		if allNamespaces {
+			name = fmt.Sprintf("%s/%s", role.Namespace, role.Name)
+		}
+		fmt.Fprintf(&sb, "• %s\trules: %d\tage: %s\n", name, len(role.Rules), formatDuration(time.Since(role.CreationTimestamp.Time)))
+	}
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// Delete removes the Role. RoleBindings that reference it stay behind but
+// no longer grant anything.
+func (r *Role) Delete(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if r.Name == "" {
+		return "", errors.New("role name is required for deletion")
+	}
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+	namespace := r.namespace(ctx, cm)
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if err := client.RbacV1().Roles(namespace).Delete(timeoutCtx, r.Name, metav1.DeleteOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("role %q not found in namespace %q: %w", r.Name, namespace, err)
+		}
+		return "", fmt.Errorf("failed to delete role %q: %w", r.Name, err)
+	}
+
+	slog.Info("role deleted",
+		slog.String("name", r.Name),
+		slog.String("namespace", namespace),
+	)
+	return fmt.Sprintf("Role %q deleted successfully from namespace %q", r.Name, namespace), nil
+}
+
+func (r *Role) namespace(ctx context.Context, cm kai.ClusterManager) string {
+	if r.Namespace != "" {
+		return r.Namespace
+	}
+	return kai.CurrentNamespace(ctx, cm)
+}
+
+// ClusterRole represents an operation target for a ClusterRole.
+type ClusterRole struct {
+	Name   string
+	Rules  []interface{}
+	Labels map[string]interface{}
+	DryRun bool
+}
+
+// Create creates the ClusterRole from its rules, which unlike Role rules
+// may grant nonResourceURLs.
+func (r *ClusterRole) Create(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if r.Name == "" {
+		return "", errors.New("cluster role name is required")
+	}
+	rules, err := parsePolicyRules(r.Rules, true)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	role := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   r.Name,
+			Labels: convertToStringMap(r.Labels),
+		},
+		Rules: rules,
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if _, err := client.RbacV1().ClusterRoles().Create(timeoutCtx, role, metav1.CreateOptions{DryRun: dryRunOption(r.DryRun)}); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return "", fmt.Errorf("cluster role %q already exists", r.Name)
+		}
+		return "", fmt.Errorf("failed to create cluster role %q: %w", r.Name, err)
+	}
+
+	slog.Info("cluster role created", slog.String("name", r.Name))
+	result := fmt.Sprintf("ClusterRole %q created successfully with %d rule(s)", r.Name, len(rules))
+	return dryRunResult(result, r.DryRun), nil
+}
+
+// Get describes the ClusterRole with its rules as a table.
+func (r *ClusterRole) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if r.Name == "" {
+		return "", errors.New("cluster role name is required")
+	}
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	role, err := client.RbacV1().ClusterRoles().Get(timeoutCtx, r.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("cluster role %q not found: %w", r.Name, err)
+		}
+		return "", fmt.Errorf("failed to get cluster role %q: %w", r.Name, err)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "ClusterRole: %s\nAge: %s\n", role.Name, formatDuration(time.Since(role.CreationTimestamp.Time)))
+	if role.AggregationRule != nil {
+		sb.WriteString("Aggregated: rules are filled in from ClusterRoles matching its aggregation selectors\n")
+	}
+	sb.WriteString(formatPolicyRules(role.Rules))
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// List lists all ClusterRoles; allNamespaces is ignored.
+func (r *ClusterRole) List(ctx context.Context, cm kai.ClusterManager, _ bool) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	roles, err := client.RbacV1().ClusterRoles().List(timeoutCtx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list cluster roles: %w", err)
+	}
+	if len(roles.Items) == 0 {
+		return "No cluster roles found", nil
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "ClusterRoles (%d):\n", len(roles.Items))
+	for i := range roles.Items {
+		role := roles.Items[i]
+		fmt.Fprintf(&sb, "• %s\trules: %d\tage: %s\n", role.Name, len(role.Rules), formatDuration(time.Since(role.CreationTimestamp.Time)))
+	}
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// Delete removes the ClusterRole. Bindings that reference it stay behind
+// but no longer grant anything.
+func (r *ClusterRole) Delete(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if r.Name == "" {
+		return "", errors.New("cluster role name is required for deletion")
+	}
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if err := client.RbacV1().ClusterRoles().Delete(timeoutCtx, r.Name, metav1.DeleteOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("cluster role %q not found: %w", r.Name, err)
+		}
+		return "", fmt.Errorf("failed to delete cluster role %q: %w", r.Name, err)
+	}
+
+	slog.Info("cluster role deleted", slog.String("name", r.Name))
+	return fmt.Sprintf("ClusterRole %q deleted successfully", r.Name), nil
+}
+
+// parsePolicyRules converts rule objects such as
+// {"apiGroups": ["apps"], "resources": ["deployments"], "verbs": ["get"]}.
+// apiGroups defaults to the core group. nonResourceURLs are only valid in
+// ClusterRoles.
+func parsePolicyRules(input []interface{}, allowNonResource bool) ([]rbacv1.PolicyRule, error) {
+	if len(input) == 0 {
+		return nil, errors.New("at least one rule is required")
+	}
+	rules := make([]rbacv1.PolicyRule, 0, len(input))
+	for i, item := range input {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("rule %d must be an object with apiGroups, resources and verbs", i+1)
+		}
+		rule := rbacv1.PolicyRule{}
+		for key, target := range map[string]*[]string{
+			"apiGroups":       &rule.APIGroups,
+			"resources":       &rule.Resources,
+			"verbs":           &rule.Verbs,
+			"resourceNames":   &rule.ResourceNames,
+			"nonResourceURLs": &rule.NonResourceURLs,
+		} {
+			raw, ok := fields[key]
+			if !ok || raw == nil {
+				continue
+			}
+			values, ok := raw.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("rule %d: %s must be an array of strings", i+1, key)
+			}
+			*target = convertToStringSlice(values)
+			if len(*target) != len(values) {
+				return nil, fmt.Errorf("rule %d: %s must be an array of strings", i+1, key)
+			}
+		}
+
+		if len(rule.Verbs) == 0 {
+			return nil, fmt.Errorf("rule %d: verbs is required", i+1)
+		}
+		if len(rule.NonResourceURLs) > 0 {
+			if !allowNonResource {
+				return nil, fmt.Errorf("rule %d: nonResourceURLs are only allowed in cluster roles", i+1)
+			}
+			if len(rule.Resources) > 0 {
+				return nil, fmt.Errorf("rule %d: a rule grants either resources or nonResourceURLs, not both", i+1)
+			}
+		} else {
+			if len(rule.Resources) == 0 {
+				return nil, fmt.Errorf("rule %d: resources is required", i+1)
+			}
+			if len(rule.APIGroups) == 0 {
+				rule.APIGroups = []string{""}
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// formatPolicyRules renders rules as a table in the layout of kubectl
+// describe role, with resources qualified by their API group.
+func formatPolicyRules(rules []rbacv1.PolicyRule) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Rules (%d):\n", len(rules))
+	if len(rules) == 0 {
+		return sb.String()
+	}
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  RESOURCES\tNON-RESOURCE URLS\tRESOURCE NAMES\tVERBS")
+	for _, rule := range rules {
+		var resources []string
+		for _, resource := range rule.Resources {
+			for _, group := range rule.APIGroups {
+				if group == "" {
+					resources = append(resources, resource)
+				} else {
+					resources = append(resources, resource+"."+group)
+				}
+			}
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", ruleColumn(resources), ruleColumn(rule.NonResourceURLs), ruleColumn(rule.ResourceNames), ruleColumn(rule.Verbs))
+	}
+	_ = w.Flush()
+	return sb.String()
+}
+
+func ruleColumn(values []string) string {
+	if len(values) == 0 {
+		return "[]"
+	}
+	return strings.Join(values, ",")
+}
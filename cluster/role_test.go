@@ -0,0 +1,152 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRole(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewSimpleClientset()
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+
+	rules := []interface{}{
+		map[string]interface{}{"resources": []interface{}{"pods", "pods/log"}, "verbs": []interface{}{"get", "list"}},
+		map[string]interface{}{"apiGroups": []interface{}{"apps"}, "resources": []interface{}{"deployments"}, "verbs": []interface{}{"get"}, "resourceNames": []interface{}{"web"}},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		result, err := (&Role{Name: "reader", Rules: rules, Labels: map[string]interface{}{"team": "a"}}).Create(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Equal(t, `Role "reader" created successfully in namespace "default" with 2 rule(s)`, result)
+
+		role, err := fakeClient.RbacV1().Roles(defaultNamespace).Get(ctx, "reader", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{""}, role.Rules[0].APIGroups)
+		assert.Equal(t, []string{"web"}, role.Rules[1].ResourceNames)
+		assert.Equal(t, "a", role.Labels["team"])
+
+		_, err = (&Role{Name: "reader", Rules: rules}).Create(ctx, mockCM)
+		assert.EqualError(t, err, `role "reader" already exists in namespace "default"`)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		result, err := (&Role{Name: "reader"}).Get(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Role: reader\nNamespace: default")
+		assert.Contains(t, result, "RESOURCES")
+		assert.Regexp(t, `pods,pods/log\s+\[\]\s+\[\]\s+get,list`, result)
+		assert.Regexp(t, `deployments\.apps\s+\[\]\s+web\s+get`, result)
+
+		_, err = (&Role{Name: "ghost"}).Get(ctx, mockCM)
+		assert.EqualError(t, err, `role "ghost" not found in namespace "default": roles.rbac.authorization.k8s.io "ghost" not found`)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		result, err := (&Role{}).List(ctx, mockCM, true)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "default/reader\trules: 2")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		result, err := (&Role{Name: "reader"}).Delete(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Equal(t, `Role "reader" deleted successfully from namespace "default"`, result)
+
+		_, err = (&Role{Name: "reader"}).Delete(ctx, mockCM)
+		assert.Error(t, err)
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		result, err := (&Role{Name: "preview", Rules: rules, DryRun: true}).Create(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "(dry run)")
+	})
+}
+
+func TestClusterRole(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewSimpleClientset(&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "admin"}})
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+	rules := []interface{}{
+		map[string]interface{}{"nonResourceURLs": []interface{}{"/healthz", "/metrics"}, "verbs": []interface{}{"get"}},
+	}
+
+	result, err := (&ClusterRole{Name: "probe", Rules: rules}).Create(ctx, mockCM)
+	assert.NoError(t, err)
+	assert.Equal(t, `ClusterRole "probe" created successfully with 1 rule(s)`, result)
+
+	get, err := (&ClusterRole{Name: "probe"}).Get(ctx, mockCM)
+	assert.NoError(t, err)
+	assert.Contains(t, get, "ClusterRole: probe")
+	assert.Regexp(t, `\[\]\s+/healthz,/metrics\s+\[\]\s+get`, get)
+
+	list, err := (&ClusterRole{}).List(ctx, mockCM, false)
+	assert.NoError(t, err)
+	assert.Contains(t, list, "ClusterRoles (2)")
+
+	deleted, err := (&ClusterRole{Name: "probe"}).Delete(ctx, mockCM)
+	assert.NoError(t, err)
+	assert.Equal(t, `ClusterRole "probe" deleted successfully`, deleted)
+
+	_, err = (&ClusterRole{Name: "probe"}).Get(ctx, mockCM)
+	assert.EqualError(t, err, `cluster role "probe" not found: clusterroles.rbac.authorization.k8s.io "probe" not found`)
+}
+
+func TestParsePolicyRules(t *testing.T) {
+	testCases := []struct {
+		name             string
+		rules            []interface{}
+		allowNonResource bool
+		expectedError    string
+	}{
+		{name: "Empty", expectedError: "at least one rule is required"},
+		{name: "NotObject", rules: []interface{}{"pods"}, expectedError: "rule 1 must be an object"},
+		{
+			name:          "MissingVerbs",
+			rules:         []interface{}{map[string]interface{}{"resources": []interface{}{"pods"}}},
+			expectedError: "rule 1: verbs is required",
+		},
+		{
+			name:          "MissingResources",
+			rules:         []interface{}{map[string]interface{}{"verbs": []interface{}{"get"}}},
+			expectedError: "rule 1: resources is required",
+		},
+		{
+			name:          "VerbsNotStrings",
+			rules:         []interface{}{map[string]interface{}{"resources": []interface{}{"pods"}, "verbs": []interface{}{float64(1)}}},
+			expectedError: "rule 1: verbs must be an array of strings",
+		},
+		{
+			name:          "NonResourceURLsInRole",
+			rules:         []interface{}{map[string]interface{}{"nonResourceURLs": []interface{}{"/healthz"}, "verbs": []interface{}{"get"}}},
+			expectedError: "rule 1: nonResourceURLs are only allowed in cluster roles",
+		},
+		{
+			name: "ResourcesAndNonResourceURLs",
+			rules: []interface{}{map[string]interface{}{
+				"resources": []interface{}{"pods"}, "nonResourceURLs": []interface{}{"/healthz"}, "verbs": []interface{}{"get"},
+			}},
+			allowNonResource: true,
+			expectedError:    "rule 1: a rule grants either resources or nonResourceURLs, not both",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parsePolicyRules(tc.rules, tc.allowNonResource)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedError)
+		})
+	}
+}
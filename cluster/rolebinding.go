@@ -0,0 +1,395 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/basebandit/kai"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RoleBinding represents an operation target for a namespaced RoleBinding.
+type RoleBinding struct {
+	Name      string
+	Namespace string
+	RoleRef   map[string]interface{}
+	Subjects  []interface{}
+	Labels    map[string]interface{}
+	DryRun    bool
+}
+
+// Create binds the subjects to a Role, or to a ClusterRole whose rules then
+// apply only within the binding's namespace. ServiceAccount subjects
+// default to the binding's namespace.
+func (b *RoleBinding) Create(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if b.Name == "" {
+		return "", errors.New("role binding name is required")
+	}
+	roleRef, err := parseRoleRef(b.RoleRef, "Role")
+	if err != nil {
+		return "", err
+	}
+
+	namespace := b.namespace(ctx, cm)
+	subjects, err := parseSubjects(b.Subjects, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      b.Name,
+			Namespace: namespace,
+			Labels:    convertToStringMap(b.Labels),
+		},
+		RoleRef:  roleRef,
+		Subjects: subjects,
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if _, err := client.RbacV1().RoleBindings(namespace).Create(timeoutCtx, binding, metav1.CreateOptions{DryRun: dryRunOption(b.DryRun)}); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return "", fmt.Errorf("role binding %q already exists in namespace %q", b.Name, namespace)
+		}
+		return "", fmt.Errorf("failed to create role binding %q: %w", b.Name, err)
+	}
+
+	slog.Info("role binding created",
+		slog.String("name", b.Name),
+		slog.String("namespace", namespace),
+	)
+	result := fmt.Sprintf("RoleBinding %q created successfully in namespace %q, binding %s/%s to %s",
+		b.Name, namespace, roleRef.Kind, roleRef.Name, formatSubjects(subjects))
+	return dryRunResult(result, b.DryRun), nil
+}
+
+// Get describes the RoleBinding with its subjects as a table.
+func (b *RoleBinding) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if b.Name == "" {
+		return "", errors.New("role binding name is required")
+	}
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+	namespace := b.namespace(ctx, cm)
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	binding, err := client.RbacV1().RoleBindings(namespace).Get(timeoutCtx, b.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("role binding %q not found in namespace %q: %w", b.Name, namespace, err)
+		}
+		return "", fmt.Errorf("failed to get role binding %q: %w", b.Name, err)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "RoleBinding: %s\nNamespace: %s\nAge: %s\nRole: %s/%s\n",
+		binding.Name, binding.Namespace, formatDuration(time.Since(binding.CreationTimestamp.Time)), binding.RoleRef.Kind, binding.RoleRef.Name)
+	sb.WriteString(formatSubjectTable(binding.Subjects))
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// List lists RoleBindings in the namespace, or in all namespaces.
+func (b *RoleBinding) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+	ns := ""
+	if !allNamespaces {
+		ns = b.namespace(ctx, cm)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	bindings, err := client.RbacV1().RoleBindings(ns).List(timeoutCtx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list role bindings: %w", err)
+	}
+	if len(bindings.Items) == 0 {
+		return "No role bindings found", nil
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "RoleBindings (%d):\n", len(bindings.Items))
+	for i := range bindings.Items {
+		binding := bindings.Items[i]
+		name := binding.Name
+		if allNamespaces {
+			name = fmt.Sprintf("%s/%s", binding.Namespace, binding.Name)
+		}
+		fmt.Fprintf(&sb, "• %s\trole: %s/%s\tsubjects: %s\n", name, binding.RoleRef.Kind, binding.RoleRef.Name, formatSubjects(binding.Subjects))
+	}
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// Delete removes the RoleBinding, revoking what it granted its subjects.
+func (b *RoleBinding) Delete(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if b.Name == "" {
+		return "", errors.New("role binding name is required for deletion")
+	}
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+	namespace := b.namespace(ctx, cm)
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if err := client.RbacV1().RoleBindings(namespace).Delete(timeoutCtx, b.Name, metav1.DeleteOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("role binding %q not found in namespace %q: %w", b.Name, namespace, err)
+		}
+		return "", fmt.Errorf("failed to delete role binding %q: %w", b.Name, err)
+	}
+
+	slog.Info("role binding deleted",
+		slog.String("name", b.Name),
+		slog.String("namespace", namespace),
+	)
+	return fmt.Sprintf("RoleBinding %q deleted successfully from namespace %q", b.Name, namespace), nil
+}
+
+func (b *RoleBinding) namespace(ctx context.Context, cm kai.ClusterManager) string {
+	if b.Namespace != "" {
+		return b.Namespace
+	}
+	return kai.CurrentNamespace(ctx, cm)
+}
+
+// ClusterRoleBinding represents an operation target for a
+// ClusterRoleBinding.
+type ClusterRoleBinding struct {
+	Name     string
+	RoleRef  map[string]interface{}
+	Subjects []interface{}
+	Labels   map[string]interface{}
+	DryRun   bool
+}
+
+// Create binds the subjects to a ClusterRole in every namespace.
+// ServiceAccount subjects must name their namespace.
+func (b *ClusterRoleBinding) Create(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if b.Name == "" {
+		return "", errors.New("cluster role binding name is required")
+	}
+	roleRef, err := parseRoleRef(b.RoleRef, "ClusterRole")
+	if err != nil {
+		return "", err
+	}
+	if roleRef.Kind != "ClusterRole" {
+		return "", fmt.Errorf("a cluster role binding can only reference a ClusterRole, not a %s", roleRef.Kind)
+	}
+	subjects, err := parseSubjects(b.Subjects, "")
+	if err != nil {
+		return "", err
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   b.Name,
+			Labels: convertToStringMap(b.Labels),
+		},
+		RoleRef:  roleRef,
+		Subjects: subjects,
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if _, err := client.RbacV1().ClusterRoleBindings().Create(timeoutCtx, binding, metav1.CreateOptions{DryRun: dryRunOption(b.DryRun)}); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return "", fmt.Errorf("cluster role binding %q already exists", b.Name)
+		}
+		return "", fmt.Errorf("failed to create cluster role binding %q: %w", b.Name, err)
+	}
+
+	slog.Info("cluster role binding created", slog.String("name", b.Name))
+	result := fmt.Sprintf("ClusterRoleBinding %q created successfully, binding ClusterRole/%s to %s",
+		b.Name, roleRef.Name, formatSubjects(subjects))
+	return dryRunResult(result, b.DryRun), nil
+}
+
+// Get describes the ClusterRoleBinding with its subjects as a table.
+func (b *ClusterRoleBinding) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if b.Name == "" {
+		return "", errors.New("cluster role binding name is required")
+	}
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	binding, err := client.RbacV1().ClusterRoleBindings().Get(timeoutCtx, b.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("cluster role binding %q not found: %w", b.Name, err)
+		}
+		return "", fmt.Errorf("failed to get cluster role binding %q: %w", b.Name, err)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "ClusterRoleBinding: %s\nAge: %s\nRole: %s/%s\n",
+		binding.Name, formatDuration(time.Since(binding.CreationTimestamp.Time)), binding.RoleRef.Kind, binding.RoleRef.Name)
+	sb.WriteString(formatSubjectTable(binding.Subjects))
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// List lists all ClusterRoleBindings; allNamespaces is ignored.
+func (b *ClusterRoleBinding) List(ctx context.Context, cm kai.ClusterManager, _ bool) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	bindings, err := client.RbacV1().ClusterRoleBindings().List(timeoutCtx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list cluster role bindings: %w", err)
+	}
+	if len(bindings.Items) == 0 {
+		return "No cluster role bindings found", nil
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "ClusterRoleBindings (%d):\n", len(bindings.Items))
+	for i := range bindings.Items {
+		binding := bindings.Items[i]
+		fmt.Fprintf(&sb, "• %s\trole: %s/%s\tsubjects: %s\n", binding.Name, binding.RoleRef.Kind, binding.RoleRef.Name, formatSubjects(binding.Subjects))
+	}
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// Delete removes the ClusterRoleBinding, revoking what it granted its
+// subjects.
+func (b *ClusterRoleBinding) Delete(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if b.Name == "" {
+		return "", errors.New("cluster role binding name is required for deletion")
+	}
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if err := client.RbacV1().ClusterRoleBindings().Delete(timeoutCtx, b.Name, metav1.DeleteOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("cluster role binding %q not found: %w", b.Name, err)
+		}
+		return "", fmt.Errorf("failed to delete cluster role binding %q: %w", b.Name, err)
+	}
+
+	slog.Info("cluster role binding deleted", slog.String("name", b.Name))
+	return fmt.Sprintf("ClusterRoleBinding %q deleted successfully", b.Name), nil
+}
+
+// parseRoleRef converts {"kind": "Role", "name": "reader"}, using
+// defaultKind when kind is omitted.
+func parseRoleRef(input map[string]interface{}, defaultKind string) (rbacv1.RoleRef, error) {
+	name, _ := input["name"].(string)
+	if name == "" {
+		return rbacv1.RoleRef{}, errors.New("role_ref.name is required")
+	}
+	kind, _ := input["kind"].(string)
+	if kind == "" {
+		kind = defaultKind
+	}
+	if kind != "Role" && kind != "ClusterRole" {
+		return rbacv1.RoleRef{}, fmt.Errorf("invalid role_ref.kind %q: must be Role or ClusterRole", kind)
+	}
+	return rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: kind, Name: name}, nil
+}
+
+// parseSubjects converts subject objects such as
+// {"kind": "ServiceAccount", "name": "deployer", "namespace": "ci"}.
+// ServiceAccounts without a namespace get defaultNamespace, and are
+// rejected when it is empty.
+func parseSubjects(input []interface{}, defaultNamespace string) ([]rbacv1.Subject, error) {
+	if len(input) == 0 {
+		return nil, errors.New("at least one subject is required")
+	}
+	subjects := make([]rbacv1.Subject, 0, len(input))
+	for i, item := range input {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("subject %d must be an object with kind and name", i+1)
+		}
+		kind, _ := fields["kind"].(string)
+		name, _ := fields["name"].(string)
+		namespace, _ := fields["namespace"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("subject %d: name is required", i+1)
+		}
+
+		subject := rbacv1.Subject{Kind: kind, Name: name}
+		switch kind {
+		case rbacv1.ServiceAccountKind:
+			if namespace == "" {
+				namespace = defaultNamespace
+			}
+			if namespace == "" {
+				return nil, fmt.Errorf("subject %d: ServiceAccount %q needs a namespace", i+1, name)
+			}
+			subject.Namespace = namespace
+		case rbacv1.UserKind, rbacv1.GroupKind:
+			subject.APIGroup = rbacv1.GroupName
+		default:
+			return nil, fmt.Errorf("subject %d: invalid kind %q: must be User, Group or ServiceAccount", i+1, kind)
+		}
+		subjects = append(subjects, subject)
+	}
+	return subjects, nil
+}
+
+func formatSubjects(subjects []rbacv1.Subject) string {
+	if len(subjects) == 0 {
+		return "<none>"
+	}
+	parts := make([]string, 0, len(subjects))
+	for _, s := range subjects {
+		if s.Namespace != "" {
+			parts = append(parts, fmt.Sprintf("%s:%s/%s", s.Kind, s.Namespace, s.Name))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s:%s", s.Kind, s.Name))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatSubjectTable renders subjects in the layout of kubectl describe
+// rolebinding.
+func formatSubjectTable(subjects []rbacv1.Subject) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Subjects (%d):\n", len(subjects))
+	if len(subjects) == 0 {
+		return sb.String()
+	}
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  KIND\tNAME\tNAMESPACE")
+	for _, s := range subjects {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", s.Kind, s.Name, s.Namespace)
+	}
+	_ = w.Flush()
+	return sb.String()
+}
@@ -0,0 +1,120 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRoleBinding(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewSimpleClientset()
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+
+	binding := &RoleBinding{
+		Name:    "read-pods",
+		RoleRef: map[string]interface{}{"name": "reader"},
+		Subjects: []interface{}{
+			map[string]interface{}{"kind": "ServiceAccount", "name": "ci"},
+			map[string]interface{}{"kind": "User", "name": "alice"},
+		},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		result, err := binding.Create(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Equal(t, `RoleBinding "read-pods" created successfully in namespace "default", binding Role/reader to ServiceAccount:default/ci, User:alice`, result)
+
+		got, err := fakeClient.RbacV1().RoleBindings(defaultNamespace).Get(ctx, "read-pods", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "reader"}, got.RoleRef)
+		assert.Equal(t, []rbacv1.Subject{
+			{Kind: "ServiceAccount", Name: "ci", Namespace: defaultNamespace},
+			{Kind: "User", Name: "alice", APIGroup: rbacv1.GroupName},
+		}, got.Subjects)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		result, err := (&RoleBinding{Name: "read-pods"}).Get(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "RoleBinding: read-pods\nNamespace: default")
+		assert.Contains(t, result, "Role: Role/reader")
+		assert.Regexp(t, `ServiceAccount\s+ci\s+default`, result)
+		assert.Regexp(t, `User\s+alice`, result)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		result, err := (&RoleBinding{}).List(ctx, mockCM, false)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "read-pods\trole: Role/reader\tsubjects: ServiceAccount:default/ci, User:alice")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		result, err := (&RoleBinding{Name: "read-pods"}).Delete(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Equal(t, `RoleBinding "read-pods" deleted successfully from namespace "default"`, result)
+
+		_, err = (&RoleBinding{Name: "read-pods"}).Get(ctx, mockCM)
+		assert.EqualError(t, err, `role binding "read-pods" not found in namespace "default": rolebindings.rbac.authorization.k8s.io "read-pods" not found`)
+	})
+
+	t.Run("InvalidRoleRefKind", func(t *testing.T) {
+		_, err := (&RoleBinding{Name: "x", RoleRef: map[string]interface{}{"kind": "Group", "name": "r"}, Subjects: binding.Subjects}).Create(ctx, mockCM)
+		assert.EqualError(t, err, `invalid role_ref.kind "Group": must be Role or ClusterRole`)
+	})
+
+	t.Run("InvalidSubjectKind", func(t *testing.T) {
+		_, err := (&RoleBinding{Name: "x", RoleRef: binding.RoleRef, Subjects: []interface{}{map[string]interface{}{"kind": "Robot", "name": "r2"}}}).Create(ctx, mockCM)
+		assert.EqualError(t, err, `subject 1: invalid kind "Robot": must be User, Group or ServiceAccount`)
+	})
+}
+
+func TestClusterRoleBinding(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewSimpleClientset()
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+	subjects := []interface{}{
+		map[string]interface{}{"kind": "Group", "name": "sre"},
+		map[string]interface{}{"kind": "ServiceAccount", "name": "monitor", "namespace": "observability"},
+	}
+
+	result, err := (&ClusterRoleBinding{Name: "sre-view", RoleRef: map[string]interface{}{"name": "view"}, Subjects: subjects}).Create(ctx, mockCM)
+	assert.NoError(t, err)
+	assert.Equal(t, `ClusterRoleBinding "sre-view" created successfully, binding ClusterRole/view to Group:sre, ServiceAccount:observability/monitor`, result)
+
+	get, err := (&ClusterRoleBinding{Name: "sre-view"}).Get(ctx, mockCM)
+	assert.NoError(t, err)
+	assert.Contains(t, get, "ClusterRoleBinding: sre-view")
+	assert.Regexp(t, `ServiceAccount\s+monitor\s+observability`, get)
+
+	list, err := (&ClusterRoleBinding{}).List(ctx, mockCM, false)
+	assert.NoError(t, err)
+	assert.Contains(t, list, "sre-view\trole: ClusterRole/view")
+
+	deleted, err := (&ClusterRoleBinding{Name: "sre-view"}).Delete(ctx, mockCM)
+	assert.NoError(t, err)
+	assert.Equal(t, `ClusterRoleBinding "sre-view" deleted successfully`, deleted)
+
+	t.Run("RejectsRole", func(t *testing.T) {
+		_, err := (&ClusterRoleBinding{Name: "x", RoleRef: map[string]interface{}{"kind": "Role", "name": "reader"}, Subjects: subjects}).Create(ctx, mockCM)
+		assert.EqualError(t, err, "a cluster role binding can only reference a ClusterRole, not a Role")
+	})
+
+	t.Run("ServiceAccountNeedsNamespace", func(t *testing.T) {
+		_, err := (&ClusterRoleBinding{
+			Name:     "x",
+			RoleRef:  map[string]interface{}{"name": "view"},
+			Subjects: []interface{}{map[string]interface{}{"kind": "ServiceAccount", "name": "monitor"}},
+		}).Create(ctx, mockCM)
+		assert.EqualError(t, err, `subject 1: ServiceAccount "monitor" needs a namespace`)
+	})
+}
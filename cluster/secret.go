@@ -4,10 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/basebandit/kai"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 )
@@ -21,6 +21,8 @@ type Secret struct {
 	StringData  map[string]interface{}
 	Labels      map[string]interface{}
 	Annotations map[string]interface{}
+	DryRun      bool
+	ShowDiff    bool
 }
 
 // Create creates a new Secret in the specified namespace.
@@ -31,7 +33,7 @@ func (s *Secret) Create(ctx context.Context, cm kai.ClusterManager) (string, err
 		return result, err
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -78,27 +80,27 @@ func (s *Secret) Create(ctx context.Context, cm kai.ClusterManager) (string, err
 		}
 	}
 
-	createdSecret, err := client.CoreV1().Secrets(s.Namespace).Create(timeoutCtx, secret, metav1.CreateOptions{})
+	createdSecret, err := client.CoreV1().Secrets(s.Namespace).Create(timeoutCtx, secret, metav1.CreateOptions{DryRun: dryRunOption(s.DryRun)})
 	if err != nil {
 		return result, fmt.Errorf("failed to create Secret: %w", err)
 	}
 
 	result = fmt.Sprintf("Secret %q created successfully in namespace %q", createdSecret.Name, createdSecret.Namespace)
-	return result, nil
+	return dryRunResult(result, s.DryRun), nil
 }
 
 // Get retrieves a Secret by name from the specified namespace.
 func (s *Secret) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
 
 	var secret *corev1.Secret
 	err = retry.OnError(retry.DefaultRetry, func(err error) bool {
-		return !strings.Contains(err.Error(), "not found")
+		return !apierrors.IsNotFound(err)
 	}, func() error {
 		var getErr error
 		secret, getErr = client.CoreV1().Secrets(s.Namespace).Get(ctx, s.Name, metav1.GetOptions{})
@@ -106,26 +108,29 @@ func (s *Secret) Get(ctx context.Context, cm kai.ClusterManager) (string, error)
 	})
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return result, fmt.Errorf("Secret %q not found in namespace %q", s.Name, s.Namespace)
+		if apierrors.IsNotFound(err) {
+			return result, fmt.Errorf("Secret %q not found in namespace %q: %w", s.Name, s.Namespace, err)
 		}
-		return result, fmt.Errorf("failed to get Secret %q: %v", s.Name, err)
+		return result, fmt.Errorf("failed to get Secret %q: %w", s.Name, err)
 	}
 
 	return formatSecret(secret), nil
 }
 
-// List retrieves all Secrets matching the specified criteria.
-func (s *Secret) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string) (string, error) {
+// List retrieves the Secrets matching the specified criteria, one page at a
+// time when limit is positive.
+func (s *Secret) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string, limit int64, continueToken string) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
 
 	listOptions := metav1.ListOptions{
 		LabelSelector: labelSelector,
+		Limit:         limit,
+		Continue:      continueToken,
 	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
@@ -152,7 +157,7 @@ func (s *Secret) List(ctx context.Context, cm kai.ClusterManager, allNamespaces
 		return result, fmt.Errorf("no Secrets found in namespace %q", s.Namespace)
 	}
 
-	return formatSecretList(secrets, allNamespaces), nil
+	return formatSecretList(secrets, allNamespaces) + continueHint(secrets.Continue), nil
 }
 
 // Delete removes a Secret by name from the specified namespace.
@@ -163,7 +168,7 @@ func (s *Secret) Delete(ctx context.Context, cm kai.ClusterManager) (string, err
 		return result, errors.New("Secret name is required for deletion")
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -194,7 +199,7 @@ func (s *Secret) Update(ctx context.Context, cm kai.ClusterManager) (string, err
 		return result, errors.New("Secret name is required for update")
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -207,6 +212,8 @@ func (s *Secret) Update(ctx context.Context, cm kai.ClusterManager) (string, err
 		return result, fmt.Errorf("Secret %q not found in namespace %q: %w", s.Name, s.Namespace, err)
 	}
 
+	before := existingSecret.DeepCopy()
+
 	if s.Data != nil {
 		existingSecret.Data = convertToSecretDataMap(s.Data)
 	}
@@ -233,13 +240,16 @@ func (s *Secret) Update(ctx context.Context, cm kai.ClusterManager) (string, err
 		}
 	}
 
-	updatedSecret, err := client.CoreV1().Secrets(s.Namespace).Update(timeoutCtx, existingSecret, metav1.UpdateOptions{})
+	updatedSecret, err := client.CoreV1().Secrets(s.Namespace).Update(timeoutCtx, existingSecret, metav1.UpdateOptions{DryRun: dryRunOption(s.DryRun)})
 	if err != nil {
 		return result, fmt.Errorf("failed to update Secret %q: %w", s.Name, err)
 	}
 
 	result = fmt.Sprintf("Secret %q updated successfully in namespace %q", updatedSecret.Name, updatedSecret.Namespace)
-	return result, nil
+	if s.ShowDiff {
+		result = withUpdateDiff(result, before, updatedSecret)
+	}
+	return dryRunResult(result, s.DryRun), nil
 }
 
 func (s *Secret) validate() error {
@@ -464,7 +464,7 @@ func testListSecrets(t *testing.T) {
 			mockCM := testmocks.NewMockClusterManager()
 			tc.setupMock(mockCM)
 
-			result, err := tc.secret.List(ctx, mockCM, tc.allNamespaces, tc.labelSelector)
+			result, err := tc.secret.List(ctx, mockCM, tc.allNamespaces, tc.labelSelector, 0, "")
 
 			if tc.expectedError != "" {
 				assert.Error(t, err)
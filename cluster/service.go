@@ -9,8 +9,11 @@ import (
 
 	"github.com/basebandit/kai"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/retry"
 )
 
@@ -26,6 +29,11 @@ type Service struct {
 	ExternalIPs     []string
 	ExternalName    string
 	SessionAffinity string
+	DryRun          bool
+	ShowDiff        bool
+	// IncludeEndpoints has Get also report the service's ready and
+	// not-ready backends from its EndpointSlices.
+	IncludeEndpoints bool
 }
 
 // ServicePort represents a service port configuration
@@ -41,7 +49,7 @@ type ServicePort struct {
 func (s *Service) Create(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -175,7 +183,7 @@ func (s *Service) Create(ctx context.Context, cm kai.ClusterManager) (string, er
 		return result, errors.New("at least one port must be specified")
 	}
 
-	createdService, err := client.CoreV1().Services(s.Namespace).Create(timeoutCtx, service, metav1.CreateOptions{})
+	createdService, err := client.CoreV1().Services(s.Namespace).Create(timeoutCtx, service, metav1.CreateOptions{DryRun: dryRunOption(s.DryRun)})
 	if err != nil {
 		return result, fmt.Errorf("failed to create service: %w", err)
 	}
@@ -209,13 +217,13 @@ func (s *Service) Create(ctx context.Context, cm kai.ClusterManager) (string, er
 		result += fmt.Sprintf("\nClusterIP: %s", createdService.Spec.ClusterIP)
 	}
 
-	return result, nil
+	return dryRunResult(result, s.DryRun), nil
 }
 
 // Get retrieves information about a specific service
 func (s *Service) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, err
 	}
@@ -223,14 +231,14 @@ func (s *Service) Get(ctx context.Context, cm kai.ClusterManager) (string, error
 	// Verify the namespace exists
 	_, err = client.CoreV1().Namespaces().Get(ctx, s.Namespace, metav1.GetOptions{})
 	if err != nil {
-		return result, fmt.Errorf("namespace '%s' not found: %v", s.Namespace, err)
+		return result, fmt.Errorf("namespace '%s' not found: %w", s.Namespace, err)
 	}
 
 	// Use retry for potential transient issues
 	var service *corev1.Service
 	err = retry.OnError(retry.DefaultRetry, func(err error) bool {
 		// Only retry on transient errors
-		return !strings.Contains(err.Error(), "not found")
+		return !apierrors.IsNotFound(err)
 	}, func() error {
 		var getErr error
 		service, getErr = client.CoreV1().Services(s.Namespace).Get(ctx, s.Name, metav1.GetOptions{})
@@ -238,27 +246,165 @@ func (s *Service) Get(ctx context.Context, cm kai.ClusterManager) (string, error
 	})
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return result, fmt.Errorf("service '%s' not found in namespace '%s'", s.Name, s.Namespace)
+		if apierrors.IsNotFound(err) {
+			return result, fmt.Errorf("service '%s' not found in namespace '%s': %w", s.Name, s.Namespace, err)
 		}
-		return result, fmt.Errorf("failed to get service '%s' in namespace '%s': %v", s.Name, s.Namespace, err)
+		return result, fmt.Errorf("failed to get service '%s' in namespace '%s': %w", s.Name, s.Namespace, err)
 	}
 
 	result = formatService(service)
+	if s.IncludeEndpoints {
+		endpoints, err := s.endpointsSummary(ctx, client, service)
+		if err != nil {
+			return "", err
+		}
+		result = strings.TrimRight(result, "\n") + "\n" + endpoints
+	}
 
 	return result, nil
 }
 
-// List lists services in the specified namespace or across all namespaces
-func (s *Service) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string) (string, error) {
+// endpointsSummary reports the ready and not-ready backends of a service
+// with the pods behind them, explaining an empty list, since a service
+// without ready backends refuses connections.
+func (s *Service) endpointsSummary(ctx context.Context, client kubernetes.Interface, service *corev1.Service) (string, error) {
+	if service.Spec.Type == corev1.ServiceTypeExternalName {
+		return fmt.Sprintf("\nEndpoints: none (ExternalName service resolves to %s)", service.Spec.ExternalName), nil
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	ready, notReady, err := serviceEndpoints(timeoutCtx, client, service)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(formatServiceEndpoints(service, ready, notReady), "\n"), nil
+}
+
+// maxServiceEvents caps the events shown by Describe.
+const maxServiceEvents = 10
+
+// Describe returns the service details together with its resolved endpoints
+// and recent events, so connectivity problems can be diagnosed in one call.
+func (s *Service) Describe(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	service, err := client.CoreV1().Services(s.Namespace).Get(timeoutCtx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("service '%s' not found in namespace '%s': %w", s.Name, s.Namespace, err)
+		}
+		return "", fmt.Errorf("failed to get service '%s' in namespace '%s': %w", s.Name, s.Namespace, err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRight(formatService(service), "\n"))
+	sb.WriteString("\n")
+
+	if service.Spec.Type == corev1.ServiceTypeExternalName {
+		fmt.Fprintf(&sb, "\nEndpoints: none (ExternalName service resolves to %s)\n", service.Spec.ExternalName)
+	} else {
+		ready, notReady, err := serviceEndpoints(timeoutCtx, client, service)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(formatServiceEndpoints(service, ready, notReady))
+	}
+
+	events, err := objectEvents(timeoutCtx, client, s.Namespace, "Service", s.Name, maxServiceEvents)
+	if err != nil {
+		return "", err
+	}
+	if len(events) == 0 {
+		sb.WriteString("\nEvents: <none>")
+	} else {
+		sb.WriteString("\n")
+		sb.WriteString(formatEventList(&corev1.EventList{Items: events}, false))
+	}
+
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// serviceEndpoints resolves the backends of a service from its
+// EndpointSlices, split by readiness. Each entry is "ip:port (target)".
+func serviceEndpoints(ctx context.Context, client kubernetes.Interface, service *corev1.Service) (ready, notReady []string, err error) {
+	slices, err := client.DiscoveryV1().EndpointSlices(service.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + service.Name,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list endpoint slices: %w", err)
+	}
+
+	for _, slice := range slices.Items {
+		for _, ep := range slice.Endpoints {
+			target := ""
+			if ep.TargetRef != nil {
+				target = fmt.Sprintf(" (%s/%s)", strings.ToLower(ep.TargetRef.Kind), ep.TargetRef.Name)
+			}
+			isReady := ep.Conditions.Ready == nil || *ep.Conditions.Ready
+			for _, addr := range ep.Addresses {
+				entries := []string{addr + target}
+				if len(slice.Ports) > 0 {
+					entries = entries[:0]
+					for _, port := range slice.Ports {
+						if port.Port != nil {
+							entries = append(entries, fmt.Sprintf("%s:%d%s", addr, *port.Port, target))
+						}
+					}
+				}
+				if isReady {
+					ready = append(ready, entries...)
+				} else {
+					notReady = append(notReady, entries...)
+				}
+			}
+		}
+	}
+	return ready, notReady, nil
+}
+
+func formatServiceEndpoints(service *corev1.Service, ready, notReady []string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\nEndpoints (ready: %d, not ready: %d):\n", len(ready), len(notReady))
+	for _, ep := range ready {
+		fmt.Fprintf(&sb, "• %s\tready\n", ep)
+	}
+	for _, ep := range notReady {
+		fmt.Fprintf(&sb, "• %s\tnot ready\n", ep)
+	}
+	if len(ready) == 0 {
+		switch {
+		case len(service.Spec.Selector) == 0:
+			sb.WriteString("Note: service has no ready backends and no selector; endpoints must be managed manually\n")
+		case len(notReady) > 0:
+			sb.WriteString("Note: service has no ready backends; matching pods are failing their readiness checks\n")
+		default:
+			sb.WriteString("Note: service has no ready backends; no pods match its selector\n")
+		}
+	}
+	return sb.String()
+}
+
+// List lists services in the specified namespace or across all namespaces,
+// one page at a time when limit is positive.
+func (s *Service) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string, limit int64, continueToken string) (string, error) {
 	var result string
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
 
 	listOptions := metav1.ListOptions{
 		LabelSelector: labelSelector,
+		Limit:         limit,
+		Continue:      continueToken,
 	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
@@ -267,7 +413,7 @@ func (s *Service) List(ctx context.Context, cm kai.ClusterManager, allNamespaces
 	// If namespace is empty but allNamespaces is false, use the current namespace
 	namespace := s.Namespace
 	if namespace == "" && !allNamespaces {
-		namespace = cm.GetCurrentNamespace()
+		namespace = kai.CurrentNamespace(ctx, cm)
 	}
 
 	if allNamespaces {
@@ -281,7 +427,7 @@ func (s *Service) List(ctx context.Context, cm kai.ClusterManager, allNamespaces
 			return result, nil
 		}
 		result = "Services across all namespaces:\n"
-		result += formatServiceList(services, true)
+		result += formatServiceList(services, true) + continueHint(services.Continue)
 	} else {
 		// First verify the namespace exists
 		_, err = client.CoreV1().Namespaces().Get(timeoutCtx, namespace, metav1.GetOptions{})
@@ -300,7 +446,7 @@ func (s *Service) List(ctx context.Context, cm kai.ClusterManager, allNamespaces
 		}
 
 		result = fmt.Sprintf("Services in namespace %q:\n", namespace)
-		result += formatServiceList(services, false)
+		result += formatServiceList(services, false) + continueHint(services.Continue)
 	}
 
 	return result, nil
@@ -310,7 +456,7 @@ func (s *Service) List(ctx context.Context, cm kai.ClusterManager, allNamespaces
 func (s *Service) Delete(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -454,7 +600,7 @@ func (s *Service) validate() error {
 func (s *Service) Update(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -467,6 +613,8 @@ func (s *Service) Update(ctx context.Context, cm kai.ClusterManager) (string, er
 		return result, fmt.Errorf("failed to get service: %w", err)
 	}
 
+	before := service.DeepCopy()
+
 	if len(s.Labels) > 0 {
 		if service.Labels == nil {
 			service.Labels = make(map[string]string)
@@ -565,20 +713,23 @@ func (s *Service) Update(ctx context.Context, cm kai.ClusterManager) (string, er
 		service.Spec.Ports = servicePorts
 	}
 
-	updatedService, err := client.CoreV1().Services(s.Namespace).Update(timeoutCtx, service, metav1.UpdateOptions{})
+	updatedService, err := client.CoreV1().Services(s.Namespace).Update(timeoutCtx, service, metav1.UpdateOptions{DryRun: dryRunOption(s.DryRun)})
 	if err != nil {
 		return result, fmt.Errorf("failed to update service: %w", err)
 	}
 
 	result = fmt.Sprintf("Service %q updated successfully in namespace %q (Type: %s)", updatedService.Name, updatedService.Namespace, updatedService.Spec.Type)
-	return result, nil
+	if s.ShowDiff {
+		result = withUpdateDiff(result, before, updatedService)
+	}
+	return dryRunResult(result, s.DryRun), nil
 }
 
 // Patch applies a partial update to an existing service
 func (s *Service) Patch(ctx context.Context, cm kai.ClusterManager, patchData map[string]interface{}) (string, error) {
 	var result string
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return result, fmt.Errorf("error getting client: %w", err)
 	}
@@ -633,11 +784,11 @@ func (s *Service) Patch(ctx context.Context, cm kai.ClusterManager, patchData ma
 		service.Spec.ExternalIPs = ips
 	}
 
-	updatedService, err := client.CoreV1().Services(s.Namespace).Update(timeoutCtx, service, metav1.UpdateOptions{})
+	updatedService, err := client.CoreV1().Services(s.Namespace).Update(timeoutCtx, service, metav1.UpdateOptions{DryRun: dryRunOption(s.DryRun)})
 	if err != nil {
 		return result, fmt.Errorf("failed to patch service: %w", err)
 	}
 
 	result = fmt.Sprintf("Service %q patched successfully in namespace %q", updatedService.Name, updatedService.Namespace)
-	return result, nil
+	return dryRunResult(result, s.DryRun), nil
 }
@@ -7,7 +7,10 @@ import (
 	"github.com/basebandit/kai/testmocks"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 )
@@ -19,6 +22,9 @@ func TestServiceOperations(t *testing.T) {
 	t.Run("DeleteService", testDeleteService)
 	t.Run("UpdateService", testUpdateService)
 	t.Run("PatchService", testPatchService)
+	t.Run("DescribeService", testDescribeService)
+	t.Run("GetServiceWithEndpoints", testGetServiceWithEndpoints)
+	t.Run("DiagnoseService", testDiagnoseService)
 }
 
 func testCreateServices(t *testing.T) {
@@ -697,7 +703,7 @@ func testListServices(t *testing.T) {
 			mockCM := testmocks.NewMockClusterManager()
 			tc.setupMock(mockCM)
 
-			result, err := tc.service.List(ctx, mockCM, tc.allNamespaces, tc.labelSelector)
+			result, err := tc.service.List(ctx, mockCM, tc.allNamespaces, tc.labelSelector, 0, "")
 
 			if tc.expectedError != "" {
 				assert.Error(t, err)
@@ -1313,3 +1319,275 @@ func testPatchService(t *testing.T) {
 		})
 	}
 }
+
+func testDescribeService(t *testing.T) {
+	ctx := context.Background()
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: "10.96.0.10",
+			Selector:  map[string]string{"app": "web"},
+			Ports:     []corev1.ServicePort{{Port: 80, Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	endpointSlice := func(ready bool) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web-abc12",
+				Namespace: testNamespace,
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "web"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{{
+				Addresses:  []string{"10.244.0.5"},
+				Conditions: discoveryv1.EndpointConditions{Ready: ptr(ready)},
+				TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: "web-1"},
+			}},
+			Ports: []discoveryv1.EndpointPort{{Port: ptr(int32(8080))}},
+		}
+	}
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "web.1", Namespace: testNamespace},
+		InvolvedObject: corev1.ObjectReference{Kind: "Service", Name: "web", Namespace: testNamespace},
+		Type:           corev1.EventTypeNormal,
+		Reason:         "EnsuredLoadBalancer",
+		Message:        "Ensured load balancer",
+		LastTimestamp:  metav1.Now(),
+	}
+
+	testCases := []struct {
+		name        string
+		objects     []runtime.Object
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "ReadyEndpoints",
+			objects:     []runtime.Object{service, endpointSlice(true), event},
+			contains:    []string{"ClusterIP: 10.96.0.10", "Endpoints (ready: 1, not ready: 0)", "10.244.0.5:8080 (pod/web-1)\tready", "EnsuredLoadBalancer"},
+			notContains: []string{"Note:"},
+		},
+		{
+			name:     "NoReadyEndpoints",
+			objects:  []runtime.Object{service, endpointSlice(false)},
+			contains: []string{"Endpoints (ready: 0, not ready: 1)", "not ready", "no ready backends", "failing their readiness checks", "Events: <none>"},
+		},
+		{
+			name:     "NoMatchingPods",
+			objects:  []runtime.Object{service},
+			contains: []string{"Endpoints (ready: 0, not ready: 0)", "no pods match its selector"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(tc.objects...), nil)
+
+			svc := &Service{Name: "web", Namespace: testNamespace}
+			result, err := svc.Describe(ctx, mockCM)
+
+			assert.NoError(t, err)
+			for _, want := range tc.contains {
+				assert.Contains(t, result, want)
+			}
+			for _, unwanted := range tc.notContains {
+				assert.NotContains(t, result, unwanted)
+			}
+			mockCM.AssertExpectations(t)
+		})
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(), nil)
+
+		svc := &Service{Name: "missing", Namespace: testNamespace}
+		_, err := svc.Describe(ctx, mockCM)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func testGetServiceWithEndpoints(t *testing.T) {
+	ctx := context.Background()
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: "10.96.0.10",
+			Selector:  map[string]string{"app": "web"},
+			Ports:     []corev1.ServicePort{{Port: 80, Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-abc12",
+			Namespace: testNamespace,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "web"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{
+				Addresses:  []string{"10.244.0.5"},
+				Conditions: discoveryv1.EndpointConditions{Ready: ptr(true)},
+				TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: "web-1"},
+			},
+			{
+				Addresses:  []string{"10.244.0.6"},
+				Conditions: discoveryv1.EndpointConditions{Ready: ptr(false)},
+				TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: "web-2"},
+			},
+		},
+		Ports: []discoveryv1.EndpointPort{{Port: ptr(int32(8080))}},
+	}
+
+	get := func(t *testing.T, includeEndpoints bool, objects ...runtime.Object) string {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(objects...), nil)
+		svc := &Service{Name: "web", Namespace: testNamespace, IncludeEndpoints: includeEndpoints}
+		result, err := svc.Get(ctx, mockCM)
+		assert.NoError(t, err)
+		return result
+	}
+
+	t.Run("ReadyAndNotReady", func(t *testing.T) {
+		result := get(t, true, namespace, service, slice)
+		assert.Contains(t, result, "ClusterIP: 10.96.0.10")
+		assert.Contains(t, result, "Endpoints (ready: 1, not ready: 1)")
+		assert.Contains(t, result, "10.244.0.5:8080 (pod/web-1)\tready")
+		assert.Contains(t, result, "10.244.0.6:8080 (pod/web-2)\tnot ready")
+	})
+
+	t.Run("NoBackends", func(t *testing.T) {
+		result := get(t, true, namespace, service)
+		assert.Contains(t, result, "Endpoints (ready: 0, not ready: 0)")
+		assert.Contains(t, result, "no pods match its selector")
+	})
+
+	t.Run("OmittedByDefault", func(t *testing.T) {
+		result := get(t, false, namespace, service, slice)
+		assert.NotContains(t, result, "Endpoints (")
+	})
+}
+
+func testDiagnoseService(t *testing.T) {
+	ctx := context.Background()
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: map[string]string{"app": "web"},
+			Ports:    []corev1.ServicePort{{Port: 80, TargetPort: intstr.FromString("http")}},
+		},
+	}
+	pod := func(name, app string, ready bool) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: map[string]string{"app": app}},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:  "web",
+				Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+			}}},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "web", Ready: ready}},
+			},
+		}
+	}
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-abc12",
+			Namespace: testNamespace,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "web"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{{
+			Addresses:  []string{"10.244.0.5"},
+			Conditions: discoveryv1.EndpointConditions{Ready: ptr(true)},
+			TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: "web-1"},
+		}},
+		Ports: []discoveryv1.EndpointPort{{Port: ptr(int32(8080))}},
+	}
+
+	testCases := []struct {
+		name        string
+		objects     []runtime.Object
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "Healthy",
+			objects:     []runtime.Object{service, pod("web-1", "web", true), slice},
+			contains:    []string{"Selector: app=web", "Matching pods: 1 (1 ready)", "Endpoints: 1 ready, 0 not ready", "No problems found"},
+			notContains: []string{"Findings:"},
+		},
+		{
+			name:     "NoMatchingPods",
+			objects:  []runtime.Object{service, pod("web-v2-1", "web-v2", true), pod("web-v2-2", "web-v2", true)},
+			contains: []string{"Matching pods: 0 (0 ready)", "• selector app=web matches 0 pods; pods have app=web-v2 (2) instead"},
+		},
+		{
+			name:     "NoLabelledPods",
+			objects:  []runtime.Object{service},
+			contains: []string{"selector app=web matches 0 pods; no pod in the namespace has the label app"},
+		},
+		{
+			name:     "NotReady",
+			objects:  []runtime.Object{service, pod("web-1", "web", false)},
+			contains: []string{"Matching pods: 1 (0 ready)", "• pod web-1 matches the selector but is not Ready (container web is not ready; check its readiness probe)"},
+		},
+		{
+			name:     "ReadyWithoutEndpoints",
+			objects:  []runtime.Object{service, pod("web-1", "web", true)},
+			contains: []string{"1 matching pod(s) are Ready but the service has no ready endpoints"},
+		},
+		{
+			name: "EmptySelector",
+			objects: []runtime.Object{&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace},
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+			}},
+			contains: []string{"Selector: <none>", "• selector is empty", "and none are ready"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(tc.objects...), nil)
+
+			svc := &Service{Name: "web", Namespace: testNamespace}
+			result, err := svc.Diagnose(ctx, mockCM)
+
+			assert.NoError(t, err)
+			for _, want := range tc.contains {
+				assert.Contains(t, result, want)
+			}
+			for _, unwanted := range tc.notContains {
+				assert.NotContains(t, result, unwanted)
+			}
+		})
+	}
+
+	t.Run("UndeclaredNamedPort", func(t *testing.T) {
+		unnamed := pod("web-1", "web", true)
+		unnamed.Spec.Containers[0].Ports = nil
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(service, unnamed, slice), nil)
+
+		result, err := (&Service{Name: "web", Namespace: testNamespace}).Diagnose(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, result, `• port 80 targets the named port "http", which no matching pod declares`)
+	})
+}
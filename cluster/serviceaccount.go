@@ -0,0 +1,216 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServiceAccount represents an operation target for a ServiceAccount.
+type ServiceAccount struct {
+	Name             string
+	Namespace        string
+	ImagePullSecrets []interface{}
+	// AutomountToken sets automountServiceAccountToken; nil leaves it unset,
+	// which pods treat as true.
+	AutomountToken *bool
+	Labels         map[string]interface{}
+	DryRun         bool
+}
+
+// Create creates the ServiceAccount. Image pull secrets are referenced by
+// name and must exist in the same namespace for pods to use them.
+func (s *ServiceAccount) Create(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if s.Name == "" {
+		return "", errors.New("service account name is required")
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := s.namespace(ctx, cm)
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.Name,
+			Namespace: namespace,
+			Labels:    convertToStringMap(s.Labels),
+		},
+		ImagePullSecrets:             convertToLocalObjectReferences(s.ImagePullSecrets),
+		AutomountServiceAccountToken: s.AutomountToken,
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if _, err := client.CoreV1().ServiceAccounts(namespace).Create(timeoutCtx, sa, metav1.CreateOptions{DryRun: dryRunOption(s.DryRun)}); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return "", fmt.Errorf("service account %q already exists in namespace %q", s.Name, namespace)
+		}
+		return "", fmt.Errorf("failed to create service account %q: %w", s.Name, err)
+	}
+
+	slog.Info("service account created",
+		slog.String("name", s.Name),
+		slog.String("namespace", namespace),
+	)
+	result := fmt.Sprintf("ServiceAccount %q created successfully in namespace %q", s.Name, namespace)
+	return dryRunResult(result, s.DryRun), nil
+}
+
+// Get describes the ServiceAccount: its token and image pull secrets and
+// whether it automounts its token into pods.
+func (s *ServiceAccount) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if s.Name == "" {
+		return "", errors.New("service account name is required")
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := s.namespace(ctx, cm)
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	sa, err := client.CoreV1().ServiceAccounts(namespace).Get(timeoutCtx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("service account %q not found in namespace %q: %w", s.Name, namespace, err)
+		}
+		return "", fmt.Errorf("failed to get service account %q: %w", s.Name, err)
+	}
+
+	return formatServiceAccount(sa), nil
+}
+
+// List lists ServiceAccounts in the namespace, or in all namespaces.
+func (s *ServiceAccount) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := ""
+	if !allNamespaces {
+		namespace = s.namespace(ctx, cm)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	sas, err := client.CoreV1().ServiceAccounts(namespace).List(timeoutCtx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return "", fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	if len(sas.Items) == 0 {
+		if labelSelector != "" {
+			return "", errors.New("no service accounts found matching the specified label selector")
+		}
+		if allNamespaces {
+			return "", errors.New("no service accounts found in any namespace")
+		}
+		return "", fmt.Errorf("no service accounts found in namespace %q", namespace)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "ServiceAccounts (%d):\n", len(sas.Items))
+	for i := range sas.Items {
+		sa := sas.Items[i]
+		name := sa.Name
+		if allNamespaces {
+			name = fmt.Sprintf("%s/%s", sa.Namespace, sa.Name)
+		}
+		fmt.Fprintf(&sb, "• %s\tsecrets: %d\tage: %s\n", name, len(sa.Secrets), formatDuration(time.Since(sa.CreationTimestamp.Time)))
+	}
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// Delete removes the ServiceAccount. Pods already running as it keep their
+// mounted token until it expires.
+func (s *ServiceAccount) Delete(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	if s.Name == "" {
+		return "", errors.New("service account name is required for deletion")
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := s.namespace(ctx, cm)
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	if err := client.CoreV1().ServiceAccounts(namespace).Delete(timeoutCtx, s.Name, metav1.DeleteOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("service account %q not found in namespace %q: %w", s.Name, namespace, err)
+		}
+		return "", fmt.Errorf("failed to delete service account %q: %w", s.Name, err)
+	}
+
+	slog.Info("service account deleted",
+		slog.String("name", s.Name),
+		slog.String("namespace", namespace),
+	)
+	return fmt.Sprintf("ServiceAccount %q deleted successfully from namespace %q", s.Name, namespace), nil
+}
+
+func (s *ServiceAccount) namespace(ctx context.Context, cm kai.ClusterManager) string {
+	if s.Namespace != "" {
+		return s.Namespace
+	}
+	return kai.CurrentNamespace(ctx, cm)
+}
+
+func formatServiceAccount(sa *corev1.ServiceAccount) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "ServiceAccount: %s\nNamespace: %s\n", sa.Name, sa.Namespace)
+	fmt.Fprintf(&sb, "Age: %s\n", formatDuration(time.Since(sa.CreationTimestamp.Time)))
+	if len(sa.Labels) > 0 {
+		labels := make([]string, 0, len(sa.Labels))
+		for k, v := range sa.Labels {
+			labels = append(labels, k+"="+v)
+		}
+		sort.Strings(labels)
+		fmt.Fprintf(&sb, "Labels: %s\n", strings.Join(labels, ", "))
+	}
+
+	secrets := "<none>"
+	if len(sa.Secrets) > 0 {
+		names := make([]string, 0, len(sa.Secrets))
+		for _, secret := range sa.Secrets {
+			names = append(names, secret.Name)
+		}
+		secrets = strings.Join(names, ", ")
+	}
+	fmt.Fprintf(&sb, "Secrets: %s\n", secrets)
+
+	pullSecrets := "<none>"
+	if len(sa.ImagePullSecrets) > 0 {
+		names := make([]string, 0, len(sa.ImagePullSecrets))
+		for _, secret := range sa.ImagePullSecrets {
+			names = append(names, secret.Name)
+		}
+		pullSecrets = strings.Join(names, ", ")
+	}
+	fmt.Fprintf(&sb, "Image Pull Secrets: %s\n", pullSecrets)
+
+	automount := "not set (pods mount the token unless they opt out)"
+	if sa.AutomountServiceAccountToken != nil {
+		automount = fmt.Sprintf("%t", *sa.AutomountServiceAccountToken)
+	}
+	fmt.Fprintf(&sb, "Automount Token: %s\n", automount)
+	return strings.TrimRight(sb.String(), "\n")
+}
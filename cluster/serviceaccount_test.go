@@ -0,0 +1,98 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestServiceAccountCreate(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewSimpleClientset()
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+	automount := false
+	sa := &ServiceAccount{
+		Name:             "builder",
+		Namespace:        defaultNamespace,
+		ImagePullSecrets: []interface{}{"registry-creds"},
+		AutomountToken:   &automount,
+		Labels:           map[string]interface{}{"team": "ci"},
+	}
+	result, err := sa.Create(ctx, mockCM)
+	assert.NoError(t, err)
+	assert.Equal(t, `ServiceAccount "builder" created successfully in namespace "default"`, result)
+
+	created, err := fakeClient.CoreV1().ServiceAccounts(defaultNamespace).Get(ctx, "builder", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry-creds"}}, created.ImagePullSecrets)
+	assert.Equal(t, &automount, created.AutomountServiceAccountToken)
+	assert.Equal(t, map[string]string{"team": "ci"}, created.Labels)
+
+	_, err = sa.Create(ctx, mockCM)
+	assert.EqualError(t, err, `service account "builder" already exists in namespace "default"`)
+
+	_, err = (&ServiceAccount{}).Create(ctx, mockCM)
+	assert.Error(t, err)
+}
+
+func TestServiceAccountGetListDelete(t *testing.T) {
+	ctx := context.Background()
+	automount := true
+	fakeClient := fake.NewSimpleClientset(
+		&corev1.ServiceAccount{
+			ObjectMeta:                   metav1.ObjectMeta{Name: "sa1", Namespace: defaultNamespace, Labels: map[string]string{"app": "web"}},
+			Secrets:                      []corev1.ObjectReference{{Name: "sa1-token"}},
+			AutomountServiceAccountToken: &automount,
+		},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "sa2", Namespace: testNamespace}},
+	)
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+
+	t.Run("Get", func(t *testing.T) {
+		get, err := (&ServiceAccount{Name: "sa1"}).Get(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, get, "ServiceAccount: sa1")
+		assert.Contains(t, get, "Secrets: sa1-token")
+		assert.Contains(t, get, "Image Pull Secrets: <none>")
+		assert.Contains(t, get, "Automount Token: true")
+
+		get, err = (&ServiceAccount{Name: "sa2", Namespace: testNamespace}).Get(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, get, "Automount Token: not set")
+
+		_, err = (&ServiceAccount{Name: "missing"}).Get(ctx, mockCM)
+		assert.EqualError(t, err, `service account "missing" not found in namespace "default": serviceaccounts "missing" not found`)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		list, err := (&ServiceAccount{}).List(ctx, mockCM, false, "")
+		assert.NoError(t, err)
+		assert.Contains(t, list, "ServiceAccounts (1)")
+		assert.Contains(t, list, "sa1")
+
+		list, err = (&ServiceAccount{}).List(ctx, mockCM, true, "")
+		assert.NoError(t, err)
+		assert.Contains(t, list, testNamespace+"/sa2")
+
+		_, err = (&ServiceAccount{}).List(ctx, mockCM, false, "app=db")
+		assert.EqualError(t, err, "no service accounts found matching the specified label selector")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		result, err := (&ServiceAccount{Name: "sa1"}).Delete(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Equal(t, `ServiceAccount "sa1" deleted successfully from namespace "default"`, result)
+
+		_, err = (&ServiceAccount{Name: "sa1"}).Delete(ctx, mockCM)
+		assert.EqualError(t, err, `service account "sa1" not found in namespace "default": serviceaccounts "sa1" not found`)
+	})
+}
@@ -0,0 +1,207 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Diagnose checks whether the service's selector matches running, Ready
+// pods and cross-references its EndpointSlices, returning actionable
+// findings such as "selector app=foo matches 0 pods".
+func (s *Service) Diagnose(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	service, err := client.CoreV1().Services(s.Namespace).Get(timeoutCtx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("service '%s' not found in namespace '%s'", s.Name, s.Namespace)
+		}
+		return "", fmt.Errorf("failed to get service '%s' in namespace '%s': %w", s.Name, s.Namespace, err)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Service %q in namespace %q (Type: %s)\n", service.Name, service.Namespace, service.Spec.Type)
+
+	if service.Spec.Type == corev1.ServiceTypeExternalName {
+		fmt.Fprintf(&sb, "No problems found: ExternalName service resolves to %s and has no pods or endpoints to check", service.Spec.ExternalName)
+		return sb.String(), nil
+	}
+
+	ready, notReady, err := serviceEndpoints(timeoutCtx, client, service)
+	if err != nil {
+		return "", err
+	}
+
+	var findings []string
+	matched, readyPods := 0, 0
+	if len(service.Spec.Selector) == 0 {
+		sb.WriteString("Selector: <none>\n")
+		finding := "selector is empty, so no pods are picked up automatically; endpoints must be managed by hand"
+		if len(ready) == 0 {
+			finding += ", and none are ready"
+		}
+		findings = append(findings, finding)
+	} else {
+		selector := labels.SelectorFromSet(service.Spec.Selector)
+		pods, err := client.CoreV1().Pods(s.Namespace).List(timeoutCtx, metav1.ListOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to list pods: %w", err)
+		}
+
+		var active []*corev1.Pod
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			if selector.Matches(labels.Set(pod.Labels)) {
+				active = append(active, pod)
+			}
+		}
+		matched = len(active)
+
+		for _, pod := range active {
+			if podIsReady(pod) {
+				readyPods++
+				continue
+			}
+			findings = append(findings, fmt.Sprintf("pod %s matches the selector but is not Ready (%s)", pod.Name, podNotReadyReason(pod)))
+		}
+
+		fmt.Fprintf(&sb, "Selector: %s\n", selector.String())
+		fmt.Fprintf(&sb, "Matching pods: %d (%d ready)\n", matched, readyPods)
+
+		if matched == 0 {
+			findings = append(findings, fmt.Sprintf("selector %s matches 0 pods; %s", selector.String(), selectorNearMisses(service.Spec.Selector, pods.Items)))
+		} else {
+			findings = append(findings, namedPortFindings(service, active)...)
+		}
+
+		if readyPods > 0 && len(ready) == 0 {
+			findings = append(findings, fmt.Sprintf("%d matching pod(s) are Ready but the service has no ready endpoints; check its EndpointSlices (list_endpoints) and that its ports match the pods' ports", readyPods))
+		}
+	}
+
+	fmt.Fprintf(&sb, "Endpoints: %d ready, %d not ready\n", len(ready), len(notReady))
+
+	if len(findings) == 0 {
+		fmt.Fprintf(&sb, "\nNo problems found: the selector matches %d ready pod(s) and the service has %d ready endpoint(s)", readyPods, len(ready))
+		return sb.String(), nil
+	}
+
+	sb.WriteString("\nFindings:\n")
+	for _, finding := range findings {
+		fmt.Fprintf(&sb, "• %s\n", finding)
+	}
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+func podIsReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// podNotReadyReason explains why a pod is not Ready: its phase while it is
+// not running, a waiting container's reason, or the Ready condition.
+func podNotReadyReason(pod *corev1.Pod) string {
+	if pod.Status.Phase != corev1.PodRunning {
+		return fmt.Sprintf("phase %s", pod.Status.Phase)
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
+			return fmt.Sprintf("container %s is waiting: %s", cs.Name, cs.State.Waiting.Reason)
+		}
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if !cs.Ready {
+			return fmt.Sprintf("container %s is not ready; check its readiness probe", cs.Name)
+		}
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady && c.Message != "" {
+			return c.Message
+		}
+	}
+	return "Ready condition is not true"
+}
+
+// selectorNearMisses points at pods that carry the selector's label keys
+// with other values, the usual cause of a selector matching nothing.
+func selectorNearMisses(selector map[string]string, pods []corev1.Pod) string {
+	keys := make([]string, 0, len(selector))
+	for key := range selector {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var hints []string
+	for _, key := range keys {
+		counts := make(map[string]int)
+		for _, pod := range pods {
+			if value, ok := pod.Labels[key]; ok && value != selector[key] {
+				counts[value]++
+			}
+		}
+		if len(counts) == 0 {
+			continue
+		}
+		values := make([]string, 0, len(counts))
+		for value := range counts {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+		for _, value := range values {
+			hints = append(hints, fmt.Sprintf("%s=%s (%d)", key, value, counts[value]))
+		}
+	}
+
+	if len(hints) == 0 {
+		return fmt.Sprintf("no pod in the namespace has the label %s", strings.Join(keys, " or "))
+	}
+	return fmt.Sprintf("pods have %s instead; check the selector or the pod template labels", strings.Join(hints, ", "))
+}
+
+// namedPortFindings flags service ports whose named targetPort is declared
+// by none of the matching pods, since traffic to them is refused.
+func namedPortFindings(service *corev1.Service, pods []*corev1.Pod) []string {
+	var findings []string
+	for _, port := range service.Spec.Ports {
+		if port.TargetPort.Type != intstr.String {
+			continue
+		}
+		name := port.TargetPort.StrVal
+		declared := false
+		for _, pod := range pods {
+			for _, container := range pod.Spec.Containers {
+				for _, cp := range container.Ports {
+					if cp.Name == name {
+						declared = true
+					}
+				}
+			}
+		}
+		if !declared {
+			findings = append(findings, fmt.Sprintf("port %d targets the named port %q, which no matching pod declares", port.Port, name))
+		}
+	}
+	return findings
+}
@@ -0,0 +1,567 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/basebandit/kai"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StatefulSet represents a Kubernetes StatefulSet resource.
+type StatefulSet struct {
+	Name                 string
+	Namespace            string
+	Image                string
+	Replicas             *int32
+	ServiceName          string
+	ContainerPort        string
+	Labels               map[string]interface{}
+	Env                  map[string]interface{}
+	VolumeClaimTemplates []kai.VolumeClaimTemplate
+	UpdateStrategy       string
+	Partition            *int32
+	ImagePullPolicy      string
+	ImagePullSecrets     []interface{}
+	DryRun               bool
+	ShowDiff             bool
+}
+
+func (s *StatefulSet) namespace(ctx context.Context, cm kai.ClusterManager) string {
+	if s.Namespace != "" {
+		return s.Namespace
+	}
+	return kai.CurrentNamespace(ctx, cm)
+}
+
+// Create creates a new StatefulSet in the specified namespace.
+func (s *StatefulSet) Create(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	var result string
+
+	if err := s.validate(); err != nil {
+		slog.Warn("invalid StatefulSet input",
+			slog.String("name", s.Name),
+			slog.String("namespace", s.Namespace),
+			slog.String("error", err.Error()),
+		)
+		return result, err
+	}
+
+	slog.Debug("StatefulSet create requested",
+		slog.String("name", s.Name),
+		slog.String("namespace", s.Namespace),
+	)
+
+	claimTemplates, mounts, err := buildVolumeClaimTemplates(s.VolumeClaimTemplates)
+	if err != nil {
+		return result, err
+	}
+
+	strategy, err := buildStatefulSetUpdateStrategy(s.UpdateStrategy, s.Partition)
+	if err != nil {
+		return result, err
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		slog.Warn("failed to get client for StatefulSet create",
+			slog.String("name", s.Name),
+			slog.String("namespace", s.Namespace),
+			slog.String("error", err.Error()),
+		)
+		return result, fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	_, err = client.CoreV1().Namespaces().Get(timeoutCtx, s.Namespace, metav1.GetOptions{})
+	if err != nil {
+		slog.Warn("namespace not found for StatefulSet create",
+			slog.String("name", s.Name),
+			slog.String("namespace", s.Namespace),
+			slog.String("error", err.Error()),
+		)
+		return result, fmt.Errorf("namespace %q not found: %w", s.Namespace, err)
+	}
+
+	labels := map[string]string{"app": s.Name}
+	for k, v := range convertToStringMap(s.Labels) {
+		labels[k] = v
+	}
+
+	container := corev1.Container{
+		Name:         s.Name,
+		Image:        s.Image,
+		VolumeMounts: mounts,
+	}
+
+	if s.ContainerPort != "" {
+		port, err := parseContainerPort(s.ContainerPort)
+		if err != nil {
+			return result, err
+		}
+		container.Ports = []corev1.ContainerPort{port}
+	}
+
+	if s.Env != nil {
+		container.Env = convertToEnvVars(s.Env)
+	}
+
+	if s.ImagePullPolicy != "" {
+		container.ImagePullPolicy = corev1.PullPolicy(s.ImagePullPolicy)
+	}
+
+	replicas := int32(1)
+	if s.Replicas != nil {
+		replicas = *s.Replicas
+	}
+
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.Name,
+			Namespace: s.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    &replicas,
+			ServiceName: s.ServiceName,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{container},
+				},
+			},
+			VolumeClaimTemplates: claimTemplates,
+			UpdateStrategy:       strategy,
+		},
+	}
+
+	if len(s.ImagePullSecrets) > 0 {
+		statefulSet.Spec.Template.Spec.ImagePullSecrets = convertToLocalObjectReferences(s.ImagePullSecrets)
+	}
+
+	created, err := client.AppsV1().StatefulSets(s.Namespace).Create(timeoutCtx, statefulSet, metav1.CreateOptions{DryRun: dryRunOption(s.DryRun)})
+	if err != nil {
+		slog.Warn("failed to create StatefulSet",
+			slog.String("name", s.Name),
+			slog.String("namespace", s.Namespace),
+			slog.String("error", err.Error()),
+		)
+		return result, fmt.Errorf("failed to create StatefulSet: %w", err)
+	}
+
+	slog.Info("StatefulSet created",
+		slog.String("name", created.Name),
+		slog.String("namespace", created.Namespace),
+	)
+
+	result = fmt.Sprintf("StatefulSet %q created successfully in namespace %q with %d replica(s)", created.Name, created.Namespace, replicas)
+	return dryRunResult(result, s.DryRun), nil
+}
+
+// Get retrieves a StatefulSet by name from the specified namespace.
+func (s *StatefulSet) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := s.namespace(ctx, cm)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	statefulSet, err := client.AppsV1().StatefulSets(namespace).Get(timeoutCtx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("StatefulSet %q not found in namespace %q: %w", s.Name, namespace, err)
+		}
+		return "", fmt.Errorf("failed to get StatefulSet %q: %w", s.Name, err)
+	}
+
+	return formatStatefulSet(statefulSet), nil
+}
+
+// List retrieves all StatefulSets matching the specified criteria.
+func (s *StatefulSet) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string) (string, error) {
+	var result string
+
+	slog.Debug("StatefulSet list requested",
+		slog.Bool("all_namespaces", allNamespaces),
+		slog.String("namespace", s.Namespace),
+		slog.String("label_selector", labelSelector),
+	)
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return result, fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := ""
+	if !allNamespaces {
+		namespace = s.namespace(ctx, cm)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	statefulSets, err := client.AppsV1().StatefulSets(namespace).List(timeoutCtx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		slog.Warn("failed to list StatefulSets",
+			slog.Bool("all_namespaces", allNamespaces),
+			slog.String("namespace", namespace),
+			slog.String("label_selector", labelSelector),
+			slog.String("error", err.Error()),
+		)
+		return result, fmt.Errorf("failed to list StatefulSets: %w", err)
+	}
+
+	if len(statefulSets.Items) == 0 {
+		if labelSelector != "" {
+			return result, errors.New("no StatefulSets found matching the specified label selector")
+		}
+		if allNamespaces {
+			return result, errors.New("no StatefulSets found in any namespace")
+		}
+		return result, fmt.Errorf("no StatefulSets found in namespace %q", namespace)
+	}
+
+	return formatStatefulSetList(statefulSets, allNamespaces), nil
+}
+
+// Update updates the image, replica count, labels, or update strategy of an
+// existing StatefulSet. The selector and volume claim templates are
+// immutable and are left as they are.
+func (s *StatefulSet) Update(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	var result string
+
+	if s.Name == "" {
+		return result, errors.New("StatefulSet name is required")
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return result, fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := s.namespace(ctx, cm)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	statefulSet, err := client.AppsV1().StatefulSets(namespace).Get(timeoutCtx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		return result, fmt.Errorf("failed to get StatefulSet: %w", err)
+	}
+
+	before := statefulSet.DeepCopy()
+
+	if s.Replicas != nil {
+		if *s.Replicas < 0 {
+			return result, fmt.Errorf("replicas must be non-negative, got %d", *s.Replicas)
+		}
+		statefulSet.Spec.Replicas = s.Replicas
+	}
+
+	if s.Image != "" {
+		containers := statefulSet.Spec.Template.Spec.Containers
+		if len(containers) == 0 {
+			return result, errors.New("no suitable container found to update image")
+		}
+		index := 0
+		for i, container := range containers {
+			if container.Name == s.Name {
+				index = i
+				break
+			}
+		}
+		containers[index].Image = s.Image
+	}
+
+	if labels := convertToStringMap(s.Labels); len(labels) > 0 {
+		if statefulSet.Labels == nil {
+			statefulSet.Labels = make(map[string]string)
+		}
+		if statefulSet.Spec.Template.Labels == nil {
+			statefulSet.Spec.Template.Labels = make(map[string]string)
+		}
+		for k, v := range labels {
+			statefulSet.Labels[k] = v
+			statefulSet.Spec.Template.Labels[k] = v
+		}
+	}
+
+	if s.UpdateStrategy != "" || s.Partition != nil {
+		strategyType := s.UpdateStrategy
+		if strategyType == "" {
+			strategyType = string(statefulSet.Spec.UpdateStrategy.Type)
+		}
+		strategy, err := buildStatefulSetUpdateStrategy(strategyType, s.Partition)
+		if err != nil {
+			return result, err
+		}
+		statefulSet.Spec.UpdateStrategy = strategy
+	}
+
+	updated, err := client.AppsV1().StatefulSets(namespace).Update(timeoutCtx, statefulSet, metav1.UpdateOptions{DryRun: dryRunOption(s.DryRun)})
+	if err != nil {
+		return result, fmt.Errorf("failed to update StatefulSet: %w", err)
+	}
+
+	result = fmt.Sprintf("StatefulSet %q updated successfully in namespace %q", updated.Name, updated.Namespace)
+	if s.ShowDiff {
+		result = withUpdateDiff(result, before, updated)
+	}
+	return dryRunResult(result, s.DryRun), nil
+}
+
+// Describe provides detailed information about a StatefulSet, including
+// its replica counts and whether its governing service is headless.
+func (s *StatefulSet) Describe(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := s.namespace(ctx, cm)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	statefulSet, err := client.AppsV1().StatefulSets(namespace).Get(timeoutCtx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get StatefulSet: %w", err)
+	}
+
+	serviceState := ""
+	if statefulSet.Spec.ServiceName != "" {
+		svc, err := client.CoreV1().Services(namespace).Get(timeoutCtx, statefulSet.Spec.ServiceName, metav1.GetOptions{})
+		switch {
+		case err != nil:
+			serviceState = "not found"
+		case svc.Spec.ClusterIP == corev1.ClusterIPNone:
+			serviceState = "headless"
+		default:
+			serviceState = fmt.Sprintf("not headless, ClusterIP %s", svc.Spec.ClusterIP)
+		}
+	}
+
+	return formatStatefulSetDetailed(statefulSet, serviceState), nil
+}
+
+// Delete removes a StatefulSet by name from the specified namespace.
+func (s *StatefulSet) Delete(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	var result string
+
+	if s.Name == "" {
+		return result, errors.New("StatefulSet name is required for deletion")
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return result, fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := s.namespace(ctx, cm)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	statefulSet, err := client.AppsV1().StatefulSets(namespace).Get(timeoutCtx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		return result, fmt.Errorf("StatefulSet %q not found in namespace %q: %w", s.Name, namespace, err)
+	}
+
+	propagationPolicy := metav1.DeletePropagationBackground
+	err = client.AppsV1().StatefulSets(namespace).Delete(timeoutCtx, s.Name, metav1.DeleteOptions{
+		PropagationPolicy: &propagationPolicy,
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to delete StatefulSet %q: %w", s.Name, err)
+	}
+
+	slog.Info("StatefulSet deleted",
+		slog.String("name", s.Name),
+		slog.String("namespace", namespace),
+	)
+
+	result = fmt.Sprintf("StatefulSet %q deleted successfully from namespace %q", s.Name, namespace)
+
+	// Claims created from the templates outlive the StatefulSet unless its
+	// retention policy says otherwise.
+	retention := statefulSet.Spec.PersistentVolumeClaimRetentionPolicy
+	if len(statefulSet.Spec.VolumeClaimTemplates) > 0 &&
+		(retention == nil || retention.WhenDeleted != appsv1.DeletePersistentVolumeClaimRetentionPolicyType) {
+		result += "; its PersistentVolumeClaims were retained"
+	}
+	return result, nil
+}
+
+// Scale sets the replica count through the scale subresource, leaving the
+// pod template untouched.
+func (s *StatefulSet) Scale(ctx context.Context, cm kai.ClusterManager, replicas int32) (string, error) {
+	var result string
+
+	if replicas < 0 {
+		return result, fmt.Errorf("replicas must be non-negative, got %d", replicas)
+	}
+
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return result, fmt.Errorf("error getting client: %w", err)
+	}
+
+	namespace := s.namespace(ctx, cm)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	scale, err := client.AppsV1().StatefulSets(namespace).GetScale(timeoutCtx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		return result, fmt.Errorf("failed to get StatefulSet scale: %w", err)
+	}
+
+	scale.Spec.Replicas = replicas
+	_, err = client.AppsV1().StatefulSets(namespace).UpdateScale(timeoutCtx, s.Name, scale, metav1.UpdateOptions{})
+	if err != nil {
+		return result, fmt.Errorf("failed to scale StatefulSet: %w", err)
+	}
+
+	result = fmt.Sprintf("StatefulSet %q scaled to %d replica(s) in namespace %q", s.Name, replicas, namespace)
+	return result, nil
+}
+
+func (s *StatefulSet) validate() error {
+	if s.Name == "" {
+		return errors.New("StatefulSet name is required")
+	}
+	if s.Namespace == "" {
+		return errors.New("namespace is required")
+	}
+	if s.Image == "" {
+		return errors.New("image is required")
+	}
+	if s.ServiceName == "" {
+		return errors.New("service name is required: StatefulSets need a headless service to give pods stable network identities")
+	}
+	if s.Replicas != nil && *s.Replicas < 0 {
+		return fmt.Errorf("replicas must be non-negative, got %d", *s.Replicas)
+	}
+	return nil
+}
+
+// buildVolumeClaimTemplates turns the requested templates into claim
+// templates and the matching mounts for the StatefulSet's container.
+func buildVolumeClaimTemplates(templates []kai.VolumeClaimTemplate) ([]corev1.PersistentVolumeClaim, []corev1.VolumeMount, error) {
+	var claims []corev1.PersistentVolumeClaim
+	var mounts []corev1.VolumeMount
+
+	for i, t := range templates {
+		if t.Name == "" {
+			return nil, nil, fmt.Errorf("volume claim template %d: name is required", i)
+		}
+		if t.MountPath == "" {
+			return nil, nil, fmt.Errorf("volume claim template %q: mount path is required", t.Name)
+		}
+		if t.Storage == "" {
+			return nil, nil, fmt.Errorf("volume claim template %q: storage request is required (e.g. '1Gi')", t.Name)
+		}
+		quantity, err := resource.ParseQuantity(t.Storage)
+		if err != nil {
+			return nil, nil, fmt.Errorf("volume claim template %q: invalid storage quantity %q: %w", t.Name, t.Storage, err)
+		}
+
+		accessModes := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+		if len(t.AccessModes) > 0 {
+			accessModes = accessModes[:0]
+			for _, m := range t.AccessModes {
+				accessModes = append(accessModes, corev1.PersistentVolumeAccessMode(m))
+			}
+		}
+
+		claim := corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: t.Name},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: accessModes,
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: quantity},
+				},
+			},
+		}
+		if t.StorageClassName != "" {
+			claim.Spec.StorageClassName = ptr(t.StorageClassName)
+		}
+
+		claims = append(claims, claim)
+		mounts = append(mounts, corev1.VolumeMount{Name: t.Name, MountPath: t.MountPath})
+	}
+
+	return claims, mounts, nil
+}
+
+// buildStatefulSetUpdateStrategy validates the strategy type and partition.
+// An empty type leaves the API server default (RollingUpdate).
+func buildStatefulSetUpdateStrategy(strategyType string, partition *int32) (appsv1.StatefulSetUpdateStrategy, error) {
+	var strategy appsv1.StatefulSetUpdateStrategy
+
+	switch appsv1.StatefulSetUpdateStrategyType(strategyType) {
+	case "":
+		if partition != nil {
+			strategy.Type = appsv1.RollingUpdateStatefulSetStrategyType
+		}
+	case appsv1.RollingUpdateStatefulSetStrategyType:
+		strategy.Type = appsv1.RollingUpdateStatefulSetStrategyType
+	case appsv1.OnDeleteStatefulSetStrategyType:
+		if partition != nil {
+			return strategy, errors.New("partition only applies to the RollingUpdate update strategy")
+		}
+		strategy.Type = appsv1.OnDeleteStatefulSetStrategyType
+		return strategy, nil
+	default:
+		return strategy, fmt.Errorf("invalid update strategy %q: must be RollingUpdate or OnDelete", strategyType)
+	}
+
+	if partition != nil {
+		if *partition < 0 {
+			return strategy, fmt.Errorf("partition must be non-negative, got %d", *partition)
+		}
+		strategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{Partition: partition}
+	}
+
+	return strategy, nil
+}
+
+// parseContainerPort parses a "port[/protocol]" string such as "5432/TCP".
+func parseContainerPort(spec string) (corev1.ContainerPort, error) {
+	parts := strings.Split(spec, "/")
+
+	var port int32
+	if _, err := fmt.Sscanf(parts[0], "%d", &port); err != nil || port <= 0 || port > 65535 {
+		return corev1.ContainerPort{}, fmt.Errorf("invalid container port %q", spec)
+	}
+
+	containerPort := corev1.ContainerPort{ContainerPort: port}
+	if len(parts) > 1 {
+		switch protocol := corev1.Protocol(strings.ToUpper(parts[1])); protocol {
+		case corev1.ProtocolTCP, corev1.ProtocolUDP, corev1.ProtocolSCTP:
+			containerPort.Protocol = protocol
+		default:
+			return corev1.ContainerPort{}, fmt.Errorf("invalid protocol %q in container port %q", parts[1], spec)
+		}
+	}
+
+	return containerPort, nil
+}
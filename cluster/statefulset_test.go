@@ -0,0 +1,401 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai"
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const statefulSetName = "postgres"
+
+func TestStatefulSetOperations(t *testing.T) {
+	t.Run("CreateStatefulSet", testCreateStatefulSet)
+	t.Run("GetStatefulSet", testGetStatefulSet)
+	t.Run("ListStatefulSets", testListStatefulSets)
+	t.Run("UpdateStatefulSet", testUpdateStatefulSet)
+	t.Run("DescribeStatefulSet", testDescribeStatefulSet)
+	t.Run("DeleteStatefulSet", testDeleteStatefulSet)
+	t.Run("ScaleStatefulSet", testScaleStatefulSet)
+}
+
+func newStatefulSet(replicas int32) *appsv1.StatefulSet {
+	labels := map[string]string{"app": statefulSetName}
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: statefulSetName, Namespace: testNamespace, Labels: labels},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    &replicas,
+			ServiceName: "postgres-headless",
+			Selector:    &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: statefulSetName, Image: "postgres:16"}},
+				},
+			},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+				ObjectMeta: metav1.ObjectMeta{Name: "data"},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+					},
+				},
+			}},
+		},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:        replicas,
+			CurrentReplicas: replicas,
+			ReadyReplicas:   replicas - 1,
+			UpdatedReplicas: replicas,
+		},
+	}
+}
+
+func testCreateStatefulSet(t *testing.T) {
+	ctx := context.Background()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+
+	t.Run("WithVolumeClaimTemplates", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(ns)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		replicas := int32(3)
+		partition := int32(2)
+		statefulSet := &StatefulSet{
+			Name:          statefulSetName,
+			Namespace:     testNamespace,
+			Image:         "postgres:16",
+			Replicas:      &replicas,
+			ServiceName:   "postgres-headless",
+			ContainerPort: "5432/TCP",
+			VolumeClaimTemplates: []kai.VolumeClaimTemplate{{
+				Name:             "data",
+				MountPath:        "/var/lib/postgresql/data",
+				Storage:          "10Gi",
+				StorageClassName: "fast",
+			}},
+			UpdateStrategy: "RollingUpdate",
+			Partition:      &partition,
+		}
+
+		result, err := statefulSet.Create(ctx, mockCM)
+		require.NoError(t, err)
+		assert.Equal(t, `StatefulSet "postgres" created successfully in namespace "test-namespace" with 3 replica(s)`, result)
+
+		created, err := fakeClient.AppsV1().StatefulSets(testNamespace).Get(ctx, statefulSetName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "postgres-headless", created.Spec.ServiceName)
+		assert.Equal(t, int32(3), *created.Spec.Replicas)
+		assert.Equal(t, map[string]string{"app": statefulSetName}, created.Spec.Selector.MatchLabels)
+		require.Len(t, created.Spec.VolumeClaimTemplates, 1)
+		claim := created.Spec.VolumeClaimTemplates[0]
+		assert.Equal(t, "data", claim.Name)
+		assert.Equal(t, "fast", *claim.Spec.StorageClassName)
+		assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, claim.Spec.AccessModes)
+		storage := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+		assert.Equal(t, "10Gi", storage.String())
+		container := created.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, []corev1.VolumeMount{{Name: "data", MountPath: "/var/lib/postgresql/data"}}, container.VolumeMounts)
+		assert.Equal(t, int32(5432), container.Ports[0].ContainerPort)
+		assert.Equal(t, appsv1.RollingUpdateStatefulSetStrategyType, created.Spec.UpdateStrategy.Type)
+		assert.Equal(t, int32(2), *created.Spec.UpdateStrategy.RollingUpdate.Partition)
+		mockCM.AssertExpectations(t)
+	})
+
+	testCases := []struct {
+		name          string
+		statefulSet   *StatefulSet
+		expectedError string
+	}{
+		{
+			name:          "MissingServiceName",
+			statefulSet:   &StatefulSet{Name: statefulSetName, Namespace: testNamespace, Image: "postgres:16"},
+			expectedError: "service name is required",
+		},
+		{
+			name: "InvalidStrategy",
+			statefulSet: &StatefulSet{Name: statefulSetName, Namespace: testNamespace, Image: "postgres:16",
+				ServiceName: "postgres-headless", UpdateStrategy: "Recreate"},
+			expectedError: `invalid update strategy "Recreate"`,
+		},
+		{
+			name: "PartitionWithOnDelete",
+			statefulSet: &StatefulSet{Name: statefulSetName, Namespace: testNamespace, Image: "postgres:16",
+				ServiceName: "postgres-headless", UpdateStrategy: "OnDelete", Partition: ptr(int32(1))},
+			expectedError: "partition only applies to the RollingUpdate update strategy",
+		},
+		{
+			name: "TemplateMissingMountPath",
+			statefulSet: &StatefulSet{Name: statefulSetName, Namespace: testNamespace, Image: "postgres:16",
+				ServiceName:          "postgres-headless",
+				VolumeClaimTemplates: []kai.VolumeClaimTemplate{{Name: "data", Storage: "1Gi"}}},
+			expectedError: `volume claim template "data": mount path is required`,
+		},
+		{
+			name: "TemplateInvalidStorage",
+			statefulSet: &StatefulSet{Name: statefulSetName, Namespace: testNamespace, Image: "postgres:16",
+				ServiceName:          "postgres-headless",
+				VolumeClaimTemplates: []kai.VolumeClaimTemplate{{Name: "data", MountPath: "/data", Storage: "lots"}}},
+			expectedError: `invalid storage quantity "lots"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+
+			result, err := tc.statefulSet.Create(ctx, mockCM)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedError)
+			assert.Empty(t, result)
+			mockCM.AssertExpectations(t)
+		})
+	}
+}
+
+func testGetStatefulSet(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Found", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(newStatefulSet(3)), nil)
+
+		statefulSet := &StatefulSet{Name: statefulSetName, Namespace: testNamespace}
+		result, err := statefulSet.Get(ctx, mockCM)
+		require.NoError(t, err)
+		assert.Contains(t, result, "StatefulSet: postgres")
+		assert.Contains(t, result, "Service Name: postgres-headless")
+		assert.Contains(t, result, "Replicas: 2/3 (ready/desired)")
+		assert.Contains(t, result, "Update Strategy: RollingUpdate")
+		mockCM.AssertExpectations(t)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(), nil)
+
+		statefulSet := &StatefulSet{Name: "missing", Namespace: testNamespace}
+		_, err := statefulSet.Get(ctx, mockCM)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `StatefulSet "missing" not found in namespace "test-namespace"`)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+}
+
+func testListStatefulSets(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("InNamespace", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(newStatefulSet(3)), nil)
+
+		statefulSet := &StatefulSet{Namespace: testNamespace}
+		result, err := statefulSet.List(ctx, mockCM, false, "")
+		require.NoError(t, err)
+		assert.Contains(t, result, `StatefulSets in namespace "test-namespace":`)
+		assert.Contains(t, result, "• postgres: 2/3 replicas ready - Service: postgres-headless")
+		assert.Contains(t, result, "Total: 1 StatefulSet(s)")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(), nil)
+
+		statefulSet := &StatefulSet{Namespace: testNamespace}
+		_, err := statefulSet.List(ctx, mockCM, false, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `no StatefulSets found in namespace "test-namespace"`)
+	})
+}
+
+func testUpdateStatefulSet(t *testing.T) {
+	ctx := context.Background()
+
+	fakeClient := fake.NewSimpleClientset(newStatefulSet(3))
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+	statefulSet := &StatefulSet{
+		Name:           statefulSetName,
+		Namespace:      testNamespace,
+		Image:          "postgres:17",
+		Labels:         map[string]interface{}{"tier": "db"},
+		UpdateStrategy: "OnDelete",
+	}
+
+	result, err := statefulSet.Update(ctx, mockCM)
+	require.NoError(t, err)
+	assert.Equal(t, `StatefulSet "postgres" updated successfully in namespace "test-namespace"`, result)
+
+	updated, err := fakeClient.AppsV1().StatefulSets(testNamespace).Get(ctx, statefulSetName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "postgres:17", updated.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "db", updated.Labels["tier"])
+	assert.Equal(t, "db", updated.Spec.Template.Labels["tier"])
+	assert.Equal(t, map[string]string{"app": statefulSetName}, updated.Spec.Selector.MatchLabels)
+	assert.Equal(t, appsv1.OnDeleteStatefulSetStrategyType, updated.Spec.UpdateStrategy.Type)
+	assert.Equal(t, int32(3), *updated.Spec.Replicas)
+}
+
+func testDescribeStatefulSet(t *testing.T) {
+	ctx := context.Background()
+
+	headless := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgres-headless", Namespace: testNamespace},
+		Spec:       corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone},
+	}
+	clusterIP := headless.DeepCopy()
+	clusterIP.Spec.ClusterIP = "10.0.0.12"
+
+	testCases := []struct {
+		name            string
+		objects         []runtime.Object
+		expectedService string
+	}{
+		{
+			name:            "HeadlessService",
+			objects:         []runtime.Object{newStatefulSet(3), headless},
+			expectedService: "Headless Service: postgres-headless (headless)",
+		},
+		{
+			name:            "ServiceWithClusterIP",
+			objects:         []runtime.Object{newStatefulSet(3), clusterIP},
+			expectedService: "Headless Service: postgres-headless (not headless, ClusterIP 10.0.0.12)",
+		},
+		{
+			name:            "MissingService",
+			objects:         []runtime.Object{newStatefulSet(3)},
+			expectedService: "Headless Service: postgres-headless (not found)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(tc.objects...), nil)
+
+			statefulSet := &StatefulSet{Name: statefulSetName, Namespace: testNamespace}
+			result, err := statefulSet.Describe(ctx, mockCM)
+			require.NoError(t, err)
+			assert.Contains(t, result, tc.expectedService)
+			assert.Contains(t, result, "- Desired: 3")
+			assert.Contains(t, result, "- Current: 3")
+			assert.Contains(t, result, "- Ready: 2")
+			assert.Contains(t, result, "- Updated: 3")
+			assert.Contains(t, result, "- data: 1Gi, RWO, StorageClass: <default>")
+		})
+	}
+}
+
+func testDeleteStatefulSet(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("RetainsClaims", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(newStatefulSet(3))
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		statefulSet := &StatefulSet{Name: statefulSetName, Namespace: testNamespace}
+		result, err := statefulSet.Delete(ctx, mockCM)
+		require.NoError(t, err)
+		assert.Equal(t, `StatefulSet "postgres" deleted successfully from namespace "test-namespace"; its PersistentVolumeClaims were retained`, result)
+
+		_, err = fakeClient.AppsV1().StatefulSets(testNamespace).Get(ctx, statefulSetName, metav1.GetOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("DeletePolicy", func(t *testing.T) {
+		existing := newStatefulSet(3)
+		existing.Spec.PersistentVolumeClaimRetentionPolicy = &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+			WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+		}
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(existing), nil)
+
+		statefulSet := &StatefulSet{Name: statefulSetName, Namespace: testNamespace}
+		result, err := statefulSet.Delete(ctx, mockCM)
+		require.NoError(t, err)
+		assert.Equal(t, `StatefulSet "postgres" deleted successfully from namespace "test-namespace"`, result)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(), nil)
+
+		statefulSet := &StatefulSet{Name: "missing", Namespace: testNamespace}
+		_, err := statefulSet.Delete(ctx, mockCM)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `StatefulSet "missing" not found`)
+	})
+}
+
+func testScaleStatefulSet(t *testing.T) {
+	ctx := context.Background()
+
+	fakeClient := fake.NewSimpleClientset(newStatefulSet(3))
+	addStatefulSetScaleReactors(fakeClient)
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+	statefulSet := &StatefulSet{Name: statefulSetName, Namespace: testNamespace}
+	result, err := statefulSet.Scale(ctx, mockCM, 5)
+	require.NoError(t, err)
+	assert.Equal(t, `StatefulSet "postgres" scaled to 5 replica(s) in namespace "test-namespace"`, result)
+
+	scaled, err := fakeClient.AppsV1().StatefulSets(testNamespace).Get(ctx, statefulSetName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), *scaled.Spec.Replicas)
+
+	_, err = statefulSet.Scale(ctx, mockCM, -1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "replicas must be non-negative")
+}
+
+// addStatefulSetScaleReactors serves the statefulsets scale subresource,
+// which the fake clientset does not implement.
+func addStatefulSetScaleReactors(client *fake.Clientset) {
+	gvr := appsv1.SchemeGroupVersion.WithResource("statefulsets")
+	client.PrependReactor("get", "statefulsets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		get := action.(k8stesting.GetAction)
+		if get.GetSubresource() != "scale" {
+			return false, nil, nil
+		}
+		obj, err := client.Tracker().Get(gvr, get.GetNamespace(), get.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+		statefulSet := obj.(*appsv1.StatefulSet)
+		return true, &autoscalingv1.Scale{
+			ObjectMeta: metav1.ObjectMeta{Name: statefulSet.Name, Namespace: statefulSet.Namespace},
+			Spec:       autoscalingv1.ScaleSpec{Replicas: *statefulSet.Spec.Replicas},
+		}, nil
+	})
+	client.PrependReactor("update", "statefulsets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		update := action.(k8stesting.UpdateAction)
+		if update.GetSubresource() != "scale" {
+			return false, nil, nil
+		}
+		scale := update.GetObject().(*autoscalingv1.Scale)
+		obj, err := client.Tracker().Get(gvr, update.GetNamespace(), scale.Name)
+		if err != nil {
+			return true, nil, err
+		}
+		statefulSet := obj.(*appsv1.StatefulSet).DeepCopy()
+		statefulSet.Spec.Replicas = &scale.Spec.Replicas
+		return true, scale, client.Tracker().Update(gvr, statefulSet, update.GetNamespace())
+	})
+}
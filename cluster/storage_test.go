@@ -113,6 +113,39 @@ func TestPersistentVolumeClaimOperations(t *testing.T) {
 		assert.Error(t, err)
 		_, err = (&PersistentVolumeClaim{Name: "x", Storage: "bad-qty"}).Create(ctx, mockCM)
 		assert.Error(t, err)
+		_, err = (&PersistentVolumeClaim{Name: "x", Storage: "1Gi", AccessModes: []string{"RWO"}}).Create(ctx, mockCM)
+		assert.EqualError(t, err, `invalid access mode "RWO": must be ReadWriteOnce, ReadOnlyMany, ReadWriteMany or ReadWriteOncePod`)
+		_, err = (&PersistentVolumeClaim{Name: "x", Storage: "1Gi", VolumeMode: "block"}).Create(ctx, mockCM)
+		assert.EqualError(t, err, `invalid volume mode "block": must be Filesystem or Block`)
+	})
+
+	t.Run("GetBound", func(t *testing.T) {
+		standard := "standard"
+		bound := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: defaultNamespace},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources:        corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}},
+				StorageClassName: &standard,
+				VolumeName:       "pvc-1234",
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Phase:       corev1.ClaimBound,
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce, corev1.ReadOnlyMany},
+				Capacity:    corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("12Gi")},
+			},
+		}
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(bound), nil)
+		mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+
+		got, err := (&PersistentVolumeClaim{Name: "data"}).Get(ctx, mockCM)
+		assert.NoError(t, err)
+		assert.Contains(t, got, "Status: Bound")
+		assert.Contains(t, got, "Bound Volume: pvc-1234")
+		assert.Contains(t, got, "Requested: 10Gi")
+		assert.Contains(t, got, "Capacity: 12Gi")
+		assert.Contains(t, got, "Access Modes: RWO,ROX")
 	})
 
 	t.Run("ListGetDelete", func(t *testing.T) {
@@ -171,15 +204,15 @@ func TestPVCNamespaceOverride(t *testing.T) {
 	assert.Contains(t, result, otherNamespace)
 }
 
-func TestRBACNamespaceOverride(t *testing.T) {
+func TestRoleNamespaceOverride(t *testing.T) {
 	ctx := context.Background()
 	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: defaultNamespace}}
 	fakeClient := fake.NewSimpleClientset(role)
 	mockCM := testmocks.NewMockClusterManager()
 	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
 
-	// Explicit namespace exercises the non-default branch of RBAC.namespace.
-	_, err := (&RBAC{Namespace: defaultNamespace}).ListRoles(ctx, mockCM, false)
+	// Explicit namespace exercises the non-default branch of Role.namespace.
+	_, err := (&Role{Namespace: defaultNamespace}).List(ctx, mockCM, false)
 	assert.NoError(t, err)
 }
 
@@ -20,7 +20,7 @@ type StorageClass struct {
 
 // List returns all storage classes in the cluster.
 func (s *StorageClass) List(ctx context.Context, cm kai.ClusterManager) (string, error) {
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting client: %w", err)
 	}
@@ -46,7 +46,7 @@ func (s *StorageClass) Get(ctx context.Context, cm kai.ClusterManager) (string,
 		return "", fmt.Errorf("storage class name is required")
 	}
 
-	client, err := cm.GetCurrentClient()
+	client, err := kai.CurrentClient(ctx, cm)
 	if err != nil {
 		return "", fmt.Errorf("error getting client: %w", err)
 	}
@@ -0,0 +1,61 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// newStreamExecutor opens exec and attach streams over WebSocket, which
+// API servers from 1.29 prefer and proxies pass more readily, and falls back
+// to SPDY for servers or proxies that refuse the WebSocket upgrade.
+func newStreamExecutor(config *rest.Config, method string, u *url.URL) (remotecommand.Executor, error) {
+	websocket, err := remotecommand.NewWebSocketExecutor(config, method, u.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create WebSocket executor: %w", err)
+	}
+	spdy, err := remotecommand.NewSPDYExecutor(config, method, u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SPDY executor: %w", err)
+	}
+	return &fallbackExecutor{primary: websocket, secondary: spdy}, nil
+}
+
+// fallbackExecutor tries primary (WebSocket) and, only when its upgrade is
+// refused, secondary (SPDY). Unlike remotecommand.FallbackExecutor it
+// reports both failures when neither protocol can be negotiated, instead of
+// just the SPDY one.
+type fallbackExecutor struct {
+	primary, secondary remotecommand.Executor
+}
+
+func (e *fallbackExecutor) Stream(options remotecommand.StreamOptions) error {
+	return e.StreamWithContext(context.Background(), options)
+}
+
+func (e *fallbackExecutor) StreamWithContext(ctx context.Context, options remotecommand.StreamOptions) error {
+	err := e.primary.StreamWithContext(ctx, options)
+	if err == nil || !(httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)) {
+		return err
+	}
+
+	slog.Debug("WebSocket upgrade refused, falling back to SPDY", slog.String("error", err.Error()))
+	spdyErr := e.secondary.StreamWithContext(ctx, options)
+	if spdyErr != nil && upgradeRefused(spdyErr) {
+		return fmt.Errorf("the API server accepted neither a WebSocket nor a SPDY streaming connection (websocket: %v; spdy: %v)", err, spdyErr)
+	}
+	return spdyErr
+}
+
+// upgradeRefused reports whether err means the SPDY upgrade itself was
+// rejected. A Status returned by the server (Forbidden, NotFound, ...) is a
+// real answer and is not treated as a protocol problem.
+func upgradeRefused(err error) bool {
+	return httpstream.IsUpgradeFailure(err) || strings.HasPrefix(err.Error(), "unable to upgrade connection")
+}
@@ -0,0 +1,103 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// protocolExecutor stands in for one streaming protocol: it either writes
+// its name to stdout or fails with err, and records whether it was used.
+type protocolExecutor struct {
+	name   string
+	err    error
+	called bool
+}
+
+func (p *protocolExecutor) Stream(options remotecommand.StreamOptions) error {
+	return p.StreamWithContext(context.Background(), options)
+}
+
+func (p *protocolExecutor) StreamWithContext(_ context.Context, options remotecommand.StreamOptions) error {
+	p.called = true
+	if p.err != nil {
+		return p.err
+	}
+	_, err := options.Stdout.Write([]byte(p.name))
+	return err
+}
+
+func TestFallbackExecutor(t *testing.T) {
+	wsRefused := &httpstream.UpgradeFailureError{Cause: errors.New("websocket: bad handshake (400 Bad Request)")}
+	spdyRefused := errors.New("unable to upgrade connection: upgrade not supported")
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods/exec"}, "web", errors.New("denied"))
+
+	stream := func(ws, spdy *protocolExecutor) (string, error) {
+		var stdout bytes.Buffer
+		err := (&fallbackExecutor{primary: ws, secondary: spdy}).StreamWithContext(context.Background(), remotecommand.StreamOptions{Stdout: &stdout})
+		return stdout.String(), err
+	}
+
+	t.Run("WebSocketOnly", func(t *testing.T) {
+		ws, spdy := &protocolExecutor{name: "websocket"}, &protocolExecutor{name: "spdy", err: spdyRefused}
+		out, err := stream(ws, spdy)
+		assert.NoError(t, err)
+		assert.Equal(t, "websocket", out)
+		assert.False(t, spdy.called)
+	})
+
+	t.Run("SPDYOnly", func(t *testing.T) {
+		ws, spdy := &protocolExecutor{name: "websocket", err: wsRefused}, &protocolExecutor{name: "spdy"}
+		out, err := stream(ws, spdy)
+		assert.NoError(t, err)
+		assert.Equal(t, "spdy", out)
+		assert.True(t, spdy.called)
+	})
+
+	t.Run("Neither", func(t *testing.T) {
+		_, err := stream(&protocolExecutor{err: wsRefused}, &protocolExecutor{err: spdyRefused})
+		assert.ErrorContains(t, err, "accepted neither a WebSocket nor a SPDY streaming connection")
+		assert.ErrorContains(t, err, "bad handshake")
+		assert.ErrorContains(t, err, "upgrade not supported")
+	})
+
+	t.Run("ServerStatusIsNotAProtocolFailure", func(t *testing.T) {
+		_, err := stream(&protocolExecutor{err: &httpstream.UpgradeFailureError{Cause: forbidden}}, &protocolExecutor{err: forbidden})
+		assert.True(t, apierrors.IsForbidden(err))
+	})
+
+	t.Run("OtherErrorsDoNotFallBack", func(t *testing.T) {
+		spdy := &protocolExecutor{name: "spdy"}
+		_, err := stream(&protocolExecutor{err: errors.New("connection reset")}, spdy)
+		assert.EqualError(t, err, "connection reset")
+		assert.False(t, spdy.called)
+	})
+}
+
+func TestNewStreamExecutorNoStreamingSupport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upgrade not supported", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL + "/api/v1/namespaces/default/pods/web/exec?command=ls&stdout=true")
+	require.NoError(t, err)
+	executor, err := newStreamExecutor(&rest.Config{Host: srv.URL}, "POST", u)
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+	err = executor.StreamWithContext(context.Background(), remotecommand.StreamOptions{Stdout: &stdout})
+	assert.ErrorContains(t, err, "accepted neither a WebSocket nor a SPDY streaming connection")
+}
@@ -0,0 +1,209 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/basebandit/kai"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// metricsUnavailable is reported instead of an error when the cluster does
+// not serve metrics.k8s.io, which almost always means metrics-server is not
+// installed.
+const metricsUnavailable = "Metrics API (metrics.k8s.io/v1beta1) is not available; install metrics-server to use %s"
+
+// topUsage is one row of top_pods or top_nodes output.
+type topUsage struct {
+	namespace string
+	name      string
+	cpu       resource.Quantity
+	memory    resource.Quantity
+	// cpuPercent and memoryPercent are of node allocatable; -1 when unknown.
+	cpuPercent    int64
+	memoryPercent int64
+}
+
+// TopPods lists pod CPU and memory usage, summed over each pod's containers,
+// with the heaviest pods first. sortBy is "cpu" or "memory" (the default).
+func (h *Health) TopPods(ctx context.Context, cm kai.ClusterManager, namespace string, allNamespaces bool, labelSelector, sortBy string) (string, error) {
+	if err := validateTopSort(sortBy); err != nil {
+		return "", err
+	}
+
+	client, err := kai.CurrentMetricsClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting metrics client: %w", err)
+	}
+
+	ns := ""
+	if !allNamespaces {
+		ns = namespace
+		if ns == "" {
+			ns = kai.CurrentNamespace(ctx, cm)
+		}
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	list, err := client.MetricsV1beta1().PodMetricses(ns).List(timeoutCtx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf(metricsUnavailable, "top_pods"), nil
+		}
+		return "", fmt.Errorf("failed to list pod metrics: %w", err)
+	}
+
+	if len(list.Items) == 0 {
+		if allNamespaces {
+			return "No pod metrics available", nil
+		}
+		return fmt.Sprintf("No pod metrics available in namespace %q", ns), nil
+	}
+
+	rows := make([]topUsage, 0, len(list.Items))
+	for _, pm := range list.Items {
+		row := topUsage{namespace: pm.Namespace, name: pm.Name, cpuPercent: -1, memoryPercent: -1}
+		for _, c := range pm.Containers {
+			row.cpu.Add(*c.Usage.Cpu())
+			row.memory.Add(*c.Usage.Memory())
+		}
+		rows = append(rows, row)
+	}
+	sortTopUsage(rows, sortBy)
+
+	var sb strings.Builder
+	if allNamespaces {
+		fmt.Fprintf(&sb, "Pod usage across all namespaces (%d):\n", len(rows))
+	} else {
+		fmt.Fprintf(&sb, "Pod usage in namespace %q (%d):\n", ns, len(rows))
+	}
+	for _, r := range rows {
+		name := r.name
+		if allNamespaces {
+			name = r.namespace + "/" + r.name
+		}
+		fmt.Fprintf(&sb, "• %s\tcpu: %s\tmemory: %s\n", name, formatCPU(r.cpu), formatMemory(r.memory))
+	}
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// TopNodes lists node CPU and memory usage alongside the share of each
+// node's allocatable resources it represents, heaviest first. sortBy is
+// "cpu" or "memory" (the default).
+func (h *Health) TopNodes(ctx context.Context, cm kai.ClusterManager, sortBy string) (string, error) {
+	if err := validateTopSort(sortBy); err != nil {
+		return "", err
+	}
+
+	metricsClient, err := kai.CurrentMetricsClient(ctx, cm)
+	if err != nil {
+		return "", fmt.Errorf("error getting metrics client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	list, err := metricsClient.MetricsV1beta1().NodeMetricses().List(timeoutCtx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf(metricsUnavailable, "top_nodes"), nil
+		}
+		return "", fmt.Errorf("failed to list node metrics: %w", err)
+	}
+
+	if len(list.Items) == 0 {
+		return "No node metrics available", nil
+	}
+
+	// Allocatable is best-effort: usage is still worth showing without it.
+	allocatable := map[string]corev1.ResourceList{}
+	if client, err := kai.CurrentClient(ctx, cm); err == nil {
+		if nodes, err := client.CoreV1().Nodes().List(timeoutCtx, metav1.ListOptions{}); err == nil {
+			for _, node := range nodes.Items {
+				allocatable[node.Name] = node.Status.Allocatable
+			}
+		}
+	}
+
+	rows := make([]topUsage, 0, len(list.Items))
+	for _, nm := range list.Items {
+		row := topUsage{
+			name:          nm.Name,
+			cpu:           *nm.Usage.Cpu(),
+			memory:        *nm.Usage.Memory(),
+			cpuPercent:    -1,
+			memoryPercent: -1,
+		}
+		if alloc, ok := allocatable[nm.Name]; ok {
+			row.cpuPercent = usagePercent(row.cpu.MilliValue(), alloc.Cpu().MilliValue())
+			row.memoryPercent = usagePercent(row.memory.Value(), alloc.Memory().Value())
+		}
+		rows = append(rows, row)
+	}
+	sortTopUsage(rows, sortBy)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Node usage (%d):\n", len(rows))
+	for _, r := range rows {
+		fmt.Fprintf(&sb, "• %s\tcpu: %s%s\tmemory: %s%s\n",
+			r.name, formatCPU(r.cpu), percentSuffix(r.cpuPercent), formatMemory(r.memory), percentSuffix(r.memoryPercent))
+	}
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+func validateTopSort(sortBy string) error {
+	switch sortBy {
+	case "", "cpu", "memory":
+		return nil
+	}
+	return fmt.Errorf("invalid sort_by %q: must be cpu or memory", sortBy)
+}
+
+// sortTopUsage orders rows by the chosen resource, descending, breaking
+// ties by name so output is stable.
+func sortTopUsage(rows []topUsage, sortBy string) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i].memory, rows[j].memory
+		if sortBy == "cpu" {
+			a, b = rows[i].cpu, rows[j].cpu
+		}
+		if c := a.Cmp(b); c != 0 {
+			return c > 0
+		}
+		if rows[i].namespace != rows[j].namespace {
+			return rows[i].namespace < rows[j].namespace
+		}
+		return rows[i].name < rows[j].name
+	})
+}
+
+func usagePercent(used, total int64) int64 {
+	if total <= 0 {
+		return -1
+	}
+	return used * 100 / total
+}
+
+func percentSuffix(percent int64) string {
+	if percent < 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%d%%)", percent)
+}
+
+// formatCPU renders CPU usage in millicores, as kubectl top does.
+func formatCPU(q resource.Quantity) string {
+	return fmt.Sprintf("%dm", q.MilliValue())
+}
+
+// formatMemory renders memory usage in mebibytes, as kubectl top does.
+func formatMemory(q resource.Quantity) string {
+	return fmt.Sprintf("%dMi", q.Value()/(1024*1024))
+}
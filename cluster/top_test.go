@@ -0,0 +1,162 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func newTopPodMetrics(namespace, name string, usage ...corev1.ResourceList) *metricsv1beta1.PodMetrics {
+	pm := &metricsv1beta1.PodMetrics{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	for i, u := range usage {
+		pm.Containers = append(pm.Containers, metricsv1beta1.ContainerMetrics{Name: string(rune('a' + i)), Usage: u})
+	}
+	return pm
+}
+
+func usageList(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{corev1.ResourceCPU: resourceQty(cpu), corev1.ResourceMemory: resourceQty(memory)}
+}
+
+// newTopMetricsClient seeds the fake through its tracker: the generated fake
+// lists "pods" and "nodes" while the tracker would guess "podmetricses" and
+// "nodemetricses" from the kinds.
+func newTopMetricsClient(t *testing.T, objects ...runtime.Object) *metricsfake.Clientset {
+	t.Helper()
+	client := metricsfake.NewSimpleClientset()
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *metricsv1beta1.PodMetrics:
+			require.NoError(t, client.Tracker().Create(metricsv1beta1.SchemeGroupVersion.WithResource("pods"), o, o.Namespace))
+		case *metricsv1beta1.NodeMetrics:
+			require.NoError(t, client.Tracker().Create(metricsv1beta1.SchemeGroupVersion.WithResource("nodes"), o, ""))
+		}
+	}
+	return client
+}
+
+func TestTopPods(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("SortedByMemory", func(t *testing.T) {
+		metrics := newTopMetricsClient(t,
+			newTopPodMetrics(testNamespace, "small", usageList("50m", "64Mi")),
+			newTopPodMetrics(testNamespace, "large", usageList("10m", "300Mi"), usageList("5m", "212Mi")),
+			newTopPodMetrics(testNamespace, "medium", usageList("900m", "128Mi")),
+			newTopPodMetrics("other", "elsewhere", usageList("1", "1Gi")),
+		)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentMetricsClient").Return(metrics, nil)
+
+		result, err := (&Health{}).TopPods(ctx, mockCM, testNamespace, false, "", "")
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, `Pod usage in namespace "test-namespace" (3)`)
+		assert.Contains(t, result, "• large\tcpu: 15m\tmemory: 512Mi")
+		assert.NotContains(t, result, "elsewhere")
+		assert.Less(t, strings.Index(result, "large"), strings.Index(result, "medium"))
+		assert.Less(t, strings.Index(result, "medium"), strings.Index(result, "small"))
+	})
+
+	t.Run("SortedByCPUAcrossNamespaces", func(t *testing.T) {
+		metrics := newTopMetricsClient(t,
+			newTopPodMetrics(testNamespace, "idle", usageList("1m", "900Mi")),
+			newTopPodMetrics("other", "busy", usageList("1500m", "10Mi")),
+		)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentMetricsClient").Return(metrics, nil)
+
+		result, err := (&Health{}).TopPods(ctx, mockCM, "", true, "", "cpu")
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "other/busy\tcpu: 1500m")
+		assert.Less(t, strings.Index(result, "other/busy"), strings.Index(result, "test-namespace/idle"))
+	})
+
+	t.Run("MetricsServerMissing", func(t *testing.T) {
+		metrics := metricsfake.NewSimpleClientset()
+		metrics.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewNotFound(schema.GroupResource{Group: "metrics.k8s.io", Resource: "pods"}, "")
+		})
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentMetricsClient").Return(metrics, nil)
+
+		result, err := (&Health{}).TopPods(ctx, mockCM, testNamespace, false, "", "")
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "install metrics-server")
+	})
+
+	t.Run("OtherListError", func(t *testing.T) {
+		metrics := metricsfake.NewSimpleClientset()
+		metrics.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("connection refused")
+		})
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentMetricsClient").Return(metrics, nil)
+
+		_, err := (&Health{}).TopPods(ctx, mockCM, testNamespace, false, "", "")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "connection refused")
+	})
+
+	t.Run("InvalidSort", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		_, err := (&Health{}).TopPods(ctx, mockCM, testNamespace, false, "", "disk")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid sort_by")
+	})
+}
+
+func TestTopNodes(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("WithAllocatablePercent", func(t *testing.T) {
+		metrics := newTopMetricsClient(t,
+			&metricsv1beta1.NodeMetrics{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}, Usage: usageList("500m", "1Gi")},
+			&metricsv1beta1.NodeMetrics{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}, Usage: usageList("2", "2Gi")},
+		)
+		nodeA := newNode("node-a", true, false)
+		nodeA.Status.Allocatable = usageList("2", "4Gi")
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentMetricsClient").Return(metrics, nil)
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(nodeA), nil)
+
+		result, err := (&Health{}).TopNodes(ctx, mockCM, "cpu")
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Node usage (2)")
+		assert.Contains(t, result, "• node-a\tcpu: 500m (25%)\tmemory: 1024Mi (25%)")
+		assert.Contains(t, result, "• node-b\tcpu: 2000m\tmemory: 2048Mi")
+		assert.Less(t, strings.Index(result, "node-b"), strings.Index(result, "node-a"))
+	})
+
+	t.Run("MetricsServerMissing", func(t *testing.T) {
+		metrics := metricsfake.NewSimpleClientset()
+		metrics.PrependReactor("list", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewNotFound(schema.GroupResource{Group: "metrics.k8s.io", Resource: "nodes"}, "")
+		})
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentMetricsClient").Return(metrics, nil)
+
+		result, err := (&Health{}).TopNodes(ctx, mockCM, "")
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "top_nodes")
+		assert.Contains(t, result, "install metrics-server")
+	})
+}
@@ -0,0 +1,80 @@
+package cluster
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// withUpdateDiff appends the fields an update changed to its result, from
+// the object as read before the update and as returned by the API server.
+// Comparing against the server's response means defaulting and admission
+// show up too, and a dry run reports what would have changed.
+func withUpdateDiff(result string, before, after runtime.Object) string {
+	changes, err := updateChanges(before, after)
+	if err != nil {
+		return result + "\nChanged fields: unavailable (" + err.Error() + ")"
+	}
+	if len(changes) == 0 {
+		return result + "\nNo fields changed"
+	}
+	return fmt.Sprintf("%s\nChanged fields (%d):\n  %s", result, len(changes), strings.Join(changes, "\n  "))
+}
+
+// updateChanges diffs spec-like content, labels and annotations, leaving
+// out status and server-managed metadata such as resourceVersion and
+// managedFields, which change on every write.
+func updateChanges(before, after runtime.Object) ([]string, error) {
+	from, err := updateContent(before)
+	if err != nil {
+		return nil, err
+	}
+	to, err := updateContent(after)
+	if err != nil {
+		return nil, err
+	}
+	return diffFields(from, to), nil
+}
+
+func updateContent(obj runtime.Object) (map[string]interface{}, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %T: %w", obj, err)
+	}
+	u := &unstructured.Unstructured{Object: content}
+	fields := comparableContent(u)
+	if _, ok := obj.(*corev1.Secret); ok {
+		hideValues(fields["data"])
+		hideValues(fields["stringData"])
+	}
+	if annotations := u.GetAnnotations(); len(annotations) > 0 {
+		metadata, _ := fields["metadata"].(map[string]interface{})
+		if metadata == nil {
+			metadata = map[string]interface{}{}
+			fields["metadata"] = metadata
+		}
+		asMap := make(map[string]interface{}, len(annotations))
+		for k, v := range annotations {
+			asMap[k] = v
+		}
+		metadata["annotations"] = asMap
+	}
+	return fields, nil
+}
+
+// hideValues replaces the values of a Secret's data or stringData with a
+// short hash, so a diff shows which keys changed without their contents.
+func hideValues(values interface{}) {
+	m, ok := values.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for key, value := range m {
+		sum := sha256.Sum256([]byte(fmt.Sprint(value)))
+		m[key] = fmt.Sprintf("hidden (sha256 %x)", sum[:4])
+	}
+}
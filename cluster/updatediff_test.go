@@ -0,0 +1,83 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestUpdateShowDiff(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(objects ...runtime.Object) *testmocks.MockClusterManager {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(objects...), nil)
+		return mockCM
+	}
+	newDeployment := func() *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: deploymentName1, Namespace: testNamespace, ResourceVersion: "1"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr(int32(2)),
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+					Spec: corev1.PodSpec{Containers: []corev1.Container{
+						{Name: "web", Image: "nginx:1.25"},
+					}},
+				},
+			},
+		}
+	}
+
+	t.Run("Image-only update reports just the image", func(t *testing.T) {
+		mockCM := setup(newDeployment())
+		deployment := &Deployment{Name: deploymentName1, Namespace: testNamespace, Image: "nginx:1.27", ShowDiff: true}
+		result, err := deployment.Update(ctx, mockCM)
+		require.NoError(t, err)
+		assert.Contains(t, result, `Deployment "deployment1" updated successfully in namespace "test-namespace"`)
+		assert.Contains(t, result, "\nChanged fields (1):\n  spec.template.spec.containers[0].image: \"nginx:1.25\" → \"nginx:1.27\"")
+	})
+
+	t.Run("Diff is left out unless asked for", func(t *testing.T) {
+		mockCM := setup(newDeployment())
+		deployment := &Deployment{Name: deploymentName1, Namespace: testNamespace, Image: "nginx:1.27"}
+		result, err := deployment.Update(ctx, mockCM)
+		require.NoError(t, err)
+		assert.NotContains(t, result, "Changed fields")
+	})
+
+	t.Run("Update that changes nothing", func(t *testing.T) {
+		mockCM := setup(newDeployment())
+		deployment := &Deployment{Name: deploymentName1, Namespace: testNamespace, Image: "nginx:1.25", ShowDiff: true}
+		result, err := deployment.Update(ctx, mockCM)
+		require.NoError(t, err)
+		assert.Contains(t, result, "\nNo fields changed")
+	})
+
+	t.Run("Secret values are hidden", func(t *testing.T) {
+		mockCM := setup(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: testNamespace},
+			Data:       map[string][]byte{"password": []byte("old-password")},
+		})
+		secret := &Secret{
+			Name:      "creds",
+			Namespace: testNamespace,
+			Data:      map[string]interface{}{"password": "new-password"},
+			ShowDiff:  true,
+		}
+		result, err := secret.Update(ctx, mockCM)
+		require.NoError(t, err)
+		assert.Contains(t, result, "Changed fields (1):\n  data.password: \"hidden (sha256 ")
+		assert.NotContains(t, result, "b2xkLXBhc3N3b3Jk") // base64 of old-password
+		assert.NotContains(t, result, "bmV3LXBhc3N3b3Jk") // base64 of new-password
+	})
+}
@@ -0,0 +1,140 @@
+package cluster
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// watchBackoff spaces out attempts to re-establish a dropped watch or log
+// stream: 250ms doubling up to 5s, with a little jitter so concurrent
+// streams do not reconnect in lockstep.
+var watchBackoff = wait.Backoff{
+	Duration: 250 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    1 << 30,
+	Cap:      5 * time.Second,
+}
+
+// resumableWatch keeps a watch running across apiserver disconnects. When
+// the result channel closes or the watch cannot be opened, it waits with
+// exponential backoff and reopens the watch from the last resourceVersion
+// it saw, so no events are lost. If that resourceVersion has expired, relist
+// supplies a fresh one.
+type resumableWatch struct {
+	// open starts a watch from resourceVersion.
+	open func(ctx context.Context, resourceVersion string) (watch.Interface, error)
+	// relist re-reads the current state after the resourceVersion expired,
+	// returning the version to watch from or done when the caller's
+	// condition already holds.
+	relist func(ctx context.Context) (resourceVersion string, done bool, err error)
+	// handle is called for every Added, Modified and Deleted event and
+	// returns done once the caller has what it waited for.
+	handle func(event watch.Event) (done bool, err error)
+}
+
+// run watches from resourceVersion until handle or relist report done, a
+// non-transient error occurs or ctx ends.
+func (r *resumableWatch) run(ctx context.Context, resourceVersion string) error {
+	backoff := watchBackoff
+	for {
+		w, err := r.open(ctx, resourceVersion)
+		if err == nil {
+			var progressed, stop bool
+			resourceVersion, progressed, stop, err = r.consume(ctx, w, resourceVersion)
+			w.Stop()
+			if stop {
+				return err
+			}
+			if progressed {
+				backoff = watchBackoff
+			}
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		switch {
+		case err == nil:
+		case isExpiredWatch(err):
+			rv, done, err := r.relist(ctx)
+			if err != nil || done {
+				return err
+			}
+			resourceVersion = rv
+			continue
+		case !isTransientWatchError(err):
+			return err
+		}
+
+		delay := backoff.Step()
+		slog.Debug("watch dropped, reconnecting",
+			slog.String("resourceVersion", resourceVersion),
+			slog.Duration("backoff", delay),
+			slog.Any("error", err),
+		)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// consume reads events from w until it closes, handle stops it or ctx ends.
+// It returns the last resourceVersion seen and whether any event arrived, so
+// the next attempt resumes where this one stopped. stop is set when handle
+// reported done or failed, which ends the watch for good.
+func (r *resumableWatch) consume(ctx context.Context, w watch.Interface, resourceVersion string) (string, bool, bool, error) {
+	progressed := false
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion, progressed, false, ctx.Err()
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return resourceVersion, progressed, false, nil
+			}
+			if event.Type == watch.Error {
+				return resourceVersion, progressed, false, apierrors.FromObject(event.Object)
+			}
+			progressed = true
+			if event.Type == watch.Bookmark {
+				if obj, err := meta.Accessor(event.Object); err == nil {
+					resourceVersion = obj.GetResourceVersion()
+				}
+				continue
+			}
+			if done, err := r.handle(event); done || err != nil {
+				return resourceVersion, progressed, true, err
+			}
+			if obj, err := meta.Accessor(event.Object); err == nil && obj.GetResourceVersion() != "" {
+				resourceVersion = obj.GetResourceVersion()
+			}
+		}
+	}
+}
+
+// isExpiredWatch reports whether the apiserver no longer has the requested
+// resourceVersion, so the watch must restart from a fresh list.
+func isExpiredWatch(err error) bool {
+	return apierrors.IsResourceExpired(err) || apierrors.IsGone(err)
+}
+
+// isTransientWatchError reports whether reopening the watch might succeed.
+// Requests the apiserver rejects outright will be rejected again.
+func isTransientWatchError(err error) bool {
+	switch {
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err),
+		apierrors.IsBadRequest(err), apierrors.IsInvalid(err),
+		apierrors.IsNotFound(err), apierrors.IsMethodNotSupported(err):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func TestResumableWatch(t *testing.T) {
+	orig := watchBackoff
+	watchBackoff.Duration = time.Millisecond
+	watchBackoff.Cap = 5 * time.Millisecond
+	defer func() { watchBackoff = orig }()
+
+	ctx := context.Background()
+	cm := func(name, rv string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, ResourceVersion: rv}}
+	}
+
+	// stream hands out one scripted watcher per open call and records the
+	// resourceVersion each call resumed from.
+	type stream struct {
+		err    error
+		events []watch.Event
+	}
+	setup := func(streams ...stream) (*resumableWatch, *[]string, *[]string) {
+		var opened, seen []string
+		rw := &resumableWatch{
+			open: func(_ context.Context, rv string) (watch.Interface, error) {
+				opened = append(opened, rv)
+				if len(streams) == 0 {
+					return nil, errors.New("no more streams")
+				}
+				s := streams[0]
+				streams = streams[1:]
+				if s.err != nil {
+					return nil, s.err
+				}
+				w := watch.NewFakeWithChanSize(len(s.events), false)
+				for _, e := range s.events {
+					w.Action(e.Type, e.Object)
+				}
+				w.Stop()
+				return w, nil
+			},
+			relist: func(context.Context) (string, bool, error) { return "100", false, nil },
+			handle: func(e watch.Event) (bool, error) {
+				name := e.Object.(*corev1.ConfigMap).Name
+				seen = append(seen, name)
+				return name == "done", nil
+			},
+		}
+		return rw, &opened, &seen
+	}
+
+	t.Run("ReopensAfterClose", func(t *testing.T) {
+		rw, opened, seen := setup(
+			stream{events: []watch.Event{{Type: watch.Added, Object: cm("a", "2")}, {Type: watch.Modified, Object: cm("b", "3")}}},
+			stream{err: apierrors.NewServiceUnavailable("apiserver restarting")},
+			stream{events: []watch.Event{{Type: watch.Modified, Object: cm("c", "4")}, {Type: watch.Modified, Object: cm("done", "5")}}},
+		)
+		require.NoError(t, rw.run(ctx, "1"))
+		assert.Equal(t, []string{"a", "b", "c", "done"}, *seen, "events continue across the drop")
+		assert.Equal(t, []string{"1", "3", "3"}, *opened, "each reconnect resumes from the last resourceVersion")
+	})
+
+	t.Run("RelistsWhenExpired", func(t *testing.T) {
+		expired := &metav1.Status{Status: metav1.StatusFailure, Code: 410, Reason: metav1.StatusReasonExpired, Message: "too old resource version"}
+		rw, opened, _ := setup(
+			stream{events: []watch.Event{{Type: watch.Error, Object: expired}}},
+			stream{events: []watch.Event{{Type: watch.Added, Object: cm("done", "101")}}},
+		)
+		require.NoError(t, rw.run(ctx, "1"))
+		assert.Equal(t, []string{"1", "100"}, *opened)
+	})
+
+	t.Run("StopsOnPermanentError", func(t *testing.T) {
+		rw, opened, _ := setup(stream{err: apierrors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, "", errors.New("denied"))})
+		err := rw.run(ctx, "1")
+		assert.True(t, apierrors.IsForbidden(err))
+		assert.Len(t, *opened, 1)
+	})
+
+	t.Run("StopsOnHandlerError", func(t *testing.T) {
+		rw, _, _ := setup(stream{events: []watch.Event{{Type: watch.Added, Object: cm("a", "2")}}})
+		rw.handle = func(watch.Event) (bool, error) { return false, errors.New("gave up") }
+		assert.EqualError(t, rw.run(ctx, "1"), "gave up")
+	})
+
+	t.Run("GivesUpWhenContextEnds", func(t *testing.T) {
+		rw, _, _ := setup()
+		timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, rw.run(timeoutCtx, "1"), context.DeadlineExceeded)
+	})
+}
@@ -2,12 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
 	"log/slog"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
@@ -27,18 +30,31 @@ var (
 func main() {
 	// CLI flags
 	var (
-		kubeconfig     string
-		contextName    string
-		inCluster      bool
-		transport      string
-		sseAddr        string
-		logFormat      string
-		logLevel       string
-		tlsCert        string
-		tlsKey         string
-		requestTimeout time.Duration
-		metricsEnabled bool
-		showVersion    bool
+		kubeconfig         string
+		contextName        string
+		inCluster          bool
+		transport          string
+		sseAddr            string
+		logFormat          string
+		logLevel           string
+		tlsCert            string
+		tlsKey             string
+		requestTimeout     time.Duration
+		clientQPS          float64
+		clientBurst        int
+		apiRetries         int
+		metricsEnabled     bool
+		namespaceKey       string
+		listThreshold      int
+		maxNamespaces      int
+		containerNames     string
+		manifestRoot       string
+		readCacheTTL       time.Duration
+		asUser             string
+		asGroups           string
+		asSA               string
+		allowImpersonation bool
+		showVersion        bool
 	)
 
 	defaultKubeconfig := filepath.Join(os.Getenv("HOME"), ".kube", "config")
@@ -53,7 +69,20 @@ func main() {
 	flag.StringVar(&tlsCert, "tls-cert", "", "Path to TLS certificate file (enables HTTPS for SSE)")
 	flag.StringVar(&tlsKey, "tls-key", "", "Path to TLS private key file (enables HTTPS for SSE)")
 	flag.DurationVar(&requestTimeout, "request-timeout", 30*time.Second, "Timeout for Kubernetes API requests")
+	flag.Float64Var(&clientQPS, "client-qps", float64(cluster.DefaultClientQPS), "Client-side queries per second allowed to the Kubernetes API (higher speeds up bulk operations but adds API server load)")
+	flag.IntVar(&clientBurst, "client-burst", cluster.DefaultClientBurst, "Client-side burst allowed above -client-qps")
+	flag.IntVar(&apiRetries, "api-retries", cluster.DefaultAPIRetries, "Times a Kubernetes API read is retried with backoff after a transient failure (0 disables)")
 	flag.BoolVar(&metricsEnabled, "metrics", true, "Enable Prometheus metrics endpoint at /metrics")
+	flag.StringVar(&namespaceKey, "namespace-meta-key", kai.DefaultNamespaceMetaKey, "Request _meta field holding a per-call namespace override (empty disables)")
+	flag.IntVar(&listThreshold, "list-summary-threshold", cluster.DefaultListSummaryThreshold, "Pod count above which unlimited list_pods calls return a summary and the first page (0 disables)")
+	flag.IntVar(&maxNamespaces, "max-namespaces-scan", 0, "Namespace count above which all_namespaces requests need confirm=true or a label_selector (0 disables)")
+	flag.StringVar(&containerNames, "container-name-strategy", string(tools.DefaultContainerNameStrategy), "How create_pod names the container when container_name is omitted: pod-name (sanitized pod name) or image (image repository name)")
+	flag.StringVar(&manifestRoot, "manifest-root", "", "Directory that tools reading local files are confined to (empty allows any path)")
+	flag.DurationVar(&readCacheTTL, "read-cache-ttl", 0, "How long results of read-only tools are reused for identical calls; any mutating tool clears them (0 disables)")
+	flag.StringVar(&asUser, "as", "", "User to impersonate for every Kubernetes API request")
+	flag.StringVar(&asGroups, "as-group", "", "Comma-separated groups to impersonate, alongside -as or -as-serviceaccount")
+	flag.StringVar(&asSA, "as-serviceaccount", "", "Service account to impersonate, as namespace/name")
+	flag.BoolVar(&allowImpersonation, "allow-impersonation", false, "Enable the impersonate tool, which lets clients act as any identity the kubeconfig's credentials may impersonate (cannot be combined with -as flags)")
 	flag.BoolVar(&showVersion, "version", false, "Show version information")
 	flag.Parse()
 
@@ -66,8 +95,48 @@ func main() {
 		os.Exit(0)
 	}
 
+	strategy, err := tools.ParseContainerNameStrategy(containerNames)
+	if err != nil {
+		logger.Error("invalid flag", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	tools.DefaultContainerNameStrategy = strategy
+
+	impersonation := kai.Impersonation{User: asUser, ServiceAccount: asSA}
+	for _, group := range strings.Split(asGroups, ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			impersonation.Groups = append(impersonation.Groups, group)
+		}
+	}
+	if err := impersonation.Validate(); err != nil {
+		logger.Error("invalid flag", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	if allowImpersonation && !impersonation.IsZero() {
+		logger.Error("invalid flag", slog.String("error", "-allow-impersonation cannot be combined with -as, -as-group or -as-serviceaccount"))
+		os.Exit(1)
+	}
+	if !impersonation.IsZero() {
+		logger.Info("impersonating", slog.String("impersonate", impersonation.String()))
+	}
+
 	// Initialize cluster manager
-	cm := cluster.New(cluster.WithRequestTimeout(requestTimeout))
+	cm := cluster.New(
+		cluster.WithRequestTimeout(requestTimeout),
+		cluster.WithClientRateLimit(float32(clientQPS), clientBurst),
+		cluster.WithAPIRetries(apiRetries),
+		cluster.WithListSummaryThreshold(listThreshold),
+		cluster.WithMaxNamespacesScan(maxNamespaces),
+		cluster.WithImpersonation(impersonation),
+	)
+
+	if !inCluster && shouldFallBackToInCluster(kubeconfig) {
+		logger.Info(
+			"kubeconfig not found, falling back to in-cluster config",
+			slog.String("path", kubeconfig),
+		)
+		inCluster = true
+	}
 
 	if inCluster {
 		if err := cm.LoadInClusterConfig(contextName); err != nil {
@@ -102,6 +171,10 @@ func main() {
 		kai.WithVersion(version),
 		kai.WithRequestTimeout(requestTimeout),
 		kai.WithMetrics(metricsEnabled),
+		kai.WithNamespaceMetaKey(namespaceKey),
+		kai.WithManifestRoot(manifestRoot),
+		kai.WithReadCacheTTL(readCacheTTL),
+		kai.WithImpersonationTool(allowImpersonation),
 	}
 
 	if tlsCert != "" && tlsKey != "" {
@@ -206,6 +279,7 @@ func registerAllTools(s *kai.Server, cm *cluster.Manager) {
 	tools.RegisterNamespaceTools(s, cm)
 	tools.RegisterPodTools(s, cm)
 	tools.RegisterDeploymentTools(s, cm)
+	tools.RegisterStatefulSetTools(s, cm)
 	tools.RegisterServiceTools(s, cm)
 	tools.RegisterContextTools(s, cm)
 	tools.RegisterConfigMapTools(s, cm)
@@ -218,8 +292,24 @@ func registerAllTools(s *kai.Server, cm *cluster.Manager) {
 	tools.RegisterNodeTools(s, cm)
 	tools.RegisterHealthTools(s, cm)
 	tools.RegisterStorageTools(s, cm)
+	tools.RegisterPVCTools(s, cm)
 	tools.RegisterRBACTools(s, cm)
+	tools.RegisterServiceAccountTools(s, cm)
 	tools.RegisterCustomResourceTools(s, cm)
 	tools.RegisterApplyTools(s, cm)
 	tools.RegisterDeleteTools(s, cm)
+	tools.RegisterAnalysisTools(s, cm)
+	tools.RegisterHPATools(s, cm)
+	tools.RegisterQuotaTools(s, cm)
+}
+
+// shouldFallBackToInCluster reports whether kai is running in a pod without
+// a kubeconfig, in which case the pod's service account is the only way to
+// reach the cluster.
+func shouldFallBackToInCluster(kubeconfig string) bool {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") == "" {
+		return false
+	}
+	_, err := os.Stat(kubeconfig)
+	return errors.Is(err, fs.ErrNotExist)
 }
@@ -0,0 +1,99 @@
+package kai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/rest"
+)
+
+// Impersonation names the identity Kubernetes API requests are made as,
+// like kubectl's --as, --as-group and --as-serviceaccount. The API server
+// checks the caller may impersonate it, then authorizes every request as the
+// impersonated identity and records both in its audit log.
+type Impersonation struct {
+	User   string
+	Groups []string
+	// ServiceAccount is "namespace/name". It is sent as the user
+	// system:serviceaccount:namespace:name and cannot be combined with User.
+	ServiceAccount string
+}
+
+// IsZero reports whether no identity is impersonated.
+func (i Impersonation) IsZero() bool {
+	return i.User == "" && i.ServiceAccount == "" && len(i.Groups) == 0
+}
+
+// Validate checks the identity is well formed: at most one of User and
+// ServiceAccount, a namespace/name service account, and groups only
+// alongside a user, which the API server requires.
+func (i Impersonation) Validate() error {
+	if i.User != "" && i.ServiceAccount != "" {
+		return errors.New("user and service account cannot both be impersonated")
+	}
+	if i.ServiceAccount != "" {
+		namespace, name, ok := strings.Cut(i.ServiceAccount, "/")
+		if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+			return fmt.Errorf("service account %q must be given as namespace/name", i.ServiceAccount)
+		}
+	}
+	if len(i.Groups) > 0 && i.User == "" && i.ServiceAccount == "" {
+		return errors.New("groups can only be impersonated together with a user or service account")
+	}
+	return nil
+}
+
+// UserName returns the user sent in the Impersonate-User header.
+func (i Impersonation) UserName() string {
+	if namespace, name, ok := strings.Cut(i.ServiceAccount, "/"); ok {
+		return "system:serviceaccount:" + namespace + ":" + name
+	}
+	return i.User
+}
+
+// Config returns the impersonation settings of a rest.Config.
+func (i Impersonation) Config() rest.ImpersonationConfig {
+	if i.IsZero() {
+		return rest.ImpersonationConfig{}
+	}
+	return rest.ImpersonationConfig{
+		UserName: i.UserName(),
+		Groups:   append([]string(nil), i.Groups...),
+	}
+}
+
+// String describes the identity for logs and tool results.
+func (i Impersonation) String() string {
+	if i.IsZero() {
+		return "<none>"
+	}
+	if len(i.Groups) == 0 {
+		return i.UserName()
+	}
+	return fmt.Sprintf("%s (groups: %s)", i.UserName(), strings.Join(i.Groups, ", "))
+}
+
+// impersonatedConfig returns a copy of config that acts as imp.
+func impersonatedConfig(config *rest.Config, imp Impersonation) *rest.Config {
+	config = rest.CopyConfig(config)
+	config.Impersonate = imp.Config()
+	return config
+}
+
+type impersonationAllowedKey struct{}
+
+// WithImpersonationAllowed returns a copy of ctx that lets the impersonate
+// tool change the identity requests are made as. The server adds it to every
+// tool call when WithImpersonationTool enabled the tool.
+func WithImpersonationAllowed(ctx context.Context) context.Context {
+	return context.WithValue(ctx, impersonationAllowedKey{}, true)
+}
+
+// ImpersonationAllowed reports whether ctx lets the impersonate tool change
+// the identity requests are made as.
+func ImpersonationAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(impersonationAllowedKey{}).(bool)
+	return allowed
+}
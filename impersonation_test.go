@@ -0,0 +1,102 @@
+package kai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func TestImpersonationValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		imp  Impersonation
+		err  string
+	}{
+		{"None", Impersonation{}, ""},
+		{"User", Impersonation{User: "jane", Groups: []string{"dev"}}, ""},
+		{"ServiceAccount", Impersonation{ServiceAccount: "team-a/deployer"}, ""},
+		{"UserAndServiceAccount", Impersonation{User: "jane", ServiceAccount: "team-a/deployer"}, "cannot both be impersonated"},
+		{"ServiceAccountWithoutNamespace", Impersonation{ServiceAccount: "deployer"}, "namespace/name"},
+		{"ServiceAccountExtraSlash", Impersonation{ServiceAccount: "team-a/deployer/x"}, "namespace/name"},
+		{"GroupsAlone", Impersonation{Groups: []string{"dev"}}, "together with a user"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.imp.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestImpersonationConfig(t *testing.T) {
+	imp := Impersonation{ServiceAccount: "team-a/deployer", Groups: []string{"qa"}}
+	assert.Equal(t, rest.ImpersonationConfig{
+		UserName: "system:serviceaccount:team-a:deployer",
+		Groups:   []string{"qa"},
+	}, imp.Config())
+	assert.Equal(t, "system:serviceaccount:team-a:deployer (groups: qa)", imp.String())
+
+	assert.Equal(t, rest.ImpersonationConfig{}, Impersonation{}.Config())
+	assert.Equal(t, "<none>", Impersonation{}.String())
+}
+
+// restConfigManager serves a fixed REST config; only the methods
+// CurrentRESTConfig uses are implemented.
+type restConfigManager struct {
+	ClusterManager
+	config *rest.Config
+}
+
+func (m restConfigManager) GetCurrentRESTConfig() (*rest.Config, error) { return m.config, nil }
+
+func TestCurrentRESTConfigImpersonation(t *testing.T) {
+	base := &rest.Config{Host: "https://example.com"}
+	cm := restConfigManager{config: base}
+
+	session := &Session{}
+	ctx := WithSession(context.Background(), session)
+
+	config, err := CurrentRESTConfig(ctx, cm)
+	require.NoError(t, err)
+	assert.Same(t, base, config)
+
+	session.SetImpersonation(Impersonation{User: "jane", Groups: []string{"dev"}})
+	config, err = CurrentRESTConfig(ctx, cm)
+	require.NoError(t, err)
+	assert.Equal(t, "jane", config.Impersonate.UserName)
+	assert.Equal(t, []string{"dev"}, config.Impersonate.Groups)
+	assert.Equal(t, base.Host, config.Host)
+	assert.Empty(t, base.Impersonate.UserName, "the shared config must not change")
+
+	config, err = CurrentRESTConfig(context.Background(), cm)
+	require.NoError(t, err)
+	assert.Empty(t, config.Impersonate.UserName, "other sessions keep their own identity")
+}
+
+func TestImpersonationTool(t *testing.T) {
+	allowed := func(t *testing.T, opts ...ServerOption) bool {
+		s := NewServer(append(opts, WithMetrics(false))...)
+		var got bool
+		s.AddTool(mcp.NewTool("impersonate"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			got = ImpersonationAllowed(ctx)
+			return mcp.NewToolResultText("ok"), nil
+		})
+		request := mcp.CallToolRequest{}
+		request.Params.Name = "impersonate"
+		_, err := s.mcpServer.GetTool("impersonate").Handler(context.Background(), request)
+		require.NoError(t, err)
+		return got
+	}
+
+	assert.False(t, allowed(t))
+	assert.False(t, allowed(t, WithImpersonationTool(false)))
+	assert.True(t, allowed(t, WithImpersonationTool(true)))
+}
@@ -6,8 +6,12 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
 // ServerInterface defines the contract for an mcp server that can register and handle tools.
@@ -23,16 +27,29 @@ type ClusterManager interface {
 	GetCurrentClient() (kubernetes.Interface, error)
 	GetCurrentContext() string
 	GetCurrentDynamicClient() (dynamic.Interface, error)
+	GetCurrentAPIExtensionsClient() (apiextensionsclientset.Interface, error)
+	GetCurrentMetricsClient() (metricsclientset.Interface, error)
+	GetCurrentRESTConfig() (*rest.Config, error)
 	GetCurrentNamespace() string
 	GetDynamicClient(string) (dynamic.Interface, error)
+	GetAPIExtensionsClient(string) (apiextensionsclientset.Interface, error)
+	GetMetricsClient(string) (metricsclientset.Interface, error)
+	GetRESTConfig(string) (*rest.Config, error)
+	GetRESTMapper(string) (meta.RESTMapper, error)
+	GetCurrentRESTMapper() (meta.RESTMapper, error)
 	ListClusters() []string
 	LoadKubeConfig(string, string) error
+	LoadKubeConfigBytes(string, []byte) error
 	SetCurrentContext(string) error
 	DeleteContext(string) error
 	GetContextInfo(string) (*ContextInfo, error)
 	RenameContext(string, string) error
 	ListContexts() []*ContextInfo
 	SetCurrentNamespace(string)
+	GetImpersonation() Impersonation
+	SetImpersonation(Impersonation) error
+	ListSummaryThreshold() int
+	MaxNamespacesScan() int
 }
 
 // NamespaceOperator defines the operations needed for namespace management
@@ -42,15 +59,29 @@ type NamespaceOperator interface {
 	List(ctx context.Context, cm ClusterManager, labelSelector string) (string, error)
 	Delete(ctx context.Context, cm ClusterManager) (string, error)
 	Update(ctx context.Context, cm ClusterManager) (string, error)
+	RestartWorkloads(ctx context.Context, cm ClusterManager, includeStatefulSets, includeDaemonSets bool) (string, error)
+	Hibernate(ctx context.Context, cm ClusterManager) (string, error)
+	Wake(ctx context.Context, cm ClusterManager) (string, error)
 }
 
 // PodOperator defines the operations needed for pod management
 type PodOperator interface {
 	Create(ctx context.Context, cm ClusterManager) (string, error)
 	Get(ctx context.Context, cm ClusterManager) (string, error)
-	List(ctx context.Context, cm ClusterManager, limit int64, labelSelector, fieldSelector string) (string, error)
+	Describe(ctx context.Context, cm ClusterManager) (string, error)
+	List(ctx context.Context, cm ClusterManager, limit int64, continueToken, labelSelector, fieldSelector string) (string, error)
 	Delete(ctx context.Context, cm ClusterManager, force bool) (string, error)
 	StreamLogs(ctx context.Context, cm ClusterManager, tailLines int64, previous bool, since *time.Duration) (string, error)
+	StreamAllLogs(ctx context.Context, cm ClusterManager, tailLines int64, previous bool, since *time.Duration, includeInit bool) (string, error)
+	LogsAcrossRestarts(ctx context.Context, cm ClusterManager, tailLines int64) (string, error)
+	SearchLogs(ctx context.Context, cm ClusterManager, pattern string, before, after int, tailLines int64) (string, error)
+	Exec(ctx context.Context, cm ClusterManager, container string, command []string) (string, error)
+	CopyTo(ctx context.Context, cm ClusterManager, container string, data []byte, destPath string) (string, error)
+	CopyFrom(ctx context.Context, cm ClusterManager, container, srcPath string) (string, error)
+	Attach(ctx context.Context, cm ClusterManager, container, stdin string, duration time.Duration, maxBytes int) (string, error)
+	PortForward(ctx context.Context, cm ClusterManager, localPort, podPort int, duration time.Duration) (string, error)
+	WaitFor(ctx context.Context, cm ClusterManager, condition string, timeout time.Duration) (string, error)
+	Run(ctx context.Context, cm ClusterManager, wait bool, timeout time.Duration, rm bool) (string, error)
 }
 
 // DeploymentOperator defines the operations needed for deployment management
@@ -59,15 +90,21 @@ type DeploymentOperator interface {
 	Get(ctx context.Context, cm ClusterManager) (string, error)
 	Update(ctx context.Context, cm ClusterManager) (string, error)
 	Describe(ctx context.Context, cm ClusterManager) (string, error)
-	List(ctx context.Context, cm ClusterManager, allNamespaces bool, labelSelector string) (string, error)
+	List(ctx context.Context, cm ClusterManager, allNamespaces bool, labelSelector string, limit int64, continueToken string) (string, error)
 	Delete(ctx context.Context, cm ClusterManager) (string, error)
-	Scale(ctx context.Context, cm ClusterManager) (string, error)
+	Scale(ctx context.Context, cm ClusterManager, replicas int32) (string, error)
 	RolloutStatus(ctx context.Context, cm ClusterManager) (string, error)
 	RolloutHistory(ctx context.Context, cm ClusterManager) (string, error)
 	RolloutUndo(ctx context.Context, cm ClusterManager, revision int64) (string, error)
+	DiffRevisions(ctx context.Context, cm ClusterManager, fromRevision, toRevision int64) (string, error)
 	RolloutRestart(ctx context.Context, cm ClusterManager) (string, error)
 	RolloutPause(ctx context.Context, cm ClusterManager) (string, error)
 	RolloutResume(ctx context.Context, cm ClusterManager) (string, error)
+	Hibernate(ctx context.Context, cm ClusterManager) (string, error)
+	Wake(ctx context.Context, cm ClusterManager) (string, error)
+	Health(ctx context.Context, cm ClusterManager) (string, error)
+	Summarize(ctx context.Context, cm ClusterManager) (string, error)
+	Expose(ctx context.Context, cm ClusterManager, serviceName, serviceType string, port, targetPort int32) (string, error)
 }
 
 // ServiceOperator defines the operations needed for service management
@@ -75,9 +112,11 @@ type ServiceOperator interface {
 	Create(ctx context.Context, cm ClusterManager) (string, error)
 	Get(ctx context.Context, cm ClusterManager) (string, error)
 	Delete(ctx context.Context, cm ClusterManager) (string, error)
-	List(ctx context.Context, cm ClusterManager, allNamespaces bool, labelSelector string) (string, error)
+	List(ctx context.Context, cm ClusterManager, allNamespaces bool, labelSelector string, limit int64, continueToken string) (string, error)
 	Update(ctx context.Context, cm ClusterManager) (string, error)
 	Patch(ctx context.Context, cm ClusterManager, patchData map[string]interface{}) (string, error)
+	Describe(ctx context.Context, cm ClusterManager) (string, error)
+	Diagnose(ctx context.Context, cm ClusterManager) (string, error)
 }
 
 // ConfigMapOperator defines the operations needed for ConfigMap management
@@ -93,7 +132,7 @@ type ConfigMapOperator interface {
 type SecretOperator interface {
 	Create(ctx context.Context, cm ClusterManager) (string, error)
 	Get(ctx context.Context, cm ClusterManager) (string, error)
-	List(ctx context.Context, cm ClusterManager, allNamespaces bool, labelSelector string) (string, error)
+	List(ctx context.Context, cm ClusterManager, allNamespaces bool, labelSelector string, limit int64, continueToken string) (string, error)
 	Delete(ctx context.Context, cm ClusterManager) (string, error)
 	Update(ctx context.Context, cm ClusterManager) (string, error)
 }
@@ -105,6 +144,8 @@ type JobOperator interface {
 	List(ctx context.Context, cm ClusterManager, allNamespaces bool, labelSelector string) (string, error)
 	Delete(ctx context.Context, cm ClusterManager) (string, error)
 	Update(ctx context.Context, cm ClusterManager) (string, error)
+	Logs(ctx context.Context, cm ClusterManager, tailLines int64, since *time.Duration) (string, error)
+	WaitForCompletion(ctx context.Context, cm ClusterManager, timeout time.Duration) (string, error)
 }
 
 // CronJobOperator defines the operations needed for CronJob management
@@ -117,6 +158,44 @@ type CronJobOperator interface {
 	SetSuspended(ctx context.Context, cm ClusterManager, suspend bool) (string, error)
 }
 
+// HPAOperator defines the operations needed for HorizontalPodAutoscaler management
+type HPAOperator interface {
+	Create(ctx context.Context, cm ClusterManager) (string, error)
+	Get(ctx context.Context, cm ClusterManager) (string, error)
+	List(ctx context.Context, cm ClusterManager, allNamespaces bool, labelSelector string) (string, error)
+	Delete(ctx context.Context, cm ClusterManager) (string, error)
+	SetBounds(ctx context.Context, cm ClusterManager, minReplicas, maxReplicas *int32) (string, error)
+}
+
+// ServiceAccountOperator defines the operations needed for ServiceAccount management
+type ServiceAccountOperator interface {
+	Create(ctx context.Context, cm ClusterManager) (string, error)
+	Get(ctx context.Context, cm ClusterManager) (string, error)
+	List(ctx context.Context, cm ClusterManager, allNamespaces bool, labelSelector string) (string, error)
+	Delete(ctx context.Context, cm ClusterManager) (string, error)
+}
+
+// RBACOperator defines the operations needed for Role, ClusterRole,
+// RoleBinding and ClusterRoleBinding management. Cluster-scoped kinds
+// ignore allNamespaces.
+type RBACOperator interface {
+	Create(ctx context.Context, cm ClusterManager) (string, error)
+	Get(ctx context.Context, cm ClusterManager) (string, error)
+	List(ctx context.Context, cm ClusterManager, allNamespaces bool) (string, error)
+	Delete(ctx context.Context, cm ClusterManager) (string, error)
+}
+
+// StatefulSetOperator defines the operations needed for StatefulSet management
+type StatefulSetOperator interface {
+	Create(ctx context.Context, cm ClusterManager) (string, error)
+	Get(ctx context.Context, cm ClusterManager) (string, error)
+	List(ctx context.Context, cm ClusterManager, allNamespaces bool, labelSelector string) (string, error)
+	Update(ctx context.Context, cm ClusterManager) (string, error)
+	Describe(ctx context.Context, cm ClusterManager) (string, error)
+	Delete(ctx context.Context, cm ClusterManager) (string, error)
+	Scale(ctx context.Context, cm ClusterManager, replicas int32) (string, error)
+}
+
 // IngressOperator defines the operations needed for Ingress management
 type IngressOperator interface {
 	Create(ctx context.Context, cm ClusterManager) (string, error)
@@ -124,4 +203,14 @@ type IngressOperator interface {
 	List(ctx context.Context, cm ClusterManager, allNamespaces bool, labelSelector string) (string, error)
 	Delete(ctx context.Context, cm ClusterManager) (string, error)
 	Update(ctx context.Context, cm ClusterManager) (string, error)
+	CreateForService(ctx context.Context, cm ClusterManager, host string, path IngressPath) (string, error)
+	Describe(ctx context.Context, cm ClusterManager) (string, error)
+}
+
+// PVCOperator defines the operations needed for PersistentVolumeClaim management
+type PVCOperator interface {
+	Create(ctx context.Context, cm ClusterManager) (string, error)
+	Get(ctx context.Context, cm ClusterManager) (string, error)
+	List(ctx context.Context, cm ClusterManager, allNamespaces bool, labelSelector string) (string, error)
+	Delete(ctx context.Context, cm ClusterManager) (string, error)
 }
@@ -0,0 +1,73 @@
+package kai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ErrPathOutsideRoot is returned when a path given to a file-reading tool
+// resolves outside the configured manifest root.
+var ErrPathOutsideRoot = errors.New("path outside allowed root")
+
+// ResolveManifestPath resolves path against root and returns the absolute
+// path a tool may read. Relative paths are taken relative to root. Paths
+// that escape root, through ".." or a symlink, fail with ErrPathOutsideRoot.
+// An empty root leaves file access unconstrained and returns path cleaned.
+func ResolveManifestPath(root, path string) (string, error) {
+	if path == "" {
+		return "", errors.New("path is required")
+	}
+	if root == "" {
+		return filepath.Clean(path), nil
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve manifest root %q: %w", root, err)
+	}
+	resolved := path
+	if !filepath.IsAbs(resolved) {
+		resolved = filepath.Join(absRoot, resolved)
+	}
+	resolved = filepath.Clean(resolved)
+	if !withinRoot(absRoot, resolved) {
+		return "", fmt.Errorf("%q: %w", path, ErrPathOutsideRoot)
+	}
+
+	// A symlink inside the root may still point outside it. Paths that do
+	// not exist yet are left to the caller's own open to report.
+	realRoot, err := filepath.EvalSymlinks(absRoot)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve manifest root %q: %w", root, err)
+	}
+	if real, err := filepath.EvalSymlinks(resolved); err == nil && !withinRoot(realRoot, real) {
+		return "", fmt.Errorf("%q: %w", path, ErrPathOutsideRoot)
+	}
+	return resolved, nil
+}
+
+type manifestRootKey struct{}
+
+// ContextWithManifestRoot returns a copy of ctx carrying the directory
+// file-reading tools are confined to. The server adds it to every tool call
+// when WithManifestRoot set one.
+func ContextWithManifestRoot(ctx context.Context, root string) context.Context {
+	return context.WithValue(ctx, manifestRootKey{}, root)
+}
+
+// ManifestRootFromContext returns the manifest root carried by ctx, if any.
+func ManifestRootFromContext(ctx context.Context) (string, bool) {
+	root, ok := ctx.Value(manifestRootKey{}).(string)
+	return root, ok && root != ""
+}
+
+func withinRoot(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,75 @@
+package kai
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveManifestPath(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "apps"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "apps", "web.yaml"), []byte("kind: Pod\n"), 0o600))
+
+	t.Run("InRootRelative", func(t *testing.T) {
+		got, err := ResolveManifestPath(root, "apps/web.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(root, "apps", "web.yaml"), got)
+	})
+
+	t.Run("InRootAbsolute", func(t *testing.T) {
+		got, err := ResolveManifestPath(root, filepath.Join(root, "apps", "..", "apps", "web.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(root, "apps", "web.yaml"), got)
+	})
+
+	t.Run("TraversalRejected", func(t *testing.T) {
+		_, err := ResolveManifestPath(root, "../../etc/passwd")
+		assert.ErrorIs(t, err, ErrPathOutsideRoot)
+		assert.Contains(t, err.Error(), "path outside allowed root")
+
+		_, err = ResolveManifestPath(root, "/etc/passwd")
+		assert.ErrorIs(t, err, ErrPathOutsideRoot)
+	})
+
+	t.Run("SymlinkEscapeRejected", func(t *testing.T) {
+		outside := t.TempDir()
+		require.NoError(t, os.Symlink(outside, filepath.Join(root, "escape")))
+		_, err := ResolveManifestPath(root, "escape")
+		assert.ErrorIs(t, err, ErrPathOutsideRoot)
+	})
+
+	t.Run("NoRootUnconstrained", func(t *testing.T) {
+		got, err := ResolveManifestPath("", "../manifests/./app.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, "../manifests/app.yaml", got)
+	})
+
+	t.Run("ServerOption", func(t *testing.T) {
+		s := NewServer(WithManifestRoot(root), WithMetrics(false))
+		assert.Equal(t, root, s.GetManifestRoot())
+
+		var got string
+		s.AddTool(mcp.NewTool("load_kubeconfig"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			got, _ = ManifestRootFromContext(ctx)
+			return mcp.NewToolResultText("ok"), nil
+		})
+		request := mcp.CallToolRequest{}
+		request.Params.Name = "load_kubeconfig"
+		_, err := s.mcpServer.GetTool("load_kubeconfig").Handler(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, root, got)
+	})
+
+	t.Run("NoRootInContext", func(t *testing.T) {
+		_, ok := ManifestRootFromContext(context.Background())
+		assert.False(t, ok)
+		_, ok = ManifestRootFromContext(ContextWithManifestRoot(context.Background(), ""))
+		assert.False(t, ok)
+	})
+}
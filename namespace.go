@@ -0,0 +1,64 @@
+package kai
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// DefaultNamespaceMetaKey is the request _meta field the server reads a
+// per-call namespace override from.
+const DefaultNamespaceMetaKey = "kai/namespace"
+
+// fallbackNamespace is used when neither the request, the session nor the
+// cluster manager names a namespace, mirroring kubectl.
+const fallbackNamespace = "default"
+
+type namespaceKey struct{}
+
+// WithNamespace returns a copy of ctx that carries a namespace override. Tools
+// use it as the default namespace instead of the cluster manager's current
+// namespace, so sessions over HTTP can work in different namespaces without
+// changing shared state.
+func WithNamespace(ctx context.Context, namespace string) context.Context {
+	return context.WithValue(ctx, namespaceKey{}, namespace)
+}
+
+// NamespaceFromContext returns the namespace override carried by ctx, if any.
+func NamespaceFromContext(ctx context.Context) (string, bool) {
+	namespace, ok := ctx.Value(namespaceKey{}).(string)
+	return namespace, ok && namespace != ""
+}
+
+// CurrentNamespace returns the namespace override carried by ctx, then the
+// namespace selected for the session, then the cluster manager's current
+// namespace. It never returns "": when nothing is set it falls back to
+// "default", so namespaced calls never target an empty namespace.
+func CurrentNamespace(ctx context.Context, cm ClusterManager) string {
+	if namespace, ok := NamespaceFromContext(ctx); ok {
+		return namespace
+	}
+	if session, ok := SessionFromContext(ctx); ok {
+		if namespace := session.Namespace(); namespace != "" {
+			return namespace
+		}
+	}
+	if namespace := strings.TrimSpace(cm.GetCurrentNamespace()); namespace != "" {
+		return namespace
+	}
+	slog.Debug("no current namespace set, using fallback", slog.String("namespace", fallbackNamespace))
+	return fallbackNamespace
+}
+
+// namespaceFromMeta reads a namespace override from the request's _meta
+// field under key.
+func namespaceFromMeta(request mcp.CallToolRequest, key string) (string, bool) {
+	if request.Params.Meta == nil || key == "" {
+		return "", false
+	}
+	namespace, ok := request.Params.Meta.AdditionalFields[key].(string)
+	namespace = strings.TrimSpace(namespace)
+	return namespace, ok && namespace != ""
+}
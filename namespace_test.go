@@ -0,0 +1,81 @@
+package kai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespaceFromMeta(t *testing.T) {
+	request := func(meta *mcp.Meta) mcp.CallToolRequest {
+		return mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "list_pods", Meta: meta}}
+	}
+
+	t.Run("Override", func(t *testing.T) {
+		ns, ok := namespaceFromMeta(request(&mcp.Meta{AdditionalFields: map[string]any{DefaultNamespaceMetaKey: "team-a"}}), DefaultNamespaceMetaKey)
+		assert.True(t, ok)
+		assert.Equal(t, "team-a", ns)
+	})
+
+	t.Run("CustomKey", func(t *testing.T) {
+		ns, ok := namespaceFromMeta(request(&mcp.Meta{AdditionalFields: map[string]any{"namespace": "team-b"}}), "namespace")
+		assert.True(t, ok)
+		assert.Equal(t, "team-b", ns)
+	})
+
+	t.Run("Absent", func(t *testing.T) {
+		_, ok := namespaceFromMeta(request(nil), DefaultNamespaceMetaKey)
+		assert.False(t, ok)
+
+		_, ok = namespaceFromMeta(request(&mcp.Meta{AdditionalFields: map[string]any{"other": "x"}}), DefaultNamespaceMetaKey)
+		assert.False(t, ok)
+	})
+
+	t.Run("IgnoresEmptyAndNonString", func(t *testing.T) {
+		_, ok := namespaceFromMeta(request(&mcp.Meta{AdditionalFields: map[string]any{DefaultNamespaceMetaKey: "  "}}), DefaultNamespaceMetaKey)
+		assert.False(t, ok)
+
+		_, ok = namespaceFromMeta(request(&mcp.Meta{AdditionalFields: map[string]any{DefaultNamespaceMetaKey: 42}}), DefaultNamespaceMetaKey)
+		assert.False(t, ok)
+	})
+
+	t.Run("DisabledKey", func(t *testing.T) {
+		_, ok := namespaceFromMeta(request(&mcp.Meta{AdditionalFields: map[string]any{DefaultNamespaceMetaKey: "team-a"}}), "")
+		assert.False(t, ok)
+	})
+}
+
+func TestNamespaceFromContext(t *testing.T) {
+	_, ok := NamespaceFromContext(context.Background())
+	assert.False(t, ok)
+
+	ns, ok := NamespaceFromContext(WithNamespace(context.Background(), "team-a"))
+	assert.True(t, ok)
+	assert.Equal(t, "team-a", ns)
+}
+
+// namespaceManager is a ClusterManager that only answers GetCurrentNamespace.
+type namespaceManager struct {
+	ClusterManager
+	namespace string
+}
+
+func (m namespaceManager) GetCurrentNamespace() string { return m.namespace }
+
+func TestCurrentNamespace(t *testing.T) {
+	t.Run("ContextOverride", func(t *testing.T) {
+		ctx := WithNamespace(context.Background(), "team-a")
+		assert.Equal(t, "team-a", CurrentNamespace(ctx, namespaceManager{namespace: "team-b"}))
+	})
+
+	t.Run("ManagerNamespace", func(t *testing.T) {
+		assert.Equal(t, "team-b", CurrentNamespace(context.Background(), namespaceManager{namespace: "team-b"}))
+	})
+
+	t.Run("FallsBackToDefault", func(t *testing.T) {
+		assert.Equal(t, "default", CurrentNamespace(context.Background(), namespaceManager{}))
+		assert.Equal(t, "default", CurrentNamespace(context.Background(), namespaceManager{namespace: "  "}))
+	})
+}
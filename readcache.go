@@ -0,0 +1,176 @@
+package kai
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// readCache keeps the results of read-only tool calls for a fixed TTL, so
+// an agent repeating list_nodes or list_api_resources while it plans does
+// not hit the API server each time. Any call to a tool that is not
+// read-only empties the cache, since it may have changed what the cached
+// reads would now return.
+type readCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]readCacheEntry
+	// generation counts invalidations. A read that started before one must
+	// not store its result afterwards.
+	generation uint64
+}
+
+type readCacheEntry struct {
+	result  *mcp.CallToolResult
+	expires time.Time
+}
+
+func newReadCache(ttl time.Duration) *readCache {
+	return &readCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]readCacheEntry),
+	}
+}
+
+// get returns the cached result for key along with the current generation,
+// to pass to put when the result has to be computed.
+func (c *readCache) get(key string) (*mcp.CallToolResult, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if ok && c.now().Before(entry.expires) {
+		return entry.result, c.generation, true
+	}
+	if ok {
+		delete(c.entries, key)
+	}
+	return nil, c.generation, false
+}
+
+// put stores result unless the cache was invalidated since generation was
+// read. Expired entries are swept first, so keys that are never looked up
+// again do not pile up.
+func (c *readCache) put(key string, generation uint64, result *mcp.CallToolResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	now := c.now()
+	for k, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = readCacheEntry{result: result, expires: now.Add(c.ttl)}
+}
+
+// invalidate drops every cached result.
+func (c *readCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	clear(c.entries)
+}
+
+// readCacheKey identifies a call by tool name, arguments, namespace
+// override and MCP session, so sessions that selected different contexts
+// or identities never share results. It reports false when the arguments
+// cannot be encoded, in which case the call is not cached.
+func readCacheKey(ctx context.Context, request mcp.CallToolRequest, namespace string) (string, bool) {
+	var sessionID string
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		sessionID = session.SessionID()
+	}
+	// json.Marshal sorts map keys, so equal arguments give equal keys.
+	key, err := json.Marshal([]interface{}{request.Params.Name, sessionID, namespace, request.GetArguments()})
+	if err != nil {
+		return "", false
+	}
+	return string(key), true
+}
+
+// uncachedTools are read-only tools whose results go stale on their own:
+// they wait for a state change, follow logs that keep growing, report
+// progress or live usage an agent polls for, or copy files that may have
+// changed. Repeating one must always run it again.
+var uncachedTools = map[string]bool{
+	"wait_for_pod":              true,
+	"wait_job":                  true,
+	"rollout_status_deployment": true,
+	"stream_logs":               true,
+	"tail_selector":             true,
+	"logs_job":                  true,
+	"logs_all_restarts":         true,
+	"search_logs":               true,
+	"list_port_forwards":        true,
+	"clusters_status":           true,
+	"top_pods":                  true,
+	"top_nodes":                 true,
+	"pod_metrics":               true,
+	"node_metrics":              true,
+	"copy_from_pod":             true,
+}
+
+// isCacheable reports whether results of tool may be served from the read
+// cache.
+func isCacheable(tool mcp.Tool) bool {
+	return isReadOnly(tool) && !uncachedTools[tool.Name]
+}
+
+// failurePrefixes start the text of tool results that report a failure.
+// Tools return most errors as plain text rather than with IsError set, so
+// the text is all there is to go on.
+var failurePrefixes = []string{
+	"Failed",
+	"failed",
+	"Error",
+	"error",
+	"Invalid",
+	"invalid",
+	"Required parameter",
+	"Parameter '",
+	"missing",
+	"not found: ",
+	"permission denied: ",
+	"unauthorized: ",
+	"conflict: ",
+	"timed out: ",
+}
+
+// isFailure reports whether result describes a failed call, either with
+// IsError or with text starting like an error message.
+func isFailure(result *mcp.CallToolResult) bool {
+	if result == nil {
+		return false
+	}
+	if result.IsError {
+		return true
+	}
+	if len(result.Content) == 0 {
+		return false
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		return false
+	}
+	for _, prefix := range failurePrefixes {
+		if strings.HasPrefix(text.Text, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isReadOnly reports whether tool is annotated as not modifying anything.
+func isReadOnly(tool mcp.Tool) bool {
+	return tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint
+}
@@ -0,0 +1,163 @@
+package kai
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCache(t *testing.T) {
+	ctx := context.Background()
+
+	// setup registers list_nodes, which reports how often it ran, and
+	// cordon_node, a mutation.
+	setup := func(ttl time.Duration) (*Server, *int) {
+		s := NewServer(WithReadCacheTTL(ttl), WithMetrics(false))
+		calls := 0
+		s.AddTool(mcp.NewTool("list_nodes",
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: mcp.ToBoolPtr(true)}),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			calls++
+			return mcp.NewToolResultText(fmt.Sprintf("call %d", calls)), nil
+		})
+		s.AddTool(mcp.NewTool("cordon_node",
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: mcp.ToBoolPtr(false)}),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("cordoned"), nil
+		})
+		return s, &calls
+	}
+	call := func(t *testing.T, s *Server, tool string, args map[string]interface{}) string {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = tool
+		request.Params.Arguments = args
+		result, err := s.mcpServer.GetTool(tool).Handler(ctx, request)
+		require.NoError(t, err)
+		require.Len(t, result.Content, 1)
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	t.Run("HitWithinTTL", func(t *testing.T) {
+		s, calls := setup(time.Minute)
+		assert.Equal(t, "call 1", call(t, s, "list_nodes", map[string]interface{}{"label_selector": "role=worker"}))
+		assert.Equal(t, "call 1", call(t, s, "list_nodes", map[string]interface{}{"label_selector": "role=worker"}))
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("KeyedByArguments", func(t *testing.T) {
+		s, calls := setup(time.Minute)
+		call(t, s, "list_nodes", map[string]interface{}{"label_selector": "role=worker"})
+		assert.Equal(t, "call 2", call(t, s, "list_nodes", map[string]interface{}{"label_selector": "role=infra"}))
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("ExpiresAfterTTL", func(t *testing.T) {
+		s, calls := setup(time.Minute)
+		now := time.Now()
+		s.readCache.now = func() time.Time { return now }
+		call(t, s, "list_nodes", nil)
+		now = now.Add(time.Minute)
+		assert.Equal(t, "call 2", call(t, s, "list_nodes", nil))
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("MutationInvalidates", func(t *testing.T) {
+		s, calls := setup(time.Minute)
+		call(t, s, "list_nodes", nil)
+		call(t, s, "cordon_node", map[string]interface{}{"name": "node-1"})
+		assert.Equal(t, "call 2", call(t, s, "list_nodes", nil))
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		s, calls := setup(0)
+		call(t, s, "list_nodes", nil)
+		assert.Equal(t, "call 2", call(t, s, "list_nodes", nil))
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("FailuresNotCached", func(t *testing.T) {
+		s, _ := setup(time.Minute)
+		calls := 0
+		s.AddTool(mcp.NewTool("get_node",
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: mcp.ToBoolPtr(true)}),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			calls++
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get node: attempt %d timed out", calls)), nil
+		})
+		call(t, s, "get_node", map[string]interface{}{"name": "node-1"})
+		assert.Equal(t, "Failed to get node: attempt 2 timed out", call(t, s, "get_node", map[string]interface{}{"name": "node-1"}))
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("WaitingToolsNotCached", func(t *testing.T) {
+		s, listCalls := setup(time.Minute)
+		calls := 0
+		s.AddTool(mcp.NewTool("wait_for_pod",
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: mcp.ToBoolPtr(true)}),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			calls++
+			return mcp.NewToolResultText(fmt.Sprintf("wait %d", calls)), nil
+		})
+		call(t, s, "list_nodes", nil)
+		call(t, s, "wait_for_pod", map[string]interface{}{"name": "web"})
+		assert.Equal(t, "wait 2", call(t, s, "wait_for_pod", map[string]interface{}{"name": "web"}))
+		// Being read-only, it leaves other cached reads alone.
+		assert.Equal(t, "call 1", call(t, s, "list_nodes", nil))
+		assert.Equal(t, 1, *listCalls)
+	})
+
+	t.Run("LiveToolsNotCached", func(t *testing.T) {
+		for _, name := range []string{"clusters_status", "top_pods", "top_nodes", "pod_metrics", "node_metrics", "copy_from_pod"} {
+			tool := mcp.NewTool(name, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: mcp.ToBoolPtr(true)}))
+			assert.False(t, isCacheable(tool), name)
+		}
+	})
+
+	t.Run("ExpiredEntriesSwept", func(t *testing.T) {
+		c := newReadCache(time.Minute)
+		now := time.Now()
+		c.now = func() time.Time { return now }
+		c.put("list_nodes", 0, mcp.NewToolResultText("nodes"))
+		c.put("list_namespaces", 0, mcp.NewToolResultText("namespaces"))
+		now = now.Add(time.Minute)
+		c.put("list_pods", 0, mcp.NewToolResultText("pods"))
+		assert.Len(t, c.entries, 1)
+		assert.Contains(t, c.entries, "list_pods")
+	})
+
+	t.Run("ReadStartedBeforeInvalidationIsNotStored", func(t *testing.T) {
+		c := newReadCache(time.Minute)
+		_, generation, hit := c.get("list_nodes")
+		require.False(t, hit)
+		c.invalidate()
+		c.put("list_nodes", generation, mcp.NewToolResultText("stale"))
+		_, _, hit = c.get("list_nodes")
+		assert.False(t, hit)
+	})
+}
+
+func TestIsFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *mcp.CallToolResult
+		want   bool
+	}{
+		{name: "Nil", result: nil, want: false},
+		{name: "Success", result: mcp.NewToolResultText("Pods in namespace 'default':"), want: false},
+		{name: "IsError", result: mcp.NewToolResultError("boom"), want: true},
+		{name: "FailedText", result: mcp.NewToolResultText("Failed to list pods: connection refused"), want: true},
+		{name: "MissingParameter", result: mcp.NewToolResultText("Required parameter 'name' is missing"), want: true},
+		{name: "ClassifiedError", result: mcp.NewToolResultText(`not found: pods "web" not found`), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isFailure(tt.result))
+		})
+	}
+}
@@ -21,6 +21,14 @@ type Server struct {
 	cfg        *serverConfig
 	ready      atomic.Bool
 	httpServer *http.Server
+
+	// sessions holds per-session state for HTTP transports. It stays unused
+	// over stdio, where the single client acts on the cluster manager.
+	sessions      *sessionStore
+	sessionScoped atomic.Bool
+
+	// readCache is nil unless WithReadCacheTTL enabled it.
+	readCache *readCache
 }
 
 // ServerOption configures the server
@@ -32,6 +40,12 @@ type serverConfig struct {
 	tlsCertFile    string
 	tlsKeyFile     string
 	metricsEnabled bool
+	namespaceKey   string
+	manifestRoot   string
+	readCacheTTL   time.Duration
+	// impersonationTool lets clients change the identity requests are made
+	// as through the impersonate tool.
+	impersonationTool bool
 }
 
 // Metrics for the MCP server
@@ -92,47 +106,148 @@ func WithMetrics(enabled bool) ServerOption {
 	}
 }
 
+// WithNamespaceMetaKey sets the request _meta field read for a per-call
+// namespace override. An empty key disables the override.
+func WithNamespaceMetaKey(key string) ServerOption {
+	return func(c *serverConfig) {
+		c.namespaceKey = key
+	}
+}
+
+// WithManifestRoot confines the local files that tools read, such as
+// manifests, to root. An empty root leaves file access unconstrained.
+func WithManifestRoot(root string) ServerOption {
+	return func(c *serverConfig) {
+		c.manifestRoot = root
+	}
+}
+
+// WithReadCacheTTL caches the results of read-only tools for ttl, keyed by
+// tool and arguments. Waits, log reads and failed calls are never cached,
+// and calls to any tool that is not read-only empty the cache. Zero, the
+// default, disables caching.
+func WithReadCacheTTL(ttl time.Duration) ServerOption {
+	return func(c *serverConfig) {
+		c.readCacheTTL = ttl
+	}
+}
+
+// WithImpersonationTool enables the impersonate tool. It is off by default,
+// since it lets any client act as any identity the kubeconfig's credentials
+// may impersonate, including dropping one set at startup.
+func WithImpersonationTool(enabled bool) ServerOption {
+	return func(c *serverConfig) {
+		c.impersonationTool = enabled
+	}
+}
+
 // NewServer creates a new MCP server for Kubernetes
 func NewServer(opts ...ServerOption) *Server {
 	cfg := &serverConfig{
 		version:        "0.0.1",
 		requestTimeout: 30 * time.Second,
 		metricsEnabled: true,
+		namespaceKey:   DefaultNamespaceMetaKey,
 	}
 
 	for _, opt := range opts {
 		opt(cfg)
 	}
 
+	s := &Server{
+		cfg:      cfg,
+		sessions: newSessionStore(),
+	}
+	if cfg.readCacheTTL > 0 {
+		s.readCache = newReadCache(cfg.readCacheTTL)
+	}
+
+	hooks := &server.Hooks{}
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		s.sessions.remove(session.SessionID())
+	})
+
 	// Create the MCP server
-	mcpServer := server.NewMCPServer(
+	s.mcpServer = server.NewMCPServer(
 		"Kubernetes MCP Server",
 		cfg.version,
 		server.WithResourceCapabilities(true, true),
 		server.WithLogging(),
+		server.WithHooks(hooks),
 	)
 
-	s := &Server{
-		mcpServer: mcpServer,
-		cfg:       cfg,
-	}
-
 	return s
 }
 
 // AddTool adds a tool to the MCP server
 func (s *Server) AddTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
 	originalHandler := handler
+	readOnly := isReadOnly(tool)
+	cacheResults := isCacheable(tool)
 	handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		toolName := request.Params.Name
-		slog.Info("tool request received", slog.String("tool", toolName))
+
+		namespace, hasNamespace := namespaceFromMeta(request, s.cfg.namespaceKey)
+		if hasNamespace {
+			ctx = WithNamespace(ctx, namespace)
+		}
+		ctx = s.withSession(ctx)
+		if s.cfg.manifestRoot != "" {
+			ctx = ContextWithManifestRoot(ctx, s.cfg.manifestRoot)
+		}
+		if s.cfg.impersonationTool {
+			ctx = WithImpersonationAllowed(ctx)
+		}
+
+		// Record who the request acts as, so the server's log can be matched
+		// against the API server's audit log.
+		attrs := []any{slog.String("tool", toolName)}
+		if imp, ok := sessionImpersonation(ctx); ok {
+			attrs = append(attrs, slog.String("impersonate", imp.String()))
+		}
+		slog.Info("tool request received", attrs...)
+
+		var (
+			cacheKey   string
+			cacheable  bool
+			generation uint64
+		)
+		if s.readCache != nil {
+			if cacheResults {
+				cacheKey, cacheable = readCacheKey(ctx, request, namespace)
+			} else if !readOnly {
+				// Whatever the outcome, the call may have changed state that
+				// cached reads describe.
+				defer s.readCache.invalidate()
+			}
+		}
+		if cacheable {
+			cached, gen, hit := s.readCache.get(cacheKey)
+			if hit {
+				slog.Info("tool request completed",
+					slog.String("tool", toolName),
+					slog.String("status", "success"),
+					slog.Bool("cached", true),
+				)
+				if s.cfg.metricsEnabled {
+					requestsTotal.WithLabelValues(toolName, "success").Inc()
+				}
+				return cached, nil
+			}
+			generation = gen
+		}
 
 		start := time.Now()
 		result, err := originalHandler(ctx, request)
 		duration := time.Since(start).Seconds()
 
+		failed := err != nil || isFailure(result)
+		if cacheable && !failed && result != nil {
+			s.readCache.put(cacheKey, generation, result)
+		}
+
 		status := "success"
-		if err != nil || (result != nil && result.IsError) {
+		if failed {
 			status = "error"
 		}
 
@@ -152,11 +267,30 @@ func (s *Server) AddTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
 	s.mcpServer.AddTool(tool, handler)
 }
 
+// withSession attaches the calling client's session state to ctx when the
+// server is running an HTTP transport.
+func (s *Server) withSession(ctx context.Context) context.Context {
+	if !s.sessionScoped.Load() {
+		return ctx
+	}
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return ctx
+	}
+	return WithSession(ctx, s.sessions.get(session.SessionID()))
+}
+
 // GetRequestTimeout returns the configured request timeout
 func (s *Server) GetRequestTimeout() time.Duration {
 	return s.cfg.requestTimeout
 }
 
+// GetManifestRoot returns the directory file-reading tools are confined to,
+// or an empty string when file access is unconstrained
+func (s *Server) GetManifestRoot() string {
+	return s.cfg.manifestRoot
+}
+
 // SetReady marks the server as ready to accept requests
 func (s *Server) SetReady(ready bool) {
 	s.ready.Store(ready)
@@ -172,6 +306,7 @@ func (s *Server) Serve() error {
 // (MCP spec 2025-03-26). The MCP endpoint is exposed at /mcp; health, ready,
 // and metrics endpoints are served from the same listener.
 func (s *Server) ServeStreamableHTTP(addr string) error {
+	s.sessionScoped.Store(true)
 	streamSrv := server.NewStreamableHTTPServer(s.mcpServer)
 
 	mux := http.NewServeMux()
@@ -197,6 +332,7 @@ func (s *Server) ServeStreamableHTTP(addr string) error {
 // 2024-11-05). Kept for compatibility with older clients; new deployments
 // should use ServeStreamableHTTP.
 func (s *Server) ServeSSE(addr string) error {
+	s.sessionScoped.Store(true)
 	sseServer := server.NewSSEServer(s.mcpServer)
 
 	mux := http.NewServeMux()
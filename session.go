@@ -0,0 +1,241 @@
+package kai
+
+import (
+	"context"
+	"sync"
+
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// Session holds the Kubernetes context, namespace and impersonated identity
+// a client selected for its own MCP session. Over HTTP transports every
+// session gets its own Session, so switch_context, set_namespace and
+// impersonate in one session leave the others untouched. An empty field
+// falls back to the cluster manager's current value.
+type Session struct {
+	mu            sync.RWMutex
+	context       string
+	namespace     string
+	impersonation Impersonation
+}
+
+// Context returns the context selected for the session, if any.
+func (s *Session) Context() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.context
+}
+
+// SetContext selects the Kubernetes context used by the session.
+func (s *Session) SetContext(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.context = name
+}
+
+// Namespace returns the namespace selected for the session, if any.
+func (s *Session) Namespace() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.namespace
+}
+
+// SetNamespace selects the default namespace used by the session.
+func (s *Session) SetNamespace(namespace string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.namespace = namespace
+}
+
+// Impersonation returns the identity the session acts as, if any.
+func (s *Session) Impersonation() Impersonation {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.impersonation
+}
+
+// SetImpersonation selects the identity the session acts as. The zero value
+// stops impersonating.
+func (s *Session) SetImpersonation(imp Impersonation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.impersonation = imp
+}
+
+type sessionKey struct{}
+
+// WithSession returns a copy of ctx that carries session state.
+func WithSession(ctx context.Context, session *Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, session)
+}
+
+// SessionFromContext returns the session state carried by ctx. Requests
+// served over stdio carry none; tools then act on the cluster manager
+// directly.
+func SessionFromContext(ctx context.Context) (*Session, bool) {
+	session, ok := ctx.Value(sessionKey{}).(*Session)
+	return session, ok && session != nil
+}
+
+// CurrentContext returns the context selected for the session carried by
+// ctx, falling back to the cluster manager's current context.
+func CurrentContext(ctx context.Context, cm ClusterManager) string {
+	if session, ok := SessionFromContext(ctx); ok {
+		if name := session.Context(); name != "" {
+			return name
+		}
+	}
+	return cm.GetCurrentContext()
+}
+
+// sessionImpersonation returns the identity the session carried by ctx acts
+// as. Clients for an impersonating session are built from its REST config on
+// each call rather than shared with other sessions.
+func sessionImpersonation(ctx context.Context) (Impersonation, bool) {
+	if session, ok := SessionFromContext(ctx); ok {
+		if imp := session.Impersonation(); !imp.IsZero() {
+			return imp, true
+		}
+	}
+	return Impersonation{}, false
+}
+
+// CurrentClient returns the clientset for the session's context, falling
+// back to the cluster manager's current client.
+func CurrentClient(ctx context.Context, cm ClusterManager) (kubernetes.Interface, error) {
+	if _, ok := sessionImpersonation(ctx); ok {
+		config, err := CurrentRESTConfig(ctx, cm)
+		if err != nil {
+			return nil, err
+		}
+		return kubernetes.NewForConfig(config)
+	}
+	if session, ok := SessionFromContext(ctx); ok {
+		if name := session.Context(); name != "" {
+			return cm.GetClient(name)
+		}
+	}
+	return cm.GetCurrentClient()
+}
+
+// CurrentDynamicClient returns the dynamic client for the session's context,
+// falling back to the cluster manager's current dynamic client.
+func CurrentDynamicClient(ctx context.Context, cm ClusterManager) (dynamic.Interface, error) {
+	if _, ok := sessionImpersonation(ctx); ok {
+		config, err := CurrentRESTConfig(ctx, cm)
+		if err != nil {
+			return nil, err
+		}
+		return dynamic.NewForConfig(config)
+	}
+	if session, ok := SessionFromContext(ctx); ok {
+		if name := session.Context(); name != "" {
+			return cm.GetDynamicClient(name)
+		}
+	}
+	return cm.GetCurrentDynamicClient()
+}
+
+// CurrentAPIExtensionsClient returns the apiextensions client for the
+// session's context, falling back to the cluster manager's current one.
+func CurrentAPIExtensionsClient(ctx context.Context, cm ClusterManager) (apiextensionsclientset.Interface, error) {
+	if _, ok := sessionImpersonation(ctx); ok {
+		config, err := CurrentRESTConfig(ctx, cm)
+		if err != nil {
+			return nil, err
+		}
+		return apiextensionsclientset.NewForConfig(config)
+	}
+	if session, ok := SessionFromContext(ctx); ok {
+		if name := session.Context(); name != "" {
+			return cm.GetAPIExtensionsClient(name)
+		}
+	}
+	return cm.GetCurrentAPIExtensionsClient()
+}
+
+// CurrentMetricsClient returns the metrics.k8s.io client for the session's
+// context, falling back to the cluster manager's current one.
+func CurrentMetricsClient(ctx context.Context, cm ClusterManager) (metricsclientset.Interface, error) {
+	if _, ok := sessionImpersonation(ctx); ok {
+		config, err := CurrentRESTConfig(ctx, cm)
+		if err != nil {
+			return nil, err
+		}
+		return metricsclientset.NewForConfig(config)
+	}
+	if session, ok := SessionFromContext(ctx); ok {
+		if name := session.Context(); name != "" {
+			return cm.GetMetricsClient(name)
+		}
+	}
+	return cm.GetCurrentMetricsClient()
+}
+
+// CurrentRESTConfig returns the REST config for the session's context,
+// falling back to the cluster manager's current one, with the session's
+// impersonation applied. Streaming subresources such as exec need it to
+// build their own transport.
+func CurrentRESTConfig(ctx context.Context, cm ClusterManager) (*rest.Config, error) {
+	var (
+		config *rest.Config
+		err    error
+	)
+	if session, ok := SessionFromContext(ctx); ok && session.Context() != "" {
+		config, err = cm.GetRESTConfig(session.Context())
+	} else {
+		config, err = cm.GetCurrentRESTConfig()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if imp, ok := sessionImpersonation(ctx); ok {
+		return impersonatedConfig(config, imp), nil
+	}
+	return config, nil
+}
+
+// CurrentRESTMapper returns the cached REST mapper for the session's
+// context, falling back to the cluster manager's current one.
+func CurrentRESTMapper(ctx context.Context, cm ClusterManager) (meta.RESTMapper, error) {
+	if session, ok := SessionFromContext(ctx); ok {
+		if name := session.Context(); name != "" {
+			return cm.GetRESTMapper(name)
+		}
+	}
+	return cm.GetCurrentRESTMapper()
+}
+
+// sessionStore maps MCP session IDs to their state.
+type sessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+func newSessionStore() *sessionStore {
+	return &sessionStore{sessions: make(map[string]*Session)}
+}
+
+// get returns the state for id, creating it on first use.
+func (st *sessionStore) get(id string) *Session {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	session, ok := st.sessions[id]
+	if !ok {
+		session = &Session{}
+		st.sessions[id] = session
+	}
+	return session
+}
+
+// remove drops the state for id once its session ends.
+func (st *sessionStore) remove(id string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	delete(st.sessions, id)
+}
@@ -0,0 +1,65 @@
+package kai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClientSession struct {
+	id string
+}
+
+func (f fakeClientSession) Initialize()       {}
+func (f fakeClientSession) Initialized() bool { return true }
+func (f fakeClientSession) SessionID() string { return f.id }
+func (f fakeClientSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return make(chan mcp.JSONRPCNotification, 1)
+}
+
+func TestServerSessionState(t *testing.T) {
+	s := NewServer()
+	sessionCtx := func(id string) context.Context {
+		return s.withSession(s.mcpServer.WithContext(context.Background(), fakeClientSession{id: id}))
+	}
+
+	t.Run("StdioHasNoSession", func(t *testing.T) {
+		_, ok := SessionFromContext(sessionCtx("stdio"))
+		assert.False(t, ok)
+	})
+
+	s.sessionScoped.Store(true)
+
+	t.Run("IndependentNamespaces", func(t *testing.T) {
+		a, ok := SessionFromContext(sessionCtx("a"))
+		require.True(t, ok)
+		b, ok := SessionFromContext(sessionCtx("b"))
+		require.True(t, ok)
+
+		a.SetNamespace("team-a")
+		b.SetNamespace("team-b")
+		a.SetContext("staging")
+
+		again, _ := SessionFromContext(sessionCtx("a"))
+		assert.Equal(t, "team-a", again.Namespace())
+		assert.Equal(t, "staging", again.Context())
+
+		again, _ = SessionFromContext(sessionCtx("b"))
+		assert.Equal(t, "team-b", again.Namespace())
+		assert.Empty(t, again.Context())
+	})
+
+	t.Run("RemovedOnUnregister", func(t *testing.T) {
+		a, _ := SessionFromContext(sessionCtx("a"))
+		a.SetNamespace("team-a")
+
+		s.sessions.remove("a")
+
+		fresh, ok := SessionFromContext(sessionCtx("a"))
+		require.True(t, ok)
+		assert.Empty(t, fresh.Namespace())
+	})
+}
@@ -3,14 +3,22 @@ package testmocks
 import (
 	"github.com/basebandit/kai"
 	"github.com/stretchr/testify/mock"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
 // MockClusterManager implements the ClusterManager interface for testing
 type MockClusterManager struct {
 	mock.Mock
 	currentNamespace string
+	listThreshold    int
+	maxNamespaces    int
 }
 
 // NewMockClusterManager initializes with defaults similar to the real implementation
@@ -26,6 +34,11 @@ func (m *MockClusterManager) LoadKubeConfig(name, path string) error {
 	return args.Error(0)
 }
 
+func (m *MockClusterManager) LoadKubeConfigBytes(name string, data []byte) error {
+	args := m.Called(name, data)
+	return args.Error(0)
+}
+
 func (m *MockClusterManager) GetClient(clusterName string) (kubernetes.Interface, error) {
 	args := m.Called(clusterName)
 	if client, ok := args.Get(0).(kubernetes.Interface); ok {
@@ -58,6 +71,54 @@ func (m *MockClusterManager) GetCurrentDynamicClient() (dynamic.Interface, error
 	return nil, args.Error(1)
 }
 
+func (m *MockClusterManager) GetCurrentAPIExtensionsClient() (apiextensionsclientset.Interface, error) {
+	args := m.Called()
+	if client, ok := args.Get(0).(apiextensionsclientset.Interface); ok {
+		return client, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockClusterManager) GetAPIExtensionsClient(name string) (apiextensionsclientset.Interface, error) {
+	args := m.Called(name)
+	if client, ok := args.Get(0).(apiextensionsclientset.Interface); ok {
+		return client, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockClusterManager) GetCurrentMetricsClient() (metricsclientset.Interface, error) {
+	args := m.Called()
+	if client, ok := args.Get(0).(metricsclientset.Interface); ok {
+		return client, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockClusterManager) GetMetricsClient(name string) (metricsclientset.Interface, error) {
+	args := m.Called(name)
+	if client, ok := args.Get(0).(metricsclientset.Interface); ok {
+		return client, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockClusterManager) GetCurrentRESTConfig() (*rest.Config, error) {
+	args := m.Called()
+	if config, ok := args.Get(0).(*rest.Config); ok {
+		return config, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockClusterManager) GetRESTConfig(name string) (*rest.Config, error) {
+	args := m.Called(name)
+	if config, ok := args.Get(0).(*rest.Config); ok {
+		return config, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *MockClusterManager) SetCurrentNamespace(namespace string) {
 	m.Called(namespace)
 	if namespace == "" {
@@ -67,6 +128,38 @@ func (m *MockClusterManager) SetCurrentNamespace(namespace string) {
 	}
 }
 
+// ListSummaryThreshold returns the threshold set with SetListSummaryThreshold;
+// summaries are disabled until then.
+func (m *MockClusterManager) ListSummaryThreshold() int {
+	return m.listThreshold
+}
+
+// SetListSummaryThreshold sets the value ListSummaryThreshold returns.
+func (m *MockClusterManager) SetListSummaryThreshold(n int) {
+	m.listThreshold = n
+}
+
+// MaxNamespacesScan returns the limit set with SetMaxNamespacesScan; the
+// check is disabled until then.
+func (m *MockClusterManager) MaxNamespacesScan() int {
+	return m.maxNamespaces
+}
+
+// SetMaxNamespacesScan sets the value MaxNamespacesScan returns.
+func (m *MockClusterManager) SetMaxNamespacesScan(n int) {
+	m.maxNamespaces = n
+}
+
+func (m *MockClusterManager) GetImpersonation() kai.Impersonation {
+	args := m.Called()
+	return args.Get(0).(kai.Impersonation)
+}
+
+func (m *MockClusterManager) SetImpersonation(imp kai.Impersonation) error {
+	args := m.Called(imp)
+	return args.Error(0)
+}
+
 func (m *MockClusterManager) GetCurrentNamespace() string {
 	args := m.Called()
 	return args.String(0)
@@ -109,3 +202,23 @@ func (m *MockClusterManager) ListContexts() []*kai.ContextInfo {
 	args := m.Called()
 	return args.Get(0).([]*kai.ContextInfo)
 }
+
+// GetRESTMapper builds a mapper from the discovery of the client GetClient
+// returns, so tests only need to stub the client.
+func (m *MockClusterManager) GetRESTMapper(clusterName string) (meta.RESTMapper, error) {
+	client, err := m.GetClient(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(client.Discovery())), nil
+}
+
+// GetCurrentRESTMapper builds a mapper from the discovery of the client
+// GetCurrentClient returns.
+func (m *MockClusterManager) GetCurrentRESTMapper() (meta.RESTMapper, error) {
+	client, err := m.GetCurrentClient()
+	if err != nil {
+		return nil, err
+	}
+	return restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(client.Discovery())), nil
+}
@@ -20,8 +20,8 @@ func (m *MockDeployment) Create(ctx context.Context, cm kai.ClusterManager) (str
 }
 
 // List mocks the List method
-func (m *MockDeployment) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string) (string, error) {
-	args := m.Called(ctx, cm, allNamespaces, labelSelector)
+func (m *MockDeployment) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string, limit int64, continueToken string) (string, error) {
+	args := m.Called(ctx, cm, allNamespaces, labelSelector, limit, continueToken)
 	return args.String(0), args.Error(1)
 }
 
@@ -50,8 +50,8 @@ func (m *MockDeployment) Delete(ctx context.Context, cm kai.ClusterManager) (str
 }
 
 // Scale mocks the Scale method
-func (m *MockDeployment) Scale(ctx context.Context, cm kai.ClusterManager) (string, error) {
-	args := m.Called(ctx, cm)
+func (m *MockDeployment) Scale(ctx context.Context, cm kai.ClusterManager, replicas int32) (string, error) {
+	args := m.Called(ctx, cm, replicas)
 	return args.String(0), args.Error(1)
 }
 
@@ -73,6 +73,12 @@ func (m *MockDeployment) RolloutUndo(ctx context.Context, cm kai.ClusterManager,
 	return args.String(0), args.Error(1)
 }
 
+// DiffRevisions mocks the DiffRevisions method
+func (m *MockDeployment) DiffRevisions(ctx context.Context, cm kai.ClusterManager, fromRevision, toRevision int64) (string, error) {
+	args := m.Called(ctx, cm, fromRevision, toRevision)
+	return args.String(0), args.Error(1)
+}
+
 // RolloutRestart mocks the RolloutRestart method
 func (m *MockDeployment) RolloutRestart(ctx context.Context, cm kai.ClusterManager) (string, error) {
 	args := m.Called(ctx, cm)
@@ -91,6 +97,18 @@ func (m *MockDeployment) RolloutResume(ctx context.Context, cm kai.ClusterManage
 	return args.String(0), args.Error(1)
 }
 
+// Hibernate mocks the Hibernate method
+func (m *MockDeployment) Hibernate(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// Wake mocks the Wake method
+func (m *MockDeployment) Wake(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
 // NewMockDeployment creates a new MockDeployment
 func NewMockDeployment(params kai.DeploymentParams) *MockDeployment {
 	return &MockDeployment{
@@ -113,3 +131,21 @@ func (m *MockDeploymentFactory) NewDeployment(params kai.DeploymentParams) kai.D
 	args := m.Called(params)
 	return args.Get(0).(kai.DeploymentOperator)
 }
+
+// Health mocks the Health method
+func (m *MockDeployment) Health(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// Summarize mocks the Summarize method
+func (m *MockDeployment) Summarize(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// Expose mocks the Expose method
+func (m *MockDeployment) Expose(ctx context.Context, cm kai.ClusterManager, serviceName, serviceType string, port, targetPort int32) (string, error) {
+	args := m.Called(ctx, cm, serviceName, serviceType, port, targetPort)
+	return args.String(0), args.Error(1)
+}
@@ -0,0 +1,67 @@
+package testmocks
+
+import (
+	"context"
+
+	"github.com/basebandit/kai"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockHPAFactory is a mock for HPAFactory.
+type MockHPAFactory struct {
+	mock.Mock
+}
+
+// NewMockHPAFactory creates a new MockHPAFactory.
+func NewMockHPAFactory() *MockHPAFactory {
+	return &MockHPAFactory{}
+}
+
+// NewHPA mocks the NewHPA method.
+func (m *MockHPAFactory) NewHPA(params kai.HPAParams) kai.HPAOperator {
+	args := m.Called(params)
+	return args.Get(0).(kai.HPAOperator)
+}
+
+// MockHPA is a mock implementation of the HPAOperator interface.
+type MockHPA struct {
+	mock.Mock
+	Params kai.HPAParams
+}
+
+// NewMockHPA creates a new MockHPA.
+func NewMockHPA(params kai.HPAParams) *MockHPA {
+	return &MockHPA{
+		Params: params,
+	}
+}
+
+// Create mocks the Create method.
+func (m *MockHPA) Create(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// Get mocks the Get method.
+func (m *MockHPA) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// List mocks the List method.
+func (m *MockHPA) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string) (string, error) {
+	args := m.Called(ctx, cm, allNamespaces, labelSelector)
+	return args.String(0), args.Error(1)
+}
+
+// Delete mocks the Delete method.
+func (m *MockHPA) Delete(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// SetBounds mocks the SetBounds method.
+func (m *MockHPA) SetBounds(ctx context.Context, cm kai.ClusterManager, minReplicas, maxReplicas *int32) (string, error) {
+	args := m.Called(ctx, cm, minReplicas, maxReplicas)
+	return args.String(0), args.Error(1)
+}
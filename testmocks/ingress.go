@@ -48,6 +48,12 @@ func (m *MockIngress) Get(ctx context.Context, cm kai.ClusterManager) (string, e
 	return args.String(0), args.Error(1)
 }
 
+// Describe mocks the Describe method.
+func (m *MockIngress) Describe(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
 // List mocks the List method.
 func (m *MockIngress) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string) (string, error) {
 	args := m.Called(ctx, cm, allNamespaces, labelSelector)
@@ -65,3 +71,9 @@ func (m *MockIngress) Delete(ctx context.Context, cm kai.ClusterManager) (string
 	args := m.Called(ctx, cm)
 	return args.String(0), args.Error(1)
 }
+
+// CreateForService mocks the CreateForService method.
+func (m *MockIngress) CreateForService(ctx context.Context, cm kai.ClusterManager, host string, path kai.IngressPath) (string, error) {
+	args := m.Called(ctx, cm, host, path)
+	return args.String(0), args.Error(1)
+}
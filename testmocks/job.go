@@ -2,6 +2,7 @@ package testmocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/basebandit/kai"
 	"github.com/stretchr/testify/mock"
@@ -65,3 +66,15 @@ func (m *MockJob) Update(ctx context.Context, cm kai.ClusterManager) (string, er
 	args := m.Called(ctx, cm)
 	return args.String(0), args.Error(1)
 }
+
+// Logs mocks the Logs method.
+func (m *MockJob) Logs(ctx context.Context, cm kai.ClusterManager, tailLines int64, since *time.Duration) (string, error) {
+	args := m.Called(ctx, cm, tailLines, since)
+	return args.String(0), args.Error(1)
+}
+
+// WaitForCompletion mocks the WaitForCompletion method.
+func (m *MockJob) WaitForCompletion(ctx context.Context, cm kai.ClusterManager, timeout time.Duration) (string, error) {
+	args := m.Called(ctx, cm, timeout)
+	return args.String(0), args.Error(1)
+}
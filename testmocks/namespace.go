@@ -64,6 +64,24 @@ func (m *MockNamespace) Update(ctx context.Context, cm kai.ClusterManager) (stri
 	return args.String(0), args.Error(1)
 }
 
+// RestartWorkloads mocks the RestartWorkloads method
+func (m *MockNamespace) RestartWorkloads(ctx context.Context, cm kai.ClusterManager, includeStatefulSets, includeDaemonSets bool) (string, error) {
+	args := m.Called(ctx, cm, includeStatefulSets, includeDaemonSets)
+	return args.String(0), args.Error(1)
+}
+
+// Hibernate mocks the Hibernate method
+func (m *MockNamespace) Hibernate(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// Wake mocks the Wake method
+func (m *MockNamespace) Wake(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
 // NamespaceFactory interface for testing
 type NamespaceFactory interface {
 	NewNamespace(params kai.NamespaceParams) kai.NamespaceOperator
@@ -75,8 +75,8 @@ func (m *MockPod) Get(ctx context.Context, cm kai.ClusterManager) (string, error
 }
 
 // List mocks the List method
-func (m *MockPod) List(ctx context.Context, cm kai.ClusterManager, limit int64, labelSelector, fieldSelector string) (string, error) {
-	args := m.Called(ctx, cm, limit, labelSelector, fieldSelector)
+func (m *MockPod) List(ctx context.Context, cm kai.ClusterManager, limit int64, continueToken, labelSelector, fieldSelector string) (string, error) {
+	args := m.Called(ctx, cm, limit, continueToken, labelSelector, fieldSelector)
 	return args.String(0), args.Error(1)
 }
 
@@ -91,3 +91,69 @@ func (m *MockPod) StreamLogs(ctx context.Context, cm kai.ClusterManager, tailLin
 	args := m.Called(ctx, cm, tailLines, previous, since)
 	return args.String(0), args.Error(1)
 }
+
+// StreamAllLogs mocks the StreamAllLogs method
+func (m *MockPod) StreamAllLogs(ctx context.Context, cm kai.ClusterManager, tailLines int64, previous bool, since *time.Duration, includeInit bool) (string, error) {
+	args := m.Called(ctx, cm, tailLines, previous, since, includeInit)
+	return args.String(0), args.Error(1)
+}
+
+// LogsAcrossRestarts mocks the LogsAcrossRestarts method
+func (m *MockPod) LogsAcrossRestarts(ctx context.Context, cm kai.ClusterManager, tailLines int64) (string, error) {
+	args := m.Called(ctx, cm, tailLines)
+	return args.String(0), args.Error(1)
+}
+
+// SearchLogs mocks the SearchLogs method
+func (m *MockPod) SearchLogs(ctx context.Context, cm kai.ClusterManager, pattern string, before, after int, tailLines int64) (string, error) {
+	args := m.Called(ctx, cm, pattern, before, after, tailLines)
+	return args.String(0), args.Error(1)
+}
+
+// Describe mocks the Describe method
+func (m *MockPod) Describe(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// Exec mocks the Exec method
+func (m *MockPod) Exec(ctx context.Context, cm kai.ClusterManager, container string, command []string) (string, error) {
+	args := m.Called(ctx, cm, container, command)
+	return args.String(0), args.Error(1)
+}
+
+// CopyTo mocks the CopyTo method
+func (m *MockPod) CopyTo(ctx context.Context, cm kai.ClusterManager, container string, data []byte, destPath string) (string, error) {
+	args := m.Called(ctx, cm, container, data, destPath)
+	return args.String(0), args.Error(1)
+}
+
+// CopyFrom mocks the CopyFrom method
+func (m *MockPod) CopyFrom(ctx context.Context, cm kai.ClusterManager, container, srcPath string) (string, error) {
+	args := m.Called(ctx, cm, container, srcPath)
+	return args.String(0), args.Error(1)
+}
+
+// Attach mocks the Attach method
+func (m *MockPod) Attach(ctx context.Context, cm kai.ClusterManager, container, stdin string, duration time.Duration, maxBytes int) (string, error) {
+	args := m.Called(ctx, cm, container, stdin, duration, maxBytes)
+	return args.String(0), args.Error(1)
+}
+
+// PortForward mocks the PortForward method
+func (m *MockPod) PortForward(ctx context.Context, cm kai.ClusterManager, localPort, podPort int, duration time.Duration) (string, error) {
+	args := m.Called(ctx, cm, localPort, podPort, duration)
+	return args.String(0), args.Error(1)
+}
+
+// WaitFor mocks the WaitFor method
+func (m *MockPod) WaitFor(ctx context.Context, cm kai.ClusterManager, condition string, timeout time.Duration) (string, error) {
+	args := m.Called(ctx, cm, condition, timeout)
+	return args.String(0), args.Error(1)
+}
+
+// Run mocks the Run method
+func (m *MockPod) Run(ctx context.Context, cm kai.ClusterManager, wait bool, timeout time.Duration, rm bool) (string, error) {
+	args := m.Called(ctx, cm, wait, timeout, rm)
+	return args.String(0), args.Error(1)
+}
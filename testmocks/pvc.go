@@ -0,0 +1,61 @@
+package testmocks
+
+import (
+	"context"
+
+	"github.com/basebandit/kai"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPVCFactory is a mock for PVCFactory.
+type MockPVCFactory struct {
+	mock.Mock
+}
+
+// NewMockPVCFactory creates a new MockPVCFactory.
+func NewMockPVCFactory() *MockPVCFactory {
+	return &MockPVCFactory{}
+}
+
+// NewPVC mocks the NewPVC method.
+func (m *MockPVCFactory) NewPVC(params kai.PVCParams) kai.PVCOperator {
+	args := m.Called(params)
+	return args.Get(0).(kai.PVCOperator)
+}
+
+// MockPVC is a mock implementation of the PVCOperator interface.
+type MockPVC struct {
+	mock.Mock
+	Params kai.PVCParams
+}
+
+// NewMockPVC creates a new MockPVC.
+func NewMockPVC(params kai.PVCParams) *MockPVC {
+	return &MockPVC{
+		Params: params,
+	}
+}
+
+// Create mocks the Create method.
+func (m *MockPVC) Create(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// Get mocks the Get method.
+func (m *MockPVC) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// List mocks the List method.
+func (m *MockPVC) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string) (string, error) {
+	args := m.Called(ctx, cm, allNamespaces, labelSelector)
+	return args.String(0), args.Error(1)
+}
+
+// Delete mocks the Delete method.
+func (m *MockPVC) Delete(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
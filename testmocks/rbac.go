@@ -0,0 +1,76 @@
+package testmocks
+
+import (
+	"context"
+
+	"github.com/basebandit/kai"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRBACFactory is a mock for RBACFactory.
+type MockRBACFactory struct {
+	mock.Mock
+}
+
+// NewMockRBACFactory creates a new MockRBACFactory.
+func NewMockRBACFactory() *MockRBACFactory {
+	return &MockRBACFactory{}
+}
+
+// NewRole mocks the NewRole method.
+func (m *MockRBACFactory) NewRole(params kai.RoleParams) kai.RBACOperator {
+	args := m.Called(params)
+	return args.Get(0).(kai.RBACOperator)
+}
+
+// NewClusterRole mocks the NewClusterRole method.
+func (m *MockRBACFactory) NewClusterRole(params kai.RoleParams) kai.RBACOperator {
+	args := m.Called(params)
+	return args.Get(0).(kai.RBACOperator)
+}
+
+// NewRoleBinding mocks the NewRoleBinding method.
+func (m *MockRBACFactory) NewRoleBinding(params kai.RoleBindingParams) kai.RBACOperator {
+	args := m.Called(params)
+	return args.Get(0).(kai.RBACOperator)
+}
+
+// NewClusterRoleBinding mocks the NewClusterRoleBinding method.
+func (m *MockRBACFactory) NewClusterRoleBinding(params kai.RoleBindingParams) kai.RBACOperator {
+	args := m.Called(params)
+	return args.Get(0).(kai.RBACOperator)
+}
+
+// MockRBAC is a mock implementation of the RBACOperator interface.
+type MockRBAC struct {
+	mock.Mock
+}
+
+// NewMockRBAC creates a new MockRBAC.
+func NewMockRBAC() *MockRBAC {
+	return &MockRBAC{}
+}
+
+// Create mocks the Create method.
+func (m *MockRBAC) Create(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// Get mocks the Get method.
+func (m *MockRBAC) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// List mocks the List method.
+func (m *MockRBAC) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool) (string, error) {
+	args := m.Called(ctx, cm, allNamespaces)
+	return args.String(0), args.Error(1)
+}
+
+// Delete mocks the Delete method.
+func (m *MockRBAC) Delete(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
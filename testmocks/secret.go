@@ -49,8 +49,8 @@ func (m *MockSecret) Get(ctx context.Context, cm kai.ClusterManager) (string, er
 }
 
 // List mocks the List method.
-func (m *MockSecret) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string) (string, error) {
-	args := m.Called(ctx, cm, allNamespaces, labelSelector)
+func (m *MockSecret) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string, limit int64, continueToken string) (string, error) {
+	args := m.Called(ctx, cm, allNamespaces, labelSelector, limit, continueToken)
 	return args.String(0), args.Error(1)
 }
 
@@ -49,8 +49,8 @@ func (m *MockService) Get(ctx context.Context, cm kai.ClusterManager) (string, e
 }
 
 // List mocks the List method
-func (m *MockService) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string) (string, error) {
-	args := m.Called(ctx, cm, allNamespaces, labelSelector)
+func (m *MockService) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string, limit int64, continueToken string) (string, error) {
+	args := m.Called(ctx, cm, allNamespaces, labelSelector, limit, continueToken)
 	return args.String(0), args.Error(1)
 }
 
@@ -71,3 +71,15 @@ func (m *MockService) Patch(ctx context.Context, cm kai.ClusterManager, patchDat
 	args := m.Called(ctx, cm, patchData)
 	return args.String(0), args.Error(1)
 }
+
+// Describe mocks the Describe method
+func (m *MockService) Describe(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// Diagnose mocks the Diagnose method
+func (m *MockService) Diagnose(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
@@ -0,0 +1,61 @@
+package testmocks
+
+import (
+	"context"
+
+	"github.com/basebandit/kai"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockServiceAccountFactory is a mock for ServiceAccountFactory.
+type MockServiceAccountFactory struct {
+	mock.Mock
+}
+
+// NewMockServiceAccountFactory creates a new MockServiceAccountFactory.
+func NewMockServiceAccountFactory() *MockServiceAccountFactory {
+	return &MockServiceAccountFactory{}
+}
+
+// NewServiceAccount mocks the NewServiceAccount method.
+func (m *MockServiceAccountFactory) NewServiceAccount(params kai.ServiceAccountParams) kai.ServiceAccountOperator {
+	args := m.Called(params)
+	return args.Get(0).(kai.ServiceAccountOperator)
+}
+
+// MockServiceAccount is a mock implementation of the ServiceAccountOperator interface.
+type MockServiceAccount struct {
+	mock.Mock
+	Params kai.ServiceAccountParams
+}
+
+// NewMockServiceAccount creates a new MockServiceAccount.
+func NewMockServiceAccount(params kai.ServiceAccountParams) *MockServiceAccount {
+	return &MockServiceAccount{
+		Params: params,
+	}
+}
+
+// Create mocks the Create method.
+func (m *MockServiceAccount) Create(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// Get mocks the Get method.
+func (m *MockServiceAccount) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// List mocks the List method.
+func (m *MockServiceAccount) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string) (string, error) {
+	args := m.Called(ctx, cm, allNamespaces, labelSelector)
+	return args.String(0), args.Error(1)
+}
+
+// Delete mocks the Delete method.
+func (m *MockServiceAccount) Delete(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
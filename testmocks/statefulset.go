@@ -0,0 +1,79 @@
+package testmocks
+
+import (
+	"context"
+
+	"github.com/basebandit/kai"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStatefulSetFactory is a mock for StatefulSetFactory.
+type MockStatefulSetFactory struct {
+	mock.Mock
+}
+
+// NewMockStatefulSetFactory creates a new MockStatefulSetFactory.
+func NewMockStatefulSetFactory() *MockStatefulSetFactory {
+	return &MockStatefulSetFactory{}
+}
+
+// NewStatefulSet mocks the NewStatefulSet method.
+func (m *MockStatefulSetFactory) NewStatefulSet(params kai.StatefulSetParams) kai.StatefulSetOperator {
+	args := m.Called(params)
+	return args.Get(0).(kai.StatefulSetOperator)
+}
+
+// MockStatefulSet is a mock implementation of the StatefulSetOperator interface.
+type MockStatefulSet struct {
+	mock.Mock
+	Params kai.StatefulSetParams
+}
+
+// NewMockStatefulSet creates a new MockStatefulSet.
+func NewMockStatefulSet(params kai.StatefulSetParams) *MockStatefulSet {
+	return &MockStatefulSet{
+		Params: params,
+	}
+}
+
+// Create mocks the Create method.
+func (m *MockStatefulSet) Create(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// Get mocks the Get method.
+func (m *MockStatefulSet) Get(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// List mocks the List method.
+func (m *MockStatefulSet) List(ctx context.Context, cm kai.ClusterManager, allNamespaces bool, labelSelector string) (string, error) {
+	args := m.Called(ctx, cm, allNamespaces, labelSelector)
+	return args.String(0), args.Error(1)
+}
+
+// Update mocks the Update method.
+func (m *MockStatefulSet) Update(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// Describe mocks the Describe method.
+func (m *MockStatefulSet) Describe(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// Delete mocks the Delete method.
+func (m *MockStatefulSet) Delete(ctx context.Context, cm kai.ClusterManager) (string, error) {
+	args := m.Called(ctx, cm)
+	return args.String(0), args.Error(1)
+}
+
+// Scale mocks the Scale method.
+func (m *MockStatefulSet) Scale(ctx context.Context, cm kai.ClusterManager, replicas int32) (string, error) {
+	args := m.Called(ctx, cm, replicas)
+	return args.String(0), args.Error(1)
+}
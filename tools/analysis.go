@@ -0,0 +1,154 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/basebandit/kai"
+	"github.com/basebandit/kai/cluster"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// RegisterAnalysisTools registers tools that report on the state of a
+// namespace rather than operate on a single resource.
+func RegisterAnalysisTools(s kai.ServerInterface, cm kai.ClusterManager) {
+	findOrphansTool := mcp.NewTool("find_orphans",
+		mcp.WithDescription("Find unreferenced resources in a namespace: Services selecting no pods, and ConfigMaps, Secrets and PVCs not used by any pod or workload"),
+		readOnlyAnnotation("Find orphaned resources"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace to inspect (defaults to current namespace)"),
+		),
+		mcp.WithArray("kinds",
+			mcp.Description("Kinds to check: services, configmaps, secrets, persistentvolumeclaims (defaults to all)"),
+		),
+	)
+	s.AddTool(findOrphansTool, findOrphansHandler(cm))
+
+	namespaceActivityTool := mcp.NewTool("namespace_activity",
+		mcp.WithDescription("Report the newest and oldest resources in a namespace and its most recently updated deployments"),
+		readOnlyAnnotation("Namespace activity"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace to inspect (defaults to current namespace)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Number of entries per section (default 5)"),
+		),
+	)
+	s.AddTool(namespaceActivityTool, namespaceActivityHandler(cm))
+
+	pendingReasonsTool := mcp.NewTool("pending_reasons",
+		mcp.WithDescription("List Pending pods grouped by why they are stuck: scheduling failures (insufficient resources, taints, unbound volumes) and container waiting reasons such as ImagePullBackOff"),
+		readOnlyAnnotation("Pending pod reasons"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace to inspect (defaults to current namespace)"),
+		),
+		mcp.WithBoolean("all_namespaces",
+			mcp.Description("Inspect pending pods across all namespaces"),
+		),
+		confirmScanOption(),
+	)
+	s.AddTool(pendingReasonsTool, pendingReasonsHandler(cm))
+
+	exportGraphTool := mcp.NewTool("export_graph",
+		mcp.WithDescription("Export a namespace's topology as a graph: Deployments, StatefulSets, DaemonSets and CronJobs owning ReplicaSets, Jobs and Pods, Services selecting Pods, and Ingresses routing to Services. Returns Graphviz DOT or JSON nodes and edges"),
+		readOnlyAnnotation("Export resource graph"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace to inspect (defaults to current namespace)"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: dot (default) or json"),
+			mcp.Enum(cluster.GraphFormatDOT, cluster.GraphFormatJSON),
+		),
+	)
+	s.AddTool(exportGraphTool, exportGraphHandler(cm))
+}
+
+func findOrphansHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "find_orphans"))
+
+		orphans := cluster.Orphans{}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			orphans.Namespace = ns
+		}
+		if kinds, ok := request.GetArguments()["kinds"].([]interface{}); ok {
+			for _, k := range kinds {
+				if kind, ok := k.(string); ok && kind != "" {
+					orphans.Kinds = append(orphans.Kinds, kind)
+				}
+			}
+		}
+
+		result, err := orphans.Find(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to find orphaned resources: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func namespaceActivityHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "namespace_activity"))
+
+		activity := cluster.NamespaceActivity{}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			activity.Namespace = ns
+		}
+		if limit, ok := request.GetArguments()["limit"].(float64); ok {
+			activity.Limit = int(limit)
+		}
+
+		result, err := activity.Report(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to report namespace activity: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func pendingReasonsHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "pending_reasons"))
+
+		pending := cluster.PendingPods{}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			pending.Namespace = ns
+		}
+		if all, ok := request.GetArguments()["all_namespaces"].(bool); ok {
+			pending.AllNamespaces = all
+		}
+		if pending.AllNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
+
+		result, err := pending.Reasons(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to report pending pods: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func exportGraphHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "export_graph"))
+
+		graph := cluster.ResourceGraph{}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			graph.Namespace = ns
+		}
+		if format, ok := request.GetArguments()["format"].(string); ok {
+			graph.Format = format
+		}
+
+		result, err := graph.Export(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to export resource graph: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
@@ -0,0 +1,19 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterAnalysisTools(t *testing.T) {
+	mockServer := &testmocks.MockServer{}
+	mockCM := testmocks.NewMockClusterManager()
+
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(4)
+
+	RegisterAnalysisTools(mockServer, mockCM)
+
+	mockServer.AssertExpectations(t)
+}
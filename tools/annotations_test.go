@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// registeredTools collects every tool the server registers at startup.
+func registeredTools(t *testing.T) []mcp.Tool {
+	t.Helper()
+	var tools []mcp.Tool
+	mockServer := &testmocks.MockServer{}
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).
+		Run(func(args mock.Arguments) { tools = append(tools, args.Get(0).(mcp.Tool)) }).Return()
+	mockCM := testmocks.NewMockClusterManager()
+	for _, register := range []func(){
+		func() { RegisterNamespaceTools(mockServer, mockCM) },
+		func() { RegisterPodTools(mockServer, mockCM) },
+		func() { RegisterDeploymentTools(mockServer, mockCM) },
+		func() { RegisterStatefulSetTools(mockServer, mockCM) },
+		func() { RegisterServiceTools(mockServer, mockCM) },
+		func() { RegisterContextTools(mockServer, mockCM) },
+		func() { RegisterConfigMapTools(mockServer, mockCM) },
+		func() { RegisterSecretTools(mockServer, mockCM) },
+		func() { RegisterJobTools(mockServer, mockCM) },
+		func() { RegisterCronJobTools(mockServer, mockCM) },
+		func() { RegisterIngressTools(mockServer, mockCM) },
+		func() { RegisterOperationsTools(mockServer, mockCM) },
+		func() { RegisterEventTools(mockServer, mockCM) },
+		func() { RegisterNodeTools(mockServer, mockCM) },
+		func() { RegisterHealthTools(mockServer, mockCM) },
+		func() { RegisterStorageTools(mockServer, mockCM) },
+		func() { RegisterPVCTools(mockServer, mockCM) },
+		func() { RegisterRBACTools(mockServer, mockCM) },
+		func() { RegisterServiceAccountTools(mockServer, mockCM) },
+		func() { RegisterCustomResourceTools(mockServer, mockCM) },
+		func() { RegisterApplyTools(mockServer, mockCM) },
+		func() { RegisterDeleteTools(mockServer, mockCM) },
+		func() { RegisterAnalysisTools(mockServer, mockCM) },
+		func() { RegisterHPATools(mockServer, mockCM) },
+		func() { RegisterQuotaTools(mockServer, mockCM) },
+	} {
+		register()
+	}
+	return tools
+}
+
+func TestToolAnnotations(t *testing.T) {
+	tools := registeredTools(t)
+	require.NotEmpty(t, tools)
+
+	seen := map[string]bool{}
+	for _, tool := range tools {
+		t.Run(tool.Name, func(t *testing.T) {
+			assert.False(t, seen[tool.Name], "tool registered twice")
+			seen[tool.Name] = true
+
+			a := tool.Annotations
+			assert.NotEmpty(t, a.Title)
+			require.NotNil(t, a.ReadOnlyHint)
+			require.NotNil(t, a.DestructiveHint)
+			require.NotNil(t, a.IdempotentHint)
+			require.NotNil(t, a.OpenWorldHint)
+			if *a.ReadOnlyHint {
+				assert.False(t, *a.DestructiveHint, "read-only tools cannot be destructive")
+			}
+
+			verb, _, _ := strings.Cut(tool.Name, "_")
+			switch verb {
+			case "get", "list", "describe":
+				assert.True(t, *a.ReadOnlyHint, "expected read-only")
+				assert.True(t, *a.IdempotentHint, "expected idempotent")
+			case "delete":
+				assert.False(t, *a.ReadOnlyHint, "expected a mutation")
+				assert.True(t, *a.DestructiveHint, "expected destructive")
+			case "create":
+				assert.False(t, *a.ReadOnlyHint, "expected a mutation")
+				assert.False(t, *a.DestructiveHint, "creates do not destroy state")
+				assert.False(t, *a.IdempotentHint, "a second create fails with already exists")
+			case "update", "scale":
+				assert.False(t, *a.ReadOnlyHint, "expected a mutation")
+				assert.False(t, *a.DestructiveHint, "updates do not destroy state")
+				assert.True(t, *a.IdempotentHint, "expected idempotent")
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 
@@ -10,16 +11,94 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
-// RegisterApplyTools registers the apply_yaml tool for applying raw manifests.
+// RegisterApplyTools registers the apply_yaml tool for applying raw manifests,
+// validate_manifest for dry-run checks, create_resource for building an
+// object of any kind from structured arguments, patch_resource for
+// patching an object of any kind, and label_resource and annotate_resource
+// for editing its metadata.
 func RegisterApplyTools(s kai.ServerInterface, cm kai.ClusterManager) {
 	s.AddTool(mcp.NewTool(
 		"apply_yaml",
-		mcp.WithDescription("Apply one or more Kubernetes resources from a YAML/JSON manifest (like `kubectl apply -f`) Supports multiple documents separated by `---` and any kind, including CRDs. Uses server-side apply: resources are created if absent or merged if they already exist."),
+		mcp.WithDescription("Apply one or more Kubernetes resources from a YAML/JSON manifest (like `kubectl apply -f`) Supports multiple documents separated by `---` and any kind, including CRDs. Uses server-side apply: resources are created if absent or merged if they already exist. Set dry_run to have the API server validate the changes without persisting them."),
 		idempotentMutationAnnotation("Apply manifest"),
 		mcp.WithString("manifest", mcp.Required(),
 			mcp.Description("Raw YAML/JSON manifest text.")),
 		mcp.WithString("namespace", mcp.Description("Default namespace for namespaced objects that omit metadata.namespace. Ignored for cluster-scoped kinds.")),
+		mcp.WithBoolean("dry_run", mcp.Description("Submit the changes as a server-side dry run without persisting them (default: false)")),
 	), applyYAMLHandler(cm))
+
+	s.AddTool(mcp.NewTool(
+		"validate_manifest",
+		mcp.WithDescription("Validate one or more Kubernetes manifests against the cluster without applying them. Each document is parsed and submitted as a server-side apply dry-run; the result reports every document as valid or invalid, separating parse errors from errors returned by the API server or admission webhooks."),
+		readOnlyAnnotation("Validate manifest"),
+		mcp.WithString("manifest", mcp.Required(),
+			mcp.Description("Raw YAML/JSON manifest text.")),
+		mcp.WithString("namespace", mcp.Description("Default namespace for namespaced objects that omit metadata.namespace. Ignored for cluster-scoped kinds.")),
+	), validateManifestHandler(cm))
+
+	s.AddTool(mcp.NewTool(
+		"create_resource",
+		mcp.WithDescription("Create a resource of any kind, including custom resources, from structured arguments. Useful for kinds without a dedicated create tool. Fails if the resource already exists."),
+		creationAnnotation("Create resource"),
+		mcp.WithString("kind", mcp.Required(),
+			mcp.Description("Kind (e.g. 'Certificate') or plural resource name (e.g. 'certificates') of the object")),
+		mcp.WithString("name", mcp.Required(),
+			mcp.Description("metadata.name of the object")),
+		mcp.WithString("group", mcp.Description("API group of the kind (empty for the core group)")),
+		mcp.WithString("version", mcp.Description("API version of the kind (defaults to the preferred version)")),
+		mcp.WithString("namespace", mcp.Description("Namespace for namespaced kinds (defaults to current namespace). Ignored for cluster-scoped kinds.")),
+		mcp.WithObject("labels", mcp.Description("Labels to apply to the object")),
+		mcp.WithObject("spec", mcp.Description("The object's spec as a JSON object")),
+	), createResourceHandler(cm))
+
+	s.AddTool(mcp.NewTool(
+		"patch_resource",
+		mcp.WithDescription("Patch a resource of any kind, including custom resources, by kind and name (like `kubectl patch --type`). Custom resources accept only merge and json patches."),
+		destructiveAnnotation("Patch resource"),
+		mcp.WithString("kind", mcp.Required(),
+			mcp.Description("Kind (e.g. 'Deployment') or plural resource name (e.g. 'deployments') of the object")),
+		mcp.WithString("name", mcp.Required(),
+			mcp.Description("Name of the object to patch")),
+		mcp.WithString("patch", mcp.Required(),
+			mcp.Description("Patch document as JSON or YAML: a partial object for strategic and merge patches, or a list of operations for a json patch")),
+		mcp.WithString("patch_type", mcp.Description("strategic (default), merge or json")),
+		mcp.WithString("group", mcp.Description("API group of the kind (empty for the core group)")),
+		mcp.WithString("version", mcp.Description("API version of the kind (defaults to the preferred version)")),
+		mcp.WithString("namespace", mcp.Description("Namespace of the object (defaults to current namespace). Ignored for cluster-scoped kinds.")),
+		dryRunOption(),
+	), patchResourceHandler(cm))
+
+	s.AddTool(mcp.NewTool(
+		"label_resource",
+		mcp.WithDescription("Add, change or remove labels on a resource of any kind (like `kubectl label --overwrite`). Only the given keys are touched; a null or empty value removes the label."),
+		idempotentMutationAnnotation("Label resource"),
+		mcp.WithString("kind", mcp.Required(),
+			mcp.Description("Kind (e.g. 'Deployment') or plural resource name (e.g. 'deployments') of the object")),
+		mcp.WithString("name", mcp.Required(),
+			mcp.Description("Name of the object to label")),
+		mcp.WithObject("labels", mcp.Required(),
+			mcp.Description("Labels to set, e.g. {\"tier\": \"frontend\", \"stale\": null}; a null or empty value removes the key")),
+		mcp.WithString("group", mcp.Description("API group of the kind (empty for the core group)")),
+		mcp.WithString("version", mcp.Description("API version of the kind (defaults to the preferred version)")),
+		mcp.WithString("namespace", mcp.Description("Namespace of the object (defaults to current namespace). Ignored for cluster-scoped kinds.")),
+		dryRunOption(),
+	), setMetadataHandler(cm, "label_resource", "labels"))
+
+	s.AddTool(mcp.NewTool(
+		"annotate_resource",
+		mcp.WithDescription("Add, change or remove annotations on a resource of any kind (like `kubectl annotate --overwrite`). Only the given keys are touched; a null or empty value removes the annotation."),
+		idempotentMutationAnnotation("Annotate resource"),
+		mcp.WithString("kind", mcp.Required(),
+			mcp.Description("Kind (e.g. 'Deployment') or plural resource name (e.g. 'deployments') of the object")),
+		mcp.WithString("name", mcp.Required(),
+			mcp.Description("Name of the object to annotate")),
+		mcp.WithObject("annotations", mcp.Required(),
+			mcp.Description("Annotations to set, e.g. {\"owner\": \"team-a\", \"old-note\": null}; a null or empty value removes the key")),
+		mcp.WithString("group", mcp.Description("API group of the kind (empty for the core group)")),
+		mcp.WithString("version", mcp.Description("API version of the kind (defaults to the preferred version)")),
+		mcp.WithString("namespace", mcp.Description("Namespace of the object (defaults to current namespace). Ignored for cluster-scoped kinds.")),
+		dryRunOption(),
+	), setMetadataHandler(cm, "annotate_resource", "annotations"))
 }
 
 func applyYAMLHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -35,6 +114,9 @@ func applyYAMLHandler(cm kai.ClusterManager) func(ctx context.Context, request m
 		if ns, ok := request.GetArguments()["namespace"].(string); ok {
 			apply.Namespace = ns
 		}
+		if dryRun, ok := request.GetArguments()["dry_run"].(bool); ok {
+			apply.DryRun = dryRun
+		}
 
 		result, err := apply.Run(ctx, cm)
 		if err != nil {
@@ -43,3 +125,172 @@ func applyYAMLHandler(cm kai.ClusterManager) func(ctx context.Context, request m
 		return mcp.NewToolResultText(result), nil
 	}
 }
+
+func validateManifestHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "validate_manifest"))
+
+		manifest, ok := request.GetArguments()["manifest"].(string)
+		if !ok || manifest == "" {
+			return mcp.NewToolResultText("Required parameter 'manifest' is missing"), nil
+		}
+
+		validate := cluster.ValidateManifest{Manifest: manifest}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			validate.Namespace = ns
+		}
+
+		result, err := validate.Run(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("failed to validate manifest: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func createResourceHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "create_resource"))
+
+		kind, ok := request.GetArguments()["kind"].(string)
+		if !ok || kind == "" {
+			return mcp.NewToolResultText("Required parameter 'kind' is missing"), nil
+		}
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if err := validateResourceName(name); err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		create := cluster.CreateResource{Kind: kind, Name: name}
+		if group, ok := request.GetArguments()["group"].(string); ok {
+			create.Group = group
+		}
+		if version, ok := request.GetArguments()["version"].(string); ok {
+			create.Version = version
+		}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			create.Namespace = ns
+		}
+		if labels, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labels); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
+			create.Labels = labels
+		}
+		if specArg, ok := request.GetArguments()["spec"]; ok && specArg != nil {
+			spec, ok := specArg.(map[string]interface{})
+			if !ok {
+				return mcp.NewToolResultText("spec parameter must be an object"), nil
+			}
+			create.Spec = spec
+		}
+
+		result, err := create.Run(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("failed to create resource: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func patchResourceHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "patch_resource"))
+
+		kind, ok := request.GetArguments()["kind"].(string)
+		if !ok || kind == "" {
+			return mcp.NewToolResultText("Required parameter 'kind' is missing"), nil
+		}
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		patch := cluster.PatchResource{Kind: kind, Name: name, DryRun: dryRunArg(request)}
+		// Clients may send the patch as a JSON value rather than a string.
+		switch body := request.GetArguments()["patch"].(type) {
+		case string:
+			patch.Patch = body
+		case map[string]interface{}, []interface{}:
+			data, err := json.Marshal(body)
+			if err != nil {
+				return mcp.NewToolResultText(fmt.Sprintf("invalid patch: %s", err.Error())), nil
+			}
+			patch.Patch = string(data)
+		}
+		if patch.Patch == "" {
+			return mcp.NewToolResultText("Required parameter 'patch' is missing"), nil
+		}
+		if patchType, ok := request.GetArguments()["patch_type"].(string); ok {
+			patch.PatchType = patchType
+		}
+		if group, ok := request.GetArguments()["group"].(string); ok {
+			patch.Group = group
+		}
+		if version, ok := request.GetArguments()["version"].(string); ok {
+			patch.Version = version
+		}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			patch.Namespace = ns
+		}
+
+		result, err := patch.Run(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("failed to patch resource: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+// setMetadataHandler handles label_resource and annotate_resource, which only
+// differ in the metadata field they edit.
+func setMetadataHandler(cm kai.ClusterManager, tool, field string) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", tool))
+
+		kind, ok := request.GetArguments()["kind"].(string)
+		if !ok || kind == "" {
+			return mcp.NewToolResultText("Required parameter 'kind' is missing"), nil
+		}
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		raw, ok := request.GetArguments()[field].(map[string]interface{})
+		if !ok || len(raw) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("Required parameter '%s' is missing", field)), nil
+		}
+		values := make(map[string]*string, len(raw))
+		for key, value := range raw {
+			switch v := value.(type) {
+			case nil:
+				values[key] = nil
+			case string:
+				values[key] = &v
+			default:
+				return mcp.NewToolResultText(fmt.Sprintf("Value of %q must be a string, or null to remove it", key)), nil
+			}
+		}
+
+		update := cluster.SetMetadata{Kind: kind, Name: name, Field: field, Values: values, DryRun: dryRunArg(request)}
+		if group, ok := request.GetArguments()["group"].(string); ok {
+			update.Group = group
+		}
+		if version, ok := request.GetArguments()["version"].(string); ok {
+			update.Version = version
+		}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			update.Namespace = ns
+		}
+
+		result, err := update.Run(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("failed to update %s: %s", field, err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
@@ -8,17 +8,20 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestRegisterApplyTools(t *testing.T) {
 	mockServer := &testmocks.MockServer{}
 	mockCM := testmocks.NewMockClusterManager()
 	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"),
-		mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(1)
+		mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(6)
 	RegisterApplyTools(mockServer, mockCM)
 	mockServer.AssertExpectations(t)
 }
@@ -35,6 +38,14 @@ func TestApplyYAMLHandler(t *testing.T) {
 		{Group: "", Version: "v1", Resource: "configmaps"}: "ConfigMapList",
 	}
 	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+	// The fake tracker cannot apply to absent objects; accept the apply
+	// patches directly.
+	applies := 0
+	dyn.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		assert.Equal(t, types.ApplyPatchType, action.(k8stesting.PatchAction).GetPatchType())
+		applies++
+		return true, nil, nil
+	})
 
 	mockCM := testmocks.NewMockClusterManager()
 	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
@@ -52,8 +63,188 @@ data:
 	assert.NoError(t, err)
 	assert.Contains(t, resultText(t, r), "ConfigMap default/cm1 created")
 
+	r, err = applyYAMLHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"manifest": manifest, "dry_run": true}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "ConfigMap default/cm1 created (dry run)")
+	assert.Equal(t, 2, applies)
+
 	// Missing manifest argument.
 	r, err = applyYAMLHandler(mockCM)(ctx, toolRequest(nil))
 	assert.NoError(t, err)
 	assert.Contains(t, resultText(t, r), "manifest")
 }
+
+func TestValidateManifestHandler(t *testing.T) {
+	ctx := context.Background()
+
+	fakeClient := fake.NewSimpleClientset()
+	fakeClient.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Name: "configmaps", Namespaced: true, Kind: "ConfigMap"}},
+	}}
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	dyn.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+
+	manifest := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+---
+kind: ConfigMap
+`
+	r, err := validateManifestHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"manifest": manifest, "namespace": "ci"}))
+	assert.NoError(t, err)
+	text := resultText(t, r)
+	assert.Contains(t, text, "1 valid, 1 invalid")
+	assert.Contains(t, text, "ConfigMap ci/cm1: valid")
+	assert.Contains(t, text, "invalid (parse error): manifest document missing apiVersion or kind")
+
+	r, err = validateManifestHandler(mockCM)(ctx, toolRequest(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "Required parameter 'manifest' is missing", resultText(t, r))
+}
+
+func TestCreateResourceHandler(t *testing.T) {
+	ctx := context.Background()
+
+	fakeClient := fake.NewSimpleClientset()
+	fakeClient.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "example.com/v1",
+		APIResources: []metav1.APIResource{{Name: "widgets", Namespaced: true, Kind: "Widget"}},
+	}}
+	widgetGVR := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		widgetGVR: "WidgetList",
+	})
+
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+
+	r, err := createResourceHandler(mockCM)(ctx, toolRequest(map[string]interface{}{
+		"kind":  "Widget",
+		"group": "example.com",
+		"name":  "w1",
+		"spec":  map[string]interface{}{"size": float64(3)},
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "Widget default/w1 created")
+
+	// Invalid spec argument.
+	r, err = createResourceHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"kind": "Widget", "name": "w2", "spec": "size=3"}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "spec parameter must be an object")
+
+	// Missing kind argument.
+	r, err = createResourceHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"name": "w1"}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "kind")
+}
+
+func TestPatchResourceHandler(t *testing.T) {
+	ctx := context.Background()
+
+	fakeClient := fake.NewSimpleClientset()
+	fakeClient.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "example.com/v1",
+		APIResources: []metav1.APIResource{{Name: "widgets", Namespaced: true, Kind: "Widget"}},
+	}}
+	widgetGVR := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		widgetGVR: "WidgetList",
+	})
+	_, err := dyn.Resource(widgetGVR).Namespace(defaultNamespace).Create(ctx, &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"metadata":   map[string]interface{}{"name": "w1", "namespace": defaultNamespace},
+	}}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+
+	// The patch may arrive as a JSON object rather than a string.
+	r, err := patchResourceHandler(mockCM)(ctx, toolRequest(map[string]interface{}{
+		"kind":       "Widget",
+		"group":      "example.com",
+		"name":       "w1",
+		"patch_type": "merge",
+		"patch":      map[string]interface{}{"spec": map[string]interface{}{"size": float64(5)}},
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "Widget default/w1 patched (merge patch)")
+
+	patched, err := dyn.Resource(widgetGVR).Namespace(defaultNamespace).Get(ctx, "w1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	size, _, _ := unstructured.NestedInt64(patched.Object, "spec", "size")
+	assert.Equal(t, int64(5), size)
+
+	// Missing patch argument.
+	r, err = patchResourceHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"kind": "Widget", "name": "w1"}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "'patch' is missing")
+}
+
+func TestSetMetadataHandler(t *testing.T) {
+	ctx := context.Background()
+
+	fakeClient := fake.NewSimpleClientset()
+	fakeClient.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Name: "configmaps", Namespaced: true, Kind: "ConfigMap"}},
+	}}
+	cmGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		cmGVR: "ConfigMapList",
+	})
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "cm1", "namespace": defaultNamespace},
+	}}
+	obj.SetLabels(map[string]string{"stale": "yes"})
+	_, err := dyn.Resource(cmGVR).Namespace(defaultNamespace).Create(ctx, obj, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+
+	r, err := setMetadataHandler(mockCM, "label_resource", "labels")(ctx, toolRequest(map[string]interface{}{
+		"kind":   "ConfigMap",
+		"name":   "cm1",
+		"labels": map[string]interface{}{"tier": "frontend", "stale": nil},
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "ConfigMap default/cm1 labeled (set tier=frontend; removed stale)", resultText(t, r))
+
+	labeled, err := dyn.Resource(cmGVR).Namespace(defaultNamespace).Get(ctx, "cm1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"tier": "frontend"}, labeled.GetLabels())
+
+	r, err = setMetadataHandler(mockCM, "annotate_resource", "annotations")(ctx, toolRequest(map[string]interface{}{
+		"kind":        "ConfigMap",
+		"name":        "cm1",
+		"annotations": map[string]interface{}{"replicas": float64(3)},
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, `Value of "replicas" must be a string, or null to remove it`, resultText(t, r))
+
+	r, err = setMetadataHandler(mockCM, "annotate_resource", "annotations")(ctx, toolRequest(map[string]interface{}{
+		"kind": "ConfigMap",
+		"name": "cm1",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "Required parameter 'annotations' is missing", resultText(t, r))
+}
@@ -32,6 +32,9 @@ func (f *DefaultConfigMapFactory) NewConfigMap(params kai.ConfigMapParams) kai.C
 		BinaryData:  params.BinaryData,
 		Labels:      params.Labels,
 		Annotations: params.Annotations,
+		Encoding:    params.Encoding,
+		DryRun:      params.DryRun,
+		ShowDiff:    params.ShowDiff,
 	}
 }
 
@@ -65,6 +68,7 @@ func RegisterConfigMapToolsWithFactory(s kai.ServerInterface, cm kai.ClusterMana
 		mcp.WithObject("annotations",
 			mcp.Description("Annotations to apply to the ConfigMap"),
 		),
+		dryRunOption(),
 	)
 	s.AddTool(createConfigMapTool, createConfigMapHandler(cm, factory))
 
@@ -78,6 +82,10 @@ func RegisterConfigMapToolsWithFactory(s kai.ServerInterface, cm kai.ClusterMana
 		mcp.WithString("namespace",
 			mcp.Description("Namespace of the ConfigMap (defaults to current namespace)"),
 		),
+		mcp.WithString("encoding",
+			mcp.Description("How binary data values are rendered: base64 (default), hex, or utf8 with invalid bytes replaced"),
+			mcp.Enum(cluster.EncodingBase64, cluster.EncodingHex, cluster.EncodingUTF8),
+		),
 	)
 	s.AddTool(getConfigMapTool, getConfigMapHandler(cm, factory))
 
@@ -87,6 +95,7 @@ func RegisterConfigMapToolsWithFactory(s kai.ServerInterface, cm kai.ClusterMana
 		mcp.WithBoolean("all_namespaces",
 			mcp.Description("Whether to list ConfigMaps across all namespaces"),
 		),
+		confirmScanOption(),
 		mcp.WithString("namespace",
 			mcp.Description("Specific namespace to list ConfigMaps from (defaults to current namespace)"),
 		),
@@ -123,7 +132,7 @@ func RegisterConfigMapToolsWithFactory(s kai.ServerInterface, cm kai.ClusterMana
 			mcp.Description("New key-value pairs of configuration data (replaces existing data)"),
 		),
 		mcp.WithObject("binary_data",
-			mcp.Description("New key-value pairs of binary data (replaces existing binary data)"),
+			mcp.Description("New key-value pairs of binary data, base64 encoded (replaces existing binary data)"),
 		),
 		mcp.WithObject("labels",
 			mcp.Description("New labels to apply to the ConfigMap (replaces existing labels)"),
@@ -131,6 +140,8 @@ func RegisterConfigMapToolsWithFactory(s kai.ServerInterface, cm kai.ClusterMana
 		mcp.WithObject("annotations",
 			mcp.Description("New annotations to apply to the ConfigMap (replaces existing annotations)"),
 		),
+		dryRunOption(),
+		showDiffOption(),
 	)
 	s.AddTool(updateConfigMapTool, updateConfigMapHandler(cm, factory))
 }
@@ -149,7 +160,11 @@ func createConfigMapHandler(cm kai.ClusterManager, factory ConfigMapFactory) fun
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		if err := validateResourceName(name); err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -168,6 +183,9 @@ func createConfigMapHandler(cm kai.ClusterManager, factory ConfigMapFactory) fun
 		}
 
 		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			params.Labels = labelsArg
 		}
 
@@ -175,6 +193,7 @@ func createConfigMapHandler(cm kai.ClusterManager, factory ConfigMapFactory) fun
 			params.Annotations = annotationsArg
 		}
 
+		params.DryRun = dryRunArg(request)
 		configMap := factory.NewConfigMap(params)
 		result, err := configMap.Create(ctx, cm)
 		if err != nil {
@@ -183,7 +202,7 @@ func createConfigMapHandler(cm kai.ClusterManager, factory ConfigMapFactory) fun
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to create ConfigMap: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to create ConfigMap: %s", classifyError(err))), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -204,7 +223,7 @@ func getConfigMapHandler(cm kai.ClusterManager, factory ConfigMapFactory) func(c
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -213,6 +232,9 @@ func getConfigMapHandler(cm kai.ClusterManager, factory ConfigMapFactory) func(c
 			Name:      name,
 			Namespace: namespace,
 		}
+		if encoding, ok := request.GetArguments()["encoding"].(string); ok {
+			params.Encoding = encoding
+		}
 
 		configMap := factory.NewConfigMap(params)
 		result, err := configMap.Get(ctx, cm)
@@ -222,7 +244,7 @@ func getConfigMapHandler(cm kai.ClusterManager, factory ConfigMapFactory) func(c
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to get ConfigMap: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get ConfigMap: %s", classifyError(err))), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -238,12 +260,18 @@ func listConfigMapsHandler(cm kai.ClusterManager, factory ConfigMapFactory) func
 			allNamespaces = allNamespacesArg
 		}
 
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
+
 		var namespace string
 		if !allNamespaces {
 			if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 				namespace = namespaceArg
 			} else {
-				namespace = cm.GetCurrentNamespace()
+				namespace = kai.CurrentNamespace(ctx, cm)
 			}
 		}
 
@@ -286,7 +314,7 @@ func deleteConfigMapHandler(cm kai.ClusterManager, factory ConfigMapFactory) fun
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -304,7 +332,7 @@ func deleteConfigMapHandler(cm kai.ClusterManager, factory ConfigMapFactory) fun
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete ConfigMap: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete ConfigMap: %s", classifyError(err))), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -325,7 +353,7 @@ func updateConfigMapHandler(cm kai.ClusterManager, factory ConfigMapFactory) fun
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -344,6 +372,9 @@ func updateConfigMapHandler(cm kai.ClusterManager, factory ConfigMapFactory) fun
 		}
 
 		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			params.Labels = labelsArg
 		}
 
@@ -351,6 +382,8 @@ func updateConfigMapHandler(cm kai.ClusterManager, factory ConfigMapFactory) fun
 			params.Annotations = annotationsArg
 		}
 
+		params.DryRun = dryRunArg(request)
+		params.ShowDiff = showDiffArg(request)
 		configMap := factory.NewConfigMap(params)
 		result, err := configMap.Update(ctx, cm)
 		if err != nil {
@@ -197,6 +197,25 @@ func TestGetConfigMapHandler(t *testing.T) {
 			expectedOutput:          fmt.Sprintf("ConfigMap %q in namespace %q:", configMapName, defaultNamespace),
 			expectConfigMapCreation: true,
 		},
+		{
+			name: "WithEncoding",
+			args: map[string]interface{}{
+				"name":     configMapName,
+				"encoding": "hex",
+			},
+			expectedParams: kai.ConfigMapParams{
+				Name:      configMapName,
+				Namespace: defaultNamespace,
+				Encoding:  "hex",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockConfigMapFactory, mockConfigMap *testmocks.MockConfigMap) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockConfigMap.On("Get", mock.Anything, mockCM).
+					Return("Binary Data (hex):\n- data.bin: 00ff10 (3 bytes)", nil)
+			},
+			expectedOutput:          "- data.bin: 00ff10 (3 bytes)",
+			expectConfigMapCreation: true,
+		},
 		{
 			name:           "MissingName",
 			args:           map[string]interface{}{},
@@ -298,6 +317,27 @@ func TestCreateConfigMapHandler(t *testing.T) {
 			expectedOutput:          fmt.Sprintf("ConfigMap %q created successfully in namespace %q", configMapName, defaultNamespace),
 			expectConfigMapCreation: true,
 		},
+		{
+			name: "DryRun",
+			args: map[string]interface{}{
+				"name":    configMapName,
+				"data":    map[string]interface{}{"key1": "value1"},
+				"dry_run": true,
+			},
+			expectedParams: kai.ConfigMapParams{
+				Name:      configMapName,
+				Namespace: defaultNamespace,
+				Data:      map[string]interface{}{"key1": "value1"},
+				DryRun:    true,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockConfigMapFactory, mockConfigMap *testmocks.MockConfigMap) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockConfigMap.On("Create", mock.Anything, mockCM).
+					Return(fmt.Sprintf("ConfigMap %q created successfully in namespace %q (dry run)", configMapName, defaultNamespace), nil)
+			},
+			expectedOutput:          "(dry run)",
+			expectConfigMapCreation: true,
+		},
 		{
 			name: "WithLabelsAndAnnotations",
 			args: map[string]interface{}{
@@ -0,0 +1,178 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/basebandit/kai"
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// singleContainerArgs describe the only container of create_pod and
+// create_deployment; with containers they belong in each entry instead.
+var singleContainerArgs = []string{"image", "container_name", "container_port", "env", "command", "args", "image_pull_policy",
+	"cpu_request", "memory_request", "cpu_limit", "memory_limit"}
+
+// containersOption declares the containers argument of create_pod and
+// create_deployment.
+func containersOption() mcp.ToolOption {
+	return mcp.WithArray("containers",
+		mcp.Description("Run several containers, e.g. an app and a sidecar proxy, instead of the single container given by image. "+
+			"Each entry is {\"name\", \"image\", \"ports\": [\"8080\", \"8125/UDP\"], \"env\": {...}, \"command\": [...], \"args\": [...], "+
+			"\"image_pull_policy\", \"resources\": {\"requests\": {\"cpu\": \"100m\"}, \"limits\": {\"memory\": \"256Mi\"}}}; name and image are required"),
+		mcp.Items(map[string]any{"type": "object"}),
+	)
+}
+
+// containersArg parses the containers argument, returning nil when it is
+// absent. It rejects the single-container arguments alongside it, which
+// would otherwise be silently ignored.
+func containersArg(request mcp.CallToolRequest) ([]kai.ContainerSpec, error) {
+	raw, ok := request.GetArguments()["containers"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	entries, ok := raw.([]interface{})
+	if !ok || len(entries) == 0 {
+		return nil, fmt.Errorf("parameter 'containers' must be a non-empty array of container objects")
+	}
+	for _, arg := range singleContainerArgs {
+		if _, ok := request.GetArguments()[arg]; ok {
+			return nil, fmt.Errorf("parameter '%s' cannot be combined with 'containers'; set it on the container entry instead", arg)
+		}
+	}
+
+	specs := make([]kai.ContainerSpec, 0, len(entries))
+	for i, entry := range entries {
+		fields, ok := entry.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("container %d must be an object", i+1)
+		}
+
+		spec := kai.ContainerSpec{}
+		spec.Name, _ = fields["name"].(string)
+		if err := validateContainerName(spec.Name); err != nil {
+			return nil, fmt.Errorf("container %d: %w", i+1, err)
+		}
+		spec.Image, _ = fields["image"].(string)
+		if spec.Image == "" {
+			return nil, fmt.Errorf("container %q: image is required", spec.Name)
+		}
+
+		if ports, ok := fields["ports"].([]interface{}); ok {
+			for _, p := range ports {
+				var port string
+				switch v := p.(type) {
+				case string:
+					port = v
+				case float64:
+					port = fmt.Sprintf("%d", int(v))
+				default:
+					return nil, fmt.Errorf("container %q: ports must be numbers or strings like \"8080/TCP\"", spec.Name)
+				}
+				if err := validateContainerPort(port); err != nil {
+					return nil, fmt.Errorf("container %q: %w", spec.Name, err)
+				}
+				spec.Ports = append(spec.Ports, port)
+			}
+		}
+		if env, ok := fields["env"].(map[string]interface{}); ok {
+			spec.Env = env
+		}
+		if command, ok := fields["command"].([]interface{}); ok {
+			spec.Command = command
+		}
+		if args, ok := fields["args"].([]interface{}); ok {
+			spec.Args = args
+		}
+		if policy, ok := fields["image_pull_policy"].(string); ok && policy != "" {
+			if err := validateImagePullPolicy(policy); err != nil {
+				return nil, fmt.Errorf("container %q: %w", spec.Name, err)
+			}
+			spec.ImagePullPolicy = policy
+		}
+		if resources, ok := fields["resources"].(map[string]interface{}); ok {
+			spec.Requests, _ = resources["requests"].(map[string]interface{})
+			spec.Limits, _ = resources["limits"].(map[string]interface{})
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// checkContainerImages runs checkImage for every container.
+func checkContainerImages(ctx context.Context, cm kai.ClusterManager, request mcp.CallToolRequest, namespace string, specs []kai.ContainerSpec, pullSecrets []interface{}) *mcp.CallToolResult {
+	for _, spec := range specs {
+		if result := checkImage(ctx, cm, request, namespace, spec.Image, pullSecrets); result != nil {
+			return result
+		}
+	}
+	return nil
+}
+
+// resourceArgs are the single-container resource arguments, in the order
+// they are checked, with an example quantity for error messages.
+var resourceArgs = []struct{ name, example string }{
+	{"cpu_request", "250m or 0.5"},
+	{"memory_request", "256Mi or 1Gi"},
+	{"cpu_limit", "250m or 0.5"},
+	{"memory_limit", "256Mi or 1Gi"},
+}
+
+// resourcesOption declares the cpu and memory request and limit arguments
+// of create_pod and create_deployment.
+func resourcesOption() mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		for _, opt := range []mcp.ToolOption{
+			mcp.WithString("cpu_request", mcp.Description("CPU reserved for the container, e.g. '250m' or '0.5'")),
+			mcp.WithString("memory_request", mcp.Description("Memory reserved for the container, e.g. '256Mi'")),
+			mcp.WithString("cpu_limit", mcp.Description("CPU the container is throttled at, e.g. '1'")),
+			mcp.WithString("memory_limit", mcp.Description("Memory above which the container is OOM-killed, e.g. '512Mi'")),
+		} {
+			opt(t)
+		}
+	}
+}
+
+// resourcesArg parses the cpu and memory arguments into the requests and
+// limits of the single container. Malformed or negative quantities, and a
+// request above its limit, which the API server would refuse, are rejected.
+func resourcesArg(request mcp.CallToolRequest) (requests, limits map[string]interface{}, err error) {
+	parsed := make(map[string]resource.Quantity, len(resourceArgs))
+	for _, arg := range resourceArgs {
+		raw, ok := request.GetArguments()[arg.name]
+		if !ok || raw == nil {
+			continue
+		}
+		value := strings.TrimSpace(fmt.Sprintf("%v", raw))
+		q, err := resource.ParseQuantity(value)
+		if err != nil || q.Sign() < 0 {
+			return nil, nil, fmt.Errorf("invalid %s %q: must be a non-negative quantity like %s", arg.name, value, arg.example)
+		}
+		parsed[arg.name] = q
+
+		name, kind, _ := strings.Cut(arg.name, "_")
+		if kind == "request" {
+			if requests == nil {
+				requests = make(map[string]interface{})
+			}
+			requests[name] = value
+		} else {
+			if limits == nil {
+				limits = make(map[string]interface{})
+			}
+			limits[name] = value
+		}
+	}
+
+	for _, name := range []string{"cpu", "memory"} {
+		req, hasRequest := parsed[name+"_request"]
+		limit, hasLimit := parsed[name+"_limit"]
+		if hasRequest && hasLimit && req.Cmp(limit) > 0 {
+			return nil, nil, fmt.Errorf("%s_request %s is above %s_limit %s", name, req.String(), name, limit.String())
+		}
+	}
+	return requests, limits, nil
+}
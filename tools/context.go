@@ -34,19 +34,59 @@ func RegisterContextTools(s kai.ServerInterface, cm kai.ClusterManager) {
 	)
 	s.AddTool(switchContextTool, switchContextHandler(cm))
 
+	setNamespaceTool := mcp.NewTool("set_namespace",
+		mcp.WithDescription("Set the default namespace used by tools when no namespace is given"),
+		idempotentMutationAnnotation("Set namespace"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("Namespace to use by default"),
+		),
+	)
+	s.AddTool(setNamespaceTool, setNamespaceHandler(cm))
+
+	impersonateTool := mcp.NewTool("impersonate",
+		mcp.WithDescription("Act as another user or service account, like kubectl --as, to test what it is allowed to do. Calling with no identity stops impersonating. Only available when the server enables it, and never when the server was started with a fixed identity"),
+		idempotentMutationAnnotation("Impersonate"),
+		mcp.WithString("user",
+			mcp.Description("User to act as"),
+		),
+		mcp.WithString("service_account",
+			mcp.Description("Service account to act as, given as namespace/name. Cannot be combined with user"),
+		),
+		mcp.WithArray("groups",
+			mcp.Description("Groups to act as, alongside user or service_account"),
+			mcp.WithStringItems(),
+		),
+	)
+	s.AddTool(impersonateTool, impersonateHandler(cm))
+
 	loadKubeconfigTool := mcp.NewTool("load_kubeconfig",
-		mcp.WithDescription("Load a kubeconfig file and register it as a new context"),
+		mcp.WithDescription("Load a kubeconfig file alongside those already loaded, registering each of its contexts as '<name>-<context>'. The active context only changes when none is active; use switch_context to move between clusters"),
 		creationAnnotation("Load kubeconfig"),
 		mcp.WithString("name",
 			mcp.Required(),
-			mcp.Description("Name to assign to this context"),
+			mcp.Description("Name prefixed to each context in the file, such as prod or staging"),
 		),
 		mcp.WithString("path",
-			mcp.Description("Path to the kubeconfig file (defaults to ~/.kube/config)"),
+			mcp.Description("Path to the kubeconfig file (defaults to ~/.kube/config). When the server confines file access to a manifest root, the path must lie within it and relative paths are taken from it"),
 		),
 	)
 	s.AddTool(loadKubeconfigTool, loadKubeconfigHandler(cm))
 
+	loadKubeconfigInlineTool := mcp.NewTool("load_kubeconfig_inline",
+		mcp.WithDescription("Load a kubeconfig passed as YAML content rather than a file path, for remote or ephemeral clusters. Contexts are registered as '<name>-<context>' just like load_kubeconfig, and nothing is written to disk"),
+		creationAnnotation("Load inline kubeconfig"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name prefixed to each context in the kubeconfig, such as ci or preview"),
+		),
+		mcp.WithString("kubeconfig",
+			mcp.Required(),
+			mcp.Description("Full kubeconfig YAML content"),
+		),
+	)
+	s.AddTool(loadKubeconfigInlineTool, loadKubeconfigInlineHandler(cm))
+
 	deleteContextTool := mcp.NewTool("delete_context",
 		mcp.WithDescription("Remove a context from the manager"),
 		destructiveAnnotation("Delete context"),
@@ -94,9 +134,19 @@ func listContextsHandler(cm kai.ClusterManager) func(ctx context.Context, reques
 		var result strings.Builder
 		result.WriteString("Available contexts:\n")
 
+		sessionContext := ""
+		if session, ok := kai.SessionFromContext(ctx); ok {
+			sessionContext = session.Context()
+		}
+
 		for _, contextInfo := range contexts {
+			active := contextInfo.IsActive
+			if sessionContext != "" {
+				active = contextInfo.Name == sessionContext
+			}
+
 			marker := " "
-			if contextInfo.IsActive {
+			if active {
 				marker = "*"
 			}
 
@@ -116,7 +166,7 @@ func listContextsHandler(cm kai.ClusterManager) func(ctx context.Context, reques
 func getCurrentContextHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		slog.Debug("tool invoked", slog.String("tool", "get_current_context"))
-		currentContext := cm.GetCurrentContext()
+		currentContext := kai.CurrentContext(ctx, cm)
 
 		if currentContext == "" {
 			return mcp.NewToolResultText("No active context"), nil
@@ -150,6 +200,15 @@ func switchContextHandler(cm kai.ClusterManager) func(ctx context.Context, reque
 			return mcp.NewToolResultText("Parameter 'name' must be a non-empty string"), nil
 		}
 
+		// Over HTTP the switch only applies to the calling session.
+		if session, ok := kai.SessionFromContext(ctx); ok {
+			if _, err := cm.GetClient(name); err != nil {
+				return mcp.NewToolResultText(fmt.Sprintf("Failed to switch context: %s", err.Error())), nil
+			}
+			session.SetContext(name)
+			return mcp.NewToolResultText(fmt.Sprintf("Switched to context '%s' for this session", name)), nil
+		}
+
 		if err := cm.SetCurrentContext(name); err != nil {
 			slog.Warn("failed to switch context", slog.String("context", name), slog.String("error", err.Error()))
 			return mcp.NewToolResultText(fmt.Sprintf("Failed to switch context: %s", err.Error())), nil
@@ -159,6 +218,76 @@ func switchContextHandler(cm kai.ClusterManager) func(ctx context.Context, reque
 	}
 }
 
+func setNamespaceHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "set_namespace"))
+		namespace, ok := request.GetArguments()["namespace"].(string)
+		if !ok || strings.TrimSpace(namespace) == "" {
+			return mcp.NewToolResultText("Required parameter 'namespace' is missing"), nil
+		}
+		namespace = strings.TrimSpace(namespace)
+
+		if session, ok := kai.SessionFromContext(ctx); ok {
+			session.SetNamespace(namespace)
+			return mcp.NewToolResultText(fmt.Sprintf("Default namespace set to '%s' for this session", namespace)), nil
+		}
+
+		cm.SetCurrentNamespace(namespace)
+		return mcp.NewToolResultText(fmt.Sprintf("Default namespace set to '%s'", namespace)), nil
+	}
+}
+
+func impersonateHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "impersonate"))
+
+		if !kai.ImpersonationAllowed(ctx) {
+			return mcp.NewToolResultText("Failed to impersonate: the impersonate tool is disabled on this server; start kai with -allow-impersonation to enable it"), nil
+		}
+
+		var imp kai.Impersonation
+		if user, ok := request.GetArguments()["user"].(string); ok {
+			imp.User = strings.TrimSpace(user)
+		}
+		if serviceAccount, ok := request.GetArguments()["service_account"].(string); ok {
+			imp.ServiceAccount = strings.TrimSpace(serviceAccount)
+		}
+		if groups, ok := request.GetArguments()["groups"].([]interface{}); ok {
+			for _, group := range groups {
+				if g, ok := group.(string); ok && strings.TrimSpace(g) != "" {
+					imp.Groups = append(imp.Groups, strings.TrimSpace(g))
+				}
+			}
+		}
+		if err := imp.Validate(); err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to impersonate: %s", err.Error())), nil
+		}
+
+		message := fmt.Sprintf("Now acting as %s", imp)
+		if imp.IsZero() {
+			message = "Stopped impersonating"
+		}
+
+		// Over HTTP the identity only applies to the calling session.
+		if session, ok := kai.SessionFromContext(ctx); ok {
+			// Sessions never change the manager's identity, so one set here
+			// came from the startup flags and must not be shed or replaced.
+			if fixed := cm.GetImpersonation(); !fixed.IsZero() {
+				return mcp.NewToolResultText(fmt.Sprintf("Failed to impersonate: requests act as %s, fixed at startup", fixed)), nil
+			}
+			session.SetImpersonation(imp)
+			slog.Info("session impersonation changed", slog.String("impersonate", imp.String()))
+			return mcp.NewToolResultText(message + " for this session"), nil
+		}
+
+		if err := cm.SetImpersonation(imp); err != nil {
+			slog.Warn("failed to impersonate", slog.String("impersonate", imp.String()), slog.String("error", err.Error()))
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to impersonate: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(message), nil
+	}
+}
+
 func loadKubeconfigHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		slog.Debug("tool invoked", slog.String("tool", "load_kubeconfig"))
@@ -177,6 +306,19 @@ func loadKubeconfigHandler(cm kai.ClusterManager) func(ctx context.Context, requ
 			path = pathArg
 		}
 
+		// A configured manifest root confines the kubeconfig like any other
+		// file a tool reads, so the default path is not used then.
+		if root, ok := kai.ManifestRootFromContext(ctx); ok {
+			resolved, err := kai.ResolveManifestPath(root, path)
+			if err != nil {
+				slog.Warn("kubeconfig path rejected", slog.String("context", name), slog.String("path", path), slog.String("error", err.Error()))
+				return mcp.NewToolResultText(fmt.Sprintf("Failed to load kubeconfig: %s", err.Error())), nil
+			}
+			path = resolved
+		}
+
+		existing := contextNames(cm)
+
 		if err := cm.LoadKubeConfig(name, path); err != nil {
 			slog.Warn("failed to load kubeconfig", slog.String("context", name), slog.String("path", path), slog.String("error", err.Error()))
 			return mcp.NewToolResultText(fmt.Sprintf("Failed to load kubeconfig: %s", err.Error())), nil
@@ -187,8 +329,69 @@ func loadKubeconfigHandler(cm kai.ClusterManager) func(ctx context.Context, requ
 			configPath = "~/.kube/config"
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully loaded kubeconfig from '%s' as context '%s'", configPath, name)), nil
+		result := fmt.Sprintf("Successfully loaded kubeconfig from '%s' as context '%s'", configPath, name)
+		result += registeredContexts(cm, existing)
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func loadKubeconfigInlineHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "load_kubeconfig_inline"))
+		name, ok := request.GetArguments()["name"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText("Required parameter 'name' is missing"), nil
+		}
+
+		kubeconfig, ok := request.GetArguments()["kubeconfig"].(string)
+		if !ok || kubeconfig == "" {
+			return mcp.NewToolResultText("Required parameter 'kubeconfig' is missing"), nil
+		}
+
+		existing := contextNames(cm)
+
+		// The content carries credentials, so only the name is logged.
+		if err := cm.LoadKubeConfigBytes(name, []byte(kubeconfig)); err != nil {
+			slog.Warn("failed to load inline kubeconfig", slog.String("context", name), slog.String("error", err.Error()))
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to load kubeconfig: %s", err.Error())), nil
+		}
+
+		result := fmt.Sprintf("Successfully loaded inline kubeconfig as context '%s'", name)
+		result += registeredContexts(cm, existing)
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+// contextNames returns the set of contexts registered before a load, so
+// registeredContexts can tell which ones the load added.
+func contextNames(cm kai.ClusterManager) map[string]bool {
+	names := make(map[string]bool)
+	for _, contextInfo := range cm.ListContexts() {
+		names[contextInfo.Name] = true
+	}
+	return names
+}
+
+// registeredContexts lists the contexts a load added. Each context of a
+// kubeconfig is registered as "<name>-<context>", so listing them tells the
+// caller what switch_context accepts.
+func registeredContexts(cm kai.ClusterManager, existing map[string]bool) string {
+	var added []string
+	for _, contextInfo := range cm.ListContexts() {
+		if existing[contextInfo.Name] {
+			continue
+		}
+		entry := contextInfo.Name
+		if contextInfo.IsActive {
+			entry += " (active)"
+		}
+		added = append(added, entry)
+	}
+	if len(added) == 0 {
+		return ""
 	}
+	return fmt.Sprintf("\nRegistered contexts: %s", strings.Join(added, ", "))
 }
 
 func deleteContextHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
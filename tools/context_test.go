@@ -3,6 +3,8 @@ package tools
 import (
 	"context"
 	"errors"
+	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/basebandit/kai"
@@ -10,6 +12,7 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestContextTools(t *testing.T) {
@@ -17,9 +20,13 @@ func TestContextTools(t *testing.T) {
 	t.Run("GetCurrentContext", testGetCurrentContextHandler)
 	t.Run("SwitchContext", testSwitchContextHandler)
 	t.Run("LoadKubeconfig", testLoadKubeconfigHandler)
+	t.Run("LoadKubeconfigManifestRoot", testLoadKubeconfigManifestRoot)
+	t.Run("LoadKubeconfigInline", testLoadKubeconfigInlineHandler)
 	t.Run("DeleteContext", testDeleteContextHandler)
 	t.Run("RenameContext", testRenameContextHandler)
 	t.Run("DescribeContext", testDescribeContextHandler)
+	t.Run("SetNamespace", testSetNamespaceHandler)
+	t.Run("SessionIsolation", testContextSessionIsolation)
 }
 
 func testListContextsHandler(t *testing.T) {
@@ -229,6 +236,7 @@ func testLoadKubeconfigHandler(t *testing.T) {
 			name: "SuccessfulLoadDefaultPath",
 			args: map[string]interface{}{"name": "test-context"},
 			setupMock: func(mockCM *testmocks.MockClusterManager) {
+				mockCM.On("ListContexts").Return([]*kai.ContextInfo{})
 				mockCM.On("LoadKubeConfig", "test-context", "").Return(nil)
 			},
 			expectedOutput: "Successfully loaded kubeconfig from '~/.kube/config' as context 'test-context'",
@@ -237,14 +245,31 @@ func testLoadKubeconfigHandler(t *testing.T) {
 			name: "SuccessfulLoadCustomPath",
 			args: map[string]interface{}{"name": "test-context", "path": "/custom/path/config"},
 			setupMock: func(mockCM *testmocks.MockClusterManager) {
+				mockCM.On("ListContexts").Return([]*kai.ContextInfo{})
 				mockCM.On("LoadKubeConfig", "test-context", "/custom/path/config").Return(nil)
 			},
 			expectedOutput: "Successfully loaded kubeconfig from '/custom/path/config' as context 'test-context'",
 		},
+		{
+			name: "ListsRegisteredContexts",
+			args: map[string]interface{}{"name": "staging", "path": "/custom/path/config"},
+			setupMock: func(mockCM *testmocks.MockClusterManager) {
+				prod := &kai.ContextInfo{Name: "prod-admin", IsActive: true}
+				mockCM.On("ListContexts").Return([]*kai.ContextInfo{prod}).Once()
+				mockCM.On("LoadKubeConfig", "staging", "/custom/path/config").Return(nil)
+				mockCM.On("ListContexts").Return([]*kai.ContextInfo{
+					prod,
+					{Name: "staging-admin"},
+					{Name: "staging-readonly"},
+				}).Once()
+			},
+			expectedOutput: "Successfully loaded kubeconfig from '/custom/path/config' as context 'staging'\nRegistered contexts: staging-admin, staging-readonly",
+		},
 		{
 			name: "LoadError",
 			args: map[string]interface{}{"name": "test-context"},
 			setupMock: func(mockCM *testmocks.MockClusterManager) {
+				mockCM.On("ListContexts").Return([]*kai.ContextInfo{})
 				mockCM.On("LoadKubeConfig", "test-context", "").Return(errors.New("file not found"))
 			},
 			expectedOutput: "Failed to load kubeconfig: file not found",
@@ -272,6 +297,102 @@ func testLoadKubeconfigHandler(t *testing.T) {
 	}
 }
 
+func testLoadKubeconfigManifestRoot(t *testing.T) {
+	root := t.TempDir()
+	ctx := kai.ContextWithManifestRoot(context.Background(), root)
+
+	t.Run("WithinRoot", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("ListContexts").Return([]*kai.ContextInfo{})
+		mockCM.On("LoadKubeConfig", "staging", filepath.Join(root, "staging.yaml")).Return(nil)
+
+		result, err := loadKubeconfigHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"name": "staging", "path": "staging.yaml"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("Successfully loaded kubeconfig from '%s' as context 'staging'", filepath.Join(root, "staging.yaml")), resultText(t, result))
+		mockCM.AssertExpectations(t)
+	})
+
+	for name, path := range map[string]string{
+		"Traversal": "../../etc/kubernetes/admin.conf",
+		"Absolute":  "/etc/kubernetes/admin.conf",
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+
+			result, err := loadKubeconfigHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"name": "stolen", "path": path}))
+			assert.NoError(t, err)
+			assert.Contains(t, resultText(t, result), "Failed to load kubeconfig: ")
+			assert.Contains(t, resultText(t, result), kai.ErrPathOutsideRoot.Error())
+			mockCM.AssertNotCalled(t, "LoadKubeConfig", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("DefaultPath", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+
+		result, err := loadKubeconfigHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"name": "local"}))
+		assert.NoError(t, err)
+		assert.Equal(t, "Failed to load kubeconfig: path is required", resultText(t, result))
+		mockCM.AssertNotCalled(t, "LoadKubeConfig", mock.Anything, mock.Anything)
+	})
+}
+
+func testLoadKubeconfigInlineHandler(t *testing.T) {
+	const kubeconfig = "apiVersion: v1\nkind: Config\n"
+
+	tests := []struct {
+		name           string
+		args           map[string]interface{}
+		setupMock      func(*testmocks.MockClusterManager)
+		expectedOutput string
+	}{
+		{
+			name:           "MissingName",
+			args:           map[string]interface{}{"kubeconfig": kubeconfig},
+			setupMock:      func(mockCM *testmocks.MockClusterManager) {},
+			expectedOutput: "Required parameter 'name' is missing",
+		},
+		{
+			name:           "MissingKubeconfig",
+			args:           map[string]interface{}{"name": "ci"},
+			setupMock:      func(mockCM *testmocks.MockClusterManager) {},
+			expectedOutput: "Required parameter 'kubeconfig' is missing",
+		},
+		{
+			name: "ListsRegisteredContexts",
+			args: map[string]interface{}{"name": "ci", "kubeconfig": kubeconfig},
+			setupMock: func(mockCM *testmocks.MockClusterManager) {
+				mockCM.On("ListContexts").Return([]*kai.ContextInfo{}).Once()
+				mockCM.On("LoadKubeConfigBytes", "ci", []byte(kubeconfig)).Return(nil)
+				mockCM.On("ListContexts").Return([]*kai.ContextInfo{{Name: "ci-kind", IsActive: true}}).Once()
+			},
+			expectedOutput: "Successfully loaded inline kubeconfig as context 'ci'\nRegistered contexts: ci-kind (active)",
+		},
+		{
+			name: "LoadError",
+			args: map[string]interface{}{"name": "ci", "kubeconfig": "not: [yaml"},
+			setupMock: func(mockCM *testmocks.MockClusterManager) {
+				mockCM.On("ListContexts").Return([]*kai.ContextInfo{})
+				mockCM.On("LoadKubeConfigBytes", "ci", []byte("not: [yaml")).Return(errors.New("error parsing kubeconfig"))
+			},
+			expectedOutput: "Failed to load kubeconfig: error parsing kubeconfig",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			tt.setupMock(mockCM)
+
+			result, err := loadKubeconfigInlineHandler(mockCM)(context.Background(), toolRequest(tt.args))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOutput, resultText(t, result))
+			mockCM.AssertExpectations(t)
+		})
+	}
+}
+
 func testDeleteContextHandler(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -484,11 +605,151 @@ func testDescribeContextHandler(t *testing.T) {
 	}
 }
 
+func testSetNamespaceHandler(t *testing.T) {
+	t.Run("MissingNamespace", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		result, err := setNamespaceHandler(mockCM)(context.Background(), toolRequest(map[string]interface{}{"namespace": " "}))
+		assert.NoError(t, err)
+		assert.Equal(t, "Required parameter 'namespace' is missing", resultText(t, result))
+	})
+
+	t.Run("GlobalWithoutSession", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("SetCurrentNamespace", "team-a").Return()
+
+		result, err := setNamespaceHandler(mockCM)(context.Background(), toolRequest(map[string]interface{}{"namespace": "team-a"}))
+		assert.NoError(t, err)
+		assert.Equal(t, "Default namespace set to 'team-a'", resultText(t, result))
+		mockCM.AssertExpectations(t)
+	})
+}
+
+func testContextSessionIsolation(t *testing.T) {
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+	mockCM.On("GetClient", "staging").Return(fake.NewSimpleClientset(), nil)
+
+	ctxA := kai.WithSession(context.Background(), &kai.Session{})
+	ctxB := kai.WithSession(context.Background(), &kai.Session{})
+
+	result, err := setNamespaceHandler(mockCM)(ctxA, toolRequest(map[string]interface{}{"namespace": "team-a"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "Default namespace set to 'team-a' for this session", resultText(t, result))
+
+	result, err = setNamespaceHandler(mockCM)(ctxB, toolRequest(map[string]interface{}{"namespace": "team-b"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "Default namespace set to 'team-b' for this session", resultText(t, result))
+
+	result, err = switchContextHandler(mockCM)(ctxA, toolRequest(map[string]interface{}{"name": "staging"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "Switched to context 'staging' for this session", resultText(t, result))
+
+	assert.Equal(t, "team-a", kai.CurrentNamespace(ctxA, mockCM))
+	assert.Equal(t, "team-b", kai.CurrentNamespace(ctxB, mockCM))
+	assert.Equal(t, defaultNamespace, kai.CurrentNamespace(context.Background(), mockCM))
+	assert.Equal(t, "override", kai.CurrentNamespace(kai.WithNamespace(ctxA, "override"), mockCM))
+
+	sessionA, _ := kai.SessionFromContext(ctxA)
+	sessionB, _ := kai.SessionFromContext(ctxB)
+	assert.Equal(t, "staging", sessionA.Context())
+	assert.Empty(t, sessionB.Context())
+
+	_, err = kai.CurrentClient(ctxA, mockCM)
+	assert.NoError(t, err)
+	mockCM.AssertCalled(t, "GetClient", "staging")
+
+	mockCM.AssertNotCalled(t, "SetCurrentNamespace", mock.Anything)
+	mockCM.AssertNotCalled(t, "SetCurrentContext", mock.Anything)
+}
+
+func TestImpersonateHandler(t *testing.T) {
+	allowed := kai.WithImpersonationAllowed(context.Background())
+
+	t.Run("Stdio", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		imp := kai.Impersonation{User: "jane", Groups: []string{"dev", "qa"}}
+		mockCM.On("SetImpersonation", imp).Return(nil)
+
+		result, err := impersonateHandler(mockCM)(allowed, toolRequest(map[string]interface{}{
+			"user":   "jane",
+			"groups": []interface{}{"dev", " qa "},
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, "Now acting as jane (groups: dev, qa)", resultText(t, result))
+		mockCM.AssertExpectations(t)
+	})
+
+	t.Run("Session", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetImpersonation").Return(kai.Impersonation{})
+		session := &kai.Session{}
+		ctx := kai.WithSession(allowed, session)
+
+		result, err := impersonateHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"service_account": "team-a/deployer"}))
+		assert.NoError(t, err)
+		assert.Equal(t, "Now acting as system:serviceaccount:team-a:deployer for this session", resultText(t, result))
+		assert.Equal(t, "team-a/deployer", session.Impersonation().ServiceAccount)
+
+		result, err = impersonateHandler(mockCM)(ctx, toolRequest(nil))
+		assert.NoError(t, err)
+		assert.Equal(t, "Stopped impersonating for this session", resultText(t, result))
+		assert.True(t, session.Impersonation().IsZero())
+		mockCM.AssertNotCalled(t, "SetImpersonation", mock.Anything)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		result, err := impersonateHandler(mockCM)(allowed, toolRequest(map[string]interface{}{"service_account": "deployer"}))
+		assert.NoError(t, err)
+		assert.Contains(t, resultText(t, result), "must be given as namespace/name")
+	})
+
+	t.Run("DisabledOverStdio", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		result, err := impersonateHandler(mockCM)(context.Background(), toolRequest(nil))
+		assert.NoError(t, err)
+		assert.Contains(t, resultText(t, result), "impersonate tool is disabled")
+		mockCM.AssertNotCalled(t, "SetImpersonation", mock.Anything)
+	})
+
+	t.Run("DisabledOverHTTP", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		session := &kai.Session{}
+		ctx := kai.WithSession(context.Background(), session)
+
+		result, err := impersonateHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"user": "cluster-admin"}))
+		assert.NoError(t, err)
+		assert.Contains(t, resultText(t, result), "impersonate tool is disabled")
+		assert.True(t, session.Impersonation().IsZero())
+	})
+
+	t.Run("FixedAtStartupOverStdio", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("SetImpersonation", kai.Impersonation{}).Return(errors.New("requests act as system:serviceaccount:team-a:deployer, fixed at startup"))
+
+		result, err := impersonateHandler(mockCM)(allowed, toolRequest(nil))
+		assert.NoError(t, err)
+		assert.Equal(t, "Failed to impersonate: requests act as system:serviceaccount:team-a:deployer, fixed at startup", resultText(t, result))
+	})
+
+	t.Run("FixedAtStartupOverHTTP", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetImpersonation").Return(kai.Impersonation{ServiceAccount: "team-a/deployer"})
+		session := &kai.Session{}
+		ctx := kai.WithSession(allowed, session)
+
+		result, err := impersonateHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"user": "cluster-admin"}))
+		assert.NoError(t, err)
+		assert.Equal(t, "Failed to impersonate: requests act as system:serviceaccount:team-a:deployer, fixed at startup", resultText(t, result))
+		assert.True(t, session.Impersonation().IsZero())
+	})
+}
+
 func TestRegisterContextTools(t *testing.T) {
 	mockServer := &testmocks.MockServer{}
 	mockCM := testmocks.NewMockClusterManager()
 
-	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(7)
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(10)
 
 	RegisterContextTools(mockServer, mockCM)
 
@@ -8,6 +8,9 @@ import (
 	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/cluster"
 	"github.com/mark3labs/mcp-go/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 )
 
 // CronJobFactory is an interface for creating CronJob operators.
@@ -43,6 +46,8 @@ func (f *DefaultCronJobFactory) NewCronJob(params kai.CronJobParams) kai.CronJob
 		Env:                        params.Env,
 		ImagePullPolicy:            params.ImagePullPolicy,
 		ImagePullSecrets:           params.ImagePullSecrets,
+		DryRun:                     params.DryRun,
+		ShowDiff:                   params.ShowDiff,
 	}
 }
 
@@ -111,6 +116,8 @@ func RegisterCronJobToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManage
 		mcp.WithArray("image_pull_secrets",
 			mcp.Description("Image pull secrets for private registries"),
 		),
+		verifyImageOption(),
+		dryRunOption(),
 	)
 	s.AddTool(createCronJobTool, createCronJobHandler(cm, factory))
 
@@ -124,6 +131,7 @@ func RegisterCronJobToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManage
 		mcp.WithString("namespace",
 			mcp.Description("Namespace of the CronJob (defaults to current namespace)"),
 		),
+		outputOption(),
 	)
 	s.AddTool(getCronJobTool, getCronJobHandler(cm, factory))
 
@@ -133,12 +141,14 @@ func RegisterCronJobToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManage
 		mcp.WithBoolean("all_namespaces",
 			mcp.Description("Whether to list CronJobs across all namespaces"),
 		),
+		confirmScanOption(),
 		mcp.WithString("namespace",
 			mcp.Description("Specific namespace to list CronJobs from (defaults to current namespace)"),
 		),
 		mcp.WithString("label_selector",
 			mcp.Description("Label selector to filter CronJobs (e.g., 'app=nginx,env=prod')"),
 		),
+		outputOption(),
 	)
 	s.AddTool(listCronJobsTool, listCronJobsHandler(cm, factory))
 
@@ -180,6 +190,8 @@ func RegisterCronJobToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManage
 		mcp.WithNumber("failed_jobs_history_limit",
 			mcp.Description("Number of failed jobs to retain"),
 		),
+		dryRunOption(),
+		showDiffOption(),
 	)
 	s.AddTool(updateCronJobTool, updateCronJobHandler(cm, factory))
 
@@ -224,6 +236,10 @@ func createCronJobHandler(cm kai.ClusterManager, factory CronJobFactory) func(ct
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
+		if err := validateResourceName(name); err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
 		scheduleArg, ok := request.GetArguments()["schedule"]
 		if !ok || scheduleArg == nil {
 			return mcp.NewToolResultText("schedule is required"), nil
@@ -244,7 +260,7 @@ func createCronJobHandler(cm kai.ClusterManager, factory CronJobFactory) func(ct
 			return mcp.NewToolResultText(errEmptyImage), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -297,6 +313,9 @@ func createCronJobHandler(cm kai.ClusterManager, factory CronJobFactory) func(ct
 		}
 
 		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			params.Labels = labelsArg
 		}
 
@@ -312,6 +331,15 @@ func createCronJobHandler(cm kai.ClusterManager, factory CronJobFactory) func(ct
 			params.ImagePullSecrets = imagePullSecretsArg
 		}
 
+		if err := ensureNamespace(ctx, cm, namespace); err != nil {
+			return mcp.NewToolResultText(classifyError(err)), nil
+		}
+
+		if result := checkImage(ctx, cm, request, params.Namespace, params.Image, params.ImagePullSecrets); result != nil {
+			return result, nil
+		}
+
+		params.DryRun = dryRunArg(request)
 		cronJob := factory.NewCronJob(params)
 		result, err := cronJob.Create(ctx, cm)
 		if err != nil {
@@ -320,10 +348,10 @@ func createCronJobHandler(cm kai.ClusterManager, factory CronJobFactory) func(ct
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to create CronJob: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to create CronJob: %s", classifyError(err))), nil
 		}
 
-		return mcp.NewToolResultText(result), nil
+		return mcp.NewToolResultText(result + imageReferenceNotes(params.Image, params.ImagePullPolicy, nil)), nil
 	}
 }
 
@@ -341,11 +369,21 @@ func getCronJobHandler(cm kai.ClusterManager, factory CronJobFactory) func(ctx c
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
 
+		format, errResult := outputFormat(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if format != outputText {
+			return structuredResult(ctx, cm, format, func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error) {
+				return client.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+			}), nil
+		}
+
 		params := kai.CronJobParams{
 			Name:      name,
 			Namespace: namespace,
@@ -359,7 +397,7 @@ func getCronJobHandler(cm kai.ClusterManager, factory CronJobFactory) func(ctx c
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to get CronJob: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get CronJob: %s", classifyError(err))), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -375,12 +413,18 @@ func listCronJobsHandler(cm kai.ClusterManager, factory CronJobFactory) func(ctx
 			allNamespaces = allNamespacesArg
 		}
 
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
+
 		var namespace string
 		if !allNamespaces {
 			if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 				namespace = namespaceArg
 			} else {
-				namespace = cm.GetCurrentNamespace()
+				namespace = kai.CurrentNamespace(ctx, cm)
 			}
 		}
 
@@ -389,6 +433,16 @@ func listCronJobsHandler(cm kai.ClusterManager, factory CronJobFactory) func(ctx
 			labelSelector = labelSelectorArg
 		}
 
+		format, errResult := outputFormat(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if format != outputText {
+			return structuredResult(ctx, cm, format, func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error) {
+				return client.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+			}), nil
+		}
+
 		params := kai.CronJobParams{
 			Namespace: namespace,
 		}
@@ -423,7 +477,7 @@ func deleteCronJobHandler(cm kai.ClusterManager, factory CronJobFactory) func(ct
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -441,7 +495,7 @@ func deleteCronJobHandler(cm kai.ClusterManager, factory CronJobFactory) func(ct
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete CronJob: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete CronJob: %s", classifyError(err))), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -460,7 +514,7 @@ func updateCronJobHandler(cm kai.ClusterManager, factory CronJobFactory) func(ct
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -475,6 +529,9 @@ func updateCronJobHandler(cm kai.ClusterManager, factory CronJobFactory) func(ct
 		}
 
 		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			params.Labels = labelsArg
 		}
 
@@ -492,6 +549,8 @@ func updateCronJobHandler(cm kai.ClusterManager, factory CronJobFactory) func(ct
 			params.FailedJobsHistoryLimit = &limit
 		}
 
+		params.DryRun = dryRunArg(request)
+		params.ShowDiff = showDiffArg(request)
 		cronJob := factory.NewCronJob(params)
 		result, err := cronJob.Update(ctx, cm)
 		if err != nil {
@@ -514,7 +573,7 @@ func suspendCronJobHandler(cm kai.ClusterManager, factory CronJobFactory) func(c
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -546,7 +605,7 @@ func resumeCronJobHandler(cm kai.ClusterManager, factory CronJobFactory) func(ct
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -163,6 +163,7 @@ func TestCreateCronJobHandler(t *testing.T) {
 			mockCronJob := &testmocks.MockCronJob{}
 			tt.mockSetup(mockCM, mockFactory, mockCronJob)
 
+			stubNamespaceCheck(mockCM)
 			handler := createCronJobHandler(mockCM, mockFactory)
 			request := mcp.CallToolRequest{
 				Params: mcp.CallToolParams{
@@ -486,6 +487,7 @@ func TestCreateCronJobHandlerError(t *testing.T) {
 	mockFactory.On("NewCronJob", mock.Anything).Return(mockCronJob)
 	mockCronJob.On("Create", mock.Anything, mockCM).Return("", assert.AnError)
 
+	stubNamespaceCheck(mockCM)
 	handler := createCronJobHandler(mockCM, mockFactory)
 	request := mcp.CallToolRequest{
 		Params: mcp.CallToolParams{
@@ -599,6 +601,7 @@ func TestCreateCronJobHandlerDefaultNamespace(t *testing.T) {
 	})).Return(mockCronJob)
 	mockCronJob.On("Create", mock.Anything, mockCM).Return("CronJob \"test-cronjob\" created successfully", nil)
 
+	stubNamespaceCheck(mockCM)
 	handler := createCronJobHandler(mockCM, mockFactory)
 	request := mcp.CallToolRequest{
 		Params: mcp.CallToolParams{
@@ -620,6 +623,40 @@ func TestCreateCronJobHandlerDefaultNamespace(t *testing.T) {
 	mockCronJob.AssertExpectations(t)
 }
 
+func TestCreateCronJobHandlerEmptyCurrentNamespace(t *testing.T) {
+	mockCM := &testmocks.MockClusterManager{}
+	mockFactory := &testmocks.MockCronJobFactory{}
+	mockCronJob := &testmocks.MockCronJob{}
+
+	// A kubeconfig context without a namespace leaves the current namespace
+	// empty; creates must still land in "default".
+	mockCM.On("GetCurrentNamespace").Return("")
+	mockFactory.On("NewCronJob", mock.MatchedBy(func(params kai.CronJobParams) bool {
+		return params.Name == "test-cronjob" && params.Namespace == defaultNamespace
+	})).Return(mockCronJob)
+	mockCronJob.On("Create", mock.Anything, mockCM).Return("CronJob \"test-cronjob\" created successfully", nil)
+
+	stubNamespaceCheck(mockCM)
+	handler := createCronJobHandler(mockCM, mockFactory)
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]any{
+				"name":     "test-cronjob",
+				"schedule": "*/5 * * * *",
+				"image":    "busybox:latest",
+			},
+		},
+	}
+
+	result, err := handler(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "created successfully")
+
+	mockCM.AssertExpectations(t)
+	mockFactory.AssertExpectations(t)
+	mockCronJob.AssertExpectations(t)
+}
+
 func TestGetCronJobHandlerDefaultNamespace(t *testing.T) {
 	mockCM := &testmocks.MockClusterManager{}
 	mockFactory := &testmocks.MockCronJobFactory{}
@@ -13,8 +13,9 @@ import (
 // RegisterCustomResourceTools registers CRD, custom resource and API discovery tools.
 func RegisterCustomResourceTools(s kai.ServerInterface, cm kai.ClusterManager) {
 	s.AddTool(mcp.NewTool("list_crds",
-		mcp.WithDescription("List all CustomResourceDefinitions registered in the cluster"),
+		mcp.WithDescription("List CustomResourceDefinitions registered in the cluster with their group, served versions and scope"),
 		readOnlyAnnotation("List CRDs"),
+		mcp.WithString("group", mcp.Description("Only list CRDs in this API group (e.g. 'cert-manager.io')")),
 	), listCRDsHandler(cm))
 
 	s.AddTool(mcp.NewTool("get_crd",
@@ -31,6 +32,7 @@ func RegisterCustomResourceTools(s kai.ServerInterface, cm kai.ClusterManager) {
 		mcp.WithString("resource", mcp.Required(), mcp.Description("Plural resource name (e.g. 'widgets')")),
 		mcp.WithString("namespace", mcp.Description("Namespace (defaults to current; ignored for cluster-scoped)")),
 		mcp.WithBoolean("all_namespaces", mcp.Description("List across all namespaces")),
+		confirmScanOption(),
 	), listCustomResourcesHandler(cm))
 
 	s.AddTool(mcp.NewTool("get_custom_resource",
@@ -63,6 +65,9 @@ func listCRDsHandler(cm kai.ClusterManager) func(ctx context.Context, request mc
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		slog.Debug("tool invoked", slog.String("tool", "list_crds"))
 		cr := cluster.CustomResource{}
+		if group, ok := request.GetArguments()["group"].(string); ok {
+			cr.Group = group
+		}
 		result, err := cr.ListCRDs(ctx, cm)
 		if err != nil {
 			return mcp.NewToolResultText(fmt.Sprintf("Failed to list CRDs: %s", err.Error())), nil
@@ -118,6 +123,11 @@ func listCustomResourcesHandler(cm kai.ClusterManager) func(ctx context.Context,
 		if all, ok := request.GetArguments()["all_namespaces"].(bool); ok {
 			allNamespaces = all
 		}
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
 		result, err := cr.List(ctx, cm, allNamespaces)
 		if err != nil {
 			return mcp.NewToolResultText(fmt.Sprintf("Failed to list custom resources: %s", err.Error())), nil
@@ -140,7 +150,7 @@ func getCustomResourceHandler(cm kai.ClusterManager) func(ctx context.Context, r
 		cr.Name = name
 		result, err := cr.Get(ctx, cm)
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to get custom resource: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get custom resource: %s", classifyError(err))), nil
 		}
 		return mcp.NewToolResultText(result), nil
 	}
@@ -160,7 +170,7 @@ func deleteCustomResourceHandler(cm kai.ClusterManager) func(ctx context.Context
 		cr.Name = name
 		result, err := cr.Delete(ctx, cm)
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete custom resource: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete custom resource: %s", classifyError(err))), nil
 		}
 		return mcp.NewToolResultText(result), nil
 	}
@@ -7,6 +7,8 @@ import (
 	"github.com/basebandit/kai/testmocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -56,13 +58,29 @@ func TestCustomResourceHandlers(t *testing.T) {
 	_, err = dyn.Resource(widgetGVRTest).Namespace(defaultNamespace).Create(ctx, widget, metav1.CreateOptions{})
 	assert.NoError(t, err)
 
+	apiext := apiextensionsfake.NewSimpleClientset(&apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group:    "example.com",
+			Scope:    apiextensionsv1.NamespaceScoped,
+			Names:    apiextensionsv1.CustomResourceDefinitionNames{Kind: "Widget", Plural: "widgets"},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1", Served: true, Storage: true}},
+		},
+	})
+
 	mockCM := testmocks.NewMockClusterManager()
 	mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+	mockCM.On("GetCurrentAPIExtensionsClient").Return(apiext, nil)
 	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
 
 	r, err := listCRDsHandler(mockCM)(ctx, toolRequest(nil))
 	assert.NoError(t, err)
 	assert.Contains(t, resultText(t, r), "widgets.example.com")
+	assert.Contains(t, resultText(t, r), "versions: v1")
+
+	r, err = listCRDsHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"group": "other.io"}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "No custom resource definitions found")
 
 	r, err = getCRDHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"name": "widgets.example.com"}))
 	assert.NoError(t, err)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/cluster"
@@ -11,7 +12,8 @@ import (
 )
 
 // RegisterDeleteTools registers the delete_yaml tool for deleting resources from
-// a raw manifest.
+// a raw manifest, delete_resource for deleting a single object by kind and
+// name, and prune_by_label for deleting everything carrying a label.
 func RegisterDeleteTools(s kai.ServerInterface, cm kai.ClusterManager) {
 	s.AddTool(mcp.NewTool(
 		"delete_yaml",
@@ -21,6 +23,37 @@ func RegisterDeleteTools(s kai.ServerInterface, cm kai.ClusterManager) {
 			mcp.Description("Raw YAML/JSON manifest text identifying the resources to delete.")),
 		mcp.WithString("namespace", mcp.Description("Default namespace for namespaced objects that omit metadata.namespace. Ignored for cluster-scoped kinds.")),
 	), deleteYAMLHandler(cm))
+
+	s.AddTool(mcp.NewTool(
+		"delete_resource",
+		mcp.WithDescription("Delete a single resource of any kind, including custom resources, by kind and name (like `kubectl delete <kind> <name>`)."),
+		destructiveAnnotation("Delete resource"),
+		mcp.WithString("kind", mcp.Required(),
+			mcp.Description("Kind (e.g. 'Deployment') or plural resource name (e.g. 'deployments') of the object")),
+		mcp.WithString("name", mcp.Required(),
+			mcp.Description("Name of the object to delete")),
+		mcp.WithString("group", mcp.Description("API group of the kind (empty for the core group)")),
+		mcp.WithString("version", mcp.Description("API version of the kind (defaults to the preferred version)")),
+		mcp.WithString("namespace", mcp.Description("Namespace of the object (defaults to current namespace). Ignored for cluster-scoped kinds.")),
+		mcp.WithBoolean("ignore_not_found", mcp.Description("Report success if the object does not exist")),
+		mcp.WithNumber("grace_period_seconds", mcp.Description("Seconds to wait before the object is deleted; 0 deletes immediately")),
+		mcp.WithString("propagation", mcp.Description("Dependent deletion policy: Foreground, Background or Orphan")),
+	), deleteResourceHandler(cm))
+
+	s.AddTool(mcp.NewTool(
+		"prune_by_label",
+		mcp.WithDescription("Delete every object of the given kinds in a namespace that matches a label selector, as GitOps pruning does. Requires confirm=true unless dry_run lists the matches first."),
+		destructiveAnnotation("Prune by label"),
+		mcp.WithString("label_selector", mcp.Required(),
+			mcp.Description("Label selector the objects must match (e.g. 'app.kubernetes.io/instance=demo'); an empty selector is rejected")),
+		mcp.WithArray("kinds", mcp.Required(),
+			mcp.Description("Namespaced kinds or plural resource names to prune (e.g. ['Deployment', 'services'])"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithString("namespace", mcp.Description("Namespace to prune (defaults to current namespace)")),
+		mcp.WithBoolean("dry_run", mcp.Description("List the objects that would be deleted without deleting them")),
+		mcp.WithBoolean("confirm", mcp.Description("Must be true to delete; not needed with dry_run")),
+	), pruneByLabelHandler(cm))
 }
 
 func deleteYAMLHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -44,3 +77,88 @@ func deleteYAMLHandler(cm kai.ClusterManager) func(ctx context.Context, request
 		return mcp.NewToolResultText(result), nil
 	}
 }
+
+func deleteResourceHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "delete_resource"))
+
+		kind, ok := request.GetArguments()["kind"].(string)
+		if !ok || kind == "" {
+			return mcp.NewToolResultText("Required parameter 'kind' is missing"), nil
+		}
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		del := cluster.DeleteResource{Kind: kind, Name: name}
+		if group, ok := request.GetArguments()["group"].(string); ok {
+			del.Group = group
+		}
+		if version, ok := request.GetArguments()["version"].(string); ok {
+			del.Version = version
+		}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			del.Namespace = ns
+		}
+		if ignore, ok := request.GetArguments()["ignore_not_found"].(bool); ok {
+			del.IgnoreNotFound = ignore
+		}
+		if grace, ok := request.GetArguments()["grace_period_seconds"].(float64); ok {
+			seconds := int64(grace)
+			del.GracePeriodSeconds = &seconds
+		}
+		if propagation, ok := request.GetArguments()["propagation"].(string); ok {
+			del.Propagation = propagation
+		}
+
+		result, err := del.Run(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("failed to delete resource: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func pruneByLabelHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "prune_by_label"))
+
+		selector, ok := request.GetArguments()["label_selector"].(string)
+		if !ok || selector == "" {
+			return mcp.NewToolResultText("Required parameter 'label_selector' is missing"), nil
+		}
+
+		prune := cluster.PruneByLabel{LabelSelector: selector}
+		if kinds, ok := request.GetArguments()["kinds"].([]interface{}); ok {
+			for _, k := range kinds {
+				if kind, ok := k.(string); ok && kind != "" {
+					prune.Kinds = append(prune.Kinds, kind)
+				}
+			}
+		}
+		if len(prune.Kinds) == 0 {
+			return mcp.NewToolResultText("Required parameter 'kinds' is missing"), nil
+		}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			prune.Namespace = ns
+		}
+		if dryRun, ok := request.GetArguments()["dry_run"].(bool); ok {
+			prune.DryRun = dryRun
+		}
+
+		if confirm, _ := request.GetArguments()["confirm"].(bool); !confirm && !prune.DryRun {
+			return mcp.NewToolResultText(fmt.Sprintf("Pruning deletes every %s matching %q; set confirm=true to proceed, or dry_run=true to preview", strings.Join(prune.Kinds, ", "), selector)), nil
+		}
+
+		result, err := prune.Run(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to prune by label",
+				slog.String("label_selector", selector),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("failed to prune: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
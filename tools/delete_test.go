@@ -19,7 +19,7 @@ func TestRegisterDeleteTools(t *testing.T) {
 	mockServer := &testmocks.MockServer{}
 	mockCM := testmocks.NewMockClusterManager()
 	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"),
-		mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(1)
+		mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(3)
 	RegisterDeleteTools(mockServer, mockCM)
 	mockServer.AssertExpectations(t)
 }
@@ -61,3 +61,119 @@ metadata:
 	assert.NoError(t, err)
 	assert.Contains(t, resultText(t, r), "manifest")
 }
+
+func TestDeleteResourceHandler(t *testing.T) {
+	ctx := context.Background()
+
+	fakeClient := fake.NewSimpleClientset()
+	fakeClient.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Name: "configmaps", Namespaced: true, Kind: "ConfigMap"}},
+	}}
+	cmGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	listKinds := map[schema.GroupVersionResource]string{cmGVR: "ConfigMapList"}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+	_, err := dyn.Resource(cmGVR).Namespace(testNamespace).Create(ctx, &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "cm1", "namespace": testNamespace},
+	}}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+
+	r, err := deleteResourceHandler(mockCM)(ctx, toolRequest(map[string]interface{}{
+		"kind":                 "ConfigMap",
+		"name":                 "cm1",
+		"namespace":            testNamespace,
+		"grace_period_seconds": float64(0),
+		"propagation":          "Background",
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "ConfigMap test-namespace/cm1 deleted")
+
+	// Missing kind argument.
+	r, err = deleteResourceHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"name": "cm1"}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "kind")
+
+	// Missing name argument.
+	r, err = deleteResourceHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"kind": "ConfigMap"}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), errMissingName)
+}
+
+func TestPruneByLabelHandler(t *testing.T) {
+	ctx := context.Background()
+
+	fakeClient := fake.NewSimpleClientset()
+	fakeClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{Name: "services", Namespaced: true, Kind: "Service"}},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{{Name: "deployments", Namespaced: true, Kind: "Deployment"}},
+		},
+	}
+	deployGVR := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	svcGVR := schema.GroupVersionResource{Version: "v1", Resource: "services"}
+	listKinds := map[schema.GroupVersionResource]string{deployGVR: "DeploymentList", svcGVR: "ServiceList"}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+	for gvr, kind := range map[schema.GroupVersionResource]string{deployGVR: "Deployment", svcGVR: "Service"} {
+		_, err := dyn.Resource(gvr).Namespace(testNamespace).Create(ctx, &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": gvr.GroupVersion().String(),
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name":      "web",
+				"namespace": testNamespace,
+				"labels":    map[string]interface{}{"app": "web"},
+			},
+		}}, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+
+	args := func(extra map[string]interface{}) map[string]interface{} {
+		a := map[string]interface{}{
+			"label_selector": "app=web",
+			"kinds":          []interface{}{"Deployment", "Service"},
+			"namespace":      testNamespace,
+		}
+		for k, v := range extra {
+			a[k] = v
+		}
+		return a
+	}
+
+	// Without confirm nothing is deleted.
+	r, err := pruneByLabelHandler(mockCM)(ctx, toolRequest(args(nil)))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "set confirm=true to proceed")
+
+	r, err = pruneByLabelHandler(mockCM)(ctx, toolRequest(args(map[string]interface{}{"dry_run": true})))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "Dry run: 2 object(s)")
+
+	r, err = pruneByLabelHandler(mockCM)(ctx, toolRequest(args(map[string]interface{}{"confirm": true})))
+	assert.NoError(t, err)
+	text := resultText(t, r)
+	assert.Contains(t, text, "Pruned 2 object(s)")
+	assert.Contains(t, text, "• Deployment web deleted")
+	assert.Contains(t, text, "• Service web deleted")
+
+	// Missing arguments.
+	r, err = pruneByLabelHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"kinds": []interface{}{"Service"}}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "label_selector")
+
+	r, err = pruneByLabelHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"label_selector": "app=web"}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "kinds")
+}
@@ -2,11 +2,16 @@ package tools
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"math"
 
 	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/cluster"
 	"github.com/mark3labs/mcp-go/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 )
 
 // DeploymentFactory is an interface for creating deployment operators
@@ -34,6 +39,12 @@ func (f *DefaultDeploymentFactory) NewDeployment(params kai.DeploymentParams) ka
 		Env:              params.Env,
 		ImagePullPolicy:  params.ImagePullPolicy,
 		ImagePullSecrets: params.ImagePullSecrets,
+		Requests:         params.Requests,
+		Limits:           params.Limits,
+		CheckQuota:       params.CheckQuota,
+		Containers:       params.Containers,
+		DryRun:           params.DryRun,
+		ShowDiff:         params.ShowDiff,
 	}
 }
 
@@ -51,12 +62,16 @@ func RegisterDeploymentToolsWithFactory(s kai.ServerInterface, cm kai.ClusterMan
 		mcp.WithBoolean("all_namespaces",
 			mcp.Description("Whether to list deployments across all namespaces"),
 		),
+		confirmScanOption(),
 		mcp.WithString("namespace",
 			mcp.Description("Specific namespace to list deployments from (defaults to current namespace)"),
 		),
 		mcp.WithString("label_selector",
 			mcp.Description("Label selector to filter deployments"),
 		),
+		limitOption("deployments"),
+		continueOption(),
+		outputOption(),
 	)
 
 	s.AddTool(listDeploymentTool, listDeploymentsHandler(cm, factory))
@@ -86,9 +101,9 @@ func RegisterDeploymentToolsWithFactory(s kai.ServerInterface, cm kai.ClusterMan
 			mcp.Description("Namespace for the deployment (defaults to current namespace)"),
 		),
 		mcp.WithString("image",
-			mcp.Required(),
-			mcp.Description("Container image to use for the deployment"),
+			mcp.Description("Container image to use for the deployment (required unless containers is set)"),
 		),
+		containersOption(),
 		mcp.WithNumber("replicas",
 			mcp.Description("Number of replicas (defaults to 1)"),
 		),
@@ -107,6 +122,9 @@ func RegisterDeploymentToolsWithFactory(s kai.ServerInterface, cm kai.ClusterMan
 		mcp.WithString("image_pull_policy",
 			mcp.Description("Image pull policy (Always, IfNotPresent, Never)"),
 		),
+		resourcesOption(),
+		verifyImageOption(),
+		dryRunOption(),
 	)
 
 	s.AddTool(createDeploymentTool, createDeploymentHandler(cm, factory))
@@ -121,6 +139,7 @@ func RegisterDeploymentToolsWithFactory(s kai.ServerInterface, cm kai.ClusterMan
 		mcp.WithString("namespace",
 			mcp.Description("Namespace of the deployment (defaults to current namespace)"),
 		),
+		outputOption(),
 	)
 
 	s.AddTool(getDeploymentTool, getDeploymentHandler(cm, factory))
@@ -156,6 +175,8 @@ func RegisterDeploymentToolsWithFactory(s kai.ServerInterface, cm kai.ClusterMan
 		mcp.WithString("image_pull_policy",
 			mcp.Description("Image pull policy (Always, IfNotPresent, Never)"),
 		),
+		dryRunOption(),
+		showDiffOption(),
 	)
 
 	s.AddTool(updateDeploymentTool, updateDeploymentHandler(cm, factory))
@@ -175,7 +196,7 @@ func RegisterDeploymentToolsWithFactory(s kai.ServerInterface, cm kai.ClusterMan
 	s.AddTool(deleteDeploymentTool, deleteDeploymentHandler(cm, factory))
 
 	scaleDeploymentTool := mcp.NewTool("scale_deployment",
-		mcp.WithDescription("Scale a deployment to a specified number of replicas"),
+		mcp.WithDescription("Scale a deployment to a specified number of replicas via the scale subresource, without touching the pod template"),
 		idempotentMutationAnnotation("Scale deployment"),
 		mcp.WithString("name",
 			mcp.Required(),
@@ -183,11 +204,14 @@ func RegisterDeploymentToolsWithFactory(s kai.ServerInterface, cm kai.ClusterMan
 		),
 		mcp.WithNumber("replicas",
 			mcp.Required(),
-			mcp.Description("Number of replicas to scale to"),
+			mcp.Description("Number of replicas to scale to (non-negative integer)"),
 		),
 		mcp.WithString("namespace",
 			mcp.Description("Namespace of the deployment (defaults to current namespace)"),
 		),
+		mcp.WithBoolean("check_quota",
+			mcp.Description("Verify the added replicas' resource requests fit within the namespace ResourceQuota before scaling up"),
+		),
 	)
 
 	s.AddTool(scaleDeploymentTool, scaleDeploymentHandler(cm, factory))
@@ -228,7 +252,7 @@ func RegisterDeploymentToolsWithFactory(s kai.ServerInterface, cm kai.ClusterMan
 			mcp.Description("Name of the deployment"),
 		),
 		mcp.WithNumber("revision",
-			mcp.Description("Specific revision to roll back to (defaults to previous revision)"),
+			mcp.Description("Specific revision to roll back to; 0 or omitted means the previous revision"),
 		),
 		mcp.WithString("namespace",
 			mcp.Description("Namespace of the deployment (defaults to current namespace)"),
@@ -237,6 +261,43 @@ func RegisterDeploymentToolsWithFactory(s kai.ServerInterface, cm kai.ClusterMan
 
 	s.AddTool(rolloutUndoTool, rolloutUndoHandler(cm, factory))
 
+	undoDeploymentTool := mcp.NewTool("undo_deployment",
+		mcp.WithDescription("Alias of rollout_undo_deployment: roll a deployment back to an earlier revision by restoring that revision's pod template"),
+		destructiveAnnotation("Undo deployment"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the deployment"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the deployment (defaults to current namespace)"),
+		),
+		mcp.WithNumber("to_revision",
+			mcp.Description("Revision to roll back to; 0 or omitted means the previous revision"),
+		),
+	)
+
+	s.AddTool(undoDeploymentTool, rolloutUndoHandler(cm, factory))
+
+	diffRevisionsTool := mcp.NewTool("diff_revisions",
+		mcp.WithDescription("Compare the pod template of two revisions of a deployment, showing changes to images, environment variables, and resource requests/limits"),
+		readOnlyAnnotation("Diff deployment revisions"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the deployment"),
+		),
+		mcp.WithNumber("from_revision",
+			mcp.Description("Older revision to compare (defaults to the revision before to_revision)"),
+		),
+		mcp.WithNumber("to_revision",
+			mcp.Description("Newer revision to compare (defaults to the latest revision)"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the deployment (defaults to current namespace)"),
+		),
+	)
+
+	s.AddTool(diffRevisionsTool, diffRevisionsHandler(cm, factory))
+
 	rolloutRestartTool := mcp.NewTool("rollout_restart_deployment",
 		mcp.WithDescription("Restart a deployment by recreating its pods"),
 		creationAnnotation("Restart rollout"),
@@ -278,6 +339,88 @@ func RegisterDeploymentToolsWithFactory(s kai.ServerInterface, cm kai.ClusterMan
 	)
 
 	s.AddTool(rolloutResumeTool, rolloutResumeHandler(cm, factory))
+
+	hibernateDeploymentTool := mcp.NewTool("hibernate_deployment",
+		mcp.WithDescription("Scale a deployment to zero replicas, recording the current count in the kai.dev/previous-replicas annotation so wake_deployment can restore it"),
+		idempotentMutationAnnotation("Hibernate deployment"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the deployment"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the deployment (defaults to current namespace)"),
+		),
+	)
+
+	s.AddTool(hibernateDeploymentTool, hibernateDeploymentHandler(cm, factory))
+
+	wakeDeploymentTool := mcp.NewTool("wake_deployment",
+		mcp.WithDescription("Restore a deployment hibernated by hibernate_deployment to the replica count recorded in its kai.dev/previous-replicas annotation"),
+		idempotentMutationAnnotation("Wake deployment"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the deployment"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the deployment (defaults to current namespace)"),
+		),
+	)
+
+	s.AddTool(wakeDeploymentTool, wakeDeploymentHandler(cm, factory))
+
+	deploymentHealthTool := mcp.NewTool("deployment_health",
+		mcp.WithDescription("Summarize deployment health (Healthy, Progressing or Degraded) from rollout status, replica readiness, crash-looping pods and recent warning events"),
+		readOnlyAnnotation("Deployment health"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the deployment"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the deployment (defaults to current namespace)"),
+		),
+	)
+
+	s.AddTool(deploymentHealthTool, deploymentHealthHandler(cm, factory))
+
+	summarizeDeploymentTool := mcp.NewTool("summarize_deployment",
+		mcp.WithDescription("Return a compact JSON view of a deployment spec for planning edits: replicas, and per container the image, ports, env variable names, resource requests/limits and which probes are set. Much smaller than the full YAML"),
+		readOnlyAnnotation("Summarize deployment"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the deployment"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the deployment (defaults to current namespace)"),
+		),
+	)
+
+	s.AddTool(summarizeDeploymentTool, summarizeDeploymentHandler(cm, factory))
+
+	exposeDeploymentTool := mcp.NewTool("expose_deployment",
+		mcp.WithDescription("Create a service that selects a deployment's pods, like kubectl expose. Without port, every container port in the pod template is exposed"),
+		creationAnnotation("Expose deployment"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the deployment to expose"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the deployment (defaults to current namespace)"),
+		),
+		mcp.WithString("service_name",
+			mcp.Description("Name of the service (defaults to the deployment name)"),
+		),
+		mcp.WithString("type",
+			mcp.Description("Service type: ClusterIP (default), NodePort or LoadBalancer"),
+		),
+		mcp.WithNumber("port",
+			mcp.Description("Port the service listens on (defaults to the pod template's container ports)"),
+		),
+		mcp.WithNumber("target_port",
+			mcp.Description("Container port traffic is sent to (defaults to port)"),
+		),
+	)
+
+	s.AddTool(exposeDeploymentTool, exposeDeploymentHandler(cm, factory))
 }
 
 // getDeploymentHandler handles the get_deployment tool
@@ -295,11 +438,21 @@ func getDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) func
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
 
+		format, errResult := outputFormat(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if format != outputText {
+			return structuredResult(ctx, cm, format, func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error) {
+				return client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+			}), nil
+		}
+
 		params := kai.DeploymentParams{
 			Name:      name,
 			Namespace: namespace,
@@ -314,7 +467,7 @@ func getDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) func
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(err.Error()), nil
+			return mcp.NewToolResultText(classifyError(err)), nil
 		}
 
 		return mcp.NewToolResultText(resultText), nil
@@ -332,12 +485,18 @@ func listDeploymentsHandler(cm kai.ClusterManager, factory DeploymentFactory) fu
 			allNamespaces = allNamespacesArg
 		}
 
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
+
 		var namespace string
 		if !allNamespaces {
 			if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 				namespace = namespaceArg
 			} else {
-				namespace = cm.GetCurrentNamespace()
+				namespace = kai.CurrentNamespace(ctx, cm)
 			}
 		}
 
@@ -345,13 +504,24 @@ func listDeploymentsHandler(cm kai.ClusterManager, factory DeploymentFactory) fu
 		if labelSelectorArg, ok := request.GetArguments()["label_selector"].(string); ok {
 			labelSelector = labelSelectorArg
 		}
+		limit, continueToken := pageArgs(request)
+
+		format, errResult := outputFormat(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if format != outputText {
+			return structuredResult(ctx, cm, format, func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error) {
+				return client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector, Limit: limit, Continue: continueToken})
+			}), nil
+		}
 
 		params := kai.DeploymentParams{
 			Namespace: namespace, // will be used if allNamespaces is false
 		}
 
 		deployment := factory.NewDeployment(params)
-		resultText, err := deployment.List(ctx, cm, allNamespaces, labelSelector)
+		resultText, err := deployment.List(ctx, cm, allNamespaces, labelSelector, limit, continueToken)
 		if err != nil {
 			slog.Warn("failed to list deployments",
 				slog.Bool("all_namespaces", allNamespaces),
@@ -381,7 +551,7 @@ func describeDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory)
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -427,14 +597,31 @@ func createDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) f
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		imageArg, ok := request.GetArguments()["image"]
-		if !ok || imageArg == nil {
-			return mcp.NewToolResultText(errMissingImage), nil
+		if err := validateResourceName(name); err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		containers, err := containersArg(request)
+		if err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
 		}
 
-		image, ok := imageArg.(string)
-		if !ok || image == "" {
-			return mcp.NewToolResultText(errEmptyImage), nil
+		params.Requests, params.Limits, err = resourcesArg(request)
+		if err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		var image string
+		if containers == nil {
+			imageArg, ok := request.GetArguments()["image"]
+			if !ok || imageArg == nil {
+				return mcp.NewToolResultText(errMissingImage), nil
+			}
+
+			image, ok = imageArg.(string)
+			if !ok || image == "" {
+				return mcp.NewToolResultText(errEmptyImage), nil
+			}
 		}
 
 		if replicasArg, ok := request.GetArguments()["replicas"].(float64); ok {
@@ -442,6 +629,9 @@ func createDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) f
 		}
 
 		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			params.Labels = labelsArg
 		}
 
@@ -469,7 +659,7 @@ func createDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) f
 			params.ImagePullPolicy = imagePullPolicyArg
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -477,7 +667,21 @@ func createDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) f
 		params.Namespace = namespace
 		params.Image = image
 		params.Name = name
+		params.Containers = containers
+
+		if err := ensureNamespace(ctx, cm, namespace); err != nil {
+			return mcp.NewToolResultText(classifyError(err)), nil
+		}
 
+		if containers != nil {
+			if result := checkContainerImages(ctx, cm, request, params.Namespace, containers, params.ImagePullSecrets); result != nil {
+				return result, nil
+			}
+		} else if result := checkImage(ctx, cm, request, params.Namespace, params.Image, params.ImagePullSecrets); result != nil {
+			return result, nil
+		}
+
+		params.DryRun = dryRunArg(request)
 		deployment := factory.NewDeployment(params)
 
 		resultText, err := deployment.Create(ctx, cm)
@@ -487,10 +691,10 @@ func createDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) f
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(err.Error()), nil
+			return mcp.NewToolResultText(classifyError(err)), nil
 		}
 
-		return mcp.NewToolResultText(resultText), nil
+		return mcp.NewToolResultText(resultText + imageReferenceNotes(params.Image, params.ImagePullPolicy, containers)), nil
 	}
 }
 
@@ -513,7 +717,7 @@ func updateDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) f
 
 		params.Name = name
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -532,6 +736,9 @@ func updateDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) f
 		}
 
 		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			params.Labels = labelsArg
 			hasUpdateParams = true
 		}
@@ -568,6 +775,8 @@ func updateDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) f
 			return mcp.NewToolResultText(errNoUpdateParams), nil
 		}
 
+		params.DryRun = dryRunArg(request)
+		params.ShowDiff = showDiffArg(request)
 		deployment := factory.NewDeployment(params)
 		resultText, err := deployment.Update(ctx, cm)
 		if err != nil {
@@ -595,7 +804,7 @@ func deleteDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) f
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -608,7 +817,7 @@ func deleteDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) f
 		deployment := factory.NewDeployment(params)
 		resultText, err := deployment.Delete(ctx, cm)
 		if err != nil {
-			return mcp.NewToolResultText(err.Error()), nil
+			return mcp.NewToolResultText(classifyError(err)), nil
 		}
 
 		return mcp.NewToolResultText(resultText), nil
@@ -636,8 +845,11 @@ func scaleDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) fu
 		if !ok {
 			return mcp.NewToolResultText("invalid replicas parameter: must be a number"), nil
 		}
+		if replicas < 0 || replicas != math.Trunc(replicas) || replicas > math.MaxInt32 {
+			return mcp.NewToolResultText("invalid replicas parameter: must be a non-negative integer"), nil
+		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -645,11 +857,14 @@ func scaleDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) fu
 		params := kai.DeploymentParams{
 			Name:      name,
 			Namespace: namespace,
-			Replicas:  replicas,
+		}
+
+		if checkQuotaArg, ok := request.GetArguments()["check_quota"].(bool); ok {
+			params.CheckQuota = checkQuotaArg
 		}
 
 		deployment := factory.NewDeployment(params)
-		resultText, err := deployment.Scale(ctx, cm)
+		resultText, err := deployment.Scale(ctx, cm, int32(replicas))
 		if err != nil {
 			return mcp.NewToolResultText(err.Error()), nil
 		}
@@ -670,7 +885,7 @@ func rolloutStatusHandler(cm kai.ClusterManager, factory DeploymentFactory) func
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -702,7 +917,7 @@ func rolloutHistoryHandler(cm kai.ClusterManager, factory DeploymentFactory) fun
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -722,8 +937,12 @@ func rolloutHistoryHandler(cm kai.ClusterManager, factory DeploymentFactory) fun
 	}
 }
 
+// rolloutUndoHandler serves both rollout_undo_deployment and its
+// undo_deployment alias, which names the revision to_revision.
 func rolloutUndoHandler(cm kai.ClusterManager, factory DeploymentFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", request.Params.Name))
+
 		nameArg, ok := request.GetArguments()["name"]
 		if !ok || nameArg == nil {
 			return mcp.NewToolResultText(errMissingName), nil
@@ -735,11 +954,18 @@ func rolloutUndoHandler(cm kai.ClusterManager, factory DeploymentFactory) func(c
 		}
 
 		var revision int64
-		if revisionArg, ok := request.GetArguments()["revision"].(float64); ok {
+		revisionArg, ok := request.GetArguments()["revision"].(float64)
+		if !ok {
+			revisionArg, ok = request.GetArguments()["to_revision"].(float64)
+		}
+		if ok {
+			if revisionArg < 0 {
+				return mcp.NewToolResultText("Revision must be a non-negative number (0 means the previous revision)"), nil
+			}
 			revision = int64(revisionArg)
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -759,6 +985,49 @@ func rolloutUndoHandler(cm kai.ClusterManager, factory DeploymentFactory) func(c
 	}
 }
 
+func diffRevisionsHandler(cm kai.ClusterManager, factory DeploymentFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		var fromRevision, toRevision int64
+		if fromArg, ok := request.GetArguments()["from_revision"].(float64); ok {
+			fromRevision = int64(fromArg)
+		}
+		if toArg, ok := request.GetArguments()["to_revision"].(float64); ok {
+			toRevision = int64(toArg)
+		}
+		if fromRevision < 0 || toRevision < 0 {
+			return mcp.NewToolResultText("Revisions must be positive numbers"), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.DeploymentParams{
+			Name:      name,
+			Namespace: namespace,
+		}
+
+		deployment := factory.NewDeployment(params)
+		resultText, err := deployment.DiffRevisions(ctx, cm, fromRevision, toRevision)
+		if err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		return mcp.NewToolResultText(resultText), nil
+	}
+}
+
 func rolloutRestartHandler(cm kai.ClusterManager, factory DeploymentFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		nameArg, ok := request.GetArguments()["name"]
@@ -771,7 +1040,7 @@ func rolloutRestartHandler(cm kai.ClusterManager, factory DeploymentFactory) fun
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -803,7 +1072,7 @@ func rolloutPauseHandler(cm kai.ClusterManager, factory DeploymentFactory) func(
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -835,7 +1104,7 @@ func rolloutResumeHandler(cm kai.ClusterManager, factory DeploymentFactory) func
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -854,3 +1123,201 @@ func rolloutResumeHandler(cm kai.ClusterManager, factory DeploymentFactory) func
 		return mcp.NewToolResultText(resultText), nil
 	}
 }
+
+func hibernateDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.DeploymentParams{
+			Name:      name,
+			Namespace: namespace,
+		}
+
+		deployment := factory.NewDeployment(params)
+		resultText, err := deployment.Hibernate(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		return mcp.NewToolResultText(resultText), nil
+	}
+}
+
+func wakeDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.DeploymentParams{
+			Name:      name,
+			Namespace: namespace,
+		}
+
+		deployment := factory.NewDeployment(params)
+		resultText, err := deployment.Wake(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		return mcp.NewToolResultText(resultText), nil
+	}
+}
+
+func deploymentHealthHandler(cm kai.ClusterManager, factory DeploymentFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "deployment_health"))
+
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.DeploymentParams{
+			Name:      name,
+			Namespace: namespace,
+		}
+
+		deployment := factory.NewDeployment(params)
+		resultText, err := deployment.Health(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to check deployment health: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(resultText), nil
+	}
+}
+
+func summarizeDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "summarize_deployment"))
+
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.DeploymentParams{
+			Name:      name,
+			Namespace: namespace,
+		}
+
+		deployment := factory.NewDeployment(params)
+		resultText, err := deployment.Summarize(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to summarize deployment: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(resultText), nil
+	}
+}
+
+func exposeDeploymentHandler(cm kai.ClusterManager, factory DeploymentFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "expose_deployment"))
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		port, err := exposePortArg(request, "port")
+		if err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+		targetPort, err := exposePortArg(request, "target_port")
+		if err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+		if targetPort != 0 && port == 0 {
+			return mcp.NewToolResultText("target_port requires port"), nil
+		}
+
+		serviceName, _ := request.GetArguments()["service_name"].(string)
+		if serviceName != "" {
+			if err := validateResourceName(serviceName); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
+		}
+		serviceType, _ := request.GetArguments()["type"].(string)
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.DeploymentParams{
+			Name:      name,
+			Namespace: namespace,
+		}
+
+		deployment := factory.NewDeployment(params)
+		resultText, err := deployment.Expose(ctx, cm, serviceName, serviceType, port, targetPort)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to expose deployment: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(resultText), nil
+	}
+}
+
+// exposePortArg reads an optional port number, returning zero when unset.
+func exposePortArg(request mcp.CallToolRequest, key string) (int32, error) {
+	arg, ok := request.GetArguments()[key]
+	if !ok || arg == nil {
+		return 0, nil
+	}
+	port, ok := arg.(float64)
+	if !ok || port != math.Trunc(port) || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid %s parameter: must be an integer between 1 and 65535", key)
+	}
+	return int32(port), nil
+}
@@ -198,6 +198,70 @@ func TestCreateDeploymentHandler(t *testing.T) {
 			expectedOutput:           errQuotaExceeded,
 			expectDeploymentCreation: true,
 		},
+		{
+			name: "Create deployment with sidecar",
+			args: map[string]interface{}{
+				"name": "web",
+				"containers": []interface{}{
+					map[string]interface{}{
+						"name":  "app",
+						"image": nginxImage,
+						"ports": []interface{}{float64(80)},
+						"resources": map[string]interface{}{
+							"requests": map[string]interface{}{"cpu": "100m"},
+						},
+					},
+					map[string]interface{}{
+						"name":  "proxy",
+						"image": "envoyproxy/envoy:v1.30",
+						"ports": []interface{}{"9901/TCP"},
+						"env":   map[string]interface{}{"LOG_LEVEL": "info"},
+					},
+				},
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "web",
+				Namespace: defaultNamespace,
+				Replicas:  1,
+				Containers: []kai.ContainerSpec{
+					{Name: "app", Image: nginxImage, Ports: []string{"80"}, Requests: map[string]interface{}{"cpu": "100m"}},
+					{Name: "proxy", Image: "envoyproxy/envoy:v1.30", Ports: []string{"9901/TCP"}, Env: map[string]interface{}{"LOG_LEVEL": "info"}},
+				},
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("Create", mock.Anything, mockCM).
+					Return(fmt.Sprintf("Deployment %q created successfully in namespace %q with %g replica(s)", "web", defaultNamespace, float64(1)), nil)
+			},
+			expectedOutput:           fmt.Sprintf("Deployment %q created successfully", "web"),
+			expectDeploymentCreation: true,
+		},
+		{
+			name: "Image combined with containers",
+			args: map[string]interface{}{
+				"name":  "web",
+				"image": nginxImage,
+				"containers": []interface{}{
+					map[string]interface{}{"name": "proxy", "image": "envoyproxy/envoy:v1.30"},
+				},
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+			},
+			expectedOutput: "parameter 'image' cannot be combined with 'containers'",
+		},
+		{
+			name: "Container without image",
+			args: map[string]interface{}{
+				"name": "web",
+				"containers": []interface{}{
+					map[string]interface{}{"name": "app", "image": nginxImage},
+					map[string]interface{}{"name": "proxy"},
+				},
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+			},
+			expectedOutput: `container "proxy": image is required`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -213,6 +277,7 @@ func TestCreateDeploymentHandler(t *testing.T) {
 
 			tc.mockSetup(mockCM, mockFactory, mockDeployment)
 
+			stubNamespaceCheck(mockCM)
 			handler := createDeploymentHandler(mockCM, mockFactory)
 
 			request := mcp.CallToolRequest{
@@ -372,6 +437,27 @@ func TestUpdateDeploymentHandler(t *testing.T) {
 			expectedOutput:           fmt.Sprintf("Deployment %q updated successfully", "test-deployment"),
 			expectDeploymentCreation: true,
 		},
+		{
+			name: "UpdateImageShowDiff",
+			args: map[string]interface{}{
+				"name":      "test-deployment",
+				"image":     "nginx:1.20",
+				"show_diff": true,
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: defaultNamespace,
+				Image:     "nginx:1.20",
+				ShowDiff:  true,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("Update", mock.Anything, mockCM).
+					Return("Deployment \"test-deployment\" updated successfully in namespace \"default\"\nChanged fields (1):\n  spec.template.spec.containers[0].image: \"nginx:1.19\" → \"nginx:1.20\"", nil)
+			},
+			expectedOutput:           "Changed fields (1):\n  spec.template.spec.containers[0].image: \"nginx:1.19\" → \"nginx:1.20\"",
+			expectDeploymentCreation: true,
+		},
 		{
 			name: "UpdateReplicas",
 			args: map[string]interface{}{
@@ -543,7 +629,7 @@ func TestListDeploymentsHandler(t *testing.T) {
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockDeployment.On("List", mock.Anything, mockCM, false, "").
+				mockDeployment.On("List", mock.Anything, mockCM, false, "", int64(0), "").
 					Return(fmt.Sprintf("Deployments in namespace %q:\n• test-deployment-1: 1/1 replicas ready\n• test-deployment-2: 2/2 replicas ready", defaultNamespace), nil)
 			},
 			expectedOutput:           fmt.Sprintf("Deployments in namespace %q", defaultNamespace),
@@ -559,7 +645,7 @@ func TestListDeploymentsHandler(t *testing.T) {
 				Namespace: testNamespace,
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
-				mockDeployment.On("List", mock.Anything, mockCM, false, "").
+				mockDeployment.On("List", mock.Anything, mockCM, false, "", int64(0), "").
 					Return(fmt.Sprintf("Deployments in namespace %q:\n• test-deployment-1: 1/1 replicas ready", testNamespace), nil)
 			},
 			expectedOutput:           fmt.Sprintf("Deployments in namespace %q", testNamespace),
@@ -574,7 +660,7 @@ func TestListDeploymentsHandler(t *testing.T) {
 				Namespace: "", // This should be ignored because all_namespaces is true
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
-				mockDeployment.On("List", mock.Anything, mockCM, true, "").
+				mockDeployment.On("List", mock.Anything, mockCM, true, "", int64(0), "").
 					Return("Deployments across all namespaces:\n• default/test-deployment-1: 1/1 replicas ready\n• test-namespace/test-deployment-2: 2/2 replicas ready", nil)
 			},
 			expectedOutput:           "Deployments across all namespaces",
@@ -591,12 +677,29 @@ func TestListDeploymentsHandler(t *testing.T) {
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockDeployment.On("List", mock.Anything, mockCM, false, "app=nginx").
+				mockDeployment.On("List", mock.Anything, mockCM, false, "app=nginx", int64(0), "").
 					Return(fmt.Sprintf("Deployments in namespace %q with label selector 'app=nginx':\n• nginx-deployment: 3/3 replicas ready", defaultNamespace), nil)
 			},
 			expectedOutput:           fmt.Sprintf("Deployments in namespace %q with label selector", defaultNamespace),
 			expectDeploymentCreation: true,
 		},
+		{
+			name: "Next page",
+			args: map[string]interface{}{
+				"limit":    float64(10),
+				"continue": "page-2",
+			},
+			expectedParams: kai.DeploymentParams{
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("List", mock.Anything, mockCM, false, "", int64(10), "page-2").
+					Return(fmt.Sprintf("Deployments in namespace %q:\n• web: 2/2 replicas ready", defaultNamespace), nil)
+			},
+			expectedOutput:           "• web: 2/2 replicas ready",
+			expectDeploymentCreation: true,
+		},
 		{
 			name: "No deployments found",
 			args: map[string]interface{}{
@@ -607,7 +710,7 @@ func TestListDeploymentsHandler(t *testing.T) {
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockDeployment.On("List", mock.Anything, mockCM, false, "").
+				mockDeployment.On("List", mock.Anything, mockCM, false, "", int64(0), "").
 					Return(fmt.Sprintf("No deployments found in namespace %q", defaultNamespace), nil)
 			},
 			expectedOutput:           fmt.Sprintf("No deployments found in namespace %q", defaultNamespace),
@@ -623,7 +726,7 @@ func TestListDeploymentsHandler(t *testing.T) {
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockDeployment.On("List", mock.Anything, mockCM, false, "").
+				mockDeployment.On("List", mock.Anything, mockCM, false, "", int64(0), "").
 					Return("", errors.New("failed to list deployments: unauthorized"))
 			},
 			expectedOutput:           "failed to list deployments: unauthorized",
@@ -873,11 +976,10 @@ func TestScaleDeploymentHandler(t *testing.T) {
 			expectedParams: kai.DeploymentParams{
 				Name:      "test-deployment",
 				Namespace: defaultNamespace,
-				Replicas:  float64(3),
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockDeployment.On("Scale", mock.Anything, mockCM).
+				mockDeployment.On("Scale", mock.Anything, mockCM, int32(3)).
 					Return("Deployment \"test-deployment\" scaled to 3 replicas", nil)
 			},
 			expectedOutput:           "scaled to 3 replicas",
@@ -927,6 +1029,30 @@ func TestScaleDeploymentHandler(t *testing.T) {
 			expectedOutput:           "invalid replicas parameter: must be a number",
 			expectDeploymentCreation: false,
 		},
+		{
+			name: "NegativeReplicas",
+			args: map[string]interface{}{
+				"name":     "test-deployment",
+				"replicas": float64(-1),
+			},
+			expectedParams: kai.DeploymentParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+			},
+			expectedOutput:           "invalid replicas parameter: must be a non-negative integer",
+			expectDeploymentCreation: false,
+		},
+		{
+			name: "FractionalReplicas",
+			args: map[string]interface{}{
+				"name":     "test-deployment",
+				"replicas": float64(2.5),
+			},
+			expectedParams: kai.DeploymentParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+			},
+			expectedOutput:           "invalid replicas parameter: must be a non-negative integer",
+			expectDeploymentCreation: false,
+		},
 		{
 			name: "WithNamespace",
 			args: map[string]interface{}{
@@ -937,16 +1063,35 @@ func TestScaleDeploymentHandler(t *testing.T) {
 			expectedParams: kai.DeploymentParams{
 				Name:      "test-deployment",
 				Namespace: testNamespace,
-				Replicas:  float64(5),
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockDeployment.On("Scale", mock.Anything, mockCM).
+				mockDeployment.On("Scale", mock.Anything, mockCM, int32(5)).
 					Return("Deployment \"test-deployment\" scaled to 5 replicas", nil)
 			},
 			expectedOutput:           "scaled to 5 replicas",
 			expectDeploymentCreation: true,
 		},
+		{
+			name: "WithCheckQuota",
+			args: map[string]interface{}{
+				"name":        "test-deployment",
+				"replicas":    float64(5),
+				"check_quota": true,
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:       "test-deployment",
+				Namespace:  defaultNamespace,
+				CheckQuota: true,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("Scale", mock.Anything, mockCM, int32(5)).
+					Return("", errors.New("scaling to 5 would exceed memory quota \"compute\""))
+			},
+			expectedOutput:           "scaling to 5 would exceed memory quota",
+			expectDeploymentCreation: true,
+		},
 		{
 			name: "Error",
 			args: map[string]interface{}{
@@ -956,11 +1101,10 @@ func TestScaleDeploymentHandler(t *testing.T) {
 			expectedParams: kai.DeploymentParams{
 				Name:      "test-deployment",
 				Namespace: defaultNamespace,
-				Replicas:  float64(3),
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockDeployment.On("Scale", mock.Anything, mockCM).
+				mockDeployment.On("Scale", mock.Anything, mockCM, int32(3)).
 					Return("", errors.New("failed to scale deployment"))
 			},
 			expectedOutput:           "failed to scale deployment",
@@ -1172,6 +1316,170 @@ func TestRolloutUndoHandler(t *testing.T) {
 	runDeploymentTests(t, testCases, rolloutUndoHandler)
 }
 
+func TestUndoDeploymentHandler(t *testing.T) {
+	testCases := []deploymentTestCase{
+		{
+			name: "PreviousRevision",
+			args: map[string]interface{}{
+				"name": "test-deployment",
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("RolloutUndo", mock.Anything, mockCM, int64(0)).
+					Return(`Deployment "test-deployment" rolled back to revision 2`, nil)
+			},
+			expectedOutput:           `Deployment "test-deployment" rolled back to revision 2`,
+			expectDeploymentCreation: true,
+		},
+		{
+			name: "ToRevision",
+			args: map[string]interface{}{
+				"name":        "test-deployment",
+				"namespace":   testNamespace,
+				"to_revision": float64(1),
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: testNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("RolloutUndo", mock.Anything, mockCM, int64(1)).
+					Return(`Deployment "test-deployment" rolled back to revision 1`, nil)
+			},
+			expectedOutput:           "rolled back to revision 1",
+			expectDeploymentCreation: true,
+		},
+		{
+			name: "NegativeRevision",
+			args: map[string]interface{}{
+				"name":        "test-deployment",
+				"to_revision": float64(-2),
+			},
+			expectedParams: kai.DeploymentParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+			},
+			expectedOutput:           "Revision must be a non-negative number (0 means the previous revision)",
+			expectDeploymentCreation: false,
+		},
+		{
+			name: "MissingRevision",
+			args: map[string]interface{}{
+				"name":        "test-deployment",
+				"to_revision": float64(9),
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("RolloutUndo", mock.Anything, mockCM, int64(9)).
+					Return("", errors.New(`revision 9 not found for deployment "test-deployment"`))
+			},
+			expectedOutput:           "revision 9 not found",
+			expectDeploymentCreation: true,
+		},
+		{
+			name:           "MissingName",
+			args:           map[string]interface{}{},
+			expectedParams: kai.DeploymentParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+			},
+			expectedOutput:           errMissingName,
+			expectDeploymentCreation: false,
+		},
+	}
+
+	runDeploymentTests(t, testCases, rolloutUndoHandler)
+}
+
+func TestDiffRevisionsHandler(t *testing.T) {
+	testCases := []deploymentTestCase{
+		{
+			name: "DefaultRevisions",
+			args: map[string]interface{}{
+				"name": "test-deployment",
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("DiffRevisions", mock.Anything, mockCM, int64(0), int64(0)).
+					Return("Deployment \"test-deployment\" revision 1 -> 2:\nImages:\n  ~ app: nginx:1.24 -> nginx:1.25", nil)
+			},
+			expectedOutput:           "nginx:1.24 -> nginx:1.25",
+			expectDeploymentCreation: true,
+		},
+		{
+			name: "ExplicitRevisions",
+			args: map[string]interface{}{
+				"name":          "test-deployment",
+				"namespace":     testNamespace,
+				"from_revision": float64(3),
+				"to_revision":   float64(5),
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: testNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("DiffRevisions", mock.Anything, mockCM, int64(3), int64(5)).
+					Return("Deployment \"test-deployment\" revision 3 -> 5: no differences in images, env, or resources", nil)
+			},
+			expectedOutput:           "revision 3 -> 5",
+			expectDeploymentCreation: true,
+		},
+		{
+			name: "NegativeRevision",
+			args: map[string]interface{}{
+				"name":          "test-deployment",
+				"from_revision": float64(-1),
+			},
+			expectedParams: kai.DeploymentParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+			},
+			expectedOutput:           "Revisions must be positive numbers",
+			expectDeploymentCreation: false,
+		},
+		{
+			name:           "MissingName",
+			args:           map[string]interface{}{},
+			expectedParams: kai.DeploymentParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+			},
+			expectedOutput:           errMissingName,
+			expectDeploymentCreation: false,
+		},
+		{
+			name: "Error",
+			args: map[string]interface{}{
+				"name": "test-deployment",
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("DiffRevisions", mock.Anything, mockCM, int64(0), int64(0)).
+					Return("", errors.New(`revision 7 not found for deployment "test-deployment"`))
+			},
+			expectedOutput:           "revision 7 not found",
+			expectDeploymentCreation: true,
+		},
+	}
+
+	runDeploymentTests(t, testCases, diffRevisionsHandler)
+}
+
 func TestRolloutRestartHandler(t *testing.T) {
 	testCases := []deploymentTestCase{
 		{
@@ -1392,3 +1700,292 @@ func runDeploymentTests(t *testing.T, testCases []deploymentTestCase, handlerFn
 		})
 	}
 }
+
+func TestDeploymentHealthHandler(t *testing.T) {
+	testCases := []deploymentTestCase{
+		{
+			name: "Success",
+			args: map[string]interface{}{
+				"name": "test-deployment",
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("Health", mock.Anything, mockCM).
+					Return("Deployment \"test-deployment\" in namespace \"default\": Healthy", nil)
+			},
+			expectedOutput:           "Healthy",
+			expectDeploymentCreation: true,
+		},
+		{
+			name:           "MissingName",
+			args:           map[string]interface{}{},
+			expectedParams: kai.DeploymentParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+			},
+			expectedOutput:           errMissingName,
+			expectDeploymentCreation: false,
+		},
+		{
+			name: "Error",
+			args: map[string]interface{}{
+				"name": "test-deployment",
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("Health", mock.Anything, mockCM).
+					Return("", errors.New("deployment not found"))
+			},
+			expectedOutput:           "Failed to check deployment health: deployment not found",
+			expectDeploymentCreation: true,
+		},
+	}
+
+	runDeploymentTests(t, testCases, deploymentHealthHandler)
+}
+
+func TestSummarizeDeploymentHandler(t *testing.T) {
+	testCases := []deploymentTestCase{
+		{
+			name: "Success",
+			args: map[string]interface{}{
+				"name":      "test-deployment",
+				"namespace": testNamespace,
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: testNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("Summarize", mock.Anything, mockCM).
+					Return(`{"name": "test-deployment", "replicas": 3}`, nil)
+			},
+			expectedOutput:           `"replicas": 3`,
+			expectDeploymentCreation: true,
+		},
+		{
+			name:           "MissingName",
+			args:           map[string]interface{}{},
+			expectedParams: kai.DeploymentParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+			},
+			expectedOutput:           errMissingName,
+			expectDeploymentCreation: false,
+		},
+		{
+			name: "Error",
+			args: map[string]interface{}{
+				"name": "test-deployment",
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("Summarize", mock.Anything, mockCM).
+					Return("", errors.New("deployment not found"))
+			},
+			expectedOutput:           "Failed to summarize deployment: deployment not found",
+			expectDeploymentCreation: true,
+		},
+	}
+
+	runDeploymentTests(t, testCases, summarizeDeploymentHandler)
+}
+
+func TestExposeDeploymentHandler(t *testing.T) {
+	testCases := []deploymentTestCase{
+		{
+			name: "DefaultsToTemplatePorts",
+			args: map[string]interface{}{
+				"name": "test-deployment",
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("Expose", mock.Anything, mockCM, "", "", int32(0), int32(0)).
+					Return(`Deployment "test-deployment" exposed as ClusterIP service "test-deployment"`, nil)
+			},
+			expectedOutput:           "exposed as ClusterIP",
+			expectDeploymentCreation: true,
+		},
+		{
+			name: "WithPortAndType",
+			args: map[string]interface{}{
+				"name":         "test-deployment",
+				"namespace":    testNamespace,
+				"service_name": "web",
+				"type":         "NodePort",
+				"port":         float64(80),
+				"target_port":  float64(8080),
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: testNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("Expose", mock.Anything, mockCM, "web", "NodePort", int32(80), int32(8080)).
+					Return(`Deployment "test-deployment" exposed as NodePort service "web"`, nil)
+			},
+			expectedOutput:           "NodePort service \"web\"",
+			expectDeploymentCreation: true,
+		},
+		{
+			name:           "MissingName",
+			args:           map[string]interface{}{},
+			expectedParams: kai.DeploymentParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+			},
+			expectedOutput:           errMissingName,
+			expectDeploymentCreation: false,
+		},
+		{
+			name: "InvalidPort",
+			args: map[string]interface{}{
+				"name": "test-deployment",
+				"port": float64(70000),
+			},
+			expectedParams: kai.DeploymentParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+			},
+			expectedOutput:           "invalid port parameter",
+			expectDeploymentCreation: false,
+		},
+		{
+			name: "TargetPortWithoutPort",
+			args: map[string]interface{}{
+				"name":        "test-deployment",
+				"target_port": float64(8080),
+			},
+			expectedParams: kai.DeploymentParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+			},
+			expectedOutput:           "target_port requires port",
+			expectDeploymentCreation: false,
+		},
+		{
+			name: "Error",
+			args: map[string]interface{}{
+				"name": "test-deployment",
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("Expose", mock.Anything, mockCM, "", "", int32(0), int32(0)).
+					Return("", errors.New("deployment \"test-deployment\" declares no container ports; specify port to expose"))
+			},
+			expectedOutput:           "Failed to expose deployment: deployment \"test-deployment\" declares no container ports",
+			expectDeploymentCreation: true,
+		},
+	}
+
+	runDeploymentTests(t, testCases, exposeDeploymentHandler)
+}
+
+func TestHibernateDeploymentHandler(t *testing.T) {
+	testCases := []deploymentTestCase{
+		{
+			name: "Success",
+			args: map[string]interface{}{
+				"name":      "test-deployment",
+				"namespace": testNamespace,
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: testNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("Hibernate", mock.Anything, mockCM).
+					Return(`Deployment "test-deployment" hibernated in namespace "test-namespace": scaled from 3 to 0 replica(s)`, nil)
+			},
+			expectedOutput:           "scaled from 3 to 0",
+			expectDeploymentCreation: true,
+		},
+		{
+			name:           "MissingName",
+			args:           map[string]interface{}{},
+			expectedParams: kai.DeploymentParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+			},
+			expectedOutput:           errMissingName,
+			expectDeploymentCreation: false,
+		},
+		{
+			name: "Error",
+			args: map[string]interface{}{
+				"name": "test-deployment",
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("Hibernate", mock.Anything, mockCM).
+					Return("", errors.New("failed to get deployment: not found"))
+			},
+			expectedOutput:           "failed to get deployment: not found",
+			expectDeploymentCreation: true,
+		},
+	}
+
+	runDeploymentTests(t, testCases, hibernateDeploymentHandler)
+}
+
+func TestWakeDeploymentHandler(t *testing.T) {
+	testCases := []deploymentTestCase{
+		{
+			name: "Success",
+			args: map[string]interface{}{
+				"name": "test-deployment",
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("Wake", mock.Anything, mockCM).
+					Return(`Deployment "test-deployment" woken in namespace "default": scaled from 0 to 3 replica(s)`, nil)
+			},
+			expectedOutput:           "scaled from 0 to 3",
+			expectDeploymentCreation: true,
+		},
+		{
+			name: "NotHibernated",
+			args: map[string]interface{}{
+				"name": "test-deployment",
+			},
+			expectedParams: kai.DeploymentParams{
+				Name:      "test-deployment",
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockDeploymentFactory, mockDeployment *testmocks.MockDeployment) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockDeployment.On("Wake", mock.Anything, mockCM).
+					Return("", errors.New(`deployment "test-deployment" in namespace "default" is not hibernated: no kai.dev/previous-replicas annotation`))
+			},
+			expectedOutput:           "is not hibernated",
+			expectDeploymentCreation: true,
+		},
+	}
+
+	runDeploymentTests(t, testCases, wakeDeploymentHandler)
+}
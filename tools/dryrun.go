@@ -0,0 +1,29 @@
+package tools
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+// dryRunOption declares the dry_run parameter of create and update tools.
+func dryRunOption() mcp.ToolOption {
+	return mcp.WithBoolean("dry_run",
+		mcp.Description("Validate the change on the server without persisting it; the result is marked (dry run)"),
+	)
+}
+
+// dryRunArg reports whether the request asked for a dry run.
+func dryRunArg(request mcp.CallToolRequest) bool {
+	dryRun, _ := request.GetArguments()["dry_run"].(bool)
+	return dryRun
+}
+
+// showDiffOption declares the show_diff parameter of update tools.
+func showDiffOption() mcp.ToolOption {
+	return mcp.WithBoolean("show_diff",
+		mcp.Description("List the fields the update changed, comparing the object before the update with the server's response"),
+	)
+}
+
+// showDiffArg reports whether the request asked for the changed fields.
+func showDiffArg(request mcp.CallToolRequest) bool {
+	showDiff, _ := request.GetArguments()["show_diff"].(bool)
+	return showDiff
+}
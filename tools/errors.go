@@ -0,0 +1,32 @@
+package tools
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// classifyError renders an operator error for a tool result. When a
+// Kubernetes API error is wrapped anywhere in err, the message leads with
+// its category and, where one helps, a next step, so a missing object reads
+// differently from a missing permission. Other errors are returned as is.
+func classifyError(err error) string {
+	msg := err.Error()
+	switch {
+	case apierrors.IsNotFound(err):
+		return fmt.Sprintf("not found: %s", msg)
+	case apierrors.IsForbidden(err):
+		return fmt.Sprintf("permission denied: %s; check what the current user may do with can_i", msg)
+	case apierrors.IsUnauthorized(err):
+		return fmt.Sprintf("unauthorized: %s; the credentials of the current context were rejected", msg)
+	case apierrors.IsAlreadyExists(err):
+		return fmt.Sprintf("already exists: %s; choose another name or update the existing object", msg)
+	case apierrors.IsConflict(err):
+		return fmt.Sprintf("conflict: %s; the object was modified concurrently, retry with its latest version", msg)
+	case apierrors.IsInvalid(err):
+		return fmt.Sprintf("invalid: %s", msg)
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err), apierrors.IsTooManyRequests(err):
+		return fmt.Sprintf("timed out: %s; the API server is busy, retry shortly", msg)
+	}
+	return msg
+}
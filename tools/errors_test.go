@@ -0,0 +1,258 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/basebandit/kai"
+	"github.com/basebandit/kai/testmocks"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	deployments := schema.GroupResource{Group: "apps", Resource: "deployments"}
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "NotFound",
+			err:      fmt.Errorf("failed to get deployment: %w", apierrors.NewNotFound(deployments, "web")),
+			expected: `not found: failed to get deployment: deployments.apps "web" not found`,
+		},
+		{
+			name:     "Forbidden",
+			err:      fmt.Errorf("failed to delete deployment: %w", apierrors.NewForbidden(deployments, "web", errors.New(`User "dev" cannot delete resource "deployments"`))),
+			expected: `permission denied: failed to delete deployment: deployments.apps "web" is forbidden: User "dev" cannot delete resource "deployments"; check what the current user may do with can_i`,
+		},
+		{
+			name:     "AlreadyExists",
+			err:      fmt.Errorf("failed to create deployment: %w", apierrors.NewAlreadyExists(deployments, "web")),
+			expected: `already exists: failed to create deployment: deployments.apps "web" already exists; choose another name or update the existing object`,
+		},
+		{
+			name:     "Conflict",
+			err:      apierrors.NewConflict(deployments, "web", errors.New("the object has been modified")),
+			expected: `conflict: Operation cannot be fulfilled on deployments.apps "web": the object has been modified; the object was modified concurrently, retry with its latest version`,
+		},
+		{
+			name:     "Unclassified",
+			err:      errors.New("replicas must be non-negative, got -1"),
+			expected: "replicas must be non-negative, got -1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, classifyError(tc.err))
+		})
+	}
+}
+
+func TestHandlerClassifiesForbidden(t *testing.T) {
+	params := kai.DeploymentParams{Name: "web", Namespace: testNamespace}
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace).Maybe()
+	mockDeployment := testmocks.NewMockDeployment(params)
+	mockDeployment.On("Get", mock.Anything, mockCM).Return("", fmt.Errorf("failed to get deployment: %w",
+		apierrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "web", errors.New("access denied"))))
+	mockFactory := testmocks.NewMockDeploymentFactory()
+	mockFactory.On("NewDeployment", params).Return(mockDeployment)
+
+	result, err := getDeploymentHandler(mockCM, mockFactory)(context.Background(), toolRequest(map[string]interface{}{
+		"name":      "web",
+		"namespace": testNamespace,
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, result), "permission denied: failed to get deployment")
+	mockDeployment.AssertExpectations(t)
+}
+
+func TestPodHandlersClassifyErrors(t *testing.T) {
+	pods := schema.GroupResource{Resource: "pods"}
+
+	testCases := []struct {
+		name     string
+		handler  func(kai.ClusterManager, PodFactory) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		getErr   error
+		expected string
+		excluded string
+	}{
+		{
+			name:     "GetPodNotFound",
+			handler:  getPodHandler,
+			getErr:   apierrors.NewNotFound(pods, "web"),
+			expected: "not found: pod 'web' not found in namespace 'test-namespace'",
+		},
+		{
+			name:     "GetPodForbidden",
+			handler:  getPodHandler,
+			getErr:   apierrors.NewForbidden(pods, "web", errors.New("access denied")),
+			expected: "permission denied: failed to get pod 'web' in namespace 'test-namespace'",
+			excluded: "not found",
+		},
+		{
+			name:     "DeletePodNotFound",
+			handler:  deletePodHandler,
+			getErr:   apierrors.NewNotFound(pods, "web"),
+			expected: `not found: pod "web" not found in namespace "test-namespace"`,
+		},
+		{
+			name:     "DeletePodForbidden",
+			handler:  deletePodHandler,
+			getErr:   apierrors.NewForbidden(pods, "web", errors.New("access denied")),
+			expected: `permission denied: failed to get pod "web" in namespace "test-namespace"`,
+			excluded: "not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}})
+			client.PrependReactor("get", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, tc.getErr
+			})
+			mockCM := testmocks.NewMockClusterManager()
+			mockCM.On("GetCurrentNamespace").Return(defaultNamespace).Maybe()
+			mockCM.On("GetCurrentClient").Return(client, nil)
+
+			result, err := tc.handler(mockCM, &DefaultPodFactory{})(context.Background(), toolRequest(map[string]interface{}{
+				"name":      "web",
+				"namespace": testNamespace,
+			}))
+			assert.NoError(t, err)
+			assert.Contains(t, resultText(t, result), tc.expected)
+			if tc.excluded != "" {
+				assert.NotContains(t, resultText(t, result), tc.excluded)
+			}
+		})
+	}
+}
+
+func TestResourceHandlersClassifyNotFound(t *testing.T) {
+	testCases := []struct {
+		name     string
+		handler  func(kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		resource schema.GroupResource
+		expected string
+	}{
+		{
+			name: "Secret",
+			handler: func(cm kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return getSecretHandler(cm, NewDefaultSecretFactory())
+			},
+			resource: schema.GroupResource{Resource: "secrets"},
+			expected: `Failed to get Secret: not found: Secret "web" not found in namespace "test-namespace"`,
+		},
+		{
+			name: "Service",
+			handler: func(cm kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return getServiceHandler(cm, NewDefaultServiceFactory())
+			},
+			resource: schema.GroupResource{Resource: "services"},
+			expected: `not found: service 'web' not found in namespace 'test-namespace'`,
+		},
+		{
+			name: "ConfigMap",
+			handler: func(cm kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return getConfigMapHandler(cm, NewDefaultConfigMapFactory())
+			},
+			resource: schema.GroupResource{Resource: "configmaps"},
+			expected: `Failed to get ConfigMap: not found: ConfigMap "web" not found in namespace "test-namespace"`,
+		},
+		{
+			name: "CronJob",
+			handler: func(cm kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return getCronJobHandler(cm, NewDefaultCronJobFactory())
+			},
+			resource: schema.GroupResource{Group: "batch", Resource: "cronjobs"},
+			expected: `Failed to get CronJob: not found: CronJob "web" not found in namespace "test-namespace"`,
+		},
+		{
+			name: "Ingress",
+			handler: func(cm kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return getIngressHandler(cm, NewDefaultIngressFactory())
+			},
+			resource: schema.GroupResource{Group: "networking.k8s.io", Resource: "ingresses"},
+			expected: `Failed to get Ingress: not found: Ingress "web" not found in namespace "test-namespace"`,
+		},
+		{
+			name: "Job",
+			handler: func(cm kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return getJobHandler(cm, NewDefaultJobFactory())
+			},
+			resource: schema.GroupResource{Group: "batch", Resource: "jobs"},
+			expected: `Failed to get Job: not found: Job "web" not found in namespace "test-namespace"`,
+		},
+		{
+			name:     "Namespace",
+			handler:  getNamespaceHandler,
+			resource: schema.GroupResource{Resource: "namespaces"},
+			expected: `Failed to get namespace: not found: namespace 'web' not found`,
+		},
+		{
+			name: "HPA",
+			handler: func(cm kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return getHPAHandler(cm, NewDefaultHPAFactory())
+			},
+			resource: schema.GroupResource{Group: "autoscaling", Resource: "horizontalpodautoscalers"},
+			expected: `Failed to get HPA: not found: HPA "web" not found in namespace "test-namespace"`,
+		},
+		{
+			name: "Role",
+			handler: func(cm kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return rbacGetHandler(cm, NewDefaultRBACFactory(), "role")
+			},
+			resource: schema.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "roles"},
+			expected: `Failed to get role: not found: role "web" not found in namespace "test-namespace"`,
+		},
+		{
+			name: "RoleBinding",
+			handler: func(cm kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return rbacGetHandler(cm, NewDefaultRBACFactory(), "rolebinding")
+			},
+			resource: schema.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "rolebindings"},
+			expected: `Failed to get rolebinding: not found: role binding "web" not found in namespace "test-namespace"`,
+		},
+		{
+			name: "ServiceAccount",
+			handler: func(cm kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return getServiceAccountHandler(cm, NewDefaultServiceAccountFactory())
+			},
+			resource: schema.GroupResource{Resource: "serviceaccounts"},
+			expected: `Failed to get service account: not found: service account "web" not found in namespace "test-namespace"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}})
+			client.PrependReactor("get", tc.resource.Resource, func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewNotFound(tc.resource, "web")
+			})
+			mockCM := testmocks.NewMockClusterManager()
+			mockCM.On("GetCurrentNamespace").Return(defaultNamespace).Maybe()
+			mockCM.On("GetCurrentClient").Return(client, nil)
+
+			result, err := tc.handler(mockCM)(context.Background(), toolRequest(map[string]interface{}{
+				"name":      "web",
+				"namespace": testNamespace,
+			}))
+			assert.NoError(t, err)
+			assert.Contains(t, resultText(t, result), tc.expected)
+		})
+	}
+}
@@ -13,7 +13,7 @@ import (
 // RegisterEventTools registers event query tools.
 func RegisterEventTools(s kai.ServerInterface, cm kai.ClusterManager) {
 	listEventsTool := mcp.NewTool("list_events",
-		mcp.WithDescription("List Kubernetes events, optionally filtered by namespace, type or involved object"),
+		mcp.WithDescription("List Kubernetes events, most recent first, optionally filtered by namespace, type or involved object kind and name"),
 		readOnlyAnnotation("List events"),
 		mcp.WithString("namespace",
 			mcp.Description("Namespace to list events from (defaults to current namespace)"),
@@ -21,9 +21,13 @@ func RegisterEventTools(s kai.ServerInterface, cm kai.ClusterManager) {
 		mcp.WithBoolean("all_namespaces",
 			mcp.Description("List events across all namespaces"),
 		),
+		confirmScanOption(),
 		mcp.WithString("type",
 			mcp.Description("Filter by event type: 'Warning' or 'Normal'"),
 		),
+		mcp.WithString("involved_kind",
+			mcp.Description("Filter to events about objects of one kind (e.g. Pod, Deployment, Node)"),
+		),
 		mcp.WithString("involved_object",
 			mcp.Description("Filter to events about a specific object by name (e.g. a pod name)"),
 		),
@@ -46,9 +50,17 @@ func listEventsHandler(cm kai.ClusterManager) func(ctx context.Context, request
 		if all, ok := request.GetArguments()["all_namespaces"].(bool); ok {
 			event.AllNamespaces = all
 		}
+		if event.AllNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
 		if t, ok := request.GetArguments()["type"].(string); ok {
 			event.Type = t
 		}
+		if kind, ok := request.GetArguments()["involved_kind"].(string); ok {
+			event.InvolvedKind = kind
+		}
 		if obj, ok := request.GetArguments()["involved_object"].(string); ok {
 			event.InvolvedObject = obj
 		}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/cluster"
@@ -18,6 +19,15 @@ func RegisterHealthTools(s kai.ServerInterface, cm kai.ClusterManager) {
 	)
 	s.AddTool(clusterHealthTool, clusterHealthHandler(cm))
 
+	clustersStatusTool := mcp.NewTool("clusters_status",
+		mcp.WithDescription("Check every loaded cluster's API server concurrently and report whether it is reachable, its version and the response latency"),
+		readOnlyAnnotation("Clusters status"),
+		mcp.WithString("timeout",
+			mcp.Description("How long to wait for each API server (e.g. 2s, defaults to 5s)"),
+		),
+	)
+	s.AddTool(clustersStatusTool, clustersStatusHandler(cm))
+
 	nodeMetricsTool := mcp.NewTool("node_metrics",
 		mcp.WithDescription("Show CPU and memory usage per node (requires metrics-server)"),
 		readOnlyAnnotation("Node metrics"),
@@ -33,8 +43,37 @@ func RegisterHealthTools(s kai.ServerInterface, cm kai.ClusterManager) {
 		mcp.WithBoolean("all_namespaces",
 			mcp.Description("Report pods across all namespaces"),
 		),
+		confirmScanOption(),
 	)
 	s.AddTool(podMetricsTool, podMetricsHandler(cm))
+
+	topPodsTool := mcp.NewTool("top_pods",
+		mcp.WithDescription("List pods by CPU and memory usage, heaviest first (requires metrics-server)"),
+		readOnlyAnnotation("Top pods"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace to report (defaults to current namespace)"),
+		),
+		mcp.WithBoolean("all_namespaces",
+			mcp.Description("Report pods across all namespaces"),
+		),
+		confirmScanOption(),
+		mcp.WithString("label_selector",
+			mcp.Description("Label selector to filter pods"),
+		),
+		mcp.WithString("sort_by",
+			mcp.Description("Resource to sort by: memory (default) or cpu"),
+		),
+	)
+	s.AddTool(topPodsTool, topPodsHandler(cm))
+
+	topNodesTool := mcp.NewTool("top_nodes",
+		mcp.WithDescription("List nodes by CPU and memory usage with the share of allocatable used, heaviest first (requires metrics-server)"),
+		readOnlyAnnotation("Top nodes"),
+		mcp.WithString("sort_by",
+			mcp.Description("Resource to sort by: memory (default) or cpu"),
+		),
+	)
+	s.AddTool(topNodesTool, topNodesHandler(cm))
 }
 
 func clusterHealthHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -49,6 +88,28 @@ func clusterHealthHandler(cm kai.ClusterManager) func(ctx context.Context, reque
 	}
 }
 
+func clustersStatusHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "clusters_status"))
+
+		var timeout time.Duration
+		if timeoutArg, ok := request.GetArguments()["timeout"].(string); ok && timeoutArg != "" {
+			parsed, err := time.ParseDuration(timeoutArg)
+			if err != nil {
+				return mcp.NewToolResultText(fmt.Sprintf("Failed to parse 'timeout' parameter: %v", err)), nil
+			}
+			timeout = parsed
+		}
+
+		health := cluster.Health{}
+		result, err := health.Clusters(ctx, cm, timeout)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to check clusters: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
 func nodeMetricsHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		slog.Debug("tool invoked", slog.String("tool", "node_metrics"))
@@ -72,6 +133,11 @@ func podMetricsHandler(cm kai.ClusterManager) func(ctx context.Context, request
 		if all, ok := request.GetArguments()["all_namespaces"].(bool); ok {
 			allNamespaces = all
 		}
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
 
 		health := cluster.Health{}
 		result, err := health.PodMetrics(ctx, cm, namespace, allNamespaces)
@@ -81,3 +147,39 @@ func podMetricsHandler(cm kai.ClusterManager) func(ctx context.Context, request
 		return mcp.NewToolResultText(result), nil
 	}
 }
+
+func topPodsHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "top_pods"))
+		namespace, _ := request.GetArguments()["namespace"].(string)
+		allNamespaces, _ := request.GetArguments()["all_namespaces"].(bool)
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
+		labelSelector, _ := request.GetArguments()["label_selector"].(string)
+		sortBy, _ := request.GetArguments()["sort_by"].(string)
+
+		health := cluster.Health{}
+		result, err := health.TopPods(ctx, cm, namespace, allNamespaces, labelSelector, sortBy)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get pod usage: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func topNodesHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "top_nodes"))
+		sortBy, _ := request.GetArguments()["sort_by"].(string)
+
+		health := cluster.Health{}
+		result, err := health.TopNodes(ctx, cm, sortBy)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get node usage: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
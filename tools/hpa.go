@@ -0,0 +1,415 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/basebandit/kai"
+	"github.com/basebandit/kai/cluster"
+	"github.com/mark3labs/mcp-go/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+// HPAFactory is an interface for creating HorizontalPodAutoscaler operators.
+type HPAFactory interface {
+	NewHPA(params kai.HPAParams) kai.HPAOperator
+}
+
+// DefaultHPAFactory implements the HPAFactory interface.
+type DefaultHPAFactory struct{}
+
+// NewDefaultHPAFactory creates a new DefaultHPAFactory.
+func NewDefaultHPAFactory() *DefaultHPAFactory {
+	return &DefaultHPAFactory{}
+}
+
+// NewHPA creates a new HPA operator.
+func (f *DefaultHPAFactory) NewHPA(params kai.HPAParams) kai.HPAOperator {
+	return &cluster.HPA{
+		Name:              params.Name,
+		Namespace:         params.Namespace,
+		TargetKind:        params.TargetKind,
+		TargetName:        params.TargetName,
+		TargetAPIVersion:  params.TargetAPIVersion,
+		MinReplicas:       params.MinReplicas,
+		MaxReplicas:       params.MaxReplicas,
+		CPUUtilization:    params.CPUUtilization,
+		MemoryUtilization: params.MemoryUtilization,
+		CustomMetrics:     params.CustomMetrics,
+		Labels:            params.Labels,
+		DryRun:            params.DryRun,
+	}
+}
+
+// RegisterHPATools registers HorizontalPodAutoscaler tools.
+func RegisterHPATools(s kai.ServerInterface, cm kai.ClusterManager) {
+	factory := NewDefaultHPAFactory()
+	RegisterHPAToolsWithFactory(s, cm, factory)
+}
+
+// RegisterHPAToolsWithFactory registers HorizontalPodAutoscaler tools using the provided factory.
+func RegisterHPAToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, factory HPAFactory) {
+	createHPATool := mcp.NewTool("create_hpa",
+		mcp.WithDescription("Create a HorizontalPodAutoscaler (autoscaling/v2) that scales a workload on CPU, memory or custom metrics"),
+		creationAnnotation("Create HPA"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the HorizontalPodAutoscaler"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace for the HPA (defaults to current namespace)"),
+		),
+		mcp.WithString("target_name",
+			mcp.Required(),
+			mcp.Description("Name of the workload to scale"),
+		),
+		mcp.WithString("target_kind",
+			mcp.Description("Kind of the workload to scale (default Deployment)"),
+		),
+		mcp.WithString("target_api_version",
+			mcp.Description("API version of the workload to scale (default apps/v1)"),
+		),
+		mcp.WithNumber("min_replicas",
+			mcp.Description("Minimum replica count (default 1)"),
+		),
+		mcp.WithNumber("max_replicas",
+			mcp.Required(),
+			mcp.Description("Maximum replica count"),
+		),
+		mcp.WithNumber("cpu_utilization",
+			mcp.Description("Target average CPU utilization, as a percentage of requests"),
+		),
+		mcp.WithNumber("memory_utilization",
+			mcp.Description("Target average memory utilization, as a percentage of requests"),
+		),
+		mcp.WithArray("custom_metrics",
+			mcp.Description("Custom metric targets, e.g. [{\"name\": \"http_requests_per_second\", \"average_value\": \"100\"}]; type is Pods (default) or External"),
+		),
+		mcp.WithObject("labels",
+			mcp.Description("Labels to apply to the HPA"),
+		),
+		dryRunOption(),
+	)
+	s.AddTool(createHPATool, createHPAHandler(cm, factory))
+
+	getHPATool := mcp.NewTool("get_hpa",
+		mcp.WithDescription("Get a HorizontalPodAutoscaler with its current and desired replicas and current metric values"),
+		readOnlyAnnotation("Get HPA"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the HorizontalPodAutoscaler"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the HPA (defaults to current namespace)"),
+		),
+		outputOption(),
+	)
+	s.AddTool(getHPATool, getHPAHandler(cm, factory))
+
+	listHPAsTool := mcp.NewTool("list_hpas",
+		mcp.WithDescription("List HorizontalPodAutoscalers in the current namespace or across all namespaces"),
+		readOnlyAnnotation("List HPAs"),
+		mcp.WithBoolean("all_namespaces",
+			mcp.Description("Whether to list HPAs across all namespaces"),
+		),
+		confirmScanOption(),
+		mcp.WithString("namespace",
+			mcp.Description("Specific namespace to list HPAs from (defaults to current namespace)"),
+		),
+		mcp.WithString("label_selector",
+			mcp.Description("Label selector to filter HPAs (e.g., 'app=web')"),
+		),
+		outputOption(),
+	)
+	s.AddTool(listHPAsTool, listHPAsHandler(cm, factory))
+
+	deleteHPATool := mcp.NewTool("delete_hpa",
+		mcp.WithDescription("Delete a HorizontalPodAutoscaler; the workload keeps its current replica count"),
+		destructiveAnnotation("Delete HPA"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the HorizontalPodAutoscaler to delete"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the HPA (defaults to current namespace)"),
+		),
+	)
+	s.AddTool(deleteHPATool, deleteHPAHandler(cm, factory))
+
+	setHPABoundsTool := mcp.NewTool("set_hpa_bounds",
+		mcp.WithDescription("Set the minimum and/or maximum replica count of a HorizontalPodAutoscaler"),
+		idempotentMutationAnnotation("Set HPA bounds"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the HorizontalPodAutoscaler"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the HPA (defaults to current namespace)"),
+		),
+		mcp.WithNumber("min_replicas",
+			mcp.Description("New minimum replica count (must be at least 1)"),
+		),
+		mcp.WithNumber("max_replicas",
+			mcp.Description("New maximum replica count (must not be below min_replicas)"),
+		),
+	)
+	s.AddTool(setHPABoundsTool, setHPABoundsHandler(cm, factory))
+}
+
+func createHPAHandler(cm kai.ClusterManager, factory HPAFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "create_hpa"))
+
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		targetName, ok := request.GetArguments()["target_name"].(string)
+		if !ok || targetName == "" {
+			return mcp.NewToolResultText("Required parameter 'target_name' is missing"), nil
+		}
+
+		maxReplicasArg, ok := request.GetArguments()["max_replicas"].(float64)
+		if !ok {
+			return mcp.NewToolResultText("Required parameter 'max_replicas' is missing"), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.HPAParams{
+			Name:        name,
+			Namespace:   namespace,
+			TargetName:  targetName,
+			MaxReplicas: int32(maxReplicasArg),
+		}
+
+		if v, ok := request.GetArguments()["target_kind"].(string); ok {
+			params.TargetKind = v
+		}
+		if v, ok := request.GetArguments()["target_api_version"].(string); ok {
+			params.TargetAPIVersion = v
+		}
+		if v, ok := request.GetArguments()["min_replicas"].(float64); ok {
+			minReplicas := int32(v)
+			params.MinReplicas = &minReplicas
+		}
+		if v, ok := request.GetArguments()["cpu_utilization"].(float64); ok {
+			cpu := int32(v)
+			params.CPUUtilization = &cpu
+		}
+		if v, ok := request.GetArguments()["memory_utilization"].(float64); ok {
+			memory := int32(v)
+			params.MemoryUtilization = &memory
+		}
+		if v, ok := request.GetArguments()["custom_metrics"].([]interface{}); ok {
+			params.CustomMetrics = v
+		}
+		if v, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			params.Labels = v
+		}
+		params.DryRun = dryRunArg(request)
+
+		hpa := factory.NewHPA(params)
+		result, err := hpa.Create(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to create HPA",
+				slog.String("name", name),
+				slog.String("namespace", namespace),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to create HPA: %s", classifyError(err))), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func getHPAHandler(cm kai.ClusterManager, factory HPAFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "get_hpa"))
+
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		format, errResult := outputFormat(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if format != outputText {
+			return structuredResult(ctx, cm, format, func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error) {
+				return client.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
+			}), nil
+		}
+
+		params := kai.HPAParams{
+			Name:      name,
+			Namespace: namespace,
+		}
+
+		hpa := factory.NewHPA(params)
+		result, err := hpa.Get(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to get HPA",
+				slog.String("name", name),
+				slog.String("namespace", namespace),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get HPA: %s", classifyError(err))), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func listHPAsHandler(cm kai.ClusterManager, factory HPAFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "list_hpas"))
+
+		var allNamespaces bool
+		if allNamespacesArg, ok := request.GetArguments()["all_namespaces"].(bool); ok {
+			allNamespaces = allNamespacesArg
+		}
+
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
+
+		var namespace string
+		if !allNamespaces {
+			if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+				namespace = namespaceArg
+			} else {
+				namespace = kai.CurrentNamespace(ctx, cm)
+			}
+		}
+
+		var labelSelector string
+		if labelSelectorArg, ok := request.GetArguments()["label_selector"].(string); ok {
+			labelSelector = labelSelectorArg
+		}
+
+		format, errResult := outputFormat(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if format != outputText {
+			return structuredResult(ctx, cm, format, func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error) {
+				return client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+			}), nil
+		}
+
+		params := kai.HPAParams{
+			Namespace: namespace,
+		}
+
+		hpa := factory.NewHPA(params)
+		result, err := hpa.List(ctx, cm, allNamespaces, labelSelector)
+		if err != nil {
+			slog.Warn("failed to list HPAs",
+				slog.Bool("all_namespaces", allNamespaces),
+				slog.String("namespace", namespace),
+				slog.String("label_selector", labelSelector),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to list HPAs: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func deleteHPAHandler(cm kai.ClusterManager, factory HPAFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "delete_hpa"))
+
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.HPAParams{
+			Name:      name,
+			Namespace: namespace,
+		}
+
+		hpa := factory.NewHPA(params)
+		result, err := hpa.Delete(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to delete HPA",
+				slog.String("name", name),
+				slog.String("namespace", namespace),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete HPA: %s", classifyError(err))), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func setHPABoundsHandler(cm kai.ClusterManager, factory HPAFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "set_hpa_bounds"))
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		params := kai.HPAParams{Name: name}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			params.Namespace = ns
+		}
+
+		var minReplicas, maxReplicas *int32
+		if v, ok := request.GetArguments()["min_replicas"].(float64); ok {
+			n := int32(v)
+			minReplicas = &n
+		}
+		if v, ok := request.GetArguments()["max_replicas"].(float64); ok {
+			n := int32(v)
+			maxReplicas = &n
+		}
+
+		result, err := factory.NewHPA(params).SetBounds(ctx, cm, minReplicas, maxReplicas)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to set HPA bounds: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
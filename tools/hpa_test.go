@@ -0,0 +1,176 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/basebandit/kai"
+	"github.com/basebandit/kai/testmocks"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterHPATools(t *testing.T) {
+	mockServer := &testmocks.MockServer{}
+	mockCM := testmocks.NewMockClusterManager()
+
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(5)
+
+	RegisterHPATools(mockServer, mockCM)
+
+	mockServer.AssertExpectations(t)
+}
+
+func TestCreateHPAHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           map[string]any
+		mockSetup      func(*testmocks.MockClusterManager, *testmocks.MockHPAFactory, *testmocks.MockHPA)
+		expectedOutput string
+	}{
+		{
+			name: "Create with CPU target",
+			args: map[string]any{
+				"name":            "web",
+				"target_name":     "web",
+				"min_replicas":    float64(2),
+				"max_replicas":    float64(10),
+				"cpu_utilization": float64(70),
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockHPAFactory, mockHPA *testmocks.MockHPA) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockFactory.On("NewHPA", mock.MatchedBy(func(params kai.HPAParams) bool {
+					return params.Name == "web" &&
+						params.Namespace == defaultNamespace &&
+						params.TargetName == "web" &&
+						*params.MinReplicas == 2 &&
+						params.MaxReplicas == 10 &&
+						*params.CPUUtilization == 70 &&
+						params.MemoryUtilization == nil
+				})).Return(mockHPA)
+				mockHPA.On("Create", mock.Anything, mockCM).Return("HPA \"web\" created successfully in namespace \"default\"", nil)
+			},
+			expectedOutput: "HPA \"web\" created successfully",
+		},
+		{
+			name: "Custom metrics are passed through",
+			args: map[string]any{
+				"name":           "web",
+				"namespace":      "apps",
+				"target_name":    "web",
+				"max_replicas":   float64(5),
+				"custom_metrics": []any{map[string]any{"name": "rps", "average_value": "100"}},
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockHPAFactory, mockHPA *testmocks.MockHPA) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockFactory.On("NewHPA", mock.MatchedBy(func(params kai.HPAParams) bool {
+					return params.Namespace == "apps" && len(params.CustomMetrics) == 1
+				})).Return(mockHPA)
+				mockHPA.On("Create", mock.Anything, mockCM).Return("HPA \"web\" created successfully in namespace \"apps\"", nil)
+			},
+			expectedOutput: "created successfully",
+		},
+		{
+			name: "Missing target name",
+			args: map[string]any{
+				"name":         "web",
+				"max_replicas": float64(5),
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockHPAFactory, mockHPA *testmocks.MockHPA) {
+			},
+			expectedOutput: "'target_name' is missing",
+		},
+		{
+			name: "Missing max replicas",
+			args: map[string]any{
+				"name":        "web",
+				"target_name": "web",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockHPAFactory, mockHPA *testmocks.MockHPA) {
+			},
+			expectedOutput: "'max_replicas' is missing",
+		},
+		{
+			name: "Create error",
+			args: map[string]any{
+				"name":         "web",
+				"target_name":  "web",
+				"max_replicas": float64(5),
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockHPAFactory, mockHPA *testmocks.MockHPA) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockFactory.On("NewHPA", mock.Anything).Return(mockHPA)
+				mockHPA.On("Create", mock.Anything, mockCM).Return("", errors.New("already exists"))
+			},
+			expectedOutput: "Failed to create HPA: already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCM := &testmocks.MockClusterManager{}
+			mockFactory := &testmocks.MockHPAFactory{}
+			mockHPA := &testmocks.MockHPA{}
+			tt.mockSetup(mockCM, mockFactory, mockHPA)
+
+			handler := createHPAHandler(mockCM, mockFactory)
+			request := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), request)
+			assert.NoError(t, err)
+			assert.Contains(t, result.Content[0].(mcp.TextContent).Text, tt.expectedOutput)
+
+			mockCM.AssertExpectations(t)
+			mockFactory.AssertExpectations(t)
+			mockHPA.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetHPAHandler(t *testing.T) {
+	mockCM := &testmocks.MockClusterManager{}
+	mockFactory := &testmocks.MockHPAFactory{}
+	mockHPA := &testmocks.MockHPA{}
+
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+	mockFactory.On("NewHPA", kai.HPAParams{Name: "web", Namespace: defaultNamespace}).Return(mockHPA)
+	mockHPA.On("Get", mock.Anything, mockCM).Return("Replicas: current 2, desired 4 (min 1, max 10)", nil)
+
+	handler := getHPAHandler(mockCM, mockFactory)
+	result, err := handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"name": "web"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "current 2, desired 4")
+	mockCM.AssertExpectations(t)
+	mockFactory.AssertExpectations(t)
+	mockHPA.AssertExpectations(t)
+}
+
+func TestDeleteHPAHandler(t *testing.T) {
+	mockCM := &testmocks.MockClusterManager{}
+	mockFactory := &testmocks.MockHPAFactory{}
+	mockHPA := &testmocks.MockHPA{}
+
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+	mockFactory.On("NewHPA", kai.HPAParams{Name: "web", Namespace: "apps"}).Return(mockHPA)
+	mockHPA.On("Delete", mock.Anything, mockCM).Return("", errors.New("HPA \"web\" not found in namespace \"apps\""))
+
+	handler := deleteHPAHandler(mockCM, mockFactory)
+	result, err := handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]any{"name": "web", "namespace": "apps"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "Failed to delete HPA")
+	mockCM.AssertExpectations(t)
+	mockFactory.AssertExpectations(t)
+	mockHPA.AssertExpectations(t)
+}
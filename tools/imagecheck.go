@@ -0,0 +1,36 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/basebandit/kai"
+	"github.com/basebandit/kai/cluster"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// verifyImageOption declares the verify_image parameter of tools that create
+// workloads from an image.
+func verifyImageOption() mcp.ToolOption {
+	return mcp.WithBoolean("verify_image",
+		mcp.Description("Check that the image exists in its registry before creating, using image_pull_secrets for private registries. Best effort: registries that cannot be reached are skipped"),
+	)
+}
+
+// checkImage runs the registry precheck when the request sets
+// verify_image=true, returning a result to send back in place of creating
+// the workload when the image is missing.
+func checkImage(ctx context.Context, cm kai.ClusterManager, request mcp.CallToolRequest, namespace, image string, pullSecrets []interface{}) *mcp.CallToolResult {
+	if verify, _ := request.GetArguments()["verify_image"].(bool); !verify {
+		return nil
+	}
+	var secrets []string
+	for _, s := range pullSecrets {
+		if name, ok := s.(string); ok && name != "" {
+			secrets = append(secrets, name)
+		}
+	}
+	if err := cluster.VerifyImage(ctx, cm, namespace, image, secrets); err != nil {
+		return mcp.NewToolResultText(err.Error())
+	}
+	return nil
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
 
 	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/cluster"
 	"github.com/mark3labs/mcp-go/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 )
 
 // IngressFactory is an interface for creating Ingress operators.
@@ -34,6 +39,8 @@ func (f *DefaultIngressFactory) NewIngress(params kai.IngressParams) kai.Ingress
 		Rules:            params.Rules,
 		TLS:              params.TLS,
 		DefaultBackend:   params.DefaultBackend,
+		DryRun:           params.DryRun,
+		ShowDiff:         params.ShowDiff,
 	}
 }
 
@@ -73,6 +80,7 @@ func RegisterIngressToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManage
 		mcp.WithObject("annotations",
 			mcp.Description("Annotations to apply to the Ingress (e.g., for ingress controller configuration)"),
 		),
+		dryRunOption(),
 	)
 	s.AddTool(createIngressTool, createIngressHandler(cm, factory))
 
@@ -86,21 +94,37 @@ func RegisterIngressToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManage
 		mcp.WithString("namespace",
 			mcp.Description("Namespace of the Ingress (defaults to current namespace)"),
 		),
+		outputOption(),
 	)
 	s.AddTool(getIngressTool, getIngressHandler(cm, factory))
 
+	describeIngressTool := mcp.NewTool("describe_ingress",
+		mcp.WithDescription("Describe an Ingress: its load balancer address, class, each rule's host/path → service:port with ready endpoint counts, TLS hosts and secrets, and recent events"),
+		readOnlyAnnotation("Describe ingress"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the Ingress"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the Ingress (defaults to current namespace)"),
+		),
+	)
+	s.AddTool(describeIngressTool, describeIngressHandler(cm, factory))
+
 	listIngressesTool := mcp.NewTool("list_ingresses",
 		mcp.WithDescription("List Ingresses in the current namespace or across all namespaces"),
 		readOnlyAnnotation("List ingresses"),
 		mcp.WithBoolean("all_namespaces",
 			mcp.Description("Whether to list Ingresses across all namespaces"),
 		),
+		confirmScanOption(),
 		mcp.WithString("namespace",
 			mcp.Description("Specific namespace to list Ingresses from (defaults to current namespace)"),
 		),
 		mcp.WithString("label_selector",
 			mcp.Description("Label selector to filter Ingresses (e.g., 'app=nginx,env=prod')"),
 		),
+		outputOption(),
 	)
 	s.AddTool(listIngressesTool, listIngressesHandler(cm, factory))
 
@@ -132,6 +156,8 @@ func RegisterIngressToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManage
 		mcp.WithObject("annotations",
 			mcp.Description("Annotations to add/update on the Ingress"),
 		),
+		dryRunOption(),
+		showDiffOption(),
 	)
 	s.AddTool(updateIngressTool, updateIngressHandler(cm, factory))
 
@@ -147,6 +173,38 @@ func RegisterIngressToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManage
 		),
 	)
 	s.AddTool(deleteIngressTool, deleteIngressHandler(cm, factory))
+
+	createIngressForServiceTool := mcp.NewTool("create_ingress_for_service",
+		mcp.WithDescription("Publish an existing service through a new Ingress that routes a host and path to one of the service's ports"),
+		creationAnnotation("Create ingress for service"),
+		mcp.WithString("service",
+			mcp.Required(),
+			mcp.Description("Name of the service to route to; it must exist in the namespace"),
+		),
+		mcp.WithString("host",
+			mcp.Description("Host to match, e.g. 'app.example.com' (omit to match any host)"),
+		),
+		mcp.WithString("path",
+			mcp.Description("Path to match (defaults to '/')"),
+		),
+		mcp.WithString("path_type",
+			mcp.Description("Path type: Prefix (default), Exact or ImplementationSpecific"),
+		),
+		mcp.WithString("port",
+			mcp.Description("Service port number or name (defaults to the service's only port)"),
+		),
+		mcp.WithString("name",
+			mcp.Description("Name of the Ingress (defaults to the service name)"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the service and Ingress (defaults to current namespace)"),
+		),
+		mcp.WithString("ingress_class",
+			mcp.Description("Ingress class name (e.g., 'nginx', 'traefik')"),
+		),
+		dryRunOption(),
+	)
+	s.AddTool(createIngressForServiceTool, createIngressForServiceHandler(cm, factory))
 }
 
 func createIngressHandler(cm kai.ClusterManager, factory IngressFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -163,6 +221,10 @@ func createIngressHandler(cm kai.ClusterManager, factory IngressFactory) func(ct
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
+		if err := validateResourceName(name); err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
 		rulesArg, hasRules := request.GetArguments()["rules"]
 		defaultBackendArg, hasDefaultBackend := request.GetArguments()["default_backend"]
 		if !hasRules && !hasDefaultBackend {
@@ -181,7 +243,7 @@ func createIngressHandler(cm kai.ClusterManager, factory IngressFactory) func(ct
 			}
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -196,6 +258,9 @@ func createIngressHandler(cm kai.ClusterManager, factory IngressFactory) func(ct
 		}
 
 		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			params.Labels = labelsArg
 		}
 
@@ -230,6 +295,7 @@ func createIngressHandler(cm kai.ClusterManager, factory IngressFactory) func(ct
 			params.TLS = tls
 		}
 
+		params.DryRun = dryRunArg(request)
 		ingress := factory.NewIngress(params)
 		result, err := ingress.Create(ctx, cm)
 		if err != nil {
@@ -238,7 +304,7 @@ func createIngressHandler(cm kai.ClusterManager, factory IngressFactory) func(ct
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to create Ingress: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to create Ingress: %s", classifyError(err))), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -259,11 +325,21 @@ func getIngressHandler(cm kai.ClusterManager, factory IngressFactory) func(ctx c
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
 
+		format, errResult := outputFormat(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if format != outputText {
+			return structuredResult(ctx, cm, format, func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error) {
+				return client.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
+			}), nil
+		}
+
 		params := kai.IngressParams{
 			Name:      name,
 			Namespace: namespace,
@@ -277,7 +353,47 @@ func getIngressHandler(cm kai.ClusterManager, factory IngressFactory) func(ctx c
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to get Ingress: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get Ingress: %s", classifyError(err))), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+// describeIngressHandler handles the describe_ingress tool
+func describeIngressHandler(cm kai.ClusterManager, factory IngressFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "describe_ingress"))
+
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.IngressParams{
+			Name:      name,
+			Namespace: namespace,
+		}
+
+		ingress := factory.NewIngress(params)
+		result, err := ingress.Describe(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to describe Ingress",
+				slog.String("name", name),
+				slog.String("namespace", namespace),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to describe Ingress: %s", err.Error())), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -293,12 +409,18 @@ func listIngressesHandler(cm kai.ClusterManager, factory IngressFactory) func(ct
 			allNamespaces = allNamespacesArg
 		}
 
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
+
 		var namespace string
 		if !allNamespaces {
 			if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 				namespace = namespaceArg
 			} else {
-				namespace = cm.GetCurrentNamespace()
+				namespace = kai.CurrentNamespace(ctx, cm)
 			}
 		}
 
@@ -307,6 +429,16 @@ func listIngressesHandler(cm kai.ClusterManager, factory IngressFactory) func(ct
 			labelSelector = labelSelectorArg
 		}
 
+		format, errResult := outputFormat(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if format != outputText {
+			return structuredResult(ctx, cm, format, func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error) {
+				return client.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+			}), nil
+		}
+
 		params := kai.IngressParams{
 			Namespace: namespace,
 		}
@@ -341,7 +473,7 @@ func updateIngressHandler(cm kai.ClusterManager, factory IngressFactory) func(ct
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -356,6 +488,9 @@ func updateIngressHandler(cm kai.ClusterManager, factory IngressFactory) func(ct
 		}
 
 		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			params.Labels = labelsArg
 		}
 
@@ -390,6 +525,8 @@ func updateIngressHandler(cm kai.ClusterManager, factory IngressFactory) func(ct
 			params.TLS = tls
 		}
 
+		params.DryRun = dryRunArg(request)
+		params.ShowDiff = showDiffArg(request)
 		ingress := factory.NewIngress(params)
 		result, err := ingress.Update(ctx, cm)
 		if err != nil {
@@ -419,7 +556,7 @@ func deleteIngressHandler(cm kai.ClusterManager, factory IngressFactory) func(ct
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -437,7 +574,87 @@ func deleteIngressHandler(cm kai.ClusterManager, factory IngressFactory) func(ct
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete Ingress: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete Ingress: %s", classifyError(err))), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func createIngressForServiceHandler(cm kai.ClusterManager, factory IngressFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "create_ingress_for_service"))
+
+		serviceArg, ok := request.GetArguments()["service"]
+		if !ok || serviceArg == nil {
+			return mcp.NewToolResultText("Required parameter 'service' is missing"), nil
+		}
+
+		service, ok := serviceArg.(string)
+		if !ok || service == "" {
+			return mcp.NewToolResultText("Parameter 'service' must be a non-empty string"), nil
+		}
+
+		name := service
+		if nameArg, ok := request.GetArguments()["name"].(string); ok && nameArg != "" {
+			name = nameArg
+		}
+		if err := validateResourceName(name); err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		path := kai.IngressPath{ServiceName: service}
+		if pathArg, ok := request.GetArguments()["path"].(string); ok {
+			if pathArg != "" && !strings.HasPrefix(pathArg, "/") {
+				return mcp.NewToolResultText("Parameter 'path' must start with '/'"), nil
+			}
+			path.Path = pathArg
+		}
+		if pathTypeArg, ok := request.GetArguments()["path_type"].(string); ok {
+			path.PathType = pathTypeArg
+		}
+
+		switch port := request.GetArguments()["port"].(type) {
+		case nil:
+		case float64:
+			path.ServicePort = int32(port)
+		case string:
+			if number, err := strconv.ParseInt(port, 10, 32); err == nil {
+				path.ServicePort = int32(number)
+			} else if port != "" {
+				path.ServicePort = port
+			}
+		default:
+			return mcp.NewToolResultText("Parameter 'port' must be a port number or name"), nil
+		}
+
+		host, _ := request.GetArguments()["host"].(string)
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.IngressParams{
+			Name:      name,
+			Namespace: namespace,
+		}
+
+		if ingressClassArg, ok := request.GetArguments()["ingress_class"].(string); ok && ingressClassArg != "" {
+			params.IngressClassName = ingressClassArg
+		}
+
+		params.DryRun = dryRunArg(request)
+		ingress := factory.NewIngress(params)
+		result, err := ingress.CreateForService(ctx, cm, host, path)
+		if err != nil {
+			slog.Warn("failed to create Ingress for service",
+				slog.String("name", name),
+				slog.String("namespace", namespace),
+				slog.String("service", service),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to create Ingress: %s", err.Error())), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
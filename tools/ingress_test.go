@@ -2,6 +2,7 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/basebandit/kai"
@@ -353,6 +354,45 @@ func TestGetIngressHandler(t *testing.T) {
 	}
 }
 
+func TestDescribeIngressHandler(t *testing.T) {
+	t.Run("Describe", func(t *testing.T) {
+		mockCM := &testmocks.MockClusterManager{}
+		mockFactory := &testmocks.MockIngressFactory{}
+		mockIngress := &testmocks.MockIngress{}
+		mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+		mockFactory.On("NewIngress", kai.IngressParams{Name: "shop", Namespace: defaultNamespace}).Return(mockIngress)
+		mockIngress.On("Describe", mock.Anything, mockCM).Return("Ingress: shop\nAddress: <pending>", nil)
+
+		result, err := describeIngressHandler(mockCM, mockFactory)(context.Background(), toolRequest(map[string]any{"name": "shop"}))
+
+		assert.NoError(t, err)
+		assert.Contains(t, resultText(t, result), "Address: <pending>")
+		mockCM.AssertExpectations(t)
+		mockFactory.AssertExpectations(t)
+		mockIngress.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockCM := &testmocks.MockClusterManager{}
+		mockFactory := &testmocks.MockIngressFactory{}
+		mockIngress := &testmocks.MockIngress{}
+		mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+		mockFactory.On("NewIngress", kai.IngressParams{Name: "shop", Namespace: "prod"}).Return(mockIngress)
+		mockIngress.On("Describe", mock.Anything, mockCM).Return("", assert.AnError)
+
+		result, err := describeIngressHandler(mockCM, mockFactory)(context.Background(), toolRequest(map[string]any{"name": "shop", "namespace": "prod"}))
+
+		assert.NoError(t, err)
+		assert.Contains(t, resultText(t, result), "Failed to describe Ingress")
+	})
+
+	t.Run("MissingName", func(t *testing.T) {
+		result, err := describeIngressHandler(&testmocks.MockClusterManager{}, &testmocks.MockIngressFactory{})(context.Background(), toolRequest(map[string]any{}))
+		assert.NoError(t, err)
+		assert.Equal(t, errMissingName, resultText(t, result))
+	})
+}
+
 func TestListIngressesHandler(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -672,6 +712,117 @@ func TestDeleteIngressHandler(t *testing.T) {
 	}
 }
 
+func TestCreateIngressForServiceHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           map[string]any
+		mockSetup      func(*testmocks.MockClusterManager, *testmocks.MockIngressFactory, *testmocks.MockIngress)
+		expectedOutput string
+	}{
+		{
+			name: "Defaults name and port from service",
+			args: map[string]any{
+				"service": "web",
+				"host":    "web.example.com",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockIngressFactory, mockIngress *testmocks.MockIngress) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockFactory.On("NewIngress", kai.IngressParams{Name: "web", Namespace: defaultNamespace}).Return(mockIngress)
+				mockIngress.On("CreateForService", mock.Anything, mockCM, "web.example.com", kai.IngressPath{ServiceName: "web"}).
+					Return("Ingress \"web\" created successfully in namespace \"default\"", nil)
+			},
+			expectedOutput: "Ingress \"web\" created successfully",
+		},
+		{
+			name: "Explicit name, path and numeric port",
+			args: map[string]any{
+				"service":       "web",
+				"name":          "web-public",
+				"path":          "/app",
+				"path_type":     "Exact",
+				"port":          "8080",
+				"namespace":     "apps",
+				"ingress_class": "nginx",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockIngressFactory, mockIngress *testmocks.MockIngress) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockFactory.On("NewIngress", kai.IngressParams{Name: "web-public", Namespace: "apps", IngressClassName: "nginx"}).Return(mockIngress)
+				mockIngress.On("CreateForService", mock.Anything, mockCM, "", kai.IngressPath{Path: "/app", PathType: "Exact", ServiceName: "web", ServicePort: int32(8080)}).
+					Return("Ingress \"web-public\" created successfully in namespace \"apps\" (Class: nginx)", nil)
+			},
+			expectedOutput: "(Class: nginx)",
+		},
+		{
+			name: "Named port",
+			args: map[string]any{
+				"service": "api",
+				"port":    "grpc",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockIngressFactory, mockIngress *testmocks.MockIngress) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockFactory.On("NewIngress", mock.Anything).Return(mockIngress)
+				mockIngress.On("CreateForService", mock.Anything, mockCM, "", kai.IngressPath{ServiceName: "api", ServicePort: "grpc"}).
+					Return("Ingress \"api\" created successfully", nil)
+			},
+			expectedOutput: "created successfully",
+		},
+		{
+			name: "Missing service",
+			args: map[string]any{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockIngressFactory, mockIngress *testmocks.MockIngress) {
+			},
+			expectedOutput: "Required parameter 'service' is missing",
+		},
+		{
+			name: "Relative path",
+			args: map[string]any{
+				"service": "web",
+				"path":    "app",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockIngressFactory, mockIngress *testmocks.MockIngress) {
+			},
+			expectedOutput: "must start with '/'",
+		},
+		{
+			name: "Service not found",
+			args: map[string]any{
+				"service": "web",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockIngressFactory, mockIngress *testmocks.MockIngress) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockFactory.On("NewIngress", mock.Anything).Return(mockIngress)
+				mockIngress.On("CreateForService", mock.Anything, mockCM, "", mock.Anything).
+					Return("", errors.New("service \"web\" not found in namespace \"default\""))
+			},
+			expectedOutput: "Failed to create Ingress: service \"web\" not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCM := &testmocks.MockClusterManager{}
+			mockFactory := &testmocks.MockIngressFactory{}
+			mockIngress := &testmocks.MockIngress{}
+			tt.mockSetup(mockCM, mockFactory, mockIngress)
+
+			handler := createIngressForServiceHandler(mockCM, mockFactory)
+			request := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), request)
+			assert.NoError(t, err)
+			assert.Contains(t, result.Content[0].(mcp.TextContent).Text, tt.expectedOutput)
+
+			mockCM.AssertExpectations(t)
+			mockFactory.AssertExpectations(t)
+			mockIngress.AssertExpectations(t)
+		})
+	}
+}
+
 func TestNewDefaultIngressFactory(t *testing.T) {
 	factory := NewDefaultIngressFactory()
 	assert.NotNil(t, factory)
@@ -715,7 +866,7 @@ func TestRegisterIngressTools(t *testing.T) {
 	mockServer := new(testmocks.MockServer)
 	mockCM := testmocks.NewMockClusterManager()
 
-	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(5)
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(7)
 
 	RegisterIngressTools(mockServer, mockCM)
 
@@ -727,7 +878,7 @@ func TestRegisterIngressToolsWithFactory(t *testing.T) {
 	mockCM := testmocks.NewMockClusterManager()
 	mockFactory := new(testmocks.MockIngressFactory)
 
-	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(5)
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(7)
 
 	RegisterIngressToolsWithFactory(mockServer, mockCM, mockFactory)
 
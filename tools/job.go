@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
+	"time"
 
 	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/cluster"
@@ -26,19 +28,23 @@ func NewDefaultJobFactory() *DefaultJobFactory {
 // NewJob creates a new Job operator.
 func (f *DefaultJobFactory) NewJob(params kai.JobParams) kai.JobOperator {
 	return &cluster.Job{
-		Name:             params.Name,
-		Namespace:        params.Namespace,
-		Image:            params.Image,
-		Command:          params.Command,
-		Args:             params.Args,
-		RestartPolicy:    params.RestartPolicy,
-		BackoffLimit:     params.BackoffLimit,
-		Completions:      params.Completions,
-		Parallelism:      params.Parallelism,
-		Labels:           params.Labels,
-		Env:              params.Env,
-		ImagePullPolicy:  params.ImagePullPolicy,
-		ImagePullSecrets: params.ImagePullSecrets,
+		Name:              params.Name,
+		Namespace:         params.Namespace,
+		Image:             params.Image,
+		Command:           params.Command,
+		Args:              params.Args,
+		RestartPolicy:     params.RestartPolicy,
+		BackoffLimit:      params.BackoffLimit,
+		Completions:       params.Completions,
+		Parallelism:       params.Parallelism,
+		Labels:            params.Labels,
+		Env:               params.Env,
+		ImagePullPolicy:   params.ImagePullPolicy,
+		ImagePullSecrets:  params.ImagePullSecrets,
+		FailOnExitCodes:   params.FailOnExitCodes,
+		IgnoreDisruptions: params.IgnoreDisruptions,
+		DryRun:            params.DryRun,
+		ShowDiff:          params.ShowDiff,
 	}
 }
 
@@ -74,7 +80,14 @@ func RegisterJobToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, f
 			mcp.Description("Restart policy for the pod (OnFailure, Never)"),
 		),
 		mcp.WithNumber("backoff_limit",
-			mcp.Description("Number of retries before marking the Job as failed"),
+			mcp.Description("Number of retries before marking the Job as failed (non-negative)"),
+		),
+		mcp.WithArray("fail_on_exit_codes",
+			mcp.Description("Container exit codes that fail the Job immediately instead of retrying; needs restart_policy Never and Kubernetes 1.26+"),
+			mcp.WithNumberItems(),
+		),
+		mcp.WithBoolean("ignore_disruptions",
+			mcp.Description("Don't count pods lost to disruptions such as node drains or preemption against backoff_limit; needs restart_policy Never and Kubernetes 1.26+"),
 		),
 		mcp.WithNumber("completions",
 			mcp.Description("Number of successful pod completions needed"),
@@ -94,6 +107,8 @@ func RegisterJobToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, f
 		mcp.WithArray("image_pull_secrets",
 			mcp.Description("Image pull secrets for private registries"),
 		),
+		verifyImageOption(),
+		dryRunOption(),
 	)
 	s.AddTool(createJobTool, createJobHandler(cm, factory))
 
@@ -116,6 +131,7 @@ func RegisterJobToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, f
 		mcp.WithBoolean("all_namespaces",
 			mcp.Description("Whether to list Jobs across all namespaces"),
 		),
+		confirmScanOption(),
 		mcp.WithString("namespace",
 			mcp.Description("Specific namespace to list Jobs from (defaults to current namespace)"),
 		),
@@ -154,8 +170,45 @@ func RegisterJobToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, f
 		mcp.WithNumber("parallelism",
 			mcp.Description("Number of pods to run in parallel"),
 		),
+		dryRunOption(),
+		showDiffOption(),
 	)
 	s.AddTool(updateJobTool, updateJobHandler(cm, factory))
+
+	logsJobTool := mcp.NewTool("logs_job",
+		mcp.WithDescription("Get logs from all pods of a Job, each line prefixed with its pod name"),
+		readOnlyAnnotation("Job logs"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the Job"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the Job (defaults to current namespace)"),
+		),
+		mcp.WithNumber("tail",
+			mcp.Description("Number of lines to show from the end of each pod's logs"),
+		),
+		mcp.WithString("since",
+			mcp.Description("Only return logs newer than a relative duration like 5s, 2m, or 3h"),
+		),
+	)
+	s.AddTool(logsJobTool, logsJobHandler(cm, factory))
+
+	waitJobTool := mcp.NewTool("wait_job",
+		mcp.WithDescription("Wait for a Job to complete or fail and report the outcome"),
+		readOnlyAnnotation("Wait for job"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the Job"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the Job (defaults to current namespace)"),
+		),
+		mcp.WithString("timeout",
+			mcp.Description("How long to wait, like 30s or 2m (defaults to 60s, capped at 5m)"),
+		),
+	)
+	s.AddTool(waitJobTool, waitJobHandler(cm, factory))
 }
 
 func createJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -172,6 +225,10 @@ func createJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx contex
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
+		if err := validateResourceName(name); err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
 		imageArg, ok := request.GetArguments()["image"]
 		if !ok || imageArg == nil {
 			return mcp.NewToolResultText(errMissingImage), nil
@@ -182,7 +239,7 @@ func createJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx contex
 			return mcp.NewToolResultText(errEmptyImage), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -210,6 +267,20 @@ func createJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx contex
 			params.BackoffLimit = &backoffLimit
 		}
 
+		if exitCodesArg, ok := request.GetArguments()["fail_on_exit_codes"].([]interface{}); ok {
+			for _, c := range exitCodesArg {
+				code, ok := c.(float64)
+				if !ok || code != math.Trunc(code) || code < math.MinInt32 || code > math.MaxInt32 {
+					return mcp.NewToolResultText(fmt.Sprintf("Invalid exit code %v in fail_on_exit_codes: must be an integer", c)), nil
+				}
+				params.FailOnExitCodes = append(params.FailOnExitCodes, int32(code))
+			}
+		}
+
+		if ignoreDisruptionsArg, ok := request.GetArguments()["ignore_disruptions"].(bool); ok {
+			params.IgnoreDisruptions = ignoreDisruptionsArg
+		}
+
 		if completionsArg, ok := request.GetArguments()["completions"].(float64); ok {
 			completions := int32(completionsArg)
 			params.Completions = &completions
@@ -221,6 +292,9 @@ func createJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx contex
 		}
 
 		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			params.Labels = labelsArg
 		}
 
@@ -236,6 +310,11 @@ func createJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx contex
 			params.ImagePullSecrets = imagePullSecretsArg
 		}
 
+		if result := checkImage(ctx, cm, request, params.Namespace, params.Image, params.ImagePullSecrets); result != nil {
+			return result, nil
+		}
+
+		params.DryRun = dryRunArg(request)
 		job := factory.NewJob(params)
 		result, err := job.Create(ctx, cm)
 		if err != nil {
@@ -244,7 +323,7 @@ func createJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx contex
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to create Job: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to create Job: %s", classifyError(err))), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -265,7 +344,7 @@ func getJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx context.C
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -283,7 +362,7 @@ func getJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx context.C
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to get Job: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get Job: %s", classifyError(err))), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -299,12 +378,18 @@ func listJobsHandler(cm kai.ClusterManager, factory JobFactory) func(ctx context
 			allNamespaces = allNamespacesArg
 		}
 
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
+
 		var namespace string
 		if !allNamespaces {
 			if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 				namespace = namespaceArg
 			} else {
-				namespace = cm.GetCurrentNamespace()
+				namespace = kai.CurrentNamespace(ctx, cm)
 			}
 		}
 
@@ -347,7 +432,7 @@ func deleteJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx contex
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -365,7 +450,7 @@ func deleteJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx contex
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete Job: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete Job: %s", classifyError(err))), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -384,7 +469,7 @@ func updateJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx contex
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -395,6 +480,9 @@ func updateJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx contex
 		}
 
 		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			params.Labels = labelsArg
 		}
 
@@ -403,6 +491,8 @@ func updateJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx contex
 			params.Parallelism = &parallelism
 		}
 
+		params.DryRun = dryRunArg(request)
+		params.ShowDiff = showDiffArg(request)
 		job := factory.NewJob(params)
 		result, err := job.Update(ctx, cm)
 		if err != nil {
@@ -412,3 +502,102 @@ func updateJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx contex
 		return mcp.NewToolResultText(result), nil
 	}
 }
+
+func logsJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "logs_job"))
+
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		var tailLines int64
+		if tailArg, ok := request.GetArguments()["tail"].(float64); ok {
+			tailLines = int64(tailArg)
+		}
+
+		var sinceDuration *time.Duration
+		if sinceArg, ok := request.GetArguments()["since"].(string); ok && sinceArg != "" {
+			duration, err := time.ParseDuration(sinceArg)
+			if err != nil {
+				return mcp.NewToolResultText(fmt.Sprintf("Failed to parse 'since' parameter: %v", err)), nil
+			}
+			sinceDuration = &duration
+		}
+
+		params := kai.JobParams{
+			Name:      name,
+			Namespace: namespace,
+		}
+
+		job := factory.NewJob(params)
+		result, err := job.Logs(ctx, cm, tailLines, sinceDuration)
+		if err != nil {
+			slog.Warn("failed to get Job logs",
+				slog.String("name", name),
+				slog.String("namespace", namespace),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get Job logs: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func waitJobHandler(cm kai.ClusterManager, factory JobFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "wait_job"))
+
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		var timeout time.Duration
+		if timeoutArg, ok := request.GetArguments()["timeout"].(string); ok && timeoutArg != "" {
+			parsed, err := time.ParseDuration(timeoutArg)
+			if err != nil {
+				return mcp.NewToolResultText(fmt.Sprintf("Failed to parse 'timeout' parameter: %v", err)), nil
+			}
+			timeout = parsed
+		}
+
+		job := factory.NewJob(kai.JobParams{
+			Name:      name,
+			Namespace: namespace,
+		})
+		result, err := job.WaitForCompletion(ctx, cm, timeout)
+		if err != nil {
+			slog.Warn("failed to wait for Job",
+				slog.String("name", name),
+				slog.String("namespace", namespace),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to wait for Job: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
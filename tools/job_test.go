@@ -2,7 +2,9 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/testmocks"
@@ -68,6 +70,42 @@ func TestCreateJobHandler(t *testing.T) {
 			expectedOutput: "Job \"full-job\" created successfully",
 			expectedError:  false,
 		},
+		{
+			name: "Create Job with pod failure policy",
+			args: map[string]any{
+				"name":               "flaky-job",
+				"image":              "busybox:latest",
+				"restart_policy":     "Never",
+				"backoff_limit":      float64(6),
+				"fail_on_exit_codes": []any{float64(42), float64(3)},
+				"ignore_disruptions": true,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockJobFactory, mockJob *testmocks.MockJob) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockFactory.On("NewJob", mock.MatchedBy(func(params kai.JobParams) bool {
+					return params.Name == "flaky-job" &&
+						*params.BackoffLimit == int32(6) &&
+						assert.ObjectsAreEqual([]int32{42, 3}, params.FailOnExitCodes) &&
+						params.IgnoreDisruptions
+				})).Return(mockJob)
+				mockJob.On("Create", mock.Anything, mockCM).Return("Job \"flaky-job\" created successfully in namespace \"default\"", nil)
+			},
+			expectedOutput: "Job \"flaky-job\" created successfully",
+			expectedError:  false,
+		},
+		{
+			name: "Invalid exit code",
+			args: map[string]any{
+				"name":               "flaky-job",
+				"image":              "busybox:latest",
+				"fail_on_exit_codes": []any{"oops"},
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockJobFactory, mockJob *testmocks.MockJob) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+			},
+			expectedOutput: "Invalid exit code oops in fail_on_exit_codes: must be an integer",
+			expectedError:  false,
+		},
 		{
 			name: "Missing Job name",
 			args: map[string]any{
@@ -91,6 +129,18 @@ func TestCreateJobHandler(t *testing.T) {
 			expectedOutput: errEmptyName,
 			expectedError:  false,
 		},
+		{
+			name: "Invalid Job name",
+			args: map[string]any{
+				"name":  "Nightly_Backup",
+				"image": "busybox:latest",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockJobFactory, mockJob *testmocks.MockJob) {
+				// No mock setup - validation fails before any calls
+			},
+			expectedOutput: "invalid name: must be a lowercase RFC 1123 subdomain",
+			expectedError:  false,
+		},
 		{
 			name: "Missing image",
 			args: map[string]any{
@@ -463,6 +513,20 @@ func TestUpdateJobHandler(t *testing.T) {
 			expectedOutput: errEmptyName,
 			expectedError:  false,
 		},
+		{
+			name: "Invalid label value",
+			args: map[string]any{
+				"name": "test-job",
+				"labels": map[string]any{
+					"owner": "data team",
+				},
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockJobFactory, mockJob *testmocks.MockJob) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+			},
+			expectedOutput: `invalid label value for key "owner"`,
+			expectedError:  false,
+		},
 		{
 			name: "Update Job error",
 			args: map[string]any{
@@ -505,3 +569,149 @@ func TestUpdateJobHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestLogsJobHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           map[string]any
+		mockSetup      func(*testmocks.MockClusterManager, *testmocks.MockJobFactory, *testmocks.MockJob)
+		expectedOutput string
+	}{
+		{
+			name: "Logs with tail",
+			args: map[string]any{
+				"name": "test-job",
+				"tail": float64(20),
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockJobFactory, mockJob *testmocks.MockJob) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockFactory.On("NewJob", mock.MatchedBy(func(params kai.JobParams) bool {
+					return params.Name == "test-job" && params.Namespace == defaultNamespace
+				})).Return(mockJob)
+				mockJob.On("Logs", mock.Anything, mockCM, int64(20), (*time.Duration)(nil)).Return("[test-job-abcde] done", nil)
+			},
+			expectedOutput: "[test-job-abcde] done",
+		},
+		{
+			name: "Invalid since",
+			args: map[string]any{
+				"name":  "test-job",
+				"since": "yesterday",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockJobFactory, mockJob *testmocks.MockJob) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+			},
+			expectedOutput: "Failed to parse 'since' parameter",
+		},
+		{
+			name: "Missing Job name",
+			args: map[string]any{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockJobFactory, mockJob *testmocks.MockJob) {
+				// No mock setup - validation fails before any calls
+			},
+			expectedOutput: errMissingName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCM := &testmocks.MockClusterManager{}
+			mockFactory := &testmocks.MockJobFactory{}
+			mockJob := &testmocks.MockJob{}
+			tt.mockSetup(mockCM, mockFactory, mockJob)
+
+			handler := logsJobHandler(mockCM, mockFactory)
+			request := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), request)
+			assert.NoError(t, err)
+			assert.Contains(t, result.Content[0].(mcp.TextContent).Text, tt.expectedOutput)
+
+			mockCM.AssertExpectations(t)
+			mockFactory.AssertExpectations(t)
+			mockJob.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWaitJobHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           map[string]any
+		mockSetup      func(*testmocks.MockClusterManager, *testmocks.MockJobFactory, *testmocks.MockJob)
+		expectedOutput string
+	}{
+		{
+			name: "Wait with timeout",
+			args: map[string]any{
+				"name":    "test-job",
+				"timeout": "2m",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockJobFactory, mockJob *testmocks.MockJob) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockFactory.On("NewJob", kai.JobParams{Name: "test-job", Namespace: defaultNamespace}).Return(mockJob)
+				mockJob.On("WaitForCompletion", mock.Anything, mockCM, 2*time.Minute).Return(`Job "test-job" completed in 12s (succeeded: 1, failed: 0)`, nil)
+			},
+			expectedOutput: `Job "test-job" completed in 12s`,
+		},
+		{
+			name: "Timed out",
+			args: map[string]any{
+				"name":      "test-job",
+				"namespace": testNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockJobFactory, mockJob *testmocks.MockJob) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockFactory.On("NewJob", kai.JobParams{Name: "test-job", Namespace: testNamespace}).Return(mockJob)
+				mockJob.On("WaitForCompletion", mock.Anything, mockCM, time.Duration(0)).Return("", errors.New(`timed out after 1m0s waiting for Job "test-job" to finish (active: 1, succeeded: 0, failed: 0)`))
+			},
+			expectedOutput: `Failed to wait for Job: timed out after 1m0s`,
+		},
+		{
+			name: "Invalid timeout",
+			args: map[string]any{
+				"name":    "test-job",
+				"timeout": "soon",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockJobFactory, mockJob *testmocks.MockJob) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+			},
+			expectedOutput: "Failed to parse 'timeout' parameter",
+		},
+		{
+			name: "Missing Job name",
+			args: map[string]any{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockJobFactory, mockJob *testmocks.MockJob) {
+			},
+			expectedOutput: errMissingName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCM := &testmocks.MockClusterManager{}
+			mockFactory := &testmocks.MockJobFactory{}
+			mockJob := &testmocks.MockJob{}
+			tt.mockSetup(mockCM, mockFactory, mockJob)
+
+			handler := waitJobHandler(mockCM, mockFactory)
+			request := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), request)
+			assert.NoError(t, err)
+			assert.Contains(t, result.Content[0].(mcp.TextContent).Text, tt.expectedOutput)
+
+			mockCM.AssertExpectations(t)
+			mockFactory.AssertExpectations(t)
+			mockJob.AssertExpectations(t)
+		})
+	}
+}
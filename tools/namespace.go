@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/cluster"
 	"github.com/mark3labs/mcp-go/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func RegisterNamespaceTools(s kai.ServerInterface, cm kai.ClusterManager) {
@@ -24,6 +27,7 @@ func RegisterNamespaceTools(s kai.ServerInterface, cm kai.ClusterManager) {
 		mcp.WithObject("annotations",
 			mcp.Description("Annotations to apply to the namespace"),
 		),
+		dryRunOption(),
 	)
 	s.AddTool(createNamespaceTool, createNamespaceHandler(cm))
 
@@ -71,8 +75,81 @@ func RegisterNamespaceTools(s kai.ServerInterface, cm kai.ClusterManager) {
 		mcp.WithObject("annotations",
 			mcp.Description("Annotations to add or update"),
 		),
+		dryRunOption(),
+		showDiffOption(),
 	)
 	s.AddTool(updateNamespaceTool, updateNamespaceHandler(cm))
+
+	restartNamespaceTool := mcp.NewTool("restart_namespace",
+		mcp.WithDescription("Rollout-restart every Deployment in a namespace, and optionally its StatefulSets and DaemonSets"),
+		destructiveAnnotation("Restart namespace workloads"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the namespace whose workloads to restart"),
+		),
+		mcp.WithBoolean("confirm",
+			mcp.Required(),
+			mcp.Description("Must be true; restarting every workload in a namespace recreates all of its pods"),
+		),
+		mcp.WithBoolean("include_statefulsets",
+			mcp.Description("Also restart StatefulSets (default false)"),
+		),
+		mcp.WithBoolean("include_daemonsets",
+			mcp.Description("Also restart DaemonSets (default false)"),
+		),
+	)
+	s.AddTool(restartNamespaceTool, restartNamespaceHandler(cm))
+
+	hibernateNamespaceTool := mcp.NewTool("hibernate_namespace",
+		mcp.WithDescription("Scale every Deployment and StatefulSet in a namespace to zero, recording each prior replica count in the kai.dev/previous-replicas annotation so wake_namespace can restore it"),
+		destructiveAnnotation("Hibernate namespace"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the namespace to hibernate"),
+		),
+		mcp.WithBoolean("confirm",
+			mcp.Required(),
+			mcp.Description("Must be true; hibernating a namespace stops all of its Deployment and StatefulSet pods"),
+		),
+	)
+	s.AddTool(hibernateNamespaceTool, hibernateNamespaceHandler(cm))
+
+	wakeNamespaceTool := mcp.NewTool("wake_namespace",
+		mcp.WithDescription("Restore every hibernated Deployment and StatefulSet in a namespace to the replica count recorded in its kai.dev/previous-replicas annotation"),
+		idempotentMutationAnnotation("Wake namespace"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the namespace to wake"),
+		),
+		mcp.WithBoolean("confirm",
+			mcp.Required(),
+			mcp.Description("Must be true; waking a namespace starts pods for every hibernated workload"),
+		),
+	)
+	s.AddTool(wakeNamespaceTool, wakeNamespaceHandler(cm))
+
+	diffNamespacesTool := mcp.NewTool("diff_namespaces",
+		mcp.WithDescription("Compare objects of one kind between two namespaces (e.g. staging and prod): objects present in only one, and field differences for shared names. Status and metadata other than labels are ignored."),
+		readOnlyAnnotation("Diff namespaces"),
+		mcp.WithString("kind",
+			mcp.Required(),
+			mcp.Description("Namespaced kind (e.g. 'Deployment') or plural resource name (e.g. 'configmaps') to compare"),
+		),
+		mcp.WithString("from",
+			mcp.Required(),
+			mcp.Description("First namespace, treated as the baseline"),
+		),
+		mcp.WithString("to",
+			mcp.Required(),
+			mcp.Description("Second namespace, compared against the baseline"),
+		),
+		mcp.WithString("label_selector",
+			mcp.Description("Only compare objects matching this label selector (e.g. 'app=web')"),
+		),
+		mcp.WithString("group", mcp.Description("API group of the kind (empty for the core group)")),
+		mcp.WithString("version", mcp.Description("API version of the kind (defaults to the preferred version)")),
+	)
+	s.AddTool(diffNamespacesTool, diffNamespacesHandler(cm))
 }
 
 func createNamespaceHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -89,11 +166,19 @@ func createNamespaceHandler(cm kai.ClusterManager) func(ctx context.Context, req
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
+		if err := validateResourceName(name); err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
 		namespace := cluster.Namespace{
-			Name: name,
+			Name:   name,
+			DryRun: dryRunArg(request),
 		}
 
 		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			namespace.Labels = labelsArg
 		}
 
@@ -107,7 +192,7 @@ func createNamespaceHandler(cm kai.ClusterManager) func(ctx context.Context, req
 				slog.String("name", name),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to create namespace: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to create namespace: %s", classifyError(err))), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -138,7 +223,7 @@ func getNamespaceHandler(cm kai.ClusterManager) func(ctx context.Context, reques
 				slog.String("name", name),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to get namespace: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get namespace: %s", classifyError(err))), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -193,7 +278,7 @@ func deleteNamespaceHandler(cm kai.ClusterManager) func(ctx context.Context, req
 				slog.String("name", namespace.Name),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete namespace: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete namespace: %s", classifyError(err))), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -213,10 +298,15 @@ func updateNamespaceHandler(cm kai.ClusterManager) func(ctx context.Context, req
 		}
 
 		namespace := cluster.Namespace{
-			Name: name,
+			Name:     name,
+			DryRun:   dryRunArg(request),
+			ShowDiff: showDiffArg(request),
 		}
 
 		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			namespace.Labels = labelsArg
 		}
 
@@ -232,3 +322,152 @@ func updateNamespaceHandler(cm kai.ClusterManager) func(ctx context.Context, req
 		return mcp.NewToolResultText(result), nil
 	}
 }
+
+func restartNamespaceHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "restart_namespace"))
+
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		if confirm, _ := request.GetArguments()["confirm"].(bool); !confirm {
+			return mcp.NewToolResultText(fmt.Sprintf("Restarting every workload in namespace %q recreates all of its pods; set confirm=true to proceed", name)), nil
+		}
+
+		includeStatefulSets, _ := request.GetArguments()["include_statefulsets"].(bool)
+		includeDaemonSets, _ := request.GetArguments()["include_daemonsets"].(bool)
+
+		namespace := cluster.Namespace{
+			Name: name,
+		}
+
+		result, err := namespace.RestartWorkloads(ctx, cm, includeStatefulSets, includeDaemonSets)
+		if err != nil {
+			slog.Warn("failed to restart namespace workloads",
+				slog.String("name", name),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to restart namespace workloads: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func hibernateNamespaceHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "hibernate_namespace"))
+
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if confirm, _ := request.GetArguments()["confirm"].(bool); !confirm {
+			return mcp.NewToolResultText(fmt.Sprintf("Hibernating namespace %q scales every Deployment and StatefulSet in it to zero; set confirm=true to proceed", name)), nil
+		}
+
+		namespace := cluster.Namespace{Name: name}
+		result, err := namespace.Hibernate(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to hibernate namespace",
+				slog.String("name", name),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to hibernate namespace: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func wakeNamespaceHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "wake_namespace"))
+
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		if confirm, _ := request.GetArguments()["confirm"].(bool); !confirm {
+			return mcp.NewToolResultText(fmt.Sprintf("Waking namespace %q starts pods for every hibernated workload in it; set confirm=true to proceed", name)), nil
+		}
+
+		namespace := cluster.Namespace{Name: name}
+		result, err := namespace.Wake(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to wake namespace",
+				slog.String("name", name),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to wake namespace: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func diffNamespacesHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "diff_namespaces"))
+
+		diff := cluster.NamespaceDiff{}
+		for _, required := range []struct {
+			param string
+			field *string
+		}{{"kind", &diff.Kind}, {"from", &diff.From}, {"to", &diff.To}} {
+			value, ok := request.GetArguments()[required.param].(string)
+			if !ok || value == "" {
+				return mcp.NewToolResultText(fmt.Sprintf("Required parameter '%s' is missing", required.param)), nil
+			}
+			*required.field = value
+		}
+		diff.LabelSelector, _ = request.GetArguments()["label_selector"].(string)
+		diff.Group, _ = request.GetArguments()["group"].(string)
+		diff.Version, _ = request.GetArguments()["version"].(string)
+
+		result, err := diff.Run(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to diff namespaces",
+				slog.String("from", diff.From),
+				slog.String("to", diff.To),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to diff namespaces: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+// ensureNamespace checks that namespace exists before a create, so a
+// mistyped namespace fails up front with the same message for every kind of
+// resource. A user who may not read namespaces is not blocked; the create
+// itself then reports any problem.
+func ensureNamespace(ctx context.Context, cm kai.ClusterManager, namespace string) error {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return fmt.Errorf("error getting client: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err = client.CoreV1().Namespaces().Get(timeoutCtx, namespace, metav1.GetOptions{})
+	switch {
+	case err == nil, apierrors.IsForbidden(err):
+		return nil
+	case apierrors.IsNotFound(err):
+		return fmt.Errorf("namespace %q not found: %w", namespace, err)
+	default:
+		return fmt.Errorf("failed to check namespace %q: %w", namespace, err)
+	}
+}
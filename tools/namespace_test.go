@@ -11,6 +11,16 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestNamespaceTools(t *testing.T) {
@@ -297,7 +307,7 @@ func TestRegisterNamespaceTools(t *testing.T) {
 	mockServer := &testmocks.MockServer{}
 	mockCM := testmocks.NewMockClusterManager()
 
-	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(5)
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(9)
 
 	RegisterNamespaceTools(mockServer, mockCM)
 
@@ -546,3 +556,204 @@ func testUpdateNamespaceHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestRestartNamespaceHandler(t *testing.T) {
+	t.Run("RestartsDeployments", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: testNamespace}},
+		)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		result, err := restartNamespaceHandler(mockCM)(context.Background(), toolRequest(map[string]interface{}{
+			"name":    testNamespace,
+			"confirm": true,
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, "Restarted 2 workload(s) in namespace \"test-namespace\"\n• Deployment/api\n• Deployment/web", resultText(t, result))
+		mockCM.AssertExpectations(t)
+	})
+
+	t.Run("RequiresConfirm", func(t *testing.T) {
+		for _, args := range []map[string]interface{}{
+			{"name": testNamespace},
+			{"name": testNamespace, "confirm": false},
+			{"name": testNamespace, "confirm": "true"},
+		} {
+			mockCM := testmocks.NewMockClusterManager()
+
+			result, err := restartNamespaceHandler(mockCM)(context.Background(), toolRequest(args))
+			assert.NoError(t, err)
+			assert.Equal(t, `Restarting every workload in namespace "test-namespace" recreates all of its pods; set confirm=true to proceed`, resultText(t, result))
+			mockCM.AssertNotCalled(t, "GetCurrentClient")
+		}
+	})
+
+	t.Run("MissingName", func(t *testing.T) {
+		result, err := restartNamespaceHandler(testmocks.NewMockClusterManager())(context.Background(), toolRequest(map[string]interface{}{"confirm": true}))
+		assert.NoError(t, err)
+		assert.Equal(t, errMissingName, resultText(t, result))
+	})
+}
+
+func TestHibernateNamespaceHandlers(t *testing.T) {
+	ctx := context.Background()
+	replicas := int32(3)
+	fakeClient := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: testNamespace}, Spec: appsv1.DeploymentSpec{Replicas: &replicas}},
+	)
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+	result, err := hibernateNamespaceHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"name": testNamespace, "confirm": true}))
+	assert.NoError(t, err)
+	assert.Equal(t, "Hibernated 1 workload(s) in namespace \"test-namespace\"\n• Deployment/web: 3 → 0 replica(s)", resultText(t, result))
+
+	result, err = wakeNamespaceHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"name": testNamespace, "confirm": true}))
+	assert.NoError(t, err)
+	assert.Equal(t, "Woke 1 workload(s) in namespace \"test-namespace\"\n• Deployment/web: 0 → 3 replica(s)", resultText(t, result))
+
+	t.Run("RequiresConfirm", func(t *testing.T) {
+		unconfirmed := testmocks.NewMockClusterManager()
+		result, err := hibernateNamespaceHandler(unconfirmed)(ctx, toolRequest(map[string]interface{}{"name": testNamespace}))
+		assert.NoError(t, err)
+		assert.Equal(t, `Hibernating namespace "test-namespace" scales every Deployment and StatefulSet in it to zero; set confirm=true to proceed`, resultText(t, result))
+
+		result, err = wakeNamespaceHandler(unconfirmed)(ctx, toolRequest(map[string]interface{}{"name": testNamespace, "confirm": false}))
+		assert.NoError(t, err)
+		assert.Contains(t, resultText(t, result), "set confirm=true to proceed")
+		unconfirmed.AssertNotCalled(t, "GetCurrentClient")
+	})
+
+	t.Run("MissingName", func(t *testing.T) {
+		result, err := wakeNamespaceHandler(testmocks.NewMockClusterManager())(ctx, toolRequest(map[string]interface{}{"confirm": true}))
+		assert.NoError(t, err)
+		assert.Equal(t, errMissingName, resultText(t, result))
+	})
+}
+
+func TestDiffNamespacesHandler(t *testing.T) {
+	ctx := context.Background()
+
+	fakeClient := fake.NewSimpleClientset()
+	fakeClient.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Name: "configmaps", Namespaced: true, Kind: "ConfigMap"}},
+	}}
+	configMapGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		configMapGVR: "ConfigMapList",
+	})
+	for namespace, level := range map[string]string{"staging": "debug", "prod": "info"} {
+		cm := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "app-config", "namespace": namespace},
+			"data":       map[string]interface{}{"LOG_LEVEL": level},
+		}}
+		_, err := dyn.Resource(configMapGVR).Namespace(namespace).Create(ctx, cm, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentDynamicClient").Return(dyn, nil)
+
+	result, err := diffNamespacesHandler(mockCM)(ctx, toolRequest(map[string]interface{}{
+		"kind": "ConfigMap", "from": "staging", "to": "prod",
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, result), `data.LOG_LEVEL: "debug" → "info"`)
+
+	result, err = diffNamespacesHandler(mockCM)(ctx, toolRequest(map[string]interface{}{
+		"kind": "ConfigMap", "from": "staging",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "Required parameter 'to' is missing", resultText(t, result))
+
+	result, err = diffNamespacesHandler(mockCM)(ctx, toolRequest(map[string]interface{}{
+		"kind": "Widget", "from": "staging", "to": "prod",
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, result), "Failed to diff namespaces")
+}
+
+// stubNamespaceCheck lets the create handlers' namespace check pass for any
+// namespace.
+func stubNamespaceCheck(mockCM *testmocks.MockClusterManager) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("get", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		name := action.(k8stesting.GetAction).GetName()
+		return true, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
+	})
+	mockCM.On("GetCurrentClient").Return(client, nil).Maybe()
+}
+
+func TestEnsureNamespace(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Exists", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}), nil)
+		assert.NoError(t, ensureNamespace(ctx, mockCM, testNamespace))
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(), nil)
+		err := ensureNamespace(ctx, mockCM, "stagign")
+		assert.EqualError(t, err, `namespace "stagign" not found: namespaces "stagign" not found`)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("ForbiddenIsNotBlocking", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		client.PrependReactor("get", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, testNamespace, errors.New("namespaced role"))
+		})
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(client, nil)
+		assert.NoError(t, ensureNamespace(ctx, mockCM, testNamespace))
+	})
+
+	t.Run("CreateHandlersRejectMissingNamespace", func(t *testing.T) {
+		requests := map[string]func(kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error){
+			"create_pod": func(cm kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return createPodHandler(cm, new(testmocks.MockPodFactory))
+			},
+			"create_deployment": func(cm kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return createDeploymentHandler(cm, testmocks.NewMockDeploymentFactory())
+			},
+			"create_cronjob": func(cm kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return createCronJobHandler(cm, testmocks.NewMockCronJobFactory())
+			},
+			"create_secret": func(cm kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return createSecretHandler(cm, testmocks.NewMockSecretFactory())
+			},
+			"create_service": func(cm kai.ClusterManager) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return createServiceHandler(cm, testmocks.NewMockServiceFactory())
+			},
+		}
+		args := map[string]interface{}{
+			"name":      "web",
+			"namespace": "stagign",
+			"image":     nginxImage,
+			"schedule":  "*/5 * * * *",
+			"ports":     []interface{}{map[string]interface{}{"port": float64(80)}},
+		}
+		for tool, newHandler := range requests {
+			t.Run(tool, func(t *testing.T) {
+				mockCM := testmocks.NewMockClusterManager()
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace).Maybe()
+				mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(), nil)
+
+				result, err := newHandler(mockCM)(ctx, toolRequest(args))
+				assert.NoError(t, err)
+				assert.Equal(t, `not found: namespace "stagign" not found: namespaces "stagign" not found`, resultText(t, result))
+			})
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/cluster"
@@ -27,6 +28,13 @@ func RegisterNodeTools(s kai.ServerInterface, cm kai.ClusterManager) {
 	)
 	s.AddTool(getNodeTool, getNodeHandler(cm))
 
+	describeNodeTool := mcp.NewTool("describe_node",
+		mcp.WithDescription("Describe a node with its allocatable CPU and memory, taints and conditions"),
+		readOnlyAnnotation("Describe node"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the node")),
+	)
+	s.AddTool(describeNodeTool, describeNodeHandler(cm))
+
 	cordonNodeTool := mcp.NewTool("cordon_node",
 		mcp.WithDescription("Mark a node as unschedulable so no new pods are scheduled onto it"),
 		idempotentMutationAnnotation("Cordon node"),
@@ -48,14 +56,82 @@ func RegisterNodeTools(s kai.ServerInterface, cm kai.ClusterManager) {
 		mcp.WithBoolean("ignore_daemonsets",
 			mcp.Description("Skip DaemonSet-managed pods instead of failing (default true)"),
 		),
-		mcp.WithBoolean("delete_local_data",
+		mcp.WithBoolean("delete_emptydir_data",
 			mcp.Description("Evict pods using emptyDir volumes, losing their local data (default false)"),
 		),
+		mcp.WithBoolean("delete_local_data",
+			mcp.Description("Deprecated alias of delete_emptydir_data"),
+		),
 		mcp.WithNumber("grace_period",
 			mcp.Description("Eviction grace period in seconds (-1 uses the pod default)"),
 		),
 	)
 	s.AddTool(drainNodeTool, drainNodeHandler(cm))
+
+	safeDrainNodeTool := mcp.NewTool("safe_drain_node",
+		mcp.WithDescription("Cordon and drain a node, then wait for the evicted pods' controllers to reschedule them and report which node each replacement landed on"),
+		destructiveAnnotation("Safe drain node"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the node")),
+		mcp.WithBoolean("delete_emptydir_data",
+			mcp.Description("Evict pods using emptyDir volumes, losing their local data (default false)"),
+		),
+		mcp.WithBoolean("delete_local_data",
+			mcp.Description("Deprecated alias of delete_emptydir_data"),
+		),
+		mcp.WithNumber("grace_period",
+			mcp.Description("Eviction grace period in seconds (-1 uses the pod default)"),
+		),
+		mcp.WithString("timeout",
+			mcp.Description("How long to wait for replacements, like 30s or 2m (defaults to 2m, capped at 10m)"),
+		),
+	)
+	s.AddTool(safeDrainNodeTool, safeDrainNodeHandler(cm))
+
+	nodeAllocationsTool := mcp.NewTool("node_allocations",
+		mcp.WithDescription("Compare summed pod resource requests against allocatable CPU/memory per node, showing utilization and schedulable headroom"),
+		readOnlyAnnotation("Node allocations"),
+		mcp.WithString("name", mcp.Description("Name of a single node to report on (defaults to all nodes)")),
+	)
+	s.AddTool(nodeAllocationsTool, nodeAllocationsHandler(cm))
+
+	taintNodeTool := mcp.NewTool("taint_node",
+		mcp.WithDescription("Add a taint to a node, replacing any existing taint with the same key and effect"),
+		idempotentMutationAnnotation("Taint node"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the node")),
+		mcp.WithString("key", mcp.Required(), mcp.Description("Taint key")),
+		mcp.WithString("value", mcp.Description("Taint value")),
+		mcp.WithString("effect", mcp.Required(),
+			mcp.Description("Taint effect (NoSchedule, PreferNoSchedule, NoExecute)"),
+		),
+	)
+	s.AddTool(taintNodeTool, taintNodeHandler(cm))
+
+	untaintNodeTool := mcp.NewTool("untaint_node",
+		mcp.WithDescription("Remove taints with the given key from a node"),
+		idempotentMutationAnnotation("Untaint node"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the node")),
+		mcp.WithString("key", mcp.Required(), mcp.Description("Taint key")),
+		mcp.WithString("effect",
+			mcp.Description("Only remove the taint with this effect (defaults to all effects)"),
+		),
+	)
+	s.AddTool(untaintNodeTool, untaintNodeHandler(cm))
+
+	labelNodeTool := mcp.NewTool("label_node",
+		mcp.WithDescription("Add or overwrite labels on a node"),
+		idempotentMutationAnnotation("Label node"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the node")),
+		mcp.WithObject("labels", mcp.Required(), mcp.Description("Labels to set on the node")),
+	)
+	s.AddTool(labelNodeTool, labelNodeHandler(cm))
+
+	unlabelNodeTool := mcp.NewTool("unlabel_node",
+		mcp.WithDescription("Remove labels from a node"),
+		idempotentMutationAnnotation("Unlabel node"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the node")),
+		mcp.WithArray("keys", mcp.Required(), mcp.Description("Label keys to remove")),
+	)
+	s.AddTool(unlabelNodeTool, unlabelNodeHandler(cm))
 }
 
 func nodeNameFromRequest(request mcp.CallToolRequest) (string, *mcp.CallToolResult) {
@@ -92,7 +168,23 @@ func getNodeHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp
 		node := cluster.Node{Name: name}
 		result, err := node.Get(ctx, cm)
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to get node: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get node: %s", classifyError(err))), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func describeNodeHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "describe_node"))
+		name, errResult := nodeNameFromRequest(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		node := cluster.Node{Name: name}
+		result, err := node.Describe(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to describe node: %s", err.Error())), nil
 		}
 		return mcp.NewToolResultText(result), nil
 	}
@@ -129,16 +221,13 @@ func drainNodeHandler(cm kai.ClusterManager) func(ctx context.Context, request m
 		if errResult != nil {
 			return errResult, nil
 		}
-		node := cluster.Node{Name: name}
+		node := cluster.Node{Name: name, Progress: progressReporter(ctx, request)}
 
 		ignoreDaemonSets := true
 		if v, ok := request.GetArguments()["ignore_daemonsets"].(bool); ok {
 			ignoreDaemonSets = v
 		}
-		deleteLocalData := false
-		if v, ok := request.GetArguments()["delete_local_data"].(bool); ok {
-			deleteLocalData = v
-		}
+		deleteLocalData := deleteEmptyDirDataArg(request)
 		gracePeriod := int64(-1)
 		if v, ok := request.GetArguments()["grace_period"].(float64); ok {
 			gracePeriod = int64(v)
@@ -151,3 +240,158 @@ func drainNodeHandler(cm kai.ClusterManager) func(ctx context.Context, request m
 		return mcp.NewToolResultText(result), nil
 	}
 }
+
+func safeDrainNodeHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "safe_drain_node"))
+		name, errResult := nodeNameFromRequest(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		node := cluster.Node{Name: name, Progress: progressReporter(ctx, request)}
+
+		deleteLocalData := deleteEmptyDirDataArg(request)
+		gracePeriod := int64(-1)
+		if v, ok := request.GetArguments()["grace_period"].(float64); ok {
+			gracePeriod = int64(v)
+		}
+		var timeout time.Duration
+		if timeoutArg, ok := request.GetArguments()["timeout"].(string); ok && timeoutArg != "" {
+			parsed, err := time.ParseDuration(timeoutArg)
+			if err != nil {
+				return mcp.NewToolResultText(fmt.Sprintf("Failed to parse 'timeout' parameter: %v", err)), nil
+			}
+			timeout = parsed
+		}
+
+		result, err := node.SafeDrain(ctx, cm, deleteLocalData, gracePeriod, timeout)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to drain node: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+// deleteEmptyDirDataArg reads delete_emptydir_data, kubectl's current name
+// for the flag, falling back to the older delete_local_data.
+func deleteEmptyDirDataArg(request mcp.CallToolRequest) bool {
+	if v, ok := request.GetArguments()["delete_emptydir_data"].(bool); ok {
+		return v
+	}
+	v, _ := request.GetArguments()["delete_local_data"].(bool)
+	return v
+}
+
+func nodeAllocationsHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "node_allocations"))
+		node := cluster.Node{}
+		if name, ok := request.GetArguments()["name"].(string); ok {
+			node.Name = name
+		}
+		result, err := node.Allocations(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get node allocations: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func taintNodeHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "taint_node"))
+		name, errResult := nodeNameFromRequest(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		key, _ := request.GetArguments()["key"].(string)
+		if key == "" {
+			return mcp.NewToolResultText("Required parameter 'key' is missing"), nil
+		}
+		effect, _ := request.GetArguments()["effect"].(string)
+		if effect == "" {
+			return mcp.NewToolResultText("Required parameter 'effect' is missing"), nil
+		}
+		value, _ := request.GetArguments()["value"].(string)
+
+		node := cluster.Node{Name: name}
+		result, err := node.Taint(ctx, cm, key, value, effect)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to taint node: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func untaintNodeHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "untaint_node"))
+		name, errResult := nodeNameFromRequest(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		key, _ := request.GetArguments()["key"].(string)
+		if key == "" {
+			return mcp.NewToolResultText("Required parameter 'key' is missing"), nil
+		}
+		effect, _ := request.GetArguments()["effect"].(string)
+
+		node := cluster.Node{Name: name}
+		result, err := node.Untaint(ctx, cm, key, effect)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to untaint node: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func labelNodeHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "label_node"))
+		name, errResult := nodeNameFromRequest(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		labels, ok := request.GetArguments()["labels"].(map[string]interface{})
+		if !ok || len(labels) == 0 {
+			return mcp.NewToolResultText("Required parameter 'labels' is missing"), nil
+		}
+		if err := validateLabels(labels); err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		node := cluster.Node{Name: name}
+		result, err := node.Label(ctx, cm, labels)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to label node: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func unlabelNodeHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "unlabel_node"))
+		name, errResult := nodeNameFromRequest(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		keysArg, _ := request.GetArguments()["keys"].([]interface{})
+		keys := make([]string, 0, len(keysArg))
+		for _, k := range keysArg {
+			if str, ok := k.(string); ok && str != "" {
+				keys = append(keys, str)
+			}
+		}
+		if len(keys) == 0 {
+			return mcp.NewToolResultText("Required parameter 'keys' is missing"), nil
+		}
+
+		node := cluster.Node{Name: name}
+		result, err := node.Unlabel(ctx, cm, keys)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to unlabel node: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
@@ -2,16 +2,23 @@ package tools
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/testmocks"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
 
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 )
@@ -64,7 +71,7 @@ func TestListEventsHandler(t *testing.T) {
 		mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
 
 		result, err := listEventsHandler(mockCM)(ctx, toolRequest(map[string]interface{}{
-			"type": "Warning", "involved_object": "pod-a", "limit": float64(10),
+			"type": "Warning", "involved_kind": "Pod", "involved_object": "pod-a", "limit": float64(10),
 		}))
 
 		assert.NoError(t, err)
@@ -122,6 +129,27 @@ func TestNodeHandlers(t *testing.T) {
 		assert.Contains(t, resultText(t, result), "Node: node-1")
 	})
 
+	t.Run("DescribeNodeMissingName", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		result, err := describeNodeHandler(mockCM)(ctx, toolRequest(map[string]interface{}{}))
+		assert.NoError(t, err)
+		assert.Equal(t, errMissingNode, resultText(t, result))
+	})
+
+	t.Run("DescribeNodeSuccess", func(t *testing.T) {
+		node := makeNode("node-1", true, false)
+		node.Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}
+		fakeClient := fake.NewSimpleClientset(node)
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		result, err := describeNodeHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"name": "node-1"}))
+		assert.NoError(t, err)
+		text := resultText(t, result)
+		assert.Contains(t, text, "Node: node-1")
+		assert.Contains(t, text, "dedicated=gpu:NoSchedule")
+	})
+
 	t.Run("Cordon", func(t *testing.T) {
 		fakeClient := fake.NewSimpleClientset(makeNode("node-1", true, false))
 		mockCM := testmocks.NewMockClusterManager()
@@ -162,6 +190,27 @@ func TestNodeHandlers(t *testing.T) {
 		assert.Contains(t, text, "drained")
 		assert.Contains(t, text, "Skipped")
 	})
+
+	t.Run("SafeDrain", func(t *testing.T) {
+		fakeClient := fake.NewSimpleClientset(makeNode("node-1", true, false))
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+
+		result, err := safeDrainNodeHandler(mockCM)(ctx, toolRequest(map[string]interface{}{
+			"name": "node-1", "timeout": "5s",
+		}))
+		assert.NoError(t, err)
+		assert.Contains(t, resultText(t, result), "Evicted 0 pod(s)")
+	})
+
+	t.Run("SafeDrainInvalidTimeout", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		result, err := safeDrainNodeHandler(mockCM)(ctx, toolRequest(map[string]interface{}{
+			"name": "node-1", "timeout": "soon",
+		}))
+		assert.NoError(t, err)
+		assert.Contains(t, resultText(t, result), "Failed to parse 'timeout' parameter")
+	})
 }
 
 func TestHealthHandlers(t *testing.T) {
@@ -180,6 +229,29 @@ func TestHealthHandlers(t *testing.T) {
 		assert.Contains(t, resultText(t, result), "Cluster Health")
 	})
 
+	t.Run("ClustersStatus", func(t *testing.T) {
+		down := fake.NewSimpleClientset()
+		down.PrependReactor("get", "version", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewServiceUnavailable("apiserver is shutting down")
+		})
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("ListContexts").Return([]*kai.ContextInfo{{Name: "prod"}, {Name: "staging"}})
+		mockCM.On("GetCurrentContext").Return("prod")
+		mockCM.On("GetClient", "prod").Return(fake.NewSimpleClientset(), nil)
+		mockCM.On("GetClient", "staging").Return(down, nil)
+
+		result, err := clustersStatusHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"timeout": "2s"}))
+		assert.NoError(t, err)
+		text := resultText(t, result)
+		assert.Contains(t, text, "Clusters (2 loaded, 1 reachable):")
+		assert.Contains(t, text, "• prod (active): reachable in")
+		assert.Contains(t, text, "apiserver is shutting down")
+
+		result, err = clustersStatusHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"timeout": "soon"}))
+		assert.NoError(t, err)
+		assert.Contains(t, resultText(t, result), "Failed to parse 'timeout' parameter")
+	})
+
 	t.Run("NodeMetricsDegradesGracefully", func(t *testing.T) {
 		dynClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), metricsListKinds)
 		mockCM := testmocks.NewMockClusterManager()
@@ -200,4 +272,43 @@ func TestHealthHandlers(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotEmpty(t, resultText(t, result))
 	})
+
+	t.Run("TopPods", func(t *testing.T) {
+		metrics := metricsfake.NewSimpleClientset()
+		for _, pm := range []*metricsv1beta1.PodMetrics{
+			{ObjectMeta: metav1.ObjectMeta{Name: "light", Namespace: defaultNamespace}, Containers: []metricsv1beta1.ContainerMetrics{{Name: "app", Usage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m"), corev1.ResourceMemory: resource.MustParse("32Mi")}}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "heavy", Namespace: defaultNamespace}, Containers: []metricsv1beta1.ContainerMetrics{{Name: "app", Usage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("5m"), corev1.ResourceMemory: resource.MustParse("1Gi")}}}},
+		} {
+			assert.NoError(t, metrics.Tracker().Create(metricsv1beta1.SchemeGroupVersion.WithResource("pods"), pm, pm.Namespace))
+		}
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+		mockCM.On("GetCurrentMetricsClient").Return(metrics, nil)
+
+		result, err := topPodsHandler(mockCM)(ctx, toolRequest(nil))
+		assert.NoError(t, err)
+		text := resultText(t, result)
+		assert.Contains(t, text, "heavy\tcpu: 5m\tmemory: 1024Mi")
+		assert.Less(t, strings.Index(text, "heavy"), strings.Index(text, "light"))
+	})
+
+	t.Run("TopPodsInvalidSort", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		result, err := topPodsHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"sort_by": "disk"}))
+		assert.NoError(t, err)
+		assert.Contains(t, resultText(t, result), "Failed to get pod usage: invalid sort_by")
+	})
+
+	t.Run("TopNodesWithoutMetricsServer", func(t *testing.T) {
+		metrics := metricsfake.NewSimpleClientset()
+		metrics.PrependReactor("list", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewNotFound(schema.GroupResource{Group: "metrics.k8s.io", Resource: "nodes"}, "")
+		})
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentMetricsClient").Return(metrics, nil)
+
+		result, err := topNodesHandler(mockCM)(ctx, toolRequest(nil))
+		assert.NoError(t, err)
+		assert.Contains(t, resultText(t, result), "install metrics-server")
+	})
 }
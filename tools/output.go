@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/basebandit/kai"
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+)
+
+// maskedSecretValue replaces Secret data values in structured output, which
+// like the text output shows keys but never values.
+const maskedSecretValue = "<masked>"
+
+// outputOption declares the output parameter of get_* and list_* tools.
+func outputOption() mcp.ToolOption {
+	return mcp.WithString("output",
+		mcp.Description("Output format: text (default, human readable), json or yaml (the Kubernetes object itself)"),
+		mcp.Enum(outputText, outputJSON, outputYAML),
+	)
+}
+
+// outputFormat reads the output argument, returning a tool result to send
+// back instead when it is not a known format.
+func outputFormat(request mcp.CallToolRequest) (string, *mcp.CallToolResult) {
+	format, _ := request.GetArguments()["output"].(string)
+	switch format {
+	case "":
+		return outputText, nil
+	case outputText, outputJSON, outputYAML:
+		return format, nil
+	}
+	return "", mcp.NewToolResultText(fmt.Sprintf("Invalid output %q: must be text, json or yaml", format))
+}
+
+// structuredResult fetches an object with the current client and returns it
+// serialized in format, for handlers asked for json or yaml output.
+func structuredResult(ctx context.Context, cm kai.ClusterManager, format string, fetch func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error)) *mcp.CallToolResult {
+	client, err := kai.CurrentClient(ctx, cm)
+	if err != nil {
+		return mcp.NewToolResultText(fmt.Sprintf("Failed to get client: %s", err.Error()))
+	}
+	obj, err := fetch(ctx, client)
+	if err != nil {
+		return mcp.NewToolResultText(err.Error())
+	}
+	text, err := formatResource(obj, format)
+	if err != nil {
+		return mcp.NewToolResultText(fmt.Sprintf("Failed to format output: %s", err.Error()))
+	}
+	return mcp.NewToolResultText(text)
+}
+
+// formatResource serializes a Kubernetes object, or list of objects, as json
+// or yaml. Objects from the typed clientset carry no apiVersion and kind, so
+// they are filled in from the scheme; managedFields are dropped as kubectl
+// does by default, and Secret values are masked.
+func formatResource(obj runtime.Object, format string) (string, error) {
+	obj = obj.DeepCopyObject()
+	if err := setTypeMeta(obj); err != nil {
+		return "", err
+	}
+	if meta.IsListType(obj) {
+		items, err := meta.ExtractList(obj)
+		if err != nil {
+			return "", fmt.Errorf("failed to read list items: %w", err)
+		}
+		for _, item := range items {
+			if err := setTypeMeta(item); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert object: %w", err)
+	}
+	if items, ok := content["items"].([]interface{}); ok {
+		for _, item := range items {
+			if m, ok := item.(map[string]interface{}); ok {
+				maskSecretData(m)
+			}
+		}
+	} else {
+		maskSecretData(content)
+	}
+
+	var out []byte
+	switch format {
+	case outputJSON:
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(content)
+		out = bytes.TrimRight(buf.Bytes(), "\n")
+	case outputYAML:
+		out, err = yaml.Marshal(content)
+	default:
+		return "", fmt.Errorf("unsupported output format %q", format)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s: %w", format, err)
+	}
+	return string(out), nil
+}
+
+func setTypeMeta(obj runtime.Object) error {
+	kinds, _, err := scheme.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return fmt.Errorf("failed to resolve object kind: %w", err)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(kinds[0])
+	if accessor, err := meta.Accessor(obj); err == nil {
+		accessor.SetManagedFields(nil)
+	}
+	return nil
+}
+
+func maskSecretData(content map[string]interface{}) {
+	if content["kind"] != "Secret" {
+		return
+	}
+	for _, field := range []string{"data", "stringData"} {
+		data, ok := content[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for key := range data {
+			data[key] = maskedSecretValue
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
+)
+
+func TestFormatResource(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          nginxPodName,
+			Namespace:     defaultNamespace,
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx:1.27"}}},
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		out, err := formatResource(pod, outputJSON)
+		require.NoError(t, err)
+
+		var got map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(out), &got))
+		assert.Equal(t, "v1", got["apiVersion"])
+		assert.Equal(t, "Pod", got["kind"])
+		metadata := got["metadata"].(map[string]interface{})
+		assert.Equal(t, nginxPodName, metadata["name"])
+		assert.NotContains(t, metadata, "managedFields")
+		assert.Empty(t, pod.Kind, "the caller's object must not be modified")
+	})
+
+	t.Run("YAMLList", func(t *testing.T) {
+		out, err := formatResource(&corev1.PodList{Items: []corev1.Pod{*pod}}, outputYAML)
+		require.NoError(t, err)
+
+		var got map[string]interface{}
+		require.NoError(t, yaml.Unmarshal([]byte(out), &got))
+		assert.Equal(t, "PodList", got["kind"])
+		items := got["items"].([]interface{})
+		require.Len(t, items, 1)
+		assert.Equal(t, "Pod", items[0].(map[string]interface{})["kind"])
+	})
+
+	t.Run("SecretValuesMasked", func(t *testing.T) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: defaultNamespace},
+			Data:       map[string][]byte{"password": []byte("hunter2")},
+		}
+		out, err := formatResource(&corev1.SecretList{Items: []corev1.Secret{*secret}}, outputJSON)
+		require.NoError(t, err)
+		assert.Contains(t, out, `"password": "<masked>"`)
+		assert.NotContains(t, out, "aHVudGVyMg==")
+		assert.Equal(t, []byte("hunter2"), secret.Data["password"])
+	})
+
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		_, err := formatResource(pod, "xml")
+		assert.EqualError(t, err, `unsupported output format "xml"`)
+	})
+}
+
+func TestStructuredOutputHandlers(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: nginxPodName, Namespace: defaultNamespace}},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: defaultNamespace},
+			Data:       map[string][]byte{"password": []byte("hunter2")},
+		},
+	)
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+
+	t.Run("GetPodJSON", func(t *testing.T) {
+		handler := getPodHandler(mockCM, &testmocks.MockPodFactory{})
+		r, err := handler(ctx, toolRequest(map[string]interface{}{"name": nginxPodName, "output": "json"}))
+		require.NoError(t, err)
+
+		var got corev1.Pod
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, r)), &got))
+		assert.Equal(t, "Pod", got.Kind)
+		assert.Equal(t, nginxPodName, got.Name)
+	})
+
+	t.Run("GetPodNotFound", func(t *testing.T) {
+		handler := getPodHandler(mockCM, &testmocks.MockPodFactory{})
+		r, err := handler(ctx, toolRequest(map[string]interface{}{"name": "missing", "output": "yaml"}))
+		require.NoError(t, err)
+		assert.Equal(t, `pods "missing" not found`, resultText(t, r))
+	})
+
+	t.Run("ListSecretsYAML", func(t *testing.T) {
+		handler := listSecretsHandler(mockCM, testmocks.NewMockSecretFactory())
+		r, err := handler(ctx, toolRequest(map[string]interface{}{"output": "yaml"}))
+		require.NoError(t, err)
+		text := resultText(t, r)
+		assert.Contains(t, text, "kind: SecretList")
+		assert.Contains(t, text, "password: <masked>")
+	})
+
+	t.Run("InvalidOutput", func(t *testing.T) {
+		handler := getPodHandler(mockCM, &testmocks.MockPodFactory{})
+		r, err := handler(ctx, toolRequest(map[string]interface{}{"name": nginxPodName, "output": "table"}))
+		require.NoError(t, err)
+		assert.Equal(t, `Invalid output "table": must be text, json or yaml`, resultText(t, r))
+	})
+}
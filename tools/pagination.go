@@ -0,0 +1,28 @@
+package tools
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+// limitOption declares the page size parameter of list tools.
+func limitOption(kind string) mcp.ToolOption {
+	return mcp.WithNumber("limit",
+		mcp.Description("Maximum number of "+kind+" to return. When more exist, the output ends with a continue token for the next page"),
+	)
+}
+
+// continueOption declares the parameter that resumes a paged list.
+func continueOption() mcp.ToolOption {
+	return mcp.WithString("continue",
+		mcp.Description("Continue token from a previous page of this list, with the same selectors"),
+	)
+}
+
+// pageArgs reads the limit and continue parameters of a list request. A
+// missing or non-positive limit means no limit.
+func pageArgs(request mcp.CallToolRequest) (int64, string) {
+	var limit int64
+	if limitArg, ok := request.GetArguments()["limit"].(float64); ok && limitArg > 0 {
+		limit = int64(limitArg)
+	}
+	continueToken, _ := request.GetArguments()["continue"].(string)
+	return limit, continueToken
+}
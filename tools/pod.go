@@ -2,15 +2,48 @@ package tools
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"log/slog"
+	"math"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/cluster"
 	"github.com/mark3labs/mcp-go/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
 )
 
+// ContainerNameStrategy decides how create_pod names the container when the
+// caller does not pass container_name.
+type ContainerNameStrategy string
+
+const (
+	// ContainerNameFromPod derives the container name from the pod name.
+	ContainerNameFromPod ContainerNameStrategy = "pod-name"
+	// ContainerNameFromImage derives the container name from the image
+	// repository, e.g. "nginx" for "docker.io/library/nginx:1.25".
+	ContainerNameFromImage ContainerNameStrategy = "image"
+)
+
+// DefaultContainerNameStrategy is the strategy create_pod uses.
+var DefaultContainerNameStrategy = ContainerNameFromPod
+
+// ParseContainerNameStrategy converts a flag value into a strategy.
+func ParseContainerNameStrategy(value string) (ContainerNameStrategy, error) {
+	switch strategy := ContainerNameStrategy(value); strategy {
+	case ContainerNameFromPod, ContainerNameFromImage:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("invalid container name strategy %q: must be %q or %q", value, ContainerNameFromPod, ContainerNameFromImage)
+	}
+}
+
 type PodFactory interface {
 	NewPod(params kai.PodParams) kai.PodOperator
 }
@@ -19,20 +52,25 @@ type DefaultPodFactory struct{}
 
 func (f *DefaultPodFactory) NewPod(params kai.PodParams) kai.PodOperator {
 	return &cluster.Pod{
-		Name:             params.Name,
-		Image:            params.Image,
-		Namespace:        params.Namespace,
-		ContainerName:    params.ContainerName,
-		ContainerPort:    params.ContainerPort,
-		ImagePullPolicy:  params.ImagePullPolicy,
-		ImagePullSecrets: params.ImagePullSecrets,
-		RestartPolicy:    params.RestartPolicy,
-		ServiceAccount:   params.ServiceAccountName,
-		Command:          params.Command,
-		Args:             params.Args,
-		NodeSelector:     params.NodeSelector,
-		Labels:           params.Labels,
-		Env:              params.Env,
+		Name:                  params.Name,
+		Image:                 params.Image,
+		Namespace:             params.Namespace,
+		ContainerName:         params.ContainerName,
+		ContainerPort:         params.ContainerPort,
+		ImagePullPolicy:       params.ImagePullPolicy,
+		ImagePullSecrets:      params.ImagePullSecrets,
+		RestartPolicy:         params.RestartPolicy,
+		ServiceAccount:        params.ServiceAccountName,
+		Command:               params.Command,
+		Args:                  params.Args,
+		NodeSelector:          params.NodeSelector,
+		Labels:                params.Labels,
+		Env:                   params.Env,
+		Requests:              params.Requests,
+		Limits:                params.Limits,
+		Containers:            params.Containers,
+		DryRun:                params.DryRun,
+		ActiveDeadlineSeconds: params.ActiveDeadlineSeconds,
 	}
 }
 
@@ -53,9 +91,9 @@ func RegisterPodToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, f
 			mcp.Description("Namespace for the pod (defaults to current namespace)"),
 		),
 		mcp.WithString("image",
-			mcp.Required(),
-			mcp.Description("Container image to use for the pod"),
+			mcp.Description("Container image to use for the pod (required unless containers is set)"),
 		),
+		containersOption(),
 		mcp.WithArray("command",
 			mcp.Description("Command to run in the container"),
 		),
@@ -66,7 +104,7 @@ func RegisterPodToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, f
 			mcp.Description("Labels to apply to the pod"),
 		),
 		mcp.WithString("container_name",
-			mcp.Description("Name of the container (defaults to pod name)"),
+			mcp.Description("Name of the container (defaults to a name derived from the pod name or image, depending on the server's container name strategy)"),
 		),
 		mcp.WithString("container_port",
 			mcp.Description("Container port to expose (format: 'port' or 'port/protocol')"),
@@ -89,6 +127,9 @@ func RegisterPodToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, f
 		mcp.WithString("service_account",
 			mcp.Description("Service account to use for the pod"),
 		),
+		resourcesOption(),
+		verifyImageOption(),
+		dryRunOption(),
 	)
 
 	s.AddTool(createPodTool, createPodHandler(cm, factory))
@@ -99,6 +140,7 @@ func RegisterPodToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, f
 		mcp.WithBoolean("all_namespaces",
 			mcp.Description("Whether to list pods across all namespaces"),
 		),
+		confirmScanOption(),
 		mcp.WithString("namespace",
 			mcp.Description("Specific namespace to list pods from (defaults to current namespace)"),
 		),
@@ -109,8 +151,10 @@ func RegisterPodToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, f
 			mcp.Description("Field selector to filter pods"),
 		),
 		mcp.WithNumber("limit",
-			mcp.Description("Maximum number of pods to list"),
+			mcp.Description("Maximum number of pods to list. When more exist, the output ends with a continue token for the next page. Without it, very large results are cut to a summary and the first page"),
 		),
+		continueOption(),
+		outputOption(),
 	)
 
 	s.AddTool(listPodTools, listPodsHandler(cm, factory))
@@ -125,10 +169,25 @@ func RegisterPodToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, f
 		mcp.WithString("namespace",
 			mcp.Description("Namespace of the pod (defaults to current namespace)"),
 		),
+		outputOption(),
 	)
 
 	s.AddTool(getPodTool, getPodHandler(cm, factory))
 
+	describePodTool := mcp.NewTool("describe_pod",
+		mcp.WithDescription("Describe a pod like kubectl describe: node, IP, QoS class, container states with restart counts and last termination reason, init containers, conditions, volumes and recent events"),
+		readOnlyAnnotation("Describe pod"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the pod to describe"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the pod (defaults to current namespace)"),
+		),
+	)
+
+	s.AddTool(describePodTool, describePodHandler(cm, factory))
+
 	deletePodTool := mcp.NewTool("delete_pod",
 		mcp.WithDescription("Delete a pod by name"),
 		destructiveAnnotation("Delete pod"),
@@ -145,7 +204,7 @@ func RegisterPodToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, f
 	s.AddTool(deletePodTool, deletePodHandler(cm, factory))
 
 	streamLogsTool := mcp.NewTool("stream_logs",
-		mcp.WithDescription("Stream logs from a container in a pod"),
+		mcp.WithDescription("Stream logs from a container in a pod, or from all of its containers with all_containers"),
 		readOnlyAnnotation("Stream pod logs"),
 		mcp.WithString("pod",
 			mcp.Required(),
@@ -154,6 +213,12 @@ func RegisterPodToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, f
 		mcp.WithString("container",
 			mcp.Description("Name of the container (defaults to the first container)"),
 		),
+		mcp.WithBoolean("all_containers",
+			mcp.Description("Read every container in the pod and interleave their lines, each prefixed with its container name. Cannot be combined with container"),
+		),
+		mcp.WithBoolean("include_init",
+			mcp.Description("With all_containers, also read the pod's init containers"),
+		),
 		mcp.WithString("namespace",
 			mcp.Description("Namespace of the pod (defaults to current namespace)"),
 		),
@@ -161,7 +226,7 @@ func RegisterPodToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, f
 			mcp.Description("Number of lines to show from the end of the logs (defaults to all)"),
 		),
 		mcp.WithBoolean("previous",
-			mcp.Description("Whether to get logs from a previous container instance"),
+			mcp.Description("Read the logs of the previous container instance, such as the run that crashed before a CrashLoopBackOff restart"),
 		),
 		mcp.WithString("since",
 			mcp.Description("Only return logs newer than a relative duration like 5s, 2m, or 3h"),
@@ -169,6 +234,271 @@ func RegisterPodToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, f
 	)
 
 	s.AddTool(streamLogsTool, streamLogsHandler(cm, factory))
+
+	logsAllRestartsTool := mcp.NewTool("logs_all_restarts",
+		mcp.WithDescription("Read a container's previous (crashed) and current logs in one call, each under its own header with the previous instance's exit code"),
+		readOnlyAnnotation("Logs across restarts"),
+		mcp.WithString("pod",
+			mcp.Required(),
+			mcp.Description("Name of the pod"),
+		),
+		mcp.WithString("container",
+			mcp.Description("Name of the container (defaults to the first container)"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the pod (defaults to current namespace)"),
+		),
+		mcp.WithNumber("tail",
+			mcp.Description("Number of lines to show from the end of each instance's logs (defaults to all)"),
+		),
+	)
+
+	s.AddTool(logsAllRestartsTool, logsAllRestartsHandler(cm, factory))
+
+	searchLogsTool := mcp.NewTool("search_logs",
+		mcp.WithDescription("Search a container's recent logs for lines matching a regular expression, with optional context lines"),
+		readOnlyAnnotation("Search pod logs"),
+		mcp.WithString("pod",
+			mcp.Required(),
+			mcp.Description("Name of the pod"),
+		),
+		mcp.WithString("pattern",
+			mcp.Required(),
+			mcp.Description("Regular expression (RE2 syntax) to match log lines against"),
+		),
+		mcp.WithString("container",
+			mcp.Description("Name of the container (defaults to the first container)"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the pod (defaults to current namespace)"),
+		),
+		mcp.WithNumber("before",
+			mcp.Description("Number of context lines to show before each match"),
+		),
+		mcp.WithNumber("after",
+			mcp.Description("Number of context lines to show after each match"),
+		),
+		mcp.WithNumber("tail",
+			mcp.Description("Number of recent lines to search (defaults to 5000)"),
+		),
+	)
+
+	s.AddTool(searchLogsTool, searchLogsHandler(cm, factory))
+
+	tailSelectorTool := mcp.NewTool("tail_selector",
+		mcp.WithDescription("Follow logs from all pods matching a label selector for a bounded window, merging lines with pod-name prefixes"),
+		readOnlyAnnotation("Tail logs by selector"),
+		mcp.WithString("label_selector",
+			mcp.Required(),
+			mcp.Description("Label selector for the pods to follow (e.g., 'app=nginx')"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the pods (defaults to current namespace)"),
+		),
+		mcp.WithString("container",
+			mcp.Description("Name of the container (defaults to each pod's first container)"),
+		),
+		mcp.WithNumber("tail",
+			mcp.Description("Number of recent lines to include from each pod before following (default 10)"),
+		),
+		mcp.WithString("timeout",
+			mcp.Description("How long to follow logs, like 10s or 1m (default 10s, max 60s)"),
+		),
+	)
+
+	s.AddTool(tailSelectorTool, tailSelectorHandler(cm))
+
+	podByIPTool := mcp.NewTool("pod_by_ip",
+		mcp.WithDescription("Find the pod that owns an IP address, searching all namespaces"),
+		readOnlyAnnotation("Find pod by IP"),
+		mcp.WithString("ip",
+			mcp.Required(),
+			mcp.Description("Pod IP address, e.g. from a log line or connection error"),
+		),
+	)
+
+	s.AddTool(podByIPTool, podByIPHandler(cm))
+
+	execPodTool := mcp.NewTool("exec_pod",
+		mcp.WithDescription("Run a command inside a pod's container and return its stdout, stderr and exit code. The command is not run through a shell"),
+		destructiveAnnotation("Exec in pod"),
+		mcp.WithString("pod",
+			mcp.Required(),
+			mcp.Description("Name of the pod"),
+		),
+		mcp.WithArray("command",
+			mcp.Required(),
+			mcp.Description("Command and arguments, e.g. [\"ls\", \"-l\", \"/data\"]; wrap in [\"sh\", \"-c\", \"...\"] for shell syntax"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithString("container",
+			mcp.Description("Name of the container (required when the pod has several and no default container)"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the pod (defaults to current namespace)"),
+		),
+	)
+
+	s.AddTool(execPodTool, execPodHandler(cm, factory))
+
+	copyToPodTool := mcp.NewTool("copy_to_pod",
+		mcp.WithDescription("Write a file into a pod's container, like kubectl cp. The content is base64-encoded and the container image must include tar"),
+		idempotentMutationAnnotation("Copy to pod"),
+		mcp.WithString("pod",
+			mcp.Required(),
+			mcp.Description("Name of the pod"),
+		),
+		mcp.WithString("dest_path",
+			mcp.Required(),
+			mcp.Description("Path of the file to write in the container, e.g. /tmp/config.yaml; its directory must exist and an existing file is overwritten"),
+		),
+		mcp.WithString("content",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("File content, base64-encoded (at most %d bytes decoded)", cluster.MaxCopyBytes)),
+		),
+		mcp.WithString("container",
+			mcp.Description("Name of the container (required when the pod has several and no default container)"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the pod (defaults to current namespace)"),
+		),
+	)
+
+	s.AddTool(copyToPodTool, copyToPodHandler(cm, factory))
+
+	copyFromPodTool := mcp.NewTool("copy_from_pod",
+		mcp.WithDescription("Read a file, or every file under a directory, from a pod's container, like kubectl cp. Returns each file's content base64-encoded; the container image must include tar"),
+		readOnlyAnnotation("Copy from pod"),
+		mcp.WithString("pod",
+			mcp.Required(),
+			mcp.Description("Name of the pod"),
+		),
+		mcp.WithString("src_path",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("Path of the file or directory in the container, e.g. /etc/nginx/nginx.conf (at most %d bytes in total)", cluster.MaxCopyBytes)),
+		),
+		mcp.WithString("container",
+			mcp.Description("Name of the container (required when the pod has several and no default container)"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the pod (defaults to current namespace)"),
+		),
+	)
+
+	s.AddTool(copyFromPodTool, copyFromPodHandler(cm, factory))
+
+	attachPodTool := mcp.NewTool("attach_pod",
+		mcp.WithDescription("Attach to the running process of a pod's container for a bounded time and return what it writes to stdout and stderr, like kubectl attach. Optionally sends input to its stdin first"),
+		destructiveAnnotation("Attach to pod"),
+		mcp.WithString("pod",
+			mcp.Required(),
+			mcp.Description("Name of the pod"),
+		),
+		mcp.WithString("container",
+			mcp.Description("Name of the container (required when the pod has several and no default container)"),
+		),
+		mcp.WithString("stdin",
+			mcp.Description("Input to send to the process; the container must have stdin: true"),
+		),
+		mcp.WithString("duration",
+			mcp.Description("How long to stay attached, e.g. 10s or 1m (default 10s, at most 5m)"),
+		),
+		mcp.WithNumber("max_bytes",
+			mcp.Description("Detach once this much output is captured (default and maximum 102400)"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the pod (defaults to current namespace)"),
+		),
+	)
+
+	s.AddTool(attachPodTool, attachPodHandler(cm, factory))
+
+	portForwardPodTool := mcp.NewTool("port_forward_pod",
+		mcp.WithDescription("Forward a local port to a pod port for a bounded time, then close it and report what was forwarded. The call returns once the duration is up; use start_port_forward for a forward that stays open"),
+		creationAnnotation("Port forward pod"),
+		mcp.WithString("pod",
+			mcp.Required(),
+			mcp.Description("Name of the pod"),
+		),
+		mcp.WithNumber("pod_port",
+			mcp.Required(),
+			mcp.Description("Port in the pod to forward to"),
+		),
+		mcp.WithNumber("local_port",
+			mcp.Description("Local port to listen on (defaults to pod_port; 0 picks a free port)"),
+		),
+		mcp.WithString("duration",
+			mcp.Description("How long to keep the forward open, e.g. 30s or 2m (default 30s, at most 10m)"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the pod (defaults to current namespace)"),
+		),
+	)
+
+	s.AddTool(portForwardPodTool, portForwardPodHandler(cm, factory))
+
+	waitForPodTool := mcp.NewTool("wait_for_pod",
+		mcp.WithDescription("Wait until a pod is Ready, has been deleted or has completed, e.g. after create_pod or delete_pod"),
+		readOnlyAnnotation("Wait for pod"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the pod"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the pod (defaults to current namespace)"),
+		),
+		mcp.WithString("condition",
+			mcp.Description("Condition to wait for: Ready (default), Deleted, or Completed once the pod has Succeeded or Failed"),
+			mcp.Enum(cluster.PodConditionReady, cluster.PodConditionDeleted, cluster.PodConditionCompleted),
+		),
+		mcp.WithString("timeout",
+			mcp.Description("How long to wait, like 30s or 2m (defaults to 60s, capped at 5m)"),
+		),
+	)
+
+	s.AddTool(waitForPodTool, waitForPodHandler(cm, factory))
+
+	runPodTool := mcp.NewTool("run_pod",
+		mcp.WithDescription("Run a one-off debug pod with restartPolicy Never, wait for it to finish and return its exit code and logs, like kubectl run --rm"),
+		creationAnnotation("Run pod"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the pod"),
+		),
+		mcp.WithString("image",
+			mcp.Required(),
+			mcp.Description("Container image to run, e.g. busybox:1.36"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace for the pod (defaults to current namespace)"),
+		),
+		mcp.WithArray("command",
+			mcp.Description("Command to run instead of the image entrypoint, e.g. [\"nslookup\", \"kubernetes.default\"]"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithArray("args",
+			mcp.Description("Arguments to the command"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithObject("env",
+			mcp.Description("Environment variables as key-value pairs"),
+		),
+		mcp.WithNumber("active_deadline_seconds",
+			mcp.Description("Seconds the pod may run before Kubernetes kills it (activeDeadlineSeconds)"),
+		),
+		mcp.WithBoolean("wait",
+			mcp.Description("Wait for the pod to finish and return its logs (default true)"),
+		),
+		mcp.WithString("timeout",
+			mcp.Description("How long to wait, like 30s or 2m (defaults to 60s, capped at 5m)"),
+		),
+		mcp.WithBoolean("rm",
+			mcp.Description("Delete the pod once it has finished or the wait has timed out; requires wait"),
+		),
+		dryRunOption(),
+	)
+
+	s.AddTool(runPodTool, runPodHandler(cm, factory))
 }
 
 // createPodHandler handles the create_pod tool
@@ -190,17 +520,34 @@ func createPodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx contex
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		imageArg, ok := request.GetArguments()["image"]
-		if !ok || imageArg == nil {
-			return mcp.NewToolResultText("Required parameter 'image' is missing"), nil
+		if err := validateResourceName(name); err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
 		}
 
-		image, ok := imageArg.(string)
-		if !ok || image == "" {
-			return mcp.NewToolResultText("Parameter 'image' must be a non-empty string"), nil
+		containers, err := containersArg(request)
+		if err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		params.Requests, params.Limits, err = resourcesArg(request)
+		if err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		var image string
+		if containers == nil {
+			imageArg, ok := request.GetArguments()["image"]
+			if !ok || imageArg == nil {
+				return mcp.NewToolResultText("Required parameter 'image' is missing"), nil
+			}
+
+			image, ok = imageArg.(string)
+			if !ok || image == "" {
+				return mcp.NewToolResultText("Parameter 'image' must be a non-empty string"), nil
+			}
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -208,6 +555,7 @@ func createPodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx contex
 		params.Name = name
 		params.Image = image
 		params.Namespace = namespace
+		params.Containers = containers
 
 		if commandArg, ok := request.GetArguments()["command"].([]interface{}); ok && len(commandArg) > 0 {
 			params.Command = make([]interface{}, len(commandArg))
@@ -228,13 +576,23 @@ func createPodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx contex
 		}
 
 		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			params.Labels = labelsArg
 		}
 
 		if containerNameArg, ok := request.GetArguments()["container_name"].(string); ok && containerNameArg != "" {
+			if err := validateContainerName(containerNameArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			params.ContainerName = containerNameArg
-		} else {
-			params.ContainerName = name
+		} else if containers == nil {
+			containerName, err := deriveContainerName(DefaultContainerNameStrategy, name, image)
+			if err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
+			params.ContainerName = containerName
 		}
 
 		if containerPortArg, ok := request.GetArguments()["container_port"].(string); ok && containerPortArg != "" {
@@ -277,6 +635,19 @@ func createPodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx contex
 			params.ServiceAccountName = serviceAccountArg
 		}
 
+		if err := ensureNamespace(ctx, cm, namespace); err != nil {
+			return mcp.NewToolResultText(classifyError(err)), nil
+		}
+
+		if containers != nil {
+			if result := checkContainerImages(ctx, cm, request, params.Namespace, containers, params.ImagePullSecrets); result != nil {
+				return result, nil
+			}
+		} else if result := checkImage(ctx, cm, request, params.Namespace, params.Image, params.ImagePullSecrets); result != nil {
+			return result, nil
+		}
+
+		params.DryRun = dryRunArg(request)
 		pod := factory.NewPod(params)
 
 		resultText, err := pod.Create(ctx, cm)
@@ -286,10 +657,10 @@ func createPodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx contex
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(err.Error()), nil
+			return mcp.NewToolResultText(classifyError(err)), nil
 		}
 
-		return mcp.NewToolResultText(resultText), nil
+		return mcp.NewToolResultText(resultText + imageReferenceNotes(params.Image, params.ImagePullPolicy, containers)), nil
 	}
 }
 
@@ -303,12 +674,18 @@ func listPodsHandler(cm kai.ClusterManager, factory PodFactory) func(ctx context
 			allNamespaces = allNamespacesArg
 		}
 
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
+
 		var namespace string
 		if !allNamespaces {
 			if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok {
 				namespace = namespaceArg
 			} else {
-				namespace = cm.GetCurrentNamespace()
+				namespace = kai.CurrentNamespace(ctx, cm)
 			}
 		}
 
@@ -322,9 +699,16 @@ func listPodsHandler(cm kai.ClusterManager, factory PodFactory) func(ctx context
 			fieldSelector = fieldSelectorArg
 		}
 
-		limit := int64(0) // default to unlimited
-		if limitArg, ok := request.GetArguments()["limit"].(float64); ok && limitArg > 0 {
-			limit = int64(limitArg)
+		limit, continueToken := pageArgs(request)
+
+		format, errResult := outputFormat(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if format != outputText {
+			return structuredResult(ctx, cm, format, func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error) {
+				return client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector, FieldSelector: fieldSelector, Limit: limit, Continue: continueToken})
+			}), nil
 		}
 
 		params := kai.PodParams{
@@ -332,7 +716,7 @@ func listPodsHandler(cm kai.ClusterManager, factory PodFactory) func(ctx context
 		}
 		pod := factory.NewPod(params)
 
-		resultText, err := pod.List(ctx, cm, limit, labelSelector, fieldSelector)
+		resultText, err := pod.List(ctx, cm, limit, continueToken, labelSelector, fieldSelector)
 		if err != nil {
 			slog.Warn("failed to list Pods",
 				slog.Bool("all_namespaces", allNamespaces),
@@ -362,11 +746,21 @@ func getPodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx context.C
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
 
+		format, errResult := outputFormat(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if format != outputText {
+			return structuredResult(ctx, cm, format, func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error) {
+				return client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+			}), nil
+		}
+
 		params := kai.PodParams{
 			Name:      name,
 			Namespace: namespace,
@@ -381,6 +775,46 @@ func getPodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx context.C
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
+			return mcp.NewToolResultText(classifyError(err)), nil
+		}
+
+		return mcp.NewToolResultText(resultText), nil
+	}
+}
+
+func describePodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "describe_pod"))
+
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.PodParams{
+			Name:      name,
+			Namespace: namespace,
+		}
+
+		pod := factory.NewPod(params)
+
+		resultText, err := pod.Describe(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to describe Pod",
+				slog.String("name", name),
+				slog.String("namespace", namespace),
+				slog.String("error", err.Error()),
+			)
 			return mcp.NewToolResultText(err.Error()), nil
 		}
 
@@ -402,7 +836,7 @@ func deletePodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx contex
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -427,7 +861,7 @@ func deletePodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx contex
 				slog.Bool("force", force),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(err.Error()), nil
+			return mcp.NewToolResultText(classifyError(err)), nil
 		}
 
 		return mcp.NewToolResultText(resultText), nil
@@ -448,7 +882,7 @@ func streamLogsHandler(cm kai.ClusterManager, factory PodFactory) func(ctx conte
 			return mcp.NewToolResultText(errEmptyPod), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -458,6 +892,15 @@ func streamLogsHandler(cm kai.ClusterManager, factory PodFactory) func(ctx conte
 			containerName = containerArg
 		}
 
+		allContainers, _ := request.GetArguments()["all_containers"].(bool)
+		includeInit, _ := request.GetArguments()["include_init"].(bool)
+		if allContainers && containerName != "" {
+			return mcp.NewToolResultText("Cannot combine 'container' with 'all_containers'"), nil
+		}
+		if includeInit && !allContainers {
+			return mcp.NewToolResultText("'include_init' requires 'all_containers'"), nil
+		}
+
 		var tailLines int64 // Default to all lines
 		if tailArg, ok := request.GetArguments()["tail"].(float64); ok {
 			tailLines = int64(tailArg)
@@ -485,7 +928,13 @@ func streamLogsHandler(cm kai.ClusterManager, factory PodFactory) func(ctx conte
 
 		pod := factory.NewPod(params)
 
-		resultText, err := pod.StreamLogs(ctx, cm, tailLines, previous, sinceDuration)
+		var resultText string
+		var err error
+		if allContainers {
+			resultText, err = pod.StreamAllLogs(ctx, cm, tailLines, previous, sinceDuration, includeInit)
+		} else {
+			resultText, err = pod.StreamLogs(ctx, cm, tailLines, previous, sinceDuration)
+		}
 
 		if err != nil {
 			slog.Warn("failed to stream pod logs",
@@ -499,3 +948,617 @@ func streamLogsHandler(cm kai.ClusterManager, factory PodFactory) func(ctx conte
 		return mcp.NewToolResultText(resultText), nil
 	}
 }
+
+func logsAllRestartsHandler(cm kai.ClusterManager, factory PodFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "logs_all_restarts"))
+
+		podArg, ok := request.GetArguments()["pod"]
+		if !ok || podArg == nil {
+			return mcp.NewToolResultText(errMissingPod), nil
+		}
+
+		podName, ok := podArg.(string)
+		if !ok || podName == "" {
+			return mcp.NewToolResultText(errEmptyPod), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		var containerName string
+		if containerArg, ok := request.GetArguments()["container"].(string); ok {
+			containerName = containerArg
+		}
+
+		var tailLines int64
+		if tailArg, ok := request.GetArguments()["tail"].(float64); ok {
+			tailLines = int64(tailArg)
+		}
+
+		params := kai.PodParams{
+			Name:          podName,
+			Namespace:     namespace,
+			ContainerName: containerName,
+		}
+
+		pod := factory.NewPod(params)
+		resultText, err := pod.LogsAcrossRestarts(ctx, cm, tailLines)
+		if err != nil {
+			slog.Warn("failed to read logs across restarts",
+				slog.String("pod", podName),
+				slog.String("namespace", namespace),
+				slog.String("container", containerName),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to read logs: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(resultText), nil
+	}
+}
+
+func searchLogsHandler(cm kai.ClusterManager, factory PodFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "search_logs"))
+
+		podArg, ok := request.GetArguments()["pod"]
+		if !ok || podArg == nil {
+			return mcp.NewToolResultText(errMissingPod), nil
+		}
+
+		podName, ok := podArg.(string)
+		if !ok || podName == "" {
+			return mcp.NewToolResultText(errEmptyPod), nil
+		}
+
+		pattern, ok := request.GetArguments()["pattern"].(string)
+		if !ok || pattern == "" {
+			return mcp.NewToolResultText(errMissingPattern), nil
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Invalid 'pattern' parameter: %v", err)), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		var containerName string
+		if containerArg, ok := request.GetArguments()["container"].(string); ok {
+			containerName = containerArg
+		}
+
+		var before, after int
+		if beforeArg, ok := request.GetArguments()["before"].(float64); ok {
+			before = int(beforeArg)
+		}
+		if afterArg, ok := request.GetArguments()["after"].(float64); ok {
+			after = int(afterArg)
+		}
+
+		var tailLines int64
+		if tailArg, ok := request.GetArguments()["tail"].(float64); ok {
+			tailLines = int64(tailArg)
+		}
+
+		params := kai.PodParams{
+			Name:          podName,
+			Namespace:     namespace,
+			ContainerName: containerName,
+		}
+
+		pod := factory.NewPod(params)
+		resultText, err := pod.SearchLogs(ctx, cm, pattern, before, after, tailLines)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to search logs: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(resultText), nil
+	}
+}
+
+func tailSelectorHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "tail_selector"))
+
+		selector, ok := request.GetArguments()["label_selector"].(string)
+		if !ok || selector == "" {
+			return mcp.NewToolResultText("Required parameter 'label_selector' is missing"), nil
+		}
+
+		tail := cluster.LogTail{LabelSelector: selector}
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok {
+			tail.Namespace = namespaceArg
+		}
+		if containerArg, ok := request.GetArguments()["container"].(string); ok {
+			tail.Container = containerArg
+		}
+		if tailArg, ok := request.GetArguments()["tail"].(float64); ok {
+			tail.TailLines = int64(tailArg)
+		}
+		if timeoutArg, ok := request.GetArguments()["timeout"].(string); ok && timeoutArg != "" {
+			timeout, err := time.ParseDuration(timeoutArg)
+			if err != nil {
+				return mcp.NewToolResultText(fmt.Sprintf("Failed to parse 'timeout' parameter: %v", err)), nil
+			}
+			tail.Timeout = timeout
+		}
+
+		result, err := tail.Run(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to tail logs: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func podByIPHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "pod_by_ip"))
+
+		ip, ok := request.GetArguments()["ip"].(string)
+		if !ok || ip == "" {
+			return mcp.NewToolResultText("Required parameter 'ip' is missing"), nil
+		}
+
+		lookup := cluster.PodByIP{IP: ip}
+		result, err := lookup.Find(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to find pod by IP: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func execPodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "exec_pod"))
+
+		podArg, ok := request.GetArguments()["pod"]
+		if !ok || podArg == nil {
+			return mcp.NewToolResultText(errMissingPod), nil
+		}
+
+		podName, ok := podArg.(string)
+		if !ok || podName == "" {
+			return mcp.NewToolResultText(errEmptyPod), nil
+		}
+
+		commandArg, ok := request.GetArguments()["command"].([]interface{})
+		if !ok || len(commandArg) == 0 {
+			return mcp.NewToolResultText("Parameter 'command' must be a non-empty array of strings"), nil
+		}
+		command := make([]string, 0, len(commandArg))
+		for _, c := range commandArg {
+			arg, ok := c.(string)
+			if !ok {
+				return mcp.NewToolResultText("Parameter 'command' must be a non-empty array of strings"), nil
+			}
+			command = append(command, arg)
+		}
+		if command[0] == "" {
+			return mcp.NewToolResultText("Parameter 'command' must start with the program to run"), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		container, _ := request.GetArguments()["container"].(string)
+
+		params := kai.PodParams{
+			Name:      podName,
+			Namespace: namespace,
+		}
+
+		pod := factory.NewPod(params)
+		result, err := pod.Exec(ctx, cm, container, command)
+		if err != nil {
+			slog.Warn("failed to exec in pod",
+				slog.String("pod", podName),
+				slog.String("namespace", namespace),
+				slog.String("container", container),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to exec in pod: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func copyToPodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "copy_to_pod"))
+
+		podArg, ok := request.GetArguments()["pod"]
+		if !ok || podArg == nil {
+			return mcp.NewToolResultText(errMissingPod), nil
+		}
+
+		podName, ok := podArg.(string)
+		if !ok || podName == "" {
+			return mcp.NewToolResultText(errEmptyPod), nil
+		}
+
+		destPath, _ := request.GetArguments()["dest_path"].(string)
+		if destPath == "" {
+			return mcp.NewToolResultText("Required parameter 'dest_path' is missing"), nil
+		}
+
+		content, ok := request.GetArguments()["content"].(string)
+		if !ok {
+			return mcp.NewToolResultText("Required parameter 'content' is missing"), nil
+		}
+		data, err := base64.StdEncoding.DecodeString(content)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Parameter 'content' must be base64-encoded: %s", err.Error())), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		container, _ := request.GetArguments()["container"].(string)
+
+		pod := factory.NewPod(kai.PodParams{Name: podName, Namespace: namespace})
+		result, err := pod.CopyTo(ctx, cm, container, data, destPath)
+		if err != nil {
+			slog.Warn("failed to copy to pod",
+				slog.String("pod", podName),
+				slog.String("namespace", namespace),
+				slog.String("path", destPath),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to copy to pod: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func copyFromPodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "copy_from_pod"))
+
+		podArg, ok := request.GetArguments()["pod"]
+		if !ok || podArg == nil {
+			return mcp.NewToolResultText(errMissingPod), nil
+		}
+
+		podName, ok := podArg.(string)
+		if !ok || podName == "" {
+			return mcp.NewToolResultText(errEmptyPod), nil
+		}
+
+		srcPath, _ := request.GetArguments()["src_path"].(string)
+		if srcPath == "" {
+			return mcp.NewToolResultText("Required parameter 'src_path' is missing"), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		container, _ := request.GetArguments()["container"].(string)
+
+		pod := factory.NewPod(kai.PodParams{Name: podName, Namespace: namespace})
+		result, err := pod.CopyFrom(ctx, cm, container, srcPath)
+		if err != nil {
+			slog.Warn("failed to copy from pod",
+				slog.String("pod", podName),
+				slog.String("namespace", namespace),
+				slog.String("path", srcPath),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to copy from pod: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func attachPodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "attach_pod"))
+
+		podArg, ok := request.GetArguments()["pod"]
+		if !ok || podArg == nil {
+			return mcp.NewToolResultText(errMissingPod), nil
+		}
+
+		podName, ok := podArg.(string)
+		if !ok || podName == "" {
+			return mcp.NewToolResultText(errEmptyPod), nil
+		}
+
+		var duration time.Duration
+		if durationArg, ok := request.GetArguments()["duration"].(string); ok && durationArg != "" {
+			parsed, err := time.ParseDuration(durationArg)
+			if err != nil || parsed <= 0 {
+				return mcp.NewToolResultText(fmt.Sprintf("Invalid duration %q: use a positive duration like 10s or 1m", durationArg)), nil
+			}
+			duration = parsed
+		}
+
+		var maxBytes int
+		if maxBytesArg, ok := request.GetArguments()["max_bytes"].(float64); ok {
+			if maxBytesArg != math.Trunc(maxBytesArg) || maxBytesArg < 1 {
+				return mcp.NewToolResultText("Parameter 'max_bytes' must be a positive integer"), nil
+			}
+			maxBytes = int(maxBytesArg)
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		container, _ := request.GetArguments()["container"].(string)
+		stdin, _ := request.GetArguments()["stdin"].(string)
+
+		params := kai.PodParams{
+			Name:      podName,
+			Namespace: namespace,
+		}
+
+		pod := factory.NewPod(params)
+		result, err := pod.Attach(ctx, cm, container, stdin, duration, maxBytes)
+		if err != nil {
+			slog.Warn("failed to attach to pod",
+				slog.String("pod", podName),
+				slog.String("namespace", namespace),
+				slog.String("container", container),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to attach to pod: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func portForwardPodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "port_forward_pod"))
+
+		podArg, ok := request.GetArguments()["pod"]
+		if !ok || podArg == nil {
+			return mcp.NewToolResultText(errMissingPod), nil
+		}
+
+		podName, ok := podArg.(string)
+		if !ok || podName == "" {
+			return mcp.NewToolResultText(errEmptyPod), nil
+		}
+
+		podPortArg, ok := request.GetArguments()["pod_port"].(float64)
+		if !ok {
+			return mcp.NewToolResultText("Required parameter 'pod_port' is missing"), nil
+		}
+		if podPortArg != math.Trunc(podPortArg) || podPortArg < 1 || podPortArg > 65535 {
+			return mcp.NewToolResultText("Parameter 'pod_port' must be an integer between 1 and 65535"), nil
+		}
+		podPort := int(podPortArg)
+
+		localPort := podPort
+		if localPortArg, ok := request.GetArguments()["local_port"].(float64); ok {
+			if localPortArg != math.Trunc(localPortArg) || localPortArg < 0 || localPortArg > 65535 {
+				return mcp.NewToolResultText("Parameter 'local_port' must be an integer between 0 and 65535"), nil
+			}
+			localPort = int(localPortArg)
+		}
+
+		var duration time.Duration
+		if durationArg, ok := request.GetArguments()["duration"].(string); ok && durationArg != "" {
+			parsed, err := time.ParseDuration(durationArg)
+			if err != nil || parsed <= 0 {
+				return mcp.NewToolResultText(fmt.Sprintf("Invalid duration %q: use a positive duration like 30s or 2m", durationArg)), nil
+			}
+			duration = parsed
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.PodParams{
+			Name:      podName,
+			Namespace: namespace,
+		}
+
+		pod := factory.NewPod(params)
+		result, err := pod.PortForward(ctx, cm, localPort, podPort, duration)
+		if err != nil {
+			slog.Warn("failed to port forward pod",
+				slog.String("pod", podName),
+				slog.String("namespace", namespace),
+				slog.Int("pod_port", podPort),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to port forward: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+// deriveContainerName builds a container name from the pod name or image,
+// depending on strategy. Pod names may contain dots and run to 253
+// characters, so the source is sanitized into an RFC 1123 label: lowercased,
+// runs of other characters collapsed to '-', and cut to 63 characters.
+func waitForPodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "wait_for_pod"))
+
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		condition, _ := request.GetArguments()["condition"].(string)
+
+		var timeout time.Duration
+		if timeoutArg, ok := request.GetArguments()["timeout"].(string); ok && timeoutArg != "" {
+			parsed, err := time.ParseDuration(timeoutArg)
+			if err != nil {
+				return mcp.NewToolResultText(fmt.Sprintf("Failed to parse 'timeout' parameter: %v", err)), nil
+			}
+			timeout = parsed
+		}
+
+		pod := factory.NewPod(kai.PodParams{
+			Name:      name,
+			Namespace: namespace,
+		})
+		result, err := pod.WaitFor(ctx, cm, condition, timeout)
+		if err != nil {
+			slog.Warn("failed to wait for Pod",
+				slog.String("name", name),
+				slog.String("namespace", namespace),
+				slog.String("condition", condition),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to wait for pod: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func runPodHandler(cm kai.ClusterManager, factory PodFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "run_pod"))
+
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if err := validateResourceName(name); err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		image, ok := request.GetArguments()["image"].(string)
+		if !ok || image == "" {
+			return mcp.NewToolResultText("Required parameter 'image' is missing"), nil
+		}
+
+		containerName, err := deriveContainerName(DefaultContainerNameStrategy, name, image)
+		if err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.PodParams{
+			Name:          name,
+			Namespace:     namespace,
+			Image:         image,
+			ContainerName: containerName,
+			DryRun:        dryRunArg(request),
+		}
+		if commandArg, ok := request.GetArguments()["command"].([]interface{}); ok && len(commandArg) > 0 {
+			params.Command = commandArg
+		}
+		if argsArg, ok := request.GetArguments()["args"].([]interface{}); ok && len(argsArg) > 0 {
+			params.Args = argsArg
+		}
+		if envArg, ok := request.GetArguments()["env"].(map[string]interface{}); ok {
+			params.Env = envArg
+		}
+		if deadlineArg, ok := request.GetArguments()["active_deadline_seconds"].(float64); ok {
+			if deadlineArg < 1 || deadlineArg != float64(int64(deadlineArg)) {
+				return mcp.NewToolResultText("Parameter 'active_deadline_seconds' must be a positive whole number"), nil
+			}
+			deadline := int64(deadlineArg)
+			params.ActiveDeadlineSeconds = &deadline
+		}
+
+		wait := true
+		if waitArg, ok := request.GetArguments()["wait"].(bool); ok {
+			wait = waitArg
+		}
+		rm, _ := request.GetArguments()["rm"].(bool)
+		if rm && !wait {
+			return mcp.NewToolResultText("Parameter 'rm' requires 'wait': the pod is only removed once it has finished"), nil
+		}
+
+		var timeout time.Duration
+		if timeoutArg, ok := request.GetArguments()["timeout"].(string); ok && timeoutArg != "" {
+			parsed, err := time.ParseDuration(timeoutArg)
+			if err != nil {
+				return mcp.NewToolResultText(fmt.Sprintf("Failed to parse 'timeout' parameter: %v", err)), nil
+			}
+			timeout = parsed
+		}
+
+		result, err := factory.NewPod(params).Run(ctx, cm, wait, timeout, rm)
+		if err != nil {
+			slog.Warn("failed to run pod",
+				slog.String("name", name),
+				slog.String("namespace", namespace),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to run pod: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func deriveContainerName(strategy ContainerNameStrategy, podName, image string) (string, error) {
+	source := podName
+	if strategy == ContainerNameFromImage {
+		source = imageRepositoryName(image)
+	}
+
+	var sb strings.Builder
+	for _, r := range strings.ToLower(source) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			sb.WriteRune(r)
+		case sb.Len() > 0 && !strings.HasSuffix(sb.String(), "-"):
+			sb.WriteByte('-')
+		}
+	}
+
+	name := sb.String()
+	if len(name) > validation.DNS1123LabelMaxLength {
+		name = name[:validation.DNS1123LabelMaxLength]
+	}
+	name = strings.TrimRight(name, "-")
+
+	if err := validateContainerName(name); err != nil {
+		return "", fmt.Errorf("cannot derive a container name from %q; pass container_name explicitly", source)
+	}
+	return name, nil
+}
+
+// imageRepositoryName returns the last path component of an image
+// reference, without its tag or digest.
+func imageRepositoryName(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, "/"); i >= 0 {
+		image = image[i+1:]
+	}
+	if i := strings.Index(image, ":"); i >= 0 {
+		image = image[:i]
+	}
+	return image
+}
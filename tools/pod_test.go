@@ -2,8 +2,10 @@ package tools
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +14,9 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 // Test case structs for table-driven tests
@@ -87,6 +92,53 @@ func TestCreatePodHandler(t *testing.T) {
 			expectedOutput:    fmt.Sprintf("Pod %q created successfully", testPodName),
 			expectPodCreation: true,
 		},
+		{
+			name: "Resources",
+			args: map[string]interface{}{
+				"name":           testPodName,
+				"image":          nginxImage,
+				"cpu_request":    "250m",
+				"memory_request": "256Mi",
+				"memory_limit":   "512Mi",
+			},
+			expectedParams: kai.PodParams{
+				Name:          testPodName,
+				Namespace:     defaultNamespace,
+				Image:         nginxImage,
+				ContainerName: testPodName,
+				RestartPolicy: defaultRestartPolicy,
+				Requests:      map[string]interface{}{"cpu": "250m", "memory": "256Mi"},
+				Limits:        map[string]interface{}{"memory": "512Mi"},
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("Create", mock.Anything, mockCM).Return(fmt.Sprintf("Pod %q created successfully in namespace %q", testPodName, defaultNamespace), nil)
+			},
+			expectedOutput:    fmt.Sprintf("Pod %q created successfully", testPodName),
+			expectPodCreation: true,
+		},
+		{
+			name: "LatestTagWithIfNotPresent",
+			args: map[string]interface{}{
+				"name":              testPodName,
+				"image":             nginxImage,
+				"image_pull_policy": "IfNotPresent",
+			},
+			expectedParams: kai.PodParams{
+				Name:            testPodName,
+				Namespace:       defaultNamespace,
+				Image:           nginxImage,
+				ContainerName:   testPodName,
+				RestartPolicy:   defaultRestartPolicy,
+				ImagePullPolicy: "IfNotPresent",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("Create", mock.Anything, mockCM).Return(fmt.Sprintf("Pod %q created successfully in namespace %q", testPodName, defaultNamespace), nil)
+			},
+			expectedOutput:    `Note: image "nginx:latest" uses the latest tag with image_pull_policy IfNotPresent`,
+			expectPodCreation: true,
+		},
 		{
 			name: "AllParams",
 			args: map[string]interface{}{
@@ -214,6 +266,78 @@ func TestCreatePodHandler(t *testing.T) {
 			expectedOutput:    "failed to create pod: resource quota exceeded",
 			expectPodCreation: true,
 		},
+		{
+			name: "DottedPodName",
+			args: map[string]interface{}{
+				"name":  "web.v2",
+				"image": nginxImage,
+			},
+			expectedParams: kai.PodParams{
+				Name:          "web.v2",
+				Namespace:     defaultNamespace,
+				Image:         nginxImage,
+				ContainerName: "web-v2", // Dots are not allowed in container names
+				RestartPolicy: defaultRestartPolicy,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("Create", mock.Anything, mockCM).Return(fmt.Sprintf("Pod %q created successfully in namespace %q, ", "web.v2", defaultNamespace), nil)
+			},
+			expectedOutput:    `Pod "web.v2" created successfully`,
+			expectPodCreation: true,
+		},
+		{
+			name: "InvalidContainerName",
+			args: map[string]interface{}{
+				"name":           testPodName,
+				"image":          nginxImage,
+				"container_name": "Web_Server",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+			},
+			expectedOutput:    "invalid container_name: must be a lowercase RFC 1123 label",
+			expectPodCreation: false,
+		},
+		{
+			name: "Containers",
+			args: map[string]interface{}{
+				"name": testPodName,
+				"containers": []interface{}{
+					map[string]interface{}{"name": "app", "image": nginxImage},
+					map[string]interface{}{"name": "log-shipper", "image": "fluent/fluent-bit:3.0", "args": []interface{}{"-c", "/etc/fluent-bit.conf"}},
+				},
+			},
+			expectedParams: kai.PodParams{
+				Name:          testPodName,
+				Namespace:     defaultNamespace,
+				RestartPolicy: defaultRestartPolicy,
+				Containers: []kai.ContainerSpec{
+					{Name: "app", Image: nginxImage},
+					{Name: "log-shipper", Image: "fluent/fluent-bit:3.0", Args: []interface{}{"-c", "/etc/fluent-bit.conf"}},
+				},
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("Create", mock.Anything, mockCM).Return(fmt.Sprintf("Pod %q created successfully in namespace %q, ", testPodName, defaultNamespace), nil)
+			},
+			expectedOutput:    "created successfully",
+			expectPodCreation: true,
+		},
+		{
+			name: "ContainerNameWithContainers",
+			args: map[string]interface{}{
+				"name":           testPodName,
+				"container_name": "app",
+				"containers": []interface{}{
+					map[string]interface{}{"name": "app", "image": nginxImage},
+				},
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+			},
+			expectedOutput:    "parameter 'container_name' cannot be combined with 'containers'",
+			expectPodCreation: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -229,6 +353,7 @@ func TestCreatePodHandler(t *testing.T) {
 
 			tc.mockSetup(mockCM, mockFactory, mockPod)
 
+			stubNamespaceCheck(mockCM)
 			handler := createPodHandler(mockCM, mockFactory)
 
 			request := mcp.CallToolRequest{
@@ -251,6 +376,119 @@ func TestCreatePodHandler(t *testing.T) {
 	}
 }
 
+func TestCreatePodHandlerVerifyImage(t *testing.T) {
+	mockCM := testmocks.NewMockClusterManager()
+	mockFactory := new(testmocks.MockPodFactory)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+
+	stubNamespaceCheck(mockCM)
+	handler := createPodHandler(mockCM, mockFactory)
+
+	// A reference the registry check rejects before any network call is
+	// made, so the pod must never be built.
+	result, err := handler(context.Background(), toolRequest(map[string]interface{}{
+		"name":         testPodName,
+		"image":        "nginx@",
+		"verify_image": true,
+	}))
+
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, result), `invalid image reference "nginx@"`)
+	mockFactory.AssertNotCalled(t, "NewPod", mock.Anything)
+}
+
+func TestResourcesArg(t *testing.T) {
+	t.Run("RequestsAndLimits", func(t *testing.T) {
+		requests, limits, err := resourcesArg(toolRequest(map[string]interface{}{
+			"cpu_request":    "250m",
+			"memory_request": "256Mi",
+			"memory_limit":   "512Mi",
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"cpu": "250m", "memory": "256Mi"}, requests)
+		assert.Equal(t, map[string]interface{}{"memory": "512Mi"}, limits)
+	})
+
+	t.Run("None", func(t *testing.T) {
+		requests, limits, err := resourcesArg(toolRequest(map[string]interface{}{"name": testPodName}))
+		assert.NoError(t, err)
+		assert.Nil(t, requests)
+		assert.Nil(t, limits)
+	})
+
+	testCases := []struct {
+		name     string
+		args     map[string]interface{}
+		expected string
+	}{
+		{"Malformed", map[string]interface{}{"memory_limit": "512MB!"}, `invalid memory_limit "512MB!": must be a non-negative quantity like 256Mi or 1Gi`},
+		{"Negative", map[string]interface{}{"cpu_request": "-1"}, `invalid cpu_request "-1": must be a non-negative quantity like 250m or 0.5`},
+		{"RequestAboveLimit", map[string]interface{}{"cpu_request": "2", "cpu_limit": "500m"}, "cpu_request 2 is above cpu_limit 500m"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := resourcesArg(toolRequest(tc.args))
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestCreatePodHandlerResources(t *testing.T) {
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+	mockFactory := new(testmocks.MockPodFactory)
+
+	result, err := createPodHandler(mockCM, mockFactory)(context.Background(), toolRequest(map[string]interface{}{
+		"name":       testPodName,
+		"containers": []interface{}{map[string]interface{}{"name": "app", "image": nginxImage}},
+		"cpu_limit":  "1",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "parameter 'cpu_limit' cannot be combined with 'containers'; set it on the container entry instead", resultText(t, result))
+	mockFactory.AssertNotCalled(t, "NewPod", mock.Anything)
+}
+
+func TestDeriveContainerName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		strategy ContainerNameStrategy
+		podName  string
+		image    string
+		expected string
+	}{
+		{"PodNameAlreadyValid", ContainerNameFromPod, "nginx-pod", nginxImage, "nginx-pod"},
+		{"PodNameWithDots", ContainerNameFromPod, "api.v1.example", nginxImage, "api-v1-example"},
+		{"PodNameTooLong", ContainerNameFromPod, strings.Repeat("a", 60) + ".bcdef", nginxImage, strings.Repeat("a", 60) + "-bc"},
+		{"PodNameTrailingDotAtCut", ContainerNameFromPod, strings.Repeat("a", 62) + ".b", nginxImage, strings.Repeat("a", 62)},
+		{"ImageWithTag", ContainerNameFromImage, "web.v2", "nginx:1.25", "nginx"},
+		{"ImageWithRegistryPort", ContainerNameFromImage, "web.v2", "registry.example.com:5000/team/My_App:1.0", "my-app"},
+		{"ImageWithDigest", ContainerNameFromImage, "web.v2", "ghcr.io/org/worker@sha256:0123abcd", "worker"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, err := deriveContainerName(tc.strategy, tc.podName, tc.image)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, name)
+			assert.NoError(t, validateContainerName(name))
+		})
+	}
+
+	t.Run("NothingUsable", func(t *testing.T) {
+		_, err := deriveContainerName(ContainerNameFromImage, "web", "___:latest")
+		assert.EqualError(t, err, `cannot derive a container name from "___"; pass container_name explicitly`)
+	})
+}
+
+func TestParseContainerNameStrategy(t *testing.T) {
+	strategy, err := ParseContainerNameStrategy("image")
+	assert.NoError(t, err)
+	assert.Equal(t, ContainerNameFromImage, strategy)
+
+	_, err = ParseContainerNameStrategy("random")
+	assert.EqualError(t, err, `invalid container name strategy "random": must be "pod-name" or "image"`)
+}
+
 func TestListPodsHandler(t *testing.T) {
 	labelSelector := "app=nginx"
 
@@ -263,7 +501,7 @@ func TestListPodsHandler(t *testing.T) {
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockPod.On("List", mock.Anything, mockCM, int64(0), "", "").
+				mockPod.On("List", mock.Anything, mockCM, int64(0), "", "", "").
 					Return(fmt.Sprintf("Pods in namespace %q:\n- pod1\n- pod2", defaultNamespace), nil)
 			},
 			expectedOutput: fmt.Sprintf("Pods in namespace %q:", defaultNamespace),
@@ -275,7 +513,7 @@ func TestListPodsHandler(t *testing.T) {
 			},
 			expectedParams: kai.PodParams{},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
-				mockPod.On("List", mock.Anything, mockCM, int64(0), "", "").
+				mockPod.On("List", mock.Anything, mockCM, int64(0), "", "", "").
 					Return("Pods across all namespaces:\n- namespace1/pod1\n- namespace2/pod2", nil)
 			},
 			expectedOutput: "Pods across all namespaces:",
@@ -290,11 +528,26 @@ func TestListPodsHandler(t *testing.T) {
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockPod.On("List", mock.Anything, mockCM, int64(0), labelSelector, "").
+				mockPod.On("List", mock.Anything, mockCM, int64(0), "", labelSelector, "").
 					Return(fmt.Sprintf("Pods in namespace %q with label %q:\n- nginx-pod-1\n- nginx-pod-2", defaultNamespace, labelSelector), nil)
 			},
 			expectedOutput: fmt.Sprintf("Pods in namespace %q with label %q:", defaultNamespace, labelSelector),
 		},
+		{
+			name: "WithFieldSelector",
+			args: map[string]interface{}{
+				"field_selector": "status.phase=Running,spec.nodeName=node-1",
+			},
+			expectedParams: kai.PodParams{
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("List", mock.Anything, mockCM, int64(0), "", "", "status.phase=Running,spec.nodeName=node-1").
+					Return(fmt.Sprintf("Pods in namespace %q:\n- web-1", defaultNamespace), nil)
+			},
+			expectedOutput: "web-1",
+		},
 		{
 			name: "WithLimit",
 			args: map[string]interface{}{
@@ -305,11 +558,27 @@ func TestListPodsHandler(t *testing.T) {
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockPod.On("List", mock.Anything, mockCM, int64(5), "", "").
+				mockPod.On("List", mock.Anything, mockCM, int64(5), "", "", "").
 					Return(fmt.Sprintf("Pods in namespace %q (limited to 5):\n- pod1\n- pod2\n- pod3\n- pod4\n- pod5", defaultNamespace), nil)
 			},
 			expectedOutput: fmt.Sprintf("Pods in namespace %q (limited to 5):", defaultNamespace),
 		},
+		{
+			name: "WithContinue",
+			args: map[string]interface{}{
+				"limit":    float64(2),
+				"continue": "page-2",
+			},
+			expectedParams: kai.PodParams{
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("List", mock.Anything, mockCM, int64(2), "page-2", "", "").
+					Return(fmt.Sprintf("Pods in namespace %q:\n- pod3", defaultNamespace), nil)
+			},
+			expectedOutput: "- pod3",
+		},
 		{
 			name: "Error",
 			args: map[string]interface{}{},
@@ -318,7 +587,7 @@ func TestListPodsHandler(t *testing.T) {
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockPod.On("List", mock.Anything, mockCM, int64(0), "", "").
+				mockPod.On("List", mock.Anything, mockCM, int64(0), "", "", "").
 					Return("", errors.New("failed to list pods: connection error"))
 			},
 			expectedOutput: "failed to list pods: connection error",
@@ -449,41 +718,24 @@ func TestGetPodHandler(t *testing.T) {
 	}
 }
 
-func TestDeletePodHandler(t *testing.T) {
-	testCases := []deletePodTestCase{
+func TestDescribePodHandler(t *testing.T) {
+	testCases := []getPodTestCase{
 		{
 			name: "Success",
 			args: map[string]interface{}{
-				"name": nginxPodName,
-			},
-			expectedParams: kai.PodParams{
-				Name:      nginxPodName,
-				Namespace: defaultNamespace,
-			},
-			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
-				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockPod.On("Delete", mock.Anything, mockCM, false).
-					Return(fmt.Sprintf(deleteSuccessMsgFmt, nginxPodName, defaultNamespace), nil)
-			},
-			expectedOutput:    fmt.Sprintf(deleteSuccessMsgFmt, nginxPodName, defaultNamespace),
-			expectPodCreation: true,
-		},
-		{
-			name: "WithForce",
-			args: map[string]interface{}{
-				"name":  nginxPodName,
-				"force": true,
+				"name":      nginxPodName,
+				"namespace": testNamespace,
 			},
 			expectedParams: kai.PodParams{
 				Name:      nginxPodName,
-				Namespace: defaultNamespace,
+				Namespace: testNamespace,
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockPod.On("Delete", mock.Anything, mockCM, true).
-					Return(fmt.Sprintf(deleteSuccessMsgFmt, nginxPodName, defaultNamespace), nil)
+				mockPod.On("Describe", mock.Anything, mockCM).
+					Return(fmt.Sprintf("Name: %s\nNamespace: %s\nQoS Class: BestEffort", nginxPodName, testNamespace), nil)
 			},
-			expectedOutput:    fmt.Sprintf(deleteSuccessMsgFmt, nginxPodName, defaultNamespace),
+			expectedOutput:    "QoS Class: BestEffort",
 			expectPodCreation: true,
 		},
 		{
@@ -491,9 +743,8 @@ func TestDeletePodHandler(t *testing.T) {
 			args:           map[string]interface{}{},
 			expectedParams: kai.PodParams{},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
-				// No setup needed
 			},
-			expectedOutput:    "Required parameter 'name' is missing",
+			expectedOutput:    errMissingName,
 			expectPodCreation: false,
 		},
 		{
@@ -507,10 +758,10 @@ func TestDeletePodHandler(t *testing.T) {
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockPod.On("Delete", mock.Anything, mockCM, false).
-					Return("", errors.New("failed to delete pod: not found"))
+				mockPod.On("Describe", mock.Anything, mockCM).
+					Return("", fmt.Errorf("pod %q not found in namespace %q", nonexistentPodName, defaultNamespace))
 			},
-			expectedOutput:    "failed to delete pod: not found",
+			expectedOutput:    fmt.Sprintf("pod %q not found in namespace %q", nonexistentPodName, defaultNamespace),
 			expectPodCreation: true,
 		},
 	}
@@ -528,20 +779,12 @@ func TestDeletePodHandler(t *testing.T) {
 
 			tc.mockSetup(mockCM, mockFactory, mockPod)
 
-			handler := deletePodHandler(mockCM, mockFactory)
-
-			request := mcp.CallToolRequest{
-				Params: mcp.CallToolParams{
-					Arguments: tc.args,
-				},
-			}
+			handler := describePodHandler(mockCM, mockFactory)
 
-			result, err := handler(context.Background(), request)
+			result, err := handler(context.Background(), toolRequest(tc.args))
 			assert.NoError(t, err)
-			assert.NotNil(t, result)
-			assert.Contains(t, result.Content[0].(mcp.TextContent).Text, tc.expectedOutput)
+			assert.Contains(t, resultText(t, result), tc.expectedOutput)
 
-			mockCM.AssertExpectations(t)
 			mockFactory.AssertExpectations(t)
 			if mockPod != nil {
 				mockPod.AssertExpectations(t)
@@ -550,12 +793,32 @@ func TestDeletePodHandler(t *testing.T) {
 	}
 }
 
-func TestStreamLogsHandler(t *testing.T) {
-	testCases := []logsTestCase{
+func TestWaitForPodHandler(t *testing.T) {
+	testCases := []getPodTestCase{
 		{
-			name: "BasicLogs",
+			name: "Ready",
 			args: map[string]interface{}{
-				"pod": nginxPodName,
+				"name":      nginxPodName,
+				"namespace": testNamespace,
+				"timeout":   "30s",
+			},
+			expectedParams: kai.PodParams{
+				Name:      nginxPodName,
+				Namespace: testNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("WaitFor", mock.Anything, mockCM, "", 30*time.Second).
+					Return(fmt.Sprintf("Pod %q in namespace %q is Ready after 4s", nginxPodName, testNamespace), nil)
+			},
+			expectedOutput:    "is Ready after 4s",
+			expectPodCreation: true,
+		},
+		{
+			name: "Deleted",
+			args: map[string]interface{}{
+				"name":      nginxPodName,
+				"condition": "Deleted",
 			},
 			expectedParams: kai.PodParams{
 				Name:      nginxPodName,
@@ -563,52 +826,49 @@ func TestStreamLogsHandler(t *testing.T) {
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockPod.On("StreamLogs", mock.Anything, mockCM, int64(0), false, (*time.Duration)(nil)).
-					Return(fmt.Sprintf("Logs from container 'nginx' in pod '%s/%s':\n2023-05-01T12:00:00Z INFO starting nginx\n2023-05-01T12:00:01Z INFO nginx started", defaultNamespace, nginxPodName), nil)
+				mockPod.On("WaitFor", mock.Anything, mockCM, "Deleted", time.Duration(0)).
+					Return(fmt.Sprintf("Pod %q in namespace %q was deleted after 2s", nginxPodName, defaultNamespace), nil)
 			},
-			expectedOutput:    fmt.Sprintf("Logs from container 'nginx' in pod '%s/%s':", defaultNamespace, nginxPodName),
+			expectedOutput:    "was deleted after 2s",
 			expectPodCreation: true,
 		},
 		{
-			name: "WithContainer",
+			name: "TimedOut",
 			args: map[string]interface{}{
-				"pod":       nginxPodName,
-				"container": "sidecar",
+				"name": nginxPodName,
 			},
 			expectedParams: kai.PodParams{
-				Name:          nginxPodName,
-				Namespace:     defaultNamespace,
-				ContainerName: "sidecar",
+				Name:      nginxPodName,
+				Namespace: defaultNamespace,
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockPod.On("StreamLogs", mock.Anything, mockCM, int64(0), false, (*time.Duration)(nil)).
-					Return(fmt.Sprintf("Logs from container 'sidecar' in pod '%s/%s':\n2023-05-01T12:00:00Z INFO starting sidecar\n2023-05-01T12:00:01Z INFO sidecar started", defaultNamespace, nginxPodName), nil)
+				mockPod.On("WaitFor", mock.Anything, mockCM, "", time.Duration(0)).
+					Return("", fmt.Errorf("timed out after 1m0s waiting for pod %q to be Ready (phase: Pending)", nginxPodName))
 			},
-			expectedOutput:    fmt.Sprintf("Logs from container 'sidecar' in pod '%s/%s':", defaultNamespace, nginxPodName),
+			expectedOutput:    "Failed to wait for pod: timed out after 1m0s",
 			expectPodCreation: true,
 		},
 		{
-			name: "InvalidSince",
+			name: "InvalidTimeout",
 			args: map[string]interface{}{
-				"pod":   nginxPodName,
-				"since": "invalid",
+				"name":    nginxPodName,
+				"timeout": "soon",
 			},
 			expectedParams: kai.PodParams{},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
 			},
-			expectedOutput:    "Failed to parse 'since' parameter",
+			expectedOutput:    "Failed to parse 'timeout' parameter",
 			expectPodCreation: false,
 		},
 		{
-			name:           "MissingPod",
+			name:           "MissingName",
 			args:           map[string]interface{}{},
 			expectedParams: kai.PodParams{},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
-				// No setup needed
 			},
-			expectedOutput:    "Required parameter 'pod' is missing",
+			expectedOutput:    errMissingName,
 			expectPodCreation: false,
 		},
 	}
@@ -626,20 +886,12 @@ func TestStreamLogsHandler(t *testing.T) {
 
 			tc.mockSetup(mockCM, mockFactory, mockPod)
 
-			handler := streamLogsHandler(mockCM, mockFactory)
-
-			request := mcp.CallToolRequest{
-				Params: mcp.CallToolParams{
-					Arguments: tc.args,
-				},
-			}
+			handler := waitForPodHandler(mockCM, mockFactory)
 
-			result, err := handler(context.Background(), request)
+			result, err := handler(context.Background(), toolRequest(tc.args))
 			assert.NoError(t, err)
-			assert.NotNil(t, result)
-			assert.Contains(t, result.Content[0].(mcp.TextContent).Text, tc.expectedOutput)
+			assert.Contains(t, resultText(t, result), tc.expectedOutput)
 
-			mockCM.AssertExpectations(t)
 			mockFactory.AssertExpectations(t)
 			if mockPod != nil {
 				mockPod.AssertExpectations(t)
@@ -648,25 +900,1035 @@ func TestStreamLogsHandler(t *testing.T) {
 	}
 }
 
-func TestRegisterPodTools(t *testing.T) {
-	mockServer := new(testmocks.MockServer)
-	mockCM := testmocks.NewMockClusterManager()
-
-	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(5)
-
-	RegisterPodTools(mockServer, mockCM)
-
-	mockServer.AssertExpectations(t)
-}
-
-func TestRegisterPodToolsWithFactory(t *testing.T) {
-	mockServer := new(testmocks.MockServer)
-	mockCM := testmocks.NewMockClusterManager()
-	mockFactory := new(testmocks.MockPodFactory)
-
-	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(5)
-
-	RegisterPodToolsWithFactory(mockServer, mockCM, mockFactory)
-
-	mockServer.AssertExpectations(t)
+func TestRunPodHandler(t *testing.T) {
+	deadline := int64(120)
+	testCases := []getPodTestCase{
+		{
+			name: "WaitAndRemove",
+			args: map[string]interface{}{
+				"name":                    "dns-check",
+				"image":                   "busybox:1.36",
+				"command":                 []interface{}{"nslookup", "kubernetes.default"},
+				"active_deadline_seconds": float64(120),
+				"timeout":                 "90s",
+				"rm":                      true,
+			},
+			expectedParams: kai.PodParams{
+				Name:                  "dns-check",
+				Namespace:             defaultNamespace,
+				Image:                 "busybox:1.36",
+				ContainerName:         "dns-check",
+				Command:               []interface{}{"nslookup", "kubernetes.default"},
+				ActiveDeadlineSeconds: &deadline,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("Run", mock.Anything, mockCM, true, 90*time.Second, true).
+					Return("Pod \"dns-check\" in namespace \"default\" completed after 3s\nPhase: Succeeded\n\nLogs:\nServer: 10.96.0.10\n\nPod \"dns-check\" deleted", nil)
+			},
+			expectedOutput:    "Server: 10.96.0.10",
+			expectPodCreation: true,
+		},
+		{
+			name: "NoWait",
+			args: map[string]interface{}{
+				"name":  "dns-check",
+				"image": "busybox:1.36",
+				"wait":  false,
+			},
+			expectedParams: kai.PodParams{
+				Name:          "dns-check",
+				Namespace:     defaultNamespace,
+				Image:         "busybox:1.36",
+				ContainerName: "dns-check",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("Run", mock.Anything, mockCM, false, time.Duration(0), false).
+					Return(`Pod "dns-check" created successfully in namespace "default"`, nil)
+			},
+			expectedOutput:    "created successfully",
+			expectPodCreation: true,
+		},
+		{
+			name: "RemoveWithoutWait",
+			args: map[string]interface{}{
+				"name":  "dns-check",
+				"image": "busybox:1.36",
+				"wait":  false,
+				"rm":    true,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+			},
+			expectedOutput: "Parameter 'rm' requires 'wait'",
+		},
+		{
+			name: "InvalidDeadline",
+			args: map[string]interface{}{
+				"name":                    "dns-check",
+				"image":                   "busybox:1.36",
+				"active_deadline_seconds": float64(0),
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+			},
+			expectedOutput: "Parameter 'active_deadline_seconds' must be a positive whole number",
+		},
+		{
+			name: "MissingImage",
+			args: map[string]interface{}{
+				"name": "dns-check",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+			},
+			expectedOutput: errMissingImage,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockFactory := new(testmocks.MockPodFactory)
+
+			var mockPod *testmocks.MockPod
+			if tc.expectPodCreation {
+				mockPod = testmocks.NewMockPod(tc.expectedParams)
+				mockFactory.On("NewPod", tc.expectedParams).Return(mockPod)
+			}
+
+			tc.mockSetup(mockCM, mockFactory, mockPod)
+
+			result, err := runPodHandler(mockCM, mockFactory)(context.Background(), toolRequest(tc.args))
+			assert.NoError(t, err)
+			assert.Contains(t, resultText(t, result), tc.expectedOutput)
+
+			mockFactory.AssertExpectations(t)
+			if mockPod != nil {
+				mockPod.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestDeletePodHandler(t *testing.T) {
+	testCases := []deletePodTestCase{
+		{
+			name: "Success",
+			args: map[string]interface{}{
+				"name": nginxPodName,
+			},
+			expectedParams: kai.PodParams{
+				Name:      nginxPodName,
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("Delete", mock.Anything, mockCM, false).
+					Return(fmt.Sprintf(deleteSuccessMsgFmt, nginxPodName, defaultNamespace), nil)
+			},
+			expectedOutput:    fmt.Sprintf(deleteSuccessMsgFmt, nginxPodName, defaultNamespace),
+			expectPodCreation: true,
+		},
+		{
+			name: "WithForce",
+			args: map[string]interface{}{
+				"name":  nginxPodName,
+				"force": true,
+			},
+			expectedParams: kai.PodParams{
+				Name:      nginxPodName,
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("Delete", mock.Anything, mockCM, true).
+					Return(fmt.Sprintf(deleteSuccessMsgFmt, nginxPodName, defaultNamespace), nil)
+			},
+			expectedOutput:    fmt.Sprintf(deleteSuccessMsgFmt, nginxPodName, defaultNamespace),
+			expectPodCreation: true,
+		},
+		{
+			name:           "MissingName",
+			args:           map[string]interface{}{},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				// No setup needed
+			},
+			expectedOutput:    "Required parameter 'name' is missing",
+			expectPodCreation: false,
+		},
+		{
+			name: "Error",
+			args: map[string]interface{}{
+				"name": nonexistentPodName,
+			},
+			expectedParams: kai.PodParams{
+				Name:      nonexistentPodName,
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("Delete", mock.Anything, mockCM, false).
+					Return("", errors.New("failed to delete pod: not found"))
+			},
+			expectedOutput:    "failed to delete pod: not found",
+			expectPodCreation: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockFactory := new(testmocks.MockPodFactory)
+
+			var mockPod *testmocks.MockPod
+			if tc.expectPodCreation {
+				mockPod = testmocks.NewMockPod(tc.expectedParams)
+				mockFactory.On("NewPod", tc.expectedParams).Return(mockPod)
+			}
+
+			tc.mockSetup(mockCM, mockFactory, mockPod)
+
+			handler := deletePodHandler(mockCM, mockFactory)
+
+			request := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.args,
+				},
+			}
+
+			result, err := handler(context.Background(), request)
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+			assert.Contains(t, result.Content[0].(mcp.TextContent).Text, tc.expectedOutput)
+
+			mockCM.AssertExpectations(t)
+			mockFactory.AssertExpectations(t)
+			if mockPod != nil {
+				mockPod.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestStreamLogsHandler(t *testing.T) {
+	testCases := []logsTestCase{
+		{
+			name: "BasicLogs",
+			args: map[string]interface{}{
+				"pod": nginxPodName,
+			},
+			expectedParams: kai.PodParams{
+				Name:      nginxPodName,
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("StreamLogs", mock.Anything, mockCM, int64(0), false, (*time.Duration)(nil)).
+					Return(fmt.Sprintf("Logs from container 'nginx' in pod '%s/%s':\n2023-05-01T12:00:00Z INFO starting nginx\n2023-05-01T12:00:01Z INFO nginx started", defaultNamespace, nginxPodName), nil)
+			},
+			expectedOutput:    fmt.Sprintf("Logs from container 'nginx' in pod '%s/%s':", defaultNamespace, nginxPodName),
+			expectPodCreation: true,
+		},
+		{
+			name: "WithContainer",
+			args: map[string]interface{}{
+				"pod":       nginxPodName,
+				"container": "sidecar",
+			},
+			expectedParams: kai.PodParams{
+				Name:          nginxPodName,
+				Namespace:     defaultNamespace,
+				ContainerName: "sidecar",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("StreamLogs", mock.Anything, mockCM, int64(0), false, (*time.Duration)(nil)).
+					Return(fmt.Sprintf("Logs from container 'sidecar' in pod '%s/%s':\n2023-05-01T12:00:00Z INFO starting sidecar\n2023-05-01T12:00:01Z INFO sidecar started", defaultNamespace, nginxPodName), nil)
+			},
+			expectedOutput:    fmt.Sprintf("Logs from container 'sidecar' in pod '%s/%s':", defaultNamespace, nginxPodName),
+			expectPodCreation: true,
+		},
+		{
+			name: "AllContainers",
+			args: map[string]interface{}{
+				"pod":            nginxPodName,
+				"all_containers": true,
+				"include_init":   true,
+			},
+			expectedParams: kai.PodParams{
+				Name:      nginxPodName,
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("StreamAllLogs", mock.Anything, mockCM, int64(0), false, (*time.Duration)(nil), true).
+					Return(fmt.Sprintf("Logs from 3 container(s) in pod '%s/%s' (include_init=true):\n\n[init] migrated\n[nginx] started", defaultNamespace, nginxPodName), nil)
+			},
+			expectedOutput:    "[init] migrated\n[nginx] started",
+			expectPodCreation: true,
+		},
+		{
+			name: "AllContainersWithContainer",
+			args: map[string]interface{}{
+				"pod":            nginxPodName,
+				"container":      "sidecar",
+				"all_containers": true,
+			},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+			},
+			expectedOutput:    "Cannot combine 'container' with 'all_containers'",
+			expectPodCreation: false,
+		},
+		{
+			name: "IncludeInitWithoutAllContainers",
+			args: map[string]interface{}{
+				"pod":          nginxPodName,
+				"include_init": true,
+			},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+			},
+			expectedOutput:    "'include_init' requires 'all_containers'",
+			expectPodCreation: false,
+		},
+		{
+			name: "InvalidSince",
+			args: map[string]interface{}{
+				"pod":   nginxPodName,
+				"since": "invalid",
+			},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+			},
+			expectedOutput:    "Failed to parse 'since' parameter",
+			expectPodCreation: false,
+		},
+		{
+			name:           "MissingPod",
+			args:           map[string]interface{}{},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				// No setup needed
+			},
+			expectedOutput:    "Required parameter 'pod' is missing",
+			expectPodCreation: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockFactory := new(testmocks.MockPodFactory)
+
+			var mockPod *testmocks.MockPod
+			if tc.expectPodCreation {
+				mockPod = testmocks.NewMockPod(tc.expectedParams)
+				mockFactory.On("NewPod", tc.expectedParams).Return(mockPod)
+			}
+
+			tc.mockSetup(mockCM, mockFactory, mockPod)
+
+			handler := streamLogsHandler(mockCM, mockFactory)
+
+			request := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.args,
+				},
+			}
+
+			result, err := handler(context.Background(), request)
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+			assert.Contains(t, result.Content[0].(mcp.TextContent).Text, tc.expectedOutput)
+
+			mockCM.AssertExpectations(t)
+			mockFactory.AssertExpectations(t)
+			if mockPod != nil {
+				mockPod.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestLogsAllRestartsHandler(t *testing.T) {
+	testCases := []logsTestCase{
+		{
+			name: "WithPreviousInstance",
+			args: map[string]interface{}{
+				"pod":       nginxPodName,
+				"container": "nginx",
+				"tail":      float64(50),
+			},
+			expectedParams: kai.PodParams{
+				Name:          nginxPodName,
+				Namespace:     defaultNamespace,
+				ContainerName: "nginx",
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("LogsAcrossRestarts", mock.Anything, mockCM, int64(50)).
+					Return("===== previous instance (exit code 1: Error) =====\npanic: boom\n\n===== current instance =====\nstarting", nil)
+			},
+			expectedOutput:    "===== previous instance (exit code 1: Error) =====",
+			expectPodCreation: true,
+		},
+		{
+			name: "WithoutPreviousInstance",
+			args: map[string]interface{}{
+				"pod": nginxPodName,
+			},
+			expectedParams: kai.PodParams{
+				Name:      nginxPodName,
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("LogsAcrossRestarts", mock.Anything, mockCM, int64(0)).
+					Return("===== previous instance =====\n<container has not restarted, so there is no previous instance>\n\n===== current instance =====\nstarting", nil)
+			},
+			expectedOutput:    "<container has not restarted, so there is no previous instance>",
+			expectPodCreation: true,
+		},
+		{
+			name: "PodNotFound",
+			args: map[string]interface{}{
+				"pod": "missing",
+			},
+			expectedParams: kai.PodParams{
+				Name:      "missing",
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("LogsAcrossRestarts", mock.Anything, mockCM, int64(0)).
+					Return("", fmt.Errorf("pod 'missing' not found in namespace '%s'", defaultNamespace))
+			},
+			expectedOutput:    "Failed to read logs: pod 'missing' not found",
+			expectPodCreation: true,
+		},
+		{
+			name:           "MissingPod",
+			args:           map[string]interface{}{},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+			},
+			expectedOutput:    "Required parameter 'pod' is missing",
+			expectPodCreation: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockFactory := new(testmocks.MockPodFactory)
+
+			var mockPod *testmocks.MockPod
+			if tc.expectPodCreation {
+				mockPod = testmocks.NewMockPod(tc.expectedParams)
+				mockFactory.On("NewPod", tc.expectedParams).Return(mockPod)
+			}
+
+			tc.mockSetup(mockCM, mockFactory, mockPod)
+
+			handler := logsAllRestartsHandler(mockCM, mockFactory)
+			result, err := handler(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tc.args},
+			})
+			assert.NoError(t, err)
+			assert.Contains(t, result.Content[0].(mcp.TextContent).Text, tc.expectedOutput)
+
+			mockCM.AssertExpectations(t)
+			mockFactory.AssertExpectations(t)
+			if mockPod != nil {
+				mockPod.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestSearchLogsHandler(t *testing.T) {
+	testCases := []logsTestCase{
+		{
+			name: "MatchWithContext",
+			args: map[string]interface{}{
+				"pod":     nginxPodName,
+				"pattern": "ERROR",
+				"before":  float64(1),
+				"after":   float64(2),
+			},
+			expectedParams: kai.PodParams{
+				Name:      nginxPodName,
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("SearchLogs", mock.Anything, mockCM, "ERROR", 1, 2, int64(0)).
+					Return("1 line(s) matching \"ERROR\":\n\n4-INFO request\n5:ERROR upstream timed out", nil)
+			},
+			expectedOutput:    "5:ERROR upstream timed out",
+			expectPodCreation: true,
+		},
+		{
+			name: "InvalidPattern",
+			args: map[string]interface{}{
+				"pod":     nginxPodName,
+				"pattern": "(unclosed",
+			},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				// No setup needed
+			},
+			expectedOutput:    "Invalid 'pattern' parameter",
+			expectPodCreation: false,
+		},
+		{
+			name: "MissingPattern",
+			args: map[string]interface{}{
+				"pod": nginxPodName,
+			},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				// No setup needed
+			},
+			expectedOutput:    errMissingPattern,
+			expectPodCreation: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockFactory := new(testmocks.MockPodFactory)
+
+			var mockPod *testmocks.MockPod
+			if tc.expectPodCreation {
+				mockPod = testmocks.NewMockPod(tc.expectedParams)
+				mockFactory.On("NewPod", tc.expectedParams).Return(mockPod)
+			}
+
+			tc.mockSetup(mockCM, mockFactory, mockPod)
+
+			handler := searchLogsHandler(mockCM, mockFactory)
+
+			request := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.args,
+				},
+			}
+
+			result, err := handler(context.Background(), request)
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+			assert.Contains(t, result.Content[0].(mcp.TextContent).Text, tc.expectedOutput)
+
+			mockCM.AssertExpectations(t)
+			mockFactory.AssertExpectations(t)
+			if mockPod != nil {
+				mockPod.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestExecPodHandler(t *testing.T) {
+	testCases := []logsTestCase{
+		{
+			name: "Success",
+			args: map[string]interface{}{
+				"pod":     nginxPodName,
+				"command": []interface{}{"cat", "/etc/hostname"},
+			},
+			expectedParams: kai.PodParams{
+				Name:      nginxPodName,
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("Exec", mock.Anything, mockCM, "", []string{"cat", "/etc/hostname"}).
+					Return(fmt.Sprintf("Ran \"cat /etc/hostname\" in %s/nginx (exit code 0)\n\nstdout:\n%s", nginxPodName, nginxPodName), nil)
+			},
+			expectedOutput:    "(exit code 0)",
+			expectPodCreation: true,
+		},
+		{
+			name: "WithContainerAndNamespace",
+			args: map[string]interface{}{
+				"pod":       nginxPodName,
+				"namespace": testNamespace,
+				"container": "sidecar",
+				"command":   []interface{}{"env"},
+			},
+			expectedParams: kai.PodParams{
+				Name:      nginxPodName,
+				Namespace: testNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("Exec", mock.Anything, mockCM, "sidecar", []string{"env"}).
+					Return(fmt.Sprintf("Ran \"env\" in %s/sidecar (exit code 0)", nginxPodName), nil)
+			},
+			expectedOutput:    "/sidecar",
+			expectPodCreation: true,
+		},
+		{
+			name: "AmbiguousContainer",
+			args: map[string]interface{}{
+				"pod":     nginxPodName,
+				"command": []interface{}{"ls"},
+			},
+			expectedParams: kai.PodParams{
+				Name:      nginxPodName,
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("Exec", mock.Anything, mockCM, "", []string{"ls"}).
+					Return("", fmt.Errorf("pod %q has 2 containers (nginx, sidecar); specify container", nginxPodName))
+			},
+			expectedOutput:    "Failed to exec in pod: pod \"" + nginxPodName + "\" has 2 containers (nginx, sidecar); specify container",
+			expectPodCreation: true,
+		},
+		{
+			name:           "MissingPod",
+			args:           map[string]interface{}{"command": []interface{}{"ls"}},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+			},
+			expectedOutput:    errMissingPod,
+			expectPodCreation: false,
+		},
+		{
+			name:           "MissingCommand",
+			args:           map[string]interface{}{"pod": nginxPodName},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+			},
+			expectedOutput:    "Parameter 'command' must be a non-empty array of strings",
+			expectPodCreation: false,
+		},
+		{
+			name: "NonStringCommand",
+			args: map[string]interface{}{
+				"pod":     nginxPodName,
+				"command": []interface{}{"sleep", float64(5)},
+			},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+			},
+			expectedOutput:    "Parameter 'command' must be a non-empty array of strings",
+			expectPodCreation: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockFactory := new(testmocks.MockPodFactory)
+
+			var mockPod *testmocks.MockPod
+			if tc.expectPodCreation {
+				mockPod = testmocks.NewMockPod(tc.expectedParams)
+				mockFactory.On("NewPod", tc.expectedParams).Return(mockPod)
+			}
+
+			tc.mockSetup(mockCM, mockFactory, mockPod)
+
+			result, err := execPodHandler(mockCM, mockFactory)(context.Background(), toolRequest(tc.args))
+			assert.NoError(t, err)
+			assert.Contains(t, resultText(t, result), tc.expectedOutput)
+
+			mockCM.AssertExpectations(t)
+			mockFactory.AssertExpectations(t)
+			if mockPod != nil {
+				mockPod.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestCopyPodHandlers(t *testing.T) {
+	type handlerFunc func(kai.ClusterManager, PodFactory) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	testCases := []struct {
+		logsTestCase
+		handler handlerFunc
+	}{
+		{
+			handler: copyToPodHandler,
+			logsTestCase: logsTestCase{
+				name: "CopyToDecodesContent",
+				args: map[string]interface{}{
+					"pod":       nginxPodName,
+					"container": "nginx",
+					"dest_path": "/tmp/app.conf",
+					"content":   base64.StdEncoding.EncodeToString([]byte("listen 8080;\n")),
+				},
+				expectedParams: kai.PodParams{Name: nginxPodName, Namespace: defaultNamespace},
+				mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+					mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+					mockPod.On("CopyTo", mock.Anything, mockCM, "nginx", []byte("listen 8080;\n"), "/tmp/app.conf").
+						Return(fmt.Sprintf("Copied 13 bytes to /tmp/app.conf in %s/nginx", nginxPodName), nil)
+				},
+				expectedOutput:    "Copied 13 bytes to /tmp/app.conf",
+				expectPodCreation: true,
+			},
+		},
+		{
+			handler: copyToPodHandler,
+			logsTestCase: logsTestCase{
+				name: "CopyToInvalidBase64",
+				args: map[string]interface{}{
+					"pod":       nginxPodName,
+					"dest_path": "/tmp/app.conf",
+					"content":   "not base64!",
+				},
+				mockSetup:      func(*testmocks.MockClusterManager, *testmocks.MockPodFactory, *testmocks.MockPod) {},
+				expectedOutput: "Parameter 'content' must be base64-encoded",
+			},
+		},
+		{
+			handler: copyToPodHandler,
+			logsTestCase: logsTestCase{
+				name:           "CopyToMissingPath",
+				args:           map[string]interface{}{"pod": nginxPodName, "content": ""},
+				mockSetup:      func(*testmocks.MockClusterManager, *testmocks.MockPodFactory, *testmocks.MockPod) {},
+				expectedOutput: "Required parameter 'dest_path' is missing",
+			},
+		},
+		{
+			handler: copyFromPodHandler,
+			logsTestCase: logsTestCase{
+				name: "CopyFrom",
+				args: map[string]interface{}{
+					"pod":       nginxPodName,
+					"namespace": testNamespace,
+					"src_path":  "/etc/hostname",
+				},
+				expectedParams: kai.PodParams{Name: nginxPodName, Namespace: testNamespace},
+				mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+					mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+					mockPod.On("CopyFrom", mock.Anything, mockCM, "", "/etc/hostname").
+						Return("", errors.New(`container "nginx" has no tar binary, which copying requires; use an image that includes tar`))
+				},
+				expectedOutput:    "Failed to copy from pod: container \"nginx\" has no tar binary",
+				expectPodCreation: true,
+			},
+		},
+		{
+			handler: copyFromPodHandler,
+			logsTestCase: logsTestCase{
+				name:           "CopyFromMissingPod",
+				args:           map[string]interface{}{"src_path": "/etc/hostname"},
+				mockSetup:      func(*testmocks.MockClusterManager, *testmocks.MockPodFactory, *testmocks.MockPod) {},
+				expectedOutput: errMissingPod,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockFactory := new(testmocks.MockPodFactory)
+
+			var mockPod *testmocks.MockPod
+			if tc.expectPodCreation {
+				mockPod = testmocks.NewMockPod(tc.expectedParams)
+				mockFactory.On("NewPod", tc.expectedParams).Return(mockPod)
+			}
+
+			tc.mockSetup(mockCM, mockFactory, mockPod)
+
+			result, err := tc.handler(mockCM, mockFactory)(context.Background(), toolRequest(tc.args))
+			assert.NoError(t, err)
+			assert.Contains(t, resultText(t, result), tc.expectedOutput)
+
+			mockCM.AssertExpectations(t)
+			mockFactory.AssertExpectations(t)
+			if mockPod != nil {
+				mockPod.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestPortForwardPodHandler(t *testing.T) {
+	testCases := []logsTestCase{
+		{
+			name: "DefaultsLocalPortAndDuration",
+			args: map[string]interface{}{
+				"pod":      nginxPodName,
+				"pod_port": float64(80),
+			},
+			expectedParams: kai.PodParams{
+				Name:      nginxPodName,
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("PortForward", mock.Anything, mockCM, 80, 80, time.Duration(0)).
+					Return(fmt.Sprintf("Forwarded localhost:80 -> pod %s/%s:80 for 30s", defaultNamespace, nginxPodName), nil)
+			},
+			expectedOutput:    "Forwarded localhost:80",
+			expectPodCreation: true,
+		},
+		{
+			name: "ExplicitLocalPortAndDuration",
+			args: map[string]interface{}{
+				"pod":        nginxPodName,
+				"pod_port":   float64(80),
+				"local_port": float64(0),
+				"duration":   "2m",
+			},
+			expectedParams: kai.PodParams{
+				Name:      nginxPodName,
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("PortForward", mock.Anything, mockCM, 0, 80, 2*time.Minute).
+					Return("Forwarded localhost:41234 -> pod default/nginx:80 for 2m0s", nil)
+			},
+			expectedOutput:    "localhost:41234",
+			expectPodCreation: true,
+		},
+		{
+			name: "Error",
+			args: map[string]interface{}{
+				"pod":      nginxPodName,
+				"pod_port": float64(80),
+			},
+			expectedParams: kai.PodParams{
+				Name:      nginxPodName,
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("PortForward", mock.Anything, mockCM, 80, 80, time.Duration(0)).
+					Return("", fmt.Errorf("pod %q is Pending; port forwarding needs a running pod", nginxPodName))
+			},
+			expectedOutput:    "Failed to port forward: pod",
+			expectPodCreation: true,
+		},
+		{
+			name:           "MissingPodPort",
+			args:           map[string]interface{}{"pod": nginxPodName},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+			},
+			expectedOutput:    "Required parameter 'pod_port' is missing",
+			expectPodCreation: false,
+		},
+		{
+			name:           "InvalidPodPort",
+			args:           map[string]interface{}{"pod": nginxPodName, "pod_port": float64(80.5)},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+			},
+			expectedOutput:    "must be an integer between 1 and 65535",
+			expectPodCreation: false,
+		},
+		{
+			name:           "InvalidDuration",
+			args:           map[string]interface{}{"pod": nginxPodName, "pod_port": float64(80), "duration": "soon"},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+			},
+			expectedOutput:    "Invalid duration \"soon\"",
+			expectPodCreation: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockFactory := new(testmocks.MockPodFactory)
+
+			var mockPod *testmocks.MockPod
+			if tc.expectPodCreation {
+				mockPod = testmocks.NewMockPod(tc.expectedParams)
+				mockFactory.On("NewPod", tc.expectedParams).Return(mockPod)
+			}
+
+			tc.mockSetup(mockCM, mockFactory, mockPod)
+
+			result, err := portForwardPodHandler(mockCM, mockFactory)(context.Background(), toolRequest(tc.args))
+			assert.NoError(t, err)
+			assert.Contains(t, resultText(t, result), tc.expectedOutput)
+
+			mockCM.AssertExpectations(t)
+			mockFactory.AssertExpectations(t)
+			if mockPod != nil {
+				mockPod.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestAttachPodHandler(t *testing.T) {
+	testCases := []logsTestCase{
+		{
+			name:           "Defaults",
+			args:           map[string]interface{}{"pod": nginxPodName},
+			expectedParams: kai.PodParams{Name: nginxPodName, Namespace: defaultNamespace},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("Attach", mock.Anything, mockCM, "", "", time.Duration(0), 0).
+					Return("Attached to default/nginx (container nginx) for 10s; detached: duration elapsed", nil)
+			},
+			expectedOutput:    "detached: duration elapsed",
+			expectPodCreation: true,
+		},
+		{
+			name: "AllArguments",
+			args: map[string]interface{}{
+				"pod":       nginxPodName,
+				"namespace": testNamespace,
+				"container": "repl",
+				"stdin":     "status\n",
+				"duration":  "1m",
+				"max_bytes": float64(4096),
+			},
+			expectedParams: kai.PodParams{Name: nginxPodName, Namespace: testNamespace},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("Attach", mock.Anything, mockCM, "repl", "status\n", time.Minute, 4096).
+					Return("Attached to test-namespace/nginx (container repl) for 1s; detached: stream closed", nil)
+			},
+			expectedOutput:    "container repl",
+			expectPodCreation: true,
+		},
+		{
+			name:           "Error",
+			args:           map[string]interface{}{"pod": nginxPodName},
+			expectedParams: kai.PodParams{Name: nginxPodName, Namespace: defaultNamespace},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockPod.On("Attach", mock.Anything, mockCM, "", "", time.Duration(0), 0).
+					Return("", fmt.Errorf("container %q in pod %q is not running", "nginx", nginxPodName))
+			},
+			expectedOutput:    "Failed to attach to pod: container",
+			expectPodCreation: true,
+		},
+		{
+			name:           "MissingPod",
+			args:           map[string]interface{}{},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+			},
+			expectedOutput:    errMissingPod,
+			expectPodCreation: false,
+		},
+		{
+			name:           "InvalidDuration",
+			args:           map[string]interface{}{"pod": nginxPodName, "duration": "-5s"},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+			},
+			expectedOutput:    "Invalid duration \"-5s\"",
+			expectPodCreation: false,
+		},
+		{
+			name:           "InvalidMaxBytes",
+			args:           map[string]interface{}{"pod": nginxPodName, "max_bytes": float64(0)},
+			expectedParams: kai.PodParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockPodFactory, mockPod *testmocks.MockPod) {
+			},
+			expectedOutput:    "Parameter 'max_bytes' must be a positive integer",
+			expectPodCreation: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockFactory := new(testmocks.MockPodFactory)
+
+			var mockPod *testmocks.MockPod
+			if tc.expectPodCreation {
+				mockPod = testmocks.NewMockPod(tc.expectedParams)
+				mockFactory.On("NewPod", tc.expectedParams).Return(mockPod)
+			}
+
+			tc.mockSetup(mockCM, mockFactory, mockPod)
+
+			result, err := attachPodHandler(mockCM, mockFactory)(context.Background(), toolRequest(tc.args))
+			assert.NoError(t, err)
+			assert.Contains(t, resultText(t, result), tc.expectedOutput)
+
+			mockCM.AssertExpectations(t)
+			mockFactory.AssertExpectations(t)
+			if mockPod != nil {
+				mockPod.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestRegisterPodTools(t *testing.T) {
+	mockServer := new(testmocks.MockServer)
+	mockCM := testmocks.NewMockClusterManager()
+
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(17)
+
+	RegisterPodTools(mockServer, mockCM)
+
+	mockServer.AssertExpectations(t)
+}
+
+func TestRegisterPodToolsWithFactory(t *testing.T) {
+	mockServer := new(testmocks.MockServer)
+	mockCM := testmocks.NewMockClusterManager()
+	mockFactory := new(testmocks.MockPodFactory)
+
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(17)
+
+	RegisterPodToolsWithFactory(mockServer, mockCM, mockFactory)
+
+	mockServer.AssertExpectations(t)
+}
+
+func TestPodByIPHandler(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: testPodName, Namespace: testNamespace},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: nginxImage}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.244.0.12"},
+	}
+
+	tests := []struct {
+		name           string
+		args           map[string]interface{}
+		expectedOutput string
+	}{
+		{
+			name:           "Found",
+			args:           map[string]interface{}{"ip": "10.244.0.12"},
+			expectedOutput: testPodName,
+		},
+		{
+			name:           "NotFound",
+			args:           map[string]interface{}{"ip": "10.244.0.99"},
+			expectedOutput: "Failed to find pod by IP: no pod found with IP 10.244.0.99",
+		},
+		{
+			name:           "MissingIP",
+			args:           map[string]interface{}{},
+			expectedOutput: "Required parameter 'ip' is missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(pod), nil)
+
+			result, err := podByIPHandler(mockCM)(context.Background(), toolRequest(tt.args))
+			assert.NoError(t, err)
+			assert.Contains(t, resultText(t, result), tt.expectedOutput)
+		})
+	}
 }
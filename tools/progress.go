@@ -0,0 +1,42 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// progressReporter returns a callback that sends MCP progress notifications
+// for the request, or nil when the client did not ask for progress by
+// setting a progress token.
+func progressReporter(ctx context.Context, request mcp.CallToolRequest) func(done, total int, message string) {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return nil
+	}
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return nil
+	}
+	return progressNotifier(ctx, request.Params.Meta.ProgressToken, srv.SendNotificationToClient)
+}
+
+// progressNotifier builds the notifications/progress callback around send.
+// Progress is best effort: a notification that cannot be delivered is
+// logged and the operation carries on.
+func progressNotifier(ctx context.Context, token mcp.ProgressToken, send func(context.Context, string, map[string]any) error) func(done, total int, message string) {
+	return func(done, total int, message string) {
+		params := map[string]any{
+			"progressToken": token,
+			"progress":      done,
+			"message":       message,
+		}
+		if total > 0 {
+			params["total"] = total
+		}
+		if err := send(ctx, "notifications/progress", params); err != nil {
+			slog.Debug("failed to send progress notification", slog.String("error", err.Error()))
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressReporter(t *testing.T) {
+	t.Run("NoProgressToken", func(t *testing.T) {
+		assert.Nil(t, progressReporter(context.Background(), toolRequest(map[string]any{"name": "node-1"})))
+	})
+
+	t.Run("NoServerInContext", func(t *testing.T) {
+		request := toolRequest(map[string]any{"name": "node-1"})
+		request.Params.Meta = &mcp.Meta{ProgressToken: "drain-1"}
+		assert.Nil(t, progressReporter(context.Background(), request))
+	})
+
+	t.Run("SendsNotifications", func(t *testing.T) {
+		var sent []map[string]any
+		report := progressNotifier(context.Background(), "drain-1", func(_ context.Context, method string, params map[string]any) error {
+			assert.Equal(t, "notifications/progress", method)
+			sent = append(sent, params)
+			return nil
+		})
+
+		report(1, 2, "evicted default/web")
+		report(2, 0, "done")
+
+		assert.Equal(t, []map[string]any{
+			{"progressToken": "drain-1", "progress": 1, "total": 2, "message": "evicted default/web"},
+			{"progressToken": "drain-1", "progress": 2, "message": "done"},
+		}, sent)
+	})
+}
@@ -0,0 +1,195 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/basebandit/kai"
+	"github.com/basebandit/kai/cluster"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// PVCFactory is an interface for creating PersistentVolumeClaim operators.
+type PVCFactory interface {
+	NewPVC(params kai.PVCParams) kai.PVCOperator
+}
+
+// DefaultPVCFactory implements the PVCFactory interface.
+type DefaultPVCFactory struct{}
+
+// NewDefaultPVCFactory creates a new DefaultPVCFactory.
+func NewDefaultPVCFactory() *DefaultPVCFactory {
+	return &DefaultPVCFactory{}
+}
+
+// NewPVC creates a new PersistentVolumeClaim operator.
+func (f *DefaultPVCFactory) NewPVC(params kai.PVCParams) kai.PVCOperator {
+	return &cluster.PersistentVolumeClaim{
+		Name:             params.Name,
+		Namespace:        params.Namespace,
+		StorageClassName: params.StorageClassName,
+		AccessModes:      params.AccessModes,
+		Storage:          params.Storage,
+		VolumeMode:       params.VolumeMode,
+		Labels:           params.Labels,
+		Annotations:      params.Annotations,
+		DryRun:           params.DryRun,
+	}
+}
+
+// RegisterPVCTools registers all PersistentVolumeClaim tools with the server.
+func RegisterPVCTools(s kai.ServerInterface, cm kai.ClusterManager) {
+	factory := NewDefaultPVCFactory()
+	RegisterPVCToolsWithFactory(s, cm, factory)
+}
+
+// RegisterPVCToolsWithFactory registers all PersistentVolumeClaim tools using the provided factory.
+func RegisterPVCToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, factory PVCFactory) {
+	s.AddTool(mcp.NewTool("create_persistent_volume_claim",
+		mcp.WithDescription("Create a persistent volume claim"),
+		creationAnnotation("Create PVC"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the PVC")),
+		mcp.WithString("namespace", mcp.Description("Namespace (defaults to current)")),
+		mcp.WithString("storage", mcp.Required(), mcp.Description("Requested storage, e.g. '10Gi'")),
+		mcp.WithString("storage_class", mcp.Description("Storage class name")),
+		mcp.WithString("volume_mode", mcp.Description("Volume mode: Filesystem (default) or Block")),
+		mcp.WithArray("access_modes", mcp.Description("Access modes (ReadWriteOnce, ReadOnlyMany, ReadWriteMany, ReadWriteOncePod)")),
+		mcp.WithObject("labels", mcp.Description("Labels to apply to the PVC")),
+		dryRunOption(),
+	), createPVCHandler(cm, factory))
+
+	s.AddTool(mcp.NewTool("list_persistent_volume_claims",
+		mcp.WithDescription("List persistent volume claims in a namespace"),
+		readOnlyAnnotation("List PVCs"),
+		mcp.WithString("namespace", mcp.Description("Namespace (defaults to current)")),
+		mcp.WithBoolean("all_namespaces", mcp.Description("List across all namespaces")),
+		confirmScanOption(),
+		mcp.WithString("label_selector", mcp.Description("Label selector to filter PVCs")),
+	), listPVCHandler(cm, factory))
+
+	s.AddTool(mcp.NewTool("get_persistent_volume_claim",
+		mcp.WithDescription("Get details about a specific persistent volume claim, including its bound volume, capacity, phase and access modes"),
+		readOnlyAnnotation("Get PVC"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the PVC")),
+		mcp.WithString("namespace", mcp.Description("Namespace (defaults to current)")),
+	), getPVCHandler(cm, factory))
+
+	s.AddTool(mcp.NewTool("delete_persistent_volume_claim",
+		mcp.WithDescription("Delete a persistent volume claim"),
+		destructiveAnnotation("Delete PVC"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the PVC")),
+		mcp.WithString("namespace", mcp.Description("Namespace (defaults to current)")),
+	), deletePVCHandler(cm, factory))
+}
+
+func createPVCHandler(cm kai.ClusterManager, factory PVCFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "create_persistent_volume_claim"))
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if err := validateResourceName(name); err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+		params := kai.PVCParams{Name: name}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			params.Namespace = ns
+		}
+		if storage, ok := request.GetArguments()["storage"].(string); ok {
+			params.Storage = storage
+		}
+		if sc, ok := request.GetArguments()["storage_class"].(string); ok {
+			params.StorageClassName = sc
+		}
+		if vm, ok := request.GetArguments()["volume_mode"].(string); ok {
+			params.VolumeMode = vm
+		}
+		if modes, ok := request.GetArguments()["access_modes"].([]interface{}); ok {
+			for _, m := range modes {
+				if s, ok := m.(string); ok {
+					params.AccessModes = append(params.AccessModes, s)
+				}
+			}
+		}
+		if labels, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labels); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
+			params.Labels = labels
+		}
+		params.DryRun = dryRunArg(request)
+		result, err := factory.NewPVC(params).Create(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to create PVC: %s", classifyError(err))), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func listPVCHandler(cm kai.ClusterManager, factory PVCFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "list_persistent_volume_claims"))
+		params := kai.PVCParams{}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			params.Namespace = ns
+		}
+		allNamespaces := false
+		if all, ok := request.GetArguments()["all_namespaces"].(bool); ok {
+			allNamespaces = all
+		}
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
+		labelSelector := ""
+		if ls, ok := request.GetArguments()["label_selector"].(string); ok {
+			labelSelector = ls
+		}
+		result, err := factory.NewPVC(params).List(ctx, cm, allNamespaces, labelSelector)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to list PVCs: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func getPVCHandler(cm kai.ClusterManager, factory PVCFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "get_persistent_volume_claim"))
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		params := kai.PVCParams{Name: name}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			params.Namespace = ns
+		}
+		result, err := factory.NewPVC(params).Get(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get PVC: %s", classifyError(err))), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func deletePVCHandler(cm kai.ClusterManager, factory PVCFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "delete_persistent_volume_claim"))
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		params := kai.PVCParams{Name: name}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			params.Namespace = ns
+		}
+		result, err := factory.NewPVC(params).Delete(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete PVC: %s", classifyError(err))), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
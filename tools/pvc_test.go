@@ -0,0 +1,122 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/basebandit/kai"
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRegisterPVCTools(t *testing.T) {
+	mockServer := &testmocks.MockServer{}
+	mockCM := testmocks.NewMockClusterManager()
+	mockFactory := testmocks.NewMockPVCFactory()
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(4)
+	RegisterPVCToolsWithFactory(mockServer, mockCM, mockFactory)
+	mockServer.AssertExpectations(t)
+}
+
+func TestCreatePVCHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           map[string]interface{}
+		mockSetup      func(*testmocks.MockPVCFactory, *testmocks.MockPVC)
+		expectedOutput string
+	}{
+		{
+			name: "Create",
+			args: map[string]interface{}{
+				"name":          "data",
+				"namespace":     testNamespace,
+				"storage":       "10Gi",
+				"storage_class": "fast",
+				"volume_mode":   "Block",
+				"access_modes":  []interface{}{"ReadWriteOnce"},
+				"labels":        map[string]interface{}{"app": "db"},
+			},
+			mockSetup: func(mockFactory *testmocks.MockPVCFactory, mockPVC *testmocks.MockPVC) {
+				mockFactory.On("NewPVC", kai.PVCParams{
+					Name:             "data",
+					Namespace:        testNamespace,
+					Storage:          "10Gi",
+					StorageClassName: "fast",
+					VolumeMode:       "Block",
+					AccessModes:      []string{"ReadWriteOnce"},
+					Labels:           map[string]interface{}{"app": "db"},
+				}).Return(mockPVC)
+				mockPVC.On("Create", mock.Anything, mock.Anything).Return(`PersistentVolumeClaim "data" created successfully in namespace "test-namespace"`, nil)
+			},
+			expectedOutput: `PersistentVolumeClaim "data" created successfully in namespace "test-namespace"`,
+		},
+		{
+			name: "Create error",
+			args: map[string]interface{}{"name": "data", "storage": "10Gi", "access_modes": []interface{}{"RWO"}},
+			mockSetup: func(mockFactory *testmocks.MockPVCFactory, mockPVC *testmocks.MockPVC) {
+				mockFactory.On("NewPVC", mock.Anything).Return(mockPVC)
+				mockPVC.On("Create", mock.Anything, mock.Anything).Return("", errors.New(`invalid access mode "RWO"`))
+			},
+			expectedOutput: `Failed to create PVC: invalid access mode "RWO"`,
+		},
+		{
+			name:           "Invalid label",
+			args:           map[string]interface{}{"name": "data", "storage": "10Gi", "labels": map[string]interface{}{"app": "not valid"}},
+			mockSetup:      func(*testmocks.MockPVCFactory, *testmocks.MockPVC) {},
+			expectedOutput: `invalid label value for key "app"`,
+		},
+		{
+			name:           "Missing name",
+			args:           map[string]interface{}{"storage": "10Gi"},
+			mockSetup:      func(*testmocks.MockPVCFactory, *testmocks.MockPVC) {},
+			expectedOutput: errMissingName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockFactory := testmocks.NewMockPVCFactory()
+			mockPVC := &testmocks.MockPVC{}
+			tt.mockSetup(mockFactory, mockPVC)
+
+			result, err := createPVCHandler(mockCM, mockFactory)(context.Background(), toolRequest(tt.args))
+			assert.NoError(t, err)
+			assert.Contains(t, resultText(t, result), tt.expectedOutput)
+
+			mockFactory.AssertExpectations(t)
+			mockPVC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPVCHandlers(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewSimpleClientset()
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+	factory := NewDefaultPVCFactory()
+
+	r, err := createPVCHandler(mockCM, factory)(ctx, toolRequest(map[string]interface{}{
+		"name": "pvc-1", "storage": "1Gi", "storage_class": "standard",
+		"volume_mode": "Filesystem", "access_modes": []interface{}{"ReadWriteOnce"},
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "pvc-1")
+
+	r, err = listPVCHandler(mockCM, factory)(ctx, toolRequest(map[string]interface{}{"all_namespaces": true}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "pvc-1")
+
+	r, err = getPVCHandler(mockCM, factory)(ctx, toolRequest(map[string]interface{}{"name": "pvc-1"}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "PersistentVolumeClaim: pvc-1")
+
+	r, err = deletePVCHandler(mockCM, factory)(ctx, toolRequest(map[string]interface{}{"name": "pvc-1"}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "deleted")
+}
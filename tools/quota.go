@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/basebandit/kai"
+	"github.com/basebandit/kai/cluster"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// RegisterQuotaTools registers read-only ResourceQuota and LimitRange tools,
+// which explain why the API server rejects a workload's pods in a
+// namespace with quotas or resource constraints.
+func RegisterQuotaTools(s kai.ServerInterface, cm kai.ClusterManager) {
+	s.AddTool(mcp.NewTool("get_resource_quota",
+		mcp.WithDescription("Get a ResourceQuota with used against hard amounts for each resource, flagging resources at their limit"),
+		readOnlyAnnotation("Get resource quota"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the resource quota")),
+		mcp.WithString("namespace", mcp.Description("Namespace of the resource quota (defaults to current namespace)")),
+	), getResourceQuotaHandler(cm))
+
+	s.AddTool(mcp.NewTool("list_resource_quotas",
+		mcp.WithDescription("List ResourceQuotas with used/hard amounts in the current namespace or across all namespaces"),
+		readOnlyAnnotation("List resource quotas"),
+		mcp.WithString("namespace", mcp.Description("Namespace to list resource quotas from (defaults to current namespace)")),
+		mcp.WithBoolean("all_namespaces", mcp.Description("Whether to list resource quotas across all namespaces")),
+		confirmScanOption(),
+	), listResourceQuotasHandler(cm))
+
+	s.AddTool(mcp.NewTool("get_limit_range",
+		mcp.WithDescription("Get a LimitRange with the min, max, default request, default limit and max limit/request ratio per type and resource"),
+		readOnlyAnnotation("Get limit range"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the limit range")),
+		mcp.WithString("namespace", mcp.Description("Namespace of the limit range (defaults to current namespace)")),
+	), getLimitRangeHandler(cm))
+
+	s.AddTool(mcp.NewTool("list_limit_ranges",
+		mcp.WithDescription("List LimitRanges in the current namespace or across all namespaces"),
+		readOnlyAnnotation("List limit ranges"),
+		mcp.WithString("namespace", mcp.Description("Namespace to list limit ranges from (defaults to current namespace)")),
+		mcp.WithBoolean("all_namespaces", mcp.Description("Whether to list limit ranges across all namespaces")),
+		confirmScanOption(),
+	), listLimitRangesHandler(cm))
+}
+
+func getResourceQuotaHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "get_resource_quota"))
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		quota := cluster.ResourceQuota{Name: name, Namespace: quotaNamespace(ctx, cm, request)}
+		result, err := quota.Get(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get resource quota: %s", classifyError(err))), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func listResourceQuotasHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "list_resource_quotas"))
+		allNamespaces, _ := request.GetArguments()["all_namespaces"].(bool)
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
+		quota := cluster.ResourceQuota{Namespace: quotaNamespace(ctx, cm, request)}
+		result, err := quota.List(ctx, cm, allNamespaces)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to list resource quotas: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func getLimitRangeHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "get_limit_range"))
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		limitRange := cluster.LimitRange{Name: name, Namespace: quotaNamespace(ctx, cm, request)}
+		result, err := limitRange.Get(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get limit range: %s", classifyError(err))), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func listLimitRangesHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "list_limit_ranges"))
+		allNamespaces, _ := request.GetArguments()["all_namespaces"].(bool)
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
+		limitRange := cluster.LimitRange{Namespace: quotaNamespace(ctx, cm, request)}
+		result, err := limitRange.List(ctx, cm, allNamespaces)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to list limit ranges: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+// quotaNamespace is the namespace argument, or the current namespace when
+// it is not given.
+func quotaNamespace(ctx context.Context, cm kai.ClusterManager, request mcp.CallToolRequest) string {
+	if namespace, ok := request.GetArguments()["namespace"].(string); ok && namespace != "" {
+		return namespace
+	}
+	return kai.CurrentNamespace(ctx, cm)
+}
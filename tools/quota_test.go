@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRegisterQuotaTools(t *testing.T) {
+	mockServer := &testmocks.MockServer{}
+	mockCM := testmocks.NewMockClusterManager()
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(4)
+	RegisterQuotaTools(mockServer, mockCM)
+	mockServer.AssertExpectations(t)
+}
+
+func TestQuotaHandlers(t *testing.T) {
+	ctx := context.Background()
+
+	fakeClient := fake.NewSimpleClientset(
+		&corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: defaultNamespace},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("2")},
+				Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("2")},
+			},
+		},
+		&corev1.LimitRange{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: testNamespace},
+			Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{{
+				Type:    corev1.LimitTypeContainer,
+				Default: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			}}},
+		},
+	)
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+
+	r, err := getResourceQuotaHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"name": "compute"}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "At limit: pods")
+
+	r, err = listResourceQuotasHandler(mockCM)(ctx, toolRequest(nil))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "• compute: pods: 2/2")
+
+	r, err = getResourceQuotaHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"name": "compute", "namespace": testNamespace}))
+	assert.NoError(t, err)
+	assert.Equal(t, `Failed to get resource quota: resource quota "compute" not found in namespace "test-namespace"`, resultText(t, r))
+
+	r, err = getLimitRangeHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"name": "defaults", "namespace": testNamespace}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "256Mi")
+
+	r, err = listLimitRangesHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"all_namespaces": true, "confirm": true}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "• test-namespace/defaults: Types=Container")
+
+	r, err = getLimitRangeHandler(mockCM)(ctx, toolRequest(map[string]interface{}{}))
+	assert.NoError(t, err)
+	assert.Equal(t, errMissingName, resultText(t, r))
+}
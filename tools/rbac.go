@@ -4,72 +4,391 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/cluster"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
-// RegisterRBACTools registers read-only RBAC inspection tools.
+// RBACFactory is an interface for creating RBAC operators.
+type RBACFactory interface {
+	NewRole(params kai.RoleParams) kai.RBACOperator
+	NewClusterRole(params kai.RoleParams) kai.RBACOperator
+	NewRoleBinding(params kai.RoleBindingParams) kai.RBACOperator
+	NewClusterRoleBinding(params kai.RoleBindingParams) kai.RBACOperator
+}
+
+// DefaultRBACFactory implements the RBACFactory interface.
+type DefaultRBACFactory struct{}
+
+// NewDefaultRBACFactory creates a new DefaultRBACFactory.
+func NewDefaultRBACFactory() *DefaultRBACFactory {
+	return &DefaultRBACFactory{}
+}
+
+// NewRole creates a new Role operator.
+func (f *DefaultRBACFactory) NewRole(params kai.RoleParams) kai.RBACOperator {
+	return &cluster.Role{
+		Name:      params.Name,
+		Namespace: params.Namespace,
+		Rules:     params.Rules,
+		Labels:    params.Labels,
+		DryRun:    params.DryRun,
+	}
+}
+
+// NewClusterRole creates a new ClusterRole operator; params.Namespace is
+// ignored.
+func (f *DefaultRBACFactory) NewClusterRole(params kai.RoleParams) kai.RBACOperator {
+	return &cluster.ClusterRole{
+		Name:   params.Name,
+		Rules:  params.Rules,
+		Labels: params.Labels,
+		DryRun: params.DryRun,
+	}
+}
+
+// NewRoleBinding creates a new RoleBinding operator.
+func (f *DefaultRBACFactory) NewRoleBinding(params kai.RoleBindingParams) kai.RBACOperator {
+	return &cluster.RoleBinding{
+		Name:      params.Name,
+		Namespace: params.Namespace,
+		RoleRef:   params.RoleRef,
+		Subjects:  params.Subjects,
+		Labels:    params.Labels,
+		DryRun:    params.DryRun,
+	}
+}
+
+// NewClusterRoleBinding creates a new ClusterRoleBinding operator;
+// params.Namespace is ignored.
+func (f *DefaultRBACFactory) NewClusterRoleBinding(params kai.RoleBindingParams) kai.RBACOperator {
+	return &cluster.ClusterRoleBinding{
+		Name:     params.Name,
+		RoleRef:  params.RoleRef,
+		Subjects: params.Subjects,
+		Labels:   params.Labels,
+		DryRun:   params.DryRun,
+	}
+}
+
+// RegisterRBACTools registers tools for Roles, ClusterRoles, RoleBindings
+// and ClusterRoleBindings, attach_pull_secret for adding registry
+// credentials to a service account, create_sa_token for minting
+// short-lived service account tokens and can_i for checking permissions.
+// The service accounts themselves are managed by RegisterServiceAccountTools.
 func RegisterRBACTools(s kai.ServerInterface, cm kai.ClusterManager) {
+	factory := NewDefaultRBACFactory()
+	RegisterRBACToolsWithFactory(s, cm, factory)
+}
+
+// RegisterRBACToolsWithFactory registers RBAC tools using the provided factory.
+func RegisterRBACToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, factory RBACFactory) {
 	nsArg := mcp.WithString("namespace", mcp.Description("Namespace (defaults to current)"))
 	allNsArg := mcp.WithBoolean("all_namespaces", mcp.Description("List across all namespaces"))
 	nameArg := mcp.WithString("name", mcp.Required(), mcp.Description("Resource name"))
+	labelsArg := mcp.WithObject("labels", mcp.Description("Labels to apply"))
+	rulesArg := func(extra string) mcp.ToolOption {
+		return mcp.WithArray("rules",
+			mcp.Required(),
+			mcp.Description("Rules granted, each {\"apiGroups\": [\"apps\"], \"resources\": [\"deployments\"], \"verbs\": [\"get\", \"list\"]} "+
+				"with optional \"resourceNames\". apiGroups defaults to the core group (\"\")"+extra),
+			mcp.Items(map[string]any{"type": "object"}),
+		)
+	}
+	subjectsArg := func(extra string) mcp.ToolOption {
+		return mcp.WithArray("subjects",
+			mcp.Required(),
+			mcp.Description("Who is granted the role, each {\"kind\": \"User\"|\"Group\"|\"ServiceAccount\", \"name\", \"namespace\"}; "+extra),
+			mcp.Items(map[string]any{"type": "object"}),
+		)
+	}
 
+	s.AddTool(mcp.NewTool("create_role",
+		mcp.WithDescription("Create a namespaced RBAC role granting the given rules"),
+		creationAnnotation("Create role"), nameArg, nsArg, rulesArg(""), labelsArg, dryRunOption()),
+		createRoleHandler(cm, factory, "role"))
 	s.AddTool(mcp.NewTool("list_roles", mcp.WithDescription("List RBAC roles in a namespace"),
-		readOnlyAnnotation("List roles"), nsArg, allNsArg), rbacListHandler(cm, "role"))
-	s.AddTool(mcp.NewTool("get_role", mcp.WithDescription("Get an RBAC role with its rules"),
-		readOnlyAnnotation("Get role"), nameArg, nsArg), rbacGetHandler(cm, "role"))
+		readOnlyAnnotation("List roles"), nsArg, allNsArg, confirmScanOption()), rbacListHandler(cm, factory, "role"))
+	s.AddTool(mcp.NewTool("get_role", mcp.WithDescription("Get an RBAC role with its rules as a table"),
+		readOnlyAnnotation("Get role"), nameArg, nsArg), rbacGetHandler(cm, factory, "role"))
+	s.AddTool(mcp.NewTool("delete_role", mcp.WithDescription("Delete an RBAC role; role bindings referencing it stop granting anything"),
+		destructiveAnnotation("Delete role"), nameArg, nsArg), rbacDeleteHandler(cm, factory, "role"))
 
+	s.AddTool(mcp.NewTool("create_role_binding",
+		mcp.WithDescription("Bind users, groups or service accounts to a Role, or to a ClusterRole limited to the binding's namespace"),
+		creationAnnotation("Create role binding"), nameArg, nsArg,
+		mcp.WithObject("role_ref", mcp.Required(),
+			mcp.Description("Role to bind, {\"kind\": \"Role\"|\"ClusterRole\", \"name\"}; kind defaults to Role")),
+		subjectsArg("ServiceAccount namespace defaults to the binding's"), labelsArg, dryRunOption()),
+		createRoleBindingHandler(cm, factory, "rolebinding"))
 	s.AddTool(mcp.NewTool("list_role_bindings", mcp.WithDescription("List RBAC role bindings in a namespace"),
-		readOnlyAnnotation("List role bindings"), nsArg, allNsArg), rbacListHandler(cm, "rolebinding"))
-	s.AddTool(mcp.NewTool("get_role_binding", mcp.WithDescription("Get an RBAC role binding"),
-		readOnlyAnnotation("Get role binding"), nameArg, nsArg), rbacGetHandler(cm, "rolebinding"))
+		readOnlyAnnotation("List role bindings"), nsArg, allNsArg, confirmScanOption()), rbacListHandler(cm, factory, "rolebinding"))
+	s.AddTool(mcp.NewTool("get_role_binding", mcp.WithDescription("Get an RBAC role binding with its subjects"),
+		readOnlyAnnotation("Get role binding"), nameArg, nsArg), rbacGetHandler(cm, factory, "rolebinding"))
+	s.AddTool(mcp.NewTool("delete_role_binding", mcp.WithDescription("Delete an RBAC role binding, revoking what it granted"),
+		destructiveAnnotation("Delete role binding"), nameArg, nsArg), rbacDeleteHandler(cm, factory, "rolebinding"))
 
+	s.AddTool(mcp.NewTool("create_cluster_role",
+		mcp.WithDescription("Create a cluster role granting the given rules; bind it with a cluster role binding for every namespace or a role binding for one"),
+		creationAnnotation("Create cluster role"), nameArg, rulesArg(". Rules may instead grant \"nonResourceURLs\" such as [\"/healthz\"]"), labelsArg, dryRunOption()),
+		createRoleHandler(cm, factory, "clusterrole"))
 	s.AddTool(mcp.NewTool("list_cluster_roles", mcp.WithDescription("List cluster roles"),
-		readOnlyAnnotation("List cluster roles")), rbacListHandler(cm, "clusterrole"))
-	s.AddTool(mcp.NewTool("get_cluster_role", mcp.WithDescription("Get a cluster role with its rules"),
-		readOnlyAnnotation("Get cluster role"), nameArg), rbacGetHandler(cm, "clusterrole"))
+		readOnlyAnnotation("List cluster roles")), rbacListHandler(cm, factory, "clusterrole"))
+	s.AddTool(mcp.NewTool("get_cluster_role", mcp.WithDescription("Get a cluster role with its rules as a table"),
+		readOnlyAnnotation("Get cluster role"), nameArg), rbacGetHandler(cm, factory, "clusterrole"))
+	s.AddTool(mcp.NewTool("delete_cluster_role", mcp.WithDescription("Delete a cluster role; bindings referencing it stop granting anything"),
+		destructiveAnnotation("Delete cluster role"), nameArg), rbacDeleteHandler(cm, factory, "clusterrole"))
 
+	s.AddTool(mcp.NewTool("create_cluster_role_binding",
+		mcp.WithDescription("Bind users, groups or service accounts to a ClusterRole in every namespace"),
+		creationAnnotation("Create cluster role binding"), nameArg,
+		mcp.WithObject("role_ref", mcp.Required(),
+			mcp.Description("ClusterRole to bind, {\"kind\": \"ClusterRole\", \"name\"}")),
+		subjectsArg("ServiceAccounts must set namespace"), labelsArg, dryRunOption()),
+		createRoleBindingHandler(cm, factory, "clusterrolebinding"))
 	s.AddTool(mcp.NewTool("list_cluster_role_bindings", mcp.WithDescription("List cluster role bindings"),
-		readOnlyAnnotation("List cluster role bindings")), rbacListHandler(cm, "clusterrolebinding"))
-	s.AddTool(mcp.NewTool("get_cluster_role_binding", mcp.WithDescription("Get a cluster role binding"),
-		readOnlyAnnotation("Get cluster role binding"), nameArg), rbacGetHandler(cm, "clusterrolebinding"))
+		readOnlyAnnotation("List cluster role bindings")), rbacListHandler(cm, factory, "clusterrolebinding"))
+	s.AddTool(mcp.NewTool("get_cluster_role_binding", mcp.WithDescription("Get a cluster role binding with its subjects"),
+		readOnlyAnnotation("Get cluster role binding"), nameArg), rbacGetHandler(cm, factory, "clusterrolebinding"))
+	s.AddTool(mcp.NewTool("delete_cluster_role_binding", mcp.WithDescription("Delete a cluster role binding, revoking what it granted"),
+		destructiveAnnotation("Delete cluster role binding"), nameArg), rbacDeleteHandler(cm, factory, "clusterrolebinding"))
+
+	s.AddTool(mcp.NewTool("attach_pull_secret",
+		mcp.WithDescription("Add an imagePullSecret to a service account so its pods can pull from a private registry. The secret must exist in the same namespace and be of type kubernetes.io/dockerconfigjson or kubernetes.io/dockercfg."),
+		idempotentMutationAnnotation("Attach pull secret"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the service account")),
+		mcp.WithString("secret", mcp.Required(), mcp.Description("Name of the docker registry secret")),
+		nsArg), attachPullSecretHandler(cm))
 
-	s.AddTool(mcp.NewTool("list_service_accounts", mcp.WithDescription("List service accounts in a namespace"),
-		readOnlyAnnotation("List service accounts"), nsArg, allNsArg), rbacListHandler(cm, "serviceaccount"))
-	s.AddTool(mcp.NewTool("get_service_account", mcp.WithDescription("Get a service account"),
-		readOnlyAnnotation("Get service account"), nameArg, nsArg), rbacGetHandler(cm, "serviceaccount"))
+	s.AddTool(mcp.NewTool("create_sa_token",
+		mcp.WithDescription("Mint a short-lived bearer token for a service account using the TokenRequest API. The result contains a SENSITIVE credential: do not echo it back, store it, or share it beyond the task it was requested for."),
+		creationAnnotation("Create service account token"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the service account")),
+		mcp.WithString("duration", mcp.Description("Token lifetime between 10m and 24h, like 30m or 2h (default 1h)")),
+		nsArg), createSATokenHandler(cm))
+
+	s.AddTool(mcp.NewTool("can_i",
+		mcp.WithDescription("Check whether the current user may perform an action, like kubectl auth can-i. Use it before an operation to tell a missing permission apart from other failures."),
+		readOnlyAnnotation("Check access"),
+		mcp.WithString("verb", mcp.Required(),
+			mcp.Description("API verb such as get, list, watch, create, update, patch, delete, or * for any")),
+		mcp.WithString("resource", mcp.Required(),
+			mcp.Description("Resource such as pods, deployments.apps or pods/log; the API group is looked up when omitted")),
+		mcp.WithString("subresource", mcp.Description("Subresource such as log, exec or scale")),
+		mcp.WithString("name", mcp.Description("Name of a specific object to check")),
+		mcp.WithString("namespace", mcp.Description("Namespace to check in (defaults to current)")),
+		mcp.WithBoolean("all_namespaces", mcp.Description("Check access in every namespace, which is also how cluster-scoped resources are checked")),
+	), canIHandler(cm))
 }
 
-func rbacListHandler(cm kai.ClusterManager, kind string) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func canIHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		slog.Debug("tool invoked", slog.String("tool", "list_"+kind))
-		rbac := cluster.RBAC{}
+		slog.Debug("tool invoked", slog.String("tool", "can_i"))
+		verb, ok := request.GetArguments()["verb"].(string)
+		if !ok || verb == "" {
+			return mcp.NewToolResultText("Required parameter 'verb' is missing"), nil
+		}
+		resource, ok := request.GetArguments()["resource"].(string)
+		if !ok || resource == "" {
+			return mcp.NewToolResultText("Required parameter 'resource' is missing"), nil
+		}
+
+		review := cluster.AccessReview{Verb: verb, Resource: resource}
+		review.Subresource, _ = request.GetArguments()["subresource"].(string)
+		review.Name, _ = request.GetArguments()["name"].(string)
+		if all, _ := request.GetArguments()["all_namespaces"].(bool); !all {
+			review.Namespace = kai.CurrentNamespace(ctx, cm)
+			if ns, ok := request.GetArguments()["namespace"].(string); ok && ns != "" {
+				review.Namespace = ns
+			}
+		}
+
+		result, err := review.Check(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to check access: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+// createSATokenHandler never logs the result: it holds the minted token.
+func createSATokenHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "create_sa_token"))
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		rbac := cluster.RBAC{Name: name}
 		if ns, ok := request.GetArguments()["namespace"].(string); ok {
 			rbac.Namespace = ns
 		}
+
+		var expiration time.Duration
+		if durationArg, ok := request.GetArguments()["duration"].(string); ok && durationArg != "" {
+			parsed, err := time.ParseDuration(durationArg)
+			if err != nil || parsed <= 0 {
+				return mcp.NewToolResultText(fmt.Sprintf("Invalid duration %q: use a positive duration like 30m or 2h", durationArg)), nil
+			}
+			expiration = parsed
+		}
+
+		result, err := rbac.CreateToken(ctx, cm, expiration)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to create service account token: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func attachPullSecretHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "attach_pull_secret"))
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		secret, ok := request.GetArguments()["secret"].(string)
+		if !ok || secret == "" {
+			return mcp.NewToolResultText("Required parameter 'secret' is missing"), nil
+		}
+		rbac := cluster.RBAC{Name: name}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			rbac.Namespace = ns
+		}
+
+		result, err := rbac.AttachPullSecret(ctx, cm, secret)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to attach pull secret: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+// rbacOperator builds the operator for kind, one of "role",
+// "rolebinding", "clusterrole" or "clusterrolebinding".
+func rbacOperator(factory RBACFactory, kind, name, namespace string) kai.RBACOperator {
+	switch kind {
+	case "role":
+		return factory.NewRole(kai.RoleParams{Name: name, Namespace: namespace})
+	case "clusterrole":
+		return factory.NewClusterRole(kai.RoleParams{Name: name})
+	case "rolebinding":
+		return factory.NewRoleBinding(kai.RoleBindingParams{Name: name, Namespace: namespace})
+	default:
+		return factory.NewClusterRoleBinding(kai.RoleBindingParams{Name: name})
+	}
+}
+
+func createRoleHandler(cm kai.ClusterManager, factory RBACFactory, kind string) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "create_"+kind))
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		rules, ok := request.GetArguments()["rules"].([]interface{})
+		if !ok || len(rules) == 0 {
+			return mcp.NewToolResultText("Required parameter 'rules' must be a non-empty array of rules"), nil
+		}
+
+		params := kai.RoleParams{
+			Name:   name,
+			Rules:  rules,
+			DryRun: dryRunArg(request),
+		}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			params.Namespace = ns
+		}
+		if labels, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			params.Labels = labels
+		}
+
+		var operator kai.RBACOperator
+		if kind == "clusterrole" {
+			operator = factory.NewClusterRole(params)
+		} else {
+			operator = factory.NewRole(params)
+		}
+		result, err := operator.Create(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to create "+kind,
+				slog.String("name", name),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to create %s: %s", kind, classifyError(err))), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func createRoleBindingHandler(cm kai.ClusterManager, factory RBACFactory, kind string) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "create_"+kind))
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		roleRef, ok := request.GetArguments()["role_ref"].(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultText("Required parameter 'role_ref' must be an object with kind and name"), nil
+		}
+		subjects, ok := request.GetArguments()["subjects"].([]interface{})
+		if !ok || len(subjects) == 0 {
+			return mcp.NewToolResultText("Required parameter 'subjects' must be a non-empty array of subjects"), nil
+		}
+
+		params := kai.RoleBindingParams{
+			Name:     name,
+			RoleRef:  roleRef,
+			Subjects: subjects,
+			DryRun:   dryRunArg(request),
+		}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			params.Namespace = ns
+		}
+		if labels, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			params.Labels = labels
+		}
+
+		var operator kai.RBACOperator
+		if kind == "clusterrolebinding" {
+			operator = factory.NewClusterRoleBinding(params)
+		} else {
+			operator = factory.NewRoleBinding(params)
+		}
+		result, err := operator.Create(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to create "+kind,
+				slog.String("name", name),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to create %s: %s", kind, classifyError(err))), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func rbacListHandler(cm kai.ClusterManager, factory RBACFactory, kind string) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "list_"+kind))
+		var namespace string
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			namespace = ns
+		}
 		allNamespaces := false
 		if all, ok := request.GetArguments()["all_namespaces"].(bool); ok {
 			allNamespaces = all
 		}
-
-		var (
-			result string
-			err    error
-		)
-		switch kind {
-		case "role":
-			result, err = rbac.ListRoles(ctx, cm, allNamespaces)
-		case "rolebinding":
-			result, err = rbac.ListRoleBindings(ctx, cm, allNamespaces)
-		case "clusterrole":
-			result, err = rbac.ListClusterRoles(ctx, cm)
-		case "clusterrolebinding":
-			result, err = rbac.ListClusterRoleBindings(ctx, cm)
-		case "serviceaccount":
-			result, err = rbac.ListServiceAccounts(ctx, cm, allNamespaces)
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
 		}
+
+		result, err := rbacOperator(factory, kind, "", namespace).List(ctx, cm, allNamespaces)
 		if err != nil {
 			return mcp.NewToolResultText(fmt.Sprintf("Failed to list %s: %s", kind, err.Error())), nil
 		}
@@ -77,36 +396,45 @@ func rbacListHandler(cm kai.ClusterManager, kind string) func(ctx context.Contex
 	}
 }
 
-func rbacGetHandler(cm kai.ClusterManager, kind string) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func rbacGetHandler(cm kai.ClusterManager, factory RBACFactory, kind string) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		slog.Debug("tool invoked", slog.String("tool", "get_"+kind))
 		name, errResult := requireName(request)
 		if errResult != nil {
 			return errResult, nil
 		}
-		rbac := cluster.RBAC{Name: name}
+		var namespace string
 		if ns, ok := request.GetArguments()["namespace"].(string); ok {
-			rbac.Namespace = ns
+			namespace = ns
 		}
 
-		var (
-			result string
-			err    error
-		)
-		switch kind {
-		case "role":
-			result, err = rbac.GetRole(ctx, cm)
-		case "rolebinding":
-			result, err = rbac.GetRoleBinding(ctx, cm)
-		case "clusterrole":
-			result, err = rbac.GetClusterRole(ctx, cm)
-		case "clusterrolebinding":
-			result, err = rbac.GetClusterRoleBinding(ctx, cm)
-		case "serviceaccount":
-			result, err = rbac.GetServiceAccount(ctx, cm)
+		result, err := rbacOperator(factory, kind, name, namespace).Get(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get %s: %s", kind, classifyError(err))), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func rbacDeleteHandler(cm kai.ClusterManager, factory RBACFactory, kind string) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "delete_"+kind))
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		var namespace string
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			namespace = ns
 		}
+
+		result, err := rbacOperator(factory, kind, name, namespace).Delete(ctx, cm)
 		if err != nil {
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to get %s: %s", kind, err.Error())), nil
+			slog.Warn("failed to delete "+kind,
+				slog.String("name", name),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete %s: %s", kind, classifyError(err))), nil
 		}
 		return mcp.NewToolResultText(result), nil
 	}
@@ -2,21 +2,27 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/testmocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestRegisterRBACTools(t *testing.T) {
 	mockServer := &testmocks.MockServer{}
 	mockCM := testmocks.NewMockClusterManager()
-	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(10)
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(19)
 	RegisterRBACTools(mockServer, mockCM)
 	mockServer.AssertExpectations(t)
 }
@@ -30,7 +36,6 @@ func TestRBACHandlers(t *testing.T) {
 			&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "rb1", Namespace: defaultNamespace}, RoleRef: rbacv1.RoleRef{Kind: "Role", Name: "r1"}},
 			&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "cr1"}},
 			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "crb1"}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "cr1"}},
-			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "sa1", Namespace: defaultNamespace}},
 		)
 		mockCM := testmocks.NewMockClusterManager()
 		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
@@ -45,11 +50,10 @@ func TestRBACHandlers(t *testing.T) {
 		{"rolebinding", "rb1"},
 		{"clusterrole", "cr1"},
 		{"clusterrolebinding", "crb1"},
-		{"serviceaccount", "sa1"},
 	}
 	for _, tc := range listCases {
 		mockCM, _ := newCM()
-		r, err := rbacListHandler(mockCM, tc.kind)(ctx, toolRequest(nil))
+		r, err := rbacListHandler(mockCM, NewDefaultRBACFactory(), tc.kind)(ctx, toolRequest(nil))
 		assert.NoError(t, err)
 		assert.Contains(t, resultText(t, r), tc.want)
 	}
@@ -61,19 +65,205 @@ func TestRBACHandlers(t *testing.T) {
 		{"rolebinding", "rb1", "RoleBinding: rb1"},
 		{"clusterrole", "cr1", "ClusterRole: cr1"},
 		{"clusterrolebinding", "crb1", "ClusterRoleBinding: crb1"},
-		{"serviceaccount", "sa1", "ServiceAccount: sa1"},
 	}
 	for _, tc := range getCases {
 		mockCM, _ := newCM()
-		r, err := rbacGetHandler(mockCM, tc.kind)(ctx, toolRequest(map[string]interface{}{"name": tc.name}))
+		r, err := rbacGetHandler(mockCM, NewDefaultRBACFactory(), tc.kind)(ctx, toolRequest(map[string]interface{}{"name": tc.name}))
 		assert.NoError(t, err)
 		assert.Contains(t, resultText(t, r), tc.want)
 	}
 
 	t.Run("GetMissingName", func(t *testing.T) {
 		mockCM, _ := newCM()
-		r, err := rbacGetHandler(mockCM, "role")(ctx, toolRequest(map[string]interface{}{}))
+		r, err := rbacGetHandler(mockCM, NewDefaultRBACFactory(), "role")(ctx, toolRequest(map[string]interface{}{}))
 		assert.NoError(t, err)
 		assert.Equal(t, errMissingName, resultText(t, r))
 	})
 }
+
+func TestCreateRoleHandler(t *testing.T) {
+	ctx := context.Background()
+	rules := []interface{}{
+		map[string]interface{}{"resources": []interface{}{"pods"}, "verbs": []interface{}{"get", "list"}},
+	}
+
+	t.Run("Role", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockFactory := testmocks.NewMockRBACFactory()
+		mockRole := testmocks.NewMockRBAC()
+		mockFactory.On("NewRole", kai.RoleParams{Name: "reader", Namespace: "team-a", Rules: rules, DryRun: true}).Return(mockRole)
+		mockRole.On("Create", mock.Anything, mockCM).Return(`Role "reader" created successfully in namespace "team-a" with 1 rule(s) (dry run)`, nil)
+
+		r, err := createRoleHandler(mockCM, mockFactory, "role")(ctx, toolRequest(map[string]interface{}{
+			"name": "reader", "namespace": "team-a", "rules": rules, "dry_run": true,
+		}))
+		assert.NoError(t, err)
+		assert.Contains(t, resultText(t, r), "created successfully")
+		mockFactory.AssertExpectations(t)
+		mockRole.AssertExpectations(t)
+	})
+
+	t.Run("ClusterRole", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockFactory := testmocks.NewMockRBACFactory()
+		mockRole := testmocks.NewMockRBAC()
+		mockFactory.On("NewClusterRole", kai.RoleParams{Name: "reader", Rules: rules}).Return(mockRole)
+		mockRole.On("Create", mock.Anything, mockCM).Return("", errors.New("forbidden"))
+
+		r, err := createRoleHandler(mockCM, mockFactory, "clusterrole")(ctx, toolRequest(map[string]interface{}{"name": "reader", "rules": rules}))
+		assert.NoError(t, err)
+		assert.Equal(t, "Failed to create clusterrole: forbidden", resultText(t, r))
+		mockFactory.AssertExpectations(t)
+		mockRole.AssertExpectations(t)
+	})
+
+	t.Run("MissingRules", func(t *testing.T) {
+		r, err := createRoleHandler(testmocks.NewMockClusterManager(), testmocks.NewMockRBACFactory(), "role")(ctx, toolRequest(map[string]interface{}{"name": "reader"}))
+		assert.NoError(t, err)
+		assert.Equal(t, "Required parameter 'rules' must be a non-empty array of rules", resultText(t, r))
+	})
+}
+
+func TestCreateRoleBindingHandler(t *testing.T) {
+	ctx := context.Background()
+	roleRef := map[string]interface{}{"kind": "ClusterRole", "name": "view"}
+	subjects := []interface{}{map[string]interface{}{"kind": "Group", "name": "sre"}}
+
+	mockCM := testmocks.NewMockClusterManager()
+	mockFactory := testmocks.NewMockRBACFactory()
+	mockBinding := testmocks.NewMockRBAC()
+	mockFactory.On("NewClusterRoleBinding", kai.RoleBindingParams{Name: "sre-view", RoleRef: roleRef, Subjects: subjects}).Return(mockBinding)
+	mockBinding.On("Create", mock.Anything, mockCM).Return(`ClusterRoleBinding "sre-view" created successfully, binding ClusterRole/view to Group:sre`, nil)
+
+	r, err := createRoleBindingHandler(mockCM, mockFactory, "clusterrolebinding")(ctx, toolRequest(map[string]interface{}{
+		"name": "sre-view", "role_ref": roleRef, "subjects": subjects,
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "created successfully")
+	mockBinding.AssertExpectations(t)
+
+	r, err = createRoleBindingHandler(mockCM, mockFactory, "rolebinding")(ctx, toolRequest(map[string]interface{}{"name": "x", "subjects": subjects}))
+	assert.NoError(t, err)
+	assert.Equal(t, "Required parameter 'role_ref' must be an object with kind and name", resultText(t, r))
+
+	r, err = createRoleBindingHandler(mockCM, mockFactory, "rolebinding")(ctx, toolRequest(map[string]interface{}{"name": "x", "role_ref": roleRef}))
+	assert.NoError(t, err)
+	assert.Equal(t, "Required parameter 'subjects' must be a non-empty array of subjects", resultText(t, r))
+}
+
+func TestRBACDeleteHandler(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewSimpleClientset(
+		&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "rb1", Namespace: defaultNamespace}, RoleRef: rbacv1.RoleRef{Kind: "Role", Name: "r1"}},
+	)
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+	handler := rbacDeleteHandler(mockCM, NewDefaultRBACFactory(), "rolebinding")
+
+	r, err := handler(ctx, toolRequest(map[string]interface{}{"name": "rb1"}))
+	assert.NoError(t, err)
+	assert.Equal(t, `RoleBinding "rb1" deleted successfully from namespace "default"`, resultText(t, r))
+
+	r, err = handler(ctx, toolRequest(map[string]interface{}{"name": "rb1"}))
+	assert.NoError(t, err)
+	assert.Equal(t, `Failed to delete rolebinding: not found: role binding "rb1" not found in namespace "default": rolebindings.rbac.authorization.k8s.io "rb1" not found`, resultText(t, r))
+}
+
+func TestAttachPullSecretHandler(t *testing.T) {
+	ctx := context.Background()
+
+	fakeClient := fake.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "sa1", Namespace: defaultNamespace}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: defaultNamespace}, Type: corev1.SecretTypeDockerConfigJson},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: defaultNamespace}, Type: corev1.SecretTypeTLS},
+	)
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+	handler := attachPullSecretHandler(mockCM)
+
+	r, err := handler(ctx, toolRequest(map[string]interface{}{"name": "sa1", "secret": "registry"}))
+	assert.NoError(t, err)
+	assert.Equal(t, `Image pull secret "registry" attached to ServiceAccount "sa1" in namespace "default"`, resultText(t, r))
+
+	r, err = handler(ctx, toolRequest(map[string]interface{}{"name": "sa1", "secret": "tls"}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), `Failed to attach pull secret: secret "tls" has type "kubernetes.io/tls"`)
+
+	r, err = handler(ctx, toolRequest(map[string]interface{}{"name": "sa1"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "Required parameter 'secret' is missing", resultText(t, r))
+}
+
+func TestCreateSATokenHandler(t *testing.T) {
+	ctx := context.Background()
+
+	fakeClient := fake.NewSimpleClientset(&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "sa1", Namespace: defaultNamespace}})
+	fakeClient.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		req := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenRequest).DeepCopy()
+		req.Status.Token = "minted-token"
+		return true, req, nil
+	})
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+	handler := createSATokenHandler(mockCM)
+
+	r, err := handler(ctx, toolRequest(map[string]interface{}{"name": "sa1", "duration": "2h"}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), "SENSITIVE")
+	assert.Contains(t, resultText(t, r), "Token: minted-token")
+
+	r, err = handler(ctx, toolRequest(map[string]interface{}{"name": "ghost"}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, r), `Failed to create service account token: failed to get service account "ghost"`)
+
+	r, err = handler(ctx, toolRequest(map[string]interface{}{"name": "sa1", "duration": "soon"}))
+	assert.NoError(t, err)
+	assert.Equal(t, `Invalid duration "soon": use a positive duration like 30m or 2h`, resultText(t, r))
+
+	r, err = handler(ctx, toolRequest(map[string]interface{}{}))
+	assert.NoError(t, err)
+	assert.Equal(t, errMissingName, resultText(t, r))
+}
+
+func TestCanIHandler(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewSimpleClientset()
+	fakeClient.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Name: "pods", Namespaced: true, Kind: "Pod"}, {Name: "nodes", Kind: "Node"}},
+	}}
+	var reviewed []authorizationv1.ResourceAttributes
+	fakeClient.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview).DeepCopy()
+		reviewed = append(reviewed, *review.Spec.ResourceAttributes)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Verb == "get"
+		return true, review, nil
+	})
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+	handler := canIHandler(mockCM)
+
+	r, err := handler(ctx, toolRequest(map[string]interface{}{"verb": "get", "resource": "pods", "subresource": "log"}))
+	assert.NoError(t, err)
+	assert.Equal(t, `yes: you can get pods/log in namespace "default"`, resultText(t, r))
+
+	r, err = handler(ctx, toolRequest(map[string]interface{}{"verb": "delete", "resource": "nodes", "name": "node-1", "all_namespaces": true}))
+	assert.NoError(t, err)
+	assert.Equal(t, `no: you cannot delete nodes "node-1" in any namespace`, resultText(t, r))
+	assert.Equal(t, "", reviewed[1].Namespace)
+
+	r, err = handler(ctx, toolRequest(map[string]interface{}{"resource": "pods"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "Required parameter 'verb' is missing", resultText(t, r))
+
+	r, err = handler(ctx, toolRequest(map[string]interface{}{"verb": "get"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "Required parameter 'resource' is missing", resultText(t, r))
+}
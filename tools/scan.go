@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/basebandit/kai"
+	"github.com/basebandit/kai/cluster"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// confirmScanOption declares the confirm parameter of tools that accept
+// all_namespaces.
+func confirmScanOption() mcp.ToolOption {
+	return mcp.WithBoolean("confirm",
+		mcp.Description("With all_namespaces, scan even when the cluster has more namespaces than the server allows by default"),
+	)
+}
+
+// checkNamespaceScan guards an all_namespaces request. A label_selector or
+// confirm=true lets it through; otherwise clusters with more namespaces than
+// the cluster manager's MaxNamespacesScan are refused with a hint to narrow the request.
+func checkNamespaceScan(ctx context.Context, cm kai.ClusterManager, request mcp.CallToolRequest) *mcp.CallToolResult {
+	if selector, _ := request.GetArguments()["label_selector"].(string); selector != "" {
+		return nil
+	}
+	if confirm, _ := request.GetArguments()["confirm"].(bool); confirm {
+		return nil
+	}
+	if err := cluster.CheckNamespaceScan(ctx, cm); err != nil {
+		return mcp.NewToolResultText(err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNamespaceScanGuard(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}},
+	)
+
+	tests := []struct {
+		name           string
+		args           map[string]interface{}
+		expectList     bool
+		expectedOutput string
+	}{
+		{
+			name:           "Trips",
+			args:           map[string]interface{}{"all_namespaces": true},
+			expectedOutput: "more than 2 namespaces; narrow with a namespace or label_selector, or set confirm=true to scan all of them",
+		},
+		{
+			name:           "BypassedWithConfirm",
+			args:           map[string]interface{}{"all_namespaces": true, "confirm": true},
+			expectList:     true,
+			expectedOutput: "Pods across all namespaces",
+		},
+		{
+			name:           "BypassedWithLabelSelector",
+			args:           map[string]interface{}{"all_namespaces": true, "label_selector": "app=web"},
+			expectList:     true,
+			expectedOutput: "Pods across all namespaces",
+		},
+		{
+			name:           "SingleNamespace",
+			args:           map[string]interface{}{"namespace": testNamespace},
+			expectList:     true,
+			expectedOutput: "Pods in namespace test-namespace",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockCM.SetMaxNamespacesScan(2)
+			mockCM.On("GetCurrentClient").Return(fakeClient, nil)
+			mockFactory := &testmocks.MockPodFactory{}
+			mockPod := &testmocks.MockPod{}
+			if tt.expectList {
+				mockFactory.On("NewPod", mock.Anything).Return(mockPod)
+				mockPod.On("List", mock.Anything, mockCM, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tt.expectedOutput, nil)
+			}
+
+			result, err := listPodsHandler(mockCM, mockFactory)(context.Background(), toolRequest(tt.args))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOutput, resultText(t, result))
+			mockFactory.AssertExpectations(t)
+			mockPod.AssertExpectations(t)
+		})
+	}
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/cluster"
 	"github.com/mark3labs/mcp-go/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 )
 
 // SecretFactory is an interface for creating Secret operators.
@@ -33,6 +36,8 @@ func (f *DefaultSecretFactory) NewSecret(params kai.SecretParams) kai.SecretOper
 		StringData:  params.StringData,
 		Labels:      params.Labels,
 		Annotations: params.Annotations,
+		DryRun:      params.DryRun,
+		ShowDiff:    params.ShowDiff,
 	}
 }
 
@@ -69,6 +74,7 @@ func RegisterSecretToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager
 		mcp.WithObject("annotations",
 			mcp.Description("Annotations to apply to the Secret"),
 		),
+		dryRunOption(),
 	)
 	s.AddTool(createSecretTool, createSecretHandler(cm, factory))
 
@@ -82,6 +88,7 @@ func RegisterSecretToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager
 		mcp.WithString("namespace",
 			mcp.Description("Namespace of the Secret (defaults to current namespace)"),
 		),
+		outputOption(),
 	)
 	s.AddTool(getSecretTool, getSecretHandler(cm, factory))
 
@@ -91,12 +98,16 @@ func RegisterSecretToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager
 		mcp.WithBoolean("all_namespaces",
 			mcp.Description("Whether to list Secrets across all namespaces"),
 		),
+		confirmScanOption(),
 		mcp.WithString("namespace",
 			mcp.Description("Specific namespace to list Secrets from (defaults to current namespace)"),
 		),
 		mcp.WithString("label_selector",
 			mcp.Description("Label selector to filter Secrets (e.g., 'app=nginx,env=prod')"),
 		),
+		limitOption("Secrets"),
+		continueOption(),
+		outputOption(),
 	)
 	s.AddTool(listSecretsTool, listSecretsHandler(cm, factory))
 
@@ -138,6 +149,8 @@ func RegisterSecretToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager
 		mcp.WithObject("annotations",
 			mcp.Description("New annotations to apply to the Secret (replaces existing annotations)"),
 		),
+		dryRunOption(),
+		showDiffOption(),
 	)
 	s.AddTool(updateSecretTool, updateSecretHandler(cm, factory))
 }
@@ -156,7 +169,11 @@ func createSecretHandler(cm kai.ClusterManager, factory SecretFactory) func(ctx
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		if err := validateResourceName(name); err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -182,6 +199,9 @@ func createSecretHandler(cm kai.ClusterManager, factory SecretFactory) func(ctx
 		}
 
 		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			params.Labels = labelsArg
 		}
 
@@ -189,6 +209,11 @@ func createSecretHandler(cm kai.ClusterManager, factory SecretFactory) func(ctx
 			params.Annotations = annotationsArg
 		}
 
+		if err := ensureNamespace(ctx, cm, namespace); err != nil {
+			return mcp.NewToolResultText(classifyError(err)), nil
+		}
+
+		params.DryRun = dryRunArg(request)
 		secret := factory.NewSecret(params)
 		result, err := secret.Create(ctx, cm)
 		if err != nil {
@@ -197,7 +222,7 @@ func createSecretHandler(cm kai.ClusterManager, factory SecretFactory) func(ctx
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to create Secret: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to create Secret: %s", classifyError(err))), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -218,11 +243,21 @@ func getSecretHandler(cm kai.ClusterManager, factory SecretFactory) func(ctx con
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
 
+		format, errResult := outputFormat(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if format != outputText {
+			return structuredResult(ctx, cm, format, func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error) {
+				return client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+			}), nil
+		}
+
 		params := kai.SecretParams{
 			Name:      name,
 			Namespace: namespace,
@@ -236,7 +271,7 @@ func getSecretHandler(cm kai.ClusterManager, factory SecretFactory) func(ctx con
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to get Secret: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get Secret: %s", classifyError(err))), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -252,12 +287,18 @@ func listSecretsHandler(cm kai.ClusterManager, factory SecretFactory) func(ctx c
 			allNamespaces = allNamespacesArg
 		}
 
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
+
 		var namespace string
 		if !allNamespaces {
 			if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 				namespace = namespaceArg
 			} else {
-				namespace = cm.GetCurrentNamespace()
+				namespace = kai.CurrentNamespace(ctx, cm)
 			}
 		}
 
@@ -265,13 +306,24 @@ func listSecretsHandler(cm kai.ClusterManager, factory SecretFactory) func(ctx c
 		if labelSelectorArg, ok := request.GetArguments()["label_selector"].(string); ok {
 			labelSelector = labelSelectorArg
 		}
+		limit, continueToken := pageArgs(request)
+
+		format, errResult := outputFormat(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if format != outputText {
+			return structuredResult(ctx, cm, format, func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error) {
+				return client.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector, Limit: limit, Continue: continueToken})
+			}), nil
+		}
 
 		params := kai.SecretParams{
 			Namespace: namespace,
 		}
 
 		secret := factory.NewSecret(params)
-		result, err := secret.List(ctx, cm, allNamespaces, labelSelector)
+		result, err := secret.List(ctx, cm, allNamespaces, labelSelector, limit, continueToken)
 		if err != nil {
 			slog.Warn("failed to list Secrets",
 				slog.Bool("all_namespaces", allNamespaces),
@@ -300,7 +352,7 @@ func deleteSecretHandler(cm kai.ClusterManager, factory SecretFactory) func(ctx
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -318,7 +370,7 @@ func deleteSecretHandler(cm kai.ClusterManager, factory SecretFactory) func(ctx
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete Secret: %s", err.Error())), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete Secret: %s", classifyError(err))), nil
 		}
 
 		return mcp.NewToolResultText(result), nil
@@ -339,7 +391,7 @@ func updateSecretHandler(cm kai.ClusterManager, factory SecretFactory) func(ctx
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -365,6 +417,9 @@ func updateSecretHandler(cm kai.ClusterManager, factory SecretFactory) func(ctx
 		}
 
 		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			params.Labels = labelsArg
 		}
 
@@ -372,6 +427,8 @@ func updateSecretHandler(cm kai.ClusterManager, factory SecretFactory) func(ctx
 			params.Annotations = annotationsArg
 		}
 
+		params.DryRun = dryRunArg(request)
+		params.ShowDiff = showDiffArg(request)
 		secret := factory.NewSecret(params)
 		result, err := secret.Update(ctx, cm)
 		if err != nil {
@@ -280,6 +280,7 @@ func TestCreateSecretHandler(t *testing.T) {
 				})).Return(mockSecret)
 			}
 
+			stubNamespaceCheck(mockCM)
 			handler := createSecretHandler(mockCM, mockFactory)
 			request := mcp.CallToolRequest{
 				Params: mcp.CallToolParams{
@@ -417,7 +418,7 @@ func TestListSecretsHandler(t *testing.T) {
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockSecretFactory, mockSecret *testmocks.MockSecret) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockSecret.On("List", mock.Anything, mockCM, false, "").
+				mockSecret.On("List", mock.Anything, mockCM, false, "", int64(0), "").
 					Return(fmt.Sprintf("Secrets in namespace %q:\n- secret1\n- secret2", defaultNamespace), nil)
 			},
 			expectedOutput: fmt.Sprintf("Secrets in namespace %q:", defaultNamespace),
@@ -429,7 +430,7 @@ func TestListSecretsHandler(t *testing.T) {
 			},
 			expectedParams: kai.SecretParams{},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockSecretFactory, mockSecret *testmocks.MockSecret) {
-				mockSecret.On("List", mock.Anything, mockCM, true, "").
+				mockSecret.On("List", mock.Anything, mockCM, true, "", int64(0), "").
 					Return("Secrets across all namespaces:\n- ns1/secret1\n- ns2/secret2", nil)
 			},
 			expectedOutput: "Secrets across all namespaces:",
@@ -443,7 +444,7 @@ func TestListSecretsHandler(t *testing.T) {
 				Namespace: testNamespace,
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockSecretFactory, mockSecret *testmocks.MockSecret) {
-				mockSecret.On("List", mock.Anything, mockCM, false, "").
+				mockSecret.On("List", mock.Anything, mockCM, false, "", int64(0), "").
 					Return(fmt.Sprintf("Secrets in namespace %q:\n- secret1", testNamespace), nil)
 			},
 			expectedOutput: fmt.Sprintf("Secrets in namespace %q:", testNamespace),
@@ -458,7 +459,7 @@ func TestListSecretsHandler(t *testing.T) {
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockSecretFactory, mockSecret *testmocks.MockSecret) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockSecret.On("List", mock.Anything, mockCM, false, "app=backend").
+				mockSecret.On("List", mock.Anything, mockCM, false, "app=backend", int64(0), "").
 					Return(fmt.Sprintf("Secrets in namespace %q with label 'app=backend':\n- backend-secret", defaultNamespace), nil)
 			},
 			expectedOutput: fmt.Sprintf("Secrets in namespace %q with label 'app=backend':", defaultNamespace),
@@ -471,7 +472,7 @@ func TestListSecretsHandler(t *testing.T) {
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockSecretFactory, mockSecret *testmocks.MockSecret) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockSecret.On("List", mock.Anything, mockCM, false, "").
+				mockSecret.On("List", mock.Anything, mockCM, false, "", int64(0), "").
 					Return("", errors.New("connection failed"))
 			},
 			expectedOutput: "Failed to list Secrets: connection failed",
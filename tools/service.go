@@ -9,6 +9,9 @@ import (
 	"github.com/basebandit/kai"
 	"github.com/basebandit/kai/cluster"
 	"github.com/mark3labs/mcp-go/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 )
 
 // ServiceFactory is an interface for creating service operators
@@ -38,16 +41,19 @@ func (f *DefaultServiceFactory) NewService(params kai.ServiceParams) kai.Service
 	}
 
 	return &cluster.Service{
-		Name:            params.Name,
-		Namespace:       params.Namespace,
-		Labels:          params.Labels,
-		Selector:        params.Selector,
-		Type:            params.Type,
-		Ports:           ports,
-		ClusterIP:       params.ClusterIP,
-		ExternalIPs:     params.ExternalIPs,
-		ExternalName:    params.ExternalName,
-		SessionAffinity: params.SessionAffinity,
+		Name:             params.Name,
+		Namespace:        params.Namespace,
+		Labels:           params.Labels,
+		Selector:         params.Selector,
+		Type:             params.Type,
+		Ports:            ports,
+		ClusterIP:        params.ClusterIP,
+		ExternalIPs:      params.ExternalIPs,
+		ExternalName:     params.ExternalName,
+		SessionAffinity:  params.SessionAffinity,
+		DryRun:           params.DryRun,
+		ShowDiff:         params.ShowDiff,
+		IncludeEndpoints: params.IncludeEndpoints,
 	}
 }
 
@@ -65,12 +71,16 @@ func RegisterServiceToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManage
 		mcp.WithBoolean("all_namespaces",
 			mcp.Description("Whether to list services across all namespaces"),
 		),
+		confirmScanOption(),
 		mcp.WithString("namespace",
 			mcp.Description("Specific namespace to list services from (defaults to current namespace)"),
 		),
 		mcp.WithString("label_selector",
 			mcp.Description("Label selector to filter services"),
 		),
+		limitOption("services"),
+		continueOption(),
+		outputOption(),
 	)
 
 	s.AddTool(listServiceTool, listServicesHandler(cm, factory))
@@ -85,10 +95,54 @@ func RegisterServiceToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManage
 		mcp.WithString("namespace",
 			mcp.Description("Namespace of the service (defaults to current namespace)"),
 		),
+		mcp.WithBoolean("include_endpoints",
+			mcp.Description("Also list the ready and not-ready backend addresses from the service's EndpointSlices with the pods behind them, and why there are none; ignored with output json or yaml"),
+		),
+		outputOption(),
 	)
 
 	s.AddTool(getServiceTool, getServiceHandler(cm, factory))
 
+	describeServiceTool := mcp.NewTool("describe_service",
+		mcp.WithDescription("Describe a service with its ready and not-ready endpoints and recent events"),
+		readOnlyAnnotation("Describe service"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the service to describe"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the service (defaults to current namespace)"),
+		),
+	)
+
+	s.AddTool(describeServiceTool, describeServiceHandler(cm, factory))
+
+	diagnoseServiceTool := mcp.NewTool("diagnose_service",
+		mcp.WithDescription("Check whether a service's selector matches running, Ready pods and report problems such as an empty selector, no matching pods, or a named target port no pod declares"),
+		readOnlyAnnotation("Diagnose service"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the service to diagnose"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the service (defaults to current namespace)"),
+		),
+	)
+
+	s.AddTool(diagnoseServiceTool, diagnoseServiceHandler(cm, factory))
+
+	listEndpointsTool := mcp.NewTool("list_endpoints",
+		mcp.WithDescription("List EndpointSlices in a namespace with their backing service, ports, and ready address counts"),
+		readOnlyAnnotation("List endpoints"),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace to list EndpointSlices from (defaults to current namespace)"),
+		),
+		mcp.WithString("label_selector",
+			mcp.Description("Label selector to filter EndpointSlices (e.g., 'kubernetes.io/service-name=web')"),
+		),
+	)
+	s.AddTool(listEndpointsTool, listEndpointsHandler(cm))
+
 	createServiceTool := mcp.NewTool("create_service",
 		mcp.WithDescription("Create a new service in the current namespace"),
 		creationAnnotation("Create service"),
@@ -124,6 +178,7 @@ func RegisterServiceToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManage
 		mcp.WithString("session_affinity",
 			mcp.Description("Session affinity (None, ClientIP)"),
 		),
+		dryRunOption(),
 	)
 
 	s.AddTool(createServiceTool, createServiceHandler(cm, factory))
@@ -178,6 +233,8 @@ func RegisterServiceToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManage
 		mcp.WithString("session_affinity",
 			mcp.Description("Session affinity (None or ClientIP)"),
 		),
+		dryRunOption(),
+		showDiffOption(),
 	)
 
 	s.AddTool(updateServiceTool, updateServiceHandler(cm, factory))
@@ -196,6 +253,7 @@ func RegisterServiceToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManage
 			mcp.Required(),
 			mcp.Description("Patch data as key-value pairs (e.g., labels, selector, type, externalIPs)"),
 		),
+		dryRunOption(),
 	)
 
 	s.AddTool(patchServiceTool, patchServiceHandler(cm, factory))
@@ -212,12 +270,18 @@ func listServicesHandler(cm kai.ClusterManager, factory ServiceFactory) func(ctx
 			allNamespaces = allNamespacesArg
 		}
 
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
+
 		var namespace string
 		if !allNamespaces {
 			if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 				namespace = namespaceArg
 			} else {
-				namespace = cm.GetCurrentNamespace()
+				namespace = kai.CurrentNamespace(ctx, cm)
 			}
 		}
 
@@ -225,13 +289,24 @@ func listServicesHandler(cm kai.ClusterManager, factory ServiceFactory) func(ctx
 		if labelSelectorArg, ok := request.GetArguments()["label_selector"].(string); ok {
 			labelSelector = labelSelectorArg
 		}
+		limit, continueToken := pageArgs(request)
+
+		format, errResult := outputFormat(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if format != outputText {
+			return structuredResult(ctx, cm, format, func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error) {
+				return client.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector, Limit: limit, Continue: continueToken})
+			}), nil
+		}
 
 		params := kai.ServiceParams{
 			Namespace: namespace, // will be used if allNamespaces is false
 		}
 		service := factory.NewService(params)
 
-		resultText, err := service.List(ctx, cm, allNamespaces, labelSelector)
+		resultText, err := service.List(ctx, cm, allNamespaces, labelSelector, limit, continueToken)
 		if err != nil {
 			slog.Warn("failed to list services",
 				slog.Bool("all_namespaces", allNamespaces),
@@ -261,14 +336,26 @@ func getServiceHandler(cm kai.ClusterManager, factory ServiceFactory) func(ctx c
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
 
+		format, errResult := outputFormat(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if format != outputText {
+			return structuredResult(ctx, cm, format, func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error) {
+				return client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+			}), nil
+		}
+
+		includeEndpoints, _ := request.GetArguments()["include_endpoints"].(bool)
 		params := kai.ServiceParams{
-			Name:      name,
-			Namespace: namespace,
+			Name:             name,
+			Namespace:        namespace,
+			IncludeEndpoints: includeEndpoints,
 		}
 
 		service := factory.NewService(params)
@@ -280,6 +367,102 @@ func getServiceHandler(cm kai.ClusterManager, factory ServiceFactory) func(ctx c
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
+			return mcp.NewToolResultText(classifyError(err)), nil
+		}
+
+		return mcp.NewToolResultText(resultText), nil
+	}
+}
+
+// listEndpointsHandler handles the list_endpoints tool
+func listEndpointsHandler(cm kai.ClusterManager) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "list_endpoints"))
+
+		slices := cluster.EndpointSlices{}
+		if ns, ok := request.GetArguments()["namespace"].(string); ok {
+			slices.Namespace = ns
+		}
+		if selector, ok := request.GetArguments()["label_selector"].(string); ok {
+			slices.LabelSelector = selector
+		}
+
+		result, err := slices.List(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to list endpoints: %s", err.Error())), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+// describeServiceHandler handles the describe_service tool
+func describeServiceHandler(cm kai.ClusterManager, factory ServiceFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "describe_service"))
+
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.ServiceParams{
+			Name:      name,
+			Namespace: namespace,
+		}
+
+		service := factory.NewService(params)
+
+		resultText, err := service.Describe(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to describe service",
+				slog.String("name", name),
+				slog.String("namespace", namespace),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		return mcp.NewToolResultText(resultText), nil
+	}
+}
+
+// diagnoseServiceHandler handles the diagnose_service tool
+func diagnoseServiceHandler(cm kai.ClusterManager, factory ServiceFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "diagnose_service"))
+
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		service := factory.NewService(kai.ServiceParams{
+			Name:      name,
+			Namespace: namespace,
+		})
+
+		resultText, err := service.Diagnose(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to diagnose service",
+				slog.String("name", name),
+				slog.String("namespace", namespace),
+				slog.String("error", err.Error()),
+			)
 			return mcp.NewToolResultText(err.Error()), nil
 		}
 
@@ -304,6 +487,10 @@ func createServiceHandler(cm kai.ClusterManager, factory ServiceFactory) func(ct
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
+		if err := validateResourceName(name); err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
 		portsArg, ok := request.GetArguments()["ports"]
 		if !ok || portsArg == nil {
 			return mcp.NewToolResultText(errMissingPorts), nil
@@ -319,7 +506,7 @@ func createServiceHandler(cm kai.ClusterManager, factory ServiceFactory) func(ct
 			return mcp.NewToolResultText(fmt.Sprintf("Invalid ports configuration: %v", err)), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -347,6 +534,9 @@ func createServiceHandler(cm kai.ClusterManager, factory ServiceFactory) func(ct
 
 		var labels map[string]interface{}
 		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok && len(labelsArg) > 0 {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			labels = labelsArg
 		}
 
@@ -396,6 +586,11 @@ func createServiceHandler(cm kai.ClusterManager, factory ServiceFactory) func(ct
 			return mcp.NewToolResultText("ExternalName must be specified for ExternalName service type"), nil
 		}
 
+		if err := ensureNamespace(ctx, cm, namespace); err != nil {
+			return mcp.NewToolResultText(classifyError(err)), nil
+		}
+
+		params.DryRun = dryRunArg(request)
 		service := factory.NewService(params)
 		resultText, err := service.Create(ctx, cm)
 		if err != nil {
@@ -404,7 +599,7 @@ func createServiceHandler(cm kai.ClusterManager, factory ServiceFactory) func(ct
 				slog.String("namespace", namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(err.Error()), nil
+			return mcp.NewToolResultText(classifyError(err)), nil
 		}
 
 		return mcp.NewToolResultText(resultText), nil
@@ -418,7 +613,7 @@ func deleteServiceHandler(cm kai.ClusterManager, factory ServiceFactory) func(ct
 
 		params := kai.ServiceParams{}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -458,7 +653,7 @@ func deleteServiceHandler(cm kai.ClusterManager, factory ServiceFactory) func(ct
 				slog.String("namespace", params.Namespace),
 				slog.String("error", err.Error()),
 			)
-			return mcp.NewToolResultText(err.Error()), nil
+			return mcp.NewToolResultText(classifyError(err)), nil
 		}
 
 		return mcp.NewToolResultText(resultText), nil
@@ -600,7 +795,7 @@ func updateServiceHandler(cm kai.ClusterManager, factory ServiceFactory) func(ct
 			return mcp.NewToolResultText(errEmptyName), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -615,6 +810,9 @@ func updateServiceHandler(cm kai.ClusterManager, factory ServiceFactory) func(ct
 		}
 
 		if labels, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labels); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
 			params.Labels = labels
 		}
 
@@ -652,6 +850,8 @@ func updateServiceHandler(cm kai.ClusterManager, factory ServiceFactory) func(ct
 			params.SessionAffinity = sessionAffinity
 		}
 
+		params.DryRun = dryRunArg(request)
+		params.ShowDiff = showDiffArg(request)
 		service := factory.NewService(params)
 		resultText, err := service.Update(ctx, cm)
 		if err != nil {
@@ -684,7 +884,7 @@ func patchServiceHandler(cm kai.ClusterManager, factory ServiceFactory) func(ctx
 			return mcp.NewToolResultText("patch parameter must be an object"), nil
 		}
 
-		namespace := cm.GetCurrentNamespace()
+		namespace := kai.CurrentNamespace(ctx, cm)
 		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
 			namespace = namespaceArg
 		}
@@ -694,6 +894,7 @@ func patchServiceHandler(cm kai.ClusterManager, factory ServiceFactory) func(ctx
 			Namespace: namespace,
 		}
 
+		params.DryRun = dryRunArg(request)
 		service := factory.NewService(params)
 		resultText, err := service.Patch(ctx, cm, patchData)
 		if err != nil {
@@ -11,6 +11,9 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 type listServicesTestCase struct {
@@ -53,7 +56,7 @@ func TestRegisterServiceTools(t *testing.T) {
 	mockClusterMgr := testmocks.NewMockClusterManager()
 
 	// Expect AddTool to be called once for each tool we register
-	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(6)
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(9)
 	RegisterServiceTools(mockServer, mockClusterMgr)
 	mockServer.AssertExpectations(t)
 }
@@ -64,7 +67,7 @@ func TestRegisterServiceToolsWithFactory(t *testing.T) {
 	mockFactory := testmocks.NewMockServiceFactory()
 
 	// Expect AddTool to be called once for each tool we register
-	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(6)
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(9)
 	RegisterServiceToolsWithFactory(mockServer, mockClusterMgr, mockFactory)
 	mockServer.AssertExpectations(t)
 }
@@ -79,7 +82,7 @@ func TestListServicesHandler(t *testing.T) {
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockServiceFactory, mockService *testmocks.MockService) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockService.On("List", mock.Anything, mockCM, false, "").
+				mockService.On("List", mock.Anything, mockCM, false, "", int64(0), "").
 					Return(fmt.Sprintf("Services in namespace %q:\n- service1\n- service2", defaultNamespace), nil)
 			},
 			expectedOutput: fmt.Sprintf("Services in namespace %q:", defaultNamespace),
@@ -91,7 +94,7 @@ func TestListServicesHandler(t *testing.T) {
 			},
 			expectedParams: kai.ServiceParams{},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockServiceFactory, mockService *testmocks.MockService) {
-				mockService.On("List", mock.Anything, mockCM, true, "").
+				mockService.On("List", mock.Anything, mockCM, true, "", int64(0), "").
 					Return("Services across all namespaces:\n- ns1/service1\n- ns2/service2", nil)
 			},
 			expectedOutput: "Services across all namespaces:",
@@ -105,7 +108,7 @@ func TestListServicesHandler(t *testing.T) {
 				Namespace: testNamespace,
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockServiceFactory, mockService *testmocks.MockService) {
-				mockService.On("List", mock.Anything, mockCM, false, "").
+				mockService.On("List", mock.Anything, mockCM, false, "", int64(0), "").
 					Return(fmt.Sprintf("Services in namespace %q:\n- service1", testNamespace), nil)
 			},
 			expectedOutput: fmt.Sprintf("Services in namespace %q:", testNamespace),
@@ -120,7 +123,7 @@ func TestListServicesHandler(t *testing.T) {
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockServiceFactory, mockService *testmocks.MockService) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockService.On("List", mock.Anything, mockCM, false, "app=backend").
+				mockService.On("List", mock.Anything, mockCM, false, "app=backend", int64(0), "").
 					Return(fmt.Sprintf("Services in namespace %q with label 'app=backend':\n- backend-service", defaultNamespace), nil)
 			},
 			expectedOutput: fmt.Sprintf("Services in namespace %q with label 'app=backend':", defaultNamespace),
@@ -133,7 +136,7 @@ func TestListServicesHandler(t *testing.T) {
 			},
 			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockServiceFactory, mockService *testmocks.MockService) {
 				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-				mockService.On("List", mock.Anything, mockCM, false, "").
+				mockService.On("List", mock.Anything, mockCM, false, "", int64(0), "").
 					Return("", errors.New(errConnectionFailed))
 			},
 			expectedOutput: errConnectionFailed,
@@ -190,6 +193,25 @@ func TestGetServiceHandler(t *testing.T) {
 			expectedOutput:        fmt.Sprintf("Service %q in namespace %q:", serviceName, defaultNamespace),
 			expectServiceCreation: true,
 		},
+		{
+			name: "IncludeEndpoints",
+			args: map[string]interface{}{
+				"name":              serviceName,
+				"include_endpoints": true,
+			},
+			expectedParams: kai.ServiceParams{
+				Name:             serviceName,
+				Namespace:        defaultNamespace,
+				IncludeEndpoints: true,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockServiceFactory, mockService *testmocks.MockService) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockService.On("Get", mock.Anything, mockCM).
+					Return("Service \"test-service\"\n\nEndpoints (ready: 0, not ready: 1):\n• 10.244.0.6:8080 (pod/web-2)\tnot ready", nil)
+			},
+			expectedOutput:        "Endpoints (ready: 0, not ready: 1)",
+			expectServiceCreation: true,
+		},
 		{
 			name:           "MissingName",
 			args:           map[string]interface{}{},
@@ -266,6 +288,145 @@ func TestGetServiceHandler(t *testing.T) {
 	}
 }
 
+func TestGetServiceHandlerNamespaceOverride(t *testing.T) {
+	ctx := kai.WithNamespace(context.Background(), "team-a")
+
+	t.Run("OverrideReplacesCurrentNamespace", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockFactory := testmocks.NewMockServiceFactory()
+		params := kai.ServiceParams{Name: "web", Namespace: "team-a"}
+		mockService := testmocks.NewMockService(params)
+		mockFactory.On("NewService", params).Return(mockService)
+		mockService.On("Get", mock.Anything, mockCM).Return("Service: web", nil)
+
+		result, err := getServiceHandler(mockCM, mockFactory)(ctx, toolRequest(map[string]interface{}{"name": "web"}))
+		assert.NoError(t, err)
+		assert.Contains(t, resultText(t, result), "Service: web")
+
+		// GetCurrentNamespace has no expectation, so the global was never consulted.
+		mockCM.AssertExpectations(t)
+		mockFactory.AssertExpectations(t)
+	})
+
+	t.Run("ExplicitArgumentWins", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockFactory := testmocks.NewMockServiceFactory()
+		params := kai.ServiceParams{Name: "web", Namespace: testNamespace}
+		mockService := testmocks.NewMockService(params)
+		mockFactory.On("NewService", params).Return(mockService)
+		mockService.On("Get", mock.Anything, mockCM).Return("Service: web", nil)
+
+		_, err := getServiceHandler(mockCM, mockFactory)(ctx, toolRequest(map[string]interface{}{"name": "web", "namespace": testNamespace}))
+		assert.NoError(t, err)
+		mockFactory.AssertExpectations(t)
+	})
+}
+
+func TestDescribeServiceHandler(t *testing.T) {
+	serviceName := "test-service"
+
+	testCases := []getServiceTestCase{
+		{
+			name: "Success",
+			args: map[string]interface{}{
+				"name":      serviceName,
+				"namespace": testNamespace,
+			},
+			expectedParams: kai.ServiceParams{
+				Name:      serviceName,
+				Namespace: testNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockServiceFactory, mockService *testmocks.MockService) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockService.On("Describe", mock.Anything, mockCM).
+					Return("Service: test-service\n\nEndpoints (ready: 1, not ready: 0):\n• 10.0.0.5:8080 (pod/web-1)\tready", nil)
+			},
+			expectedOutput:        "Endpoints (ready: 1, not ready: 0)",
+			expectServiceCreation: true,
+		},
+		{
+			name:           "MissingName",
+			args:           map[string]interface{}{},
+			expectedParams: kai.ServiceParams{},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockServiceFactory, mockService *testmocks.MockService) {
+			},
+			expectedOutput:        errMissingName,
+			expectServiceCreation: false,
+		},
+		{
+			name: "Error",
+			args: map[string]interface{}{
+				"name": "nonexistent-service",
+			},
+			expectedParams: kai.ServiceParams{
+				Name:      "nonexistent-service",
+				Namespace: defaultNamespace,
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockServiceFactory, mockService *testmocks.MockService) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockService.On("Describe", mock.Anything, mockCM).
+					Return("", errors.New("service 'nonexistent-service' not found in namespace 'default'"))
+			},
+			expectedOutput:        "not found",
+			expectServiceCreation: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockFactory := testmocks.NewMockServiceFactory()
+
+			var mockService *testmocks.MockService
+			if tc.expectServiceCreation {
+				mockService = testmocks.NewMockService(tc.expectedParams)
+				mockFactory.On("NewService", tc.expectedParams).Return(mockService)
+			}
+
+			tc.mockSetup(mockCM, mockFactory, mockService)
+
+			handler := describeServiceHandler(mockCM, mockFactory)
+
+			request := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.args,
+				},
+			}
+
+			result, err := handler(context.Background(), request)
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+			assert.Contains(t, result.Content[0].(mcp.TextContent).Text, tc.expectedOutput)
+
+			mockCM.AssertExpectations(t)
+			mockFactory.AssertExpectations(t)
+			if mockService != nil {
+				mockService.AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestListEndpointsHandler(t *testing.T) {
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-abc12",
+			Namespace: testNamespace,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "web"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+	}
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentClient").Return(fake.NewSimpleClientset(slice), nil)
+
+	result, err := listEndpointsHandler(mockCM)(context.Background(), toolRequest(map[string]interface{}{
+		"namespace":      testNamespace,
+		"label_selector": "kubernetes.io/service-name=web",
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, result), "• web-abc12: Service=web")
+}
+
 func TestCreateServiceHandler(t *testing.T) {
 	testServiceName := "test-service"
 	clusterIPType := "ClusterIP"
@@ -574,6 +735,7 @@ func TestCreateServiceHandler(t *testing.T) {
 
 			tc.mockSetup(mockCM, mockFactory, mockService)
 
+			stubNamespaceCheck(mockCM)
 			handler := createServiceHandler(mockCM, mockFactory)
 
 			request := mcp.CallToolRequest{
@@ -1196,3 +1358,31 @@ func TestPatchServiceHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestDiagnoseServiceHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		params := kai.ServiceParams{Name: "web", Namespace: testNamespace}
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+		mockService := testmocks.NewMockService(params)
+		mockService.On("Diagnose", mock.Anything, mockCM).Return("Findings:\n• selector app=web matches 0 pods", nil)
+		mockFactory := testmocks.NewMockServiceFactory()
+		mockFactory.On("NewService", params).Return(mockService)
+
+		result, err := diagnoseServiceHandler(mockCM, mockFactory)(context.Background(), toolRequest(map[string]interface{}{
+			"name":      "web",
+			"namespace": testNamespace,
+		}))
+		assert.NoError(t, err)
+		assert.Contains(t, resultText(t, result), "selector app=web matches 0 pods")
+		mockFactory.AssertExpectations(t)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("MissingName", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		result, err := diagnoseServiceHandler(mockCM, testmocks.NewMockServiceFactory())(context.Background(), toolRequest(map[string]interface{}{}))
+		assert.NoError(t, err)
+		assert.Equal(t, errMissingName, resultText(t, result))
+	})
+}
@@ -0,0 +1,282 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/basebandit/kai"
+	"github.com/basebandit/kai/cluster"
+	"github.com/mark3labs/mcp-go/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ServiceAccountFactory is an interface for creating ServiceAccount operators.
+type ServiceAccountFactory interface {
+	NewServiceAccount(params kai.ServiceAccountParams) kai.ServiceAccountOperator
+}
+
+// DefaultServiceAccountFactory implements the ServiceAccountFactory interface.
+type DefaultServiceAccountFactory struct{}
+
+// NewDefaultServiceAccountFactory creates a new DefaultServiceAccountFactory.
+func NewDefaultServiceAccountFactory() *DefaultServiceAccountFactory {
+	return &DefaultServiceAccountFactory{}
+}
+
+// NewServiceAccount creates a new ServiceAccount operator.
+func (f *DefaultServiceAccountFactory) NewServiceAccount(params kai.ServiceAccountParams) kai.ServiceAccountOperator {
+	return &cluster.ServiceAccount{
+		Name:             params.Name,
+		Namespace:        params.Namespace,
+		ImagePullSecrets: params.ImagePullSecrets,
+		AutomountToken:   params.AutomountToken,
+		Labels:           params.Labels,
+		DryRun:           params.DryRun,
+	}
+}
+
+// RegisterServiceAccountTools registers ServiceAccount tools.
+func RegisterServiceAccountTools(s kai.ServerInterface, cm kai.ClusterManager) {
+	factory := NewDefaultServiceAccountFactory()
+	RegisterServiceAccountToolsWithFactory(s, cm, factory)
+}
+
+// RegisterServiceAccountToolsWithFactory registers ServiceAccount tools using the provided factory.
+func RegisterServiceAccountToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, factory ServiceAccountFactory) {
+	createServiceAccountTool := mcp.NewTool("create_service_account",
+		mcp.WithDescription("Create a ServiceAccount for pods to run as, optionally with image pull secrets and with token automounting turned off"),
+		creationAnnotation("Create service account"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the service account"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace for the service account (defaults to current namespace)"),
+		),
+		mcp.WithArray("image_pull_secrets",
+			mcp.Description("Names of docker registry secrets in the same namespace used to pull images for pods running as this account"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithBoolean("automount",
+			mcp.Description("Whether pods mount the account's API token (automountServiceAccountToken). Unset leaves the Kubernetes default of true"),
+		),
+		mcp.WithObject("labels",
+			mcp.Description("Labels to apply to the service account"),
+		),
+		dryRunOption(),
+	)
+	s.AddTool(createServiceAccountTool, createServiceAccountHandler(cm, factory))
+
+	getServiceAccountTool := mcp.NewTool("get_service_account",
+		mcp.WithDescription("Get a service account with its secrets, image pull secrets and whether it automounts its token"),
+		readOnlyAnnotation("Get service account"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the service account"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the service account (defaults to current namespace)"),
+		),
+		outputOption(),
+	)
+	s.AddTool(getServiceAccountTool, getServiceAccountHandler(cm, factory))
+
+	listServiceAccountsTool := mcp.NewTool("list_service_accounts",
+		mcp.WithDescription("List service accounts in the current namespace or across all namespaces"),
+		readOnlyAnnotation("List service accounts"),
+		mcp.WithBoolean("all_namespaces",
+			mcp.Description("Whether to list service accounts across all namespaces"),
+		),
+		confirmScanOption(),
+		mcp.WithString("namespace",
+			mcp.Description("Specific namespace to list service accounts from (defaults to current namespace)"),
+		),
+		mcp.WithString("label_selector",
+			mcp.Description("Label selector to filter service accounts (e.g., 'app=web')"),
+		),
+		outputOption(),
+	)
+	s.AddTool(listServiceAccountsTool, listServiceAccountsHandler(cm, factory))
+
+	deleteServiceAccountTool := mcp.NewTool("delete_service_account",
+		mcp.WithDescription("Delete a service account; pods already running as it keep their mounted token until it expires"),
+		destructiveAnnotation("Delete service account"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the service account to delete"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the service account (defaults to current namespace)"),
+		),
+	)
+	s.AddTool(deleteServiceAccountTool, deleteServiceAccountHandler(cm, factory))
+}
+
+func createServiceAccountHandler(cm kai.ClusterManager, factory ServiceAccountFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "create_service_account"))
+
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.ServiceAccountParams{
+			Name:      name,
+			Namespace: namespace,
+			DryRun:    dryRunArg(request),
+		}
+		if v, ok := request.GetArguments()["image_pull_secrets"].([]interface{}); ok {
+			params.ImagePullSecrets = v
+		}
+		if v, ok := request.GetArguments()["automount"].(bool); ok {
+			params.AutomountToken = &v
+		}
+		if v, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			params.Labels = v
+		}
+
+		result, err := factory.NewServiceAccount(params).Create(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to create service account",
+				slog.String("name", name),
+				slog.String("namespace", namespace),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to create service account: %s", classifyError(err))), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func getServiceAccountHandler(cm kai.ClusterManager, factory ServiceAccountFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "get_service_account"))
+
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		format, errResult := outputFormat(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if format != outputText {
+			return structuredResult(ctx, cm, format, func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error) {
+				return client.CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
+			}), nil
+		}
+
+		params := kai.ServiceAccountParams{
+			Name:      name,
+			Namespace: namespace,
+		}
+
+		result, err := factory.NewServiceAccount(params).Get(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get service account: %s", classifyError(err))), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func listServiceAccountsHandler(cm kai.ClusterManager, factory ServiceAccountFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "list_service_accounts"))
+
+		var allNamespaces bool
+		if allNamespacesArg, ok := request.GetArguments()["all_namespaces"].(bool); ok {
+			allNamespaces = allNamespacesArg
+		}
+
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
+
+		var namespace string
+		if !allNamespaces {
+			if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+				namespace = namespaceArg
+			} else {
+				namespace = kai.CurrentNamespace(ctx, cm)
+			}
+		}
+
+		var labelSelector string
+		if labelSelectorArg, ok := request.GetArguments()["label_selector"].(string); ok {
+			labelSelector = labelSelectorArg
+		}
+
+		format, errResult := outputFormat(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if format != outputText {
+			return structuredResult(ctx, cm, format, func(ctx context.Context, client kubernetes.Interface) (runtime.Object, error) {
+				return client.CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+			}), nil
+		}
+
+		params := kai.ServiceAccountParams{
+			Namespace: namespace,
+		}
+
+		result, err := factory.NewServiceAccount(params).List(ctx, cm, allNamespaces, labelSelector)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to list service accounts: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func deleteServiceAccountHandler(cm kai.ClusterManager, factory ServiceAccountFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "delete_service_account"))
+
+		name, errResult := requireName(request)
+		if errResult != nil {
+			return errResult, nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.ServiceAccountParams{
+			Name:      name,
+			Namespace: namespace,
+		}
+
+		result, err := factory.NewServiceAccount(params).Delete(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to delete service account",
+				slog.String("name", name),
+				slog.String("namespace", namespace),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete service account: %s", classifyError(err))), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
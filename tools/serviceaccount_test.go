@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/basebandit/kai"
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterServiceAccountTools(t *testing.T) {
+	mockServer := &testmocks.MockServer{}
+	mockCM := testmocks.NewMockClusterManager()
+
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(4)
+
+	RegisterServiceAccountTools(mockServer, mockCM)
+
+	mockServer.AssertExpectations(t)
+}
+
+func TestCreateServiceAccountHandler(t *testing.T) {
+	mockCM := testmocks.NewMockClusterManager()
+	mockFactory := testmocks.NewMockServiceAccountFactory()
+	mockSA := &testmocks.MockServiceAccount{}
+
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+	mockFactory.On("NewServiceAccount", mock.MatchedBy(func(params kai.ServiceAccountParams) bool {
+		return params.Name == "builder" &&
+			params.Namespace == defaultNamespace &&
+			len(params.ImagePullSecrets) == 1 &&
+			params.AutomountToken != nil && !*params.AutomountToken
+	})).Return(mockSA)
+	mockSA.On("Create", mock.Anything, mockCM).Return(`ServiceAccount "builder" created successfully in namespace "default"`, nil)
+
+	result, err := createServiceAccountHandler(mockCM, mockFactory)(context.Background(), toolRequest(map[string]interface{}{
+		"name":               "builder",
+		"image_pull_secrets": []interface{}{"registry-creds"},
+		"automount":          false,
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, result), "created successfully")
+	mockFactory.AssertExpectations(t)
+	mockSA.AssertExpectations(t)
+
+	result, err = createServiceAccountHandler(mockCM, mockFactory)(context.Background(), toolRequest(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, errMissingName, resultText(t, result))
+}
+
+func TestGetServiceAccountHandler(t *testing.T) {
+	mockCM := testmocks.NewMockClusterManager()
+	mockFactory := testmocks.NewMockServiceAccountFactory()
+	mockSA := &testmocks.MockServiceAccount{}
+
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+	mockFactory.On("NewServiceAccount", kai.ServiceAccountParams{Name: "sa1", Namespace: "apps"}).Return(mockSA)
+	mockSA.On("Get", mock.Anything, mockCM).Return("ServiceAccount: sa1\nAutomount Token: false", nil)
+
+	result, err := getServiceAccountHandler(mockCM, mockFactory)(context.Background(), toolRequest(map[string]interface{}{
+		"name":      "sa1",
+		"namespace": "apps",
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, result), "Automount Token: false")
+	mockFactory.AssertExpectations(t)
+	mockSA.AssertExpectations(t)
+}
+
+func TestListServiceAccountsHandler(t *testing.T) {
+	mockCM := testmocks.NewMockClusterManager()
+	mockFactory := testmocks.NewMockServiceAccountFactory()
+	mockSA := &testmocks.MockServiceAccount{}
+
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+	mockFactory.On("NewServiceAccount", kai.ServiceAccountParams{Namespace: defaultNamespace}).Return(mockSA)
+	mockSA.On("List", mock.Anything, mockCM, false, "team=ci").Return("ServiceAccounts (1):\n• builder", nil)
+
+	result, err := listServiceAccountsHandler(mockCM, mockFactory)(context.Background(), toolRequest(map[string]interface{}{
+		"label_selector": "team=ci",
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, resultText(t, result), "builder")
+	mockSA.AssertExpectations(t)
+}
+
+func TestDeleteServiceAccountHandler(t *testing.T) {
+	mockCM := testmocks.NewMockClusterManager()
+	mockFactory := testmocks.NewMockServiceAccountFactory()
+	mockSA := &testmocks.MockServiceAccount{}
+
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+	mockFactory.On("NewServiceAccount", kai.ServiceAccountParams{Name: "sa1", Namespace: defaultNamespace}).Return(mockSA)
+	mockSA.On("Delete", mock.Anything, mockCM).Return("", errors.New(`service account "sa1" not found in namespace "default"`))
+
+	result, err := deleteServiceAccountHandler(mockCM, mockFactory)(context.Background(), toolRequest(map[string]interface{}{"name": "sa1"}))
+	assert.NoError(t, err)
+	assert.Equal(t, `Failed to delete service account: service account "sa1" not found in namespace "default"`, resultText(t, result))
+	mockSA.AssertExpectations(t)
+}
@@ -0,0 +1,596 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/basebandit/kai"
+	"github.com/basebandit/kai/cluster"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// StatefulSetFactory is an interface for creating StatefulSet operators.
+type StatefulSetFactory interface {
+	NewStatefulSet(params kai.StatefulSetParams) kai.StatefulSetOperator
+}
+
+// DefaultStatefulSetFactory implements the StatefulSetFactory interface.
+type DefaultStatefulSetFactory struct{}
+
+// NewDefaultStatefulSetFactory creates a new DefaultStatefulSetFactory.
+func NewDefaultStatefulSetFactory() *DefaultStatefulSetFactory {
+	return &DefaultStatefulSetFactory{}
+}
+
+// NewStatefulSet creates a new StatefulSet operator.
+func (f *DefaultStatefulSetFactory) NewStatefulSet(params kai.StatefulSetParams) kai.StatefulSetOperator {
+	return &cluster.StatefulSet{
+		Name:                 params.Name,
+		Namespace:            params.Namespace,
+		Image:                params.Image,
+		Replicas:             params.Replicas,
+		ServiceName:          params.ServiceName,
+		ContainerPort:        params.ContainerPort,
+		Labels:               params.Labels,
+		Env:                  params.Env,
+		VolumeClaimTemplates: params.VolumeClaimTemplates,
+		UpdateStrategy:       params.UpdateStrategy,
+		Partition:            params.Partition,
+		ImagePullPolicy:      params.ImagePullPolicy,
+		ImagePullSecrets:     params.ImagePullSecrets,
+		DryRun:               params.DryRun,
+		ShowDiff:             params.ShowDiff,
+	}
+}
+
+// RegisterStatefulSetTools registers all StatefulSet-related tools with the server.
+func RegisterStatefulSetTools(s kai.ServerInterface, cm kai.ClusterManager) {
+	factory := NewDefaultStatefulSetFactory()
+	RegisterStatefulSetToolsWithFactory(s, cm, factory)
+}
+
+// RegisterStatefulSetToolsWithFactory registers all StatefulSet-related tools using the provided factory.
+func RegisterStatefulSetToolsWithFactory(s kai.ServerInterface, cm kai.ClusterManager, factory StatefulSetFactory) {
+	createStatefulSetTool := mcp.NewTool("create_statefulset",
+		mcp.WithDescription("Create a new StatefulSet governed by a headless service, optionally with per-replica volume claim templates"),
+		creationAnnotation("Create statefulset"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the StatefulSet"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace for the StatefulSet (defaults to current namespace)"),
+		),
+		mcp.WithString("image",
+			mcp.Required(),
+			mcp.Description("Container image to run"),
+		),
+		mcp.WithString("service_name",
+			mcp.Required(),
+			mcp.Description("Name of the headless service that gives the pods stable network identities"),
+		),
+		mcp.WithNumber("replicas",
+			mcp.Description("Number of replicas (defaults to 1)"),
+		),
+		mcp.WithString("container_port",
+			mcp.Description(descContainerPortFormat),
+		),
+		mcp.WithArray("volume_claim_templates",
+			mcp.Description("Per-replica volumes as array of objects with 'name', 'mount_path', 'storage' (e.g. '1Gi'), and optional 'storage_class' and 'access_modes' (defaults to ReadWriteOnce)"),
+		),
+		mcp.WithString("update_strategy",
+			mcp.Description("Update strategy: RollingUpdate (default) or OnDelete"),
+		),
+		mcp.WithNumber("partition",
+			mcp.Description("For RollingUpdate, only pods with an ordinal at or above the partition are updated"),
+		),
+		mcp.WithObject("labels",
+			mcp.Description("Labels to apply to the StatefulSet and its pods"),
+		),
+		mcp.WithObject("env",
+			mcp.Description("Environment variables as key-value pairs"),
+		),
+		mcp.WithString("image_pull_policy",
+			mcp.Description(descImagePullPolicy),
+		),
+		mcp.WithArray("image_pull_secrets",
+			mcp.Description("Image pull secrets for private registries"),
+		),
+		verifyImageOption(),
+		dryRunOption(),
+	)
+	s.AddTool(createStatefulSetTool, createStatefulSetHandler(cm, factory))
+
+	getStatefulSetTool := mcp.NewTool("get_statefulset",
+		mcp.WithDescription("Get information about a specific StatefulSet"),
+		readOnlyAnnotation("Get statefulset"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the StatefulSet"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the StatefulSet (defaults to current namespace)"),
+		),
+	)
+	s.AddTool(getStatefulSetTool, getStatefulSetHandler(cm, factory))
+
+	listStatefulSetsTool := mcp.NewTool("list_statefulsets",
+		mcp.WithDescription("List StatefulSets in the current namespace or across all namespaces"),
+		readOnlyAnnotation("List statefulsets"),
+		mcp.WithBoolean("all_namespaces",
+			mcp.Description("Whether to list StatefulSets across all namespaces"),
+		),
+		confirmScanOption(),
+		mcp.WithString("namespace",
+			mcp.Description("Specific namespace to list StatefulSets from (defaults to current namespace)"),
+		),
+		mcp.WithString("label_selector",
+			mcp.Description("Label selector to filter StatefulSets (e.g., 'app=postgres')"),
+		),
+	)
+	s.AddTool(listStatefulSetsTool, listStatefulSetsHandler(cm, factory))
+
+	updateStatefulSetTool := mcp.NewTool("update_statefulset",
+		mcp.WithDescription("Update an existing StatefulSet's image, replicas, labels, or update strategy"),
+		idempotentMutationAnnotation("Update statefulset"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the StatefulSet to update"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the StatefulSet (defaults to current namespace)"),
+		),
+		mcp.WithString("image",
+			mcp.Description("New container image"),
+		),
+		mcp.WithNumber("replicas",
+			mcp.Description("New number of replicas"),
+		),
+		mcp.WithObject("labels",
+			mcp.Description("Labels to add or update"),
+		),
+		mcp.WithString("update_strategy",
+			mcp.Description("Update strategy: RollingUpdate or OnDelete"),
+		),
+		mcp.WithNumber("partition",
+			mcp.Description("RollingUpdate partition; set it to stage an update on the highest ordinals first"),
+		),
+		dryRunOption(),
+		showDiffOption(),
+	)
+	s.AddTool(updateStatefulSetTool, updateStatefulSetHandler(cm, factory))
+
+	describeStatefulSetTool := mcp.NewTool("describe_statefulset",
+		mcp.WithDescription("Describe a StatefulSet: current, ready, and updated replicas, its headless service, and volume claim templates"),
+		readOnlyAnnotation("Describe statefulset"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the StatefulSet"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the StatefulSet (defaults to current namespace)"),
+		),
+	)
+	s.AddTool(describeStatefulSetTool, describeStatefulSetHandler(cm, factory))
+
+	deleteStatefulSetTool := mcp.NewTool("delete_statefulset",
+		mcp.WithDescription("Delete a StatefulSet and its pods. PersistentVolumeClaims created from its templates are kept unless its retention policy deletes them"),
+		destructiveAnnotation("Delete statefulset"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the StatefulSet to delete"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the StatefulSet (defaults to current namespace)"),
+		),
+	)
+	s.AddTool(deleteStatefulSetTool, deleteStatefulSetHandler(cm, factory))
+
+	scaleStatefulSetTool := mcp.NewTool("scale_statefulset",
+		mcp.WithDescription("Scale a StatefulSet to a specified number of replicas via the scale subresource"),
+		idempotentMutationAnnotation("Scale statefulset"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the StatefulSet to scale"),
+		),
+		mcp.WithNumber("replicas",
+			mcp.Required(),
+			mcp.Description("Number of replicas to scale to (non-negative integer)"),
+		),
+		mcp.WithString("namespace",
+			mcp.Description("Namespace of the StatefulSet (defaults to current namespace)"),
+		),
+	)
+	s.AddTool(scaleStatefulSetTool, scaleStatefulSetHandler(cm, factory))
+}
+
+func createStatefulSetHandler(cm kai.ClusterManager, factory StatefulSetFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "create_statefulset"))
+
+		nameArg, ok := request.GetArguments()["name"]
+		if !ok || nameArg == nil {
+			return mcp.NewToolResultText(errMissingName), nil
+		}
+
+		name, ok := nameArg.(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultText(errEmptyName), nil
+		}
+
+		if err := validateResourceName(name); err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		imageArg, ok := request.GetArguments()["image"]
+		if !ok || imageArg == nil {
+			return mcp.NewToolResultText(errMissingImage), nil
+		}
+
+		image, ok := imageArg.(string)
+		if !ok || image == "" {
+			return mcp.NewToolResultText(errEmptyImage), nil
+		}
+
+		serviceName, ok := request.GetArguments()["service_name"].(string)
+		if !ok || serviceName == "" {
+			return mcp.NewToolResultText("Required parameter 'service_name' is missing"), nil
+		}
+
+		namespace := kai.CurrentNamespace(ctx, cm)
+		if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+			namespace = namespaceArg
+		}
+
+		params := kai.StatefulSetParams{
+			Name:        name,
+			Namespace:   namespace,
+			Image:       image,
+			ServiceName: serviceName,
+		}
+
+		if replicasArg, ok := request.GetArguments()["replicas"].(float64); ok {
+			replicas, err := statefulSetCount("replicas", replicasArg)
+			if err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
+			params.Replicas = &replicas
+		}
+
+		if portArg, ok := request.GetArguments()["container_port"].(string); ok && portArg != "" {
+			params.ContainerPort = portArg
+		}
+
+		if templatesArg, ok := request.GetArguments()["volume_claim_templates"].([]interface{}); ok {
+			templates, err := parseVolumeClaimTemplates(templatesArg)
+			if err != nil {
+				return mcp.NewToolResultText(fmt.Sprintf("Invalid volume_claim_templates: %s", err.Error())), nil
+			}
+			params.VolumeClaimTemplates = templates
+		}
+
+		if strategyArg, ok := request.GetArguments()["update_strategy"].(string); ok && strategyArg != "" {
+			params.UpdateStrategy = strategyArg
+		}
+
+		if partitionArg, ok := request.GetArguments()["partition"].(float64); ok {
+			partition, err := statefulSetCount("partition", partitionArg)
+			if err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
+			params.Partition = &partition
+		}
+
+		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
+			params.Labels = labelsArg
+		}
+
+		if envArg, ok := request.GetArguments()["env"].(map[string]interface{}); ok {
+			params.Env = envArg
+		}
+
+		if imagePullPolicyArg, ok := request.GetArguments()["image_pull_policy"].(string); ok && imagePullPolicyArg != "" {
+			params.ImagePullPolicy = imagePullPolicyArg
+		}
+
+		if imagePullSecretsArg, ok := request.GetArguments()["image_pull_secrets"].([]interface{}); ok {
+			params.ImagePullSecrets = imagePullSecretsArg
+		}
+
+		if result := checkImage(ctx, cm, request, params.Namespace, params.Image, params.ImagePullSecrets); result != nil {
+			return result, nil
+		}
+
+		params.DryRun = dryRunArg(request)
+		statefulSet := factory.NewStatefulSet(params)
+		result, err := statefulSet.Create(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to create StatefulSet",
+				slog.String("name", name),
+				slog.String("namespace", namespace),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to create StatefulSet: %s", classifyError(err))), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func getStatefulSetHandler(cm kai.ClusterManager, factory StatefulSetFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "get_statefulset"))
+
+		params, errText := statefulSetNameParams(ctx, cm, request)
+		if errText != "" {
+			return mcp.NewToolResultText(errText), nil
+		}
+
+		result, err := factory.NewStatefulSet(params).Get(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to get StatefulSet: %s", classifyError(err))), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func listStatefulSetsHandler(cm kai.ClusterManager, factory StatefulSetFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "list_statefulsets"))
+
+		var allNamespaces bool
+		if allNamespacesArg, ok := request.GetArguments()["all_namespaces"].(bool); ok {
+			allNamespaces = allNamespacesArg
+		}
+
+		if allNamespaces {
+			if result := checkNamespaceScan(ctx, cm, request); result != nil {
+				return result, nil
+			}
+		}
+
+		var namespace string
+		if !allNamespaces {
+			if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+				namespace = namespaceArg
+			} else {
+				namespace = kai.CurrentNamespace(ctx, cm)
+			}
+		}
+
+		var labelSelector string
+		if labelSelectorArg, ok := request.GetArguments()["label_selector"].(string); ok {
+			labelSelector = labelSelectorArg
+		}
+
+		params := kai.StatefulSetParams{
+			Namespace: namespace,
+		}
+
+		result, err := factory.NewStatefulSet(params).List(ctx, cm, allNamespaces, labelSelector)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to list StatefulSets: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func updateStatefulSetHandler(cm kai.ClusterManager, factory StatefulSetFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "update_statefulset"))
+
+		params, errText := statefulSetNameParams(ctx, cm, request)
+		if errText != "" {
+			return mcp.NewToolResultText(errText), nil
+		}
+
+		updated := false
+
+		if imageArg, ok := request.GetArguments()["image"].(string); ok && imageArg != "" {
+			params.Image = imageArg
+			updated = true
+		}
+
+		if replicasArg, ok := request.GetArguments()["replicas"].(float64); ok {
+			replicas, err := statefulSetCount("replicas", replicasArg)
+			if err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
+			params.Replicas = &replicas
+			updated = true
+		}
+
+		if labelsArg, ok := request.GetArguments()["labels"].(map[string]interface{}); ok && len(labelsArg) > 0 {
+			if err := validateLabels(labelsArg); err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
+			params.Labels = labelsArg
+			updated = true
+		}
+
+		if strategyArg, ok := request.GetArguments()["update_strategy"].(string); ok && strategyArg != "" {
+			params.UpdateStrategy = strategyArg
+			updated = true
+		}
+
+		if partitionArg, ok := request.GetArguments()["partition"].(float64); ok {
+			partition, err := statefulSetCount("partition", partitionArg)
+			if err != nil {
+				return mcp.NewToolResultText(err.Error()), nil
+			}
+			params.Partition = &partition
+			updated = true
+		}
+
+		if !updated {
+			return mcp.NewToolResultText(errNoUpdateParams), nil
+		}
+
+		params.DryRun = dryRunArg(request)
+		params.ShowDiff = showDiffArg(request)
+		result, err := factory.NewStatefulSet(params).Update(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to update StatefulSet: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func describeStatefulSetHandler(cm kai.ClusterManager, factory StatefulSetFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "describe_statefulset"))
+
+		params, errText := statefulSetNameParams(ctx, cm, request)
+		if errText != "" {
+			return mcp.NewToolResultText(errText), nil
+		}
+
+		result, err := factory.NewStatefulSet(params).Describe(ctx, cm)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to describe StatefulSet: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func deleteStatefulSetHandler(cm kai.ClusterManager, factory StatefulSetFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "delete_statefulset"))
+
+		params, errText := statefulSetNameParams(ctx, cm, request)
+		if errText != "" {
+			return mcp.NewToolResultText(errText), nil
+		}
+
+		result, err := factory.NewStatefulSet(params).Delete(ctx, cm)
+		if err != nil {
+			slog.Warn("failed to delete StatefulSet",
+				slog.String("name", params.Name),
+				slog.String("namespace", params.Namespace),
+				slog.String("error", err.Error()),
+			)
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to delete StatefulSet: %s", classifyError(err))), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+func scaleStatefulSetHandler(cm kai.ClusterManager, factory StatefulSetFactory) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		slog.Debug("tool invoked", slog.String("tool", "scale_statefulset"))
+
+		params, errText := statefulSetNameParams(ctx, cm, request)
+		if errText != "" {
+			return mcp.NewToolResultText(errText), nil
+		}
+
+		replicasArg, ok := request.GetArguments()["replicas"]
+		if !ok || replicasArg == nil {
+			return mcp.NewToolResultText("missing required parameter: replicas"), nil
+		}
+
+		replicasValue, ok := replicasArg.(float64)
+		if !ok {
+			return mcp.NewToolResultText("invalid replicas parameter: must be a number"), nil
+		}
+
+		replicas, err := statefulSetCount("replicas", replicasValue)
+		if err != nil {
+			return mcp.NewToolResultText(err.Error()), nil
+		}
+
+		result, err := factory.NewStatefulSet(params).Scale(ctx, cm, replicas)
+		if err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Failed to scale StatefulSet: %s", err.Error())), nil
+		}
+
+		return mcp.NewToolResultText(result), nil
+	}
+}
+
+// statefulSetNameParams reads the required name and optional namespace
+// shared by the single-StatefulSet tools. A non-empty string is the error
+// to return to the caller.
+func statefulSetNameParams(ctx context.Context, cm kai.ClusterManager, request mcp.CallToolRequest) (kai.StatefulSetParams, string) {
+	nameArg, ok := request.GetArguments()["name"]
+	if !ok || nameArg == nil {
+		return kai.StatefulSetParams{}, errMissingName
+	}
+
+	name, ok := nameArg.(string)
+	if !ok || name == "" {
+		return kai.StatefulSetParams{}, errEmptyName
+	}
+
+	namespace := kai.CurrentNamespace(ctx, cm)
+	if namespaceArg, ok := request.GetArguments()["namespace"].(string); ok && namespaceArg != "" {
+		namespace = namespaceArg
+	}
+
+	return kai.StatefulSetParams{Name: name, Namespace: namespace}, ""
+}
+
+// statefulSetCount converts a JSON number to a non-negative int32.
+func statefulSetCount(param string, value float64) (int32, error) {
+	if value < 0 || value != math.Trunc(value) || value > math.MaxInt32 {
+		return 0, fmt.Errorf("invalid %s parameter: must be a non-negative integer", param)
+	}
+	return int32(value), nil
+}
+
+func parseVolumeClaimTemplates(templatesSlice []interface{}) ([]kai.VolumeClaimTemplate, error) {
+	templates := make([]kai.VolumeClaimTemplate, 0, len(templatesSlice))
+
+	for i, item := range templatesSlice {
+		templateMap, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("template %d: must be an object", i)
+		}
+
+		template := kai.VolumeClaimTemplate{}
+
+		name, ok := templateMap["name"].(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("template %d: 'name' is required", i)
+		}
+		template.Name = name
+
+		mountPath, ok := templateMap["mount_path"].(string)
+		if !ok || mountPath == "" {
+			return nil, fmt.Errorf("template %d: 'mount_path' is required", i)
+		}
+		template.MountPath = mountPath
+
+		storage, ok := templateMap["storage"].(string)
+		if !ok || storage == "" {
+			return nil, fmt.Errorf("template %d: 'storage' is required", i)
+		}
+		template.Storage = storage
+
+		if storageClass, ok := templateMap["storage_class"].(string); ok {
+			template.StorageClassName = storageClass
+		}
+
+		if modesArg, ok := templateMap["access_modes"].([]interface{}); ok {
+			for _, m := range modesArg {
+				if mode, ok := m.(string); ok && mode != "" {
+					template.AccessModes = append(template.AccessModes, mode)
+				}
+			}
+		}
+
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
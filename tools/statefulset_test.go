@@ -0,0 +1,234 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/basebandit/kai"
+	"github.com/basebandit/kai/testmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterStatefulSetTools(t *testing.T) {
+	mockServer := new(testmocks.MockServer)
+	mockCM := testmocks.NewMockClusterManager()
+	mockFactory := testmocks.NewMockStatefulSetFactory()
+
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(7)
+
+	RegisterStatefulSetToolsWithFactory(mockServer, mockCM, mockFactory)
+
+	mockServer.AssertExpectations(t)
+}
+
+func TestCreateStatefulSetHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           map[string]interface{}
+		mockSetup      func(*testmocks.MockClusterManager, *testmocks.MockStatefulSetFactory, *testmocks.MockStatefulSet)
+		expectedOutput string
+	}{
+		{
+			name: "Create with volume claim templates",
+			args: map[string]interface{}{
+				"name":            "postgres",
+				"image":           "postgres:16",
+				"service_name":    "postgres-headless",
+				"replicas":        float64(3),
+				"update_strategy": "RollingUpdate",
+				"partition":       float64(1),
+				"volume_claim_templates": []interface{}{
+					map[string]interface{}{
+						"name":          "data",
+						"mount_path":    "/var/lib/postgresql/data",
+						"storage":       "10Gi",
+						"storage_class": "fast",
+						"access_modes":  []interface{}{"ReadWriteOnce"},
+					},
+				},
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, mockFactory *testmocks.MockStatefulSetFactory, mockStatefulSet *testmocks.MockStatefulSet) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+				mockFactory.On("NewStatefulSet", mock.MatchedBy(func(params kai.StatefulSetParams) bool {
+					return params.Name == "postgres" &&
+						params.Namespace == defaultNamespace &&
+						params.ServiceName == "postgres-headless" &&
+						*params.Replicas == 3 &&
+						*params.Partition == 1 &&
+						len(params.VolumeClaimTemplates) == 1 &&
+						assert.ObjectsAreEqual(kai.VolumeClaimTemplate{
+							Name:             "data",
+							MountPath:        "/var/lib/postgresql/data",
+							Storage:          "10Gi",
+							StorageClassName: "fast",
+							AccessModes:      []string{"ReadWriteOnce"},
+						}, params.VolumeClaimTemplates[0])
+				})).Return(mockStatefulSet)
+				mockStatefulSet.On("Create", mock.Anything, mockCM).Return(`StatefulSet "postgres" created successfully in namespace "default" with 3 replica(s)`, nil)
+			},
+			expectedOutput: `StatefulSet "postgres" created successfully`,
+		},
+		{
+			name: "Missing service name",
+			args: map[string]interface{}{
+				"name":  "postgres",
+				"image": "postgres:16",
+			},
+			mockSetup:      func(*testmocks.MockClusterManager, *testmocks.MockStatefulSetFactory, *testmocks.MockStatefulSet) {},
+			expectedOutput: "Required parameter 'service_name' is missing",
+		},
+		{
+			name: "Template without mount path",
+			args: map[string]interface{}{
+				"name":         "postgres",
+				"image":        "postgres:16",
+				"service_name": "postgres-headless",
+				"volume_claim_templates": []interface{}{
+					map[string]interface{}{"name": "data", "storage": "1Gi"},
+				},
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, _ *testmocks.MockStatefulSetFactory, _ *testmocks.MockStatefulSet) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+			},
+			expectedOutput: "Invalid volume_claim_templates: template 0: 'mount_path' is required",
+		},
+		{
+			name: "Fractional replicas",
+			args: map[string]interface{}{
+				"name":         "postgres",
+				"image":        "postgres:16",
+				"service_name": "postgres-headless",
+				"replicas":     float64(1.5),
+			},
+			mockSetup: func(mockCM *testmocks.MockClusterManager, _ *testmocks.MockStatefulSetFactory, _ *testmocks.MockStatefulSet) {
+				mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+			},
+			expectedOutput: "invalid replicas parameter: must be a non-negative integer",
+		},
+		{
+			name: "Missing image",
+			args: map[string]interface{}{
+				"name": "postgres",
+			},
+			mockSetup:      func(*testmocks.MockClusterManager, *testmocks.MockStatefulSetFactory, *testmocks.MockStatefulSet) {},
+			expectedOutput: errMissingImage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockFactory := testmocks.NewMockStatefulSetFactory()
+			mockStatefulSet := &testmocks.MockStatefulSet{}
+			tt.mockSetup(mockCM, mockFactory, mockStatefulSet)
+
+			result, err := createStatefulSetHandler(mockCM, mockFactory)(context.Background(), toolRequest(tt.args))
+			assert.NoError(t, err)
+			assert.Contains(t, resultText(t, result), tt.expectedOutput)
+
+			mockCM.AssertExpectations(t)
+			mockFactory.AssertExpectations(t)
+			mockStatefulSet.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateStatefulSetHandler(t *testing.T) {
+	t.Run("NoFields", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+		mockFactory := testmocks.NewMockStatefulSetFactory()
+
+		result, err := updateStatefulSetHandler(mockCM, mockFactory)(context.Background(), toolRequest(map[string]interface{}{
+			"name": "postgres",
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, errNoUpdateParams, resultText(t, result))
+		mockFactory.AssertExpectations(t)
+	})
+
+	t.Run("Image", func(t *testing.T) {
+		mockCM := testmocks.NewMockClusterManager()
+		mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+		mockFactory := testmocks.NewMockStatefulSetFactory()
+		mockStatefulSet := &testmocks.MockStatefulSet{}
+		mockFactory.On("NewStatefulSet", kai.StatefulSetParams{
+			Name:      "postgres",
+			Namespace: defaultNamespace,
+			Image:     "postgres:17",
+		}).Return(mockStatefulSet)
+		mockStatefulSet.On("Update", mock.Anything, mockCM).Return(`StatefulSet "postgres" updated successfully in namespace "default"`, nil)
+
+		result, err := updateStatefulSetHandler(mockCM, mockFactory)(context.Background(), toolRequest(map[string]interface{}{
+			"name":  "postgres",
+			"image": "postgres:17",
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, `StatefulSet "postgres" updated successfully in namespace "default"`, resultText(t, result))
+		mockStatefulSet.AssertExpectations(t)
+	})
+}
+
+func TestDescribeStatefulSetHandler(t *testing.T) {
+	mockCM := testmocks.NewMockClusterManager()
+	mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+	mockFactory := testmocks.NewMockStatefulSetFactory()
+	mockStatefulSet := &testmocks.MockStatefulSet{}
+	mockFactory.On("NewStatefulSet", kai.StatefulSetParams{Name: "postgres", Namespace: testNamespace}).Return(mockStatefulSet)
+	mockStatefulSet.On("Describe", mock.Anything, mockCM).Return("", errors.New(`statefulsets.apps "postgres" not found`))
+
+	result, err := describeStatefulSetHandler(mockCM, mockFactory)(context.Background(), toolRequest(map[string]interface{}{
+		"name":      "postgres",
+		"namespace": testNamespace,
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, `Failed to describe StatefulSet: statefulsets.apps "postgres" not found`, resultText(t, result))
+	mockStatefulSet.AssertExpectations(t)
+}
+
+func TestScaleStatefulSetHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           map[string]interface{}
+		callScale      bool
+		expectedOutput string
+	}{
+		{
+			name:           "Scale",
+			args:           map[string]interface{}{"name": "postgres", "replicas": float64(5)},
+			callScale:      true,
+			expectedOutput: `StatefulSet "postgres" scaled to 5 replica(s) in namespace "default"`,
+		},
+		{
+			name:           "MissingReplicas",
+			args:           map[string]interface{}{"name": "postgres"},
+			expectedOutput: "missing required parameter: replicas",
+		},
+		{
+			name:           "NegativeReplicas",
+			args:           map[string]interface{}{"name": "postgres", "replicas": float64(-1)},
+			expectedOutput: "invalid replicas parameter: must be a non-negative integer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCM := testmocks.NewMockClusterManager()
+			mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
+			mockFactory := testmocks.NewMockStatefulSetFactory()
+			mockStatefulSet := &testmocks.MockStatefulSet{}
+			if tt.callScale {
+				mockFactory.On("NewStatefulSet", kai.StatefulSetParams{Name: "postgres", Namespace: defaultNamespace}).Return(mockStatefulSet)
+				mockStatefulSet.On("Scale", mock.Anything, mockCM, int32(5)).Return(tt.expectedOutput, nil)
+			}
+
+			result, err := scaleStatefulSetHandler(mockCM, mockFactory)(context.Background(), toolRequest(tt.args))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOutput, resultText(t, result))
+			mockFactory.AssertExpectations(t)
+			mockStatefulSet.AssertExpectations(t)
+		})
+	}
+}
@@ -17,7 +17,7 @@ import (
 func TestRegisterStorageTools(t *testing.T) {
 	mockServer := &testmocks.MockServer{}
 	mockCM := testmocks.NewMockClusterManager()
-	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(9)
+	mockServer.On("AddTool", mock.AnythingOfType("mcp.Tool"), mock.AnythingOfType("server.ToolHandlerFunc")).Return().Times(5)
 	RegisterStorageTools(mockServer, mockCM)
 	mockServer.AssertExpectations(t)
 }
@@ -61,32 +61,6 @@ func TestStorageHandlers(t *testing.T) {
 		assert.Contains(t, resultText(t, r), "deleted")
 	})
 
-	t.Run("PVCHandlers", func(t *testing.T) {
-		fakeClient := fake.NewSimpleClientset()
-		mockCM := testmocks.NewMockClusterManager()
-		mockCM.On("GetCurrentClient").Return(fakeClient, nil)
-		mockCM.On("GetCurrentNamespace").Return(defaultNamespace)
-
-		r, err := createPVCHandler(mockCM)(ctx, toolRequest(map[string]interface{}{
-			"name": "pvc-1", "storage": "1Gi", "storage_class": "standard",
-			"volume_mode": "Filesystem", "access_modes": []interface{}{"ReadWriteOnce"},
-		}))
-		assert.NoError(t, err)
-		assert.Contains(t, resultText(t, r), "pvc-1")
-
-		r, err = listPVCHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"all_namespaces": true}))
-		assert.NoError(t, err)
-		assert.Contains(t, resultText(t, r), "pvc-1")
-
-		r, err = getPVCHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"name": "pvc-1"}))
-		assert.NoError(t, err)
-		assert.Contains(t, resultText(t, r), "PersistentVolumeClaim: pvc-1")
-
-		r, err = deletePVCHandler(mockCM)(ctx, toolRequest(map[string]interface{}{"name": "pvc-1"}))
-		assert.NoError(t, err)
-		assert.Contains(t, resultText(t, r), "deleted")
-	})
-
 	t.Run("StorageClassHandlers", func(t *testing.T) {
 		fakeClient := fake.NewSimpleClientset(sc)
 		mockCM := testmocks.NewMockClusterManager()
@@ -39,6 +39,7 @@ const (
 	errMissingName          = "Required parameter 'name' is missing"
 	errMissingImage         = "Required parameter 'image' is missing"
 	errMissingPod           = "Required parameter 'pod' is missing"
+	errMissingPattern       = "Required parameter 'pattern' is missing"
 	errMissingPorts         = "Required parameter 'ports' is missing"
 	errMissingLabels        = "Parameter 'labels' must be an object"
 	errEmptyName            = "Parameter 'name' must be a non-empty string"
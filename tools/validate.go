@@ -2,10 +2,54 @@ package tools
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/basebandit/kai"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+// validateResourceName checks that name is a valid Kubernetes object name, an
+// RFC 1123 subdomain, so malformed names are rejected before any API call.
+func validateResourceName(name string) error {
+	if len(validation.IsDNS1123Subdomain(name)) > 0 {
+		return fmt.Errorf("invalid name: must be a lowercase RFC 1123 subdomain of at most 253 characters, using only 'a-z', '0-9', '-' and '.', and starting and ending with an alphanumeric character (got %q)", name)
+	}
+	return nil
+}
+
+// validateContainerName checks that name is a valid container name, an
+// RFC 1123 label.
+func validateContainerName(name string) error {
+	if len(validation.IsDNS1123Label(name)) > 0 {
+		return fmt.Errorf("invalid container_name: must be a lowercase RFC 1123 label of at most 63 characters, using only 'a-z', '0-9' and '-', and starting and ending with an alphanumeric character (got %q)", name)
+	}
+	return nil
+}
+
+// validateLabels checks label keys and values against the Kubernetes syntax
+// rules. Non-string values are checked as formatted, the same way they are
+// converted when the labels are applied.
+func validateLabels(labels map[string]any) error {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return fmt.Errorf("invalid label key %q: %s", k, strings.Join(errs, "; "))
+		}
+		value := fmt.Sprintf("%v", labels[k])
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid label value for key %q: %s", k, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
 // validateContainerPort checks if the containerPort string has the correct format
 // Returns true if valid, false if invalid
 func validateContainerPort(port string) error {
@@ -42,6 +86,45 @@ func validateImagePullPolicy(policy string) error {
 	return nil
 }
 
+// validateImageReference returns a note when image runs from the latest
+// tag, named or implied by omitting a tag, with the IfNotPresent pull
+// policy: nodes that already pulled the image keep starting their cached,
+// possibly stale copy. The combination is valid, so it warns rather than
+// fails. Images pinned by digest are never stale.
+func validateImageReference(image, pullPolicy string) string {
+	if pullPolicy != "IfNotPresent" || image == "" || strings.Contains(image, "@") {
+		return ""
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	tag := "latest"
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		tag = name[i+1:]
+	}
+	if tag != "latest" {
+		return ""
+	}
+	return fmt.Sprintf("Note: image %q uses the latest tag with image_pull_policy IfNotPresent, so nodes that already pulled it keep running their cached copy; pin a version tag or use Always", image)
+}
+
+// imageReferenceNotes collects the validateImageReference notes for a
+// workload's image, or for each of its containers when they are given, to
+// append to the success message.
+func imageReferenceNotes(image, pullPolicy string, containers []kai.ContainerSpec) string {
+	var notes []string
+	if containers == nil {
+		containers = []kai.ContainerSpec{{Image: image, ImagePullPolicy: pullPolicy}}
+	}
+	for _, c := range containers {
+		if note := validateImageReference(c.Image, c.ImagePullPolicy); note != "" {
+			notes = append(notes, note)
+		}
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	return "\n" + strings.Join(notes, "\n")
+}
+
 // validateRestartPolicy checks if the restart policy is one of "Always", "OnFailure", or "Never"
 func validateRestartPolicy(policy string) error {
 	validPolicies := map[string]bool{
@@ -1,11 +1,73 @@
 package tools
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
+func TestValidateResourceName(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       string
+		expectError bool
+	}{
+		{"Valid simple name", "nginx", false},
+		{"Valid with hyphens", "my-app-1", false},
+		{"Valid with dots", "web.v2.example", false},
+		{"Valid single character", "a", false},
+		{"Valid leading digit", "1st-run", false},
+		{"Valid max length", strings.Repeat("a", 253), false},
+		{"Invalid uppercase", "MyApp", true},
+		{"Invalid underscore", "my_app", true},
+		{"Invalid leading hyphen", "-app", true},
+		{"Invalid trailing hyphen", "app-", true},
+		{"Invalid space", "my app", true},
+		{"Invalid empty string", "", true},
+		{"Invalid too long", strings.Repeat("a", 254), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateResourceName(tc.value)
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid name: must be a lowercase RFC 1123")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateLabels(t *testing.T) {
+	testCases := []struct {
+		name        string
+		labels      map[string]any
+		errContains string
+	}{
+		{"Valid label set", map[string]any{"app": "nginx", "app.kubernetes.io/version": "1.25.3", "tier": ""}, ""},
+		{"Valid non-string value", map[string]any{"replicas": float64(3), "canary": true}, ""},
+		{"Invalid over-length value", map[string]any{"app": strings.Repeat("a", 64)}, `invalid label value for key "app"`},
+		{"Invalid value with space", map[string]any{"team": "data platform"}, `invalid label value for key "team"`},
+		{"Invalid value with slash", map[string]any{"ref": "feature/login"}, `invalid label value for key "ref"`},
+		{"Invalid key", map[string]any{"bad key": "x"}, `invalid label key "bad key"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateLabels(tc.labels)
+			if tc.errContains != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateContainerPort(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -74,6 +136,33 @@ func TestValidateImagePullPolicy(t *testing.T) {
 	}
 }
 
+func TestValidateImageReference(t *testing.T) {
+	testCases := []struct {
+		name   string
+		image  string
+		policy string
+		warns  bool
+	}{
+		{"LatestWithIfNotPresent", "nginx:latest", "IfNotPresent", true},
+		{"UntaggedWithIfNotPresent", "registry.example.com:5000/team/app", "IfNotPresent", true},
+		{"LatestWithAlways", "nginx:latest", "Always", false},
+		{"LatestWithDefaultPolicy", "nginx:latest", "", false},
+		{"VersionTag", "registry.example.com:5000/team/app:1.4.2", "IfNotPresent", false},
+		{"Digest", "nginx@sha256:0123abcd", "IfNotPresent", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			note := validateImageReference(tc.image, tc.policy)
+			if tc.warns {
+				assert.Contains(t, note, "uses the latest tag with image_pull_policy IfNotPresent")
+			} else {
+				assert.Empty(t, note)
+			}
+		})
+	}
+}
+
 func TestValidateRestartPolicy(t *testing.T) {
 	testCases := []struct {
 		name        string
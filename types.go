@@ -22,6 +22,33 @@ type DeploymentParams struct {
 	Env              map[string]interface{}
 	ImagePullPolicy  string
 	ImagePullSecrets []interface{}
+	// Requests and Limits set the resources of the single container,
+	// mapping cpu and memory to quantities like "250m" or "256Mi".
+	Requests map[string]interface{}
+	Limits   map[string]interface{}
+	// Containers replaces Image and the other single-container fields when
+	// the deployment runs more than one container, e.g. an app and a proxy.
+	Containers []ContainerSpec
+	CheckQuota bool
+	DryRun     bool
+	ShowDiff   bool
+}
+
+// ContainerSpec describes one container of a multi-container pod or
+// deployment.
+type ContainerSpec struct {
+	Name  string
+	Image string
+	// Ports are container ports like "8080" or "8125/UDP".
+	Ports           []string
+	Env             map[string]interface{}
+	Command         []interface{}
+	Args            []interface{}
+	ImagePullPolicy string
+	// Requests and Limits map resource names such as cpu and memory to
+	// quantities like "100m" or "128Mi".
+	Requests map[string]interface{}
+	Limits   map[string]interface{}
 }
 
 // PodParams holds all possible pod configuration parameters
@@ -42,6 +69,15 @@ type PodParams struct {
 	ServiceAccountName string
 	Volumes            []interface{}
 	VolumeMounts       []interface{}
+	// Requests and Limits set the resources of the single container,
+	// mapping cpu and memory to quantities like "250m" or "256Mi".
+	Requests map[string]interface{}
+	Limits   map[string]interface{}
+	// Containers replaces Image and the other single-container fields when
+	// the pod runs more than one container.
+	Containers            []ContainerSpec
+	ActiveDeadlineSeconds *int64
+	DryRun                bool
 }
 
 // ServiceParams holds all possible service configuration parameters
@@ -56,6 +92,10 @@ type ServiceParams struct {
 	ExternalIPs     []string
 	ExternalName    string
 	SessionAffinity string
+	DryRun          bool
+	ShowDiff        bool
+	// IncludeEndpoints adds the service's EndpointSlice backends to Get.
+	IncludeEndpoints bool
 }
 
 // ServicePort represents a service port configuration
@@ -72,6 +112,8 @@ type NamespaceParams struct {
 	Name        string
 	Labels      map[string]interface{}
 	Annotations map[string]interface{}
+	DryRun      bool
+	ShowDiff    bool
 }
 
 // ConfigMapParams holds all possible configmap configuration parameters
@@ -82,6 +124,9 @@ type ConfigMapParams struct {
 	BinaryData  map[string]interface{}
 	Labels      map[string]interface{}
 	Annotations map[string]interface{}
+	Encoding    string // how Get renders binary values: base64 (default), hex or utf8
+	DryRun      bool
+	ShowDiff    bool
 }
 
 // SecretParams holds all possible secret configuration parameters
@@ -93,6 +138,8 @@ type SecretParams struct {
 	StringData  map[string]interface{}
 	Labels      map[string]interface{}
 	Annotations map[string]interface{}
+	DryRun      bool
+	ShowDiff    bool
 }
 
 // JobParams holds all possible job configuration parameters
@@ -110,6 +157,12 @@ type JobParams struct {
 	Env              map[string]interface{}
 	ImagePullPolicy  string
 	ImagePullSecrets []interface{}
+	// FailOnExitCodes and IgnoreDisruptions build the Job's pod failure
+	// policy; either one requires the Never restart policy.
+	FailOnExitCodes   []int32
+	IgnoreDisruptions bool
+	DryRun            bool
+	ShowDiff          bool
 }
 
 // CronJobParams holds all possible cronjob configuration parameters
@@ -131,6 +184,57 @@ type CronJobParams struct {
 	Env                        map[string]interface{}
 	ImagePullPolicy            string
 	ImagePullSecrets           []interface{}
+	DryRun                     bool
+	ShowDiff                   bool
+}
+
+// HPAParams holds all possible HorizontalPodAutoscaler configuration parameters
+type HPAParams struct {
+	Name              string
+	Namespace         string
+	TargetKind        string
+	TargetName        string
+	TargetAPIVersion  string
+	MinReplicas       *int32
+	MaxReplicas       int32
+	CPUUtilization    *int32
+	MemoryUtilization *int32
+	CustomMetrics     []interface{}
+	Labels            map[string]interface{}
+	DryRun            bool
+}
+
+// ServiceAccountParams holds all possible ServiceAccount configuration parameters
+type ServiceAccountParams struct {
+	Name             string
+	Namespace        string
+	ImagePullSecrets []interface{}
+	AutomountToken   *bool
+	Labels           map[string]interface{}
+	DryRun           bool
+}
+
+// RoleParams holds all possible Role and ClusterRole configuration
+// parameters. Each rule is an object with apiGroups, resources, verbs and
+// optionally resourceNames, or nonResourceURLs for ClusterRoles.
+type RoleParams struct {
+	Name      string
+	Namespace string
+	Rules     []interface{}
+	Labels    map[string]interface{}
+	DryRun    bool
+}
+
+// RoleBindingParams holds all possible RoleBinding and ClusterRoleBinding
+// configuration parameters. RoleRef is an object with kind and name; each
+// subject is an object with kind, name and, for ServiceAccounts, namespace.
+type RoleBindingParams struct {
+	Name      string
+	Namespace string
+	RoleRef   map[string]interface{}
+	Subjects  []interface{}
+	Labels    map[string]interface{}
+	DryRun    bool
 }
 
 // IngressParams holds all possible ingress configuration parameters
@@ -143,6 +247,8 @@ type IngressParams struct {
 	Rules            []IngressRule
 	TLS              []IngressTLS
 	DefaultBackend   *IngressBackend
+	DryRun           bool
+	ShowDiff         bool
 }
 
 // IngressRule represents an ingress rule configuration
@@ -181,4 +287,34 @@ type PVCParams struct {
 	VolumeMode       string // Filesystem or Block
 	Labels           map[string]interface{}
 	Annotations      map[string]interface{}
+	DryRun           bool
+}
+
+// StatefulSetParams holds all possible StatefulSet configuration parameters
+type StatefulSetParams struct {
+	Name                 string
+	Namespace            string
+	Image                string
+	Replicas             *int32
+	ServiceName          string // governing headless service
+	ContainerPort        string
+	Labels               map[string]interface{}
+	Env                  map[string]interface{}
+	VolumeClaimTemplates []VolumeClaimTemplate
+	UpdateStrategy       string // RollingUpdate or OnDelete
+	Partition            *int32
+	ImagePullPolicy      string
+	ImagePullSecrets     []interface{}
+	DryRun               bool
+	ShowDiff             bool
+}
+
+// VolumeClaimTemplate describes a per-replica PersistentVolumeClaim and
+// where it is mounted in the StatefulSet's container
+type VolumeClaimTemplate struct {
+	Name             string
+	MountPath        string
+	Storage          string // requested storage, e.g. "1Gi"
+	StorageClassName string
+	AccessModes      []string
 }